@@ -0,0 +1,80 @@
+package actions
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/backup"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// maxBackupUploadSize is the largest archive accepted on the upload flow, bigger ones must be restored with the CLI
+const maxBackupUploadSize = 100 * 1024 * 1024
+
+// RestoreBackup is used to restore a backup.zip into a new site
+type RestoreBackup struct {
+	FileName   string `json:"fileName"`
+	Content    []byte `json:"content"`
+	Subdomain  string `json:"subdomain" format:"lower"`
+	DryRun     bool   `json:"dryRun"`
+	Passphrase string `json:"passphrase"`
+
+	Archive *zip.Reader
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RestoreBackup) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionImportData)
+}
+
+// Validate if current model is valid
+func (action *RestoreBackup) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(action.Content) == 0 {
+		result.AddFieldFailure("content", "Please select a backup file.")
+	} else if len(action.Content) > maxBackupUploadSize {
+		result.AddFieldFailure("content", "Backup is larger than 100 MB, please restore it with the 'fider restore' command.")
+	} else if backup.IsEncrypted(action.Content) && action.Passphrase == "" {
+		result.AddFieldFailure("passphrase", "Backup is encrypted, please enter its passphrase.")
+	} else {
+		content := action.Content
+		if backup.IsEncrypted(content) {
+			decrypted, err := decryptBackup(content, action.Passphrase)
+			if err == backup.ErrInvalidPassphrase {
+				result.AddFieldFailure("passphrase", "Passphrase is invalid or the backup is corrupted.")
+			} else if err != nil {
+				return validate.Error(err)
+			}
+			content = decrypted
+		}
+
+		if result.Ok {
+			archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			if err != nil {
+				result.AddFieldFailure("content", "Backup is not a valid ZIP file.")
+			}
+			action.Archive = archive
+		}
+	}
+
+	messages, err := backup.ValidateTarget(ctx, action.Subdomain, action.DryRun)
+	if err != nil {
+		return validate.Error(err)
+	}
+	result.AddFieldFailure("subdomain", messages...)
+
+	return result
+}
+
+func decryptBackup(content []byte, passphrase string) ([]byte, error) {
+	reader, err := backup.NewDecryptReader(bytes.NewReader(content), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,130 @@
+package actions_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/backup"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func emptyZip() []byte {
+	buffer := new(bytes.Buffer)
+	_ = zip.NewWriter(buffer).Close()
+	return buffer.Bytes()
+}
+
+func TestRestoreBackup_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		nil,
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := &actions.RestoreBackup{}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+
+	action := &actions.RestoreBackup{}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})).IsTrue()
+}
+
+func TestRestoreBackup_InvalidContent(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	action := &actions.RestoreBackup{Subdomain: "restored"}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "content")
+
+	action = &actions.RestoreBackup{Subdomain: "restored", Content: []byte("not a zip")}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "content")
+}
+
+func TestRestoreBackup_InvalidSubdomain(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = q.Subdomain != "taken"
+		return nil
+	})
+
+	for _, subdomain := range []string{"", "ab", "taken", "admin"} {
+		action := &actions.RestoreBackup{Subdomain: subdomain, Content: emptyZip()}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "subdomain")
+	}
+}
+
+func TestRestoreBackup_Valid(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	action := &actions.RestoreBackup{Subdomain: "restored", Content: emptyZip()}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Archive).IsNotNil()
+}
+
+func TestRestoreBackup_Encrypted(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	encrypted := new(bytes.Buffer)
+	writer, err := backup.NewEncryptWriter(encrypted, "correct horse")
+	Expect(err).IsNil()
+	_, _ = writer.Write(emptyZip())
+	Expect(writer.Close()).IsNil()
+
+	action := &actions.RestoreBackup{Subdomain: "restored", Content: encrypted.Bytes()}
+	ExpectFailed(action.Validate(context.Background(), nil), "passphrase")
+
+	action = &actions.RestoreBackup{Subdomain: "restored", Content: encrypted.Bytes(), Passphrase: "wrong"}
+	ExpectFailed(action.Validate(context.Background(), nil), "passphrase")
+
+	action = &actions.RestoreBackup{Subdomain: "restored", Content: encrypted.Bytes(), Passphrase: "correct horse"}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Archive).IsNotNil()
+}
+
+func TestRestoreBackup_SingleHostMode(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "single"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetFirstTenant) error {
+		q.Result = &entity.Tenant{ID: 1}
+		return nil
+	})
+
+	action := &actions.RestoreBackup{Content: emptyZip(), DryRun: true}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+
+	action = &actions.RestoreBackup{Content: emptyZip()}
+	ExpectFailed(action.Validate(context.Background(), nil), "subdomain")
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/export"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// ExportData is used to export the posts, comments or votes that match a filter
+type ExportData struct {
+	Type    export.Type `route:"type"`
+	Format  export.Format
+	Columns []string
+	Filter  query.ExportFilter
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ExportData) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionExportData)
+}
+
+// Validate if current model is valid
+func (action *ExportData) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if !action.Type.IsValid() {
+		result.AddFieldFailure("type", "Only posts, comments and votes can be exported.")
+		return result
+	}
+
+	if action.Format == "" {
+		action.Format = export.FormatCSV
+	}
+	if !action.Format.IsValid() {
+		result.AddFieldFailure("format", "Format must be csv, json or ndjson.")
+	}
+
+	available := export.Columns(action.Type, CanExportPersonalData(user))
+	for _, name := range action.Columns {
+		if !slices.Contains(available, name) {
+			result.AddFieldFailure("columns", fmt.Sprintf("Column '%s' cannot be exported.", name))
+		}
+	}
+
+	return result
+}
+
+// Options returns the options of the export on behalf of given user
+func (action *ExportData) Options(user *entity.User) export.Options {
+	return export.Options{
+		Type:    action.Type,
+		Format:  action.Format,
+		Columns: action.Columns,
+		Filter:  action.Filter,
+		Private: CanExportPersonalData(user),
+	}
+}
+
+// CanExportPersonalData returns true if given user can export columns with personal data, such as emails.
+// That requires being able to export data and to view the members of the site
+func CanExportPersonalData(user *entity.User) bool {
+	return user.HasPermission(enum.PermissionExportData) && user.HasPermission(enum.PermissionViewUsers)
+}
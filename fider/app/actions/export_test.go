@@ -0,0 +1,64 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/export"
+)
+
+func TestExportData_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		nil,
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := &actions.ExportData{}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+
+	action := &actions.ExportData{}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})).IsTrue()
+}
+
+func TestExportData_Invalid(t *testing.T) {
+	RegisterT(t)
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+
+	action := &actions.ExportData{Type: "users"}
+	ExpectFailed(action.Validate(context.Background(), collaborator), "type")
+
+	action = &actions.ExportData{Type: export.TypePosts, Format: "xml"}
+	ExpectFailed(action.Validate(context.Background(), collaborator), "format")
+
+	action = &actions.ExportData{Type: export.TypePosts, Columns: []string{"title", "unknown"}}
+	ExpectFailed(action.Validate(context.Background(), collaborator), "columns")
+
+	action = &actions.ExportData{Type: export.TypePosts, Columns: []string{"voter_emails"}}
+	ExpectFailed(action.Validate(context.Background(), collaborator), "columns")
+
+	exporter := &entity.User{ID: 2, Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionExportData},
+	}}
+	action = &actions.ExportData{Type: export.TypePosts, Columns: []string{"voter_emails"}}
+	ExpectFailed(action.Validate(context.Background(), exporter), "columns")
+}
+
+func TestExportData_Valid(t *testing.T) {
+	RegisterT(t)
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+
+	action := &actions.ExportData{Type: export.TypePosts, Columns: []string{"title", "voter_emails"}}
+	ExpectSuccess(action.Validate(context.Background(), admin))
+	Expect(action.Format).Equals(export.FormatCSV)
+
+	opts := action.Options(admin)
+	Expect(opts.Private).IsTrue()
+	Expect(opts.FileName()).Equals("posts.csv")
+}
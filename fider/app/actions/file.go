@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// MaxFileKilobytes is the max size of each attached file
+const MaxFileKilobytes = 10240
+
+// UploadFile is used to attach a non-image file to a post or one of its comments
+type UploadFile struct {
+	Number    int             `route:"number"`
+	CommentID int             `json:"commentId"`
+	File      *dto.FileUpload `json:"file"`
+
+	Post    *entity.Post
+	Comment *entity.Comment
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UploadFile) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil {
+		return false
+	}
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return false
+	}
+	action.Post = getPost.Result
+
+	if action.CommentID == 0 {
+		return user.ID == action.Post.User.ID || user.HasPermission(enum.PermissionEditPosts)
+	}
+
+	getComments := &query.GetCommentsByPost{Post: action.Post}
+	if err := bus.Dispatch(ctx, getComments); err != nil {
+		return false
+	}
+	for _, comment := range getComments.Result {
+		if comment.ID == action.CommentID {
+			action.Comment = comment
+		}
+	}
+
+	return action.Comment != nil && (user.ID == action.Comment.User.ID || user.HasPermission(enum.PermissionEditComments))
+}
+
+// Validate if current model is valid
+func (action *UploadFile) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	getUsage := &query.GetFilesUsage{}
+	if err := bus.Dispatch(ctx, getUsage); err != nil {
+		return validate.Error(err)
+	}
+
+	messages := validate.FileUpload(ctx, action.File, validate.FileUploadOpts{
+		AllowedTypes:   tenant.AllowedFileTypes,
+		MaxKilobytes:   MaxFileKilobytes,
+		AvailableBytes: int64(tenant.FileQuotaMB)*1024*1024 - getUsage.Result,
+	})
+	result.AddFieldFailure("file", messages...)
+
+	return result
+}
+
+// DeleteFile is used to remove a file from its post or comment
+type DeleteFile struct {
+	Number int `route:"number"`
+	FileID int `route:"id"`
+
+	File *entity.File
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteFile) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil {
+		return false
+	}
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	getFile := &query.GetFileByID{FileID: action.FileID}
+	if err := bus.Dispatch(ctx, getPost, getFile); err != nil {
+		return false
+	}
+	action.File = getFile.Result
+
+	if action.File.PostID != getPost.Result.ID {
+		return false
+	}
+
+	return user.ID == action.File.UserID ||
+		user.HasPermission(enum.PermissionModerateContent) ||
+		(action.File.CommentID == 0 && user.HasPermission(enum.PermissionEditPosts)) ||
+		(action.File.CommentID > 0 && user.HasPermission(enum.PermissionEditComments))
+}
+
+// Validate if current model is valid
+func (action *DeleteFile) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return validate.Success()
+}
@@ -0,0 +1,104 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestUploadFile_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	author := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	commenter := &entity.User{ID: 2, Role: enum.RoleVisitor}
+	collaborator := &entity.User{ID: 3, Role: enum.RoleCollaborator}
+	post := &entity.Post{ID: 10, Number: 1, User: author}
+	comment := &entity.Comment{ID: 20, User: commenter}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		if q.Number == post.Number {
+			q.Result = post
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPost) error {
+		q.Result = []*entity.Comment{comment}
+		return nil
+	})
+
+	action := &actions.UploadFile{Number: 1}
+	Expect(action.IsAuthorized(context.Background(), author)).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), commenter)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), collaborator)).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+
+	action = &actions.UploadFile{Number: 1, CommentID: 20}
+	Expect(action.IsAuthorized(context.Background(), author)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), commenter)).IsTrue()
+	Expect(action.Comment).Equals(comment)
+
+	action = &actions.UploadFile{Number: 1, CommentID: 21}
+	Expect(action.IsAuthorized(context.Background(), commenter)).IsFalse()
+
+	action = &actions.UploadFile{Number: 2}
+	Expect(action.IsAuthorized(context.Background(), author)).IsFalse()
+}
+
+func TestUploadFile_Quota(t *testing.T) {
+	RegisterT(t)
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		AllowedFileTypes: ".log",
+		FileQuotaMB:      1,
+	})
+
+	usage := int64(0)
+	bus.AddHandler(func(ctx context.Context, q *query.GetFilesUsage) error {
+		q.Result = usage
+		return nil
+	})
+
+	action := &actions.UploadFile{File: &dto.FileUpload{FileName: "app.log", Content: []byte("ERROR failed")}}
+	result := action.Validate(ctx, nil)
+	ExpectSuccess(result)
+
+	usage = 1024*1024 - 5
+	result = action.Validate(ctx, nil)
+	ExpectFailed(result, "file")
+
+	action = &actions.UploadFile{File: &dto.FileUpload{FileName: "app.pdf", Content: []byte("ERROR failed")}}
+	usage = 0
+	result = action.Validate(ctx, nil)
+	ExpectFailed(result, "file")
+}
+
+func TestDeleteFile_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	uploader := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	other := &entity.User{ID: 2, Role: enum.RoleVisitor}
+	collaborator := &entity.User{ID: 3, Role: enum.RoleCollaborator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetFileByID) error {
+		q.Result = &entity.File{ID: q.FileID, PostID: 10, UserID: uploader.ID}
+		return nil
+	})
+
+	action := &actions.DeleteFile{Number: 1, FileID: 5}
+	Expect(action.IsAuthorized(context.Background(), uploader)).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), other)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), collaborator)).IsTrue()
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/importer"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// maxImportFileSize is the largest file accepted by an import
+const maxImportFileSize = 50 * 1024 * 1024
+
+// StartImport is used to import posts, comments and votes exported from another feedback tool
+type StartImport struct {
+	Source   enum.ImportSource `json:"source"`
+	FileName string            `json:"fileName"`
+	Content  []byte            `json:"content"`
+	Mapping  importer.Mapping  `json:"mapping"`
+
+	Parsed *importer.Result
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *StartImport) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionImportData)
+}
+
+// Validate if current model is valid
+func (action *StartImport) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if !action.Source.IsValid() {
+		result.AddFieldFailure("source", "Please select where the file was exported from.")
+	}
+	for field := range action.Mapping {
+		if !field.IsValid() {
+			result.AddFieldFailure("mapping", fmt.Sprintf("Field '%s' is unknown.", field))
+		}
+	}
+
+	if len(action.Content) == 0 {
+		result.AddFieldFailure("content", "Please select a file to import.")
+	} else if len(action.Content) > maxImportFileSize {
+		result.AddFieldFailure("content", "File is larger than 50 MB, please split it into smaller files.")
+	}
+
+	if !result.Ok {
+		return result
+	}
+
+	parsed, err := importer.Parse(action.Source, action.Content, action.Mapping)
+	if fileErr, ok := errors.Cause(err).(*importer.FileError); ok {
+		result.AddFieldFailure("content", fileErr.Error())
+		return result
+	} else if err != nil {
+		return validate.Error(err)
+	}
+
+	if parsed.Rows() == 0 {
+		result.AddFieldFailure("content", "File has no rows to import.")
+	}
+	action.Parsed = parsed
+
+	return result
+}
@@ -0,0 +1,62 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/importer"
+)
+
+func TestStartImport_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		nil,
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := &actions.StartImport{}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+
+	action := &actions.StartImport{}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})).IsTrue()
+}
+
+func TestStartImport_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.StartImport{}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "source", "content")
+
+	action = &actions.StartImport{Source: enum.ImportSourceCanny, Content: []byte("not json")}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "content")
+
+	action = &actions.StartImport{Source: enum.ImportSourceCSV, Content: []byte("title\n"), Mapping: importer.Mapping{importer.FieldTitle: "title"}}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "content")
+
+	action = &actions.StartImport{Source: enum.ImportSourceCSV, Content: []byte("title\nA\n"), Mapping: importer.Mapping{"owner": "title"}}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "mapping")
+}
+
+func TestStartImport_Valid(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.StartImport{
+		Source:  enum.ImportSourceCSV,
+		Content: []byte("Title,State\nDark mode,planned\n,open\n"),
+		Mapping: importer.Mapping{importer.FieldTitle: "Title", importer.FieldStatus: "State"},
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Parsed.Rows()).Equals(2)
+	Expect(action.Parsed.Records).HasLen(1)
+}
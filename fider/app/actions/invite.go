@@ -27,7 +27,7 @@ type InviteUsers struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *InviteUsers) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsCollaborator()
+	return user != nil && user.HasPermission(enum.PermissionSendInvitations)
 }
 
 // Validate if current model is valid
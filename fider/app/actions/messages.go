@@ -24,3 +24,8 @@ func propertyMaxStringLen(ctx context.Context, fieldName string, maxLen int) str
 		i18n.Params{"len": maxLen},
 	)
 }
+
+func propertyHasBlockedWords(ctx context.Context, fieldName string) string {
+	displayName := i18n.T(ctx, fmt.Sprintf("property.%s", fieldName))
+	return i18n.T(ctx, "validation.blockedwords", i18n.Params{"name": displayName})
+}
@@ -43,7 +43,7 @@ func NewCreateEditOAuthConfig() *CreateEditOAuthConfig {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *CreateEditOAuthConfig) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionManageAuthentication)
 }
 
 // SetSystemProviderStatus is used to enable/disable built-in OAuth providers
@@ -54,7 +54,7 @@ type SetSystemProviderStatus struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *SetSystemProviderStatus) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionManageAuthentication)
 }
 
 // Validate if current model is valid
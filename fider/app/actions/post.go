@@ -27,20 +27,32 @@ type CreateNewPost struct {
 	TagSlugs    []string           `json:"tags"`
 	Attachments []*dto.ImageUpload `json:"attachments"`
 
-	Tags []*entity.Tag
+	Tags      []*entity.Tag
+	TagGroups map[int]*entity.TagGroup
+	HeldBy    []*entity.WordFilter
 }
 
-// OnPreExecute prefetches Tags for later use
+// OnPreExecute prefetches Tags and their groups for later use
 func (input *CreateNewPost) OnPreExecute(ctx context.Context) error {
 	if env.Config.PostCreationWithTagsEnabled {
 		input.Tags = make([]*entity.Tag, 0, len(input.TagSlugs))
+		input.TagGroups = make(map[int]*entity.TagGroup)
 		for _, slug := range input.TagSlugs {
 			getTag := &query.GetTagBySlug{Slug: slug}
 			if err := bus.Dispatch(ctx, getTag); err != nil {
 				break
 			}
 
-			input.Tags = append(input.Tags, getTag.Result)
+			tag := getTag.Result
+			if tag.GroupID > 0 && input.TagGroups[tag.GroupID] == nil {
+				getGroup := &query.GetTagGroupByID{GroupID: tag.GroupID}
+				if err := bus.Dispatch(ctx, getGroup); err != nil {
+					return err
+				}
+				input.TagGroups[tag.GroupID] = getGroup.Result
+			}
+
+			input.Tags = append(input.Tags, tag)
 		}
 	}
 
@@ -52,9 +64,12 @@ func (action *CreateNewPost) IsAuthorized(ctx context.Context, user *entity.User
 
 	if user == nil {
 		return false
-	} else if env.Config.PostCreationWithTagsEnabled && !user.IsCollaborator() {
+	} else if env.Config.PostCreationWithTagsEnabled {
 		for _, tag := range action.Tags {
-			if !tag.IsPublic {
+			if tag.GroupID > 0 && !action.TagGroups[tag.GroupID].IsAssignableBy(user) {
+				return false
+			}
+			if !tag.IsPublic && !user.HasPermission(enum.PermissionAssignTags) {
 				return false
 			}
 		}
@@ -77,6 +92,8 @@ func (action *CreateNewPost) Validate(ctx context.Context, user *entity.User) *v
 		result.AddFieldFailure("title", propertyMaxStringLen(ctx, "title", 100))
 	} else if env.Config.PostCreationWithTagsEnabled && len(action.TagSlugs) != len(action.Tags) {
 		result.AddFieldFailure("tags", propertyIsInvalid(ctx, "tags"))
+	} else if env.Config.PostCreationWithTagsEnabled && hasMultipleTagsOfSingleGroup(action.Tags, action.TagGroups) {
+		result.AddFieldFailure("tags", i18n.T(ctx, "validation.custom.singletagpergroup"))
 	} else {
 		err := bus.Dispatch(ctx, &query.GetPostBySlug{Slug: slug.Make(action.Title)})
 		if err != nil && errors.Cause(err) != app.ErrNotFound {
@@ -96,9 +113,32 @@ func (action *CreateNewPost) Validate(ctx context.Context, user *entity.User) *v
 	}
 	result.AddFieldFailure("attachments", messages...)
 
+	action.HeldBy, err = applyWordFilters(ctx, user, result, true,
+		filteredField{"title", "title", &action.Title},
+		filteredField{"description", "description", &action.Description},
+	)
+	if err != nil {
+		return validate.Error(err)
+	}
+
 	return result
 }
 
+func hasMultipleTagsOfSingleGroup(tags []*entity.Tag, groups map[int]*entity.TagGroup) bool {
+	seen := make(map[int]bool)
+	for _, tag := range tags {
+		group := groups[tag.GroupID]
+		if group == nil || !group.IsSingleSelection() {
+			continue
+		}
+		if seen[group.ID] {
+			return true
+		}
+		seen[group.ID] = true
+	}
+	return false
+}
+
 // UpdatePost is used to edit an existing new post
 type UpdatePost struct {
 	Number      int                `route:"number"`
@@ -106,7 +146,8 @@ type UpdatePost struct {
 	Description string             `json:"description"`
 	Attachments []*dto.ImageUpload `json:"attachments"`
 
-	Post *entity.Post
+	Post   *entity.Post
+	HeldBy []*entity.WordFilter
 }
 
 // OnPreExecute prefetches Post for later use
@@ -122,7 +163,7 @@ func (input *UpdatePost) OnPreExecute(ctx context.Context) error {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (input *UpdatePost) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	if user.IsCollaborator() {
+	if user.HasPermission(enum.PermissionEditPosts) {
 		return true
 	}
 
@@ -168,6 +209,14 @@ func (action *UpdatePost) Validate(ctx context.Context, user *entity.User) *vali
 		result.AddFieldFailure("attachments", messages...)
 	}
 
+	action.HeldBy, err = applyWordFilters(ctx, user, result, true,
+		filteredField{"title", "title", &action.Title},
+		filteredField{"description", "description", &action.Description},
+	)
+	if err != nil {
+		return validate.Error(err)
+	}
+
 	return result
 }
 
@@ -184,30 +233,83 @@ func (action *ToggleCommentReaction) IsAuthorized(ctx context.Context, user *ent
 
 // Validate if current model is valid
 func (action *ToggleCommentReaction) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return validateReaction(ctx, user, action.Number, action.Comment, action.Reaction)
+}
 
+// TogglePostReaction adds or removes a reaction of current user on a post
+type TogglePostReaction struct {
+	Number   int    `route:"number"`
+	Reaction string `route:"reaction"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *TogglePostReaction) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *TogglePostReaction) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return validateReaction(ctx, user, action.Number, 0, action.Reaction)
+}
+
+// validateReaction checks that emoji is on the reaction palette of current tenant.
+// Reactions that are no longer on the palette can still be toggled off by the users who added them
+func validateReaction(ctx context.Context, user *entity.User, number, commentID int, emoji string) *validate.Result {
 	result := validate.Success()
 
-	allowedEmojis := []string{"👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"}
-	isAllowed := false
-	for _, emoji := range allowedEmojis {
-		if action.Reaction == emoji {
-			isAllowed = true
-			break
+	palette := strings.Fields(entity.DefaultReactionEmojis)
+	if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok && tenant != nil {
+		palette = tenant.ReactionPalette()
+	}
+
+	for _, allowed := range palette {
+		if emoji == allowed {
+			return result
 		}
 	}
 
-	if !isAllowed {
-		result.AddFieldFailure("reaction", i18n.T(ctx, "validation.custom.invalidemoji"))
+	hasReacted, err := hasReactedWith(ctx, user, number, commentID, emoji)
+	if err != nil {
+		return validate.Error(err)
+	}
+	if hasReacted {
+		return result
 	}
 
+	result.AddFieldFailure("reaction", i18n.T(ctx, "validation.custom.invalidemoji"))
 	return result
 }
 
+func hasReactedWith(ctx context.Context, user *entity.User, number, commentID int, emoji string) (bool, error) {
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	listReactions := &query.ListReactions{PostID: getPost.Result.ID, CommentID: commentID}
+	if err := bus.Dispatch(ctx, listReactions); err != nil {
+		return false, err
+	}
+
+	for _, reaction := range listReactions.Result {
+		if reaction.Emoji == emoji && reaction.User != nil && reaction.User.ID == user.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // AddNewComment represents a new comment to be added
 type AddNewComment struct {
 	Number      int                `route:"number"`
 	Content     string             `json:"content"`
+	ParentID    int                `json:"parentId"`
 	Attachments []*dto.ImageUpload `json:"attachments"`
+
+	HeldBy []*entity.WordFilter
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -233,9 +335,60 @@ func (action *AddNewComment) Validate(ctx context.Context, user *entity.User) *v
 	}
 	result.AddFieldFailure("attachments", messages...)
 
+	if action.ParentID != 0 {
+		if err := action.validateParent(ctx, result); err != nil {
+			return validate.Error(err)
+		}
+	}
+
+	action.HeldBy, err = applyWordFilters(ctx, user, result, true, filteredField{"content", "comment", &action.Content})
+	if err != nil {
+		return validate.Error(err)
+	}
+
 	return result
 }
 
+// validateParent checks that the parent comment can be replied to. Replies that'd be deeper
+// than COMMENT_REPLY_DEPTH are moved to the deepest ancestor that still allows replies
+func (action *AddNewComment) validateParent(ctx context.Context, result *validate.Result) error {
+	if env.Config.Comments.ReplyDepth <= 0 {
+		result.AddFieldFailure("parentId", i18n.T(ctx, "validation.custom.repliesdisabled"))
+		return nil
+	}
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return err
+	}
+
+	getComments := &query.GetCommentsByPost{Post: getPost.Result, IncludeRemovedParents: true}
+	if err := bus.Dispatch(ctx, getComments); err != nil {
+		return err
+	}
+
+	byID := make(map[int]*entity.Comment, len(getComments.Result))
+	for _, comment := range getComments.Result {
+		byID[comment.ID] = comment
+	}
+
+	parent := byID[action.ParentID]
+	if parent == nil || parent.IsRemoved {
+		result.AddFieldFailure("parentId", i18n.T(ctx, "validation.custom.parentcommentnotfound"))
+		return nil
+	}
+
+	// ancestors goes from the parent up to the top level comment
+	ancestors := []*entity.Comment{parent}
+	for c := byID[parent.ParentID]; c != nil; c = byID[c.ParentID] {
+		ancestors = append(ancestors, c)
+	}
+	if len(ancestors) > env.Config.Comments.ReplyDepth {
+		action.ParentID = ancestors[len(ancestors)-env.Config.Comments.ReplyDepth].ID
+	}
+	return nil
+}
+
 // SetResponse represents the action to update an post response
 type SetResponse struct {
 	Number         int             `route:"number"`
@@ -248,7 +401,7 @@ type SetResponse struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *SetResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsCollaborator()
+	return user != nil && user.HasPermission(enum.PermissionRespondToPosts)
 }
 
 // Validate if current model is valid
@@ -292,7 +445,7 @@ type DeletePost struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *DeletePost) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionDeletePosts)
 }
 
 // Validate if current model is valid
@@ -325,6 +478,7 @@ type EditComment struct {
 
 	Post    *entity.Post
 	Comment *entity.Comment
+	HeldBy  []*entity.WordFilter
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -337,7 +491,7 @@ func (action *EditComment) IsAuthorized(ctx context.Context, user *entity.User)
 
 	action.Post = postByNumber.Result
 	action.Comment = commentByID.Result
-	return user.ID == action.Comment.User.ID || user.IsCollaborator()
+	return user.ID == action.Comment.User.ID || user.HasPermission(enum.PermissionEditComments)
 }
 
 // Validate if current model is valid
@@ -363,6 +517,12 @@ func (action *EditComment) Validate(ctx context.Context, user *entity.User) *val
 
 	}
 
+	heldBy, err := applyWordFilters(ctx, user, result, true, filteredField{"content", "comment", &action.Content})
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.HeldBy = heldBy
+
 	return result
 }
 
@@ -379,7 +539,7 @@ func (action *DeleteComment) IsAuthorized(ctx context.Context, user *entity.User
 		return false
 	}
 
-	return user.ID == commentByID.Result.User.ID || user.IsCollaborator()
+	return user.ID == commentByID.Result.User.ID || user.HasPermission(enum.PermissionEditComments)
 }
 
 // Validate if current model is valid
@@ -12,6 +12,7 @@ import (
 	"github.com/getfider/fider/app/actions"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 )
 
 func TestCreateNewPost_InvalidPostTitles(t *testing.T) {
@@ -133,3 +134,98 @@ func TestDeleteComment(t *testing.T) {
 	authorized = action.IsAuthorized(context.Background(), administrator)
 	Expect(authorized).IsTrue()
 }
+
+func TestAddNewComment_Replies(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Post 1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPost) error {
+		q.Result = []*entity.Comment{
+			{ID: 4, ParentID: 3},
+			{ID: 3, ParentID: 2},
+			{ID: 2, ParentID: 1},
+			{ID: 1, IsRemoved: true},
+		}
+		return nil
+	})
+
+	replyDepth := env.Config.Comments.ReplyDepth
+	defer func() { env.Config.Comments.ReplyDepth = replyDepth }()
+
+	env.Config.Comments.ReplyDepth = 1
+	action := &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 2}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.ParentID).Equals(1)
+
+	env.Config.Comments.ReplyDepth = 3
+	action = &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 3}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.ParentID).Equals(3)
+
+	action = &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 4}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.ParentID).Equals(3)
+
+	action = &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 1}
+	ExpectFailed(action.Validate(context.Background(), nil), "parentId")
+
+	action = &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 5}
+	ExpectFailed(action.Validate(context.Background(), nil), "parentId")
+
+	env.Config.Comments.ReplyDepth = 0
+	action = &actions.AddNewComment{Number: post.Number, Content: "Reply", ParentID: 2}
+	ExpectFailed(action.Validate(context.Background(), nil), "parentId")
+}
+
+func TestCreateNewPost_TagGroups(t *testing.T) {
+	RegisterT(t)
+	env.Config.PostCreationWithTagsEnabled = true
+
+	groups := map[int]*entity.TagGroup{
+		1: {ID: 1, Slug: "quarter", Selection: enum.TagGroupSelectionSingle, AssignableBy: []string{"visitor"}},
+		2: {ID: 2, Slug: "customer-tier", Selection: enum.TagGroupSelectionMultiple},
+	}
+	tags := map[string]*entity.Tag{
+		"q1":         {ID: 1, Slug: "q1", IsPublic: true, GroupID: 1},
+		"q2":         {ID: 2, Slug: "q2", IsPublic: true, GroupID: 1},
+		"enterprise": {ID: 3, Slug: "enterprise", IsPublic: true, GroupID: 2},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if tag, ok := tags[q.Slug]; ok {
+			q.Result = tag
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupByID) error {
+		q.Result = groups[q.GroupID]
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+
+	visitor := &entity.User{Role: enum.RoleVisitor}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+
+	action := &actions.CreateNewPost{Title: "this is my new post", TagSlugs: []string{"q1"}}
+	Expect(action.OnPreExecute(context.Background())).IsNil()
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsTrue()
+	ExpectSuccess(action.Validate(context.Background(), visitor))
+
+	action = &actions.CreateNewPost{Title: "this is my new post", TagSlugs: []string{"enterprise"}}
+	Expect(action.OnPreExecute(context.Background())).IsNil()
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), collaborator)).IsTrue()
+
+	action = &actions.CreateNewPost{Title: "this is my new post", TagSlugs: []string{"q1", "q2"}}
+	Expect(action.OnPreExecute(context.Background())).IsNil()
+	ExpectFailed(action.Validate(context.Background(), visitor), "tags")
+}
@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// ReportContent is used to report a post or a comment to the moderators
+type ReportContent struct {
+	Number    int               `route:"number"`
+	CommentID int               `route:"id"`
+	Reason    enum.ReportReason `json:"reason"`
+	Details   string            `json:"details"`
+
+	Post    *entity.Post
+	Comment *entity.Comment
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ReportContent) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil {
+		return false
+	}
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return false
+	}
+	action.Post = getPost.Result
+
+	if action.CommentID > 0 {
+		getComment := &query.GetCommentByID{CommentID: action.CommentID}
+		if err := bus.Dispatch(ctx, getComment); err != nil {
+			return false
+		}
+		action.Comment = getComment.Result
+		// the post is the one that's reported along with the comment, so they must match
+		if action.Comment.PostID != action.Post.ID {
+			return false
+		}
+		return action.Comment.User.ID != user.ID
+	}
+
+	return action.Post.User.ID != user.ID
+}
+
+// Validate if current model is valid
+func (action *ReportContent) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Reason.String() == "" {
+		result.AddFieldFailure("reason", propertyIsInvalid(ctx, "reason"))
+	}
+
+	action.Details = strings.TrimSpace(action.Details)
+	if len(action.Details) > 500 {
+		result.AddFieldFailure("details", propertyMaxStringLen(ctx, "details", 500))
+	}
+
+	hasReported := &query.HasReportedContent{PostID: action.Post.ID, CommentID: action.CommentID}
+	if err := bus.Dispatch(ctx, hasReported); err != nil {
+		return validate.Error(err)
+	}
+	if hasReported.Result {
+		result.AddFieldFailure("reason", i18n.T(ctx, "validation.custom.alreadyreported"))
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package actions_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func reportContext(alreadyReported bool) context.Context {
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number, User: &entity.User{ID: 1}}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		postID := 10
+		if q.CommentID == 6 {
+			postID = 11
+		}
+		q.Result = &entity.Comment{ID: q.CommentID, PostID: postID, User: &entity.User{ID: 2}}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.HasReportedContent) error {
+		q.Result = alreadyReported
+		return nil
+	})
+	return context.Background()
+}
+
+func TestReportContent_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+	ctx := reportContext(false)
+
+	postAuthor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	commentAuthor := &entity.User{ID: 2, Role: enum.RoleVisitor}
+
+	Expect((&actions.ReportContent{Number: 1}).IsAuthorized(ctx, nil)).IsFalse()
+	Expect((&actions.ReportContent{Number: 1}).IsAuthorized(ctx, postAuthor)).IsFalse()
+	Expect((&actions.ReportContent{Number: 1}).IsAuthorized(ctx, commentAuthor)).IsTrue()
+	Expect((&actions.ReportContent{Number: 1, CommentID: 5}).IsAuthorized(ctx, postAuthor)).IsTrue()
+	Expect((&actions.ReportContent{Number: 1, CommentID: 5}).IsAuthorized(ctx, commentAuthor)).IsFalse()
+
+	// comment of another post
+	Expect((&actions.ReportContent{Number: 1, CommentID: 6}).IsAuthorized(ctx, postAuthor)).IsFalse()
+}
+
+func TestReportContent_Validate(t *testing.T) {
+	RegisterT(t)
+	ctx := reportContext(false)
+	user := &entity.User{ID: 3, Role: enum.RoleVisitor}
+
+	action := &actions.ReportContent{Number: 1, Reason: enum.ReportSpam, Details: "  Selling watches  "}
+	Expect(action.IsAuthorized(ctx, user)).IsTrue()
+	ExpectSuccess(action.Validate(ctx, user))
+	Expect(action.Details).Equals("Selling watches")
+
+	action = &actions.ReportContent{Number: 1}
+	Expect(action.IsAuthorized(ctx, user)).IsTrue()
+	ExpectFailed(action.Validate(ctx, user), "reason")
+
+	action = &actions.ReportContent{Number: 1, Reason: enum.ReportOther, Details: strings.Repeat("a", 501)}
+	Expect(action.IsAuthorized(ctx, user)).IsTrue()
+	ExpectFailed(action.Validate(ctx, user), "details")
+}
+
+func TestReportContent_AlreadyReported(t *testing.T) {
+	RegisterT(t)
+	ctx := reportContext(true)
+	user := &entity.User{ID: 3, Role: enum.RoleVisitor}
+
+	action := &actions.ReportContent{Number: 1, CommentID: 5, Reason: enum.ReportHarassment}
+	Expect(action.IsAuthorized(ctx, user)).IsTrue()
+	ExpectFailed(action.Validate(ctx, user), "reason")
+}
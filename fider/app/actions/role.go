@@ -0,0 +1,167 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateEditRole is used to create a new custom role or edit existing
+type CreateEditRole struct {
+	ID          int               `route:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+
+	Role *entity.Role
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageRoles)
+}
+
+// Validate if current model is valid
+func (action *CreateEditRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getRole := &query.GetRoleByID{RoleID: action.ID}
+		if err := bus.Dispatch(ctx, getRole); err != nil {
+			return validate.Error(err)
+		}
+		action.Role = getRole.Result
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 100 {
+		result.AddFieldFailure("name", "Name must have less than 100 characters.")
+	} else if isBuiltInRoleName(action.Name) {
+		result.AddFieldFailure("name", "This name is reserved for a built-in role.")
+	} else {
+		getDuplicate := &query.GetRoleByName{Name: action.Name}
+		err := bus.Dispatch(ctx, getDuplicate)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err == nil && (action.Role == nil || action.Role.ID != getDuplicate.Result.ID) {
+			result.AddFieldFailure("name", "This role name is already in use.")
+		}
+	}
+
+	if len(action.Description) > 500 {
+		result.AddFieldFailure("description", "Description must have less than 500 characters.")
+	}
+
+	seen := make(map[enum.Permission]bool, len(action.Permissions))
+	permissions := make([]enum.Permission, 0, len(action.Permissions))
+	for _, permission := range action.Permissions {
+		if !permission.IsValid() {
+			result.AddFieldFailure("permissions", "Unknown permission '"+string(permission)+"'.")
+		} else if !user.HasPermission(permission) {
+			result.AddFieldFailure("permissions", "You cannot grant a permission you don't have: '"+string(permission)+"'.")
+		} else if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	action.Permissions = permissions
+
+	return result
+}
+
+// DeleteRole is used to delete an existing custom role
+type DeleteRole struct {
+	ID int `route:"id"`
+
+	Role *entity.Role
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageRoles)
+}
+
+// Validate if current model is valid
+func (action *DeleteRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getRole := &query.GetRoleByID{RoleID: action.ID}
+	if err := bus.Dispatch(ctx, getRole); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Role = getRole.Result
+	return validate.Success()
+}
+
+// AssignCustomRole is used to assign a custom role to a member or remove it when RoleID is zero
+type AssignCustomRole struct {
+	UserID int `route:"userID"`
+	RoleID int `json:"roleID"`
+
+	User *entity.User
+	Role *entity.Role
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AssignCustomRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageRoles) && user.ID != action.UserID
+}
+
+// Validate if current model is valid
+func (action *AssignCustomRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	userByID := &query.GetUserByID{UserID: action.UserID}
+	err := bus.Dispatch(ctx, userByID)
+	if err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return validate.Error(app.ErrNotFound)
+		}
+		return validate.Error(err)
+	}
+	if userByID.Result.Tenant.ID != user.Tenant.ID {
+		return validate.Error(app.ErrNotFound)
+	}
+	action.User = userByID.Result
+
+	if !user.HasPermissionsOf(action.User) {
+		result.AddFieldFailure("userID", "You cannot manage members with permissions you don't have.")
+		return result
+	}
+
+	if action.RoleID > 0 {
+		getRole := &query.GetRoleByID{RoleID: action.RoleID}
+		err := bus.Dispatch(ctx, getRole)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				result.AddFieldFailure("roleID", "Role not found.")
+				return result
+			}
+			return validate.Error(err)
+		}
+		action.Role = getRole.Result
+
+		if !user.HasPermissions(action.Role.Permissions) {
+			result.AddFieldFailure("roleID", "You cannot assign a role with permissions you don't have.")
+		}
+	}
+
+	return result
+}
+
+func isBuiltInRoleName(name string) bool {
+	name = strings.ToLower(name)
+	for _, role := range []enum.Role{enum.RoleVisitor, enum.RoleCollaborator, enum.RoleAdministrator} {
+		if name == role.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+func TestCreateEditRole_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		nil,
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := &actions.CreateEditRole{Name: "Moderator"}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+}
+
+func TestCreateEditRole_InvalidName(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByName) error {
+		if q.Name == "Moderator" {
+			q.Result = &entity.Role{ID: 1, Name: "Moderator"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	for _, name := range []string{
+		"",
+		"Moderator",
+		"Administrator",
+		"visitor",
+		rand.String(101),
+	} {
+		action := &actions.CreateEditRole{Name: name}
+		result := action.Validate(context.Background(), admin)
+		ExpectFailed(result, "name")
+	}
+}
+
+func TestCreateEditRole_InvalidPermissions(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByName) error {
+		return app.ErrNotFound
+	})
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.CreateEditRole{Name: "Support", Permissions: []enum.Permission{"posts.fly"}}
+	result := action.Validate(context.Background(), admin)
+	ExpectFailed(result, "permissions")
+
+	manager := &entity.User{ID: 2, Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageRoles},
+	}}
+	action = &actions.CreateEditRole{Name: "Support", Permissions: []enum.Permission{enum.PermissionManageSettings}}
+	result = action.Validate(context.Background(), manager)
+	ExpectFailed(result, "permissions")
+}
+
+func TestCreateEditRole_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByName) error {
+		return app.ErrNotFound
+	})
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.CreateEditRole{
+		Name:        "Support",
+		Permissions: []enum.Permission{enum.PermissionRespondToPosts, enum.PermissionAccessAdmin, enum.PermissionRespondToPosts},
+	}
+	result := action.Validate(context.Background(), admin)
+	Expect(result.Ok).IsTrue()
+	Expect(action.Permissions).Equals([]enum.Permission{enum.PermissionRespondToPosts, enum.PermissionAccessAdmin})
+}
+
+func TestAssignCustomRole_CurrentUser(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.AssignCustomRole{UserID: 1, RoleID: 2}
+	Expect(action.IsAuthorized(context.Background(), admin)).IsFalse()
+}
+
+func TestAssignCustomRole_RoleWithMorePermissions(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 3, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleVisitor}
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = targetUser
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		q.Result = &entity.Role{ID: q.RoleID, Name: "Site Owner", Permissions: enum.Permissions}
+		return nil
+	})
+
+	manager := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageRoles},
+	}}
+	action := &actions.AssignCustomRole{UserID: targetUser.ID, RoleID: 5}
+	Expect(action.IsAuthorized(context.Background(), manager)).IsTrue()
+	result := action.Validate(context.Background(), manager)
+	ExpectFailed(result, "roleID")
+
+	admin := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action = &actions.AssignCustomRole{UserID: targetUser.ID, RoleID: 5}
+	result = action.Validate(context.Background(), admin)
+	Expect(result.Ok).IsTrue()
+	Expect(action.Role.ID).Equals(5)
+	Expect(action.User).Equals(targetUser)
+}
+
+func TestAssignCustomRole_TargetWithMorePermissions(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 3, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleCollaborator}
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = targetUser
+		return nil
+	})
+
+	manager := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageRoles},
+	}}
+	action := &actions.AssignCustomRole{UserID: targetUser.ID}
+	Expect(action.IsAuthorized(context.Background(), manager)).IsTrue()
+	result := action.Validate(context.Background(), manager)
+	ExpectFailed(result, "userID")
+}
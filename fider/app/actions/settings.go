@@ -45,6 +45,11 @@ func (action *UpdateUserSettings) Validate(ctx context.Context, user *entity.Use
 		result.AddFieldFailure("name", propertyMaxStringLen(ctx, "name", 50))
 	}
 
+	// Display names can't be held for moderation, so moderate filters reject them
+	if _, err := applyWordFilters(ctx, user, result, false, filteredField{"name", "name", &action.Name}); err != nil {
+		return validate.Error(err)
+	}
+
 	action.Avatar.BlobKey = user.AvatarBlobKey
 	messages, err := validate.ImageUpload(ctx, action.Avatar, validate.ImageUploadOpts{
 		IsRequired:   action.AvatarType == enum.AvatarTypeCustom,
@@ -36,7 +36,7 @@ func (action *SignInByEmail) IsAuthorized(ctx context.Context, user *entity.User
 	if err := bus.Dispatch(ctx, getUser); err != nil {
 		return false
 	}
-	return getUser.Result != nil && getUser.Result.IsAdministrator()
+	return getUser.Result != nil && getUser.Result.HasPermission(enum.PermissionManageAuthentication)
 }
 
 // Validate if current model is valid
@@ -150,6 +150,10 @@ func (action *SignInByEmailWithName) Validate(ctx context.Context, user *entity.
 		result.AddFieldFailure("name", propertyMaxStringLen(ctx, "name", 100))
 	}
 
+	if _, err := applyWordFilters(ctx, user, result, false, filteredField{"name", "name", &action.Name}); err != nil {
+		return validate.Error(err)
+	}
+
 	return result
 }
 
@@ -199,5 +203,9 @@ func (action *CompleteProfile) Validate(ctx context.Context, user *entity.User)
 		result.AddFieldFailure("key", propertyIsRequired(ctx, "key"))
 	}
 
+	if _, err := applyWordFilters(ctx, user, result, false, filteredField{"name", "name", &action.Name}); err != nil {
+		return validate.Error(err)
+	}
+
 	return result
 }
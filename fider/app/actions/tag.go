@@ -3,8 +3,11 @@ package actions
 import (
 	"context"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 
 	"github.com/getfider/fider/app"
@@ -22,13 +25,14 @@ type CreateEditTag struct {
 	Name     string `json:"name"`
 	Color    string `json:"color" format:"upper"`
 	IsPublic bool   `json:"isPublic"`
+	GroupID  int    `json:"groupId"`
 
 	Tag *entity.Tag
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *CreateEditTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionManageTags)
 }
 
 // Validate if current model is valid
@@ -66,6 +70,15 @@ func (action *CreateEditTag) Validate(ctx context.Context, user *entity.User) *v
 		result.AddFieldFailure("color", "Color is invalid.")
 	}
 
+	if action.GroupID > 0 {
+		err := bus.Dispatch(ctx, &query.GetTagGroupByID{GroupID: action.GroupID})
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("groupId", "Tag group is invalid.")
+		}
+	}
+
 	return result
 }
 
@@ -78,7 +91,7 @@ type DeleteTag struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *DeleteTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionManageTags)
 }
 
 // Validate if current model is valid
@@ -104,7 +117,7 @@ type AssignUnassignTag struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *AssignUnassignTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsCollaborator()
+	return user != nil && user.HasPermission(enum.PermissionAssignTags)
 }
 
 // Validate if current model is valid
@@ -117,5 +130,133 @@ func (action *AssignUnassignTag) Validate(ctx context.Context, user *entity.User
 
 	action.Post = getPost.Result
 	action.Tag = getSlug.Result
+
+	if action.Tag.GroupID > 0 {
+		getGroup := &query.GetTagGroupByID{GroupID: action.Tag.GroupID}
+		if err := bus.Dispatch(ctx, getGroup); err != nil {
+			return validate.Error(err)
+		}
+
+		if !getGroup.Result.IsAssignableBy(user) {
+			return validate.Unauthorized()
+		}
+	}
+
+	return validate.Success()
+}
+
+// CreateEditTagGroup is used to create a new tag group or edit existing
+type CreateEditTagGroup struct {
+	Slug         string                 `route:"slug"`
+	Name         string                 `json:"name"`
+	Selection    enum.TagGroupSelection `json:"selection"`
+	VisibleTo    []string               `json:"visibleTo"`
+	AssignableBy []string               `json:"assignableBy"`
+
+	Group *entity.TagGroup
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditTagGroup) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageTags)
+}
+
+// Validate if current model is valid
+func (action *CreateEditTagGroup) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Slug != "" {
+		getGroup := &query.GetTagGroupBySlug{Slug: action.Slug}
+		if err := bus.Dispatch(ctx, getGroup); err != nil {
+			return validate.Error(err)
+		}
+		action.Group = getGroup.Result
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	} else {
+		getDuplicate := &query.GetTagGroupBySlug{Slug: slug.Make(action.Name)}
+		err := bus.Dispatch(ctx, getDuplicate)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err == nil && (action.Group == nil || action.Group.ID != getDuplicate.Result.ID) {
+			result.AddFieldFailure("name", "This tag group name is already in use.")
+		}
+	}
+
+	if action.Selection == "" {
+		action.Selection = enum.TagGroupSelectionMultiple
+	} else if !action.Selection.IsValid() {
+		result.AddFieldFailure("selection", "Selection must be either 'single' or 'multiple'.")
+	}
+
+	var err error
+	if action.VisibleTo, err = validateRoleKeys(ctx, result, "visibleTo", action.VisibleTo); err != nil {
+		return validate.Error(err)
+	}
+	if action.AssignableBy, err = validateRoleKeys(ctx, result, "assignableBy", action.AssignableBy); err != nil {
+		return validate.Error(err)
+	}
+
+	return result
+}
+
+// DeleteTagGroup is used to delete an existing tag group
+type DeleteTagGroup struct {
+	Slug string `route:"slug"`
+
+	Group *entity.TagGroup
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteTagGroup) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageTags)
+}
+
+// Validate if current model is valid
+func (action *DeleteTagGroup) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getGroup := &query.GetTagGroupBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getGroup); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Group = getGroup.Result
 	return validate.Success()
 }
+
+// validateRoleKeys checks that every key references a built-in role or an existing custom role
+// and returns the keys without duplicates
+func validateRoleKeys(ctx context.Context, result *validate.Result, field string, keys []string) ([]string, error) {
+	seen := make(map[string]bool, len(keys))
+	valid := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+
+		var role enum.Role
+		_ = role.UnmarshalText([]byte(key))
+		if role == 0 {
+			roleID, err := strconv.Atoi(strings.TrimPrefix(key, "role:"))
+			if err != nil || entity.CustomRoleKey(roleID) != key {
+				result.AddFieldFailure(field, "Unknown role '"+key+"'.")
+				continue
+			}
+
+			err = bus.Dispatch(ctx, &query.GetRoleByID{RoleID: roleID})
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return nil, err
+			} else if err != nil {
+				result.AddFieldFailure(field, "Unknown role '"+key+"'.")
+				continue
+			}
+		}
+
+		seen[key] = true
+		valid = append(valid, key)
+	}
+	return valid, nil
+}
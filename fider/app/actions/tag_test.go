@@ -7,6 +7,7 @@ import (
 	"github.com/getfider/fider/app"
 
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 
@@ -78,3 +79,65 @@ func TestCreateEditTag_ValidInput(t *testing.T) {
 	ExpectSuccess(result)
 	Expect(action.Tag).Equals(tag)
 }
+
+func TestCreateEditTagGroup_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		if q.Slug == "quarter" {
+			q.Result = &entity.TagGroup{ID: 1, Slug: "quarter", Name: "Quarter"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.CreateEditTagGroup{Name: ""}
+	ExpectFailed(action.Validate(context.Background(), nil), "name")
+
+	action = &actions.CreateEditTagGroup{Name: "Quarter"}
+	ExpectFailed(action.Validate(context.Background(), nil), "name")
+
+	action = &actions.CreateEditTagGroup{Name: "Product Area", Selection: "many"}
+	ExpectFailed(action.Validate(context.Background(), nil), "selection")
+
+	action = &actions.CreateEditTagGroup{Name: "Product Area", VisibleTo: []string{"everyone"}, AssignableBy: []string{"role:7"}}
+	ExpectFailed(action.Validate(context.Background(), nil), "visibleTo", "assignableBy")
+}
+
+func TestCreateEditTagGroup_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	group := &entity.TagGroup{ID: 1, Slug: "quarter", Name: "Quarter"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		if q.Slug == group.Slug {
+			q.Result = group
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		q.Result = &entity.Role{ID: q.RoleID, Name: "Support"}
+		return nil
+	})
+
+	action := &actions.CreateEditTagGroup{
+		Name:         "Customer Tier",
+		VisibleTo:    []string{"collaborator", "role:2", "collaborator"},
+		AssignableBy: []string{"visitor"},
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Group).IsNil()
+	Expect(action.Selection).Equals(enum.TagGroupSelectionMultiple)
+	Expect(action.VisibleTo).Equals([]string{"collaborator", "role:2"})
+
+	action = &actions.CreateEditTagGroup{Slug: "quarter", Name: "Quarter", Selection: enum.TagGroupSelectionSingle}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Group).Equals(group)
+}
@@ -3,6 +3,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/query"
@@ -207,7 +208,7 @@ func NewUpdateTenantSettings() *UpdateTenantSettings {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *UpdateTenantSettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.Role == enum.RoleAdministrator
+	return user != nil && user.HasPermission(enum.PermissionManageSettings)
 }
 
 // Validate if current model is valid
@@ -261,18 +262,32 @@ func (action *UpdateTenantSettings) Validate(ctx context.Context, user *entity.U
 
 // UpdateTenantAdvancedSettings is the input model used to update tenant advanced settings
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS      string `json:"customCSS"`
-	AllowedSchemes string `json:"allowedSchemes"`
+	CustomCSS        string `json:"customCSS"`
+	AllowedSchemes   string `json:"allowedSchemes"`
+	AllowedFileTypes string `json:"allowedFileTypes"`
+	FileQuotaMB      int    `json:"fileQuotaMB"`
+	ReactionEmojis   string `json:"reactionEmojis"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *UpdateTenantAdvancedSettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.Role == enum.RoleAdministrator
+	return user != nil && user.HasPermission(enum.PermissionManageSettings)
 }
 
 // Validate if current model is valid
 func (action *UpdateTenantAdvancedSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
-	return validate.Success()
+	result := validate.Success()
+
+	result.AddFieldFailure("allowedFileTypes", validate.FileTypes(ctx, action.AllowedFileTypes)...)
+
+	if action.FileQuotaMB < 0 {
+		result.AddFieldFailure("fileQuotaMB", "File quota must be zero or more.")
+	}
+
+	action.ReactionEmojis = strings.Join(strings.Fields(action.ReactionEmojis), " ")
+	result.AddFieldFailure("reactionEmojis", validate.ReactionEmojis(ctx, action.ReactionEmojis)...)
+
+	return result
 }
 
 // UpdateTenantPrivacySettings is the input model used to update tenant privacy settings
@@ -284,7 +299,7 @@ type UpdateTenantPrivacySettings struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *UpdateTenantPrivacySettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.Role == enum.RoleAdministrator
+	return user != nil && user.HasPermission(enum.PermissionManageSettings)
 }
 
 // Validate if current model is valid
@@ -302,7 +317,7 @@ type UpdateTenantEmailAuthAllowed struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *UpdateTenantEmailAuthAllowed) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.Role == enum.RoleAdministrator
+	return user != nil && user.HasPermission(enum.PermissionManageAuthentication)
 }
 
 // Validate if current model is valid
@@ -320,3 +335,24 @@ func (action *UpdateTenantEmailAuthAllowed) Validate(ctx context.Context, user *
 
 	return result
 }
+
+// UpdateTenantTwoFactorRequired is the input model used to require two-factor authentication for staff members
+type UpdateTenantTwoFactorRequired struct {
+	IsTwoFactorRequired bool `json:"isTwoFactorRequired"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateTenantTwoFactorRequired) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionManageAuthentication)
+}
+
+// Validate if current model is valid
+func (action *UpdateTenantTwoFactorRequired) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.IsTwoFactorRequired && !user.IsTwoFactorEnabled {
+		result.AddFieldFailure("isTwoFactorRequired", "You need to enable two-factor authentication on your own account first.")
+	}
+
+	return result
+}
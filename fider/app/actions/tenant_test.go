@@ -181,3 +181,19 @@ func TestUpdateTenantSettings_ExistingTenant_WithLogo(t *testing.T) {
 	ExpectSuccess(result)
 	Expect(action.Logo.BlobKey).Equals("hello-world.png")
 }
+
+func TestUpdateTenantAdvancedSettings_FileTypes(t *testing.T) {
+	RegisterT(t)
+
+	action := actions.UpdateTenantAdvancedSettings{AllowedFileTypes: ".log, application/pdf, text/*", FileQuotaMB: 100}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+
+	action = actions.UpdateTenantAdvancedSettings{AllowedFileTypes: "log, application/pdf"}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "allowedFileTypes")
+
+	action = actions.UpdateTenantAdvancedSettings{FileQuotaMB: -1}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "fileQuotaMB")
+}
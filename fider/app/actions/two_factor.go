@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// VerifyTwoFactor is used to verify the second factor of a user, either with a code from an authenticator app or with a recovery code
+type VerifyTwoFactor struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *VerifyTwoFactor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	// users waiting to verify their second factor are not signed in yet
+	return true
+}
+
+// Validate if current model is valid
+func (action *VerifyTwoFactor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Code == "" && action.RecoveryCode == "" {
+		result.AddFieldFailure("code", "Enter a code from your authenticator app or one of your recovery codes.")
+	}
+
+	return result
+}
+
+// DisableTwoFactor is used to turn off two-factor authentication of current user
+type DisableTwoFactor struct {
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DisableTwoFactor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsTwoFactorEnabled
+}
+
+// Validate if current model is valid
+func (action *DisableTwoFactor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if tenant.IsTwoFactorRequired && user.HasPermission(enum.PermissionAccessAdmin) {
+		return validate.Failed("Two-factor authentication is required for staff members and cannot be disabled.")
+	}
+	return validate.Success()
+}
+
+// RegenerateRecoveryCodes is used to replace the recovery codes of current user
+type RegenerateRecoveryCodes struct {
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RegenerateRecoveryCodes) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsTwoFactorEnabled
+}
+
+// Validate if current model is valid
+func (action *RegenerateRecoveryCodes) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return validate.Success()
+}
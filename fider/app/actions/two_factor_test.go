@@ -0,0 +1,68 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestVerifyTwoFactor_Empty(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.VerifyTwoFactor{}
+	Expect(action.IsAuthorized(context.Background(), nil)).IsTrue()
+	ExpectFailed(action.Validate(context.Background(), nil), "code")
+}
+
+func TestVerifyTwoFactor_CodeOrRecoveryCode(t *testing.T) {
+	RegisterT(t)
+
+	ExpectSuccess((&actions.VerifyTwoFactor{Code: "123456"}).Validate(context.Background(), nil))
+	ExpectSuccess((&actions.VerifyTwoFactor{RecoveryCode: "abcde-fghij"}).Validate(context.Background(), nil))
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	RegisterT(t)
+
+	tenant := &entity.Tenant{ID: 1}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, tenant)
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator, Tenant: tenant}
+	visitor := &entity.User{ID: 2, Role: enum.RoleVisitor, Tenant: tenant}
+
+	action := &actions.DisableTwoFactor{}
+	Expect(action.IsAuthorized(ctx, nil)).IsFalse()
+	Expect(action.IsAuthorized(ctx, admin)).IsFalse()
+
+	admin.IsTwoFactorEnabled = true
+	visitor.IsTwoFactorEnabled = true
+	Expect(action.IsAuthorized(ctx, admin)).IsTrue()
+	ExpectSuccess(action.Validate(ctx, admin))
+
+	tenant.IsTwoFactorRequired = true
+	ExpectFailed(action.Validate(ctx, admin), "")
+	ExpectSuccess(action.Validate(ctx, visitor))
+}
+
+func TestUpdateTenantTwoFactorRequired(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	collaborator := &entity.User{ID: 2, Role: enum.RoleCollaborator}
+
+	action := &actions.UpdateTenantTwoFactorRequired{IsTwoFactorRequired: true}
+	Expect(action.IsAuthorized(context.Background(), collaborator)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), admin)).IsTrue()
+	ExpectFailed(action.Validate(context.Background(), admin), "isTwoFactorRequired")
+
+	admin.IsTwoFactorEnabled = true
+	ExpectSuccess(action.Validate(context.Background(), admin))
+
+	admin.IsTwoFactorEnabled = false
+	action.IsTwoFactorRequired = false
+	ExpectSuccess(action.Validate(context.Background(), admin))
+}
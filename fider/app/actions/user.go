@@ -22,7 +22,7 @@ type CreateUser struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *CreateUser) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && user.IsAdministrator()
+	return user != nil && user.HasPermission(enum.PermissionManageUsers)
 }
 
 // Validate if current model is valid
@@ -57,6 +57,7 @@ func (action *CreateUser) Validate(ctx context.Context, user *entity.User) *vali
 type ChangeUserRole struct {
 	Role   enum.Role `route:"role"`
 	UserID int       `json:"userID"`
+	User   *entity.User
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -64,7 +65,7 @@ func (action *ChangeUserRole) IsAuthorized(ctx context.Context, user *entity.Use
 	if user == nil {
 		return false
 	}
-	return user.IsAdministrator() && user.ID != action.UserID
+	return user.HasPermission(enum.PermissionManageUsers) && user.ID != action.UserID
 }
 
 // Validate if current model is valid
@@ -88,6 +89,13 @@ func (action *ChangeUserRole) Validate(ctx context.Context, user *entity.User) *
 		}
 	} else if userByID.Result.Tenant.ID != user.Tenant.ID {
 		result.AddFieldFailure("userID", "User not found.")
+	} else {
+		action.User = userByID.Result
+		if !user.HasPermissionsOf(action.User) {
+			result.AddFieldFailure("userID", "You cannot manage members with permissions you don't have.")
+		} else if !user.HasPermissions(action.Role.Permissions()) {
+			result.AddFieldFailure("userID", "You cannot assign a role with permissions you don't have.")
+		}
 	}
 	return result
 }
@@ -189,3 +189,74 @@ func TestChangeUserRole_CurrentUser(t *testing.T) {
 	result := action.Validate(context.Background(), currentUser)
 	ExpectFailed(result, "userID")
 }
+
+func TestChangeUserRole_CustomRoleCannotPromoteToAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:     3,
+		Tenant: &entity.Tenant{ID: 1},
+		Role:   enum.RoleVisitor,
+	}
+
+	currentUser := &entity.User{
+		ID:     4,
+		Tenant: &entity.Tenant{ID: 1},
+		Role:   enum.RoleVisitor,
+		CustomRole: &entity.Role{
+			Name:        "Community Manager",
+			Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageUsers},
+		},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := actions.ChangeUserRole{UserID: targetUser.ID, Role: enum.RoleAdministrator}
+	Expect(action.IsAuthorized(context.Background(), currentUser)).IsTrue()
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+
+	action = actions.ChangeUserRole{UserID: targetUser.ID, Role: enum.RoleCollaborator}
+	result = action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+
+	action = actions.ChangeUserRole{UserID: targetUser.ID, Role: enum.RoleVisitor}
+	result = action.Validate(context.Background(), currentUser)
+	Expect(result.Ok).IsTrue()
+}
+
+func TestChangeUserRole_CustomRoleCannotManageMoreAccessibleUsers(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:     3,
+		Tenant: &entity.Tenant{ID: 1},
+		Role:   enum.RoleCollaborator,
+	}
+
+	currentUser := &entity.User{
+		ID:     4,
+		Tenant: &entity.Tenant{ID: 1},
+		Role:   enum.RoleVisitor,
+		CustomRole: &entity.Role{
+			Name:        "Community Manager",
+			Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageUsers},
+		},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = targetUser
+		return nil
+	})
+
+	action := actions.ChangeUserRole{UserID: targetUser.ID, Role: enum.RoleVisitor}
+	Expect(action.IsAuthorized(context.Background(), currentUser)).IsTrue()
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+}
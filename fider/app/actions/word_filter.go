@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateWordFilter is used to add a blocked word or pattern
+type CreateWordFilter struct {
+	Pattern string                `json:"pattern"`
+	IsRegex bool                  `json:"isRegex"`
+	Action  enum.WordFilterAction `json:"action"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateWordFilter) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionModerateContent)
+}
+
+// Validate if current model is valid
+func (action *CreateWordFilter) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Pattern = strings.TrimSpace(action.Pattern)
+	if action.Pattern == "" {
+		result.AddFieldFailure("pattern", "Pattern is required.")
+	} else if len(action.Pattern) > 200 {
+		result.AddFieldFailure("pattern", "Pattern must have less than 200 characters.")
+	} else {
+		filter := &entity.WordFilter{Pattern: action.Pattern, IsRegex: action.IsRegex}
+		if _, err := filter.Regexp(); err != nil {
+			result.AddFieldFailure("pattern", "Pattern is not a valid regular expression.")
+		}
+	}
+
+	if action.Action.String() == "" {
+		result.AddFieldFailure("action", "Action must be reject, mask or moderate.")
+	}
+
+	return result
+}
+
+// DeleteWordFilter is used to remove an existing word filter
+type DeleteWordFilter struct {
+	ID int `route:"id"`
+
+	Filter *entity.WordFilter
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteWordFilter) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionModerateContent)
+}
+
+// Validate if current model is valid
+func (action *DeleteWordFilter) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getFilter := &query.GetWordFilterByID{ID: action.ID}
+	if err := bus.Dispatch(ctx, getFilter); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Filter = getFilter.Result
+	return validate.Success()
+}
+
+// filteredField is a field of an action that is checked against the word filters
+type filteredField struct {
+	field    string
+	property string
+	value    *string
+}
+
+// applyWordFilters checks given fields against the word filters of current tenant.
+// Fields matching a reject filter fail the validation and text matching a mask filter is replaced with asterisks.
+// The moderate filters that matched are returned so that the content is held for moderation, but when it can't be held,
+// either because the tenant has no moderation queue or because of what the content is, they reject it instead.
+// Staff that moderate content are never filtered
+func applyWordFilters(ctx context.Context, user *entity.User, result *validate.Result, canHold bool, fields ...filteredField) ([]*entity.WordFilter, error) {
+	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if !ok || tenant == nil || (user != nil && user.HasPermission(enum.PermissionModerateContent)) {
+		return nil, nil
+	}
+
+	getFilters := &query.GetWordFilters{}
+	if err := bus.Dispatch(ctx, getFilters); err != nil {
+		return nil, err
+	}
+
+	canHold = canHold && tenant.HasCommercialFeatures
+	held := make([]*entity.WordFilter, 0)
+	for _, field := range fields {
+		rejected := false
+		masked := *field.value
+
+		for _, filter := range getFilters.Result {
+			re, err := filter.Regexp()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to compile word filter '%d'", filter.ID)
+			}
+
+			if filter.Action == enum.WordFilterMask {
+				masked = re.ReplaceAllStringFunc(masked, func(match string) string {
+					return strings.Repeat("*", utf8.RuneCountInString(match))
+				})
+			} else if re.MatchString(*field.value) {
+				if filter.Action == enum.WordFilterModerate && canHold {
+					if !containsWordFilter(held, filter) {
+						held = append(held, filter)
+					}
+				} else {
+					rejected = true
+				}
+			}
+		}
+
+		if rejected {
+			result.AddFieldFailure(field.field, propertyHasBlockedWords(ctx, field.property))
+		} else {
+			*field.value = masked
+		}
+	}
+
+	if !result.Ok {
+		return nil, nil
+	}
+	return held, nil
+}
+
+func containsWordFilter(filters []*entity.WordFilter, filter *entity.WordFilter) bool {
+	for _, f := range filters {
+		if f.ID == filter.ID {
+			return true
+		}
+	}
+	return false
+}
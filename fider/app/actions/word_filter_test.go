@@ -0,0 +1,105 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+var wordFilters = []*entity.WordFilter{
+	{ID: 1, Pattern: "darn", Action: enum.WordFilterMask},
+	{ID: 2, Pattern: "acme corp", Action: enum.WordFilterModerate},
+	{ID: 3, Pattern: `\b\d{4}[ -]?\d{4}[ -]?\d{4}[ -]?\d{4}\b`, IsRegex: true, Action: enum.WordFilterReject},
+}
+
+func wordFilterContext(hasCommercialFeatures bool) context.Context {
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error {
+		q.Result = wordFilters
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+
+	return context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		ID:                    1,
+		HasCommercialFeatures: hasCommercialFeatures,
+	})
+}
+
+func TestCreateWordFilter_Validate(t *testing.T) {
+	RegisterT(t)
+
+	ExpectSuccess((&actions.CreateWordFilter{Pattern: " acme ", Action: enum.WordFilterReject}).Validate(context.Background(), nil))
+	ExpectSuccess((&actions.CreateWordFilter{Pattern: `\d+`, IsRegex: true, Action: enum.WordFilterMask}).Validate(context.Background(), nil))
+	ExpectFailed((&actions.CreateWordFilter{Pattern: "", Action: enum.WordFilterReject}).Validate(context.Background(), nil), "pattern")
+	ExpectFailed((&actions.CreateWordFilter{Pattern: "(abc", IsRegex: true, Action: enum.WordFilterReject}).Validate(context.Background(), nil), "pattern")
+	ExpectFailed((&actions.CreateWordFilter{Pattern: "acme"}).Validate(context.Background(), nil), "action")
+}
+
+func TestWordFilters_Post(t *testing.T) {
+	RegisterT(t)
+	ctx := wordFilterContext(true)
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+
+	action := &actions.CreateNewPost{Title: "Darn, this is slow", Description: "Please make it DARN faster"}
+	ExpectSuccess(action.Validate(ctx, visitor))
+	Expect(action.Title).Equals("****, this is slow")
+	Expect(action.Description).Equals("Please make it **** faster")
+	Expect(action.HeldBy).HasLen(0)
+
+	action = &actions.CreateNewPost{Title: "Integration with Acme Corp", Description: "Like Acme corp does"}
+	ExpectSuccess(action.Validate(ctx, visitor))
+	Expect(action.HeldBy).Equals([]*entity.WordFilter{wordFilters[1]})
+
+	action = &actions.CreateNewPost{Title: "Payment is not working", Description: "My card 4111 1111 1111 1111 is refused"}
+	ExpectFailed(action.Validate(ctx, visitor), "description")
+
+	// darnit is not the darn word
+	action = &actions.CreateNewPost{Title: "Darnit, this is slow", Description: ""}
+	ExpectSuccess(action.Validate(ctx, visitor))
+	Expect(action.Title).Equals("Darnit, this is slow")
+}
+
+func TestWordFilters_Comment_WithoutModerationQueue(t *testing.T) {
+	RegisterT(t)
+	ctx := wordFilterContext(false)
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+
+	action := &actions.AddNewComment{Number: 1, Content: "Have you tried Acme Corp?"}
+	ExpectFailed(action.Validate(ctx, visitor), "content")
+
+	action = &actions.AddNewComment{Number: 1, Content: "Darn"}
+	ExpectSuccess(action.Validate(ctx, visitor))
+	Expect(action.Content).Equals("****")
+}
+
+func TestWordFilters_DisplayName(t *testing.T) {
+	RegisterT(t)
+	ctx := wordFilterContext(true)
+
+	action := &actions.CompleteProfile{Key: "1234", Name: "Acme Corp Support"}
+	ExpectFailed(action.Validate(ctx, nil), "name")
+
+	action = &actions.CompleteProfile{Key: "1234", Name: "Darn Smith"}
+	ExpectSuccess(action.Validate(ctx, nil))
+	Expect(action.Name).Equals("**** Smith")
+}
+
+func TestWordFilters_ModeratorsAreNotFiltered(t *testing.T) {
+	RegisterT(t)
+	ctx := wordFilterContext(true)
+	administrator := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+
+	action := &actions.AddNewComment{Number: 1, Content: "Darn, Acme Corp again"}
+	ExpectSuccess(action.Validate(ctx, administrator))
+	Expect(action.Content).Equals("Darn, Acme Corp again")
+	Expect(action.HeldBy).HasLen(0)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/blobgc"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+// RunBlobs reports referenced blobs that are missing and stored blobs that are orphaned on every active site
+// Usage: fider blobs [-delete] [-grace=168h] [-subdomain=name]
+// Orphaned blobs are only deleted with -delete, once they've been orphaned for longer than the grace period
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunBlobs(args []string) int {
+	flags := flag.NewFlagSet("blobs", flag.ContinueOnError)
+	deleteOrphans := flags.Bool("delete", false, "delete blobs orphaned for longer than the grace period")
+	grace := flags.Duration("grace", env.Config.BlobGC.GracePeriod, "how long blobs must be orphaned before they're deleted")
+	subdomain := flags.String("subdomain", "", "only check the site with this subdomain")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 0 {
+		fmt.Println("Usage: fider blobs [-delete] [-grace=168h] [-subdomain=name]")
+		return 1
+	}
+
+	bus.Init()
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       "BLOBS",
+		log.PropertyKeyContextID: rand.String(32),
+	})
+	defer log.Flush(ctx)
+
+	q := &query.GetActiveTenants{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, tenant := range q.Result {
+		if *subdomain != "" && tenant.Subdomain != *subdomain {
+			continue
+		}
+
+		tenantCtx := context.WithValue(ctx, app.TenantCtxKey, tenant)
+		report, err := blobgc.Collect(tenantCtx, blobgc.Options{
+			Delete:      *deleteOrphans,
+			GracePeriod: *grace,
+		})
+		if err != nil {
+			log.Error(tenantCtx, err)
+			exitCode = 1
+		}
+		if report != nil {
+			printBlobsReport(tenant.Subdomain, report)
+		}
+	}
+	return exitCode
+}
+
+func printBlobsReport(subdomain string, report *blobgc.Report) {
+	fmt.Printf("Site: %s\n", subdomain)
+	fmt.Printf("  %d referenced, %d stored\n", report.Referenced, report.Stored)
+	for _, key := range report.Missing {
+		fmt.Printf("  missing:  %s\n", key)
+	}
+	deleted := make(map[string]bool, len(report.Deleted))
+	for _, key := range report.Deleted {
+		deleted[key] = true
+	}
+	for _, key := range report.Orphaned {
+		if deleted[key] {
+			fmt.Printf("  deleted:  %s\n", key)
+		} else {
+			fmt.Printf("  orphaned: %s\n", key)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/services/blob"
+)
+
+// RunCopyBlobs copies every blob, of all sites, from one blob storage to another
+// Usage: fider copy-blobs -from=sql [-to=s3] [-verify]
+// Blobs that already exist on the target are skipped, so an interrupted copy can be run again to resume it.
+// With -verify, the ones that differ are copied again, unless the blob on the target is newer
+// Use BLOB_STORAGE_FALLBACK to keep serving blobs from the previous storage while they're copied
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunCopyBlobs(args []string) int {
+	flags := flag.NewFlagSet("copy-blobs", flag.ContinueOnError)
+	from := flags.String("from", env.Config.BlobStorage.Fallback, "storage to copy blobs from, defaults to BLOB_STORAGE_FALLBACK")
+	to := flags.String("to", env.Config.BlobStorage.Type, "storage to copy blobs to, defaults to BLOB_STORAGE")
+	verify := flags.Bool("verify", false, "compare checksums of blobs that already exist on the target and copy the ones that differ, unless the target is newer")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 0 || *from == "" || *from == *to {
+		fmt.Println("Usage: fider copy-blobs -from=sql [-to=s3] [-verify]")
+		return 1
+	}
+
+	bus.Init()
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       "COPY-BLOBS",
+		log.PropertyKeyContextID: rand.String(32),
+	})
+	defer log.Flush(ctx)
+
+	source, err := blob.GetBackend(*from)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+	target, err := blob.GetBackend(*to)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	q := &query.GetAllTenants{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	fmt.Printf("Copying blobs from '%s' to '%s'\n", *from, *to)
+
+	total := &blob.CopyReport{}
+	scopes := append([]context.Context{ctx}, make([]context.Context, len(q.Result))...)
+	for i, tenant := range q.Result {
+		scopes[i+1] = context.WithValue(ctx, app.TenantCtxKey, tenant)
+	}
+
+	for i, scope := range scopes {
+		name := "(global)"
+		if i > 0 {
+			name = q.Result[i-1].Subdomain
+		}
+
+		report, err := blob.Copy(scope, source, target, blob.CopyOptions{
+			Verify: *verify,
+			Progress: func(done, total int, key string, status blob.CopyStatus) {
+				fmt.Printf("  [%s %d/%d] %-7s %s\n", name, done, total, status, key)
+			},
+		})
+		if err != nil {
+			log.Error(scope, err)
+			fmt.Println("Copy failed, run it again to resume.")
+			return 1
+		}
+
+		fmt.Printf("Site: %s, %d blobs, %d copied, %d skipped, %d newer on target\n", name, report.Total, report.Copied, report.Skipped, report.Newer)
+		total.Total += report.Total
+		total.Copied += report.Copied
+		total.Skipped += report.Skipped
+		total.Newer += report.Newer
+		total.Bytes += report.Bytes
+	}
+
+	fmt.Printf("Done: %d blobs, %d copied (%d bytes), %d skipped, %d newer on target\n", total.Total, total.Copied, total.Bytes, total.Skipped, total.Newer)
+	if total.Newer > 0 {
+		fmt.Println("Blobs that are newer on the target were kept, review them before switching storages.")
+	}
+	return 0
+}
@@ -20,6 +20,7 @@ func RunMigrate() int {
 		log.PropertyKeyTag:       "MIGRATE",
 		log.PropertyKeyContextID: rand.String(32),
 	})
+	defer log.Flush(ctx)
 
 	err := dbx.Migrate(ctx, "/migrations")
 	if err != nil {
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/backup"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+// RunRestore restores a backup.zip into a new tenant on current DATABASE_URL
+// Usage: fider restore [-dry-run] [-subdomain=name] [-passphrase=secret] backup.zip
+// Encrypted backups are decrypted with -passphrase, or BACKUP_PASSPHRASE when not given
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunRestore(args []string) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "validate the backup and report what would be restored, without changing anything")
+	subdomain := flags.String("subdomain", "", "subdomain of the new site, required on HOST_MODE=multi")
+	passphrase := flags.String("passphrase", env.Config.Backup.Passphrase, "passphrase of an encrypted backup")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 1 {
+		fmt.Println("Usage: fider restore [-dry-run] [-subdomain=name] [-passphrase=secret] backup.zip")
+		return 1
+	}
+
+	bus.Init()
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       "RESTORE",
+		log.PropertyKeyContextID: rand.String(32),
+	})
+	defer log.Flush(ctx)
+
+	fileName, cleanup, err := decryptFile(flags.Arg(0), *passphrase)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+	defer cleanup()
+
+	report, err := restoreFile(ctx, fileName, backup.RestoreOptions{
+		Subdomain: strings.ToLower(*subdomain),
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	printRestoreReport(report)
+	return 0
+}
+
+// decryptFile returns the name of a file with the decrypted content of an encrypted backup,
+// which is removed by cleanup. Backups that are not encrypted are returned as they are
+func decryptFile(fileName, passphrase string) (string, func(), error) {
+	noop := func() {}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return "", noop, errors.Wrap(err, "failed to open '%s'", fileName)
+	}
+	defer file.Close()
+
+	header := make([]byte, 8)
+	n, _ := io.ReadFull(file, header)
+	if !backup.IsEncrypted(header[:n]) {
+		return fileName, noop, nil
+	}
+	if passphrase == "" {
+		return "", noop, errors.New("'%s' is encrypted, use -passphrase or BACKUP_PASSPHRASE to restore it", fileName)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", noop, errors.Wrap(err, "failed to read '%s'", fileName)
+	}
+	reader, err := backup.NewDecryptReader(file, passphrase)
+	if err != nil {
+		return "", noop, err
+	}
+
+	decrypted, err := os.CreateTemp("", "fider-restore-*.zip")
+	if err != nil {
+		return "", noop, errors.Wrap(err, "failed to create temporary file")
+	}
+	cleanup := func() { _ = os.Remove(decrypted.Name()) }
+
+	_, err = io.Copy(decrypted, reader)
+	if closeErr := decrypted.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", noop, errors.Wrap(err, "failed to decrypt '%s'", fileName)
+	}
+	return decrypted.Name(), cleanup, nil
+}
+
+func restoreFile(ctx context.Context, fileName string, opts backup.RestoreOptions) (*backup.RestoreReport, error) {
+	archive, err := zip.OpenReader(fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open '%s'", fileName)
+	}
+	defer archive.Close()
+
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, app.TransactionCtxKey, trx)
+
+	messages, err := backup.ValidateTarget(ctx, opts.Subdomain, opts.DryRun)
+	if err != nil {
+		trx.MustRollback()
+		return nil, err
+	}
+	if len(messages) > 0 {
+		trx.MustRollback()
+		return nil, errors.New("%s", strings.Join(messages, " "))
+	}
+
+	report, err := backup.Restore(ctx, &archive.Reader, opts)
+	if err != nil {
+		trx.MustRollback()
+		return nil, err
+	}
+
+	if err := trx.Commit(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func printRestoreReport(report *backup.RestoreReport) {
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was changed.")
+	}
+	fmt.Printf("Site: %s (subdomain: %s, id: %d)\n", report.TenantName, report.Subdomain, report.TenantID)
+	fmt.Printf("Schema version: %d\n", report.SchemaVersion)
+
+	tables := make([]string, 0, len(report.Rows))
+	for table := range report.Rows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		fmt.Printf("  %-20s %d rows\n", table, report.Rows[table])
+	}
+	fmt.Printf("  %-20s %d files\n", "blobs", report.Blobs)
+
+	for _, warning := range report.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	if report.DryRun {
+		fmt.Println("Run again without -dry-run to restore it.")
+	}
+}
@@ -18,10 +18,14 @@ func routes(r *web.Engine) *web.Engine {
 	r.Worker().Use(middlewares.WorkerSetup())
 
 	r.Get("/_health", handlers.Health())
+	r.Get("/_health/live", handlers.Liveness())
+	r.Get("/_health/ready", handlers.Readiness())
 
 	r.Use(middlewares.CatchPanic())
 	r.Use(middlewares.Instrumentation())
 
+	r.Get("/_diagnostics", handlers.Diagnostics())
+
 	r.NotFound(func(c *web.Context) error {
 		mw := middlewares.Chain(
 			middlewares.WebSetup(),
@@ -82,7 +86,11 @@ func routes(r *web.Engine) *web.Engine {
 
 	r.Get("/terms", handlers.LegalPage("Terms of Service", "terms.md"))
 
-	r.Post("/_api/tenants", handlers.CreateTenant())
+	createTenant := r.Group()
+	{
+		createTenant.Use(middlewares.RateLimit("auth", env.Config.RateLimit.Auth))
+		createTenant.Post("/_api/tenants", handlers.CreateTenant())
+	}
 	r.Get("/_api/tenants/:subdomain/availability", handlers.CheckAvailability())
 	r.Get("/signup", handlers.SignUp())
 	r.Get("/oauth/:provider", handlers.SignInByOAuth())
@@ -114,7 +122,11 @@ func routes(r *web.Engine) *web.Engine {
 
 	r.Get("/_design", handlers.Page("Design System", "A preview of Fider UI elements", "DesignSystem/DesignSystem.page"))
 	r.Get("/signup/verify", handlers.VerifySignUpKey())
-	r.Post("/_api/signup/resend", handlers.ResendSignUpEmail())
+	signup := r.Group()
+	{
+		signup.Use(middlewares.RateLimit("auth", env.Config.RateLimit.Auth))
+		signup.Post("/_api/signup/resend", handlers.ResendSignUpEmail())
+	}
 	r.Get("/signout", handlers.SignOut())
 	r.Get("/oauth/:provider/token", handlers.OAuthToken())
 	r.Get("/oauth/:provider/echo", handlers.OAuthEcho())
@@ -128,11 +140,18 @@ func routes(r *web.Engine) *web.Engine {
 	r.Get("/not-invited", handlers.NotInvitedPage())
 	r.Get("/signin/verify", handlers.VerifySignInKey(enum.EmailVerificationKindSignIn))
 	r.Get("/invite/verify", handlers.VerifySignInKey(enum.EmailVerificationKindUserInvitation))
-	r.Post("/_api/signin/complete", handlers.CompleteSignInProfile())
-	r.Post("/_api/signin", handlers.SignInByEmail())
-	r.Post("/_api/signin/newuser", handlers.SignInByEmailWithName())
-	r.Post("/_api/signin/verify", handlers.VerifySignInCode())
-	r.Post("/_api/signin/resend", handlers.ResendSignInCode())
+	r.Get("/signin/2fa", handlers.TwoFactorPage())
+
+	auth := r.Group()
+	{
+		auth.Use(middlewares.RateLimit("auth", env.Config.RateLimit.Auth))
+		auth.Post("/_api/signin/complete", handlers.CompleteSignInProfile())
+		auth.Post("/_api/signin", handlers.SignInByEmail())
+		auth.Post("/_api/signin/newuser", handlers.SignInByEmailWithName())
+		auth.Post("/_api/signin/verify", handlers.VerifySignInCode())
+		auth.Post("/_api/signin/resend", handlers.ResendSignInCode())
+		auth.Post("/_api/signin/2fa", handlers.VerifyTwoFactor())
+	}
 
 	// Block if it's private tenant with unauthenticated user
 	r.Use(middlewares.CheckTenantPrivacy())
@@ -140,6 +159,7 @@ func routes(r *web.Engine) *web.Engine {
 	r.Get("/", handlers.Index())
 	r.Get("/posts/:number", handlers.PostDetails())
 	r.Get("/posts/:number/:slug", handlers.PostDetails())
+	r.Get("/files/:id/:name", handlers.DownloadFile())
 
 	ui := r.Group()
 	{
@@ -153,14 +173,22 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Get("/change-email/verify", handlers.VerifyChangeEmailKey())
 
 		ui.Delete("/_api/user", handlers.DeleteUser())
-		ui.Post("/_api/user/regenerate-apikey", handlers.RegenerateAPIKey())
 		ui.Post("/_api/user/settings", handlers.UpdateUserSettings())
 		ui.Post("/_api/user/change-email", handlers.ChangeUserEmail())
 		ui.Post("/_api/notifications/read-all", handlers.ReadAllNotifications())
 		ui.Get("/_api/notifications/unread/total", handlers.TotalUnreadNotifications())
 
-		// From this step, only Collaborators and Administrators are allowed
-		ui.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
+		// Sensitive operations require a recent verification of the second factor
+		account := ui.Group()
+		{
+			account.Use(middlewares.RequireTwoFactor())
+			account.Post("/_api/user/regenerate-apikey", handlers.RegenerateAPIKey())
+			account.Post("/_api/user/2fa/recovery-codes", handlers.RegenerateRecoveryCodes())
+			account.Delete("/_api/user/2fa", handlers.DisableTwoFactor())
+		}
+
+		// From this step, only members with access to the administration are allowed
+		ui.Use(middlewares.HasPermission(enum.PermissionAccessAdmin))
 
 		// locale is forced to English for administrative pages.
 		// This is meant to be removed when all pages are translated.
@@ -176,37 +204,124 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Get("/admin/authentication", handlers.ManageAuthentication())
 		ui.Get("/_api/admin/oauth/:provider", handlers.GetOAuthConfig())
 
-		// From this step, only Administrators are allowed
-		ui.Use(middlewares.IsAuthorized(enum.RoleAdministrator))
-
-		ui.Get("/admin/export", handlers.Page("Export · Site Settings", "", "Administration/pages/Export.page"))
-		ui.Get("/admin/export/posts.csv", handlers.ExportPostsToCSV())
-		ui.Get("/admin/export/backup.zip", handlers.ExportBackupZip())
-		ui.Get("/admin/webhooks", handlers.ManageWebhooks())
-		ui.Post("/_api/admin/webhook", handlers.CreateWebhook())
-		ui.Put("/_api/admin/webhook/:id", handlers.UpdateWebhook())
-		ui.Delete("/_api/admin/webhook/:id", handlers.DeleteWebhook())
-		ui.Get("/_api/admin/webhook/test/:id", handlers.TestWebhook())
-		ui.Post("/_api/admin/webhook/preview", handlers.PreviewWebhook())
-		ui.Get("/_api/admin/webhook/props/:type", handlers.GetWebhookProps())
-		ui.Post("/_api/admin/settings/general", handlers.UpdateSettings())
-		ui.Post("/_api/admin/settings/advanced", handlers.UpdateAdvancedSettings())
-		ui.Post("/_api/admin/settings/privacy", handlers.UpdatePrivacySettings())
-		ui.Post("/_api/admin/settings/emailauth", handlers.UpdateEmailAuthAllowed())
-		ui.Post("/_api/admin/oauth", handlers.SaveOAuthConfig())
-		ui.Post("/_api/admin/oauth/:provider/status", handlers.SetSystemProviderStatus())
-		ui.Post("/_api/admin/roles/:role/users", handlers.ChangeUserRole())
-		ui.Put("/_api/admin/users/:userID/block", handlers.BlockUser())
-		ui.Delete("/_api/admin/users/:userID/block", handlers.UnblockUser())
-		ui.Put("/_api/admin/users/:userID/trust", handlers.TrustUser())
-		ui.Delete("/_api/admin/users/:userID/trust", handlers.UntrustUser())
-		ui.Get("/_api/admin/moderation/items", handlers.GetModerationItemsHandler())
-		ui.Get("/_api/admin/moderation/count", handlers.GetModerationCountHandler())
+		export := ui.Group()
+		{
+			export.Use(middlewares.HasPermission(enum.PermissionExportData))
+			export.Use(middlewares.RequireTwoFactor())
+			export.Get("/admin/export", handlers.ExportPage())
+			export.Get("/admin/export/posts.csv", handlers.ExportPostsToCSV())
+			export.Get("/admin/export/backup.zip", handlers.ExportBackupZip())
+			export.Get("/admin/export/data/:type", handlers.ExportData())
+		}
+
+		// Restoring a backup creates a new site, which is an operation of the whole instance.
+		// Uploaded backups can only be validated on installations that host a single site, restoring them is done with the CLI
+		if env.IsSingleHostMode() {
+			restore := ui.Group()
+			restore.Use(middlewares.HasPermission(enum.PermissionImportData))
+			restore.Use(middlewares.RequireTwoFactor())
+			restore.Post("/_api/admin/backup/restore", handlers.RestoreBackupZip())
+		}
+
+		imports := ui.Group()
+		{
+			imports.Use(middlewares.HasPermission(enum.PermissionImportData))
+			imports.Use(middlewares.RequireTwoFactor())
+			imports.Get("/admin/import", handlers.ImportPage())
+			imports.Post("/_api/admin/imports", handlers.StartImport())
+			imports.Get("/_api/admin/imports/:id", handlers.GetImport())
+		}
+
+		audit := ui.Group()
+		{
+			audit.Use(middlewares.HasPermission(enum.PermissionViewAuditLog))
+			audit.Use(middlewares.RequireTwoFactor())
+			audit.Get("/admin/audit", handlers.AuditLogPage())
+			audit.Get("/admin/export/audit.csv", handlers.ExportAuditLogToCSV())
+			audit.Get("/admin/export/audit.json", handlers.ExportAuditLogToJSON())
+		}
+
+		webhooks := ui.Group()
+		{
+			webhooks.Use(middlewares.HasPermission(enum.PermissionManageWebhooks))
+			webhooks.Use(middlewares.RequireTwoFactor())
+			webhooks.Get("/admin/webhooks", handlers.ManageWebhooks())
+			webhooks.Post("/_api/admin/webhook", handlers.CreateWebhook())
+			webhooks.Put("/_api/admin/webhook/:id", handlers.UpdateWebhook())
+			webhooks.Delete("/_api/admin/webhook/:id", handlers.DeleteWebhook())
+			webhooks.Get("/_api/admin/webhook/test/:id", handlers.TestWebhook())
+			webhooks.Post("/_api/admin/webhook/preview", handlers.PreviewWebhook())
+			webhooks.Get("/_api/admin/webhook/props/:type", handlers.GetWebhookProps())
+		}
+
+		settings := ui.Group()
+		{
+			settings.Use(middlewares.HasPermission(enum.PermissionManageSettings))
+			settings.Use(middlewares.RequireTwoFactor())
+			settings.Post("/_api/admin/settings/general", handlers.UpdateSettings())
+			settings.Post("/_api/admin/settings/advanced", handlers.UpdateAdvancedSettings())
+			settings.Post("/_api/admin/settings/privacy", handlers.UpdatePrivacySettings())
+		}
+
+		authentication := ui.Group()
+		{
+			authentication.Use(middlewares.HasPermission(enum.PermissionManageAuthentication))
+			authentication.Use(middlewares.RequireTwoFactor())
+			authentication.Post("/_api/admin/settings/emailauth", handlers.UpdateEmailAuthAllowed())
+			authentication.Post("/_api/admin/settings/twofactor", handlers.UpdateTwoFactorRequired())
+			authentication.Post("/_api/admin/oauth", handlers.SaveOAuthConfig())
+			authentication.Post("/_api/admin/oauth/:provider/status", handlers.SetSystemProviderStatus())
+		}
+
+		users := ui.Group()
+		{
+			users.Use(middlewares.HasPermission(enum.PermissionManageUsers))
+			users.Use(middlewares.RequireTwoFactor())
+			users.Post("/_api/admin/roles/:role/users", handlers.ChangeUserRole())
+			users.Put("/_api/admin/users/:userID/block", handlers.BlockUser())
+			users.Delete("/_api/admin/users/:userID/block", handlers.UnblockUser())
+			users.Put("/_api/admin/users/:userID/trust", handlers.TrustUser())
+			users.Delete("/_api/admin/users/:userID/trust", handlers.UntrustUser())
+		}
+
+		roles := ui.Group()
+		{
+			roles.Use(middlewares.HasPermission(enum.PermissionManageRoles))
+			roles.Use(middlewares.RequireTwoFactor())
+			roles.Get("/admin/roles", handlers.ManageRoles())
+			roles.Post("/_api/admin/custom-roles", handlers.CreateRole())
+			roles.Put("/_api/admin/custom-roles/:id", handlers.UpdateRole())
+			roles.Delete("/_api/admin/custom-roles/:id", handlers.DeleteRole())
+			roles.Put("/_api/admin/users/:userID/custom-role", handlers.AssignCustomRole())
+		}
+
+		moderation := ui.Group()
+		{
+			moderation.Use(middlewares.HasPermission(enum.PermissionModerateContent))
+			moderation.Use(middlewares.RequireTwoFactor())
+			moderation.Get("/_api/admin/moderation/items", handlers.GetModerationItemsHandler())
+			moderation.Get("/_api/admin/moderation/count", handlers.GetModerationCountHandler())
+		}
+
+		// Scheduled jobs run for the whole instance, so they can only be managed when it hosts a single site
+		if env.IsSingleHostMode() {
+			jobs := ui.Group()
+			jobs.Use(middlewares.HasPermission(enum.PermissionManageJobs))
+			jobs.Use(middlewares.RequireTwoFactor())
+			jobs.Get("/admin/jobs", handlers.ManageJobs())
+			jobs.Get("/_api/admin/jobs", handlers.ListJobs())
+			jobs.Post("/_api/admin/jobs/:name/run", handlers.RunJob())
+			jobs.Put("/_api/admin/jobs/:name/pause", handlers.PauseJob())
+			jobs.Delete("/_api/admin/jobs/:name/pause", handlers.ResumeJob())
+		}
 
 		if env.IsBillingEnabled() {
-			ui.Get("/admin/billing", handlers.ManageBilling())
-			ui.Post("/_api/admin/billing/portal", handlers.CreateStripePortalSession())
-			ui.Post("/_api/admin/billing/checkout", handlers.CreateStripeCheckoutSession())
+			billing := ui.Group()
+			billing.Use(middlewares.HasPermission(enum.PermissionManageBilling))
+			billing.Use(middlewares.RequireTwoFactor())
+			billing.Get("/admin/billing", handlers.ManageBilling())
+			billing.Post("/_api/admin/billing/portal", handlers.CreateStripePortalSession())
+			billing.Post("/_api/admin/billing/checkout", handlers.CreateStripeCheckoutSession())
 		}
 	}
 
@@ -214,14 +329,18 @@ func routes(r *web.Engine) *web.Engine {
 	// Does not require authentication
 	publicApi := r.Group()
 	{
+		publicApi.Use(middlewares.RateLimit("public_api", env.Config.RateLimit.PublicAPI))
 		publicApi.Get("/api/v1/similarposts", apiv1.FindSimilarPosts())
 		publicApi.Get("/api/v1/posts", apiv1.SearchPosts())
 		publicApi.Get("/api/v1/tags", apiv1.ListTags())
+		publicApi.Get("/api/v1/tag-groups", apiv1.ListTagGroups())
 		publicApi.Get("/api/v1/posts/:number", apiv1.GetPost())
 		publicApi.Get("/api/v1/posts/:number/comments", apiv1.ListComments())
 		publicApi.Get("/api/v1/posts/:number/comments/:id", apiv1.GetComment())
-		publicApi.Get("/api/v1/taggable-users", apiv1.ListTaggableUsers())
 		publicApi.Get("/api/v1/posts/:number/votes", apiv1.ListVotes())
+		publicApi.Get("/api/v1/posts/:number/reactions", apiv1.ListReactions())
+		publicApi.Get("/api/v1/posts/:number/comments/:id/reactions", apiv1.ListReactions())
+		publicApi.Get("/api/v1/posts/:number/files", apiv1.ListFiles())
 	}
 
 	// Operations used to manage the content of a site
@@ -230,64 +349,105 @@ func routes(r *web.Engine) *web.Engine {
 	{
 		membersApi.Use(middlewares.IsAuthenticated())
 		membersApi.Use(middlewares.BlockLockedTenants())
+		membersApi.Use(middlewares.RateLimit("members_api", env.Config.RateLimit.MembersAPI))
 
+		membersApi.Get("/api/v1/taggable-users", apiv1.ListTaggableUsers())
 		membersApi.Post("/api/v1/posts", apiv1.CreatePost())
 		membersApi.Put("/api/v1/posts/:number", apiv1.UpdatePost())
+		membersApi.Post("/api/v1/posts/:number/reactions/:reaction", apiv1.TogglePostReaction())
 		membersApi.Post("/api/v1/posts/:number/comments/:id/reactions/:reaction", apiv1.ToggleReaction())
 		membersApi.Post("/api/v1/posts/:number/comments", apiv1.PostComment())
 		membersApi.Put("/api/v1/posts/:number/comments/:id", apiv1.UpdateComment())
 		membersApi.Delete("/api/v1/posts/:number/comments/:id", apiv1.DeleteComment())
+		membersApi.Post("/api/v1/posts/:number/report", apiv1.ReportContent())
+		membersApi.Post("/api/v1/posts/:number/comments/:id/report", apiv1.ReportContent())
+		membersApi.Post("/api/v1/posts/:number/files", apiv1.UploadFile())
+		membersApi.Delete("/api/v1/posts/:number/files/:id", apiv1.DeleteFile())
 		membersApi.Post("/api/v1/posts/:number/votes", apiv1.AddVote())
 		membersApi.Delete("/api/v1/posts/:number/votes", apiv1.RemoveVote())
 		membersApi.Post("/api/v1/posts/:number/votes/toggle", apiv1.ToggleVote())
 		membersApi.Post("/api/v1/posts/:number/subscription", apiv1.Subscribe())
 		membersApi.Delete("/api/v1/posts/:number/subscription", apiv1.Unsubscribe())
 
-		membersApi.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
+		membersApi.Use(middlewares.HasPermission(enum.PermissionRespondToPosts))
 		membersApi.Put("/api/v1/posts/:number/status", apiv1.SetResponse())
 	}
 
 	// Operations used to manage a site
-	// Available to both collaborators and administrators
+	// Available to members with the corresponding permissions
 	staffApi := r.Group()
 	{
 		staffApi.Use(middlewares.SetLocale("en"))
 		staffApi.Use(middlewares.IsAuthenticated())
-		staffApi.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
+		staffApi.Use(middlewares.RequireTwoFactor())
+
+		users := staffApi.Group()
+		{
+			users.Use(middlewares.HasPermission(enum.PermissionViewUsers))
+			users.Get("/api/v1/users", apiv1.ListUsers())
+		}
+
+		createUsers := staffApi.Group()
+		{
+			createUsers.Use(middlewares.HasPermission(enum.PermissionManageUsers))
+			createUsers.Post("/api/v1/users", apiv1.CreateUser())
+		}
+
+		invitations := staffApi.Group()
+		{
+			invitations.Use(middlewares.HasPermission(enum.PermissionSendInvitations))
+			invitations.Post("/api/v1/invitations/send", apiv1.SendInvites())
+			invitations.Post("/api/v1/invitations/sample", apiv1.SendSampleInvite())
+		}
+
+		audit := staffApi.Group()
+		{
+			audit.Use(middlewares.HasPermission(enum.PermissionViewAuditLog))
+			audit.Get("/api/v1/admin/audit", apiv1.ListAuditLogs())
+		}
 
-		staffApi.Get("/api/v1/users", apiv1.ListUsers())
-		staffApi.Post("/api/v1/invitations/send", apiv1.SendInvites())
-		staffApi.Post("/api/v1/invitations/sample", apiv1.SendSampleInvite())
+		moderation := staffApi.Group()
+		{
+			moderation.Use(middlewares.HasPermission(enum.PermissionModerateContent))
+			moderation.Post("/api/v1/admin/moderation/posts/:id/approve-and-verify", apiv1.GetApprovePostAndVerifyHandler())
+			moderation.Post("/api/v1/admin/moderation/posts/:id/decline-and-block", apiv1.GetDeclinePostAndBlockHandler())
+			moderation.Post("/api/v1/admin/moderation/posts/:id/approve", apiv1.GetApprovePostHandler())
+			moderation.Post("/api/v1/admin/moderation/posts/:id/decline", apiv1.GetDeclinePostHandler())
+			moderation.Post("/api/v1/admin/moderation/comments/:id/approve-and-verify", apiv1.GetApproveCommentAndVerifyHandler())
+			moderation.Post("/api/v1/admin/moderation/comments/:id/decline-and-block", apiv1.GetDeclineCommentAndBlockHandler())
+			moderation.Post("/api/v1/admin/moderation/comments/:id/approve", apiv1.GetApproveCommentHandler())
+			moderation.Post("/api/v1/admin/moderation/comments/:id/decline", apiv1.GetDeclineCommentHandler())
+			moderation.Post("/api/v1/admin/files/:id/release", apiv1.ReleaseFile())
+			moderation.Get("/api/v1/admin/moderation/word-filters", apiv1.ListWordFilters())
+			moderation.Post("/api/v1/admin/moderation/word-filters", apiv1.CreateWordFilter())
+			moderation.Delete("/api/v1/admin/moderation/word-filters/:id", apiv1.DeleteWordFilter())
+		}
+
+		manageTags := staffApi.Group()
+		{
+			manageTags.Use(middlewares.HasPermission(enum.PermissionManageTags))
+			manageTags.Post("/api/v1/tags", apiv1.CreateEditTag())
+			manageTags.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
+			manageTags.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
+			manageTags.Post("/api/v1/tag-groups", apiv1.CreateEditTagGroup())
+			manageTags.Put("/api/v1/tag-groups/:slug", apiv1.CreateEditTagGroup())
+			manageTags.Delete("/api/v1/tag-groups/:slug", apiv1.DeleteTagGroup())
+		}
 
 		staffApi.Use(middlewares.BlockLockedTenants())
-		staffApi.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
-		staffApi.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
-	}
 
-	// Operations used to manage a site
-	// Only available to administrators
-	adminApi := r.Group()
-	{
-		adminApi.Use(middlewares.SetLocale("en"))
-		adminApi.Use(middlewares.IsAuthenticated())
-		adminApi.Use(middlewares.IsAuthorized(enum.RoleAdministrator))
-
-		adminApi.Post("/api/v1/users", apiv1.CreateUser())
-		adminApi.Post("/api/v1/tags", apiv1.CreateEditTag())
-		adminApi.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
-		adminApi.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
-
-		adminApi.Post("/api/v1/admin/moderation/posts/:id/approve-and-verify", apiv1.GetApprovePostAndVerifyHandler())
-		adminApi.Post("/api/v1/admin/moderation/posts/:id/decline-and-block", apiv1.GetDeclinePostAndBlockHandler())
-		adminApi.Post("/api/v1/admin/moderation/posts/:id/approve", apiv1.GetApprovePostHandler())
-		adminApi.Post("/api/v1/admin/moderation/posts/:id/decline", apiv1.GetDeclinePostHandler())
-		adminApi.Post("/api/v1/admin/moderation/comments/:id/approve-and-verify", apiv1.GetApproveCommentAndVerifyHandler())
-		adminApi.Post("/api/v1/admin/moderation/comments/:id/decline-and-block", apiv1.GetDeclineCommentAndBlockHandler())
-		adminApi.Post("/api/v1/admin/moderation/comments/:id/approve", apiv1.GetApproveCommentHandler())
-		adminApi.Post("/api/v1/admin/moderation/comments/:id/decline", apiv1.GetDeclineCommentHandler())
-
-		adminApi.Use(middlewares.BlockLockedTenants())
-		adminApi.Delete("/api/v1/posts/:number", apiv1.DeletePost())
+		assignTags := staffApi.Group()
+		{
+			assignTags.Use(middlewares.HasPermission(enum.PermissionAssignTags))
+			assignTags.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
+			assignTags.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
+		}
+
+		deletePosts := staffApi.Group()
+		{
+			deletePosts.Use(middlewares.HasPermission(enum.PermissionDeletePosts))
+			deletePosts.Delete("/api/v1/posts/:number", apiv1.DeletePost())
+		}
 	}
 
 	return r
@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"path"
 	"syscall"
+	"time"
 
 	"github.com/getfider/fider/app/jobs"
 	"github.com/getfider/fider/app/models/dto"
@@ -14,21 +15,28 @@ import (
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/tracing"
 	"github.com/getfider/fider/app/pkg/web"
-	"github.com/robfig/cron"
 
 	_ "github.com/getfider/fider/app/services/blob/fs"
+	_ "github.com/getfider/fider/app/services/blob/readthrough"
 	_ "github.com/getfider/fider/app/services/blob/s3"
 	_ "github.com/getfider/fider/app/services/blob/sql"
 	_ "github.com/getfider/fider/app/services/email/awsses"
 	_ "github.com/getfider/fider/app/services/email/mailgun"
 	_ "github.com/getfider/fider/app/services/email/noop"
 	_ "github.com/getfider/fider/app/services/email/smtp"
+	_ "github.com/getfider/fider/app/services/filescan/clamav"
+	_ "github.com/getfider/fider/app/services/filescan/noop"
 	_ "github.com/getfider/fider/app/services/httpclient"
 	_ "github.com/getfider/fider/app/services/log/console"
 	_ "github.com/getfider/fider/app/services/log/file"
+	_ "github.com/getfider/fider/app/services/log/gelf"
+	_ "github.com/getfider/fider/app/services/log/http"
 	_ "github.com/getfider/fider/app/services/log/sql"
 	_ "github.com/getfider/fider/app/services/oauth"
+	_ "github.com/getfider/fider/app/services/ratelimit/memory"
+	_ "github.com/getfider/fider/app/services/ratelimit/sql"
 	_ "github.com/getfider/fider/app/services/sqlstore/postgres"
 	_ "github.com/getfider/fider/app/services/userlist"
 	_ "github.com/getfider/fider/app/services/webhook"
@@ -46,21 +54,42 @@ func RunServer() int {
 		})
 	}
 
+	err := tracing.Init(func(err error) {
+		log.Error(ctx, errors.Wrap(err, "failed to export traces"))
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "failed to initialize tracing"))
+	}
+
 	copyEtcFiles(ctx)
 	startJobs(ctx)
 
 	e := routes(web.New())
 	go e.Start(env.Config.Host + ":" + env.Config.Port)
-	return listenSignals(e)
+	exitCode := listenSignals(e)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tracing.Shutdown(shutdownCtx); err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to shutdown tracing"))
+	}
+
+	log.Flush(ctx)
+	return exitCode
 }
 
 // Starts all scheduled jobs
 func startJobs(ctx context.Context) {
-	c := cron.New()
-	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredNotificationsJob", jobs.PurgeExpiredNotificationsJobHandler{}))
-	_ = c.AddJob(jobs.NewJob(ctx, "EmailSupressionJob", jobs.EmailSupressionJobHandler{}))
+	jobs.Register(ctx, "PurgeExpiredNotificationsJob", jobs.PurgeExpiredNotificationsJobHandler{})
+	jobs.Register(ctx, "EmailSupressionJob", jobs.EmailSupressionJobHandler{})
+	if env.Config.Backup.Schedule != "" {
+		jobs.Register(ctx, "BackupJob", jobs.BackupJobHandler{})
+	}
+	if env.Config.BlobGC.Schedule != "" {
+		jobs.Register(ctx, "BlobGCJob", jobs.BlobGCJobHandler{})
+	}
 
-	c.Start()
+	jobs.Start()
 }
 
 // on startup, copy all etc/ files from configured blob storage into local etc/ folder
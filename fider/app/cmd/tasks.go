@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// RunTasks lists the background tasks that failed on all their attempts and won't be retried by the worker
+// Usage: fider tasks [-retry | -purge] [-id=123] [-older-than=720h]
+// Dead tasks are handed back to the queue with -retry, or removed with -purge
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunTasks(args []string) int {
+	flags := flag.NewFlagSet("tasks", flag.ContinueOnError)
+	retry := flags.Bool("retry", false, "hand dead tasks back to the queue, with all their attempts")
+	purge := flags.Bool("purge", false, "remove dead tasks")
+	id := flags.Int64("id", 0, "only the dead task with this id")
+	olderThan := flags.Duration("older-than", 0, "only dead tasks that failed longer than this ago")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 0 || (*retry && *purge) {
+		fmt.Println("Usage: fider tasks [-retry | -purge] [-id=123] [-older-than=720h]")
+		return 1
+	}
+
+	bus.Init()
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       "TASKS",
+		log.PropertyKeyContextID: rand.String(32),
+	})
+	defer log.Flush(ctx)
+
+	filter := worker.DeadTaskFilter{ID: *id, OlderThan: *olderThan}
+
+	if *retry {
+		count, err := worker.RetryDeadTasks(ctx, filter)
+		if err != nil {
+			log.Error(ctx, err)
+			return 1
+		}
+		fmt.Printf("%d dead tasks were handed back to the queue\n", count)
+		return 0
+	}
+
+	if *purge {
+		count, err := worker.PurgeDeadTasks(ctx, filter)
+		if err != nil {
+			log.Error(ctx, err)
+			return 1
+		}
+		fmt.Printf("%d dead tasks were removed\n", count)
+		return 0
+	}
+
+	tasks, err := worker.ListDeadTasks(ctx, filter)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	fmt.Printf("%d dead tasks\n", len(tasks))
+	for _, task := range tasks {
+		tenant := "-"
+		if task.TenantID.Valid {
+			tenant = fmt.Sprintf("%d", task.TenantID.Int64)
+		}
+		fmt.Printf("  #%d  %s  tenant=%s  attempts=%d  failed=%s\n", task.ID, task.Type, tenant, task.Attempts, task.UpdatedAt.Format("2006-01-02 15:04:05"))
+		if task.LastError.Valid {
+			fmt.Printf("      %s\n", task.LastError.String)
+		}
+	}
+	return 0
+}
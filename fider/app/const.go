@@ -36,4 +36,7 @@ var (
 	LocaleCtxKey      = createKey("LOCALE")
 	UserCtxKey        = createKey("USER")
 	LogPropsCtxKey    = createKey("LOG_PROPS")
+
+	TwoFactorVerifiedAtCtxKey = createKey("TWO_FACTOR_VERIFIED_AT")
+	APIKeyOwnerCtxKey         = createKey("API_KEY_OWNER")
 )
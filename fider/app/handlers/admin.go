@@ -7,6 +7,7 @@ import (
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
@@ -28,7 +29,8 @@ func GeneralSettingsPage() web.HandlerFunc {
 func AdvancedSettingsPage() web.HandlerFunc {
 	return func(c *web.Context) error {
 		billingState := &query.GetStripeBillingState{}
-		if err := bus.Dispatch(c, billingState); err != nil {
+		filesUsage := &query.GetFilesUsage{}
+		if err := bus.Dispatch(c, billingState, filesUsage); err != nil {
 			return c.Failure(err)
 		}
 
@@ -38,6 +40,10 @@ func AdvancedSettingsPage() web.HandlerFunc {
 			Data: web.Map{
 				"customCSS":              c.Tenant().CustomCSS,
 				"allowedSchemes":         c.Tenant().AllowedSchemes,
+				"allowedFileTypes":       c.Tenant().AllowedFileTypes,
+				"fileQuotaMB":            c.Tenant().FileQuotaMB,
+				"reactionEmojis":         c.Tenant().ReactionEmojis,
+				"filesUsage":             filesUsage.Result,
 				"licenseKey":             billingState.Result.LicenseKey,
 				"hasCommercialFeatures": c.Tenant().HasCommercialFeatures,
 			},
@@ -53,6 +59,16 @@ func UpdateSettings() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		tenant := c.Tenant()
+		before := dto.Props{
+			"title":          tenant.Name,
+			"invitation":     tenant.Invitation,
+			"welcomeMessage": tenant.WelcomeMessage,
+			"welcomeHeader":  tenant.WelcomeHeader,
+			"cname":          tenant.CNAME,
+			"locale":         tenant.Locale,
+		}
+
 		if err := bus.Dispatch(c,
 			&cmd.UploadImage{
 				Image:  action.Logo,
@@ -67,6 +83,22 @@ func UpdateSettings() web.HandlerFunc {
 				CNAME:          action.CNAME,
 				Locale:         action.Locale,
 			},
+			&cmd.AddAuditLog{
+				Action:     enum.AuditGeneralSettingsUpdated,
+				TargetType: "tenant",
+				TargetID:   tenant.ID,
+				TargetName: tenant.Name,
+				Before:     before,
+				After: dto.Props{
+					"title":          action.Title,
+					"invitation":     action.Invitation,
+					"welcomeMessage": action.WelcomeMessage,
+					"welcomeHeader":  action.WelcomeHeader,
+					"cname":          action.CNAME,
+					"locale":         action.Locale,
+					"logoChanged":    action.Logo != nil && (action.Logo.Upload != nil || action.Logo.Remove),
+				},
+			},
 		); err != nil {
 			return c.Failure(err)
 		}
@@ -91,10 +123,35 @@ func UpdateAdvancedSettings() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		tenant := c.Tenant()
+		auditLog := &cmd.AddAuditLog{
+			Action:     enum.AuditAdvancedSettingsUpdated,
+			TargetType: "tenant",
+			TargetID:   tenant.ID,
+			TargetName: tenant.Name,
+			Before: dto.Props{
+				"customCSS":        tenant.CustomCSS,
+				"allowedSchemes":   tenant.AllowedSchemes,
+				"allowedFileTypes": tenant.AllowedFileTypes,
+				"fileQuotaMB":      tenant.FileQuotaMB,
+				"reactionEmojis":   tenant.ReactionEmojis,
+			},
+			After: dto.Props{
+				"customCSS":        action.CustomCSS,
+				"allowedSchemes":   action.AllowedSchemes,
+				"allowedFileTypes": action.AllowedFileTypes,
+				"fileQuotaMB":      action.FileQuotaMB,
+				"reactionEmojis":   action.ReactionEmojis,
+			},
+		}
+
 		if err := bus.Dispatch(c, &cmd.UpdateTenantAdvancedSettings{
-			CustomCSS:      action.CustomCSS,
-			AllowedSchemes: action.AllowedSchemes,
-		}); err != nil {
+			CustomCSS:        action.CustomCSS,
+			AllowedSchemes:   action.AllowedSchemes,
+			AllowedFileTypes: action.AllowedFileTypes,
+			FileQuotaMB:      action.FileQuotaMB,
+			ReactionEmojis:   action.ReactionEmojis,
+		}, auditLog); err != nil {
 			return c.Failure(err)
 		}
 
@@ -110,12 +167,59 @@ func UpdatePrivacySettings() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		tenant := c.Tenant()
+		auditLog := &cmd.AddAuditLog{
+			Action:     enum.AuditPrivacySettingsUpdated,
+			TargetType: "tenant",
+			TargetID:   tenant.ID,
+			TargetName: tenant.Name,
+			Before: dto.Props{
+				"isPrivate":           tenant.IsPrivate,
+				"isFeedEnabled":       tenant.IsFeedEnabled,
+				"isModerationEnabled": tenant.IsModerationEnabled,
+			},
+			After: dto.Props{
+				"isPrivate":           action.IsPrivate,
+				"isFeedEnabled":       action.IsFeedEnabled,
+				"isModerationEnabled": action.IsModerationEnabled,
+			},
+		}
+
 		updateSettings := &cmd.UpdateTenantPrivacySettings{
 			IsPrivate:           action.IsPrivate,
 			IsFeedEnabled:       action.IsFeedEnabled,
 			IsModerationEnabled: action.IsModerationEnabled,
 		}
-		if err := bus.Dispatch(c, updateSettings); err != nil {
+		if err := bus.Dispatch(c, updateSettings, auditLog); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// UpdateTwoFactorRequired update current tenant's requirement of two-factor authentication for staff members
+func UpdateTwoFactorRequired() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateTenantTwoFactorRequired)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		tenant := c.Tenant()
+		auditLog := &cmd.AddAuditLog{
+			Action:     enum.AuditTwoFactorSettingsUpdated,
+			TargetType: "tenant",
+			TargetID:   tenant.ID,
+			TargetName: tenant.Name,
+			Before:     dto.Props{"isTwoFactorRequired": tenant.IsTwoFactorRequired},
+			After:      dto.Props{"isTwoFactorRequired": action.IsTwoFactorRequired},
+		}
+
+		updateSettings := &cmd.UpdateTenantTwoFactorSettings{
+			IsTwoFactorRequired: action.IsTwoFactorRequired,
+		}
+		if err := bus.Dispatch(c, updateSettings, auditLog); err != nil {
 			return c.Failure(err)
 		}
 
@@ -131,10 +235,20 @@ func UpdateEmailAuthAllowed() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		tenant := c.Tenant()
+		auditLog := &cmd.AddAuditLog{
+			Action:     enum.AuditEmailAuthSettingsUpdated,
+			TargetType: "tenant",
+			TargetID:   tenant.ID,
+			TargetName: tenant.Name,
+			Before:     dto.Props{"isEmailAuthAllowed": tenant.IsEmailAuthAllowed},
+			After:      dto.Props{"isEmailAuthAllowed": action.IsEmailAuthAllowed},
+		}
+
 		updateSettings := &cmd.UpdateTenantEmailAuthAllowedSettings{
 			IsEmailAuthAllowed: action.IsEmailAuthAllowed,
 		}
-		if err := bus.Dispatch(c, updateSettings); err != nil {
+		if err := bus.Dispatch(c, updateSettings, auditLog); err != nil {
 			return c.Failure(err)
 		}
 
@@ -158,7 +272,8 @@ func ManageMembers() web.HandlerFunc {
 			Limit: 10,
 		}
 
-		if err := bus.Dispatch(c, searchUsers); err != nil {
+		listRoles := &query.ListRoles{}
+		if err := bus.Dispatch(c, searchUsers, listRoles); err != nil {
 			return c.Failure(err)
 		}
 
@@ -175,6 +290,7 @@ func ManageMembers() web.HandlerFunc {
 			Title: "Manage Members · Site Settings",
 			Data: web.Map{
 				"users":      allUsersWithEmail,
+				"roles":      listRoles.Result,
 				"totalPages": (searchUsers.TotalCount + 10 - 1) / 10,
 			},
 		})
@@ -243,6 +359,23 @@ func SaveOAuthConfig() web.HandlerFunc {
 				JSONUserNamePath:  action.JSONUserNamePath,
 				JSONUserEmailPath: action.JSONUserEmailPath,
 			},
+			&cmd.AddAuditLog{
+				Action:     enum.AuditOAuthConfigSaved,
+				TargetType: "oauth_provider",
+				TargetID:   action.ID,
+				TargetName: action.DisplayName,
+				After: dto.Props{
+					"provider":     action.Provider,
+					"status":       action.Status,
+					"displayName":  action.DisplayName,
+					"clientId":     action.ClientID,
+					"authorizeURL": action.AuthorizeURL,
+					"tokenURL":     action.TokenURL,
+					"profileURL":   action.ProfileURL,
+					"scope":        action.Scope,
+					"isTrusted":    action.IsTrusted,
+				},
+			},
 		); err != nil {
 			return c.Failure(err)
 		}
@@ -262,6 +395,11 @@ func SetSystemProviderStatus() web.HandlerFunc {
 		if err := bus.Dispatch(c, &cmd.SetTenantProviderStatus{
 			Provider:  action.Provider,
 			IsEnabled: action.IsEnabled,
+		}, &cmd.AddAuditLog{
+			Action:     enum.AuditOAuthProviderStatusChanged,
+			TargetType: "oauth_provider",
+			TargetName: action.Provider,
+			After:      dto.Props{"isEnabled": action.IsEnabled},
 		}); err != nil {
 			return c.Failure(err)
 		}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -36,6 +37,12 @@ func TestUpdateSettingsHandler(t *testing.T) {
 		return nil
 	})
 
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
 	server := mock.NewServer()
 	mock.DemoTenant.LogoBlobKey = "logos/hello-world.png"
 
@@ -50,6 +57,10 @@ func TestUpdateSettingsHandler(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 	ExpectHandler(&cmd.UpdateTenantSettings{}).CalledOnce()
 	ExpectHandler(&cmd.UploadImage{}).CalledOnce()
+	ExpectHandler(&cmd.AddAuditLog{}).CalledOnce()
+	Expect(auditLog.Action).Equals(enum.AuditGeneralSettingsUpdated)
+	Expect(auditLog.TargetID).Equals(mock.DemoTenant.ID)
+	Expect(auditLog.After["title"]).Equals("GoT")
 }
 
 func TestUpdateSettingsHandler_NewLogo(t *testing.T) {
@@ -70,6 +81,10 @@ func TestUpdateSettingsHandler_NewLogo(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		return nil
+	})
+
 	logoBytes, _ := os.ReadFile(env.Etc("logo.png"))
 	logoB64 := base64.StdEncoding.EncodeToString(logoBytes)
 
@@ -113,6 +128,10 @@ func TestUpdateSettingsHandler_RemoveLogo(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	mock.DemoTenant.LogoBlobKey = "logos/hello-world.png"
 
@@ -144,6 +163,12 @@ func TestUpdatePrivacySettingsHandler(t *testing.T) {
 		return nil
 	})
 
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, _ := server.
 		OnTenant(mock.DemoTenant).
@@ -156,6 +181,8 @@ func TestUpdatePrivacySettingsHandler(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 	Expect(updateCmd.IsPrivate).IsTrue()
 	Expect(updateCmd.IsFeedEnabled).IsFalse()
+	Expect(auditLog.Action).Equals(enum.AuditPrivacySettingsUpdated)
+	Expect(auditLog.After["isPrivate"]).IsTrue()
 
 	server = mock.NewServer()
 	code, _ = server.
@@ -207,6 +234,11 @@ func TestManageMembersHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.ListRoles) error {
+		q.Result = []*entity.Role{}
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, _ := server.
 		OnTenant(mock.DemoTenant).
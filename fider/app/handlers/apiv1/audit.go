@@ -0,0 +1,50 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListAuditLogs returns a paginated list of administrative actions
+func ListAuditLogs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		page, _ := c.QueryParamAsInt("page")
+		if page <= 0 {
+			page = 1
+		}
+
+		limit, _ := c.QueryParamAsInt("limit")
+		if limit <= 0 {
+			limit = 50
+		} else if limit > 100 {
+			limit = 100
+		}
+
+		actorID, _ := c.QueryParamAsInt("actor")
+		targetID, _ := c.QueryParamAsInt("targetId")
+
+		searchAuditLogs := &query.SearchAuditLogs{
+			Action:     enum.AuditAction(c.QueryParam("action")),
+			ActorID:    actorID,
+			TargetType: c.QueryParam("targetType"),
+			TargetID:   targetID,
+			Page:       page,
+			Limit:      limit,
+		}
+		searchAuditLogs.SetPeriodFromStrings(c.QueryParam("since"), c.QueryParam("until"))
+
+		if err := bus.Dispatch(c, searchAuditLogs); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"auditLogs":  searchAuditLogs.Result,
+			"totalCount": searchAuditLogs.TotalCount,
+			"totalPages": (searchAuditLogs.TotalCount + limit - 1) / limit,
+			"page":       page,
+			"limit":      limit,
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package apiv1
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/services/blob"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// ListFiles returns the files attached to a post and its comments.
+// Quarantined files are only listed to their uploader and moderators, files of deleted posts are not listed
+func ListFiles() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+		if getPost.Result.Status == enum.PostDeleted {
+			return c.NotFound()
+		}
+
+		listFiles := &query.ListPostFiles{PostID: getPost.Result.ID}
+		if err := bus.Dispatch(c, listFiles); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(visibleFiles(c.User(), listFiles.Result))
+	}
+}
+
+func visibleFiles(user *entity.User, files []*entity.File) []*entity.File {
+	result := make([]*entity.File, 0, len(files))
+	for _, file := range files {
+		if file.Status != enum.FileStatusQuarantined {
+			result = append(result, file)
+		} else if user != nil && (user.ID == file.UserID || user.HasPermission(enum.PermissionModerateContent)) {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// UploadFile attaches a new file to a post or comment. Files can only be downloaded after they're cleared by the scanner
+func UploadFile() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UploadFile)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		file := &entity.File{
+			PostID:      action.Post.ID,
+			Name:        action.File.FileName,
+			ContentType: action.File.ContentType,
+			Size:        int64(len(action.File.Content)),
+			BlobKey:     fmt.Sprintf("files/%s-%s", rand.String(64), blob.SanitizeFileName(action.File.FileName)),
+		}
+		if action.Comment != nil {
+			file.CommentID = action.Comment.ID
+		}
+
+		err := bus.Dispatch(c, &cmd.StoreBlob{
+			Key:         file.BlobKey,
+			Content:     action.File.Content,
+			ContentType: file.ContentType,
+		}, &cmd.AddFile{File: file})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.ScanFile(file))
+
+		return c.Ok(file)
+	}
+}
+
+// DeleteFile removes a file from its post or comment
+func DeleteFile() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteFile)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteFile{FileID: action.File.ID}, &cmd.DeleteBlob{Key: action.File.BlobKey})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ReleaseFile clears a quarantined file that was wrongly flagged by the scanner
+func ReleaseFile() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getFile := &query.GetFileByID{FileID: id}
+		if err := bus.Dispatch(c, getFile); err != nil {
+			return c.Failure(err)
+		}
+
+		file := getFile.Result
+		if file.Status != enum.FileStatusQuarantined {
+			return c.HandleValidation(validate.Failed("Only quarantined files can be released."))
+		}
+
+		err = bus.Dispatch(c, &cmd.SetFileStatus{
+			FileID:     file.ID,
+			Status:     enum.FileStatusClean,
+			ScanResult: file.ScanResult,
+		}, &cmd.AddAuditLog{
+			Action:     enum.AuditFileReleased,
+			TargetType: "file",
+			TargetID:   file.ID,
+			TargetName: file.Name,
+			Before:     dto.Props{"status": file.Status, "scanResult": file.ScanResult},
+			After:      dto.Props{"status": enum.FileStatusClean},
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,175 @@
+package apiv1_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestUploadFileHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, User: mock.AryaStark}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetFilesUsage) error {
+		q.Result = 0
+		return nil
+	})
+
+	var storeBlob *cmd.StoreBlob
+	bus.AddHandler(func(ctx context.Context, c *cmd.StoreBlob) error {
+		storeBlob = c
+		return nil
+	})
+
+	var addFile *cmd.AddFile
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddFile) error {
+		addFile = c
+		c.File.ID = 5
+		c.File.Status = enum.FileStatusPending
+		return nil
+	})
+
+	content := base64.StdEncoding.EncodeToString([]byte("2025-12-01 ERROR failed"))
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		ExecutePostAsJSON(apiv1.UploadFile(), fmt.Sprintf(`{ "file": { "fileName": "My App.log", "contentType": "text/html", "content": "%s" } }`, content))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(json.Int32("id")).Equals(5)
+	Expect(json.String("name")).Equals("My App.log")
+	Expect(json.String("contentType")).Equals("text/plain")
+	Expect(json.String("status")).Equals("pending")
+	Expect(json.Contains("blobKey")).IsFalse()
+
+	Expect(strings.HasPrefix(storeBlob.Key, "files/")).IsTrue()
+	Expect(strings.HasSuffix(storeBlob.Key, "-my-app.log")).IsTrue()
+	Expect(storeBlob.ContentType).Equals("text/plain")
+	Expect(addFile.File.BlobKey).Equals(storeBlob.Key)
+	Expect(addFile.File.PostID).Equals(post.ID)
+	Expect(addFile.File.Size).Equals(int64(23))
+}
+
+func TestUploadFileHandler_NotAllowed(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, User: mock.AryaStark}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetFilesUsage) error {
+		q.Result = 0
+		return nil
+	})
+
+	content := base64.StdEncoding.EncodeToString([]byte("MZ\x90\x00"))
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.UploadFile(), fmt.Sprintf(`{ "file": { "fileName": "setup.exe", "content": "%s" } }`, content))
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectHandler(&cmd.StoreBlob{}).CalledTimes(0)
+	ExpectHandler(&cmd.AddFile{}).CalledTimes(0)
+}
+
+func TestListFilesHandler_HidesQuarantinedFiles(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostFiles) error {
+		q.Result = []*entity.File{
+			{ID: 1, Name: "app.log", Status: enum.FileStatusClean, UserID: mock.JonSnow.ID},
+			{ID: 2, Name: "eicar.txt", Status: enum.FileStatusQuarantined, UserID: mock.AryaStark.ID},
+			{ID: 3, Name: "repro.csv", Status: enum.FileStatusPending, UserID: mock.JonSnow.ID},
+		}
+		return nil
+	})
+
+	list := func(user *entity.User) int {
+		server := mock.NewServer().OnTenant(mock.DemoTenant).AddParam("number", 1)
+		if user != nil {
+			server.AsUser(user)
+		}
+		code, json := server.ExecuteAsJSON(apiv1.ListFiles())
+		Expect(code).Equals(http.StatusOK)
+		return json.ArrayLength()
+	}
+
+	Expect(list(nil)).Equals(2)
+	Expect(list(mock.AryaStark)).Equals(3)
+	Expect(list(mock.JonSnow)).Equals(3)
+}
+
+func TestListFilesHandler_DeletedPost(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Status: enum.PostDeleted}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		ExecuteAsJSON(apiv1.ListFiles())
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestReleaseFileHandler(t *testing.T) {
+	RegisterT(t)
+
+	file := &entity.File{ID: 2, Name: "eicar.txt", Status: enum.FileStatusQuarantined, ScanResult: "Eicar-Test-Signature"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetFileByID) error {
+		q.Result = file
+		return nil
+	})
+
+	var setStatus *cmd.SetFileStatus
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetFileStatus) error {
+		setStatus = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", file.ID).
+		ExecutePost(apiv1.ReleaseFile(), "")
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setStatus.Status).Equals(enum.FileStatusClean)
+	Expect(setStatus.ScanResult).Equals("Eicar-Test-Signature")
+	ExpectHandler(&cmd.AddAuditLog{}).CalledOnce()
+
+	file.Status = enum.FileStatusClean
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", file.ID).
+		ExecutePost(apiv1.ReleaseFile(), "")
+	Expect(code).Equals(http.StatusBadRequest)
+}
@@ -4,11 +4,14 @@ import (
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/markdown"
+	"github.com/getfider/fider/app/pkg/spam"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/getfider/fider/app/tasks"
 )
@@ -25,6 +28,7 @@ func SearchPosts() web.HandlerFunc {
 			View:             viewQueryParams,
 			Limit:            c.QueryParam("limit"),
 			Tags:             c.QueryParamAsArray("tags"),
+			TagGroups:        c.QueryParamAsArray("taggroups"),
 			ModerationFilter: c.QueryParam("moderation"),
 		}
 		if myVotesOnly, err := c.QueryParamAsBool("myvotes"); err == nil {
@@ -71,11 +75,18 @@ func CreatePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		spamScore, err := spam.Evaluate(c, action.Title, action.Description, c.User(), c.Request.ClientIP)
+		if err != nil {
+			return c.Failure(err)
+		}
+		spamScore = spam.Hold(spamScore, action.Title, action.Description, c.Request.ClientIP, action.HeldBy)
+
 		newPost := &cmd.AddNewPost{
 			Title:       action.Title,
 			Description: action.Description,
+			SpamScore:   spamScore,
 		}
-		err := bus.Dispatch(c, newPost)
+		err = bus.Dispatch(c, newPost)
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -95,7 +106,10 @@ func CreatePost() web.HandlerFunc {
 			}
 		}
 
-		c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
+		// Content held because of its spam score doesn't notify anyone
+		if newPost.Result.IsApproved || spamScore == nil || !spamScore.IsFlagged {
+			c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
+		}
 
 		metrics.TotalPosts.Inc()
 		return c.Ok(web.Map{
@@ -121,6 +135,10 @@ func GetPost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		if err := markdown.ResolveMentions(c, &getPost.Result.Description); err != nil {
+			return c.Failure(err)
+		}
+
 		return c.Ok(getPost.Result)
 	}
 }
@@ -137,6 +155,7 @@ func UpdatePost() web.HandlerFunc {
 			Post:        action.Post,
 			Title:       action.Title,
 			Description: action.Description,
+			SpamScore:   spam.Hold(nil, action.Title, action.Description, c.Request.ClientIP, action.HeldBy),
 		}
 
 		err := bus.Dispatch(c,
@@ -155,8 +174,10 @@ func UpdatePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		// Notify about mentions in the updated post
-		c.Enqueue(tasks.NotifyAboutUpdatedPost(updatePost.Result))
+		// Notify about mentions in the updated post, unless a word filter held it for moderation
+		if len(action.HeldBy) == 0 {
+			c.Enqueue(tasks.NotifyAboutUpdatedPost(updatePost.Result))
+		}
 
 		return c.Ok(web.Map{})
 	}
@@ -210,6 +231,13 @@ func DeletePost() web.HandlerFunc {
 			Post:   action.Post,
 			Text:   action.Text,
 			Status: enum.PostDeleted,
+		}, &cmd.AddAuditLog{
+			Action:     enum.AuditPostDeleted,
+			TargetType: "post",
+			TargetID:   action.Post.ID,
+			TargetName: action.Post.Title,
+			Before:     dto.Props{"status": action.Post.Status},
+			After:      dto.Props{"status": enum.PostDeleted, "reason": action.Text},
 		})
 		if err != nil {
 			return c.Failure(err)
@@ -234,11 +262,19 @@ func ListComments() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		getComments := &query.GetCommentsByPost{Post: getPost.Result, IncludeRemovedParents: true}
 		if err := bus.Dispatch(c, getComments); err != nil {
 			return c.Failure(err)
 		}
 
+		contents := make([]*string, len(getComments.Result))
+		for i, comment := range getComments.Result {
+			contents[i] = &comment.Content
+		}
+		if err := markdown.ResolveMentions(c, contents...); err != nil {
+			return c.Failure(err)
+		}
+
 		for _, comment := range getComments.Result {
 			commentString := entity.CommentString(comment.Content)
 			comment.Content = commentString.SanitizeMentions()
@@ -261,6 +297,10 @@ func GetComment() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		if err := markdown.ResolveMentions(c, &commentByID.Result.Content); err != nil {
+			return c.Failure(err)
+		}
+
 		commentString := entity.CommentString(commentByID.Result.Content)
 		commentByID.Result.Content = commentString.SanitizeMentions()
 
@@ -296,6 +336,69 @@ func ToggleReaction() web.HandlerFunc {
 	}
 }
 
+// TogglePostReaction adds or removes a reaction on a post
+func TogglePostReaction() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.TogglePostReaction)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		getPost := &query.GetPostByNumber{Number: action.Number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		toggleReaction := &cmd.TogglePostReaction{
+			Post:  getPost.Result,
+			Emoji: action.Reaction,
+			User:  c.User(),
+		}
+		if err := bus.Dispatch(c, toggleReaction); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"added": toggleReaction.Result,
+		})
+	}
+}
+
+// ListReactions returns who reacted to a post, or to one of its comments, and with what
+func ListReactions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		listReactions := &query.ListReactions{PostID: getPost.Result.ID}
+		if c.Param("id") != "" {
+			commentID, err := c.ParamAsInt("id")
+			if err != nil {
+				return c.NotFound()
+			}
+
+			getComment := &query.GetCommentByID{CommentID: commentID}
+			if err := bus.Dispatch(c, getComment); err != nil {
+				return c.Failure(err)
+			}
+			listReactions.CommentID = getComment.Result.ID
+		}
+
+		if err := bus.Dispatch(c, listReactions); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(listReactions.Result)
+	}
+}
+
 // PostComment creates a new comment on given post
 func PostComment() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -313,9 +416,17 @@ func PostComment() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		spamScore, err := spam.Evaluate(c, "", action.Content, c.User(), c.Request.ClientIP)
+		if err != nil {
+			return c.Failure(err)
+		}
+		spamScore = spam.Hold(spamScore, "", action.Content, c.Request.ClientIP, action.HeldBy)
+
 		addNewComment := &cmd.AddNewComment{
-			Post:    getPost.Result,
-			Content: action.Content,
+			Post:      getPost.Result,
+			Content:   action.Content,
+			ParentID:  action.ParentID,
+			SpamScore: spamScore,
 		}
 		if err := bus.Dispatch(c, addNewComment); err != nil {
 			return c.Failure(err)
@@ -332,11 +443,15 @@ func PostComment() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		c.Enqueue(tasks.NotifyAboutNewComment(addNewComment.Result, getPost.Result))
+		// Content held because of its spam score doesn't notify anyone
+		if addNewComment.Result.IsApproved || spamScore == nil || !spamScore.IsFlagged {
+			c.Enqueue(tasks.NotifyAboutNewComment(addNewComment.Result, getPost.Result))
+		}
 
 		metrics.TotalComments.Inc()
 		return c.Ok(web.Map{
-			"id": addNewComment.Result.ID,
+			"id":         addNewComment.Result.ID,
+			"isApproved": addNewComment.Result.IsApproved,
 		})
 	}
 }
@@ -367,6 +482,7 @@ func UpdateComment() web.HandlerFunc {
 			&cmd.UpdateComment{
 				CommentID: action.ID,
 				Content:   action.Content,
+				SpamScore: spam.Hold(nil, "", action.Content, c.Request.ClientIP, action.HeldBy),
 			},
 			&cmd.SetAttachments{
 				Post:        action.Post,
@@ -378,9 +494,10 @@ func UpdateComment() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		// Update the content
-
-		c.Enqueue(tasks.NotifyAboutUpdatedComment(getPost.Result, comment))
+		// Notify about mentions in the updated comment, unless a word filter held it for moderation
+		if len(action.HeldBy) == 0 {
+			c.Enqueue(tasks.NotifyAboutUpdatedComment(getPost.Result, comment))
+		}
 
 		return c.Ok(web.Map{})
 	}
@@ -506,7 +623,7 @@ func ListVotes() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		includeEmail := c.User() != nil && c.User().IsCollaborator()
+		includeEmail := c.User() != nil && c.User().HasPermission(enum.PermissionViewUsers)
 		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID, IncludeEmail: includeEmail}
 		if err := bus.Dispatch(c, listVotes); err != nil {
 			return c.Failure(err)
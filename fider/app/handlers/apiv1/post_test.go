@@ -120,6 +120,8 @@ func TestCreatePostHandler_WithPublicTagAsVisitor(t *testing.T) {
 	if env.Config.PostCreationWithTagsEnabled {
 		RegisterT(t)
 
+		bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 		var newPost *cmd.AddNewPost
 		bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
 			newPost = c
@@ -159,6 +161,10 @@ func TestCreatePostHandler_WithPublicTagAsVisitor(t *testing.T) {
 		bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
 		bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error { return nil })
 		bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
+		bus.AddHandler(func(ctx context.Context, q *query.GetSpamSignals) error {
+			q.Result = &entity.SpamSignals{AccountCreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+			return nil
+		})
 
 		code, _ := mock.NewServer().
 			OnTenant(mock.DemoTenant).
@@ -359,6 +365,8 @@ func TestUpdatePostHandler_IsOwner_AfterGracePeriod(t *testing.T) {
 func TestUpdatePostHandler_IsOwner_WithinGracePeriod(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	post := &entity.Post{
 		ID:          5,
 		Number:      5,
@@ -682,6 +690,10 @@ func TestDeletePostHandler_Authorized(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		return nil
+	})
+
 	code, _ := mock.NewServer().
 		OnTenant(mock.DemoTenant).
 		AsUser(mock.JonSnow).
@@ -692,6 +704,7 @@ func TestDeletePostHandler_Authorized(t *testing.T) {
 	Expect(deletePost.Post).Equals(post)
 	Expect(deletePost.Status).Equals(enum.PostDeleted)
 	Expect(deletePost.Text).Equals("")
+	ExpectHandler(&cmd.AddAuditLog{}).CalledOnce()
 }
 
 func TestPostCommentHandler(t *testing.T) {
@@ -775,6 +788,8 @@ func TestPostCommentHandler_WithoutContent(t *testing.T) {
 func TestUpdateCommentHandler_Authorized(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	server := mock.NewServer()
 
 	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
@@ -922,6 +937,16 @@ func TestCommentReactionToggleHandler_InvalidEmoji(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		q.Result = []*entity.Reaction{}
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, c *cmd.ToggleCommentReaction) error {
 		return nil
 	})
@@ -937,6 +962,50 @@ func TestCommentReactionToggleHandler_InvalidEmoji(t *testing.T) {
 	Expect(code).Equals(http.StatusBadRequest)
 }
 
+func TestCommentReactionToggleHandler_RemovesReactionNoLongerOnPalette(t *testing.T) {
+	RegisterT(t)
+
+	comment := &entity.Comment{ID: 5, Content: "Old comment text", User: mock.AryaStark}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = comment
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1}
+		return nil
+	})
+
+	var listReactions *query.ListReactions
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		listReactions = q
+		q.Result = []*entity.Reaction{{ID: 1, Emoji: "👍", User: mock.AryaStark}}
+		return nil
+	})
+
+	var toggleReaction *cmd.ToggleCommentReaction
+	bus.AddHandler(func(ctx context.Context, c *cmd.ToggleCommentReaction) error {
+		toggleReaction = c
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.ReactionEmojis = "🎉 🚀"
+
+	code, _ := mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("id", comment.ID).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.ToggleReaction(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listReactions.PostID).Equals(1)
+	Expect(listReactions.CommentID).Equals(comment.ID)
+	Expect(toggleReaction.Emoji).Equals("👍")
+}
+
 func TestCommentReactionToggleHandler_UnAuthorised(t *testing.T) {
 	RegisterT(t)
 
@@ -981,3 +1050,143 @@ func TestCommentReactionToggleHandler_MismatchingTenantAndComment(t *testing.T)
 
 	Expect(code).Equals(http.StatusNotFound)
 }
+
+func TestPostReactionToggleHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var toggleReaction *cmd.TogglePostReaction
+	bus.AddHandler(func(ctx context.Context, c *cmd.TogglePostReaction) error {
+		toggleReaction = c
+		c.Result = true
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		AddParam("reaction", "🎉").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(toggleReaction.Post).Equals(post)
+	Expect(toggleReaction.Emoji).Equals("🎉")
+	Expect(toggleReaction.User).Equals(mock.JonSnow)
+}
+
+func TestPostReactionToggleHandler_EmojiNotOnTenantPalette(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		q.Result = []*entity.Reaction{{ID: 1, Emoji: "👍", User: mock.AryaStark}}
+		return nil
+	})
+
+	toggled := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.TogglePostReaction) error {
+		toggled = true
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.ReactionEmojis = "🎉 🚀"
+
+	code, _ := mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(toggled).IsFalse()
+}
+
+func TestPostReactionToggleHandler_RemovesReactionNoLongerOnPalette(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		q.Result = []*entity.Reaction{{ID: 1, Emoji: "👍", User: mock.JonSnow}}
+		return nil
+	})
+
+	var toggleReaction *cmd.TogglePostReaction
+	bus.AddHandler(func(ctx context.Context, c *cmd.TogglePostReaction) error {
+		toggleReaction = c
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.ReactionEmojis = "🎉 🚀"
+
+	code, _ := mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(toggleReaction.Emoji).Equals("👍")
+}
+
+func TestListReactionsHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 4, Number: 1}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = &entity.Comment{ID: q.CommentID}
+		return nil
+	})
+
+	var listReactions *query.ListReactions
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		listReactions = q
+		q.Result = []*entity.Reaction{
+			{ID: 1, Emoji: "👍", User: mock.AryaStark},
+			{ID: 2, Emoji: "🎉", User: mock.JonSnow},
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListReactions())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.ArrayLength()).Equals(2)
+	Expect(listReactions.PostID).Equals(post.ID)
+	Expect(listReactions.CommentID).Equals(0)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", post.Number).
+		AddParam("id", 7).
+		ExecuteAsJSON(apiv1.ListReactions())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listReactions.PostID).Equals(post.ID)
+	Expect(listReactions.CommentID).Equals(7)
+}
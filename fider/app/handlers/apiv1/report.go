@@ -0,0 +1,33 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ReportContent reports a post, or one of its comments, to the moderators
+func ReportContent() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.ReportContent)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addReport := &cmd.AddContentReport{
+			Post:    action.Post,
+			Comment: action.Comment,
+			Reason:  action.Reason,
+			Details: action.Details,
+		}
+		if err := bus.Dispatch(c, addReport); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"id":       addReport.Result.ID,
+			"isHidden": addReport.IsHidden,
+		})
+	}
+}
@@ -3,6 +3,9 @@ package apiv1
 import (
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/web"
@@ -68,6 +71,7 @@ func CreateEditTag() web.HandlerFunc {
 				Name:     action.Name,
 				Color:    action.Color,
 				IsPublic: action.IsPublic,
+				GroupID:  action.GroupID,
 			}
 			if err := bus.Dispatch(c, updateTag); err != nil {
 				return c.Failure(err)
@@ -79,6 +83,7 @@ func CreateEditTag() web.HandlerFunc {
 			Name:     action.Name,
 			Color:    action.Color,
 			IsPublic: action.IsPublic,
+			GroupID:  action.GroupID,
 		}
 		if err := bus.Dispatch(c, addNewTag); err != nil {
 			return c.Failure(err)
@@ -95,7 +100,18 @@ func DeleteTag() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
-		err := bus.Dispatch(c, &cmd.DeleteTag{Tag: action.Tag})
+		err := bus.Dispatch(c, &cmd.DeleteTag{Tag: action.Tag}, &cmd.AddAuditLog{
+			Action:     enum.AuditTagDeleted,
+			TargetType: "tag",
+			TargetID:   action.Tag.ID,
+			TargetName: action.Tag.Name,
+			Before: dto.Props{
+				"name":     action.Tag.Name,
+				"slug":     action.Tag.Slug,
+				"color":    action.Tag.Color,
+				"isPublic": action.Tag.IsPublic,
+			},
+		})
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -103,3 +119,103 @@ func DeleteTag() web.HandlerFunc {
 		return c.Ok(web.Map{})
 	}
 }
+
+// ListTagGroups returns all tag groups visible to current user
+func ListTagGroups() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllTagGroups{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditTagGroup creates a new tag group or changes an existing one
+func CreateEditTagGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditTagGroup)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Group != nil {
+			updateGroup := &cmd.UpdateTagGroup{
+				GroupID:      action.Group.ID,
+				Name:         action.Name,
+				Selection:    action.Selection,
+				VisibleTo:    action.VisibleTo,
+				AssignableBy: action.AssignableBy,
+			}
+			if err := bus.Dispatch(c, updateGroup); err != nil {
+				return c.Failure(err)
+			}
+
+			if err := bus.Dispatch(c, &cmd.AddAuditLog{
+				Action:     enum.AuditTagGroupUpdated,
+				TargetType: "tag_group",
+				TargetID:   updateGroup.Result.ID,
+				TargetName: updateGroup.Result.Name,
+				Before:     tagGroupAuditProps(action.Group),
+				After:      tagGroupAuditProps(updateGroup.Result),
+			}); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateGroup.Result)
+		}
+
+		addNewGroup := &cmd.AddNewTagGroup{
+			Name:         action.Name,
+			Selection:    action.Selection,
+			VisibleTo:    action.VisibleTo,
+			AssignableBy: action.AssignableBy,
+		}
+		if err := bus.Dispatch(c, addNewGroup); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditTagGroupCreated,
+			TargetType: "tag_group",
+			TargetID:   addNewGroup.Result.ID,
+			TargetName: addNewGroup.Result.Name,
+			After:      tagGroupAuditProps(addNewGroup.Result),
+		}); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewGroup.Result)
+	}
+}
+
+// DeleteTagGroup deletes an existing tag group, its tags are kept without a group
+func DeleteTagGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteTagGroup)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteTagGroup{Group: action.Group}, &cmd.AddAuditLog{
+			Action:     enum.AuditTagGroupDeleted,
+			TargetType: "tag_group",
+			TargetID:   action.Group.ID,
+			TargetName: action.Group.Name,
+			Before:     tagGroupAuditProps(action.Group),
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+func tagGroupAuditProps(group *entity.TagGroup) dto.Props {
+	return dto.Props{
+		"name":         group.Name,
+		"selection":    group.Selection,
+		"visibleTo":    group.VisibleTo,
+		"assignableBy": group.AssignableBy,
+	}
+}
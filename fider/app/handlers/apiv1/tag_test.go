@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/handlers/apiv1"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
@@ -186,6 +187,12 @@ func TestDeleteExistingTagHandler(t *testing.T) {
 		return nil
 	})
 
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
 	status, _ := mock.NewServer().
 		AsUser(mock.JonSnow).
 		AddParam("slug", "bug").
@@ -193,6 +200,9 @@ func TestDeleteExistingTagHandler(t *testing.T) {
 
 	Expect(status).Equals(http.StatusOK)
 	Expect(deleteTag.Tag).Equals(tag)
+	Expect(auditLog.Action).Equals(enum.AuditTagDeleted)
+	Expect(auditLog.TargetID).Equals(tag.ID)
+	Expect(auditLog.TargetName).Equals("Bug")
 }
 
 func TestDeleteExistingTagHandler_Collaborator(t *testing.T) {
@@ -337,3 +347,96 @@ func TestListTagsHandler(t *testing.T) {
 	Expect(query.IsArray()).IsTrue()
 	Expect(query.ArrayLength()).Equals(2)
 }
+
+func TestAssignTagHandler_GroupNotAssignable(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 2, Number: 2}
+	tag := &entity.Tag{ID: 5, Slug: "enterprise", GroupID: 3}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = tag
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupByID) error {
+		q.Result = &entity.TagGroup{ID: 3, Slug: "customer-tier", AssignableBy: []string{"administrator"}}
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.Role = enum.RoleCollaborator
+	status, _ := server.
+		AsUser(mock.JonSnow).
+		AddParam("slug", tag.Slug).
+		AddParam("number", post.Number).
+		Execute(apiv1.AssignTag())
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestCreateTagGroupHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		return app.ErrNotFound
+	})
+
+	var addNewGroup *cmd.AddNewTagGroup
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewTagGroup) error {
+		addNewGroup = c
+		c.Result = &entity.TagGroup{ID: 1, Name: c.Name, Slug: "quarter", Selection: c.Selection}
+		return nil
+	})
+
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
+	status, query := mock.NewServer().
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(
+			apiv1.CreateEditTagGroup(),
+			`{ "name": "Quarter", "selection": "single", "assignableBy": ["visitor"] }`,
+		)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(query.String("slug")).Equals("quarter")
+	Expect(addNewGroup.Selection).Equals(enum.TagGroupSelectionSingle)
+	Expect(addNewGroup.AssignableBy).Equals([]string{"visitor"})
+	Expect(auditLog.Action).Equals(enum.AuditTagGroupCreated)
+}
+
+func TestDeleteTagGroupHandler(t *testing.T) {
+	RegisterT(t)
+
+	group := &entity.TagGroup{ID: 1, Name: "Quarter", Slug: "quarter"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		q.Result = group
+		return nil
+	})
+
+	var deleteGroup *cmd.DeleteTagGroup
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteTagGroup) error {
+		deleteGroup = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", group.Slug).
+		Execute(apiv1.DeleteTagGroup())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(deleteGroup.Group).Equals(group)
+}
@@ -1,9 +1,13 @@
 package apiv1
 
 import (
+	"strings"
+	"unicode/utf8"
+
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
@@ -56,13 +60,37 @@ func ListUsers() web.HandlerFunc {
 	}
 }
 
+// ListTaggableUsers returns a page of the users that can be mentioned, whose name starts with the query
 func ListTaggableUsers() web.HandlerFunc {
 	return func(c *web.Context) error {
-		allUsers := &query.GetAllUsersNames{}
-		if err := bus.Dispatch(c, allUsers); err != nil {
+		page, _ := c.QueryParamAsInt("page")
+		if page <= 0 {
+			page = 1
+		}
+
+		limit, _ := c.QueryParamAsInt("limit")
+		if limit <= 0 {
+			limit = 10
+		} else if limit > 50 {
+			limit = 50
+		}
+
+		// On private sites, only those who can see the member list may browse it,
+		// everyone else must type the beginning of a name to find someone
+		search := strings.TrimSpace(c.QueryParam("query"))
+		if c.Tenant().IsPrivate && utf8.RuneCountInString(search) < 2 && !c.User().HasPermission(enum.PermissionViewUsers) {
+			return c.Ok([]*dto.UserNames{})
+		}
+
+		searchUsers := &query.SearchTaggableUsers{
+			Query: search,
+			Page:  page,
+			Limit: limit,
+		}
+		if err := bus.Dispatch(c, searchUsers); err != nil {
 			return c.Failure(err)
 		}
-		return c.Ok(allUsers.Result)
+		return c.Ok(searchUsers.Result)
 	}
 }
 
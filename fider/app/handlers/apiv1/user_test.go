@@ -9,6 +9,7 @@ import (
 
 	"github.com/getfider/fider/app/handlers/apiv1"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
@@ -188,3 +189,74 @@ func TestCreateUser_NewUser(t *testing.T) {
 	theOtherUserID := query.Int32("id")
 	Expect(theOtherUserID).Equals(userID)
 }
+
+func TestListTaggableUsersHandler(t *testing.T) {
+	RegisterT(t)
+
+	var searchUsers *query.SearchTaggableUsers
+	bus.AddHandler(func(ctx context.Context, q *query.SearchTaggableUsers) error {
+		searchUsers = q
+		q.Result = []*dto.UserNames{{ID: 1, Name: "Jon Snow"}}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/taggable-users?query=jo&page=2&limit=500").
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.ArrayLength()).Equals(1)
+	Expect(searchUsers.Query).Equals("jo")
+	Expect(searchUsers.Page).Equals(2)
+	Expect(searchUsers.Limit).Equals(50)
+}
+
+func TestListTaggableUsersHandler_PrivateTenant(t *testing.T) {
+	RegisterT(t)
+
+	var searchUsers *query.SearchTaggableUsers
+	bus.AddHandler(func(ctx context.Context, q *query.SearchTaggableUsers) error {
+		searchUsers = q
+		q.Result = []*dto.UserNames{{ID: 1, Name: "Jon Snow"}}
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.IsPrivate = true
+
+	// Members can't browse the member list of a private site
+	code, response := mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/taggable-users?query=j").
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.ArrayLength()).Equals(0)
+	Expect(searchUsers).IsNil()
+
+	// But can find someone by the beginning of their name
+	code, response = mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/taggable-users?query=jo").
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.ArrayLength()).Equals(1)
+	Expect(searchUsers.Query).Equals("jo")
+
+	// Those who can see the member list can browse it
+	searchUsers = nil
+	code, response = mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/taggable-users").
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.ArrayLength()).Equals(1)
+	Expect(searchUsers.Query).Equals("")
+}
@@ -0,0 +1,86 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListWordFilters returns all word filters of current tenant
+func ListWordFilters() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetWordFilters{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateWordFilter adds a new word filter to current tenant
+func CreateWordFilter() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateWordFilter)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addFilter := &cmd.AddWordFilter{
+			Pattern: action.Pattern,
+			IsRegex: action.IsRegex,
+			Action:  action.Action,
+		}
+		if err := bus.Dispatch(c, addFilter); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditWordFilterCreated,
+			TargetType: "word_filter",
+			TargetID:   addFilter.Result.ID,
+			TargetName: addFilter.Result.Pattern,
+			After:      wordFilterAuditProps(addFilter.Result),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addFilter.Result)
+	}
+}
+
+// DeleteWordFilter removes an existing word filter
+func DeleteWordFilter() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteWordFilter)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteWordFilter{ID: action.Filter.ID}, &cmd.AddAuditLog{
+			Action:     enum.AuditWordFilterDeleted,
+			TargetType: "word_filter",
+			TargetID:   action.Filter.ID,
+			TargetName: action.Filter.Pattern,
+			Before:     wordFilterAuditProps(action.Filter),
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+func wordFilterAuditProps(filter *entity.WordFilter) dto.Props {
+	return dto.Props{
+		"pattern": filter.Pattern,
+		"isRegex": filter.IsRegex,
+		"action":  filter.Action.String(),
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/csv"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const auditLogPageSize = 50
+
+// AuditLogPage is the page used by administrators to review administrative actions
+func AuditLogPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		searchAuditLogs := auditLogFilters(c)
+		searchAuditLogs.Limit = auditLogPageSize
+
+		if err := bus.Dispatch(c, searchAuditLogs); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/AuditLog.page",
+			Title: "Audit Log · Site Settings",
+			Data: web.Map{
+				"auditLogs":  searchAuditLogs.Result,
+				"actions":    enum.AuditActions,
+				"totalCount": searchAuditLogs.TotalCount,
+				"totalPages": (searchAuditLogs.TotalCount + auditLogPageSize - 1) / auditLogPageSize,
+			},
+		})
+	}
+}
+
+// ExportAuditLogToCSV returns a CSV with all audit log entries matching the filters
+func ExportAuditLogToCSV() web.HandlerFunc {
+	return func(c *web.Context) error {
+		auditLogs, err := exportAuditLogs(c, "csv")
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := csv.FromAuditLogs(auditLogs)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment("audit.csv", "text/csv", bytes)
+	}
+}
+
+// ExportAuditLogToJSON returns a JSON file with all audit log entries matching the filters
+func ExportAuditLogToJSON() web.HandlerFunc {
+	return func(c *web.Context) error {
+		auditLogs, err := exportAuditLogs(c, "json")
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := json.Marshal(auditLogs)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment("audit.json", "application/json", bytes)
+	}
+}
+
+// exportAuditLogs loads every entry matching the filters and records the export itself on the audit log
+func exportAuditLogs(c *web.Context, format string) ([]*entity.AuditLog, error) {
+	auditLogs := make([]*entity.AuditLog, 0)
+	searchAuditLogs := auditLogFilters(c)
+	searchAuditLogs.Limit = 500
+	searchAuditLogs.Page = 1
+
+	for {
+		if err := bus.Dispatch(c, searchAuditLogs); err != nil {
+			return nil, err
+		}
+		auditLogs = append(auditLogs, searchAuditLogs.Result...)
+		if len(searchAuditLogs.Result) < searchAuditLogs.Limit {
+			break
+		}
+		searchAuditLogs.Page++
+	}
+
+	err := bus.Dispatch(c, &cmd.AddAuditLog{
+		Action:     enum.AuditAuditLogExported,
+		TargetType: "tenant",
+		TargetID:   c.Tenant().ID,
+		TargetName: c.Tenant().Name,
+		After:      dto.Props{"format": format, "count": len(auditLogs)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
+
+func auditLogFilters(c *web.Context) *query.SearchAuditLogs {
+	page, _ := c.QueryParamAsInt("page")
+	actorID, _ := c.QueryParamAsInt("actor")
+	targetID, _ := c.QueryParamAsInt("targetId")
+
+	searchAuditLogs := &query.SearchAuditLogs{
+		Action:     enum.AuditAction(c.QueryParam("action")),
+		ActorID:    actorID,
+		TargetType: c.QueryParam("targetType"),
+		TargetID:   targetID,
+		Page:       page,
+	}
+	searchAuditLogs.SetPeriodFromStrings(c.QueryParam("since"), c.QueryParam("until"))
+	return searchAuditLogs
+}
@@ -1,22 +1,79 @@
 package handlers
 
 import (
+	"io"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/backup"
+	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/validate"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
-// ExportBackupZip returns a Zip file with all content
+// ExportBackupZip returns a Zip file with all content, streamed as it is generated
 func ExportBackupZip() web.HandlerFunc {
 	return func(c *web.Context) error {
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditBackupExported,
+			TargetType: "tenant",
+			TargetID:   c.Tenant().ID,
+			TargetName: c.Tenant().Name,
+		}); err != nil {
+			return c.Failure(err)
+		}
 
-		file, err := backup.Create(c)
+		err := c.StreamAttachment("backup.zip", "application/zip", func(w io.Writer) error {
+			_, err := backup.Write(c, w, backup.WriteOptions{})
+			return err
+		})
 		if err != nil {
+			// The response has already started, so the download is left truncated and the error is only logged
 			log.Error(c, errors.Wrap(err, "failed to create backup"))
-			return c.Failure(err)
+		}
+		return nil
+	}
+}
+
+// RestoreBackupZip restores an uploaded backup into a new site, or only reports what would be restored on a dry run
+func RestoreBackupZip() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.RestoreBackup{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		report, err := backup.Restore(c, action.Archive, backup.RestoreOptions{
+			Subdomain: action.Subdomain,
+			DryRun:    action.DryRun,
+		})
+		if restoreErr, ok := errors.Cause(err).(*backup.RestoreError); ok {
+			return c.HandleValidation(validate.Failed(restoreErr.Error()))
+		} else if err != nil {
+			return c.Failure(errors.Wrap(err, "failed to restore backup '%s'", action.FileName))
+		}
+
+		if !report.DryRun {
+			if err := bus.Dispatch(c, &cmd.AddAuditLog{
+				Action:     enum.AuditBackupRestored,
+				TargetType: "tenant",
+				TargetID:   report.TenantID,
+				TargetName: report.TenantName,
+				After: dto.Props{
+					"subdomain": report.Subdomain,
+					"fileName":  action.FileName,
+					"rows":      report.Rows,
+					"blobs":     report.Blobs,
+				},
+			}); err != nil {
+				return c.Failure(err)
+			}
 		}
 
-		return c.Attachment("backup.zip", "application/zip", file.Bytes())
+		return c.Ok(report)
 	}
 }
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/export"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ExportPage is the page used to download exports and backups
+func ExportPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getAllTags := &query.GetAllTags{}
+		if err := bus.Dispatch(c, getAllTags); err != nil {
+			return c.Failure(err)
+		}
+
+		private := actions.CanExportPersonalData(c.User())
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/Export.page",
+			Title: "Export · Site Settings",
+			Data: web.Map{
+				"tags": getAllTags.Result,
+				"columns": web.Map{
+					string(export.TypePosts):     export.Columns(export.TypePosts, private),
+					string(export.TypeComments):  export.Columns(export.TypeComments, private),
+					string(export.TypeVotes):     export.Columns(export.TypeVotes, private),
+					string(export.TypeReactions): export.Columns(export.TypeReactions, private),
+				},
+			},
+		})
+	}
+}
+
+// ExportData streams the posts, comments, votes or reactions that match the filters on the query string.
+// Filters are the same used to search posts, plus the period when records were created
+func ExportData() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.ExportData{
+			Format:  export.Format(c.QueryParam("format")),
+			Columns: c.QueryParamAsArray("columns"),
+		}
+		action.Filter.Query = c.QueryParam("query")
+		action.Filter.Tags = c.QueryParamAsArray("tags")
+		action.Filter.TagGroups = c.QueryParamAsArray("taggroups")
+		action.Filter.SetStatusesFromStrings(c.QueryParamAsArray("statuses"))
+		action.Filter.SetPeriodFromStrings(c.QueryParam("since"), c.QueryParam("until"))
+		if noTagsOnly, err := c.QueryParamAsBool("notags"); err == nil {
+			action.Filter.NoTagsOnly = noTagsOnly
+		}
+
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		opts := action.Options(c.User())
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditDataExported,
+			TargetType: "tenant",
+			TargetID:   c.Tenant().ID,
+			TargetName: c.Tenant().Name,
+			After: dto.Props{
+				"type":    opts.Type,
+				"format":  opts.Format,
+				"columns": opts.Columns,
+				"query":   c.Request.URL.RawQuery,
+			},
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		err := c.StreamAttachment(opts.FileName(), opts.Format.ContentType(), func(w io.Writer) error {
+			return export.Write(c, w, opts)
+		})
+		if err != nil {
+			// The response has already started, so the download is left truncated and the error is only logged
+			log.Error(c, errors.Wrap(err, "failed to export %s", opts.Type))
+		}
+		return nil
+	}
+}
@@ -120,7 +120,8 @@ func generatePostContent(c *web.Context, post *entity.Post, options *generatorOp
 		})
 	}
 
-	return string(markdown.Full(title+post.Description+footer, true))
+	description := entity.CommentString(post.Description).SanitizeMentions()
+	return string(markdown.Full(title+description+footer, true))
 }
 
 func appendTags(c *web.Context, categories []*Category, post *entity.Post) ([]*Category, error) {
@@ -157,6 +158,14 @@ func GlobalFeed() web.HandlerFunc {
 		}
 		posts := searchPosts.Result
 
+		descriptions := make([]*string, len(posts))
+		for i, post := range posts {
+			descriptions[i] = &post.Description
+		}
+		if err := markdown.ResolveMentions(c, descriptions...); err != nil {
+			return c.Failure(err)
+		}
+
 		feed := &AtomFeed{
 			Title:    c.Tenant().Name,
 			Subtitle: Content{Body: string(markdown.Full(c.Tenant().WelcomeMessage, true)), Type: "html"},
@@ -250,6 +259,14 @@ func CommentFeed() web.HandlerFunc {
 		comments := getComments.Result
 		comments = comments[max(0, len(comments)-30):] // get the last 30 comments
 
+		contents := []*string{&post.Description}
+		for _, comment := range comments {
+			contents = append(contents, &comment.Content)
+		}
+		if err := markdown.ResolveMentions(c, contents...); err != nil {
+			return c.Failure(err)
+		}
+
 		authorName := ""
 		if post.User != nil {
 			authorName = post.User.Name
@@ -257,7 +274,7 @@ func CommentFeed() web.HandlerFunc {
 
 		feed := &AtomFeed{
 			Title:    post.Title,
-			Subtitle: Content{Body: string(markdown.Full(post.Description, true)), Type: "html"},
+			Subtitle: Content{Body: string(markdown.Full(entity.CommentString(post.Description).SanitizeMentions(), true)), Type: "html"},
 			Author:   &Author{Name: authorName},
 			Id:       fmt.Sprintf("%s/posts/%d/#comments", web.BaseURL(c), post.Number),
 			Link: []Link{
@@ -325,7 +342,7 @@ func CommentFeed() web.HandlerFunc {
 					}
 					return formatTime(*comment.EditedAt)
 				}(),
-				Content: &Content{Type: "html", Body: string(markdown.Full(html.UnescapeString(comment.Content), true))},
+				Content: &Content{Type: "html", Body: string(markdown.Full(html.UnescapeString(entity.CommentString(comment.Content).SanitizeMentions()), true))},
 				Id:      fmt.Sprintf("%s/posts/%d/#comment-%d", web.BaseURL(c), post.Number, comment.ID),
 				Link:    []Link{{Href: fmt.Sprintf("%s/posts/%d/#comment-%d", web.BaseURL(c), post.Number, comment.ID), Type: "text/html", Rel: "alternate"}},
 			})
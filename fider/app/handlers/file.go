@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"slices"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// DownloadFile returns an attached file as a download, as long as it has been cleared by the scanner.
+// The file must be listed on its post for current user, so it's hidden along with the post or comment it's attached to
+func DownloadFile() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getFile := &query.GetFileByID{FileID: id}
+		if err := bus.Dispatch(c, getFile); err != nil {
+			return c.Failure(err)
+		}
+
+		file := getFile.Result
+		if !file.CanBeDownloaded() {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByID{PostID: file.PostID}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+		if getPost.Result.Status == enum.PostDeleted {
+			return c.NotFound()
+		}
+
+		listFiles := &query.ListPostFiles{PostID: file.PostID}
+		if err := bus.Dispatch(c, listFiles); err != nil {
+			return c.Failure(err)
+		}
+		if !slices.ContainsFunc(listFiles.Result, func(f *entity.File) bool { return f.ID == file.ID }) {
+			return c.NotFound()
+		}
+
+		getBlob := &query.GetBlobByKey{Key: file.BlobKey}
+		if err := bus.Dispatch(c, getBlob); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment(file.Name, file.ContentType, getBlob.Result.Content)
+	}
+}
@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestDownloadFileHandler(t *testing.T) {
+	RegisterT(t)
+
+	file := &entity.File{ID: 1, PostID: 2, Name: "crash \"report\" é.log", ContentType: "text/plain", BlobKey: "files/abc-crash-report.log"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetFileByID) error {
+		q.Result = file
+		return nil
+	})
+	post := &entity.Post{ID: 2, Number: 2, Status: enum.PostOpen}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		Expect(q.PostID).Equals(file.PostID)
+		q.Result = post
+		return nil
+	})
+	listed := true
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostFiles) error {
+		Expect(q.PostID).Equals(file.PostID)
+		q.Result = []*entity.File{}
+		if listed {
+			q.Result = append(q.Result, file)
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		Expect(q.Key).Equals(file.BlobKey)
+		q.Result = &dto.Blob{Content: []byte("ERROR failed"), ContentType: "text/plain"}
+		return nil
+	})
+
+	download := func() (int, http.Header, string) {
+		code, response := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AddParam("id", file.ID).
+			AddHeader("Accept", "application/json").
+			Execute(handlers.DownloadFile())
+		return code, response.Header(), response.Body.String()
+	}
+
+	for _, status := range []enum.FileStatus{enum.FileStatusPending, enum.FileStatusQuarantined} {
+		file.Status = status
+		code, _, _ := download()
+		Expect(code).Equals(http.StatusNotFound)
+	}
+
+	file.Status = enum.FileStatusClean
+	code, header, body := download()
+	Expect(code).Equals(http.StatusOK)
+	Expect(body).Equals("ERROR failed")
+	Expect(header.Get("Content-Type")).Equals("text/plain")
+	Expect(header.Get("Content-Disposition")).Equals("attachment; filename*=utf-8''crash%20%22report%22%20%C3%A9.log")
+
+	// files of comments that can't be seen are not listed
+	listed = false
+	code, _, _ = download()
+	Expect(code).Equals(http.StatusNotFound)
+
+	listed = true
+	post.Status = enum.PostDeleted
+	code, _, _ = download()
+	Expect(code).Equals(http.StatusNotFound)
+}
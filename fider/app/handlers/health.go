@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+const migrationsPath = "/migrations"
+
+// healthCheck is the result of checking one of the dependencies of Fider
+type healthCheck struct {
+	Name       string  `json:"name"`
+	Healthy    bool    `json:"healthy"`
+	DurationMs int64   `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+	Details    web.Map `json:"details,omitempty"`
+}
+
+type healthReport struct {
+	Healthy bool           `json:"healthy"`
+	Checks  []*healthCheck `json:"checks"`
+}
+
+// Liveness returns OK while the process is able to serve requests, regardless of its dependencies
+func Liveness() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return c.Ok(web.Map{"status": "Healthy"})
+	}
+}
+
+// Readiness returns OK when the database is reachable and migrated and the blob storage is working,
+// otherwise it returns 503 with the checks that failed. Errors and details are only shown on Diagnostics
+func Readiness() web.HandlerFunc {
+	return func(c *web.Context) error {
+		report := newHealthReport(
+			runHealthCheck("database", checkDatabase),
+			runHealthCheck("migrations", checkMigrations),
+			runHealthCheck("blobStorage", func() (web.Map, error) {
+				return checkBlobStorage(c)
+			}),
+		)
+		for _, check := range report.Checks {
+			check.Error = ""
+			check.Details = nil
+		}
+		return writeHealthReport(c, report)
+	}
+}
+
+// Diagnostics returns a report of every dependency of Fider, as JSON or as text when format=text.
+// It requires the DIAGNOSTICS_TOKEN as a bearer token and is disabled when the token is not set
+func Diagnostics() web.HandlerFunc {
+	return func(c *web.Context) error {
+		token := env.Config.Diagnostics.Token
+		if token == "" {
+			return c.JSON(http.StatusNotFound, web.Map{})
+		}
+
+		auth := c.Request.GetHeader("Authorization")
+		if subtle.ConstantTimeCompare([]byte(auth), []byte("Bearer "+token)) != 1 {
+			return c.JSON(http.StatusUnauthorized, web.Map{})
+		}
+
+		report := newHealthReport(
+			runHealthCheck("database", checkDatabase),
+			runHealthCheck("migrations", checkMigrations),
+			runHealthCheck("blobStorage", func() (web.Map, error) {
+				return checkBlobStorage(c)
+			}),
+			runHealthCheck("email", checkEmail),
+			runHealthCheck("worker", func() (web.Map, error) {
+				return checkWorker(c.Engine().Worker())
+			}),
+			runHealthCheck("jobs", func() (web.Map, error) {
+				return checkJobs(c)
+			}),
+			runHealthCheck("services", checkServices),
+		)
+
+		if c.QueryParam("format") == "text" {
+			status := http.StatusOK
+			if !report.Healthy {
+				status = http.StatusServiceUnavailable
+			}
+			return c.String(status, report.Text())
+		}
+		return writeHealthReport(c, report)
+	}
+}
+
+func newHealthReport(checks ...*healthCheck) *healthReport {
+	report := &healthReport{Healthy: true, Checks: checks}
+	for _, check := range checks {
+		report.Healthy = report.Healthy && check.Healthy
+	}
+	return report
+}
+
+func writeHealthReport(c *web.Context, report *healthReport) error {
+	if !report.Healthy {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.Ok(report)
+}
+
+// Text returns the report in a format meant to be read by humans
+func (r *healthReport) Text() string {
+	buf := new(strings.Builder)
+	status := "healthy"
+	if !r.Healthy {
+		status = "unhealthy"
+	}
+	fmt.Fprintf(buf, "Fider is %s\n", status)
+
+	for _, check := range r.Checks {
+		result := " OK "
+		if !check.Healthy {
+			result = "FAIL"
+		}
+		fmt.Fprintf(buf, "\n[%s] %s (%dms)\n", result, check.Name, check.DurationMs)
+		if check.Error != "" {
+			fmt.Fprintf(buf, "       error: %s\n", strings.ReplaceAll(check.Error, "\n", "\n       "))
+		}
+
+		keys := make([]string, 0, len(check.Details))
+		for key := range check.Details {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(buf, "       %s: %s\n", key, detailText(check.Details[key]))
+		}
+	}
+	return buf.String()
+}
+
+func detailText(value any) string {
+	switch value.(type) {
+	case string, bool, int, int64, float64:
+		return fmt.Sprint(value)
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
+
+func runHealthCheck(name string, check func() (web.Map, error)) *healthCheck {
+	start := time.Now()
+	details, err := check()
+
+	result := &healthCheck{
+		Name:       name,
+		Healthy:    err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+		Details:    details,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func checkDatabase() (web.Map, error) {
+	if err := dbx.Ping(); err != nil {
+		return nil, err
+	}
+
+	stats := dbx.Connection().Stats()
+	return web.Map{
+		"maxOpenConnections": stats.MaxOpenConnections,
+		"openConnections":    stats.OpenConnections,
+		"inUse":              stats.InUse,
+		"idle":               stats.Idle,
+		"waitCount":          stats.WaitCount,
+		"waitDurationMs":     stats.WaitDuration.Milliseconds(),
+	}, nil
+}
+
+func checkMigrations() (web.Map, error) {
+	current, expected, err := dbx.MigrationStatus(migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	details := web.Map{"current": current, "expected": expected}
+	if current < expected {
+		return details, errors.New("database is at version %d, expected %d", current, expected)
+	}
+	return details, nil
+}
+
+// checkBlobStorage stores, reads and deletes a blob, within a transaction that is rolled back when blobs are stored on the database
+func checkBlobStorage(ctx context.Context) (web.Map, error) {
+	details := web.Map{"type": env.Config.BlobStorage.Type}
+
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return details, err
+	}
+	defer trx.MustRollback()
+	ctx = context.WithValue(ctx, app.TransactionCtxKey, trx)
+
+	key := fmt.Sprintf("health/%s.txt", rand.String(16))
+	content := []byte(rand.String(32))
+	if err := bus.Dispatch(ctx, &cmd.StoreBlob{Key: key, Content: content, ContentType: "text/plain"}); err != nil {
+		return details, err
+	}
+	defer func() {
+		_ = bus.Dispatch(ctx, &cmd.DeleteBlob{Key: key})
+	}()
+
+	getBlob := &query.GetBlobByKey{Key: key}
+	if err := bus.Dispatch(ctx, getBlob); err != nil {
+		return details, err
+	}
+	if !bytes.Equal(getBlob.Result.Content, content) {
+		return details, errors.New("blob '%s' was read with a different content than it was stored with", key)
+	}
+	return details, nil
+}
+
+// checkEmail checks that an email service is enabled for the configured provider and that SMTP servers are reachable
+func checkEmail() (web.Map, error) {
+	details := web.Map{
+		"type":    env.Config.Email.Type,
+		"noreply": env.Config.Email.NoReply,
+	}
+
+	for _, svc := range bus.InitializedServices() {
+		if svc.Category() == "email" {
+			details["service"] = svc.Name()
+		}
+	}
+	if details["service"] == nil {
+		return details, errors.New("no email service is enabled for EMAIL='%s'", env.Config.Email.Type)
+	}
+
+	if env.Config.Email.Type == "smtp" {
+		address := net.JoinHostPort(env.Config.Email.SMTP.Host, env.Config.Email.SMTP.Port)
+		details["address"] = address
+		conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+		if err != nil {
+			return details, errors.Wrap(err, "failed to connect to SMTP server '%s'", address)
+		}
+		_ = conn.Close()
+	}
+	return details, nil
+}
+
+func checkWorker(w worker.Worker) (web.Map, error) {
+	details := web.Map{
+		"queue":   env.Config.Worker.Queue,
+		"running": w.Length(),
+	}
+
+	if durable, ok := w.(*worker.DurableWorker); ok {
+		counts, err := durable.CountStored()
+		if err != nil {
+			return details, err
+		}
+		details["stored"] = counts
+	}
+	return details, nil
+}
+
+// checkJobs reports the last run of each scheduled job, and fails when any of them failed on its last run
+func checkJobs(ctx context.Context) (web.Map, error) {
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer trx.MustRollback()
+
+	list, err := jobs.List(context.WithValue(ctx, app.TransactionCtxKey, trx))
+	if err != nil {
+		return nil, err
+	}
+
+	details := web.Map{}
+	failed := make([]string, 0)
+	for _, job := range list {
+		status := web.Map{
+			"schedule": job.Schedule,
+			"isPaused": job.IsPaused,
+		}
+		if len(job.Runs) > 0 {
+			last := job.Runs[0]
+			status["lastRun"] = web.Map{
+				"status":     last.Status,
+				"startedAt":  last.StartedAt,
+				"durationMs": last.DurationMs,
+				"error":      last.Error,
+			}
+			if last.Status == enum.JobRunFailed {
+				failed = append(failed, job.Name)
+			}
+		}
+		details[job.Name] = status
+	}
+
+	if len(failed) > 0 {
+		return details, errors.New("last run of %s failed", strings.Join(failed, ", "))
+	}
+	return details, nil
+}
+
+func checkServices() (web.Map, error) {
+	services := make([]string, 0)
+	for _, svc := range bus.InitializedServices() {
+		services = append(services, svc.Category()+"."+svc.Name())
+	}
+	sort.Strings(services)
+	return web.Map{"enabled": services}, nil
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, _ := server.Execute(handlers.Liveness())
+
+	Expect(code).Equals(http.StatusOK)
+}
+
+func TestDiagnosticsHandler_Disabled(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Diagnostics.Token = ""
+	server := mock.NewServer()
+	code, _ := server.
+		AddHeader("Authorization", "Bearer ").
+		Execute(handlers.Diagnostics())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestDiagnosticsHandler_InvalidToken(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Diagnostics.Token = "s3cr3t"
+	defer func() { env.Config.Diagnostics.Token = "" }()
+
+	server := mock.NewServer()
+	code, _ := server.
+		AddHeader("Authorization", "Bearer wrong").
+		Execute(handlers.Diagnostics())
+
+	Expect(code).Equals(http.StatusUnauthorized)
+}
+
+func TestDiagnosticsHandler_Text(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Diagnostics.Token = "s3cr3t"
+	defer func() { env.Config.Diagnostics.Token = "" }()
+
+	blobs := make(map[string][]byte)
+	bus.AddHandler(func(ctx context.Context, c *cmd.StoreBlob) error {
+		blobs[c.Key] = c.Content
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		q.Result = &dto.Blob{Content: blobs[q.Key]}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteBlob) error {
+		delete(blobs, c.Key)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListJobRuns) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io/_diagnostics?format=text").
+		AddHeader("Authorization", "Bearer s3cr3t").
+		Execute(handlers.Diagnostics())
+
+	// no email service is enabled during tests
+	Expect(code).Equals(http.StatusServiceUnavailable)
+	body := response.Body.String()
+	Expect(body).ContainsSubstring("Fider is unhealthy")
+	Expect(body).ContainsSubstring("[ OK ] database")
+	Expect(body).ContainsSubstring("[ OK ] blobStorage")
+	Expect(body).ContainsSubstring("[FAIL] email")
+	Expect(body).ContainsSubstring("[ OK ] worker")
+	Expect(body).ContainsSubstring("[ OK ] services")
+	Expect(blobs).HasLen(0)
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/query"
 
+	"github.com/getfider/fider/app/pkg/backup"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/crypto"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/img"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/goenning/imagic"
@@ -161,17 +163,39 @@ func Favicon() web.HandlerFunc {
 	}
 }
 
-// ViewUploadedImage returns any uploaded image by given ID and size
+// uploadedImageSizes are the sizes the UI requests uploaded images at.
+// Other sizes are snapped to these, so that only a few variants of each image are ever cached
+var uploadedImageSizes = []int{24, 50, 100, 200}
+
+// snapImageSize returns the smallest allowed size that fits given size.
+// Zero, as well as any size larger than the allowed ones, stands for the original image
+func snapImageSize(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	for _, allowed := range uploadedImageSizes {
+		if size <= allowed {
+			return allowed
+		}
+	}
+	return 0
+}
+
+// ViewUploadedImage returns any uploaded image by given ID and size.
+// Resized and converted variants are cached in the blob storage under variants/<bkey>/
 func ViewUploadedImage() web.HandlerFunc {
 	return func(c *web.Context) error {
 		bkey := c.Param("bkey")
+		if strings.HasPrefix(bkey, backup.BlobPrefix) {
+			return c.NotFound()
+		}
 
 		size, err := c.QueryParamAsInt("size")
 		if err != nil {
 			return c.BadRequest(web.Map{})
 		}
 
-		size = between(size, 0, 2000)
+		size = snapImageSize(size)
 
 		q := &query.GetBlobByKey{Key: bkey}
 		err = bus.Dispatch(c, q)
@@ -179,14 +203,48 @@ func ViewUploadedImage() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		bytes := q.Result.Content
-		if size > 0 {
-			bytes, err = imagic.Apply(bytes, imagic.Resize(size))
-			if err != nil {
-				return c.Failure(err)
-			}
+		// Only images are served, any other blob such as attachments or backups are served by their own handlers
+		if !strings.HasPrefix(q.Result.ContentType, "image/") {
+			return c.NotFound()
+		}
+
+		c.Response.Header().Add("Vary", "Accept")
+
+		format := img.Negotiate(c.Request.GetHeader("Accept"), strings.TrimPrefix(q.Result.ContentType, "image/"))
+		if size == 0 && img.ContentType(format) == q.Result.ContentType {
+			return c.Image(q.Result.ContentType, q.Result.Content)
+		}
+
+		// variants keep the format of the original image, unless it's converted to WebP
+		variantKey := fmt.Sprintf("variants/%s/%d", bkey, size)
+		variantFormat := ""
+		if format == img.FormatWebP {
+			variantKey += ".webp"
+			variantFormat = img.FormatWebP
+		}
+
+		cached := &query.GetBlobByKey{Key: variantKey}
+		if err := bus.Dispatch(c, cached); err == nil {
+			return c.Image(cached.Result.ContentType, cached.Result.Content)
+		}
+
+		variant, err := img.Resize(q.Result.Content, size, variantFormat)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		err = bus.Dispatch(c, &cmd.StoreBlob{
+			Key:         variantKey,
+			Content:     variant.Content,
+			ContentType: variant.ContentType,
+		})
+		if err != nil {
+			log.Warnf(c, "Failed to cache image variant '@{Key}': @{Error}", dto.Props{
+				"Key":   variantKey,
+				"Error": err.Error(),
+			})
 		}
 
-		return c.Image(q.Result.ContentType, bytes)
+		return c.Image(variant.ContentType, variant.Content)
 	}
 }
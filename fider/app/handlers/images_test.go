@@ -1,15 +1,22 @@
 package handlers_test
 
 import (
+	"bytes"
 	"context"
+	"image"
+	"image/png"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/services/blob"
 	"github.com/getfider/fider/app/services/httpclient"
 
 	"github.com/getfider/fider/app/pkg/mock"
@@ -102,3 +109,98 @@ func TestUnknownUser_LetterAvatarHandler(t *testing.T) {
 	bytes, _ := io.ReadAll(response.Body)
 	Expect(bytes).Equals(expectedAvatar)
 }
+
+func TestViewUploadedImage_CachesVariants(t *testing.T) {
+	RegisterT(t)
+
+	buf := new(bytes.Buffer)
+	_ = png.Encode(buf, image.NewGray(image.Rect(0, 0, 400, 200)))
+
+	blobs := map[string]*dto.Blob{
+		"attachments/logo.png": {ContentType: "image/png", Content: buf.Bytes()},
+	}
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		b, ok := blobs[q.Key]
+		if !ok {
+			return blob.ErrNotFound
+		}
+		q.Result = b
+		return nil
+	})
+	stored := []string{}
+	bus.AddHandler(func(ctx context.Context, c *cmd.StoreBlob) error {
+		stored = append(stored, c.Key)
+		blobs[c.Key] = &dto.Blob{ContentType: c.ContentType, Content: c.Content}
+		return nil
+	})
+
+	view := func(url, accept string) (int, *httptest.ResponseRecorder) {
+		return mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			WithURL(url).
+			AddHeader("Accept", accept).
+			AddParam("bkey", "attachments/logo.png").
+			Execute(handlers.ViewUploadedImage())
+	}
+
+	code, response := view("https://demo.test.fider.io/?size=100", "image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("image/png")
+	Expect(response.Header().Get("Vary")).Equals("Accept")
+	config, _, err := image.DecodeConfig(response.Body)
+	Expect(err).IsNil()
+	Expect(config.Width).Equals(100)
+	Expect(config.Height).Equals(50)
+	Expect(stored).Equals([]string{"variants/attachments/logo.png/100"})
+
+	code, response = view("https://demo.test.fider.io/?size=100", "image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.Bytes()).Equals(blobs["variants/attachments/logo.png/100"].Content)
+	Expect(stored).HasLen(1)
+
+	code, response = view("https://demo.test.fider.io/?size=100", "image/webp,image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("image/webp")
+	Expect(stored).Equals([]string{"variants/attachments/logo.png/100", "variants/attachments/logo.png/100.webp"})
+
+	code, response = view("https://demo.test.fider.io/", "image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.Bytes()).Equals(buf.Bytes())
+	Expect(stored).HasLen(2)
+
+	// sizes are snapped to the ones used by the UI, so arbitrary sizes don't create new variants
+	code, response = view("https://demo.test.fider.io/?size=99", "image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.Bytes()).Equals(blobs["variants/attachments/logo.png/100"].Content)
+	Expect(stored).HasLen(2)
+
+	code, response = view("https://demo.test.fider.io/?size=1999", "image/*")
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.Bytes()).Equals(buf.Bytes())
+	Expect(stored).HasLen(2)
+}
+
+func TestViewUploadedImage_OnlyServesImages(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		if q.Key == "attachments/report.pdf" {
+			q.Result = &dto.Blob{ContentType: "application/pdf", Content: []byte("%PDF-1.4")}
+			return nil
+		}
+		if q.Key == "backups/1/backup.zip" {
+			q.Result = &dto.Blob{ContentType: "application/zip", Content: []byte("PK")}
+			return nil
+		}
+		return blob.ErrNotFound
+	})
+
+	for _, bkey := range []string{"attachments/report.pdf", "backups/1/backup.zip"} {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			WithURL("https://demo.test.fider.io/?size=0").
+			AddParam("bkey", bkey).
+			Execute(handlers.ViewUploadedImage())
+		Expect(code).Equals(http.StatusNotFound)
+	}
+}
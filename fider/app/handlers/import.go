@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/importer"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// ImportPage is the page used to import posts from other feedback tools and follow the progress of imports
+func ImportPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		listImportJobs := &query.ListImportJobs{}
+		if err := bus.Dispatch(c, listImportJobs); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/Import.page",
+			Title: "Import · Site Settings",
+			Data: web.Map{
+				"jobs":   listImportJobs.Result,
+				"fields": importer.Fields,
+			},
+		})
+	}
+}
+
+// StartImport parses an import file and imports its rows on background
+func StartImport() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.StartImport{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addImportJob := &cmd.AddImportJob{
+			Source:    action.Source,
+			FileName:  action.FileName,
+			TotalRows: action.Parsed.Rows(),
+		}
+		if err := bus.Dispatch(c, addImportJob); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditDataImported,
+			TargetType: "import",
+			TargetID:   addImportJob.Result.ID,
+			TargetName: action.FileName,
+			After: dto.Props{
+				"source": action.Source,
+				"rows":   addImportJob.Result.TotalRows,
+			},
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.RunImport(addImportJob.Result, action.Parsed))
+
+		return c.Ok(addImportJob.Result)
+	}
+}
+
+// GetImport returns the progress of an import and the rows that could not be imported
+func GetImport() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getImportJob := &query.GetImportJobByID{JobID: id}
+		if err := bus.Dispatch(c, getImportJob); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getImportJob.Result)
+	}
+}
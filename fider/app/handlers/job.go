@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ManageJobs is the page used by operators to follow and control the scheduled jobs of the instance
+func ManageJobs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		list, err := jobs.List(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageJobs.page",
+			Title: "Jobs · Site Settings",
+			Data: web.Map{
+				"jobs": list,
+			},
+		})
+	}
+}
+
+// ListJobs returns the scheduled jobs of the instance with their state and most recent runs
+func ListJobs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		list, err := jobs.List(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(list)
+	}
+}
+
+// RunJob starts a run of a scheduled job on background
+func RunJob() web.HandlerFunc {
+	return func(c *web.Context) error {
+		name := c.Param("name")
+		if err := jobs.Trigger(name); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditJobTriggered,
+			TargetType: "job",
+			TargetName: name,
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// PauseJob stops the scheduled runs of a job until it is resumed
+func PauseJob() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return setJobPaused(c, true, enum.AuditJobPaused)
+	}
+}
+
+// ResumeJob resumes the scheduled runs of a paused job
+func ResumeJob() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return setJobPaused(c, false, enum.AuditJobResumed)
+	}
+}
+
+func setJobPaused(c *web.Context, paused bool, action enum.AuditAction) error {
+	name := c.Param("name")
+	if err := jobs.SetPaused(c, name, paused); err != nil {
+		return c.Failure(err)
+	}
+
+	if err := bus.Dispatch(c, &cmd.AddAuditLog{
+		Action:     action,
+		TargetType: "job",
+		TargetName: name,
+		Before:     dto.Props{"isPaused": !paused},
+		After:      dto.Props{"isPaused": paused},
+	}); err != nil {
+		return c.Failure(err)
+	}
+
+	return c.Ok(web.Map{})
+}
@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+type noopJobHandler struct{}
+
+func (noopJobHandler) Schedule() string {
+	return "0 0 * * * *"
+}
+
+func (noopJobHandler) Run(ctx jobs.Context) error {
+	return nil
+}
+
+func TestPauseJobHandler(t *testing.T) {
+	RegisterT(t)
+
+	jobs.Register(context.Background(), "HandlerTestJob", noopJobHandler{})
+
+	var setting *cmd.SetSystemSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetSystemSettings) error {
+		setting = c
+		return nil
+	})
+
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "HandlerTestJob").
+		ExecutePost(handlers.PauseJob(), "")
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setting.Key).Equals("jobs.HandlerTestJob.paused")
+	Expect(setting.Value).Equals("true")
+	Expect(auditLog.Action).Equals(enum.AuditJobPaused)
+	Expect(auditLog.TargetType).Equals("job")
+	Expect(auditLog.TargetName).Equals("HandlerTestJob")
+	Expect(auditLog.After["isPaused"]).Equals(true)
+}
+
+func TestRunJobHandler_UnknownJob(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "UnknownJob").
+		ExecutePost(handlers.RunJob(), "")
+
+	Expect(code).Equals(http.StatusNotFound)
+	ExpectHandler(&cmd.AddAuditLog{}).CalledTimes(0)
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/web"
-	webutil "github.com/getfider/fider/app/pkg/web/util"
 )
 
 // OAuthEcho exchanges OAuth Code for a user profile and return directly to the UI, without storing it
@@ -138,7 +137,9 @@ func OAuthToken() web.HandlerFunc {
 			}
 		}
 
-		webutil.AddAuthUserCookie(c, user)
+		if signInUser(c, user) {
+			return c.Redirect(twoFactorURL(c, redirectURL.RequestURI()))
+		}
 
 		return c.Redirect(redirectURL.String())
 	}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/csv"
@@ -18,10 +21,11 @@ func Index() web.HandlerFunc {
 		c.SetCanonicalURL("")
 
 		searchPosts := &query.SearchPosts{
-			Query: c.QueryParam("query"),
-			View:  c.QueryParam("view"),
-			Limit: c.QueryParam("limit"),
-			Tags:  c.QueryParamAsArray("tags"),
+			Query:     c.QueryParam("query"),
+			View:      c.QueryParam("view"),
+			Limit:     c.QueryParam("limit"),
+			Tags:      c.QueryParamAsArray("tags"),
+			TagGroups: c.QueryParamAsArray("taggroups"),
 		}
 
 		if myVotesOnly, err := c.QueryParamAsBool("myvotes"); err == nil {
@@ -55,9 +59,10 @@ func Index() web.HandlerFunc {
 
 		searchPosts.SetStatusesFromStrings(actualStatuses)
 		getAllTags := &query.GetAllTags{}
+		getAllTagGroups := &query.GetAllTagGroups{}
 		countPerStatus := &query.CountPostPerStatus{}
 
-		if err := bus.Dispatch(c, searchPosts, getAllTags, countPerStatus); err != nil {
+		if err := bus.Dispatch(c, searchPosts, getAllTags, getAllTagGroups, countPerStatus); err != nil {
 			return c.Failure(err)
 		}
 
@@ -72,6 +77,7 @@ func Index() web.HandlerFunc {
 			"searchNoiseWords": env.SearchNoiseWords(),
 			"posts":            searchPosts.Result,
 			"tags":             getAllTags.Result,
+			"tagGroups":        getAllTagGroups.Result,
 			"countPerStatus":   countPerStatus.Result,
 		}
 
@@ -102,23 +108,34 @@ func PostDetails() web.HandlerFunc {
 		}
 
 		isSubscribed := &query.UserSubscribedTo{PostID: getPost.Result.ID}
-		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		getComments := &query.GetCommentsByPost{Post: getPost.Result, IncludeRemovedParents: true}
 		getAllTags := &query.GetAllTags{}
+		getAllTagGroups := &query.GetAllTagGroups{}
 		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID, Limit: 24, IncludeEmail: false}
 		getAttachments := &query.GetAttachments{Post: getPost.Result}
-		if err := bus.Dispatch(c, getAllTags, getComments, listVotes, isSubscribed, getAttachments); err != nil {
+		if err := bus.Dispatch(c, getAllTags, getAllTagGroups, getComments, listVotes, isSubscribed, getAttachments); err != nil {
 			return c.Failure(err)
 		}
 
+		contents := []*string{&getPost.Result.Description}
+		for _, comment := range getComments.Result {
+			contents = append(contents, &comment.Content)
+		}
+		if err := markdown.ResolveMentions(c, contents...); err != nil {
+			return c.Failure(err)
+		}
+
+		description := entity.CommentString(getPost.Result.Description).SanitizeMentions()
 		return c.Page(http.StatusOK, web.Props{
 			Page:        "ShowPost/ShowPost.page",
 			Title:       getPost.Result.Title,
-			Description: markdown.PlainText(getPost.Result.Description),
+			Description: markdown.PlainText(description),
 			Data: web.Map{
 				"comments":    getComments.Result,
 				"subscribed":  isSubscribed.Result,
 				"post":        getPost.Result,
 				"tags":        getAllTags.Result,
+				"tagGroups":   getAllTagGroups.Result,
 				"votes":       listVotes.Result,
 				"attachments": getAttachments.Result,
 			},
@@ -131,7 +148,12 @@ func ExportPostsToCSV() web.HandlerFunc {
 	return func(c *web.Context) error {
 
 		allPosts := &query.GetAllPosts{}
-		if err := bus.Dispatch(c, allPosts); err != nil {
+		if err := bus.Dispatch(c, allPosts, &cmd.AddAuditLog{
+			Action:     enum.AuditPostsExported,
+			TargetType: "tenant",
+			TargetID:   c.Tenant().ID,
+			TargetName: c.Tenant().Name,
+		}); err != nil {
 			return c.Failure(err)
 		}
 
@@ -27,6 +27,10 @@ func TestIndexHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.SearchPosts) error {
 		return nil
 	})
@@ -65,6 +69,10 @@ func TestDetailsHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.UserSubscribedTo) error {
 		return nil
 	})
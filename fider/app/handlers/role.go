@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ManageRoles is the page used by administrators to manage custom roles
+func ManageRoles() web.HandlerFunc {
+	return func(c *web.Context) error {
+		listRoles := &query.ListRoles{}
+		if err := bus.Dispatch(c, listRoles); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageRoles.page",
+			Title: "Roles · Site Settings",
+			Data: web.Map{
+				"roles":       listRoles.Result,
+				"permissions": enum.Permissions,
+				"presets": web.Map{
+					enum.RoleCollaborator.String():  enum.RoleCollaborator.Permissions(),
+					enum.RoleAdministrator.String(): enum.RoleAdministrator.Permissions(),
+				},
+			},
+		})
+	}
+}
+
+// CreateRole creates a new custom role
+func CreateRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.CreateEditRole{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addNewRole := &cmd.AddNewRole{
+			Name:        action.Name,
+			Description: action.Description,
+			Permissions: action.Permissions,
+		}
+		if err := bus.Dispatch(c, addNewRole); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditRoleCreated,
+			TargetType: "role",
+			TargetID:   addNewRole.Result.ID,
+			TargetName: addNewRole.Result.Name,
+			After:      roleAuditProps(addNewRole.Result),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addNewRole.Result)
+	}
+}
+
+// UpdateRole changes an existing custom role
+func UpdateRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.CreateEditRole{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		updateRole := &cmd.UpdateRole{
+			RoleID:      action.Role.ID,
+			Name:        action.Name,
+			Description: action.Description,
+			Permissions: action.Permissions,
+		}
+		if err := bus.Dispatch(c, updateRole); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditRoleUpdated,
+			TargetType: "role",
+			TargetID:   updateRole.Result.ID,
+			TargetName: updateRole.Result.Name,
+			Before:     roleAuditProps(action.Role),
+			After:      roleAuditProps(updateRole.Result),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(updateRole.Result)
+	}
+}
+
+// DeleteRole deletes an existing custom role, members assigned to it fall back to their built-in role
+func DeleteRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.DeleteRole{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteRole{Role: action.Role}, &cmd.AddAuditLog{
+			Action:     enum.AuditRoleDeleted,
+			TargetType: "role",
+			TargetID:   action.Role.ID,
+			TargetName: action.Role.Name,
+			Before:     roleAuditProps(action.Role),
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// AssignCustomRole assigns a custom role to a member, or removes it when no role is given
+func AssignCustomRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.AssignCustomRole{}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		before := dto.Props{"customRole": nil}
+		if action.User.CustomRole != nil {
+			before["customRole"] = action.User.CustomRole.Name
+		}
+		after := dto.Props{"customRole": nil}
+		if action.Role != nil {
+			after["customRole"] = action.Role.Name
+		}
+
+		err := bus.Dispatch(c, &cmd.AssignCustomRole{UserID: action.User.ID, Role: action.Role}, &cmd.AddAuditLog{
+			Action:     enum.AuditUserCustomRoleChanged,
+			TargetType: "user",
+			TargetID:   action.User.ID,
+			TargetName: action.User.Name,
+			Before:     before,
+			After:      after,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+func roleAuditProps(role *entity.Role) dto.Props {
+	return dto.Props{
+		"name":        role.Name,
+		"description": role.Description,
+		"permissions": role.Permissions,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
 
@@ -82,11 +83,25 @@ func UserSettings() web.HandlerFunc {
 			return err
 		}
 
+		user := c.User()
+		twoFactor := web.Map{
+			"isEnabled":  user.IsTwoFactorEnabled,
+			"isRequired": user.RequiresTwoFactor(),
+		}
+		if user.IsTwoFactorEnabled {
+			countRecoveryCodes := &query.CountUnusedRecoveryCodes{UserID: user.ID}
+			if err := bus.Dispatch(c, countRecoveryCodes); err != nil {
+				return err
+			}
+			twoFactor["recoveryCodesLeft"] = countRecoveryCodes.Result
+		}
+
 		return c.Page(http.StatusOK, web.Props{
 			Page:  "MySettings/MySettings.page",
 			Title: "Settings",
 			Data: web.Map{
 				"userSettings": settings.Result,
+				"twoFactor":    twoFactor,
 			},
 		})
 	}
@@ -138,7 +153,22 @@ func ChangeUserRole() web.HandlerFunc {
 			Role:   action.Role,
 		}
 
-		if err := bus.Dispatch(c, changeRole); err != nil {
+		before := dto.Props{"role": action.User.Role}
+		after := dto.Props{"role": action.Role}
+		if action.User.CustomRole != nil {
+			// the custom role is removed along with the role change
+			before["customRole"] = action.User.CustomRole.Name
+			after["customRole"] = nil
+		}
+
+		if err := bus.Dispatch(c, changeRole, &cmd.AddAuditLog{
+			Action:     enum.AuditUserRoleChanged,
+			TargetType: "user",
+			TargetID:   action.User.ID,
+			TargetName: action.User.Name,
+			Before:     before,
+			After:      after,
+		}); err != nil {
 			return c.Failure(err)
 		}
 
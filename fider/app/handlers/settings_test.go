@@ -138,6 +138,12 @@ func TestChangeRoleHandler_Valid(t *testing.T) {
 		return nil
 	})
 
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, _ := server.
 		OnTenant(mock.DemoTenant).
@@ -148,6 +154,10 @@ func TestChangeRoleHandler_Valid(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 	Expect(changeRole.UserID).Equals(mock.AryaStark.ID)
 	Expect(changeRole.Role).Equals(enum.RoleAdministrator)
+	Expect(auditLog.Action).Equals(enum.AuditUserRoleChanged)
+	Expect(auditLog.TargetID).Equals(mock.AryaStark.ID)
+	Expect(auditLog.Before["role"]).Equals(mock.AryaStark.Role)
+	Expect(auditLog.After["role"]).Equals(enum.RoleAdministrator)
 }
 
 func TestChangeUserEmailHandler_Valid(t *testing.T) {
@@ -231,8 +231,12 @@ func VerifySignInCode() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		// Authenticate user
-		webutil.AddAuthUserCookie(c, userByEmail.Result)
+		// Authenticate user, unless a second factor is required
+		if signInUser(c, userByEmail.Result) {
+			return c.Ok(web.Map{
+				"twoFactorRequired": true,
+			})
+		}
 
 		return c.Ok(web.Map{})
 	}
@@ -328,7 +332,9 @@ func VerifySignInKey(kind enum.EmailVerificationKind) web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		webutil.AddAuthUserCookie(c, userByEmail.Result)
+		if signInUser(c, userByEmail.Result) {
+			return c.Redirect(twoFactorURL(c, "/"))
+		}
 
 		baseURL := c.BaseURL()
 		return c.Redirect(baseURL)
@@ -82,6 +82,8 @@ func TestSignInByEmailHandler_NewUser(t *testing.T) {
 func TestSignInByEmailWithNameHandler_NewUser(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	var saveKeyCmd *cmd.SaveVerificationKey
 	bus.AddHandler(func(ctx context.Context, c *cmd.SaveVerificationKey) error {
 		saveKeyCmd = c
@@ -107,6 +109,8 @@ func TestSignInByEmailWithNameHandler_NewUser(t *testing.T) {
 func TestSignInByEmailWithNameHandler_ExistingUser(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
 		if q.Email == "jon.snow@got.com" {
 			q.Result = mock.JonSnow
@@ -328,6 +332,10 @@ func TestVerifySignInKeyHandler_CorrectKey_NewUser(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
 		return app.ErrNotFound
 	})
@@ -637,6 +645,8 @@ func TestVerifySignUpKeyHandler_PendingTenant(t *testing.T) {
 func TestCompleteSignInProfileHandler_UnknownKey(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	server := mock.NewServer()
 
 	code, _ := server.
@@ -650,6 +660,8 @@ func TestCompleteSignInProfileHandler_UnknownKey(t *testing.T) {
 func TestCompleteSignInProfileHandler_ExistingUser_CorrectKey(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	server := mock.NewServer()
 	key := "1234567890"
 
@@ -691,6 +703,8 @@ func TestCompleteSignInProfileHandler_ExistingUser_CorrectKey(t *testing.T) {
 func TestCompleteSignInProfileHandler_CorrectKey(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetWordFilters) error { return nil })
+
 	server := mock.NewServer()
 	key := "1234567890"
 
@@ -12,7 +12,9 @@ import (
 func ManageTags() web.HandlerFunc {
 	return func(c *web.Context) error {
 		getAllTags := &query.GetAllTags{}
-		if err := bus.Dispatch(c, getAllTags); err != nil {
+		getAllTagGroups := &query.GetAllTagGroups{}
+		listRoles := &query.ListRoles{}
+		if err := bus.Dispatch(c, getAllTags, getAllTagGroups, listRoles); err != nil {
 			return c.Failure(err)
 		}
 
@@ -20,7 +22,9 @@ func ManageTags() web.HandlerFunc {
 			Page:  "Administration/pages/ManageTags.page",
 			Title: "Manage Tags · Site Settings",
 			Data: web.Map{
-				"tags": getAllTags.Result,
+				"tags":      getAllTags.Result,
+				"tagGroups": getAllTagGroups.Result,
+				"roles":     listRoles.Result,
 			},
 		})
 	}
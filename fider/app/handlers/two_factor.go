@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/totp"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+	webutil "github.com/getfider/fider/app/pkg/web/util"
+)
+
+// Consecutive failed verifications of the second factor after which the user is locked out for a while,
+// so that codes can't be brute forced
+const (
+	twoFactorMaxFailedAttempts = 5
+	twoFactorLockout           = 15 * time.Minute
+)
+
+// TwoFactorPage renders the page where users verify their second factor, or enroll on 2FA when they haven't yet
+func TwoFactorPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		user, err := twoFactorUser(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if user == nil {
+			return c.Redirect(c.BaseURL())
+		}
+
+		data := web.Map{
+			"isEnabled": user.IsTwoFactorEnabled,
+			"redirect":  twoFactorRedirect(c.QueryParam("redirect")),
+		}
+
+		if !user.IsTwoFactorEnabled {
+			// A pending secret is kept until enrollment completes, so reloading the page doesn't invalidate a scanned QR code
+			getSecret := &query.GetTwoFactorSecret{UserID: user.ID}
+			if err := bus.Dispatch(c, getSecret); err != nil {
+				return c.Failure(err)
+			}
+
+			secret := getSecret.Result
+			if secret == "" {
+				secret, err = totp.GenerateSecret()
+				if err != nil {
+					return c.Failure(err)
+				}
+
+				if err := bus.Dispatch(c, &cmd.SaveTwoFactorSecret{UserID: user.ID, Secret: secret}); err != nil {
+					return c.Failure(err)
+				}
+			}
+
+			account := user.Email
+			if account == "" {
+				account = user.Name
+			}
+			data["secret"] = secret
+			data["uri"] = totp.URI(c.Tenant().Name, account, secret)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "SignIn/TwoFactor.page",
+			Title: "Two-factor authentication",
+			Data:  data,
+		})
+	}
+}
+
+// VerifyTwoFactor checks the second factor of a user and completes the sign in.
+// Users that are enrolling on 2FA receive their recovery codes
+func VerifyTwoFactor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.VerifyTwoFactor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		user, err := twoFactorUser(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if user == nil {
+			return c.Unauthorized()
+		}
+
+		if user.IsTwoFactorEnabled {
+			result, err := limitTwoFactorAttempts(c, user, func() (bool, error) {
+				return verifySecondFactor(c, user, action)
+			})
+			if err != nil {
+				return c.Failure(err)
+			}
+			if result != nil {
+				return c.HandleValidation(result)
+			}
+
+			completeTwoFactorSignIn(c, user)
+			return c.Ok(web.Map{})
+		}
+
+		// When enrolling, the code must match the secret that was shown on the setup page
+		result, err := limitTwoFactorAttempts(c, user, func() (bool, error) {
+			return verifyTwoFactorCode(c, user, action.Code)
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+		if result != nil {
+			return c.HandleValidation(result)
+		}
+
+		// the audit log is attributed to the user that is signing in
+		c.SetUser(user)
+
+		recoveryCodes := entity.GenerateRecoveryCodes()
+		if err := bus.Dispatch(c,
+			&cmd.EnableTwoFactor{
+				UserID:             user.ID,
+				RecoveryCodeHashes: hashRecoveryCodes(recoveryCodes),
+			},
+			&cmd.AddAuditLog{
+				Action:     enum.AuditUserTwoFactorEnabled,
+				TargetType: "user",
+				TargetID:   user.ID,
+				TargetName: user.Name,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		completeTwoFactorSignIn(c, user)
+		return c.Ok(web.Map{
+			"recoveryCodes": recoveryCodes,
+		})
+	}
+}
+
+// DisableTwoFactor turns off two-factor authentication of current user
+func DisableTwoFactor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DisableTwoFactor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		user := c.User()
+		if err := bus.Dispatch(c,
+			&cmd.DisableTwoFactor{UserID: user.ID},
+			&cmd.AddAuditLog{
+				Action:     enum.AuditUserTwoFactorDisabled,
+				TargetType: "user",
+				TargetID:   user.ID,
+				TargetName: user.Name,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of current user
+func RegenerateRecoveryCodes() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RegenerateRecoveryCodes)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		user := c.User()
+		recoveryCodes := entity.GenerateRecoveryCodes()
+		if err := bus.Dispatch(c,
+			&cmd.ReplaceRecoveryCodes{
+				UserID:             user.ID,
+				RecoveryCodeHashes: hashRecoveryCodes(recoveryCodes),
+			},
+			&cmd.AddAuditLog{
+				Action:     enum.AuditUserRecoveryCodesRegenerated,
+				TargetType: "user",
+				TargetID:   user.ID,
+				TargetName: user.Name,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"recoveryCodes": recoveryCodes,
+		})
+	}
+}
+
+// signInUser authenticates given user, unless a second factor is required.
+// In that case the user is remembered for a few minutes and true is returned so they can be sent to the 2FA page
+func signInUser(c *web.Context, user *entity.User) bool {
+	if user.RequiresTwoFactor() {
+		webutil.SetTwoFactorPendingCookie(c, user)
+		return true
+	}
+
+	webutil.AddAuthUserCookie(c, user)
+	return false
+}
+
+// twoFactorURL returns the address of the 2FA page that goes back to given path once verified
+func twoFactorURL(c *web.Context, redirect string) string {
+	return c.BaseURL() + "/signin/2fa?redirect=" + url.QueryEscape(twoFactorRedirect(redirect))
+}
+
+// twoFactorUser returns the user that is verifying their second factor.
+// It's either a user half-way through signing in or the current user confirming their identity before a sensitive action
+func twoFactorUser(c *web.Context) (*entity.User, error) {
+	if userID := webutil.GetTwoFactorPendingUserID(c); userID > 0 {
+		getUser := &query.GetUserByID{UserID: userID}
+		err := bus.Dispatch(c, getUser)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return nil, err
+		}
+
+		user := getUser.Result
+		if err == nil && user.Tenant.ID == c.Tenant().ID && user.Status != enum.UserBlocked {
+			return user, nil
+		}
+		c.RemoveCookie(web.CookieTwoFactorName)
+	}
+	return c.User(), nil
+}
+
+func twoFactorRedirect(redirect string) string {
+	if strings.HasPrefix(redirect, "/") && !strings.HasPrefix(redirect, "//") && !strings.HasPrefix(redirect, "/\\") {
+		return redirect
+	}
+	return "/"
+}
+
+func completeTwoFactorSignIn(c *web.Context, user *entity.User) {
+	c.RemoveCookie(web.CookieTwoFactorName)
+	webutil.AddTwoFactorAuthUserCookie(c, user)
+}
+
+// limitTwoFactorAttempts runs given verification unless the user is locked out after too many failed attempts.
+// Failures are counted per user on the server, so they can't be reset by dropping cookies or switching addresses.
+// The returned validation result is nil when the verification succeeds
+func limitTwoFactorAttempts(c *web.Context, user *entity.User, verify func() (bool, error)) (*validate.Result, error) {
+	getLockout := &query.GetTwoFactorLockout{UserID: user.ID}
+	if err := bus.Dispatch(c, getLockout); err != nil {
+		return nil, err
+	}
+	if time.Now().Before(getLockout.Result) {
+		result := validate.Success()
+		result.AddFieldFailure("code", "Too many failed attempts, please try again later.")
+		return result, nil
+	}
+
+	ok, err := verify()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		if err := bus.Dispatch(c, &cmd.RecordTwoFactorFailure{
+			UserID:      user.ID,
+			MaxAttempts: twoFactorMaxFailedAttempts,
+			Lockout:     twoFactorLockout,
+		}); err != nil {
+			return nil, err
+		}
+		return invalidTwoFactorCode(), nil
+	}
+
+	if err := bus.Dispatch(c, &cmd.ResetTwoFactorFailures{UserID: user.ID}); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func verifySecondFactor(c *web.Context, user *entity.User, action *actions.VerifyTwoFactor) (bool, error) {
+	if action.RecoveryCode != "" {
+		useRecoveryCode := &cmd.UseRecoveryCode{
+			UserID:   user.ID,
+			CodeHash: entity.HashRecoveryCode(action.RecoveryCode),
+		}
+		if err := bus.Dispatch(c, useRecoveryCode); err != nil {
+			return false, err
+		}
+		return useRecoveryCode.Result, nil
+	}
+	return verifyTwoFactorCode(c, user, action.Code)
+}
+
+func verifyTwoFactorCode(c *web.Context, user *entity.User, code string) (bool, error) {
+	getSecret := &query.GetTwoFactorSecret{UserID: user.ID}
+	if err := bus.Dispatch(c, getSecret); err != nil {
+		return false, err
+	}
+	if getSecret.Result == "" {
+		return false, nil
+	}
+
+	step, ok := totp.Validate(getSecret.Result, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	// each code can only be used once
+	markStepUsed := &cmd.MarkTwoFactorStepUsed{UserID: user.ID, Step: step}
+	if err := bus.Dispatch(c, markStepUsed); err != nil {
+		return false, err
+	}
+	return markStepUsed.Result, nil
+}
+
+func hashRecoveryCodes(codes []string) []string {
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = entity.HashRecoveryCode(code)
+	}
+	return hashes
+}
+
+func invalidTwoFactorCode() *validate.Result {
+	result := validate.Success()
+	result.AddFieldFailure("code", "Invalid code, please try again.")
+	return result
+}
@@ -0,0 +1,383 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/base32"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/jsonq"
+	"github.com/getfider/fider/app/pkg/jwt"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/pkg/totp"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+var twoFactorSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func twoFactorPendingToken(user *entity.User) string {
+	token, _ := jwt.Encode(jwt.TwoFactorClaims{
+		UserID: user.ID,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(10 * time.Minute)),
+		},
+	})
+	return token
+}
+
+type twoFactorAttempts struct {
+	failures int
+	resets   int
+}
+
+func mockTwoFactorAttempts(lockedUntil time.Time) *twoFactorAttempts {
+	attempts := &twoFactorAttempts{}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorLockout) error {
+		q.Result = lockedUntil
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordTwoFactorFailure) error {
+		attempts.failures++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.ResetTwoFactorFailures) error {
+		attempts.resets++
+		return nil
+	})
+	return attempts
+}
+
+func findCookie(response *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, c := range response.Header()["Set-Cookie"] {
+		cookie := web.ParseCookie(c)
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestVerifySignInCodeHandler_CorrectCode_TwoFactorEnabled(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetVerificationByEmailAndCode) error {
+		q.Result = &entity.EmailVerification{
+			Email:     "jon.snow@got.com",
+			Key:       "123456",
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(15 * time.Minute),
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetKeyAsVerified) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePost(handlers.VerifySignInCode(), `{ "email": "jon.snow@got.com", "code": "123456" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(jsonq.New(response.Body.String()).Contains("twoFactorRequired")).IsTrue()
+	ExpectFiderAuthCookie(response, nil)
+	Expect(findCookie(response, web.CookieTwoFactorName)).IsNotNil()
+}
+
+func TestVerifySignInKeyHandler_CorrectKey_TwoFactorRequiredByTenant(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetVerificationByKey) error {
+		q.Result = &entity.EmailVerification{
+			Key:       q.Key,
+			Kind:      q.Kind,
+			ExpiresAt: time.Now().Add(5 * time.Minute),
+			Email:     "jon.snow@got.com",
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetKeyAsVerified) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/signin/verify?k=1234567890").
+		Execute(handlers.VerifySignInKey(enum.EmailVerificationKindSignIn))
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("http://demo.test.fider.io/signin/2fa?redirect=%2F")
+	ExpectFiderAuthCookie(response, nil)
+	Expect(findCookie(response, web.CookieTwoFactorName)).IsNotNil()
+}
+
+func TestVerifyTwoFactorHandler_WithoutPendingUser(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AddHeader("Accept", "application/json").
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "code": "123456" }`)
+
+	Expect(code).Equals(http.StatusUnauthorized)
+}
+
+func TestVerifyTwoFactorHandler_ValidCode(t *testing.T) {
+	RegisterT(t)
+	attempts := mockTwoFactorAttempts(time.Time{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorSecret) error {
+		Expect(q.UserID).Equals(mock.JonSnow.ID)
+		q.Result = twoFactorSecret
+		return nil
+	})
+
+	var usedStep int64
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkTwoFactorStepUsed) error {
+		usedStep = c.Step
+		c.Result = true
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	totpCode, _ := totp.Code(twoFactorSecret, time.Now())
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "code": "`+totpCode+`" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(usedStep).Equals(totp.Step(time.Now()))
+	Expect(attempts.resets).Equals(1)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+
+	claims, err := jwt.DecodeFiderClaims(findCookie(response, web.CookieAuthName).Value)
+	Expect(err).IsNil()
+	Expect(claims.TwoFactorVerifiedAt.Time).TemporarilySimilar(time.Now(), 5*time.Second)
+	Expect(findCookie(response, web.CookieTwoFactorName).MaxAge).Equals(-1)
+}
+
+func TestVerifyTwoFactorHandler_ReplayedCode(t *testing.T) {
+	RegisterT(t)
+	attempts := mockTwoFactorAttempts(time.Time{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorSecret) error {
+		q.Result = twoFactorSecret
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkTwoFactorStepUsed) error {
+		c.Result = false
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	totpCode, _ := totp.Code(twoFactorSecret, time.Now())
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "code": "`+totpCode+`" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(attempts.failures).Equals(1)
+	ExpectFiderAuthCookie(response, nil)
+}
+
+func TestVerifyTwoFactorHandler_LockedOut(t *testing.T) {
+	RegisterT(t)
+	attempts := mockTwoFactorAttempts(time.Now().Add(10 * time.Minute))
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	totpCode, _ := totp.Code(twoFactorSecret, time.Now())
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "code": "`+totpCode+`" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(attempts.failures).Equals(0)
+	ExpectFiderAuthCookie(response, nil)
+}
+
+func TestVerifyTwoFactorHandler_RecoveryCode(t *testing.T) {
+	RegisterT(t)
+	mockTwoFactorAttempts(time.Time{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseRecoveryCode) error {
+		c.Result = c.CodeHash == entity.HashRecoveryCode("abcde-fghij")
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "recoveryCode": "ABCDE FGHIJ" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+}
+
+func TestVerifyTwoFactorHandler_Enroll(t *testing.T) {
+	RegisterT(t)
+	mockTwoFactorAttempts(time.Time{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorSecret) error {
+		q.Result = twoFactorSecret
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkTwoFactorStepUsed) error {
+		c.Result = true
+		return nil
+	})
+
+	var enabled *cmd.EnableTwoFactor
+	bus.AddHandler(func(ctx context.Context, c *cmd.EnableTwoFactor) error {
+		enabled = c
+		return nil
+	})
+
+	var auditLog *cmd.AddAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddAuditLog) error {
+		auditLog = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	totpCode, _ := totp.Code(twoFactorSecret, time.Now())
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecutePost(handlers.VerifyTwoFactor(), `{ "code": "`+totpCode+`" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(jsonq.New(response.Body.String()).String("recoveryCodes[9]")).HasLen(11)
+	Expect(enabled.UserID).Equals(mock.JonSnow.ID)
+	Expect(enabled.RecoveryCodeHashes).HasLen(entity.RecoveryCodesCount)
+	Expect(auditLog.Action).Equals(enum.AuditUserTwoFactorEnabled)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+}
+
+func TestTwoFactorPage_Setup(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	var savedSecret string
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorSecret) error {
+		q.Result = savedSecret
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SaveTwoFactorSecret) error {
+		savedSecret = c.Secret
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, page := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/signin/2fa?redirect=//evil.com").
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecuteAsPage(handlers.TwoFactorPage())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(page.Data["isEnabled"]).Equals(false)
+	Expect(savedSecret).IsNotEmpty()
+	Expect(page.Data["secret"]).Equals(savedSecret)
+	Expect(page.Data["redirect"]).Equals("/")
+}
+
+func TestTwoFactorPage_Setup_KeepsPendingSecret(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTwoFactorSecret) error {
+		q.Result = "JBSWY3DPEHPK3PXP"
+		return nil
+	})
+
+	saved := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.SaveTwoFactorSecret) error {
+		saved = true
+		return nil
+	})
+
+	code, page := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorPendingToken(mock.JonSnow)).
+		ExecuteAsPage(handlers.TwoFactorPage())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(page.Data["secret"]).Equals("JBSWY3DPEHPK3PXP")
+	Expect(saved).IsFalse()
+}
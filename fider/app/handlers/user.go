@@ -1,20 +1,33 @@
 package handlers
 
 import (
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
 // BlockUser is used to block an existing user from using Fider
 func BlockUser() web.HandlerFunc {
 	return func(c *web.Context) error {
-		userID, err := c.ParamAsInt("userID")
+		user, err := getTargetUser(c)
 		if err != nil {
-			return c.NotFound()
+			return c.Failure(err)
 		}
 
-		err = bus.Dispatch(c, &cmd.BlockUser{UserID: userID})
+		err = bus.Dispatch(c, &cmd.BlockUser{UserID: user.ID}, &cmd.AddAuditLog{
+			Action:     enum.AuditUserBlocked,
+			TargetType: "user",
+			TargetID:   user.ID,
+			TargetName: user.Name,
+			Before:     dto.Props{"status": user.Status},
+			After:      dto.Props{"status": enum.UserBlocked},
+		})
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -26,12 +39,19 @@ func BlockUser() web.HandlerFunc {
 // UnblockUser is used to unblock an existing user so they can use Fider again
 func UnblockUser() web.HandlerFunc {
 	return func(c *web.Context) error {
-		userID, err := c.ParamAsInt("userID")
+		user, err := getTargetUser(c)
 		if err != nil {
-			return c.NotFound()
+			return c.Failure(err)
 		}
 
-		err = bus.Dispatch(c, &cmd.UnblockUser{UserID: userID})
+		err = bus.Dispatch(c, &cmd.UnblockUser{UserID: user.ID}, &cmd.AddAuditLog{
+			Action:     enum.AuditUserUnblocked,
+			TargetType: "user",
+			TargetID:   user.ID,
+			TargetName: user.Name,
+			Before:     dto.Props{"status": user.Status},
+			After:      dto.Props{"status": enum.UserActive},
+		})
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -43,12 +63,19 @@ func UnblockUser() web.HandlerFunc {
 // TrustUser is used to trust an existing user
 func TrustUser() web.HandlerFunc {
 	return func(c *web.Context) error {
-		userID, err := c.ParamAsInt("userID")
+		user, err := getTargetUser(c)
 		if err != nil {
-			return c.NotFound()
+			return c.Failure(err)
 		}
 
-		err = bus.Dispatch(c, &cmd.TrustUser{UserID: userID})
+		err = bus.Dispatch(c, &cmd.TrustUser{UserID: user.ID}, &cmd.AddAuditLog{
+			Action:     enum.AuditUserTrusted,
+			TargetType: "user",
+			TargetID:   user.ID,
+			TargetName: user.Name,
+			Before:     dto.Props{"isTrusted": user.IsTrusted},
+			After:      dto.Props{"isTrusted": true},
+		})
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -60,12 +87,19 @@ func TrustUser() web.HandlerFunc {
 // UntrustUser is used to untrust an existing user
 func UntrustUser() web.HandlerFunc {
 	return func(c *web.Context) error {
-		userID, err := c.ParamAsInt("userID")
+		user, err := getTargetUser(c)
 		if err != nil {
-			return c.NotFound()
+			return c.Failure(err)
 		}
 
-		err = bus.Dispatch(c, &cmd.UntrustUser{UserID: userID})
+		err = bus.Dispatch(c, &cmd.UntrustUser{UserID: user.ID}, &cmd.AddAuditLog{
+			Action:     enum.AuditUserUntrusted,
+			TargetType: "user",
+			TargetID:   user.ID,
+			TargetName: user.Name,
+			Before:     dto.Props{"isTrusted": user.IsTrusted},
+			After:      dto.Props{"isTrusted": false},
+		})
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -73,3 +107,27 @@ func UntrustUser() web.HandlerFunc {
 		return c.Ok(web.Map{})
 	}
 }
+
+// getTargetUser returns the user referenced by the userID route param, scoped to the current tenant.
+// Users can only be managed by those who hold every permission they have, so administrators can only be managed by other administrators
+func getTargetUser(c *web.Context) (*entity.User, error) {
+	userID, err := c.ParamAsInt("userID")
+	if err != nil {
+		return nil, app.ErrNotFound
+	}
+
+	getUser := &query.GetUserByID{UserID: userID}
+	if err := bus.Dispatch(c, getUser); err != nil {
+		return nil, err
+	}
+
+	if getUser.Result.Tenant == nil || getUser.Result.Tenant.ID != c.Tenant().ID {
+		return nil, errors.Wrap(app.ErrNotFound, "user '%d' does not belong to current tenant", userID)
+	}
+
+	if !c.User().HasPermissionsOf(getUser.Result) {
+		return nil, errors.Wrap(app.ErrNotFound, "user '%d' has permissions that current user lacks", userID)
+	}
+
+	return getUser.Result, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
@@ -51,6 +52,16 @@ func CreateWebhook() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		if err := bus.Dispatch(c, &cmd.AddAuditLog{
+			Action:     enum.AuditWebhookCreated,
+			TargetType: "webhook",
+			TargetID:   createWebhook.Result,
+			TargetName: action.Name,
+			After:      webhookAuditProps(createWebhook.Name, createWebhook.Type, createWebhook.Status, createWebhook.Url, createWebhook.HttpMethod),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
 		return c.Ok(web.Map{"id": createWebhook.Result})
 	}
 }
@@ -81,7 +92,21 @@ func UpdateWebhook() web.HandlerFunc {
 		if action.Status == enum.WebhookFailed {
 			updateWebhook.Status = enum.WebhookDisabled
 		}
-		if err := bus.Dispatch(c, updateWebhook); err != nil {
+
+		getWebhook := &query.GetWebhook{ID: id}
+		if err := bus.Dispatch(c, getWebhook); err != nil {
+			return c.Failure(err)
+		}
+
+		before := getWebhook.Result
+		if err := bus.Dispatch(c, updateWebhook, &cmd.AddAuditLog{
+			Action:     enum.AuditWebhookUpdated,
+			TargetType: "webhook",
+			TargetID:   id,
+			TargetName: action.Name,
+			Before:     webhookAuditProps(before.Name, before.Type, before.Status, before.Url, before.HttpMethod),
+			After:      webhookAuditProps(updateWebhook.Name, updateWebhook.Type, updateWebhook.Status, updateWebhook.Url, updateWebhook.HttpMethod),
+		}); err != nil {
 			return c.Failure(err)
 		}
 
@@ -97,8 +122,20 @@ func DeleteWebhook() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		getWebhook := &query.GetWebhook{ID: id}
+		if err = bus.Dispatch(c, getWebhook); err != nil {
+			return c.Failure(err)
+		}
+
+		before := getWebhook.Result
 		deleteWebhook := &query.DeleteWebhook{ID: id}
-		if err = bus.Dispatch(c, deleteWebhook); err != nil {
+		if err = bus.Dispatch(c, deleteWebhook, &cmd.AddAuditLog{
+			Action:     enum.AuditWebhookDeleted,
+			TargetType: "webhook",
+			TargetID:   id,
+			TargetName: before.Name,
+			Before:     webhookAuditProps(before.Name, before.Type, before.Status, before.Url, before.HttpMethod),
+		}); err != nil {
 			return c.Failure(err)
 		}
 
@@ -160,3 +197,14 @@ func GetWebhookProps() web.HandlerFunc {
 		return c.Ok(webhookProps.Result)
 	}
 }
+
+// webhookAuditProps lists the webhook fields recorded on the audit log, headers are left out as they often hold secrets
+func webhookAuditProps(name string, webhookType enum.WebhookType, status enum.WebhookStatus, url, httpMethod string) dto.Props {
+	return dto.Props{
+		"name":       name,
+		"type":       webhookType,
+		"status":     status,
+		"url":        url,
+		"httpMethod": httpMethod,
+	}
+}
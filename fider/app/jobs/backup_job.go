@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/backup"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+// BackupJobHandler writes a backup of every active tenant into the blob storage under backups/<tenant id>/,
+// outside of the blobs served by the tenant, and deletes the oldest ones beyond BACKUP_RETENTION
+type BackupJobHandler struct {
+}
+
+func (e BackupJobHandler) Schedule() string {
+	return env.Config.Backup.Schedule
+}
+
+func (e BackupJobHandler) Run(ctx Context) error {
+	q := &query.GetActiveTenants{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get active tenants")
+	}
+
+	failed := 0
+	for _, tenant := range q.Result {
+		tenantCtx := context.WithValue(ctx, app.TenantCtxKey, tenant)
+		if err := backupTenant(tenantCtx, tenant); err != nil {
+			log.Error(tenantCtx, errors.Wrap(err, "failed to backup tenant %d", tenant.ID))
+			failed++
+		}
+	}
+
+	log.Debugf(ctx, "@{Count} site(s) backed up", dto.Props{
+		"Count": len(q.Result) - failed,
+	})
+
+	if failed > 0 {
+		return errors.New("failed to backup %d of %d site(s)", failed, len(q.Result))
+	}
+	return nil
+}
+
+func backupTenant(ctx context.Context, tenant *entity.Tenant) error {
+	passphrase := env.Config.Backup.Passphrase
+	// The random suffix makes keys unguessable, while the timestamp still sorts them
+	key := backup.TenantBlobPrefix(tenant.ID) + "backup-" + time.Now().UTC().Format("20060102T150405Z") + "-" + rand.String(24) + ".zip"
+	contentType := "application/zip"
+	if passphrase != "" {
+		key += ".enc"
+		contentType = "application/octet-stream"
+	}
+
+	// The archive is uploaded while it's written, so it's never held in memory
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := backup.Write(ctx, writer, backup.WriteOptions{Passphrase: passphrase})
+		writer.CloseWithError(err)
+	}()
+
+	err := bus.Dispatch(instanceContext(ctx), &cmd.StoreBlobStream{
+		Key:         key,
+		Reader:      reader,
+		ContentType: contentType,
+	})
+	// Unblocks the writer in case storage gave up before reading everything
+	reader.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return errors.Wrap(err, "failed to store backup '%s'", key)
+	}
+
+	return purgeOldBackups(ctx, tenant)
+}
+
+// instanceContext removes the tenant from ctx, so that blobs are stored outside of its namespace
+func instanceContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, app.TenantCtxKey, nil)
+}
+
+func purgeOldBackups(ctx context.Context, tenant *entity.Tenant) error {
+	retention := env.Config.Backup.Retention
+	if retention <= 0 {
+		return nil
+	}
+
+	ctx = instanceContext(ctx)
+	q := &query.ListBlobs{Prefix: backup.TenantBlobPrefix(tenant.ID)}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to list backups")
+	}
+
+	// Keys hold the UTC timestamp of the backup, so sorting them orders backups from oldest to newest
+	keys := q.Result
+	sort.Strings(keys)
+	for len(keys) > retention {
+		if err := bus.Dispatch(ctx, &cmd.DeleteBlob{Key: keys[0]}); err != nil {
+			return errors.Wrap(err, "failed to delete backup '%s'", keys[0])
+		}
+		keys = keys[1:]
+	}
+	return nil
+}
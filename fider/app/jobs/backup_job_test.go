@@ -0,0 +1,17 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/jobs"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func TestBackupJob_Schedule_IsConfigurable(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Backup.Schedule = "0 0 3 * * *"
+	job := &jobs.BackupJobHandler{}
+	Expect(job.Schedule()).Equals("0 0 3 * * *")
+}
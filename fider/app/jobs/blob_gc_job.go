@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/blobgc"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+// BlobGCJobHandler cross-references the blobs of every active tenant against the database,
+// reporting missing blobs and deleting the ones orphaned for longer than BLOB_GC_GRACE_PERIOD
+type BlobGCJobHandler struct {
+}
+
+func (e BlobGCJobHandler) Schedule() string {
+	return env.Config.BlobGC.Schedule
+}
+
+func (e BlobGCJobHandler) Run(ctx Context) error {
+	q := &query.GetActiveTenants{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get active tenants")
+	}
+
+	total := &blobgc.Report{}
+	failed := 0
+	for _, tenant := range q.Result {
+		tenantCtx := context.WithValue(ctx, app.TenantCtxKey, tenant)
+		report, err := blobgc.Collect(tenantCtx, blobgc.Options{
+			Delete:      true,
+			GracePeriod: env.Config.BlobGC.GracePeriod,
+		})
+		if report != nil {
+			metrics.BlobsDeleted.Add(float64(len(report.Deleted)))
+		}
+		if err != nil {
+			log.Error(tenantCtx, errors.Wrap(err, "failed to collect blobs of tenant %d", tenant.ID))
+			failed++
+			continue
+		}
+
+		if len(report.Missing) > 0 {
+			log.Warnf(tenantCtx, "@{Count} referenced blob(s) are missing: @{Keys}", dto.Props{
+				"Count": len(report.Missing),
+				"Keys":  report.Missing,
+			})
+		}
+
+		total.Referenced += report.Referenced
+		total.Stored += report.Stored
+		total.Missing = append(total.Missing, report.Missing...)
+		total.Orphaned = append(total.Orphaned, report.Orphaned...)
+		total.Deleted = append(total.Deleted, report.Deleted...)
+	}
+
+	metrics.BlobsChecked.WithLabelValues("referenced").Set(float64(total.Referenced))
+	metrics.BlobsChecked.WithLabelValues("stored").Set(float64(total.Stored))
+	metrics.BlobsChecked.WithLabelValues("missing").Set(float64(len(total.Missing)))
+	metrics.BlobsChecked.WithLabelValues("orphaned").Set(float64(len(total.Orphaned)))
+
+	log.Debugf(ctx, "@{Stored} blob(s) checked, @{Missing} missing, @{Orphaned} orphaned and @{Deleted} deleted", dto.Props{
+		"Stored":   total.Stored,
+		"Missing":  len(total.Missing),
+		"Orphaned": len(total.Orphaned),
+		"Deleted":  len(total.Deleted),
+	})
+
+	if failed > 0 {
+		return errors.New("failed to collect blobs of %d of %d site(s)", failed, len(q.Result))
+	}
+	return nil
+}
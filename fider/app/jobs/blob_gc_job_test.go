@@ -0,0 +1,69 @@
+package jobs_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestBlobGCJob_Schedule_IsConfigurable(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.BlobGC.Schedule = "0 0 5 * * *"
+	job := &jobs.BlobGCJobHandler{}
+	Expect(job.Schedule()).Equals("0 0 5 * * *")
+}
+
+func TestBlobGCJob_ShouldDeleteOrphansOfEachTenant(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.BlobGC.GracePeriod = 24 * time.Hour
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveTenants) error {
+		q.Result = []*entity.Tenant{mock.DemoTenant, mock.AvengersTenant}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListReferencedBlobs) error {
+		q.Result = []string{"avatars/jon.png"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListBlobs) error {
+		q.Result = make([]string, 0)
+		if q.Prefix == "attachments/" || q.Prefix == "avatars/" {
+			q.Result = []string{q.Prefix + "jon.png"}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TrackOrphanedBlobs) error {
+		c.Result = map[string]time.Time{"attachments/jon.png": time.Now().Add(-48 * time.Hour)}
+		return nil
+	})
+
+	deleted := make([]string, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteBlob) error {
+		tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+		deleted = append(deleted, tenant.Subdomain+":"+c.Key)
+		return nil
+	})
+
+	job := &jobs.BlobGCJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(strings.Join(deleted, ",")).Equals("demo:attachments/jon.png,avengers:attachments/jon.png")
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
@@ -23,7 +25,7 @@ type fiderJob struct {
 }
 
 func NewJob(ctx context.Context, name string, handler Handler) (string, fiderJob) {
-	schedule := handler.Schedule()
+	schedule := scheduleOf(name, handler)
 	log.Debugf(ctx, "Job '@{JobName}' scheduled to run '@{Schedule}'", dto.Props{
 		"JobName":  name,
 		"Schedule": schedule,
@@ -31,7 +33,30 @@ func NewJob(ctx context.Context, name string, handler Handler) (string, fiderJob
 	return schedule, fiderJob{Name: name, Handler: handler}
 }
 
+// Run is called by the scheduler, it does nothing while the job is paused
 func (j fiderJob) Run() {
+	ctx, trx, err := newJobContext()
+	if err != nil {
+		return
+	}
+	paused, err := isPaused(ctx, j.Name)
+	trx.MustCommit()
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+
+	if paused {
+		log.Debugf(ctx, "Job '@{JobName}' skipped, it is paused", dto.Props{
+			"JobName": j.Name,
+		})
+		return
+	}
+
+	j.run(enum.JobTriggerSchedule)
+}
+
+func (j fiderJob) run(trigger enum.JobTrigger) {
 	locked := false
 	unlock := func() {}
 
@@ -61,19 +86,24 @@ func (j fiderJob) Run() {
 
 	defer func() {
 		if r := recover(); r != nil {
+			err := errors.Panicked(r)
+			metrics.JobDuration.WithLabelValues(j.Name, "failure").Observe(time.Since(start).Seconds())
+			addRun(j.Name, trigger, start, err)
 			logFinish()
-			log.Error(ctx, errors.Panicked(r))
+			log.Error(ctx, err)
 			setLastFailedRun(j.Name, start)
 			trx.MustRollback()
 		}
 	}()
 
-	log.Debugf(ctx, "Job '@{JobName}' started", dto.Props{
+	log.Debugf(ctx, "Job '@{JobName}' started by @{Trigger}", dto.Props{
 		"JobName": j.Name,
+		"Trigger": trigger,
 	})
 
 	locked, unlock = dbx.TryLock(ctx, trx, j.Name)
 	if !locked {
+		metrics.JobLockContention.WithLabelValues(j.Name).Inc()
 		log.Debugf(ctx, "Job '@{JobName}' skipped, could not acquire lock", dto.Props{
 			"JobName": j.Name,
 		})
@@ -84,10 +114,15 @@ func (j fiderJob) Run() {
 	defer logFinish()
 
 	if err := j.Handler.Run(ctx); err != nil {
+		metrics.JobDuration.WithLabelValues(j.Name, "failure").Observe(time.Since(start).Seconds())
+		addRun(j.Name, trigger, start, err)
 		log.Error(ctx, err)
 		setLastFailedRun(j.Name, start)
 		trx.MustRollback()
 	} else {
+		metrics.JobDuration.WithLabelValues(j.Name, "success").Observe(time.Since(start).Seconds())
+		metrics.JobLastSuccess.WithLabelValues(j.Name).SetToCurrentTime()
+		addRun(j.Name, trigger, start, nil)
 		setLastSuccessfulRun(j.Name, start)
 		trx.MustCommit()
 	}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/log"
 )
 
@@ -17,7 +20,14 @@ type Context struct {
 }
 
 func getLastSuccessfulRun(ctx context.Context, jobName string) *time.Time {
-	key := fmt.Sprintf("jobs.%s.last_successful_run", jobName)
+	return getLastRun(ctx, fmt.Sprintf("jobs.%s.last_successful_run", jobName))
+}
+
+func getLastFailedRun(ctx context.Context, jobName string) *time.Time {
+	return getLastRun(ctx, fmt.Sprintf("jobs.%s.last_failed_run", jobName))
+}
+
+func getLastRun(ctx context.Context, key string) *time.Time {
 	get := &query.GetSystemSettings{
 		Key: key,
 	}
@@ -61,3 +71,51 @@ func setLastRun(key string, t time.Time) {
 		log.Error(ctx, err)
 	}
 }
+
+func isPaused(ctx context.Context, jobName string) (bool, error) {
+	get := &query.GetSystemSettings{
+		Key: fmt.Sprintf("jobs.%s.paused", jobName),
+	}
+	if err := bus.Dispatch(ctx, get); err != nil {
+		return false, err
+	}
+	return get.Value == "true", nil
+}
+
+func setPaused(ctx context.Context, jobName string, paused bool) error {
+	return bus.Dispatch(ctx, &cmd.SetSystemSettings{
+		Key:   fmt.Sprintf("jobs.%s.paused", jobName),
+		Value: fmt.Sprint(paused),
+	})
+}
+
+// addRun records a finished run on the job history, which keeps the last JOBS_HISTORY_SIZE runs of each job
+func addRun(jobName string, trigger enum.JobTrigger, start time.Time, runErr error) {
+	ctx, trx, err := newJobContext()
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	defer trx.MustCommit()
+
+	finishedAt := time.Now()
+	run := &entity.JobRun{
+		JobName:    jobName,
+		Trigger:    trigger,
+		Status:     enum.JobRunSucceeded,
+		StartedAt:  start,
+		FinishedAt: finishedAt,
+		DurationMs: finishedAt.Sub(start).Milliseconds(),
+	}
+	if runErr != nil {
+		run.Status = enum.JobRunFailed
+		run.Error = runErr.Error()
+	}
+
+	if err = bus.Dispatch(ctx, &cmd.AddJobRun{
+		Run:  run,
+		Keep: env.Config.Jobs.HistorySize,
+	}); err != nil {
+		log.Error(ctx, err)
+	}
+}
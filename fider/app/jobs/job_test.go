@@ -7,13 +7,28 @@ import (
 	"testing"
 	"time"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clientmodel "github.com/prometheus/client_model/go"
 )
 
+// sampleCount returns the number of observations of a histogram with given labels
+func sampleCount(histogram *prometheus.HistogramVec, labels ...string) uint64 {
+	metric := &clientmodel.Metric{}
+	_ = histogram.WithLabelValues(labels...).(prometheus.Histogram).Write(metric)
+	return metric.GetHistogram().GetSampleCount()
+}
+
 type MockJobHandler struct {
 	WaitTime   time.Duration
 	ShouldFail bool
@@ -38,7 +53,9 @@ func TestJob_WhenSuccessful_ShouldUpdateLastSuccessfulRun(t *testing.T) {
 	RegisterT(t)
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
-		Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		if q.Key != "jobs.Test.paused" {
+			Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		}
 		return nil
 	})
 
@@ -48,19 +65,37 @@ func TestJob_WhenSuccessful_ShouldUpdateLastSuccessfulRun(t *testing.T) {
 		return nil
 	})
 
+	var run *entity.JobRun
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		run = c.Run
+		return nil
+	})
+
+	successes := sampleCount(metrics.JobDuration, "Test", "success")
+
 	schedule, job := jobs.NewJob(context.Background(), "Test", MockJobHandler{
 		ShouldFail: false,
 	})
 	Expect(schedule).Equals("0 * * * * *")
 
 	job.Run()
+
+	Expect(run.JobName).Equals("Test")
+	Expect(run.Trigger).Equals(enum.JobTriggerSchedule)
+	Expect(run.Status).Equals(enum.JobRunSucceeded)
+	Expect(run.Error).Equals("")
+	Expect(sampleCount(metrics.JobDuration, "Test", "success")).Equals(successes + 1)
+	lastSuccess := time.Unix(int64(testutil.ToFloat64(metrics.JobLastSuccess.WithLabelValues("Test"))), 0)
+	Expect(lastSuccess).TemporarilySimilar(time.Now(), 5*time.Second)
 }
 
 func TestJob_WhenError_ShouldUpdateLastFailedRun(t *testing.T) {
 	RegisterT(t)
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
-		Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		if q.Key != "jobs.Test.paused" {
+			Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		}
 		return nil
 	})
 
@@ -70,12 +105,26 @@ func TestJob_WhenError_ShouldUpdateLastFailedRun(t *testing.T) {
 		return nil
 	})
 
+	var run *entity.JobRun
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		run = c.Run
+		return nil
+	})
+
+	failures := sampleCount(metrics.JobDuration, "Test", "failure")
+	lastSuccess := testutil.ToFloat64(metrics.JobLastSuccess.WithLabelValues("Test"))
+
 	schedule, job := jobs.NewJob(context.Background(), "Test", MockJobHandler{
 		ShouldFail: true,
 	})
 	Expect(schedule).Equals("0 * * * * *")
 
 	job.Run()
+
+	Expect(run.Status).Equals(enum.JobRunFailed)
+	Expect(run.Error).ContainsSubstring("Failed")
+	Expect(sampleCount(metrics.JobDuration, "Test", "failure")).Equals(failures + 1)
+	Expect(testutil.ToFloat64(metrics.JobLastSuccess.WithLabelValues("Test"))).Equals(lastSuccess)
 }
 
 func TestJob_WhenTwoConcurrentRuns_ShouldExecuteOnce(t *testing.T) {
@@ -91,6 +140,12 @@ func TestJob_WhenTwoConcurrentRuns_ShouldExecuteOnce(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		return nil
+	})
+
+	contention := testutil.ToFloat64(metrics.JobLockContention.WithLabelValues("Test"))
+
 	_, job1 := jobs.NewJob(context.Background(), "Test", MockJobHandler{
 		WaitTime: 1 * time.Second,
 	})
@@ -113,4 +168,121 @@ func TestJob_WhenTwoConcurrentRuns_ShouldExecuteOnce(t *testing.T) {
 	wg.Wait()
 
 	Expect(counter).Equals(1)
+	Expect(testutil.ToFloat64(metrics.JobLockContention.WithLabelValues("Test"))).Equals(contention + 1)
+}
+
+func TestJob_WhenPaused_ShouldNotRun(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		Expect(q.Key).Equals("jobs.Test.paused")
+		q.Value = "true"
+		return nil
+	})
+
+	_, job := jobs.NewJob(context.Background(), "Test", MockJobHandler{
+		ShouldFail: true,
+	})
+
+	job.Run()
+}
+
+func TestJob_ScheduleCanBeOverridden(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Jobs.Schedules = "Other=@every 5m; Test=0 30 * * * *"
+	defer func() { env.Config.Jobs.Schedules = "" }()
+
+	schedule, _ := jobs.NewJob(context.Background(), "Test", MockJobHandler{})
+	Expect(schedule).Equals("0 30 * * * *")
+
+	schedule, _ = jobs.NewJob(context.Background(), "Unknown", MockJobHandler{})
+	Expect(schedule).Equals("0 * * * * *")
+}
+
+func TestJob_InvalidScheduleOverride(t *testing.T) {
+	RegisterT(t)
+
+	defer func() { env.Config.Jobs.Schedules = "" }()
+
+	for _, schedules := range []string{"Test", "Test=", "Test=not a schedule"} {
+		env.Config.Jobs.Schedules = schedules
+		Expect(func() {
+			jobs.NewJob(context.Background(), "Test", MockJobHandler{})
+		}).Panics()
+	}
+}
+
+func TestList_ShouldReturnRegisteredJobs(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Jobs.Schedules = "ListTest=@every 2h"
+	defer func() { env.Config.Jobs.Schedules = "" }()
+
+	jobs.Register(context.Background(), "ListTest", MockJobHandler{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		switch q.Key {
+		case "jobs.ListTest.paused":
+			q.Value = "true"
+		case "jobs.ListTest.last_failed_run":
+			q.Value = "2025-12-01T10:00:00Z"
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListJobRuns) error {
+		Expect(q.JobName).Equals("ListTest")
+		Expect(q.Limit).Equals(env.Config.Jobs.HistorySize)
+		q.Result = []*entity.JobRun{
+			{JobName: "ListTest", Status: enum.JobRunSucceeded},
+			{JobName: "ListTest", Status: enum.JobRunFailed, Error: "Failed again"},
+			{JobName: "ListTest", Status: enum.JobRunFailed, Error: "Failed"},
+		}
+		return nil
+	})
+
+	list, err := jobs.List(context.Background())
+	Expect(err).IsNil()
+
+	var job *entity.Job
+	for _, j := range list {
+		if j.Name == "ListTest" {
+			job = j
+		}
+	}
+	Expect(job).IsNotNil()
+	Expect(job.Schedule).Equals("@every 2h")
+	Expect(job.DefaultSchedule).Equals("0 * * * * *")
+	Expect(job.IsPaused).IsTrue()
+	Expect(job.LastSuccessfulRun).IsNil()
+	Expect(job.LastFailedRun.Format(time.RFC3339)).Equals("2025-12-01T10:00:00Z")
+	Expect(job.LastError).Equals("Failed again")
+	Expect(job.Runs).HasLen(3)
+}
+
+func TestTriggerAndSetPaused_UnknownJob(t *testing.T) {
+	RegisterT(t)
+
+	Expect(jobs.Trigger("Unknown")).Equals(app.ErrNotFound)
+	Expect(jobs.SetPaused(context.Background(), "Unknown", true)).Equals(app.ErrNotFound)
+}
+
+func TestSetPaused(t *testing.T) {
+	RegisterT(t)
+
+	jobs.Register(context.Background(), "PauseTest", MockJobHandler{})
+
+	var saved *cmd.SetSystemSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetSystemSettings) error {
+		saved = c
+		return nil
+	})
+
+	Expect(jobs.SetPaused(context.Background(), "PauseTest", true)).IsNil()
+	Expect(saved.Key).Equals("jobs.PauseTest.paused")
+	Expect(saved.Value).Equals("true")
+
+	Expect(jobs.SetPaused(context.Background(), "PauseTest", false)).IsNil()
+	Expect(saved.Value).Equals("false")
 }
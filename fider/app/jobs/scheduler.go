@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+type registration struct {
+	schedule string
+	job      fiderJob
+}
+
+var (
+	registry     []registration
+	registryLock sync.RWMutex
+)
+
+// Register adds a job to the list of jobs scheduled by Start, replacing the job previously registered with the same name
+func Register(ctx context.Context, name string, handler Handler) {
+	schedule, job := NewJob(ctx, name, handler)
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	for i, r := range registry {
+		if r.job.Name == name {
+			registry[i] = registration{schedule: schedule, job: job}
+			return
+		}
+	}
+	registry = append(registry, registration{schedule: schedule, job: job})
+}
+
+// Start runs every registered job on its schedule
+func Start() {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	c := cron.New()
+	for _, r := range registry {
+		if err := c.AddJob(r.schedule, r.job); err != nil {
+			panic(errors.Wrap(err, "invalid schedule '%s' of job '%s'", r.schedule, r.job.Name))
+		}
+	}
+	c.Start()
+}
+
+// List returns every registered job with its state and most recent runs
+func List(ctx context.Context) ([]*entity.Job, error) {
+	registryLock.RLock()
+	registered := append([]registration{}, registry...)
+	registryLock.RUnlock()
+
+	result := make([]*entity.Job, 0, len(registered))
+	for _, r := range registered {
+		listRuns := &query.ListJobRuns{JobName: r.job.Name, Limit: env.Config.Jobs.HistorySize}
+		if err := bus.Dispatch(ctx, listRuns); err != nil {
+			return nil, err
+		}
+
+		paused, err := isPaused(ctx, r.job.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		job := &entity.Job{
+			Name:              r.job.Name,
+			Schedule:          r.schedule,
+			DefaultSchedule:   r.job.Handler.Schedule(),
+			IsPaused:          paused,
+			LastSuccessfulRun: getLastSuccessfulRun(ctx, r.job.Name),
+			LastFailedRun:     getLastFailedRun(ctx, r.job.Name),
+			Runs:              listRuns.Result,
+		}
+		for _, run := range listRuns.Result {
+			if run.Status == enum.JobRunFailed {
+				job.LastError = run.Error
+				break
+			}
+		}
+		result = append(result, job)
+	}
+	return result, nil
+}
+
+// Trigger starts a run of a registered job on background, even if the job is paused
+func Trigger(name string) error {
+	r, ok := find(name)
+	if !ok {
+		return app.ErrNotFound
+	}
+
+	go r.job.run(enum.JobTriggerManual)
+	return nil
+}
+
+// SetPaused pauses or resumes the scheduled runs of a registered job on every instance
+func SetPaused(ctx context.Context, name string, paused bool) error {
+	if _, ok := find(name); !ok {
+		return app.ErrNotFound
+	}
+	return setPaused(ctx, name, paused)
+}
+
+func find(name string) (registration, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	for _, r := range registry {
+		if r.job.Name == name {
+			return r, true
+		}
+	}
+	return registration{}, false
+}
+
+// scheduleOf returns the schedule of a job, which can be overridden with JOBS_SCHEDULES
+func scheduleOf(name string, handler Handler) string {
+	if schedule, ok := parseSchedules(env.Config.Jobs.Schedules)[name]; ok {
+		return schedule
+	}
+	return handler.Schedule()
+}
+
+// parseSchedules parses a list of schedules in the format Name=spec;Name=spec
+func parseSchedules(value string) map[string]string {
+	schedules := make(map[string]string)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, spec, ok := strings.Cut(item, "=")
+		name, spec = strings.TrimSpace(name), strings.TrimSpace(spec)
+		if !ok || name == "" || spec == "" {
+			panic(errors.New("invalid JOBS_SCHEDULES entry '%s', expected JobName=schedule", item))
+		}
+		if _, err := cron.Parse(spec); err != nil {
+			panic(errors.Wrap(err, "invalid schedule '%s' of job '%s' on JOBS_SCHEDULES", spec, name))
+		}
+		schedules[name] = spec
+	}
+	return schedules
+}
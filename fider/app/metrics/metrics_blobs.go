@@ -0,0 +1,22 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var BlobsChecked = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "fider_blobs",
+		Help: "Number of blobs found by the last garbage collection, by state: referenced, stored, missing or orphaned.",
+	},
+	[]string{"state"},
+)
+
+var BlobsDeleted = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "fider_blobs_deleted_total",
+		Help: "Number of orphaned blobs deleted by garbage collection.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(BlobsChecked, BlobsDeleted)
+}
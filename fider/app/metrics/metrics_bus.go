@@ -0,0 +1,13 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var BusDispatchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "fider_bus_dispatch_duration_seconds",
+	Help:    "Duration of commands and queries dispatched through the bus, by handled message.",
+	Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+}, []string{"handler", "outcome"})
+
+func init() {
+	prometheus.MustRegister(BusDispatchDuration)
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var EmailsSent = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_emails_sent_total",
+		Help: "Number of emails sent.",
+	},
+	[]string{"provider", "template"},
+)
+
+var EmailFailures = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_email_failures_total",
+		Help: "Number of emails that could not be sent.",
+	},
+	[]string{"provider", "template"},
+)
+
+func init() {
+	prometheus.MustRegister(EmailsSent, EmailFailures)
+}
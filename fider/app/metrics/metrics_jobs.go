@@ -0,0 +1,29 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var JobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "fider_job_duration_seconds",
+	Help:    "Duration of scheduled jobs.",
+	Buckets: []float64{1, 5, 15, 60, 300, 900},
+}, []string{"job", "outcome"})
+
+var JobLastSuccess = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "fider_job_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful run of scheduled jobs.",
+	},
+	[]string{"job"},
+)
+
+var JobLockContention = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_job_lock_contention_total",
+		Help: "Number of scheduled job runs skipped because another instance held the lock.",
+	},
+	[]string{"job"},
+)
+
+func init() {
+	prometheus.MustRegister(JobDuration, JobLastSuccess, JobLockContention)
+}
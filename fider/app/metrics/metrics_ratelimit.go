@@ -0,0 +1,23 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var RateLimitRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_rate_limit_requests_total",
+		Help: "Number of requests evaluated by the rate limiter.",
+	},
+	[]string{"bucket", "result"},
+)
+
+var RateLimitErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_rate_limit_errors_total",
+		Help: "Number of requests allowed because the rate limit backend failed.",
+	},
+	[]string{"bucket"},
+)
+
+func init() {
+	prometheus.MustRegister(RateLimitRequests, RateLimitErrors)
+}
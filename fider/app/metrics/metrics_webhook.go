@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var WebhookDeliveries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_webhook_deliveries_total",
+		Help: "Number of webhook deliveries. Status code is 'error' when the request failed without a response.",
+	},
+	[]string{"type", "status_code"},
+)
+
+func init() {
+	prometheus.MustRegister(WebhookDeliveries)
+}
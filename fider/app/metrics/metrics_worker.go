@@ -0,0 +1,35 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var WorkerQueueLength = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "fider_worker_queue_length",
+		Help: "Number of tasks waiting or running on the background worker.",
+	},
+)
+
+var WorkerDeadTasks = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "fider_worker_dead_tasks",
+		Help: "Number of stored tasks that failed on all their attempts and won't be retried.",
+	},
+)
+
+var WorkerTasks = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fider_worker_tasks_total",
+		Help: "Number of background tasks executed.",
+	},
+	[]string{"task", "outcome"},
+)
+
+var WorkerTaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "fider_worker_task_duration_seconds",
+	Help:    "Duration of background tasks.",
+	Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60},
+}, []string{"task", "outcome"})
+
+func init() {
+	prometheus.MustRegister(WorkerQueueLength, WorkerDeadTasks, WorkerTasks, WorkerTaskDuration)
+}
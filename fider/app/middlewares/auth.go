@@ -1,10 +1,18 @@
 package middlewares
 
 import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
+// TwoFactorWindow is for how long a second factor verification allows sensitive actions
+const TwoFactorWindow = 15 * time.Minute
+
 // IsAuthenticated blocks non-authenticated requests
 func IsAuthenticated() web.MiddlewareFunc {
 	return func(next web.HandlerFunc) web.HandlerFunc {
@@ -31,3 +39,51 @@ func IsAuthorized(roles ...enum.Role) web.MiddlewareFunc {
 		}
 	}
 }
+
+// HasPermission blocks requests from users without all given permissions
+func HasPermission(permissions ...enum.Permission) web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			user := c.User()
+			if user == nil {
+				return c.Unauthorized()
+			}
+			for _, permission := range permissions {
+				if !user.HasPermission(permission) {
+					return c.Forbidden()
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireTwoFactor blocks sensitive requests until the current user has recently verified their second factor.
+// It only applies to cookie sessions of users that have enrolled on 2FA or that are required to by the tenant,
+// requests authenticated with an API key can't step up and are exempt
+func RequireTwoFactor() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			if _, ok := c.Value(app.APIKeyOwnerCtxKey).(int); ok {
+				return next(c)
+			}
+
+			user := c.User()
+			if user == nil || !user.RequiresTwoFactor() {
+				return next(c)
+			}
+
+			if user.IsTwoFactorEnabled {
+				verifiedAt, ok := c.Value(app.TwoFactorVerifiedAtCtxKey).(time.Time)
+				if ok && time.Since(verifiedAt) < TwoFactorWindow {
+					return next(c)
+				}
+			}
+
+			if c.Request.Method == http.MethodGet && !c.IsAjax() {
+				return c.Redirect("/signin/2fa?redirect=" + url.QueryEscape(c.Request.URL.RequestURI()))
+			}
+			return c.JSON(http.StatusForbidden, web.Map{"requiresTwoFactor": true})
+		}
+	}
+}
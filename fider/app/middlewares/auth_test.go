@@ -3,8 +3,11 @@ package middlewares_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/middlewares"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/mock"
@@ -59,3 +62,179 @@ func TestIsAuthenticated_WithoutUser(t *testing.T) {
 
 	Expect(status).Equals(http.StatusUnauthorized)
 }
+
+func TestHasPermission_WithPresetPermission(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionManageSettings))
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestHasPermission_WithoutPermission(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionAccessAdmin))
+	status, _ := server.AsUser(mock.AryaStark).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestHasPermission_WithCustomRole(t *testing.T) {
+	RegisterT(t)
+
+	moderator := &entity.User{
+		ID:   10,
+		Name: "Sam Tarly",
+		Role: enum.RoleVisitor,
+		CustomRole: &entity.Role{
+			ID:          1,
+			Name:        "Moderator",
+			Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionModerateContent},
+		},
+	}
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionModerateContent))
+	status, _ := server.AsUser(moderator).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	Expect(status).Equals(http.StatusOK)
+
+	server = mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionManageSettings))
+	status, _ = server.AsUser(moderator).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestHasPermission_WithoutUser(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionAccessAdmin))
+	status, _ := server.Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusUnauthorized)
+}
+
+func TestRequireTwoFactor_NotRequired(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestRequireTwoFactor_RequiredByTenant_NotEnrolled(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	server.Use(middlewares.RequireTwoFactor())
+	status, response := server.
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/admin/export/backup.zip").
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/signin/2fa?redirect=%2Fadmin%2Fexport%2Fbackup.zip")
+}
+
+func TestRequireTwoFactor_RequiredByTenant_VisitorIsNotAffected(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.AsUser(mock.AryaStark).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestRequireTwoFactor_Enrolled_NotRecentlyVerified(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	server.Use(func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			c.Set(app.TwoFactorVerifiedAtCtxKey, time.Now().Add(-1*time.Hour))
+			return next(c)
+		}
+	})
+	server.Use(middlewares.RequireTwoFactor())
+	status, query := server.
+		AsUser(mock.JonSnow).
+		AddHeader("Accept", "application/json").
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusForbidden)
+	Expect(query.Contains("requiresTwoFactor")).IsTrue()
+}
+
+func TestRequireTwoFactor_Enrolled_RecentlyVerified(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.JonSnow.IsTwoFactorEnabled = true
+
+	server.Use(func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			c.Set(app.TwoFactorVerifiedAtCtxKey, time.Now().Add(-1*time.Minute))
+			return next(c)
+		}
+	})
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestRequireTwoFactor_APIKeyIsExempt(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	server.Use(func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			c.Set(app.APIKeyOwnerCtxKey, mock.JonSnow.ID)
+			return next(c)
+		}
+	})
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.
+		AsUser(mock.JonSnow).
+		AddHeader("Accept", "application/json").
+		ExecutePost(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, "{}")
+
+	Expect(status).Equals(http.StatusOK)
+}
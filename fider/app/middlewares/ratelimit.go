@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// RateLimitRule is the amount of requests allowed within a window of time
+type RateLimitRule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRateLimitRule parses rules written as "<requests>/<window>", e.g. "60/1m" or "1000/1h".
+// The window can be omitted and defaults to one second, e.g. "5" is the same as "5/1s"
+func ParseRateLimitRule(value string) (RateLimitRule, error) {
+	limitText, windowText, hasWindow := strings.Cut(strings.TrimSpace(value), "/")
+
+	limit, err := strconv.Atoi(strings.TrimSpace(limitText))
+	if err != nil || limit < 0 {
+		return RateLimitRule{}, errors.New("invalid rate limit '%s': requests must be a non-negative number", value)
+	}
+
+	window := time.Second
+	if hasWindow {
+		windowText = strings.TrimSpace(windowText)
+		if windowText != "" && (windowText[0] < '0' || windowText[0] > '9') {
+			windowText = "1" + windowText
+		}
+		window, err = time.ParseDuration(windowText)
+		if err != nil || window < time.Second {
+			return RateLimitRule{}, errors.New("invalid rate limit '%s': window must be a duration of at least 1s", value)
+		}
+	}
+
+	return RateLimitRule{Limit: limit, Window: window}, nil
+}
+
+// RateLimit throttles the requests of given bucket according to the rule, e.g. "60/1m".
+// Requests are counted per API Key, User or IP Address (in this order), within the current tenant.
+// A rule of zero requests disables the rate limit of the bucket
+func RateLimit(bucket, value string) web.MiddlewareFunc {
+	if !env.Config.RateLimit.Enabled {
+		return nil
+	}
+
+	rule, err := ParseRateLimitRule(value)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to configure rate limit of '%s'", bucket))
+	}
+
+	if rule.Limit == 0 {
+		return nil
+	}
+
+	policy := fmt.Sprintf("%d;w=%d", rule.Limit, int(rule.Window.Seconds()))
+
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			now := time.Now()
+			windowStart := now.Truncate(rule.Window)
+
+			hit := &cmd.HitRateLimit{
+				Key:         rateLimitKey(c, bucket),
+				WindowStart: windowStart,
+				Window:      rule.Window,
+			}
+			if err := bus.Dispatch(c, hit); err != nil {
+				// an unavailable backend shouldn't take the site down with it
+				metrics.RateLimitErrors.WithLabelValues(bucket).Inc()
+				log.Error(c, errors.Wrap(err, "failed to check rate limit of '%s'", bucket))
+				return next(c)
+			}
+
+			reset := int(math.Ceil(windowStart.Add(rule.Window).Sub(now).Seconds()))
+			remaining := rule.Limit - hit.Result
+			if remaining < 0 {
+				remaining = 0
+			}
+
+			header := c.Response.Header()
+			header.Set("RateLimit-Policy", policy)
+			header.Set("RateLimit-Limit", strconv.Itoa(rule.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(reset))
+
+			if hit.Result > rule.Limit {
+				metrics.RateLimitRequests.WithLabelValues(bucket, "limited").Inc()
+				header.Set("Retry-After", strconv.Itoa(reset))
+				return c.TooManyRequests()
+			}
+
+			metrics.RateLimitRequests.WithLabelValues(bucket, "allowed").Inc()
+			return next(c)
+		}
+	}
+}
+
+func rateLimitKey(c *web.Context, bucket string) string {
+	tenantID := 0
+	if c.Tenant() != nil {
+		tenantID = c.Tenant().ID
+	}
+
+	subject := "ip:" + c.Request.ClientIP
+	if ownerID, ok := c.Value(app.APIKeyOwnerCtxKey).(int); ok {
+		subject = fmt.Sprintf("apikey:%d", ownerID)
+	} else if c.User() != nil {
+		subject = fmt.Sprintf("user:%d", c.User().ID)
+	}
+
+	return fmt.Sprintf("%s:%d:%s", bucket, tenantID, subject)
+}
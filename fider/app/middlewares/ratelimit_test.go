@@ -0,0 +1,139 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/middlewares"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+func TestParseRateLimitRule(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		value  string
+		limit  int
+		window time.Duration
+	}{
+		{"60/1m", 60, time.Minute},
+		{"1000/1h", 1000, time.Hour},
+		{"10/m", 10, time.Minute},
+		{"5", 5, time.Second},
+		{" 0/30s ", 0, 30 * time.Second},
+	}
+
+	for _, testCase := range testCases {
+		rule, err := middlewares.ParseRateLimitRule(testCase.value)
+		Expect(err).IsNil()
+		Expect(rule.Limit).Equals(testCase.limit)
+		Expect(rule.Window).Equals(testCase.window)
+	}
+
+	for _, value := range []string{"", "abc", "-1/1m", "10/abc", "10/500ms"} {
+		_, err := middlewares.ParseRateLimitRule(value)
+		Expect(err).IsNotNil()
+	}
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.RateLimit.Enabled = false
+	Expect(middlewares.RateLimit("auth", "1/1m")).IsNil()
+
+	env.Config.RateLimit.Enabled = true
+	Expect(middlewares.RateLimit("auth", "0/1m")).IsNil()
+}
+
+func TestRateLimit_AllowedAndLimited(t *testing.T) {
+	RegisterT(t)
+	env.Config.RateLimit.Enabled = true
+
+	hits := 0
+	var key string
+	bus.AddHandler(func(ctx context.Context, c *cmd.HitRateLimit) error {
+		hits++
+		key = c.Key
+		c.Result = hits
+		return nil
+	})
+
+	handler := func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	server := mock.NewServer()
+	server.Use(middlewares.RateLimit("auth", "2/1m"))
+	status, response := server.Execute(handler)
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("RateLimit-Limit")).Equals("2")
+	Expect(response.Header().Get("RateLimit-Remaining")).Equals("1")
+	Expect(response.Header().Get("RateLimit-Policy")).Equals("2;w=60")
+	Expect(response.Header().Get("Retry-After")).Equals("")
+	Expect(key).ContainsSubstring("auth:")
+	Expect(key).ContainsSubstring(":ip:")
+
+	server = mock.NewServer()
+	server.Use(middlewares.RateLimit("auth", "2/1m"))
+	status, _ = server.Execute(handler)
+	Expect(status).Equals(http.StatusOK)
+
+	server = mock.NewServer()
+	server.Use(middlewares.RateLimit("auth", "2/1m"))
+	status, response = server.Execute(handler)
+	Expect(status).Equals(http.StatusTooManyRequests)
+	Expect(response.Header().Get("RateLimit-Remaining")).Equals("0")
+	Expect(response.Header().Get("Retry-After")).IsNotEmpty()
+}
+
+func TestRateLimit_KeyedByUser(t *testing.T) {
+	RegisterT(t)
+	env.Config.RateLimit.Enabled = true
+
+	var key string
+	bus.AddHandler(func(ctx context.Context, c *cmd.HitRateLimit) error {
+		key = c.Key
+		c.Result = 1
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.RateLimit("members_api", "60/1m"))
+	status, _ := server.
+		OnTenant(&entity.Tenant{ID: 5}).
+		AsUser(&entity.User{ID: 42}).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(key).Equals("members_api:5:user:42")
+}
+
+func TestRateLimit_BackendFailure(t *testing.T) {
+	RegisterT(t)
+	env.Config.RateLimit.Enabled = true
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.HitRateLimit) error {
+		return errors.New("database is down")
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.RateLimit("public_api", "1/1m"))
+	status, response := server.Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("RateLimit-Limit")).Equals("")
+}
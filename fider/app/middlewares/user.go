@@ -45,6 +45,10 @@ func User() web.MiddlewareFunc {
 					return next(c)
 				}
 
+				if claims.TwoFactorVerifiedAt != nil {
+					c.Set(app.TwoFactorVerifiedAtCtxKey, claims.TwoFactorVerifiedAt.Time)
+				}
+
 				userByClaimsID := &query.GetUserByID{UserID: claims.UserID}
 				err = bus.Dispatch(c, userByClaimsID)
 				user = userByClaimsID.Result
@@ -69,11 +73,17 @@ func User() web.MiddlewareFunc {
 						return err
 					}
 					user = getUserByAPIKey.Result
+					c.Set(app.APIKeyOwnerCtxKey, user.ID)
 
-					if !user.IsCollaborator() {
+					if !user.HasPermission(enum.PermissionUseAPI) {
 						return c.HandleValidation(validate.Failed("API Key is invalid"))
 					}
 
+					// staff must enroll on 2FA before their API Keys can be used when the tenant requires it
+					if user.RequiresTwoFactor() && !user.IsTwoFactorEnabled {
+						return c.HandleValidation(validate.Failed("Two-factor authentication must be enabled to use the API"))
+					}
+
 					if impersonateUserIDStr := c.Request.GetHeader("X-Fider-UserID"); impersonateUserIDStr != "" {
 						if !user.IsAdministrator() {
 							return c.HandleValidation(validate.Failed("Only Administrators are allowed to impersonate another user"))
@@ -426,3 +426,67 @@ func TestUser_Impersonation_ValidUser(t *testing.T) {
 	Expect(status).Equals(http.StatusOK)
 	Expect(response.Body.String()).Equals("Arya Stark")
 }
+
+func TestUser_WithTwoFactorVerifiedCookie(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	verifiedAt := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:              mock.JonSnow.ID,
+		UserName:            mock.JonSnow.Name,
+		TwoFactorVerifiedAt: jwt.Time(verifiedAt),
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			return c.String(http.StatusOK, c.Value(app.TwoFactorVerifiedAtCtxKey).(time.Time).Format(time.RFC3339))
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals(verifiedAt.Format(time.RFC3339))
+}
+
+func TestUser_ValidAPIKey_TwoFactorRequired(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByAPIKey) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsTwoFactorRequired = true
+
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1").
+		AddHeader("Authorization", "Bearer 1234567890").
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("Two-factor authentication must be enabled to use the API")
+
+	mock.JonSnow.IsTwoFactorEnabled = true
+	status, _ = mock.NewServer().
+		Use(middlewares.User()).
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1").
+		AddHeader("Authorization", "Bearer 1234567890").
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// AddAuditLog records an administrative action performed by the current user.
+// The actor and client IP are taken from the context.
+type AddAuditLog struct {
+	Action     enum.AuditAction
+	TargetType string
+	TargetID   int
+	TargetName string
+	Before     dto.Props
+	After      dto.Props
+}
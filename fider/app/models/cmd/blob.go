@@ -1,11 +1,31 @@
 package cmd
 
+import (
+	"io"
+	"time"
+)
+
 type StoreBlob struct {
 	Key         string
 	Content     []byte
 	ContentType string
 }
 
+// StoreBlobStream stores the content read from Reader, without holding all of it in memory when the storage allows it
+type StoreBlobStream struct {
+	Key         string
+	Reader      io.Reader
+	ContentType string
+}
+
 type DeleteBlob struct {
 	Key string
 }
+
+// TrackOrphanedBlobs keeps track of when each of the given blobs of current tenant was first seen orphaned.
+// Blobs that are no longer on the list stop being tracked, Result holds when each of the given blobs was first seen
+type TrackOrphanedBlobs struct {
+	Keys []string
+
+	Result map[string]time.Time
+}
@@ -5,8 +5,10 @@ import (
 )
 
 type AddNewComment struct {
-	Post    *entity.Post
-	Content string
+	Post      *entity.Post
+	Content   string
+	ParentID  int
+	SpamScore *entity.SpamScore
 
 	Result *entity.Comment
 }
@@ -14,6 +16,7 @@ type AddNewComment struct {
 type UpdateComment struct {
 	CommentID int
 	Content   string
+	SpamScore *entity.SpamScore
 }
 
 type DeleteComment struct {
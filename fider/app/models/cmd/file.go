@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// AddFile attaches an already stored blob to a post or comment, setting File.ID
+type AddFile struct {
+	File *entity.File
+}
+
+// SetFileStatus records the outcome of the scan of a file
+type SetFileStatus struct {
+	FileID     int
+	Status     enum.FileStatus
+	ScanResult string
+}
+
+// DeleteFile removes a file from its post or comment
+type DeleteFile struct {
+	FileID int
+}
+
+// ScanFile checks the content of a file for malware
+type ScanFile struct {
+	FileName string
+	Content  []byte
+
+	IsInfected bool
+	Threat     string
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddImportJob struct {
+	Source    enum.ImportSource
+	FileName  string
+	TotalRows int
+
+	Result *entity.ImportJob
+}
+
+type UpdateImportJobProgress struct {
+	JobID         int
+	Status        enum.ImportStatus
+	ProcessedRows int
+	FailedRows    int
+	Errors        []*entity.ImportRowError
+}
+
+// ImportUser finds the user previously imported with SourceID, or a member with the same email, and creates one otherwise
+type ImportUser struct {
+	Source    enum.ImportSource
+	SourceID  string
+	Name      string
+	Email     string
+	CreatedAt time.Time
+
+	Result *entity.User
+}
+
+// ImportPost creates a post with its original author and dates, or updates the one previously imported with SourceID
+type ImportPost struct {
+	Source      enum.ImportSource
+	SourceID    string
+	Title       string
+	Description string
+	User        *entity.User
+	CreatedAt   time.Time
+	Status      enum.PostStatus
+	Response    string
+	RespondedAt *time.Time
+	Tags        []string
+
+	Result *entity.Post
+}
+
+// ImportComment adds a comment with its original author and date, unless it was already imported with SourceID
+type ImportComment struct {
+	Source    enum.ImportSource
+	SourceID  string
+	Post      *entity.Post
+	User      *entity.User
+	Content   string
+	CreatedAt time.Time
+}
+
+// ImportVote adds a vote with its original date, votes that already exist are kept as they are
+type ImportVote struct {
+	Post      *entity.Post
+	User      *entity.User
+	CreatedAt time.Time
+}
@@ -0,0 +1,9 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+// AddJobRun records a run of a scheduled job and deletes its runs older than the last Keep ones
+type AddJobRun struct {
+	Run  *entity.JobRun
+	Keep int
+}
@@ -22,3 +22,6 @@ type LogInfo struct {
 	Message string
 	Props   dto.Props
 }
+
+// FlushLogs writes the entries buffered by log sinks, which stop accepting new entries afterwards
+type FlushLogs struct{}
@@ -8,6 +8,7 @@ import (
 type AddNewPost struct {
 	Title       string
 	Description string
+	SpamScore   *entity.SpamScore
 
 	Result *entity.Post
 }
@@ -16,6 +17,7 @@ type UpdatePost struct {
 	Post        *entity.Post
 	Title       string
 	Description string
+	SpamScore   *entity.SpamScore
 
 	Result *entity.Post
 }
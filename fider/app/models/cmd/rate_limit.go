@@ -0,0 +1,13 @@
+package cmd
+
+import "time"
+
+// HitRateLimit counts one request on the rate limit counter identified by Key.
+// Result is the number of requests counted on the window that started at WindowStart
+type HitRateLimit struct {
+	Key         string
+	WindowStart time.Time
+	Window      time.Duration
+
+	Result int
+}
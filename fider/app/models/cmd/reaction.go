@@ -8,3 +8,10 @@ type ToggleCommentReaction struct {
 	User    *entity.User
 	Result  bool
 }
+
+type TogglePostReaction struct {
+	Post   *entity.Post
+	Emoji  string
+	User   *entity.User
+	Result bool
+}
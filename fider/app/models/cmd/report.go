@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddContentReport struct {
+	Post    *entity.Post
+	Comment *entity.Comment
+	Reason  enum.ReportReason
+	Details string
+
+	Result *entity.ContentReport
+	// IsHidden is true when this report made the content reach REPORT_HIDE_THRESHOLD
+	IsHidden bool
+}
+
+type ResolveContentReports struct {
+	PostID     int
+	CommentID  int
+	Resolution enum.ReportResolution
+}
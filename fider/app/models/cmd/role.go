@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddNewRole struct {
+	Name        string
+	Description string
+	Permissions []enum.Permission
+
+	Result *entity.Role
+}
+
+type UpdateRole struct {
+	RoleID      int
+	Name        string
+	Description string
+	Permissions []enum.Permission
+
+	Result *entity.Role
+}
+
+type DeleteRole struct {
+	Role *entity.Role
+}
+
+type AssignCustomRole struct {
+	UserID int
+	Role   *entity.Role
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 )
 
 type AddNewTag struct {
 	Name     string
 	Color    string
 	IsPublic bool
+	GroupID  int
 
 	Result *entity.Tag
 }
@@ -17,6 +19,7 @@ type UpdateTag struct {
 	Name     string
 	Color    string
 	IsPublic bool
+	GroupID  int
 
 	Result *entity.Tag
 }
@@ -34,3 +37,26 @@ type UnassignTag struct {
 	Tag  *entity.Tag
 	Post *entity.Post
 }
+
+type AddNewTagGroup struct {
+	Name         string
+	Selection    enum.TagGroupSelection
+	VisibleTo    []string
+	AssignableBy []string
+
+	Result *entity.TagGroup
+}
+
+type UpdateTagGroup struct {
+	GroupID      int
+	Name         string
+	Selection    enum.TagGroupSelection
+	VisibleTo    []string
+	AssignableBy []string
+
+	Result *entity.TagGroup
+}
+
+type DeleteTagGroup struct {
+	Group *entity.TagGroup
+}
@@ -26,6 +26,10 @@ type UpdateTenantEmailAuthAllowedSettings struct {
 	IsEmailAuthAllowed bool
 }
 
+type UpdateTenantTwoFactorSettings struct {
+	IsTwoFactorRequired bool
+}
+
 type UpdateTenantSettings struct {
 	Logo           *dto.ImageUpload
 	Title          string
@@ -37,8 +41,11 @@ type UpdateTenantSettings struct {
 }
 
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS      string
-	AllowedSchemes string
+	CustomCSS        string
+	AllowedSchemes   string
+	AllowedFileTypes string
+	FileQuotaMB      int
+	ReactionEmojis   string
 }
 
 type ActivateTenant struct {
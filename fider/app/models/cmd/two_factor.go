@@ -0,0 +1,52 @@
+package cmd
+
+import "time"
+
+type SaveTwoFactorSecret struct {
+	UserID int
+	Secret string
+}
+
+type EnableTwoFactor struct {
+	UserID             int
+	RecoveryCodeHashes []string
+}
+
+type DisableTwoFactor struct {
+	UserID int
+}
+
+type ReplaceRecoveryCodes struct {
+	UserID             int
+	RecoveryCodeHashes []string
+}
+
+// MarkTwoFactorStepUsed records the time step of an accepted code so it can't be replayed.
+// Result is false when given step (or a later one) has already been used
+type MarkTwoFactorStepUsed struct {
+	UserID int
+	Step   int64
+
+	Result bool
+}
+
+// UseRecoveryCode marks a recovery code as used. Result is false when code is unknown or was used before
+type UseRecoveryCode struct {
+	UserID   int
+	CodeHash string
+
+	Result bool
+}
+
+// RecordTwoFactorFailure counts a failed verification of the second factor.
+// After MaxAttempts consecutive failures, the user is locked out of 2FA for the duration of Lockout
+type RecordTwoFactorFailure struct {
+	UserID      int
+	MaxAttempts int
+	Lockout     time.Duration
+}
+
+// ResetTwoFactorFailures clears the failed verifications of a user after a successful one
+type ResetTwoFactorFailures struct {
+	UserID int
+}
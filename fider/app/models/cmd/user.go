@@ -25,6 +25,7 @@ type RegenerateAPIKey struct {
 type DeleteCurrentUser struct {
 }
 
+// ChangeUserRole sets the built-in role of a user and removes their custom role, if any
 type ChangeUserRole struct {
 	UserID int
 	Role   enum.Role
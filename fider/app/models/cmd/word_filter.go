@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddWordFilter struct {
+	Pattern string
+	IsRegex bool
+	Action  enum.WordFilterAction
+
+	Result *entity.WordFilter
+}
+
+type DeleteWordFilter struct {
+	ID int
+}
@@ -1,7 +1,20 @@
 package dto
 
+import (
+	"io"
+	"time"
+)
+
 type Blob struct {
 	Size        int64
 	Content     []byte
 	ContentType string
 }
+
+// BlobReader is the content of a blob being read as a stream. Reader must be closed once done
+type BlobReader struct {
+	Reader      io.ReadCloser
+	ContentType string
+	// ModifiedAt is when the blob was last written, it's zero when the storage doesn't know
+	ModifiedAt time.Time
+}
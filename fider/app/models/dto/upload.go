@@ -13,3 +13,10 @@ type ImageUploadData struct {
 	ContentType string `json:"contentType"`
 	Content     []byte `json:"content"`
 }
+
+//FileUpload is the input model used to upload a non-image file
+type FileUpload struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Content     []byte `json:"content"`
+}
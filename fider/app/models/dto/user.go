@@ -1,5 +1,6 @@
 package dto
 
 type UserNames struct {
+	ID   int    `json:"id"`
 	Name string `json:"name"`
 }
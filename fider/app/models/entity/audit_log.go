@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// AuditLog is an immutable record of an administrative action
+type AuditLog struct {
+	ID         int              `json:"id"`
+	ActorID    int              `json:"actorId,omitempty"`
+	ActorName  string           `json:"actorName"`
+	Action     enum.AuditAction `json:"action"`
+	TargetType string           `json:"targetType"`
+	TargetID   int              `json:"targetId,omitempty"`
+	TargetName string           `json:"targetName"`
+	Before     map[string]any   `json:"before,omitempty"`
+	After      map[string]any   `json:"after,omitempty"`
+	ClientIP   string           `json:"clientIp"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
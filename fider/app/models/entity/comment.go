@@ -13,6 +13,7 @@ type ReactionCounts struct {
 // Comment represents an user comment on an post
 type Comment struct {
 	ID             int              `json:"id"`
+	PostID         int              `json:"-"`
 	Content        string           `json:"content"`
 	CreatedAt      time.Time        `json:"createdAt"`
 	User           *User            `json:"user"`
@@ -21,4 +22,8 @@ type Comment struct {
 	EditedBy       *User            `json:"editedBy,omitempty"`
 	ReactionCounts []ReactionCounts `json:"reactionCounts,omitempty"`
 	IsApproved     bool             `json:"isApproved"`
+	ParentID       int              `json:"parentId,omitempty"`
+	// IsRemoved is set on comments that were deleted or can't be seen by current user,
+	// but that are kept without their content so that their replies are shown in place
+	IsRemoved bool `json:"isRemoved,omitempty"`
 }
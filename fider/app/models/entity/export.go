@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// ExportedPost is a post with the statistics that are only needed by exports
+type ExportedPost struct {
+	*Post
+	VotesByRole    map[enum.Role]int
+	VoterEmails    []string
+	LastActivityAt time.Time
+}
+
+// ExportedComment is a comment with the post it belongs to
+type ExportedComment struct {
+	ID         int
+	PostNumber int
+	PostTitle  string
+	Content    string
+	User       *User
+	CreatedAt  time.Time
+	EditedAt   *time.Time
+	IsApproved bool
+}
+
+// ExportedVote is a vote with the post it belongs to
+type ExportedVote struct {
+	PostID     int
+	PostNumber int
+	PostTitle  string
+	User       *User
+	CreatedAt  time.Time
+}
+
+// ExportedReaction is a reaction with the post it belongs to. CommentID is nil for reactions to the post itself
+type ExportedReaction struct {
+	ID         int
+	PostNumber int
+	PostTitle  string
+	CommentID  *int
+	Emoji      string
+	User       *User
+	CreatedAt  time.Time
+}
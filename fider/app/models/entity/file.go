@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// File is a non-image file attached to a post or one of its comments
+type File struct {
+	ID          int             `json:"id"`
+	PostID      int             `json:"postId"`
+	CommentID   int             `json:"commentId,omitempty"`
+	UserID      int             `json:"userId"`
+	Name        string          `json:"name"`
+	ContentType string          `json:"contentType"`
+	Size        int64           `json:"size"`
+	Status      enum.FileStatus `json:"status"`
+	ScanResult  string          `json:"scanResult,omitempty"`
+	BlobKey     string          `json:"-"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// CanBeDownloaded returns true if the file has been cleared by the scanner
+func (f *File) CanBeDownloaded() bool {
+	return f.Status == enum.FileStatusClean
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// ImportJob is an import of posts, comments and votes from another feedback tool
+type ImportJob struct {
+	ID            int               `json:"id"`
+	Source        enum.ImportSource `json:"source"`
+	FileName      string            `json:"fileName"`
+	Status        enum.ImportStatus `json:"status"`
+	TotalRows     int               `json:"totalRows"`
+	ProcessedRows int               `json:"processedRows"`
+	FailedRows    int               `json:"failedRows"`
+	Errors        []*ImportRowError `json:"errors"`
+	CreatedBy     *User             `json:"createdBy"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	FinishedAt    *time.Time        `json:"finishedAt,omitempty"`
+}
+
+// ImportRowError is the reason a row of an import file could not be imported
+type ImportRowError struct {
+	Row      int    `json:"row"`
+	SourceID string `json:"sourceId,omitempty"`
+	Message  string `json:"message"`
+}
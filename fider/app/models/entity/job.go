@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Job is a scheduled job that runs for the whole instance
+type Job struct {
+	Name              string     `json:"name"`
+	Schedule          string     `json:"schedule"`
+	DefaultSchedule   string     `json:"defaultSchedule"`
+	IsPaused          bool       `json:"isPaused"`
+	LastSuccessfulRun *time.Time `json:"lastSuccessfulRun,omitempty"`
+	LastFailedRun     *time.Time `json:"lastFailedRun,omitempty"`
+	LastError         string     `json:"lastError,omitempty"`
+	Runs              []*JobRun  `json:"runs"`
+}
+
+// JobRun is a finished run of a scheduled job
+type JobRun struct {
+	ID         int               `json:"id"`
+	JobName    string            `json:"jobName"`
+	Trigger    enum.JobTrigger   `json:"trigger"`
+	Status     enum.JobRunStatus `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt time.Time         `json:"finishedAt"`
+	DurationMs int64             `json:"durationMs"`
+}
@@ -1,29 +1,71 @@
 package entity
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 )
 
 type CommentString string
 
-const mentionRegex = `@\[(.*?)\]`
+// Mention is a reference to a user on the content of a post or a comment.
+// Mentions are written as @[name]{id}, where the id identifies the user and the name is only what was shown when it was written.
+// Mentions written before ids were added have only a name and an UserID of 0
+type Mention struct {
+	UserID int
+	Name   string
+}
 
-func (commentString CommentString) ParseMentions() []string {
-	r, _ := regexp.Compile(mentionRegex)
-	matches := r.FindAllStringSubmatch(string(commentString), -1)
+// Matches returns true if given user is the one mentioned
+func (m Mention) Matches(user *User) bool {
+	if m.UserID > 0 {
+		return m.UserID == user.ID
+	}
+	return m.Name == user.Name
+}
 
-	mentions := []string{}
+// FormatMention returns the text of a mention to given user
+func FormatMention(userID int, name string) string {
+	return fmt.Sprintf("@[%s]{%d}", name, userID)
+}
+
+var mentionRegex = regexp.MustCompile(`@\[(.*?)\](?:\{(\d+)\})?`)
+
+func toMention(match []string) Mention {
+	mention := Mention{Name: match[1]}
+	if len(match) >= 3 && match[2] != "" {
+		mention.UserID, _ = strconv.Atoi(match[2])
+	}
+	return mention
+}
+
+func (commentString CommentString) ParseMentions() []Mention {
+	matches := mentionRegex.FindAllStringSubmatch(string(commentString), -1)
+
+	mentions := []Mention{}
 
 	for _, match := range matches {
-		if len(match) >= 2 && match[1] != "" {
-			mentions = append(mentions, match[1])
+		if match[1] != "" {
+			mentions = append(mentions, toMention(match))
 		}
 	}
 
 	return mentions
 }
 
+// ReplaceMentions replaces each mention with the text returned by replace
+func (commentString CommentString) ReplaceMentions(replace func(m Mention) string) string {
+	return mentionRegex.ReplaceAllStringFunc(string(commentString), func(text string) string {
+		match := mentionRegex.FindStringSubmatch(text)
+		if match[1] == "" {
+			return text
+		}
+		return replace(toMention(match))
+	})
+}
+
 func (commentString CommentString) SanitizeMentions() string {
-	r, _ := regexp.Compile(mentionRegex)
-	return r.ReplaceAllString(string(commentString), "@$1")
+	return commentString.ReplaceMentions(func(m Mention) string {
+		return "@" + m.Name
+	})
 }
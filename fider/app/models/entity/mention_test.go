@@ -0,0 +1,39 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestCommentString_ParseMentions(t *testing.T) {
+	RegisterT(t)
+
+	content := entity.CommentString("Thanks @[Jon Snow]{12} and @[Arya Stark], but not @[]{3}")
+	Expect(content.ParseMentions()).Equals([]entity.Mention{
+		{UserID: 12, Name: "Jon Snow"},
+		{UserID: 0, Name: "Arya Stark"},
+	})
+
+	Expect(entity.CommentString("No mentions here").ParseMentions()).HasLen(0)
+}
+
+func TestCommentString_SanitizeMentions(t *testing.T) {
+	RegisterT(t)
+
+	content := entity.CommentString("Thanks @[Jon Snow]{12} and @[Arya Stark]!")
+	Expect(content.SanitizeMentions()).Equals("Thanks @Jon Snow and @Arya Stark!")
+}
+
+func TestMention_Matches(t *testing.T) {
+	RegisterT(t)
+
+	jon := &entity.User{ID: 12, Name: "Jon Snow"}
+	otherJon := &entity.User{ID: 13, Name: "Jon Snow"}
+
+	Expect(entity.Mention{UserID: 12, Name: "Jon"}.Matches(jon)).IsTrue()
+	Expect(entity.Mention{UserID: 12, Name: "Jon Snow"}.Matches(otherJon)).IsFalse()
+	Expect(entity.Mention{Name: "Jon Snow"}.Matches(otherJon)).IsTrue()
+	Expect(entity.FormatMention(12, "Jon Snow")).Equals("@[Jon Snow]{12}")
+}
@@ -23,6 +23,8 @@ type Post struct {
 	Response      *PostResponse   `json:"response,omitempty"`
 	Tags          []string        `json:"tags"`
 	IsApproved    bool            `json:"isApproved"`
+	// ReactionCounts is only loaded when a post is read through GetPostBy* or SearchPosts
+	ReactionCounts []ReactionCounts `json:"reactionCounts,omitempty"`
 }
 
 // CanBeVoted returns true if this post can have its vote changed
@@ -2,7 +2,10 @@ package entity
 
 import "time"
 
-// Reaction represents a user's emoji reaction to a comment
+// DefaultReactionEmojis is the palette of reactions of tenants that didn't choose their own
+const DefaultReactionEmojis = "👍 👎 😄 🎉 😕 ❤️ 🚀 👀"
+
+// Reaction represents a user's emoji reaction to a post or a comment
 type Reaction struct {
 	ID        int       `json:"id"`
 	Emoji     string    `json:"emoji"`
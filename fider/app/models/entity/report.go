@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// ContentReport is a member flagging a post or a comment to the moderators
+type ContentReport struct {
+	ID         int                   `json:"id"`
+	PostID     int                   `json:"postId"`
+	CommentID  int                   `json:"commentId,omitempty"`
+	Reason     enum.ReportReason     `json:"reason"`
+	Details    string                `json:"details,omitempty"`
+	Reporter   *User                 `json:"reporter"`
+	CreatedAt  time.Time             `json:"createdAt"`
+	Resolution enum.ReportResolution `json:"resolution,omitempty"`
+	ResolvedAt *time.Time            `json:"resolvedAt,omitempty"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Role is a named set of permissions defined by a tenant
+type Role struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
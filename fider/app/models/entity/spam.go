@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// SpamReason is why a scorer gave points to a new post or comment
+type SpamReason struct {
+	Scorer      string `json:"scorer"`
+	Points      int    `json:"points"`
+	Description string `json:"description"`
+}
+
+// SpamScore is the result of scoring a new post or comment for spam and abuse
+type SpamScore struct {
+	Score     int          `json:"score"`
+	Reasons   []SpamReason `json:"reasons"`
+	IsFlagged bool         `json:"isFlagged"`
+
+	ContentHash string `json:"-"`
+	IP          string `json:"-"`
+}
+
+// SpamSignals is the recent activity of an user, an IP and a content used to score new posts and comments
+type SpamSignals struct {
+	RecentByUser     int
+	RecentByIP       int
+	DuplicateCount   int
+	AccountCreatedAt time.Time
+}
@@ -1,5 +1,9 @@
 package entity
 
+import (
+	"github.com/getfider/fider/app/models/enum"
+)
+
 //Tag represents a simple tag
 type Tag struct {
 	ID       int    `json:"id"`
@@ -7,4 +11,58 @@ type Tag struct {
 	Slug     string `json:"slug"`
 	Color    string `json:"color"`
 	IsPublic bool   `json:"isPublic"`
+	GroupID  int    `json:"groupId,omitempty"`
+}
+
+// TagGroup organizes tags and defines which roles can see and assign them.
+// An empty VisibleTo means every visitor can see the group and an empty AssignableBy
+// means anyone allowed to assign tags can assign tags of the group
+type TagGroup struct {
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	Slug         string                 `json:"slug"`
+	Selection    enum.TagGroupSelection `json:"selection"`
+	VisibleTo    []string               `json:"visibleTo"`
+	AssignableBy []string               `json:"assignableBy"`
+}
+
+// IsSingleSelection returns true if only one tag of this group can be assigned to a post
+func (g *TagGroup) IsSingleSelection() bool {
+	return g.Selection == enum.TagGroupSelectionSingle
+}
+
+// IsVisibleTo returns true if given user (which can be nil) is allowed to see tags of this group
+func (g *TagGroup) IsVisibleTo(u *User) bool {
+	if len(g.VisibleTo) == 0 {
+		return true
+	}
+	if u == nil {
+		return false
+	}
+	return u.HasPermission(enum.PermissionManageTags) || matchRoleKeys(u, g.VisibleTo)
+}
+
+// IsAssignableBy returns true if given user (which can be nil) is allowed to assign tags of this group
+func (g *TagGroup) IsAssignableBy(u *User) bool {
+	if u == nil || !g.IsVisibleTo(u) {
+		return false
+	}
+	if u.HasPermission(enum.PermissionManageTags) {
+		return true
+	}
+	if len(g.AssignableBy) == 0 {
+		return u.HasPermission(enum.PermissionAssignTags)
+	}
+	return matchRoleKeys(u, g.AssignableBy)
+}
+
+func matchRoleKeys(u *User, keys []string) bool {
+	for _, userKey := range u.RoleKeys() {
+		for _, key := range keys {
+			if userKey == key {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestTagGroup_IsVisibleTo(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{Role: enum.RoleAdministrator}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+	visitor := &entity.User{Role: enum.RoleVisitor}
+	support := &entity.User{Role: enum.RoleVisitor, CustomRole: &entity.Role{ID: 5}}
+
+	public := &entity.TagGroup{}
+	Expect(public.IsVisibleTo(nil)).IsTrue()
+	Expect(public.IsVisibleTo(visitor)).IsTrue()
+
+	restricted := &entity.TagGroup{VisibleTo: []string{"collaborator", "role:5"}}
+	Expect(restricted.IsVisibleTo(nil)).IsFalse()
+	Expect(restricted.IsVisibleTo(visitor)).IsFalse()
+	Expect(restricted.IsVisibleTo(collaborator)).IsTrue()
+	Expect(restricted.IsVisibleTo(support)).IsTrue()
+	Expect(restricted.IsVisibleTo(admin)).IsTrue()
+}
+
+func TestTagGroup_IsAssignableBy(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{Role: enum.RoleAdministrator}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+	visitor := &entity.User{Role: enum.RoleVisitor}
+
+	staffOnly := &entity.TagGroup{}
+	Expect(staffOnly.IsAssignableBy(nil)).IsFalse()
+	Expect(staffOnly.IsAssignableBy(visitor)).IsFalse()
+	Expect(staffOnly.IsAssignableBy(collaborator)).IsTrue()
+	Expect(staffOnly.IsAssignableBy(admin)).IsTrue()
+
+	visitors := &entity.TagGroup{AssignableBy: []string{"visitor"}}
+	Expect(visitors.IsAssignableBy(visitor)).IsTrue()
+	Expect(visitors.IsAssignableBy(collaborator)).IsFalse()
+	Expect(visitors.IsAssignableBy(admin)).IsTrue()
+
+	hidden := &entity.TagGroup{VisibleTo: []string{"collaborator"}, AssignableBy: []string{"visitor"}}
+	Expect(hidden.IsAssignableBy(visitor)).IsFalse()
+}
+
+func TestUser_RoleKeys(t *testing.T) {
+	RegisterT(t)
+
+	Expect((&entity.User{Role: enum.RoleVisitor}).RoleKeys()).Equals([]string{"visitor"})
+	Expect((&entity.User{Role: enum.RoleCollaborator, CustomRole: &entity.Role{ID: 3}}).RoleKeys()).Equals([]string{"collaborator", "role:3"})
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"strings"
+
 	"github.com/getfider/fider/app/models/enum"
 )
 
@@ -19,10 +21,14 @@ type Tenant struct {
 	LogoBlobKey         string            `json:"logoBlobKey"`
 	CustomCSS           string            `json:"-"`
 	AllowedSchemes      string            `json:"allowedSchemes"`
+	AllowedFileTypes    string            `json:"allowedFileTypes"`
+	ReactionEmojis      string            `json:"reactionEmojis"`
+	FileQuotaMB         int               `json:"fileQuotaMB"`
 	IsEmailAuthAllowed  bool              `json:"isEmailAuthAllowed"`
 	IsFeedEnabled       bool              `json:"isFeedEnabled"`
 	PreventIndexing     bool              `json:"preventIndexing"`
 	IsModerationEnabled      bool              `json:"isModerationEnabled"`
+	IsTwoFactorRequired      bool              `json:"isTwoFactorRequired"`
 	HasCommercialFeatures    bool              `json:"hasCommercialFeatures"`
 }
 
@@ -30,6 +36,14 @@ func (t *Tenant) IsDisabled() bool {
 	return t.Status == enum.TenantDisabled
 }
 
+// ReactionPalette returns the emojis that can be used to react to posts and comments
+func (t *Tenant) ReactionPalette() []string {
+	if strings.TrimSpace(t.ReactionEmojis) == "" {
+		return strings.Fields(DefaultReactionEmojis)
+	}
+	return strings.Fields(t.ReactionEmojis)
+}
+
 // TenantContact is a reference to an administrator account
 type TenantContact struct {
 	Name      string `json:"name"`
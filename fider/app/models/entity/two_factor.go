@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/getfider/fider/app/pkg/crypto"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+// RecoveryCodesCount is how many recovery codes are generated at once
+const RecoveryCodesCount = 10
+
+// GenerateRecoveryCodes returns a new set of single-use recovery codes in the "xxxxx-xxxxx" format
+func GenerateRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodesCount)
+	for i := range codes {
+		code := strings.ToLower(rand.String(10))
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes
+}
+
+// HashRecoveryCode returns the value that is stored for given recovery code.
+// Codes are compared case insensitive and regardless of dashes and spaces
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	return crypto.SHA512(normalized)
+}
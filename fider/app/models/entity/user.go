@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/getfider/fider/app/models/enum"
 )
@@ -19,6 +20,9 @@ type User struct {
 	AvatarURL     string          `json:"avatarURL,omitempty"`
 	Status        enum.UserStatus `json:"status"`
 	IsTrusted     bool            `json:"isTrusted"`
+	CustomRole    *Role           `json:"customRole,omitempty"`
+
+	IsTwoFactorEnabled bool `json:"-"`
 }
 
 // HasProvider returns true if current user has registered with given provider
@@ -41,6 +45,70 @@ func (u *User) IsAdministrator() bool {
 	return u.Role == enum.RoleAdministrator
 }
 
+// Permissions returns the permissions granted to the user.
+// Administrators always have every permission, otherwise the custom role (when assigned) replaces the preset of the user role
+func (u *User) Permissions() []enum.Permission {
+	if u.Role != enum.RoleAdministrator && u.CustomRole != nil {
+		return u.CustomRole.Permissions
+	}
+	return u.Role.Permissions()
+}
+
+// RoleKeys returns the keys matched against role based rules, such as tag group visibility.
+// That's the name of the user role and, when assigned, the key of the custom role
+func (u *User) RoleKeys() []string {
+	keys := []string{u.Role.String()}
+	if u.CustomRole != nil {
+		keys = append(keys, CustomRoleKey(u.CustomRole.ID))
+	}
+	return keys
+}
+
+// CustomRoleKey returns the key used to reference a custom role on role based rules
+func CustomRoleKey(roleID int) string {
+	return fmt.Sprintf("role:%d", roleID)
+}
+
+// RequiresTwoFactor returns true if user must verify a second factor to sign in.
+// That's the case when user has enrolled on 2FA or when tenant enforces it for staff members
+func (u *User) RequiresTwoFactor() bool {
+	if u.IsTwoFactorEnabled {
+		return true
+	}
+	return u.Tenant != nil && u.Tenant.IsTwoFactorRequired && u.HasPermission(enum.PermissionAccessAdmin)
+}
+
+// HasPermission returns true if user has been granted given permission
+func (u *User) HasPermission(permission enum.Permission) bool {
+	for _, p := range u.Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermissions returns true if user has been granted every given permission
+func (u *User) HasPermissions(permissions []enum.Permission) bool {
+	for _, permission := range permissions {
+		if !u.HasPermission(permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPermissionsOf returns true if user has been granted every permission of given user
+func (u *User) HasPermissionsOf(other *User) bool {
+	return u.HasPermissions(other.Permissions())
+}
+
+// CanSeePendingContent returns true if user can see posts and comments of others that are awaiting moderation.
+// Collaborators always could, even though approving and declining requires the moderation permission
+func (u *User) CanSeePendingContent() bool {
+	return u.IsCollaborator() || u.HasPermission(enum.PermissionModerateContent)
+}
+
 // RequiresModeration returns true if user requires moderation
 func (u *User) RequiresModeration() bool {
 	return u.Role == enum.RoleVisitor && !u.IsTrusted
@@ -2,9 +2,11 @@ package entity_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	. "github.com/getfider/fider/app/pkg/assert"
 )
 
@@ -53,3 +55,131 @@ func TestUser_MarshalJSON(t *testing.T) {
 	Expect(string(jsonData)).Equals(expectedJSON)
 
 }
+
+func TestUser_HasPermission(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{Role: enum.RoleAdministrator}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+	visitor := &entity.User{Role: enum.RoleVisitor}
+
+	for _, permission := range enum.Permissions {
+		Expect(admin.HasPermission(permission)).IsTrue()
+		Expect(visitor.HasPermission(permission)).IsFalse()
+	}
+
+	Expect(collaborator.HasPermission(enum.PermissionAccessAdmin)).IsTrue()
+	Expect(collaborator.HasPermission(enum.PermissionRespondToPosts)).IsTrue()
+	Expect(collaborator.HasPermission(enum.PermissionManageSettings)).IsFalse()
+	Expect(collaborator.HasPermission(enum.PermissionManageRoles)).IsFalse()
+}
+
+func TestUser_HasPermission_CustomRole(t *testing.T) {
+	RegisterT(t)
+
+	role := &entity.Role{
+		ID:          1,
+		Name:        "Moderator",
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionModerateContent},
+	}
+
+	visitor := &entity.User{Role: enum.RoleVisitor, CustomRole: role}
+	Expect(visitor.HasPermission(enum.PermissionModerateContent)).IsTrue()
+	Expect(visitor.HasPermission(enum.PermissionRespondToPosts)).IsFalse()
+
+	collaborator := &entity.User{Role: enum.RoleCollaborator, CustomRole: role}
+	Expect(collaborator.HasPermission(enum.PermissionModerateContent)).IsTrue()
+	Expect(collaborator.HasPermission(enum.PermissionRespondToPosts)).IsFalse()
+
+	admin := &entity.User{Role: enum.RoleAdministrator, CustomRole: role}
+	Expect(admin.HasPermission(enum.PermissionManageSettings)).IsTrue()
+}
+
+func TestUser_HasPermissionsOf(t *testing.T) {
+	RegisterT(t)
+
+	role := &entity.Role{
+		ID:          1,
+		Name:        "Member Manager",
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionViewUsers, enum.PermissionManageUsers},
+	}
+
+	manager := &entity.User{Role: enum.RoleVisitor, CustomRole: role}
+	visitor := &entity.User{Role: enum.RoleVisitor}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+	admin := &entity.User{Role: enum.RoleAdministrator}
+
+	Expect(manager.HasPermissionsOf(visitor)).IsTrue()
+	Expect(manager.HasPermissionsOf(collaborator)).IsFalse()
+	Expect(manager.HasPermissionsOf(admin)).IsFalse()
+	Expect(collaborator.HasPermissionsOf(admin)).IsFalse()
+	Expect(admin.HasPermissionsOf(collaborator)).IsTrue()
+	Expect(admin.HasPermissionsOf(admin)).IsTrue()
+}
+
+func TestUser_HasPermissions(t *testing.T) {
+	RegisterT(t)
+
+	manager := &entity.User{Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionManageUsers},
+	}}
+
+	Expect(manager.HasPermissions([]enum.Permission{})).IsTrue()
+	Expect(manager.HasPermissions([]enum.Permission{enum.PermissionManageUsers})).IsTrue()
+	Expect(manager.HasPermissions(enum.RoleCollaborator.Permissions())).IsFalse()
+	Expect(manager.HasPermissions(enum.RoleVisitor.Permissions())).IsTrue()
+}
+
+func TestUser_CanSeePendingContent(t *testing.T) {
+	RegisterT(t)
+
+	moderator := &entity.User{Role: enum.RoleVisitor, CustomRole: &entity.Role{
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionModerateContent},
+	}}
+
+	Expect(moderator.CanSeePendingContent()).IsTrue()
+	Expect((&entity.User{Role: enum.RoleCollaborator}).CanSeePendingContent()).IsTrue()
+	Expect((&entity.User{Role: enum.RoleAdministrator}).CanSeePendingContent()).IsTrue()
+	Expect((&entity.User{Role: enum.RoleVisitor}).CanSeePendingContent()).IsFalse()
+}
+
+func TestUser_RequiresTwoFactor(t *testing.T) {
+	RegisterT(t)
+
+	tenant := &entity.Tenant{ID: 1}
+	admin := &entity.User{Role: enum.RoleAdministrator, Tenant: tenant}
+	collaborator := &entity.User{Role: enum.RoleCollaborator, Tenant: tenant}
+	visitor := &entity.User{Role: enum.RoleVisitor, Tenant: tenant}
+
+	Expect(admin.RequiresTwoFactor()).IsFalse()
+	Expect(collaborator.RequiresTwoFactor()).IsFalse()
+	Expect(visitor.RequiresTwoFactor()).IsFalse()
+
+	visitor.IsTwoFactorEnabled = true
+	Expect(visitor.RequiresTwoFactor()).IsTrue()
+
+	tenant.IsTwoFactorRequired = true
+	Expect(admin.RequiresTwoFactor()).IsTrue()
+	Expect(collaborator.RequiresTwoFactor()).IsTrue()
+
+	visitor.IsTwoFactorEnabled = false
+	Expect(visitor.RequiresTwoFactor()).IsFalse()
+
+	visitor.CustomRole = &entity.Role{Permissions: []enum.Permission{enum.PermissionAccessAdmin}}
+	Expect(visitor.RequiresTwoFactor()).IsTrue()
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	RegisterT(t)
+
+	codes := entity.GenerateRecoveryCodes()
+	Expect(codes).HasLen(entity.RecoveryCodesCount)
+	Expect(codes[0]).HasLen(11)
+	Expect(codes[0][5:6]).Equals("-")
+	Expect(codes[0]).NotEquals(codes[1])
+
+	hash := entity.HashRecoveryCode(codes[0])
+	Expect(entity.HashRecoveryCode(strings.ToUpper(codes[0]))).Equals(hash)
+	Expect(entity.HashRecoveryCode(strings.ReplaceAll(codes[0], "-", " "))).Equals(hash)
+	Expect(entity.HashRecoveryCode(codes[1])).NotEquals(hash)
+}
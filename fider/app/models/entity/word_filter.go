@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+var wordCharRegex = regexp.MustCompile(`^\w$`)
+
+// WordFilter is a blocked word or pattern and what to do with content that matches it
+type WordFilter struct {
+	ID        int                   `json:"id"`
+	Pattern   string                `json:"pattern"`
+	IsRegex   bool                  `json:"isRegex"`
+	Action    enum.WordFilterAction `json:"action"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+// Regexp compiles the filter into a regular expression. Words and phrases are matched
+// ignoring case and only as whole words, while regex patterns are used as they are
+func (f *WordFilter) Regexp() (*regexp.Regexp, error) {
+	if f.IsRegex {
+		return regexp.Compile(f.Pattern)
+	}
+
+	expr := regexp.QuoteMeta(f.Pattern)
+	if wordCharRegex.MatchString(f.Pattern[:1]) {
+		expr = `\b` + expr
+	}
+	if wordCharRegex.MatchString(f.Pattern[len(f.Pattern)-1:]) {
+		expr = expr + `\b`
+	}
+	return regexp.Compile(`(?i)` + expr)
+}
@@ -0,0 +1,29 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestWordFilter_Regexp(t *testing.T) {
+	RegisterT(t)
+
+	re, err := (&entity.WordFilter{Pattern: "spam"}).Regexp()
+	Expect(err).IsNil()
+	Expect(re.MatchString("No SPAM please")).IsTrue()
+	Expect(re.MatchString("spammer")).IsFalse()
+
+	re, err = (&entity.WordFilter{Pattern: "c++"}).Regexp()
+	Expect(err).IsNil()
+	Expect(re.MatchString("I love C++!")).IsTrue()
+
+	re, err = (&entity.WordFilter{Pattern: `sp[a4]m`, IsRegex: true}).Regexp()
+	Expect(err).IsNil()
+	Expect(re.MatchString("sp4mmer")).IsTrue()
+	Expect(re.MatchString("SPAM")).IsFalse()
+
+	_, err = (&entity.WordFilter{Pattern: `(spam`, IsRegex: true}).Regexp()
+	Expect(err).IsNotNil()
+}
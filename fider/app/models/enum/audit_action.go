@@ -0,0 +1,140 @@
+package enum
+
+// AuditAction is the kind of administrative action recorded on the audit log
+type AuditAction string
+
+var (
+	//AuditUserRoleChanged is recorded when the role of a user is changed
+	AuditUserRoleChanged AuditAction = "user.role_changed"
+	//AuditUserBlocked is recorded when a user is blocked
+	AuditUserBlocked AuditAction = "user.blocked"
+	//AuditUserUnblocked is recorded when a user is unblocked
+	AuditUserUnblocked AuditAction = "user.unblocked"
+	//AuditUserTrusted is recorded when a user is marked as trusted
+	AuditUserTrusted AuditAction = "user.trusted"
+	//AuditUserUntrusted is recorded when a user is no longer trusted
+	AuditUserUntrusted AuditAction = "user.untrusted"
+	//AuditPostApproved is recorded when a post is approved on moderation
+	AuditPostApproved AuditAction = "moderation.post_approved"
+	//AuditPostDeclined is recorded when a post is declined on moderation
+	AuditPostDeclined AuditAction = "moderation.post_declined"
+	//AuditCommentApproved is recorded when a comment is approved on moderation
+	AuditCommentApproved AuditAction = "moderation.comment_approved"
+	//AuditCommentDeclined is recorded when a comment is declined on moderation
+	AuditCommentDeclined AuditAction = "moderation.comment_declined"
+	//AuditWebhookCreated is recorded when a webhook is created
+	AuditWebhookCreated AuditAction = "webhook.created"
+	//AuditWebhookUpdated is recorded when a webhook is changed
+	AuditWebhookUpdated AuditAction = "webhook.updated"
+	//AuditWebhookDeleted is recorded when a webhook is deleted
+	AuditWebhookDeleted AuditAction = "webhook.deleted"
+	//AuditOAuthConfigSaved is recorded when an OAuth provider is created or changed
+	AuditOAuthConfigSaved AuditAction = "oauth.config_saved"
+	//AuditOAuthProviderStatusChanged is recorded when a built-in OAuth provider is enabled or disabled
+	AuditOAuthProviderStatusChanged AuditAction = "oauth.provider_status_changed"
+	//AuditGeneralSettingsUpdated is recorded when the general settings are changed
+	AuditGeneralSettingsUpdated AuditAction = "settings.general_updated"
+	//AuditAdvancedSettingsUpdated is recorded when the advanced settings are changed
+	AuditAdvancedSettingsUpdated AuditAction = "settings.advanced_updated"
+	//AuditPrivacySettingsUpdated is recorded when the privacy settings are changed
+	AuditPrivacySettingsUpdated AuditAction = "settings.privacy_updated"
+	//AuditEmailAuthSettingsUpdated is recorded when email authentication is enabled or disabled
+	AuditEmailAuthSettingsUpdated AuditAction = "settings.email_auth_updated"
+	//AuditPostsExported is recorded when posts are exported to CSV
+	AuditPostsExported AuditAction = "export.posts"
+	//AuditBackupExported is recorded when a full backup is downloaded
+	AuditBackupExported AuditAction = "export.backup"
+	//AuditDataExported is recorded when posts, comments or votes are exported with filters
+	AuditDataExported AuditAction = "export.data"
+	//AuditBackupRestored is recorded when a backup is restored into a new site
+	AuditBackupRestored AuditAction = "backup.restored"
+	//AuditDataImported is recorded when an import from another feedback tool is started
+	AuditDataImported AuditAction = "import.started"
+	//AuditAuditLogExported is recorded when the audit log itself is exported
+	AuditAuditLogExported AuditAction = "export.audit_log"
+	//AuditPostDeleted is recorded when a post is deleted
+	AuditPostDeleted AuditAction = "post.deleted"
+	//AuditTagDeleted is recorded when a tag is deleted
+	AuditTagDeleted AuditAction = "tag.deleted"
+	//AuditTagGroupCreated is recorded when a tag group is created
+	AuditTagGroupCreated AuditAction = "tag_group.created"
+	//AuditTagGroupUpdated is recorded when a tag group is changed
+	AuditTagGroupUpdated AuditAction = "tag_group.updated"
+	//AuditTagGroupDeleted is recorded when a tag group is deleted
+	AuditTagGroupDeleted AuditAction = "tag_group.deleted"
+	//AuditRoleCreated is recorded when a custom role is created
+	AuditRoleCreated AuditAction = "role.created"
+	//AuditRoleUpdated is recorded when a custom role is changed
+	AuditRoleUpdated AuditAction = "role.updated"
+	//AuditRoleDeleted is recorded when a custom role is deleted
+	AuditRoleDeleted AuditAction = "role.deleted"
+	//AuditUserCustomRoleChanged is recorded when a custom role is assigned to or removed from a user
+	AuditUserCustomRoleChanged AuditAction = "user.custom_role_changed"
+	//AuditTwoFactorSettingsUpdated is recorded when two-factor authentication is required or no longer required for staff
+	AuditTwoFactorSettingsUpdated AuditAction = "settings.two_factor_updated"
+	//AuditUserTwoFactorEnabled is recorded when a user enables two-factor authentication
+	AuditUserTwoFactorEnabled AuditAction = "user.two_factor_enabled"
+	//AuditUserTwoFactorDisabled is recorded when a user disables two-factor authentication
+	AuditUserTwoFactorDisabled AuditAction = "user.two_factor_disabled"
+	//AuditUserRecoveryCodesRegenerated is recorded when a user generates a new set of recovery codes
+	AuditUserRecoveryCodesRegenerated AuditAction = "user.recovery_codes_regenerated"
+	//AuditJobTriggered is recorded when a scheduled job is run on demand
+	AuditJobTriggered AuditAction = "job.triggered"
+	//AuditJobPaused is recorded when a scheduled job is paused
+	AuditJobPaused AuditAction = "job.paused"
+	//AuditJobResumed is recorded when a paused scheduled job is resumed
+	AuditJobResumed AuditAction = "job.resumed"
+	//AuditFileReleased is recorded when a quarantined file is released by a moderator
+	AuditFileReleased AuditAction = "file.released"
+	//AuditWordFilterCreated is recorded when a word filter is created
+	AuditWordFilterCreated AuditAction = "word_filter.created"
+	//AuditWordFilterDeleted is recorded when a word filter is deleted
+	AuditWordFilterDeleted AuditAction = "word_filter.deleted"
+)
+
+// AuditActions is the list of all actions that can be recorded on the audit log
+var AuditActions = []AuditAction{
+	AuditUserRoleChanged,
+	AuditUserBlocked,
+	AuditUserUnblocked,
+	AuditUserTrusted,
+	AuditUserUntrusted,
+	AuditPostApproved,
+	AuditPostDeclined,
+	AuditCommentApproved,
+	AuditCommentDeclined,
+	AuditWebhookCreated,
+	AuditWebhookUpdated,
+	AuditWebhookDeleted,
+	AuditOAuthConfigSaved,
+	AuditOAuthProviderStatusChanged,
+	AuditGeneralSettingsUpdated,
+	AuditAdvancedSettingsUpdated,
+	AuditPrivacySettingsUpdated,
+	AuditEmailAuthSettingsUpdated,
+	AuditPostsExported,
+	AuditBackupExported,
+	AuditDataExported,
+	AuditBackupRestored,
+	AuditDataImported,
+	AuditAuditLogExported,
+	AuditPostDeleted,
+	AuditTagDeleted,
+	AuditTagGroupCreated,
+	AuditTagGroupUpdated,
+	AuditTagGroupDeleted,
+	AuditRoleCreated,
+	AuditRoleUpdated,
+	AuditRoleDeleted,
+	AuditUserCustomRoleChanged,
+	AuditTwoFactorSettingsUpdated,
+	AuditUserTwoFactorEnabled,
+	AuditUserTwoFactorDisabled,
+	AuditUserRecoveryCodesRegenerated,
+	AuditJobTriggered,
+	AuditJobPaused,
+	AuditJobResumed,
+	AuditFileReleased,
+	AuditWordFilterCreated,
+	AuditWordFilterDeleted,
+}
@@ -0,0 +1,13 @@
+package enum
+
+// FileStatus is the malware scan status of an attached file
+type FileStatus string
+
+var (
+	// FileStatusPending is used when the file is waiting to be scanned
+	FileStatusPending FileStatus = "pending"
+	// FileStatusClean is used when the file has been cleared and can be downloaded
+	FileStatusClean FileStatus = "clean"
+	// FileStatusQuarantined is used when the scanner found a threat in the file
+	FileStatusQuarantined FileStatus = "quarantined"
+)
@@ -0,0 +1,32 @@
+package enum
+
+// ImportSource is the tool an import file was exported from
+type ImportSource string
+
+var (
+	// ImportSourceCanny is the JSON export of Canny, with posts, comments and votes
+	ImportSourceCanny ImportSource = "canny"
+	// ImportSourceUserVoice is one of the CSV exports of UserVoice: suggestions, comments or supporters
+	ImportSourceUserVoice ImportSource = "uservoice"
+	// ImportSourceCSV is a CSV file of posts whose columns are mapped when the import is started
+	ImportSourceCSV ImportSource = "csv"
+)
+
+// IsValid returns true if given source is known
+func (s ImportSource) IsValid() bool {
+	return s == ImportSourceCanny || s == ImportSourceUserVoice || s == ImportSourceCSV
+}
+
+// ImportStatus is the progress of an import job
+type ImportStatus string
+
+var (
+	// ImportPending is used when the import is waiting to be picked up by a worker
+	ImportPending ImportStatus = "pending"
+	// ImportRunning is used while rows are being imported
+	ImportRunning ImportStatus = "running"
+	// ImportCompleted is used when every row was processed, even if some of them failed
+	ImportCompleted ImportStatus = "completed"
+	// ImportFailed is used when the import stopped before processing every row
+	ImportFailed ImportStatus = "failed"
+)
@@ -0,0 +1,21 @@
+package enum
+
+// JobRunStatus is the outcome of a run of a scheduled job
+type JobRunStatus string
+
+var (
+	// JobRunSucceeded is used when the job finished without errors
+	JobRunSucceeded JobRunStatus = "success"
+	// JobRunFailed is used when the job returned an error or panicked
+	JobRunFailed JobRunStatus = "failure"
+)
+
+// JobTrigger is what started a run of a scheduled job
+type JobTrigger string
+
+var (
+	// JobTriggerSchedule is used when the job was started by its schedule
+	JobTriggerSchedule JobTrigger = "schedule"
+	// JobTriggerManual is used when the job was started by an operator
+	JobTriggerManual JobTrigger = "manual"
+)
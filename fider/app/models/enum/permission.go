@@ -0,0 +1,106 @@
+package enum
+
+// Permission grants access to a group of operations within a tenant
+type Permission string
+
+var (
+	// PermissionAccessAdmin allows access to the administration pages
+	PermissionAccessAdmin Permission = "admin.access"
+	// PermissionUseAPI allows the usage of API keys
+	PermissionUseAPI Permission = "api.use"
+	// PermissionViewUsers allows listing members and their emails
+	PermissionViewUsers Permission = "users.view"
+	// PermissionManageUsers allows changing roles, blocking and trusting members
+	PermissionManageUsers Permission = "users.manage"
+	// PermissionSendInvitations allows sending invitations to join the site
+	PermissionSendInvitations Permission = "invitations.send"
+	// PermissionRespondToPosts allows changing the status of posts
+	PermissionRespondToPosts Permission = "posts.respond"
+	// PermissionEditPosts allows editing posts created by other members
+	PermissionEditPosts Permission = "posts.edit"
+	// PermissionDeletePosts allows deleting posts
+	PermissionDeletePosts Permission = "posts.delete"
+	// PermissionEditComments allows editing and deleting comments of other members
+	PermissionEditComments Permission = "comments.edit"
+	// PermissionAssignTags allows assigning and unassigning tags to posts
+	PermissionAssignTags Permission = "tags.assign"
+	// PermissionManageTags allows creating, editing and deleting tags
+	PermissionManageTags Permission = "tags.manage"
+	// PermissionModerateContent allows approving and declining posts and comments
+	PermissionModerateContent Permission = "moderation.manage"
+	// PermissionManageSettings allows changing site settings
+	PermissionManageSettings Permission = "settings.manage"
+	// PermissionManageAuthentication allows changing authentication providers
+	PermissionManageAuthentication Permission = "authentication.manage"
+	// PermissionManageWebhooks allows managing webhooks
+	PermissionManageWebhooks Permission = "webhooks.manage"
+	// PermissionManageRoles allows managing custom roles and assigning them
+	PermissionManageRoles Permission = "roles.manage"
+	// PermissionManageBilling allows managing the billing of the site
+	PermissionManageBilling Permission = "billing.manage"
+	// PermissionExportData allows exporting site data
+	PermissionExportData Permission = "data.export"
+	// PermissionImportData allows importing posts, comments and votes from other feedback tools
+	PermissionImportData Permission = "data.import"
+	// PermissionViewAuditLog allows reviewing the audit log
+	PermissionViewAuditLog Permission = "audit.view"
+	// PermissionManageJobs allows running, pausing and resuming the scheduled jobs of the instance
+	PermissionManageJobs Permission = "jobs.manage"
+)
+
+// Permissions is the list of all available permissions
+var Permissions = []Permission{
+	PermissionAccessAdmin,
+	PermissionUseAPI,
+	PermissionViewUsers,
+	PermissionManageUsers,
+	PermissionSendInvitations,
+	PermissionRespondToPosts,
+	PermissionEditPosts,
+	PermissionDeletePosts,
+	PermissionEditComments,
+	PermissionAssignTags,
+	PermissionManageTags,
+	PermissionModerateContent,
+	PermissionManageSettings,
+	PermissionManageAuthentication,
+	PermissionManageWebhooks,
+	PermissionManageRoles,
+	PermissionManageBilling,
+	PermissionExportData,
+	PermissionImportData,
+	PermissionViewAuditLog,
+	PermissionManageJobs,
+}
+
+var collaboratorPermissions = []Permission{
+	PermissionAccessAdmin,
+	PermissionUseAPI,
+	PermissionViewUsers,
+	PermissionSendInvitations,
+	PermissionRespondToPosts,
+	PermissionEditPosts,
+	PermissionEditComments,
+	PermissionAssignTags,
+}
+
+// IsValid returns true if permission is a known permission
+func (p Permission) IsValid() bool {
+	for _, permission := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the built-in permission preset of the role
+func (role Role) Permissions() []Permission {
+	switch role {
+	case RoleAdministrator:
+		return Permissions
+	case RoleCollaborator:
+		return collaboratorPermissions
+	}
+	return []Permission{}
+}
@@ -0,0 +1,77 @@
+package enum
+
+// ReportReason is the category of a report on a post or comment
+type ReportReason int
+
+var (
+	// ReportSpam is used for advertising and other unsolicited content
+	ReportSpam ReportReason = 1
+	// ReportHarassment is used for content attacking or threatening someone
+	ReportHarassment ReportReason = 2
+	// ReportOffensive is used for hateful, obscene or otherwise offensive content
+	ReportOffensive ReportReason = 3
+	// ReportOffTopic is used for content that doesn't belong on the site
+	ReportOffTopic ReportReason = 4
+	// ReportOther is used when no other reason fits, the reporter is expected to give details
+	ReportOther ReportReason = 5
+)
+
+var reportReasonIDs = map[ReportReason]string{
+	ReportSpam:       "spam",
+	ReportHarassment: "harassment",
+	ReportOffensive:  "offensive",
+	ReportOffTopic:   "off_topic",
+	ReportOther:      "other",
+}
+
+var reportReasonNames = map[string]ReportReason{
+	"spam":       ReportSpam,
+	"harassment": ReportHarassment,
+	"offensive":  ReportOffensive,
+	"off_topic":  ReportOffTopic,
+	"other":      ReportOther,
+}
+
+// String returns the string version of the report reason
+func (r ReportReason) String() string {
+	return reportReasonIDs[r]
+}
+
+// MarshalText returns the Text version of the report reason
+func (r ReportReason) MarshalText() ([]byte, error) {
+	return []byte(reportReasonIDs[r]), nil
+}
+
+// UnmarshalText parse string into a report reason
+func (r *ReportReason) UnmarshalText(text []byte) error {
+	*r = reportReasonNames[string(text)]
+	return nil
+}
+
+// ReportResolution is how a moderator resolved the reports on a post or comment
+type ReportResolution int
+
+var (
+	// ReportDismissed is used when the content was kept
+	ReportDismissed ReportResolution = 1
+	// ReportRemoved is used when the content was removed
+	ReportRemoved ReportResolution = 2
+	// ReportAuthorBlocked is used when the content was removed and its author blocked
+	ReportAuthorBlocked ReportResolution = 3
+)
+
+var reportResolutionIDs = map[ReportResolution]string{
+	ReportDismissed:     "dismissed",
+	ReportRemoved:       "removed",
+	ReportAuthorBlocked: "author_blocked",
+}
+
+// String returns the string version of the report resolution
+func (r ReportResolution) String() string {
+	return reportResolutionIDs[r]
+}
+
+// MarshalText returns the Text version of the report resolution
+func (r ReportResolution) MarshalText() ([]byte, error) {
+	return []byte(reportResolutionIDs[r]), nil
+}
@@ -0,0 +1,16 @@
+package enum
+
+// TagGroupSelection defines how many tags of a group can be assigned to the same post
+type TagGroupSelection string
+
+var (
+	// TagGroupSelectionMultiple allows any number of tags of the group on a post
+	TagGroupSelectionMultiple TagGroupSelection = "multiple"
+	// TagGroupSelectionSingle allows at most one tag of the group on a post
+	TagGroupSelectionSingle TagGroupSelection = "single"
+)
+
+// IsValid returns true if given selection is known
+func (s TagGroupSelection) IsValid() bool {
+	return s == TagGroupSelectionMultiple || s == TagGroupSelectionSingle
+}
@@ -0,0 +1,41 @@
+package enum
+
+// WordFilterAction is what happens to content that matches a word filter
+type WordFilterAction int
+
+var (
+	//WordFilterReject fails the validation of content that matches the filter
+	WordFilterReject WordFilterAction = 1
+	//WordFilterMask replaces the matched text with asterisks
+	WordFilterMask WordFilterAction = 2
+	//WordFilterModerate holds content that matches the filter on the moderation queue
+	WordFilterModerate WordFilterAction = 3
+)
+
+var wordFilterActionIDs = map[WordFilterAction]string{
+	WordFilterReject:   "reject",
+	WordFilterMask:     "mask",
+	WordFilterModerate: "moderate",
+}
+
+var wordFilterActionNames = map[string]WordFilterAction{
+	"reject":   WordFilterReject,
+	"mask":     WordFilterMask,
+	"moderate": WordFilterModerate,
+}
+
+// String returns the string version of the word filter action
+func (a WordFilterAction) String() string {
+	return wordFilterActionIDs[a]
+}
+
+// MarshalText returns the Text version of the word filter action
+func (a WordFilterAction) MarshalText() ([]byte, error) {
+	return []byte(wordFilterActionIDs[a]), nil
+}
+
+// UnmarshalText parse string into a word filter action
+func (a *WordFilterAction) UnmarshalText(text []byte) error {
+	*a = wordFilterActionNames[string(text)]
+	return nil
+}
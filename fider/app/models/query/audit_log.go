@@ -0,0 +1,34 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type SearchAuditLogs struct {
+	Action     enum.AuditAction
+	ActorID    int
+	TargetType string
+	TargetID   int
+	Since      *time.Time
+	Until      *time.Time
+	Page       int
+	Limit      int
+
+	Result     []*entity.AuditLog
+	TotalCount int
+}
+
+// SetPeriodFromStrings parses dates in the YYYY-MM-DD format, invalid values are ignored.
+// Until is inclusive, so it is moved to the start of the following day
+func (q *SearchAuditLogs) SetPeriodFromStrings(since, until string) {
+	if t, err := time.Parse("2006-01-02", since); err == nil {
+		q.Since = &t
+	}
+	if t, err := time.Parse("2006-01-02", until); err == nil {
+		t = t.AddDate(0, 0, 1)
+		q.Until = &t
+	}
+}
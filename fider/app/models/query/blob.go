@@ -13,3 +13,16 @@ type GetBlobByKey struct {
 
 	Result *dto.Blob
 }
+
+// OpenBlob opens a blob to be read as a stream, without holding all of its content in memory when the storage allows it
+type OpenBlob struct {
+	Key string
+
+	Result *dto.BlobReader
+}
+
+// ListReferencedBlobs returns the keys of the blobs referenced by current tenant, such as attachments, avatars and logos.
+// Blobs of deleted posts and comments are no longer referenced
+type ListReferencedBlobs struct {
+	Result []string
+}
@@ -12,6 +12,8 @@ type GetCommentByID struct {
 
 type GetCommentsByPost struct {
 	Post *entity.Post
+	// IncludeRemovedParents also returns deleted or hidden comments that have visible replies, flagged as IsRemoved
+	IncludeRemovedParents bool
 
 	Result []*entity.Comment
 }
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// ExportFilter selects the posts of an export with the same criteria used by SearchPosts.
+// Since and Until limit when the exported records were created, be it posts, comments or votes
+type ExportFilter struct {
+	Query      string
+	Statuses   []enum.PostStatus
+	Tags       []string
+	TagGroups  []string
+	NoTagsOnly bool
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// SetStatusesFromStrings parses the statuses the same way SearchPosts does, unknown values are ignored
+func (f *ExportFilter) SetStatusesFromStrings(statuses []string) {
+	search := &SearchPosts{}
+	search.SetStatusesFromStrings(statuses)
+	f.Statuses = search.Statuses
+}
+
+// SetPeriodFromStrings parses dates in the YYYY-MM-DD format, invalid values are ignored.
+// Until is inclusive, so it is moved to the start of the following day
+func (f *ExportFilter) SetPeriodFromStrings(since, until string) {
+	if t, err := time.Parse("2006-01-02", since); err == nil {
+		f.Since = &t
+	}
+	if t, err := time.Parse("2006-01-02", until); err == nil {
+		t = t.AddDate(0, 0, 1)
+		f.Until = &t
+	}
+}
+
+// ExportPosts returns the next page of posts that match the filter, ordered by id
+type ExportPosts struct {
+	Filter        ExportFilter
+	AfterID       int
+	Limit         int
+	IncludeEmails bool
+
+	Result []*entity.ExportedPost
+}
+
+// ExportComments returns the next page of comments of the posts that match the filter, ordered by id
+type ExportComments struct {
+	Filter        ExportFilter
+	AfterID       int
+	Limit         int
+	IncludeEmails bool
+
+	Result []*entity.ExportedComment
+}
+
+// ExportVotes returns the next page of votes of the posts that match the filter, ordered by post and user
+type ExportVotes struct {
+	Filter        ExportFilter
+	AfterPostID   int
+	AfterUserID   int
+	Limit         int
+	IncludeEmails bool
+
+	Result []*entity.ExportedVote
+}
+
+// ExportReactions returns the next page of reactions to the posts that match the filter, and to their comments, ordered by id
+type ExportReactions struct {
+	Filter        ExportFilter
+	AfterID       int
+	Limit         int
+	IncludeEmails bool
+
+	Result []*entity.ExportedReaction
+}
@@ -0,0 +1,23 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetFileByID returns a file of current tenant
+type GetFileByID struct {
+	FileID int
+
+	Result *entity.File
+}
+
+// ListPostFiles returns the files attached to a post and its comments, oldest first.
+// Files of comments that were deleted or that current user can't see are left out
+type ListPostFiles struct {
+	PostID int
+
+	Result []*entity.File
+}
+
+// GetFilesUsage returns the total size in bytes of the files attached on current tenant
+type GetFilesUsage struct {
+	Result int64
+}
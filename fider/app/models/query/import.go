@@ -0,0 +1,24 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type GetImportJobByID struct {
+	JobID int
+
+	Result *entity.ImportJob
+}
+
+type ListImportJobs struct {
+	Result []*entity.ImportJob
+}
+
+// GetImportedPost returns the post previously imported with given SourceID, Result is nil when there is none
+type GetImportedPost struct {
+	Source   enum.ImportSource
+	SourceID string
+
+	Result *entity.Post
+}
@@ -0,0 +1,11 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// ListJobRuns returns the most recent runs of a scheduled job, newest first
+type ListJobRuns struct {
+	JobName string
+	Limit   int
+
+	Result []*entity.JobRun
+}
@@ -7,16 +7,19 @@ import (
 )
 
 type ModerationItem struct {
-	Type       string                `json:"type"` // "post" or "comment"
-	ID         int                   `json:"id"`
-	PostID     int                   `json:"postId,omitempty"`
-	PostNumber int                   `json:"postNumber,omitempty"`
-	PostSlug   string                `json:"postSlug,omitempty"`
-	Title      string                `json:"title,omitempty"`
-	Content    string                `json:"content"`
-	User       *entity.UserWithEmail `json:"user"`
-	CreatedAt  time.Time             `json:"createdAt"`
-	PostTitle  string                `json:"postTitle,omitempty"`
+	Type       string                  `json:"type"` // "post" or "comment"
+	ID         int                     `json:"id"`
+	PostID     int                     `json:"postId,omitempty"`
+	PostNumber int                     `json:"postNumber,omitempty"`
+	PostSlug   string                  `json:"postSlug,omitempty"`
+	Title      string                  `json:"title,omitempty"`
+	Content    string                  `json:"content"`
+	User       *entity.UserWithEmail   `json:"user"`
+	CreatedAt  time.Time               `json:"createdAt"`
+	PostTitle  string                  `json:"postTitle,omitempty"`
+	SpamScore  *entity.SpamScore       `json:"spamScore,omitempty"`
+	IsApproved bool                    `json:"isApproved"`
+	Reports    []*entity.ContentReport `json:"reports,omitempty"`
 }
 
 type GetModerationItems struct {
@@ -39,6 +39,7 @@ type SearchPosts struct {
 	Limit            string
 	Statuses         []enum.PostStatus
 	Tags             []string
+	TagGroups        []string
 	MyVotesOnly      bool
 	NoTagsOnly       bool
 	MyPostsOnly      bool
@@ -0,0 +1,11 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// ListReactions returns who reacted to a post, or to one of its comments when CommentID is set, and with what
+type ListReactions struct {
+	PostID    int
+	CommentID int
+
+	Result []*entity.Reaction
+}
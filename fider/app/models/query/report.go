@@ -0,0 +1,8 @@
+package query
+
+type HasReportedContent struct {
+	PostID    int
+	CommentID int
+
+	Result bool
+}
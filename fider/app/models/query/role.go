@@ -0,0 +1,21 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetRoleByID struct {
+	RoleID int
+
+	Result *entity.Role
+}
+
+type GetRoleByName struct {
+	Name string
+
+	Result *entity.Role
+}
+
+type ListRoles struct {
+	Result []*entity.Role
+}
@@ -0,0 +1,17 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+// GetSpamSignals counts the submissions of an user and an IP since given time and the previous submissions of the same content
+type GetSpamSignals struct {
+	UserID      int
+	IP          string
+	ContentHash string
+	Since       time.Time
+
+	Result *entity.SpamSignals
+}
@@ -19,3 +19,19 @@ type GetAssignedTags struct {
 type GetAllTags struct {
 	Result []*entity.Tag
 }
+
+type GetTagGroupBySlug struct {
+	Slug string
+
+	Result *entity.TagGroup
+}
+
+type GetTagGroupByID struct {
+	GroupID int
+
+	Result *entity.TagGroup
+}
+
+type GetAllTagGroups struct {
+	Result []*entity.TagGroup
+}
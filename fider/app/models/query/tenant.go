@@ -42,6 +42,19 @@ type GetFirstTenant struct {
 	Result *entity.Tenant
 }
 
+type GetActiveTenants struct {
+
+	// Output
+	Result []*entity.Tenant
+}
+
+// GetAllTenants returns every tenant, regardless of its status
+type GetAllTenants struct {
+
+	// Output
+	Result []*entity.Tenant
+}
+
 type GetTenantByDomain struct {
 	Domain string
 
@@ -49,6 +62,13 @@ type GetTenantByDomain struct {
 	Result *entity.Tenant
 }
 
+type GetTenantByID struct {
+	TenantID int
+
+	// Output
+	Result *entity.Tenant
+}
+
 type GetPendingSignUpVerification struct {
 	// Output
 	Result *entity.EmailVerification
@@ -0,0 +1,23 @@
+package query
+
+import "time"
+
+type GetTwoFactorSecret struct {
+	UserID int
+
+	Result string
+}
+
+type CountUnusedRecoveryCodes struct {
+	UserID int
+
+	Result int
+}
+
+// GetTwoFactorLockout returns the time until which the user can't verify their second factor.
+// Result is zero when the user isn't locked out
+type GetTwoFactorLockout struct {
+	UserID int
+
+	Result time.Time
+}
@@ -48,7 +48,19 @@ type GetAllUsers struct {
 	Result []*entity.User
 }
 
-type GetAllUsersNames struct {
+// SearchTaggableUsers returns a page of the active users whose name starts with Query, ordered by name
+type SearchTaggableUsers struct {
+	Query string
+	Page  int
+	Limit int
+
+	Result []*dto.UserNames
+}
+
+// GetUserNamesByIDs returns the current names of given users, deleted users are left out
+type GetUserNamesByIDs struct {
+	UserIDs []int
+
 	Result []*dto.UserNames
 }
 
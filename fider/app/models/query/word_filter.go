@@ -0,0 +1,13 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type GetWordFilters struct {
+	Result []*entity.WordFilter
+}
+
+type GetWordFilterByID struct {
+	ID int
+
+	Result *entity.WordFilter
+}
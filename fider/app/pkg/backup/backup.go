@@ -2,9 +2,14 @@ package backup
 
 import (
 	"archive/zip"
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"hash"
+	"io"
+	"strings"
 
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
@@ -12,31 +17,98 @@ import (
 	"github.com/getfider/fider/app/pkg/errors"
 )
 
-func Create(ctx context.Context) (*bytes.Buffer, error) {
-
-	buffer := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(buffer)
-
-	for _, tableName := range []string{
-		"attachments",
-		"comments",
-		"email_verifications",
-		"notifications",
-		"oauth_providers",
-		"posts",
-		"post_subscribers",
-		"post_tags",
-		"post_votes",
-		"tags",
-		"tenants",
-		"user_providers",
-		"users",
-		"user_settings",
-	} {
-		err := addTableDataToZipFile(ctx, zipWriter, tableName)
-		if err != nil {
+// tables is the list of tenant tables included on a backup
+var tables = []string{
+	"attachments",
+	"comments",
+	"content_reports",
+	"email_verifications",
+	"files",
+	"notifications",
+	"oauth_providers",
+	"posts",
+	"post_subscribers",
+	"post_tags",
+	"post_votes",
+	"reactions",
+	"roles",
+	"tag_groups",
+	"tags",
+	"tenants",
+	"user_providers",
+	"user_recovery_codes",
+	"users",
+	"user_settings",
+	"word_filters",
+}
+
+// BlobPrefix is where scheduled backups are stored, outside of the blob namespace of every tenant so they're never served.
+// Backups stored by older versions within the namespace of a tenant are never included on a backup
+const BlobPrefix = "backups/"
+
+// TenantBlobPrefix is where scheduled backups of given tenant are stored
+func TenantBlobPrefix(tenantID int) string {
+	return fmt.Sprintf("%s%d/", BlobPrefix, tenantID)
+}
+
+// WriteOptions defines how an archive is written
+type WriteOptions struct {
+	// Passphrase encrypts the archive when not empty
+	Passphrase string
+}
+
+// archiveWriter writes entries into a zip archive and records their checksums on the manifest
+type archiveWriter struct {
+	zip      *zip.Writer
+	manifest *Manifest
+}
+
+func (a *archiveWriter) create(name string, write func(w io.Writer) error) error {
+	fileWriter, err := a.zip.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to create %s in zip file", name)
+	}
+
+	checksum := sha256.New()
+	if err := write(io.MultiWriter(fileWriter, checksum)); err != nil {
+		return errors.Wrap(err, "failed to write %s to zip file", name)
+	}
+
+	a.manifest.Files[name] = checksumOf(checksum)
+	return nil
+}
+
+func checksumOf(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Write streams a backup of current tenant into w. Rows and blobs are written as they are read,
+// so the archive is never held in memory. The manifest goes last, as it holds the checksum of every other file
+func Write(ctx context.Context, w io.Writer, opts WriteOptions) (*Manifest, error) {
+	var encrypted io.WriteCloser
+	if opts.Passphrase != "" {
+		var err error
+		if encrypted, err = NewEncryptWriter(w, opts.Passphrase); err != nil {
 			return nil, err
 		}
+		w = encrypted
+	}
+
+	manifest, err := newManifest(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Encrypted = encrypted != nil
+
+	archive := &archiveWriter{zip: zip.NewWriter(w), manifest: manifest}
+
+	for _, tableName := range tables {
+		err := archive.create(fmt.Sprintf("%s.json", tableName), func(w io.Writer) error {
+			return exportTable(ctx, tableName, w)
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to export %s table", tableName)
+		}
 	}
 
 	listBlobs := &query.ListBlobs{}
@@ -45,53 +117,60 @@ func Create(ctx context.Context) (*bytes.Buffer, error) {
 	}
 
 	for _, bkey := range listBlobs.Result {
-		err := addBlobToZipFile(ctx, zipWriter, bkey)
+		if strings.HasPrefix(bkey, BlobPrefix) {
+			continue
+		}
+
+		err := addBlobToZipFile(ctx, archive, bkey)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	err := zipWriter.Close()
+	if err := addManifestToZipFile(archive.zip, manifest); err != nil {
+		return nil, err
+	}
+
+	err = archive.zip.Close()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to close zip file")
 	}
 
-	return buffer, nil
+	if encrypted != nil {
+		if err := encrypted.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest, nil
 }
 
-func addBlobToZipFile(ctx context.Context, zipWriter *zip.Writer, bkey string) error {
-	getBlob := &query.GetBlobByKey{Key: bkey}
-	if err := bus.Dispatch(ctx, getBlob); err != nil {
-		return errors.Wrap(err, "failed to get blob with key %s", bkey)
+func addManifestToZipFile(zipWriter *zip.Writer, manifest *Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal %s", manifestFileName)
 	}
 
-	fileName := fmt.Sprintf("blobs/%s", bkey)
-	fileWriter, err := zipWriter.Create(fileName)
+	fileWriter, err := zipWriter.Create(manifestFileName)
 	if err != nil {
-		return errors.Wrap(err, "failed to create %s in zip file", fileName)
+		return errors.Wrap(err, "failed to create %s in zip file", manifestFileName)
 	}
-	_, err = fileWriter.Write(getBlob.Result.Content)
+	_, err = fileWriter.Write(content)
 	if err != nil {
-		return errors.Wrap(err, "failed to write %s to zip file", fileName)
+		return errors.Wrap(err, "failed to write %s to zip file", manifestFileName)
 	}
 
 	return nil
 }
 
-func addTableDataToZipFile(ctx context.Context, zipWriter *zip.Writer, tableName string) error {
-	tableData, err := exportTable(ctx, tableName)
-	if err != nil {
-		return errors.Wrap(err, "failed to export %s table", tableName)
-	}
-
-	fileWriter, err := zipWriter.Create(fmt.Sprintf("%s.json", tableName))
-	if err != nil {
-		return errors.Wrap(err, "failed to create %s.json in zip file", tableName)
-	}
-	_, err = fileWriter.Write(tableData)
-	if err != nil {
-		return errors.Wrap(err, "failed to write %s.json to zip file", tableName)
+func addBlobToZipFile(ctx context.Context, archive *archiveWriter, bkey string) error {
+	getBlob := &query.GetBlobByKey{Key: bkey}
+	if err := bus.Dispatch(ctx, getBlob); err != nil {
+		return errors.Wrap(err, "failed to get blob with key %s", bkey)
 	}
 
-	return nil
+	return archive.create(fmt.Sprintf("blobs/%s", bkey), func(w io.Writer) error {
+		_, err := w.Write(getBlob.Result.Content)
+		return err
+	})
 }
@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/getfider/fider/app/pkg/errors"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted archives start with a header holding the parameters to derive the key from the passphrase,
+// followed by the archive split into chunks that are individually sealed with ChaCha20-Poly1305.
+// The nonce of each chunk is its sequence number plus a flag set only on the last one,
+// so chunks cannot be reordered, dropped or truncated without failing decryption.
+var encryptionMagic = []byte("FIDERBAK")
+
+const (
+	encryptionVersion   = 1
+	encryptionScryptLog = 15
+	encryptionSaltSize  = 16
+	encryptionChunkSize = 64 * 1024
+	encryptionHeaderLen = 8 + 1 + 1 + encryptionSaltSize
+)
+
+// ErrInvalidPassphrase is returned when an archive cannot be decrypted with given passphrase
+var ErrInvalidPassphrase = errors.New("invalid passphrase or corrupted backup")
+
+// IsEncrypted returns true if the content starts like an encrypted archive
+func IsEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, encryptionMagic)
+}
+
+func deriveKey(passphrase string, salt []byte, scryptLog byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<scryptLog, 8, 1, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive encryption key")
+	}
+	return chacha20poly1305.New(key)
+}
+
+func chunkNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	buffer  []byte
+	counter uint64
+}
+
+// NewEncryptWriter returns a writer that encrypts everything written to it with given passphrase.
+// Close must be called to write the last chunk, it doesn't close the underlying writer
+func NewEncryptWriter(w io.Writer, passphrase string) (io.WriteCloser, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required to encrypt a backup")
+	}
+
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	aead, err := deriveKey(passphrase, salt, encryptionScryptLog)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte{}, encryptionMagic...)
+	header = append(header, encryptionVersion, encryptionScryptLog)
+	header = append(header, salt...)
+	if _, err := w.Write(header); err != nil {
+		return nil, errors.Wrap(err, "failed to write encryption header")
+	}
+
+	return &encryptWriter{w: w, aead: aead, buffer: make([]byte, 0, encryptionChunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, as the last chunk must be flagged as such
+		if len(e.buffer) == encryptionChunkSize {
+			if err := e.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := min(encryptionChunkSize-len(e.buffer), len(p))
+		e.buffer = append(e.buffer, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.counter, last), e.buffer, nil)
+	if _, err := e.w.Write(sealed); err != nil {
+		return errors.Wrap(err, "failed to write encrypted chunk")
+	}
+	e.counter++
+	e.buffer = e.buffer[:0]
+	return nil
+}
+
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	chunk   []byte
+	plain   []byte
+	counter uint64
+	done    bool
+}
+
+// NewDecryptReader returns a reader of the original content of an archive encrypted by NewEncryptWriter
+func NewDecryptReader(r io.Reader, passphrase string) (io.Reader, error) {
+	header := make([]byte, encryptionHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil || !IsEncrypted(header) {
+		return nil, errors.New("backup is not encrypted")
+	}
+	if header[8] != encryptionVersion {
+		return nil, errors.New("backup encryption version %d is not supported", header[8])
+	}
+	if header[9] < 10 || header[9] > 20 {
+		return nil, errors.New("backup encryption has invalid key derivation parameters")
+	}
+
+	aead, err := deriveKey(passphrase, header[10:], header[9])
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		r:     bufio.NewReaderSize(r, encryptionChunkSize+chacha20poly1305.Overhead+1),
+		aead:  aead,
+		chunk: make([]byte, encryptionChunkSize+chacha20poly1305.Overhead),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	n, err := io.ReadFull(d.r, d.chunk)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return ErrInvalidPassphrase
+		}
+		return errors.Wrap(err, "failed to read encrypted chunk")
+	}
+
+	_, peekErr := d.r.Peek(1)
+	last := peekErr == io.EOF
+
+	plain, err := d.aead.Open(d.chunk[:0], chunkNonce(d.counter, last), d.chunk[:n], nil)
+	if err != nil {
+		return ErrInvalidPassphrase
+	}
+
+	d.counter++
+	d.plain = plain
+	d.done = last
+	return nil
+}
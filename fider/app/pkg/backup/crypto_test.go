@@ -0,0 +1,64 @@
+package backup_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/backup"
+)
+
+func encrypt(content []byte, passphrase string) []byte {
+	buffer := new(bytes.Buffer)
+	writer, err := backup.NewEncryptWriter(buffer, passphrase)
+	Expect(err).IsNil()
+	_, err = writer.Write(content)
+	Expect(err).IsNil()
+	Expect(writer.Close()).IsNil()
+	return buffer.Bytes()
+}
+
+func decrypt(content []byte, passphrase string) ([]byte, error) {
+	reader, err := backup.NewDecryptReader(bytes.NewReader(content), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func TestEncryption_RoundTrip(t *testing.T) {
+	RegisterT(t)
+
+	for _, size := range []int{0, 10, 64 * 1024, 64*1024 + 1, 300 * 1024} {
+		content := make([]byte, size)
+		_, _ = rand.Read(content)
+
+		encrypted := encrypt(content, "my secret")
+		Expect(backup.IsEncrypted(encrypted)).IsTrue()
+		Expect(backup.IsEncrypted(content)).IsFalse()
+
+		decrypted, err := decrypt(encrypted, "my secret")
+		Expect(err).IsNil()
+		Expect(bytes.Equal(decrypted, content)).IsTrue()
+	}
+}
+
+func TestEncryption_WrongPassphrase(t *testing.T) {
+	RegisterT(t)
+
+	encrypted := encrypt([]byte("hello world"), "my secret")
+	_, err := decrypt(encrypted, "not my secret")
+	Expect(err).Equals(backup.ErrInvalidPassphrase)
+}
+
+func TestEncryption_Truncated(t *testing.T) {
+	RegisterT(t)
+
+	content := make([]byte, 200*1024)
+	encrypted := encrypt(content, "my secret")
+
+	_, err := decrypt(encrypted[:len(encrypted)-64*1024], "my secret")
+	Expect(err).Equals(backup.ErrInvalidPassphrase)
+}
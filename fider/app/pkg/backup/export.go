@@ -5,14 +5,17 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
 )
 
-func exportTable(ctx context.Context, tableName string) ([]byte, error) {
+// exportTable writes all rows of given table as a JSON array into w, one row at a time
+func exportTable(ctx context.Context, tableName string, w io.Writer) error {
 	trx := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
 	tenant, _ := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
 	columnName := "tenant_id"
@@ -22,56 +25,81 @@ func exportTable(ctx context.Context, tableName string) ([]byte, error) {
 
 	rows, err := trx.Query(fmt.Sprintf("SELECT * FROM %s WHERE %s = $1", tableName, columnName), tenant.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return json.Marshal(jsonify(rows))
-}
-
-func jsonify(rows *sql.Rows) []map[string]any {
 	defer func() { _ = rows.Close() }()
+
 	columns, err := rows.Columns()
 	if err != nil {
-		panic(err.Error())
+		return errors.Wrap(err, "failed to get columns of %s", tableName)
 	}
 
-	allResults := make([]map[string]any, 0)
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
 
+	first := true
 	for rows.Next() {
-		results := make(map[string]any)
-		values := make([]any, len(columns))
-		scanArgs := make([]any, len(values))
-		for i := range values {
-			scanArgs[i] = &values[i]
+		row, err := jsonify(rows, columns)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan row of %s", tableName)
 		}
 
-		err = rows.Scan(scanArgs...)
+		content, err := json.Marshal(row)
 		if err != nil {
-			panic(err.Error())
+			return errors.Wrap(err, "failed to marshal row of %s", tableName)
+		}
+
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if _, err := w.Write(content); err != nil {
+			return err
 		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to read rows of %s", tableName)
+	}
 
-		for i, value := range values {
-			switch value := value.(type) {
-			case nil:
-				results[columns[i]] = nil
+	_, err = io.WriteString(w, "]")
+	return err
+}
 
-			case []byte:
-				s := string(value)
-				x, err := strconv.Atoi(s)
+func jsonify(rows *sql.Rows, columns []string) (map[string]any, error) {
+	results := make(map[string]any)
+	values := make([]any, len(columns))
+	scanArgs := make([]any, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
 
-				if err != nil {
-					results[columns[i]] = s
-				} else {
-					results[columns[i]] = x
-				}
+	if err := rows.Scan(scanArgs...); err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		switch value := value.(type) {
+		case nil:
+			results[columns[i]] = nil
+
+		case []byte:
+			s := string(value)
+			x, err := strconv.Atoi(s)
 
-			default:
-				results[columns[i]] = value
+			if err != nil {
+				results[columns[i]] = s
+			} else {
+				results[columns[i]] = x
 			}
-		}
 
-		allResults = append(allResults, results)
+		default:
+			results[columns[i]] = value
+		}
 	}
 
-	return allResults
+	return results, nil
 }
@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// FormatVersion is the version of the archive layout written by Write.
+// It must be increased whenever a change makes older versions of Restore unable to read the archive.
+// Version 2 writes the manifest last and adds the checksum of every file
+const FormatVersion = 2
+
+const manifestFileName = "manifest.json"
+
+// Manifest describes the content of a backup archive
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	SchemaVersion int       `json:"schemaVersion"`
+	AppVersion    string    `json:"appVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Tables        []string  `json:"tables"`
+	Encrypted     bool      `json:"encrypted"`
+
+	// Files maps the name of every file on the archive to its SHA-256 checksum
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// Validate returns an error if an archive with this manifest cannot be restored into a database on given schema version
+func (m *Manifest) Validate(schemaVersion int) error {
+	if m.FormatVersion < 1 || m.FormatVersion > FormatVersion {
+		return restoreFailed("Backup format version %d is not supported, expected up to %d.", m.FormatVersion, FormatVersion)
+	}
+	if m.SchemaVersion <= 0 {
+		return restoreFailed("Backup does not specify the schema version it was created from.")
+	}
+	if m.SchemaVersion > schemaVersion {
+		return restoreFailed("Backup was created from schema version %d, which is newer than this database (%d). Upgrade Fider and run the migrations before restoring it.", m.SchemaVersion, schemaVersion)
+	}
+	return nil
+}
+
+// verifyChecksums compares every file of the archive with the checksum recorded on the manifest.
+// Archives written before checksums were added have none and are accepted as they are
+func (m *Manifest) verifyChecksums(archive *zip.Reader) error {
+	if len(m.Files) == 0 {
+		return nil
+	}
+
+	for name, expected := range m.Files {
+		file, err := archive.Open(name)
+		if err != nil {
+			return restoreFailed("Backup is incomplete, %s is missing.", name)
+		}
+
+		checksum := sha256.New()
+		_, err = io.Copy(checksum, file)
+		_ = file.Close()
+		if err != nil {
+			return restoreFailed("Backup is corrupted, %s cannot be read: %s", name, err.Error())
+		}
+		if checksumOf(checksum) != expected {
+			return restoreFailed("Backup is corrupted, checksum of %s does not match.", name)
+		}
+	}
+	return nil
+}
+
+func newManifest(ctx context.Context, tables []string) (*Manifest, error) {
+	schemaVersion, err := currentSchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: schemaVersion,
+		AppVersion:    env.Version(),
+		CreatedAt:     time.Now(),
+		Tables:        tables,
+		Files:         make(map[string]string),
+	}, nil
+}
+
+func readManifest(archive *zip.Reader) (*Manifest, error) {
+	file, err := archive.Open(manifestFileName)
+	if err != nil {
+		return nil, restoreFailed("Backup has no %s, only archives exported by this version of Fider or newer can be restored.", manifestFileName)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read %s", manifestFileName)
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, restoreFailed("Backup has an invalid %s: %s", manifestFileName, err.Error())
+	}
+	return manifest, nil
+}
+
+func currentSchemaVersion(ctx context.Context) (int, error) {
+	trx := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
+
+	var version int
+	if err := trx.Scalar(&version, "SELECT COALESCE(MAX(version), 0) FROM migrations_history"); err != nil {
+		return 0, errors.Wrap(err, "failed to get current schema version")
+	}
+	return version, nil
+}
@@ -0,0 +1,28 @@
+package backup_test
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/backup"
+)
+
+func TestManifest_Validate(t *testing.T) {
+	RegisterT(t)
+
+	manifest := &backup.Manifest{FormatVersion: backup.FormatVersion, SchemaVersion: 202512221000}
+	Expect(manifest.Validate(202512221000)).IsNil()
+	Expect(manifest.Validate(202512231000)).IsNil()
+
+	for _, invalid := range []*backup.Manifest{
+		{FormatVersion: 0, SchemaVersion: 202512221000},
+		{FormatVersion: backup.FormatVersion + 1, SchemaVersion: 202512221000},
+		{FormatVersion: backup.FormatVersion, SchemaVersion: 0},
+		{FormatVersion: backup.FormatVersion, SchemaVersion: 202601011000},
+	} {
+		err := invalid.Validate(202512231000)
+		Expect(err).IsNotNil()
+		_, isRestoreError := err.(*backup.RestoreError)
+		Expect(isRestoreError).IsTrue()
+	}
+}
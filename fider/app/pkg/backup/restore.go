@@ -0,0 +1,639 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/lib/pq"
+)
+
+// RestoreOptions defines how an archive is restored
+type RestoreOptions struct {
+	// Subdomain of the new tenant, the one from the archive is used when empty
+	Subdomain string
+	// DryRun validates and imports the whole archive, but discards all changes at the end
+	DryRun bool
+}
+
+// RestoreReport is the outcome of a restore
+type RestoreReport struct {
+	DryRun        bool           `json:"dryRun"`
+	TenantID      int            `json:"tenantId"`
+	TenantName    string         `json:"tenantName"`
+	Subdomain     string         `json:"subdomain"`
+	SchemaVersion int            `json:"schemaVersion"`
+	Rows          map[string]int `json:"rows"`
+	Blobs         int            `json:"blobs"`
+	Warnings      []string       `json:"warnings"`
+}
+
+// RestoreError is a problem found on the content of the archive, its message is meant to be shown to users
+type RestoreError struct {
+	message string
+}
+
+func (e *RestoreError) Error() string {
+	return e.message
+}
+
+func restoreFailed(format string, args ...any) error {
+	return &RestoreError{message: fmt.Sprintf(format, args...)}
+}
+
+func (r *RestoreReport) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// restoreTable describes how rows of a table are linked to other tables of the archive
+type restoreTable struct {
+	name string
+	// references maps a column to the table whose ids it holds
+	references map[string]string
+	// selfReferences are columns holding ids of the same table, which are only known once all rows are inserted
+	selfReferences []string
+	// prepare rewrites a row before it's inserted
+	prepare func(r *restorer, row map[string]any) error
+}
+
+// restoreOrder lists the tables on an order that every referenced row is inserted before the rows referencing it
+var restoreOrder = []restoreTable{
+	{name: "roles"},
+	{name: "tag_groups", prepare: (*restorer).remapRoleKeys},
+	{name: "oauth_providers", prepare: (*restorer).renameProvider},
+	{name: "users", references: map[string]string{"custom_role_id": "roles"}},
+	{name: "user_providers", references: map[string]string{"user_id": "users"}, prepare: (*restorer).remapProvider},
+	{name: "user_settings", references: map[string]string{"user_id": "users"}},
+	{name: "user_recovery_codes", references: map[string]string{"user_id": "users"}},
+	{name: "word_filters"},
+	{name: "tags", references: map[string]string{"group_id": "tag_groups"}},
+	{
+		name:           "posts",
+		references:     map[string]string{"user_id": "users", "response_user_id": "users"},
+		selfReferences: []string{"original_id"},
+		prepare:        remapMentionsOf("description"),
+	},
+	{name: "post_tags", references: map[string]string{"post_id": "posts", "tag_id": "tags", "created_by_id": "users"}},
+	{name: "post_votes", references: map[string]string{"post_id": "posts", "user_id": "users"}},
+	{name: "post_subscribers", references: map[string]string{"post_id": "posts", "user_id": "users"}},
+	{
+		name:           "comments",
+		references:     map[string]string{"post_id": "posts", "user_id": "users", "edited_by_id": "users", "deleted_by_id": "users"},
+		selfReferences: []string{"parent_id"},
+		prepare:        remapMentionsOf("content"),
+	},
+	{name: "attachments", references: map[string]string{"post_id": "posts", "comment_id": "comments", "user_id": "users"}},
+	{name: "files", references: map[string]string{"post_id": "posts", "comment_id": "comments", "user_id": "users"}},
+	{name: "reactions", references: map[string]string{"post_id": "posts", "comment_id": "comments", "user_id": "users"}},
+	{
+		name:       "content_reports",
+		references: map[string]string{"post_id": "posts", "comment_id": "comments", "user_id": "users", "resolved_by_id": "users"},
+	},
+	{name: "notifications", references: map[string]string{"post_id": "posts", "user_id": "users", "author_id": "users"}},
+}
+
+type restorer struct {
+	ctx     context.Context
+	trx     *dbx.Trx
+	archive *zip.Reader
+	report  *RestoreReport
+
+	tenantID int
+	columns  map[string]map[string]bool
+	skipped  map[string]bool
+	// ids maps the ids of the archive into the ids of the new rows, per table
+	ids map[string]map[int64]int64
+	// providers maps the keys of OAuth providers that were renamed because they're already used on this installation
+	providers map[string]string
+}
+
+// Restore imports an archive created by Write into a fresh tenant.
+// All rows get new ids and every reference between them is rewritten, blobs are uploaded into the configured blob storage.
+// Pending email verifications are not restored, their keys belong to the original site.
+// Everything runs inside the transaction of given context and is discarded in case of failure or when DryRun is set
+func Restore(ctx context.Context, archive *zip.Reader, opts RestoreOptions) (*RestoreReport, error) {
+	trx := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVersion, err := currentSchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.Validate(schemaVersion); err != nil {
+		return nil, err
+	}
+	if err := manifest.verifyChecksums(archive); err != nil {
+		return nil, err
+	}
+
+	r := &restorer{
+		ctx:     ctx,
+		trx:     trx,
+		archive: archive,
+		report: &RestoreReport{
+			DryRun:        opts.DryRun,
+			SchemaVersion: manifest.SchemaVersion,
+			Rows:          make(map[string]int),
+			Warnings:      make([]string, 0),
+		},
+		columns:   make(map[string]map[string]bool),
+		skipped:   make(map[string]bool),
+		ids:       make(map[string]map[int64]int64),
+		providers: make(map[string]string),
+	}
+
+	if manifest.SchemaVersion < schemaVersion {
+		r.report.warn("Backup was created from schema version %d and is being restored into version %d. Columns added since then will use their default values.", manifest.SchemaVersion, schemaVersion)
+	}
+
+	// A savepoint keeps the outer transaction usable after a failed statement, so callers can still report the error
+	if _, err := trx.Execute("SAVEPOINT backup_restore"); err != nil {
+		return nil, errors.Wrap(err, "failed to create savepoint")
+	}
+
+	if err := r.run(opts); err != nil {
+		if _, rollbackErr := trx.Execute("ROLLBACK TO SAVEPOINT backup_restore"); rollbackErr != nil {
+			return nil, errors.Wrap(rollbackErr, "failed to rollback restore after: %s", err.Error())
+		}
+		return nil, err
+	}
+
+	release := "RELEASE SAVEPOINT backup_restore"
+	if opts.DryRun {
+		release = "ROLLBACK TO SAVEPOINT backup_restore"
+	}
+	if _, err := trx.Execute(release); err != nil {
+		return nil, errors.Wrap(err, "failed to finish restore")
+	}
+
+	return r.report, nil
+}
+
+// ValidateTarget returns the reasons that prevent an archive from being restored into a new tenant with given subdomain.
+// Single host mode serves only one tenant, so the database must have none yet
+func ValidateTarget(ctx context.Context, subdomain string, dryRun bool) ([]string, error) {
+	if env.IsSingleHostMode() {
+		if dryRun {
+			return []string{}, nil
+		}
+
+		err := bus.Dispatch(ctx, &query.GetFirstTenant{})
+		if err == nil {
+			return []string{"This installation already has a site. Restore into an empty database or use HOST_MODE=multi."}, nil
+		} else if errors.Cause(err) != app.ErrNotFound {
+			return nil, err
+		}
+		return []string{}, nil
+	}
+
+	if subdomain == "" {
+		return []string{"Subdomain is required."}, nil
+	}
+	return validate.Subdomain(ctx, subdomain)
+}
+
+func (r *restorer) run(opts RestoreOptions) error {
+	tenant, err := r.restoreTenant(opts.Subdomain, opts.DryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range restoreOrder {
+		if err := r.restoreTable(table); err != nil {
+			return err
+		}
+	}
+
+	// Blobs are stored in the context of the new tenant, so they end up in its own namespace
+	tenantCtx := context.WithValue(r.ctx, app.TenantCtxKey, tenant)
+	return r.restoreBlobs(tenantCtx, opts.DryRun)
+}
+
+func (r *restorer) restoreTenant(subdomain string, dryRun bool) (*entity.Tenant, error) {
+	rows, err := readTableRows(r.archive, "tenants")
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 {
+		return nil, restoreFailed("Backup must have exactly one site, but found %d.", len(rows))
+	}
+
+	row := rows[0]
+	if subdomain != "" {
+		row["subdomain"] = subdomain
+	}
+	// Custom domains are bound to the original installation
+	row["cname"] = ""
+	// A restored site always starts active and on the free plan, whatever the archive says about the original one
+	row["status"] = int(enum.TenantActive)
+	row["is_pro"] = false
+	for name := range row {
+		if strings.Contains(name, "billing") || strings.Contains(name, "trial") || strings.Contains(name, "subscription") {
+			delete(row, name)
+		}
+	}
+
+	tenant := &entity.Tenant{
+		Name:      fmt.Sprint(row["name"]),
+		Subdomain: fmt.Sprint(row["subdomain"]),
+	}
+
+	// Single host mode only restores into an empty database, so a dry run on a running site
+	// inserts under a placeholder subdomain to not collide with the existing site
+	if dryRun && env.IsSingleHostMode() {
+		row["subdomain"] = "restore-" + strings.ToLower(rand.String(12))
+	}
+
+	id, err := r.insert("tenants", row)
+	if err != nil {
+		return nil, restoreFailed("Site could not be restored: %s", errors.Cause(err).Error())
+	}
+	r.tenantID = int(id)
+	tenant.ID = r.tenantID
+
+	r.report.TenantID = tenant.ID
+	r.report.TenantName = tenant.Name
+	r.report.Subdomain = tenant.Subdomain
+	r.report.Rows["tenants"] = 1
+	return tenant, nil
+}
+
+func (r *restorer) restoreTable(table restoreTable) error {
+	rows, err := readTableRows(r.archive, table.name)
+	if err == errTableNotFound {
+		r.report.warn("Backup has no data for '%s', it was skipped.", table.name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	type pendingSelfReference struct {
+		id     int64
+		column string
+		oldID  int64
+	}
+	pending := make([]pendingSelfReference, 0)
+
+	for i, row := range rows {
+		if _, ok := row["tenant_id"]; ok {
+			row["tenant_id"] = r.tenantID
+		}
+
+		for column, refTable := range table.references {
+			if err := r.remap(row, column, refTable); err != nil {
+				return restoreFailed("Row %d of '%s' is invalid: %s", i+1, table.name, err.Error())
+			}
+		}
+
+		if table.prepare != nil {
+			if err := table.prepare(r, row); err != nil {
+				return err
+			}
+		}
+
+		selfRefs := make(map[string]int64)
+		for _, column := range table.selfReferences {
+			if oldID, ok := toID(row[column]); ok {
+				selfRefs[column] = oldID
+			}
+			row[column] = nil
+		}
+
+		id, err := r.insert(table.name, row)
+		if err != nil {
+			return restoreFailed("Row %d of '%s' could not be restored: %s", i+1, table.name, errors.Cause(err).Error())
+		}
+
+		for column, oldID := range selfRefs {
+			pending = append(pending, pendingSelfReference{id: id, column: column, oldID: oldID})
+		}
+	}
+
+	for _, ref := range pending {
+		newID, ok := r.ids[table.name][ref.oldID]
+		if !ok {
+			r.report.warn("'%s.%s' references id %d, which is not in the backup. The reference was removed.", table.name, ref.column, ref.oldID)
+			continue
+		}
+		query := fmt.Sprintf("UPDATE %s SET %s = $1 WHERE id = $2 AND tenant_id = $3", pq.QuoteIdentifier(table.name), pq.QuoteIdentifier(ref.column))
+		if _, err := r.trx.Execute(query, newID, ref.id, r.tenantID); err != nil {
+			return errors.Wrap(err, "failed to update '%s.%s'", table.name, ref.column)
+		}
+	}
+
+	r.report.Rows[table.name] = len(rows)
+	return nil
+}
+
+// renameProvider gives a new key to an OAuth provider whose key is already used on this installation,
+// as keys are unique across all sites
+func (r *restorer) renameProvider(row map[string]any) error {
+	key, _ := row["provider"].(string)
+
+	var exists bool
+	if err := r.trx.Scalar(&exists, "SELECT EXISTS(SELECT 1 FROM oauth_providers WHERE provider = $1)", key); err != nil {
+		return errors.Wrap(err, "failed to check OAuth provider '%s'", key)
+	}
+	if !exists {
+		return nil
+	}
+
+	newKey := "_" + strings.ToLower(rand.String(10))
+	r.providers[key] = newKey
+	row["provider"] = newKey
+	r.report.warn("OAuth provider '%s' already exists on this installation and was restored as '%s'. Update its redirect URL on the identity provider.", key, newKey)
+	return nil
+}
+
+// remapProvider points a user provider to the new key of its OAuth provider, when it was renamed
+func (r *restorer) remapProvider(row map[string]any) error {
+	if key, ok := row["provider"].(string); ok && r.providers[key] != "" {
+		row["provider"] = r.providers[key]
+	}
+	return nil
+}
+
+// remapRoleKeys rewrites the custom role keys on the role based rules of a tag group into the keys of the restored roles.
+// A rule can't be dropped, as an empty list of roles makes the group visible to everyone
+func (r *restorer) remapRoleKeys(row map[string]any) error {
+	for _, column := range []string{"visible_to", "assignable_by"} {
+		keys, ok := toStringArray(row[column])
+		if !ok {
+			return restoreFailed("Tag group '%v' has an invalid '%s': %v", row["slug"], column, row[column])
+		}
+
+		for i, key := range keys {
+			idText, isCustomRole := strings.CutPrefix(key, "role:")
+			if !isCustomRole {
+				continue
+			}
+
+			oldID, err := strconv.ParseInt(idText, 10, 64)
+			newID, found := r.ids["roles"][oldID]
+			if err != nil || !found {
+				return restoreFailed("Tag group '%v' references role '%s', which is not in the backup", row["slug"], key)
+			}
+			keys[i] = entity.CustomRoleKey(int(newID))
+		}
+
+		row[column] = keys
+	}
+	return nil
+}
+
+// remapMentionsOf rewrites the user ids of the mentions written on given column into the ids of the restored users.
+// Mentions of users that are not in the backup keep only their name
+func remapMentionsOf(column string) func(r *restorer, row map[string]any) error {
+	return func(r *restorer, row map[string]any) error {
+		content, ok := row[column].(string)
+		if !ok {
+			return nil
+		}
+
+		row[column] = entity.CommentString(content).ReplaceMentions(func(m entity.Mention) string {
+			if newID, found := r.ids["users"][int64(m.UserID)]; m.UserID > 0 && found {
+				return entity.FormatMention(int(newID), m.Name)
+			}
+			return "@[" + m.Name + "]"
+		})
+		return nil
+	}
+}
+
+// remap rewrites the value of a column holding an id of refTable into the id of the restored row
+func (r *restorer) remap(row map[string]any, column, refTable string) error {
+	value, ok := row[column]
+	if !ok || value == nil {
+		return nil
+	}
+
+	oldID, ok := toID(value)
+	if !ok {
+		return fmt.Errorf("'%s' has an invalid id: %v", column, value)
+	}
+
+	newID, ok := r.ids[refTable][oldID]
+	if !ok {
+		return fmt.Errorf("'%s' references %s with id %d, which is not in the backup", column, refTable, oldID)
+	}
+
+	row[column] = newID
+	return nil
+}
+
+// insert adds the row into the table and records its new id, if the table has one.
+// Columns that no longer exist on the current schema are skipped
+func (r *restorer) insert(tableName string, row map[string]any) (int64, error) {
+	columns, err := r.tableColumns(tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	oldID, hasID := toID(row["id"])
+	delete(row, "id")
+
+	names := make([]string, 0, len(row))
+	for name := range row {
+		if columns[name] {
+			names = append(names, name)
+		} else if !r.skipped[tableName+"."+name] {
+			r.skipped[tableName+"."+name] = true
+			r.report.warn("Column '%s.%s' no longer exists and was skipped.", tableName, name)
+		}
+	}
+	sort.Strings(names)
+
+	quoted := make([]string, len(names))
+	placeholders := make([]string, len(names))
+	values := make([]any, len(names))
+	for i, name := range names {
+		quoted[i] = pq.QuoteIdentifier(name)
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		values[i] = toSQLValue(row[name])
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		pq.QuoteIdentifier(tableName), strings.Join(quoted, ", "), strings.Join(placeholders, ", "),
+	)
+
+	if !hasID {
+		_, err := r.trx.Execute(query, values...)
+		return 0, err
+	}
+
+	var newID int64
+	if err := r.trx.Scalar(&newID, query+" RETURNING id", values...); err != nil {
+		return 0, err
+	}
+
+	if r.ids[tableName] == nil {
+		r.ids[tableName] = make(map[int64]int64)
+	}
+	r.ids[tableName][oldID] = newID
+	return newID, nil
+}
+
+// tableColumns returns the writable columns of the table on the current schema
+func (r *restorer) tableColumns(tableName string) (map[string]bool, error) {
+	if columns, ok := r.columns[tableName]; ok {
+		return columns, nil
+	}
+
+	rows, err := r.trx.Query(`
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+	`, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get columns of '%s'", tableName)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "failed to scan columns of '%s'", tableName)
+		}
+		columns[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read columns of '%s'", tableName)
+	}
+
+	r.columns[tableName] = columns
+	return columns, nil
+}
+
+func (r *restorer) restoreBlobs(ctx context.Context, dryRun bool) error {
+	for _, file := range r.archive.File {
+		key, isBlob := strings.CutPrefix(file.Name, "blobs/")
+		if !isBlob || key == "" || file.FileInfo().IsDir() {
+			continue
+		}
+
+		r.report.Blobs++
+		if dryRun {
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := bus.Dispatch(ctx, &cmd.StoreBlob{
+			Key:         key,
+			Content:     content,
+			ContentType: http.DetectContentType(content),
+		}); err != nil {
+			return errors.Wrap(err, "failed to store blob '%s'", key)
+		}
+	}
+	return nil
+}
+
+var errTableNotFound = errors.New("table not found in backup")
+
+func readTableRows(archive *zip.Reader, tableName string) ([]map[string]any, error) {
+	file, err := archive.Open(tableName + ".json")
+	if err != nil {
+		return nil, errTableNotFound
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+
+	rows := make([]map[string]any, 0)
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, restoreFailed("Backup has an invalid %s.json: %s", tableName, err.Error())
+	}
+	return rows, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open '%s'", file.Name)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read '%s'", file.Name)
+	}
+	return content, nil
+}
+
+// toID converts an id read from the archive into a number
+func toID(value any) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		id, err := v.Int64()
+		return id, err == nil
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// toStringArray converts an array column read from the archive, which is exported on the Postgres array format
+func toStringArray(value any) (pq.StringArray, bool) {
+	switch v := value.(type) {
+	case nil:
+		return pq.StringArray{}, true
+	case string:
+		var array pq.StringArray
+		if err := array.Scan(v); err != nil {
+			return nil, false
+		}
+		return array, true
+	case []any:
+		array := make(pq.StringArray, len(v))
+		for i, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			array[i] = text
+		}
+		return array, true
+	}
+	return nil, false
+}
+
+// toSQLValue converts a value read from the archive into a value accepted by the database driver
+func toSQLValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]any, []any:
+		content, _ := json.Marshal(v)
+		return string(content)
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/lib/pq"
+)
+
+func TestRestorer_RemapRoleKeys(t *testing.T) {
+	RegisterT(t)
+
+	r := &restorer{ids: map[string]map[int64]int64{"roles": {5: 42}}}
+
+	// rows are exported with array columns on the Postgres array format
+	row := map[string]any{"slug": "internal", "visible_to": "{collaborator,role:5}", "assignable_by": "{role:5}"}
+	err := r.remapRoleKeys(row)
+	Expect(err).IsNil()
+	Expect(row["visible_to"]).Equals(pq.StringArray{"collaborator", "role:42"})
+	Expect(row["assignable_by"]).Equals(pq.StringArray{"role:42"})
+
+	value, _ := row["visible_to"].(pq.StringArray).Value()
+	Expect(value).Equals(`{"collaborator","role:42"}`)
+
+	row = map[string]any{"slug": "public", "visible_to": "{}", "assignable_by": []any{"administrator"}}
+	err = r.remapRoleKeys(row)
+	Expect(err).IsNil()
+	Expect(row["visible_to"]).Equals(pq.StringArray{})
+	Expect(row["assignable_by"]).Equals(pq.StringArray{"administrator"})
+
+	// restricting a group to a role that's not in the backup would make it visible to everyone instead
+	row = map[string]any{"slug": "secret", "visible_to": "{role:7}", "assignable_by": "{}"}
+	err = r.remapRoleKeys(row)
+	Expect(err).IsNotNil()
+	Expect(err.Error()).Equals("Tag group 'secret' references role 'role:7', which is not in the backup")
+}
+
+func TestRestorer_RemapMentions(t *testing.T) {
+	RegisterT(t)
+
+	r := &restorer{ids: map[string]map[int64]int64{"users": {1: 10, 2: 20}}}
+
+	row := map[string]any{"content": "Thanks @[Jon Snow]{1} and @[Arya]{2}, cc @[Sansa]{3} @[Bran] @[]"}
+	err := remapMentionsOf("content")(r, row)
+	Expect(err).IsNil()
+	Expect(row["content"]).Equals("Thanks @[Jon Snow]{10} and @[Arya]{20}, cc @[Sansa] @[Bran] @[]")
+
+	row = map[string]any{"description": nil}
+	err = remapMentionsOf("description")(r, row)
+	Expect(err).IsNil()
+	Expect(row["description"]).IsNil()
+}
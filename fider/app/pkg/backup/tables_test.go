@@ -0,0 +1,38 @@
+package backup
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestTables_AreRestored(t *testing.T) {
+	RegisterT(t)
+
+	restored := map[string]bool{"tenants": true}
+	for _, table := range restoreOrder {
+		restored[table.name] = true
+	}
+
+	for _, table := range tables {
+		if table == "email_verifications" {
+			Expect(restored[table]).IsFalse()
+			continue
+		}
+		Expect(restored[table]).IsTrue()
+	}
+	Expect(restored["files"]).IsTrue()
+	Expect(len(restored)).Equals(len(tables) - 1)
+}
+
+func TestRestoreOrder_ReferencesComeFirst(t *testing.T) {
+	RegisterT(t)
+
+	inserted := map[string]bool{"tenants": true}
+	for _, table := range restoreOrder {
+		for _, refTable := range table.references {
+			Expect(inserted[refTable]).IsTrue()
+		}
+		inserted[table.name] = true
+	}
+}
@@ -0,0 +1,156 @@
+package blobgc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/blob"
+)
+
+// VariantsPrefix is where resized and converted images are cached, as variants/<bkey>/<size>
+const VariantsPrefix = "variants/"
+
+// ManagedPrefixes are the blob folders whose content is referenced by the database.
+// Any other blob, such as backups/ and etc/, is never considered orphaned
+var ManagedPrefixes = []string{"attachments/", "avatars/", "files/", "logos/", VariantsPrefix}
+
+// Options of a collection
+type Options struct {
+	// Delete removes the orphaned blobs that have been orphaned for at least GracePeriod.
+	// When false, nothing is changed
+	Delete      bool
+	GracePeriod time.Duration
+}
+
+// Report of a collection on a tenant
+type Report struct {
+	Referenced int
+	Stored     int
+	Missing    []string
+	Orphaned   []string
+	Deleted    []string
+}
+
+// Collect cross-references the blobs of current tenant against the database,
+// reporting referenced blobs that are missing and stored blobs that are no longer referenced
+func Collect(ctx context.Context, opts Options) (*Report, error) {
+	referenced := &query.ListReferencedBlobs{}
+	if err := bus.Dispatch(ctx, referenced); err != nil {
+		return nil, err
+	}
+
+	isReferenced := make(map[string]bool, len(referenced.Result))
+	for _, key := range referenced.Result {
+		isReferenced[key] = true
+	}
+
+	stored, err := listStoredBlobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Referenced: len(referenced.Result),
+		Stored:     len(stored),
+		Missing:    make([]string, 0),
+		Orphaned:   make([]string, 0),
+		Deleted:    make([]string, 0),
+	}
+
+	for _, key := range referenced.Result {
+		if !stored[key] {
+			exists, err := blobExists(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				report.Missing = append(report.Missing, key)
+			}
+		}
+	}
+
+	for key := range stored {
+		if !isReferenced[sourceKey(key)] {
+			report.Orphaned = append(report.Orphaned, key)
+		}
+	}
+	sort.Strings(report.Orphaned)
+
+	if !opts.Delete {
+		return report, nil
+	}
+
+	track := &cmd.TrackOrphanedBlobs{Keys: report.Orphaned}
+	if err := bus.Dispatch(ctx, track); err != nil {
+		return nil, err
+	}
+
+	for _, key := range report.Orphaned {
+		if time.Since(track.Result[key]) < opts.GracePeriod {
+			continue
+		}
+		if err := bus.Dispatch(ctx, &cmd.DeleteBlob{Key: key}); err != nil {
+			return report, errors.Wrap(err, "failed to delete orphaned blob '%s'", key)
+		}
+		report.Deleted = append(report.Deleted, key)
+	}
+
+	return report, nil
+}
+
+func listStoredBlobs(ctx context.Context) (map[string]bool, error) {
+	stored := make(map[string]bool)
+	for _, prefix := range ManagedPrefixes {
+		q := &query.ListBlobs{Prefix: prefix}
+		if err := bus.Dispatch(ctx, q); err != nil {
+			return nil, errors.Wrap(err, "failed to list blobs of '%s'", prefix)
+		}
+		for _, key := range q.Result {
+			stored[key] = true
+		}
+	}
+	return stored, nil
+}
+
+// blobExists is used for referenced blobs that are not under one of the managed prefixes
+func blobExists(ctx context.Context, key string) (bool, error) {
+	if isManaged(key) {
+		return false, nil
+	}
+
+	err := bus.Dispatch(ctx, &query.GetBlobByKey{Key: key})
+	if errors.Cause(err) == blob.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get blob '%s'", key)
+	}
+	return true, nil
+}
+
+func isManaged(key string) bool {
+	for _, prefix := range ManagedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceKey returns the key of the image a variant was created from, variants are referenced through their source
+func sourceKey(key string) string {
+	if !strings.HasPrefix(key, VariantsPrefix) {
+		return key
+	}
+	key = strings.TrimPrefix(key, VariantsPrefix)
+	if idx := strings.LastIndex(key, "/"); idx > 0 {
+		return key[:idx]
+	}
+	return key
+}
@@ -0,0 +1,107 @@
+package blobgc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/blobgc"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/services/blob"
+)
+
+var storedBlobs = []string{
+	"attachments/post.png",
+	"attachments/removed.png",
+	"avatars/jon.png",
+	"backups/backup-20251201T030000Z.zip",
+	"files/app.log",
+	"variants/attachments/post.png/200",
+	"variants/attachments/removed.png/200.webp",
+}
+
+func setupBlobs(referenced []string, firstSeen map[string]time.Time) *[]string {
+	deleted := make([]string, 0)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListReferencedBlobs) error {
+		q.Result = referenced
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListBlobs) error {
+		q.Result = make([]string, 0)
+		for _, key := range storedBlobs {
+			if strings.HasPrefix(key, q.Prefix) {
+				q.Result = append(q.Result, key)
+			}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		if q.Key == "legacy.png" {
+			q.Result = &dto.Blob{}
+			return nil
+		}
+		return blob.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TrackOrphanedBlobs) error {
+		c.Result = make(map[string]time.Time)
+		for _, key := range c.Keys {
+			if seen, ok := firstSeen[key]; ok {
+				c.Result[key] = seen
+			} else {
+				c.Result[key] = time.Now()
+			}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteBlob) error {
+		deleted = append(deleted, c.Key)
+		return nil
+	})
+
+	return &deleted
+}
+
+func TestCollect_ReportsMissingAndOrphanedBlobs(t *testing.T) {
+	RegisterT(t)
+
+	deleted := setupBlobs([]string{"attachments/post.png", "avatars/jon.png", "files/app.log", "files/lost.pdf", "legacy.png", "logos/gone.png"}, nil)
+
+	report, err := blobgc.Collect(context.Background(), blobgc.Options{})
+	Expect(err).IsNil()
+	Expect(report.Referenced).Equals(6)
+	Expect(report.Stored).Equals(6)
+	Expect(report.Missing).Equals([]string{"files/lost.pdf", "logos/gone.png"})
+	Expect(report.Orphaned).Equals([]string{"attachments/removed.png", "variants/attachments/removed.png/200.webp"})
+	Expect(report.Deleted).HasLen(0)
+	Expect(*deleted).HasLen(0)
+}
+
+func TestCollect_DeletesOrphansAfterGracePeriod(t *testing.T) {
+	RegisterT(t)
+
+	deleted := setupBlobs([]string{"avatars/jon.png", "files/app.log"}, map[string]time.Time{
+		"attachments/post.png":              time.Now().Add(-72 * time.Hour),
+		"variants/attachments/post.png/200": time.Now().Add(-12 * time.Hour),
+	})
+
+	report, err := blobgc.Collect(context.Background(), blobgc.Options{Delete: true, GracePeriod: 24 * time.Hour})
+	Expect(err).IsNil()
+	Expect(report.Orphaned).Equals([]string{
+		"attachments/post.png",
+		"attachments/removed.png",
+		"variants/attachments/post.png/200",
+		"variants/attachments/removed.png/200.webp",
+	})
+	Expect(report.Deleted).Equals([]string{"attachments/post.png"})
+	Expect(*deleted).Equals([]string{"attachments/post.png"})
+}
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 type HandlerFunc any
@@ -24,8 +30,17 @@ type Service interface {
 var handlers = make(map[string]HandlerFunc)
 var listeners = make(map[string][]HandlerFunc)
 var services = make([]Service, 0)
+var initializedServices = make([]Service, 0)
 var busLock = &sync.RWMutex{}
 
+// Log messages are not traced, as every log entry would otherwise become a span
+var untracedMessages = map[string]bool{
+	getKey(&cmd.LogDebug{}): true,
+	getKey(&cmd.LogInfo{}):  true,
+	getKey(&cmd.LogWarn{}):  true,
+	getKey(&cmd.LogError{}): true,
+}
+
 // We only keep counters during unit tests to avoid unnecessary overhead
 var shouldCount = env.IsTest()
 var handlersCallCounter = make(map[string]int)
@@ -43,6 +58,7 @@ func Reset() {
 	defer busLock.Unlock()
 
 	services = make([]Service, 0)
+	initializedServices = make([]Service, 0)
 	handlers = make(map[string]HandlerFunc)
 	listeners = make(map[string][]HandlerFunc)
 
@@ -53,19 +69,31 @@ func Reset() {
 // Services that set via Init(...services) are always registered (regardless of Enabled() function)
 /// and have preference over services registered from bus.Register
 func Init(forcedServices ...Service) []Service {
-	initializedServices := make([]Service, 0)
+	initialized := make([]Service, 0)
 	for _, svc := range forcedServices {
-		initializedServices = append(initializedServices, svc)
+		initialized = append(initialized, svc)
 		svc.Init()
 	}
 
 	for _, svc := range services {
 		if svc.Enabled() {
-			initializedServices = append(initializedServices, svc)
+			initialized = append(initialized, svc)
 			svc.Init()
 		}
 	}
-	return initializedServices
+
+	busLock.Lock()
+	initializedServices = initialized
+	busLock.Unlock()
+	return initialized
+}
+
+// InitializedServices returns the services initialized by the last call to Init
+func InitializedServices() []Service {
+	busLock.RLock()
+	defer busLock.RUnlock()
+
+	return append([]Service{}, initializedServices...)
 }
 
 func AddHandler(handler HandlerFunc) {
@@ -124,10 +152,18 @@ func Dispatch(ctx context.Context, msgs ...Msg) error {
 			counterLock.Unlock()
 		}
 
+		msgCtx, span := startSpan(ctx, "bus.Dispatch", key, msg)
+		params[0] = reflect.ValueOf(msgCtx)
+
+		start := time.Now()
 		ret := reflect.ValueOf(handler).Call(params)
 		if err := ret[0].Interface(); err != nil {
+			metrics.BusDispatchDuration.WithLabelValues(handlerName(msg), "failure").Observe(time.Since(start).Seconds())
+			tracing.End(span, err.(error))
 			return err.(error)
 		}
+		metrics.BusDispatchDuration.WithLabelValues(handlerName(msg), "success").Observe(time.Since(start).Seconds())
+		span.End()
 	}
 
 	return nil
@@ -151,18 +187,40 @@ func Publish(ctx context.Context, msgs ...Msg) {
 		}
 
 		for _, msgListener := range msgListeners {
+			msgCtx, span := startSpan(ctx, "bus.Publish", key, msg)
+			params[0] = reflect.ValueOf(msgCtx)
+
+			var err error
 			ret := reflect.ValueOf(msgListener).Call(params)
 			if len(ret) > 0 {
-				if err, isErr := ret[0].Interface().(error); isErr {
+				if listenerErr, isErr := ret[0].Interface().(error); isErr {
+					err = listenerErr
 					Publish(ctx, &cmd.LogError{
 						Err: errors.Wrap(err, "failed to execute msg '%s'", key),
 					})
 				}
 			}
+			tracing.End(span, err)
 		}
 	}
 }
 
+// startSpan creates a span for the handling of msg, named after its type, such as "bus.Dispatch cmd.SendMail".
+// Spans are only created inside of an existing trace and never for log messages
+func startSpan(ctx context.Context, operation, key string, msg Msg) (context.Context, trace.Span) {
+	if untracedMessages[key] {
+		return ctx, noop.Span{}
+	}
+	return tracing.StartChild(ctx, operation+" "+handlerName(msg), trace.WithAttributes(
+		attribute.String("fider.bus.message", key),
+	))
+}
+
+// handlerName returns the short name of the type of msg, such as cmd.SendMail
+func handlerName(msg Msg) string {
+	return reflect.TypeOf(msg).Elem().String()
+}
+
 // GetCallCount returns	the number of times a handler has been called
 // Only available during unit tests
 func GetCallCount(msg Msg) int {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/prometheus/client_golang/prometheus"
+	clientmodel "github.com/prometheus/client_model/go"
 
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/errors"
@@ -52,6 +55,21 @@ func TestBus_OverwriteService(t *testing.T) {
 	Expect(cmd.Result).Equals("Hello Fider")
 }
 
+func TestBus_InitializedServices(t *testing.T) {
+	RegisterT(t)
+
+	bus.Register(GreeterService{})
+	bus.Init(BetterGreeterService{})
+
+	services := bus.InitializedServices()
+	Expect(services).HasLen(2)
+	Expect(services[0].Name()).Equals("BetterGreeter")
+	Expect(services[1].Name()).Equals("Greeter")
+
+	bus.Reset()
+	Expect(bus.InitializedServices()).HasLen(0)
+}
+
 func TestBus_MultipleMessages(t *testing.T) {
 	RegisterT(t)
 
@@ -117,3 +135,31 @@ func TestBus_PublishError(t *testing.T) {
 	bus.Publish(context.Background(), &SayHelloCommand{Name: "123"})
 	Expect(errors.Cause(err)).Equals(boom)
 }
+
+// sampleCount returns the number of observations of a histogram with given labels
+func sampleCount(histogram *prometheus.HistogramVec, labels ...string) uint64 {
+	metric := &clientmodel.Metric{}
+	_ = histogram.WithLabelValues(labels...).(prometheus.Histogram).Write(metric)
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestBus_DispatchMetrics(t *testing.T) {
+	RegisterT(t)
+
+	successes := sampleCount(metrics.BusDispatchDuration, "bus_test.SayHelloCommand", "success")
+	failures := sampleCount(metrics.BusDispatchDuration, "bus_test.SayHelloCommand", "failure")
+
+	bus.AddHandler(func(ctx context.Context, c *SayHelloCommand) error {
+		if c.Name == "" {
+			return errors.New("Name is required")
+		}
+		return nil
+	})
+
+	Expect(bus.Dispatch(context.Background(), &SayHelloCommand{Name: "Fider"})).IsNil()
+	Expect(bus.Dispatch(context.Background(), &SayHelloCommand{Name: "Fider"})).IsNil()
+	Expect(bus.Dispatch(context.Background(), &SayHelloCommand{})).IsNotNil()
+
+	Expect(sampleCount(metrics.BusDispatchDuration, "bus_test.SayHelloCommand", "success")).Equals(successes + 2)
+	Expect(sampleCount(metrics.BusDispatchDuration, "bus_test.SayHelloCommand", "failure")).Equals(failures + 1)
+}
@@ -3,6 +3,7 @@ package csv
 import (
 	"bytes"
 	gocsv "encoding/csv"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -83,3 +84,81 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 
 	return buffer.Bytes(), nil
 }
+
+//FromAuditLogs return a byte array of CSV file containing given audit log entries
+func FromAuditLogs(auditLogs []*entity.AuditLog) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gocsv.NewWriter(buffer)
+
+	header := []string{
+		"id",
+		"created_at",
+		"actor_id",
+		"actor_name",
+		"action",
+		"target_type",
+		"target_id",
+		"target_name",
+		"before",
+		"after",
+		"client_ip",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, auditLog := range auditLogs {
+		var (
+			actorID  string
+			targetID string
+			before   string
+			after    string
+		)
+
+		if auditLog.ActorID > 0 {
+			actorID = strconv.Itoa(auditLog.ActorID)
+		}
+		if auditLog.TargetID > 0 {
+			targetID = strconv.Itoa(auditLog.TargetID)
+		}
+		if len(auditLog.Before) > 0 {
+			data, err := json.Marshal(auditLog.Before)
+			if err != nil {
+				return nil, err
+			}
+			before = string(data)
+		}
+		if len(auditLog.After) > 0 {
+			data, err := json.Marshal(auditLog.After)
+			if err != nil {
+				return nil, err
+			}
+			after = string(data)
+		}
+
+		record := []string{
+			strconv.Itoa(auditLog.ID),
+			auditLog.CreatedAt.Format(time.RFC3339),
+			actorID,
+			auditLog.ActorName,
+			string(auditLog.Action),
+			auditLog.TargetType,
+			targetID,
+			auditLog.TargetName,
+			before,
+			after,
+			auditLog.ClientIP,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
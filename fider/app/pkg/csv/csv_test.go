@@ -52,6 +52,39 @@ func TestExportPostsToCSV_MorePosts(t *testing.T) {
 	Expect(actual).Equals(expected)
 }
 
+func TestExportAuditLogsToCSV(t *testing.T) {
+	RegisterT(t)
+
+	auditLogs := []*entity.AuditLog{
+		{
+			ID:         2,
+			ActorID:    1,
+			ActorName:  "Jon Snow",
+			Action:     enum.AuditUserRoleChanged,
+			TargetType: "user",
+			TargetID:   3,
+			TargetName: "Arya Stark",
+			Before:     map[string]any{"role": "visitor"},
+			After:      map[string]any{"role": "collaborator"},
+			ClientIP:   "10.0.0.1",
+			CreatedAt:  time.Date(2025, 12, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:         1,
+			ActorName:  "System",
+			Action:     enum.AuditBackupExported,
+			TargetType: "tenant",
+			CreatedAt:  time.Date(2025, 12, 14, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	expected, err := os.ReadFile("./testdata/audit-logs.csv")
+	Expect(err).IsNil()
+	actual, err := csv.FromAuditLogs(auditLogs)
+	Expect(err).IsNil()
+	Expect(actual).Equals(expected)
+}
+
 var declinedPost = &entity.Post{
 	Number:      10,
 	Title:       "Go is fast",
@@ -14,8 +14,13 @@ import (
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 var conn *sql.DB
@@ -31,6 +36,8 @@ func init() {
 	conn.SetMaxIdleConns(env.Config.Database.MaxIdleConns)
 	conn.SetMaxOpenConns(env.Config.Database.MaxOpenConns)
 	rowMapper = NewRowMapper()
+
+	prometheus.MustRegister(collectors.NewDBStatsCollector(conn, "fider"))
 }
 
 func Connection() *sql.DB {
@@ -77,6 +84,12 @@ type Trx struct {
 	ctx context.Context
 }
 
+// WithContext returns a copy of the transaction that runs its commands with ctx,
+// so that they are traced as part of the operation that ctx belongs to
+func (trx *Trx) WithContext(ctx context.Context) *Trx {
+	return &Trx{tx: trx.tx, ctx: ctx}
+}
+
 var formatter = strings.NewReplacer("\t", "", "\n", " ")
 
 // Execute given SQL command
@@ -93,7 +106,9 @@ func (trx *Trx) Execute(command string, args ...any) (int64, error) {
 		}()
 	}
 
-	result, err := trx.tx.ExecContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Execute", command)
+	result, err := trx.tx.ExecContext(ctx, command, args...)
+	tracing.End(span, err)
 	if err != nil {
 		return 0, wrap(err, "failed to execute trx.Execute")
 	}
@@ -116,8 +131,10 @@ func (trx *Trx) Scalar(data any, command string, args ...any) error {
 		}()
 	}
 
-	row := trx.tx.QueryRowContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Scalar", command)
+	row := trx.tx.QueryRowContext(ctx, command, args...)
 	err := row.Scan(data)
+	trx.endSpan(span, err)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return app.ErrNotFound
@@ -128,7 +145,7 @@ func (trx *Trx) Scalar(data any, command string, args ...any) error {
 }
 
 // Get first row and bind to given data
-func (trx *Trx) Get(data any, command string, args ...any) error {
+func (trx *Trx) Get(data any, command string, args ...any) (err error) {
 	if log.IsEnabled(log.DEBUG) {
 		command = formatter.Replace(command)
 		start := time.Now()
@@ -141,7 +158,10 @@ func (trx *Trx) Get(data any, command string, args ...any) error {
 		}()
 	}
 
-	rows, err := trx.tx.QueryContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Get", command)
+	defer func() { trx.endSpan(span, err) }()
+
+	rows, err := trx.tx.QueryContext(ctx, command, args...)
 	if err != nil {
 		return wrap(err, "failed to execute trx.Get")
 	}
@@ -160,7 +180,7 @@ func (trx *Trx) Get(data any, command string, args ...any) error {
 }
 
 // Exists returns true if at least one record is found
-func (trx *Trx) Exists(command string, args ...any) (bool, error) {
+func (trx *Trx) Exists(command string, args ...any) (exists bool, err error) {
 	if log.IsEnabled(log.DEBUG) {
 		command = formatter.Replace(command)
 		start := time.Now()
@@ -173,7 +193,10 @@ func (trx *Trx) Exists(command string, args ...any) (bool, error) {
 		}()
 	}
 
-	rows, err := trx.tx.QueryContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Exists", command)
+	defer func() { trx.endSpan(span, err) }()
+
+	rows, err := trx.tx.QueryContext(ctx, command, args...)
 	if err != nil {
 		return false, wrap(err, "failed to execute trx.Exists")
 	}
@@ -183,7 +206,7 @@ func (trx *Trx) Exists(command string, args ...any) (bool, error) {
 }
 
 // Count returns number of rows
-func (trx *Trx) Count(command string, args ...any) (int, error) {
+func (trx *Trx) Count(command string, args ...any) (count int, err error) {
 	if log.IsEnabled(log.DEBUG) {
 		command = formatter.Replace(command)
 		start := time.Now()
@@ -196,13 +219,15 @@ func (trx *Trx) Count(command string, args ...any) (int, error) {
 		}()
 	}
 
-	rows, err := trx.tx.QueryContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Count", command)
+	defer func() { trx.endSpan(span, err) }()
+
+	rows, err := trx.tx.QueryContext(ctx, command, args...)
 	if err != nil {
 		return 0, wrap(err, "failed to execute trx.Count")
 	}
 
 	defer func() { _ = rows.Close() }()
-	count := 0
 	for rows.Next() {
 		count++
 	}
@@ -210,7 +235,7 @@ func (trx *Trx) Count(command string, args ...any) (int, error) {
 }
 
 // Select all matched rows bind to given data
-func (trx *Trx) Select(data any, command string, args ...any) error {
+func (trx *Trx) Select(data any, command string, args ...any) (err error) {
 	if log.IsEnabled(log.DEBUG) {
 		command = formatter.Replace(command)
 		start := time.Now()
@@ -223,7 +248,10 @@ func (trx *Trx) Select(data any, command string, args ...any) error {
 		}()
 	}
 
-	rows, err := trx.tx.QueryContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Select", command)
+	defer func() { trx.endSpan(span, err) }()
+
+	rows, err := trx.tx.QueryContext(ctx, command, args...)
 	if err != nil {
 		return wrap(err, "failed to execute trx.Select")
 	}
@@ -250,7 +278,8 @@ func (trx *Trx) Select(data any, command string, args ...any) error {
 	return nil
 }
 
-// Query all matched rows and return raw sql.Rows
+// Query all matched rows and return raw sql.Rows. As reading the rows is left
+// to the caller, its span only covers the execution of the command
 func (trx *Trx) Query(command string, args ...any) (*sql.Rows, error) {
 	if log.IsEnabled(log.DEBUG) {
 		command = formatter.Replace(command)
@@ -264,13 +293,36 @@ func (trx *Trx) Query(command string, args ...any) (*sql.Rows, error) {
 		}()
 	}
 
-	rows, err := trx.tx.QueryContext(trx.ctx, command, args...)
+	ctx, span := trx.startSpan("Query", command)
+	rows, err := trx.tx.QueryContext(ctx, command, args...)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, wrap(err, "failed to execute trx.Select")
 	}
 	return rows, nil
 }
 
+// startSpan creates a span for a SQL command on the trace of the transaction, if any
+func (trx *Trx) startSpan(operation, command string) (context.Context, trace.Span) {
+	ctx, span := tracing.StartChild(trx.ctx, "dbx."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.query.text", formatter.Replace(command)),
+		)
+	}
+	return ctx, span
+}
+
+// endSpan ends the span of a SQL command once its rows have been read. Not finding
+// any row is an expected outcome, so it doesn't mark the span as failed
+func (trx *Trx) endSpan(span trace.Span, err error) {
+	if err == sql.ErrNoRows || err == app.ErrNotFound {
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
 // Commit current transaction
 func (trx *Trx) Commit() error {
 	err := trx.tx.Commit()
@@ -9,6 +9,7 @@ import (
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type user struct {
@@ -278,3 +279,37 @@ func TestByteArray(t *testing.T) {
 	Expect(theFile.Content).Equals(fileContent)
 	Expect(theFile.Size).Equals(len(theFile.Content))
 }
+
+// poolMetric returns the value of given connection pool metric of the database
+func poolMetric(name string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	Expect(err).IsNil()
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "db_name" && label.GetValue() == "fider" {
+					return metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	panic("metric " + name + " not found")
+}
+
+func TestPoolMetrics(t *testing.T) {
+	RegisterT(t)
+
+	Expect(poolMetric("go_sql_max_open_connections")).Equals(float64(env.Config.Database.MaxOpenConns))
+
+	trx, err := dbx.BeginTx(context.Background())
+	Expect(err).IsNil()
+	defer trx.MustRollback()
+
+	var one int
+	err = trx.Scalar(&one, "SELECT 1")
+	Expect(err).IsNil()
+	Expect(poolMetric("go_sql_in_use_connections") >= 1).IsTrue()
+}
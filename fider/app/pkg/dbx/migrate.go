@@ -21,32 +21,10 @@ var ErrNoChanges = stdErrors.New("nothing to migrate")
 // Migrate the database to latest version
 func Migrate(ctx context.Context, path string) error {
 	log.Info(ctx, "Running migrations...")
-	dir, err := os.Open(env.Path(path))
+	versions, versionFiles, err := readMigrations(path)
 	if err != nil {
-		return errors.Wrap(err, "failed to open dir '%s'", path)
-	}
-
-	files, err := dir.Readdir(0)
-	if err != nil {
-		return errors.Wrap(err, "failed to read files from dir '%s'", path)
-	}
-
-	versions := make([]int, len(files))
-	versionFiles := make(map[int]string, len(files))
-	for i, file := range files {
-		fileName := file.Name()
-		parts := strings.Split(fileName, "_")
-		if len(parts[0]) != 12 {
-			return errors.New("migration file must have exactly 12 chars for version: '%s' is invalid.", fileName)
-		}
-
-		versions[i], err = strconv.Atoi(parts[0])
-		versionFiles[versions[i]] = fileName
-		if err != nil {
-			return errors.Wrap(err, "failed to convert '%s' to number", parts[0])
-		}
+		return err
 	}
-	sort.Ints(versions)
 
 	log.Infof(ctx, "Found total of @{Total} migration files.", dto.Props{
 		"Total": len(versions),
@@ -89,6 +67,55 @@ func Migrate(ctx context.Context, path string) error {
 	return nil
 }
 
+// MigrationStatus returns the version of the last migration applied to the database and the version of the last migration file on path
+func MigrationStatus(path string) (current int, expected int, err error) {
+	versions, _, err := readMigrations(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(versions) > 0 {
+		expected = versions[len(versions)-1]
+	}
+
+	var lastVersion sql.NullInt64
+	if err := conn.QueryRow("SELECT MAX(version) FROM migrations_history").Scan(&lastVersion); err != nil {
+		return 0, expected, wrap(err, "failed to get last migration record")
+	}
+	return int(lastVersion.Int64), expected, nil
+}
+
+// readMigrations returns the sorted versions of the migration files on path and the file name of each version
+func readMigrations(path string) ([]int, map[int]string, error) {
+	dir, err := os.Open(env.Path(path))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open dir '%s'", path)
+	}
+	defer func() { _ = dir.Close() }()
+
+	files, err := dir.Readdir(0)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read files from dir '%s'", path)
+	}
+
+	versions := make([]int, len(files))
+	versionFiles := make(map[int]string, len(files))
+	for i, file := range files {
+		fileName := file.Name()
+		parts := strings.Split(fileName, "_")
+		if len(parts[0]) != 12 {
+			return nil, nil, errors.New("migration file must have exactly 12 chars for version: '%s' is invalid.", fileName)
+		}
+
+		versions[i], err = strconv.Atoi(parts[0])
+		versionFiles[versions[i]] = fileName
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to convert '%s' to number", parts[0])
+		}
+	}
+	sort.Ints(versions)
+	return versions, versionFiles, nil
+}
+
 func runMigration(ctx context.Context, version int, path, fileName string) error {
 	filePath := env.Path(path + "/" + fileName)
 	content, err := os.ReadFile(filePath)
@@ -52,3 +52,19 @@ func TestMigrate_Failure(t *testing.T) {
 	_, err = trx.Execute("SELECT description FROM dummy")
 	Expect(err).IsNotNil()
 }
+
+func TestMigrationStatus(t *testing.T) {
+	setupMigrationTest(t)
+
+	current, expected, err := dbx.MigrationStatus("/app/pkg/dbx/testdata/migration_success")
+	Expect(err).IsNil()
+	Expect(expected).Equals(210001010001)
+	Expect(current < expected).IsTrue()
+
+	err = dbx.Migrate(context.Background(), "/app/pkg/dbx/testdata/migration_success")
+	Expect(err).IsNil()
+
+	current, expected, err = dbx.MigrationStatus("/app/pkg/dbx/testdata/migration_success")
+	Expect(err).IsNil()
+	Expect(current).Equals(expected)
+}
@@ -2,6 +2,7 @@ package env
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -46,6 +47,8 @@ type config struct {
 		ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT,default=5s,strict"`
 		WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s,strict"`
 		IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT,default=120s,strict"`
+		// TrustedProxies is a comma separated list of IPs or CIDRs whose X-Forwarded-For and X-Real-IP headers are honored
+		TrustedProxies string `env:"HTTP_TRUSTED_PROXIES"`
 	}
 	Port                        string `env:"PORT,default=3000"`
 	Host                        string `env:"HOST,default="`
@@ -66,6 +69,14 @@ type config struct {
 		Port    string `env:"METRICS_PORT,default=4000"`
 		Host    string `env:"METRICS_HOST,default="`
 	}
+	Tracing struct {
+		Enabled        bool    `env:"TRACING_ENABLED,default=false"`
+		ServiceName    string  `env:"OTEL_SERVICE_NAME,default=fider"`
+		Endpoint       string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT,default=http://localhost:4318"`
+		TracesEndpoint string  `env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"`
+		Headers        string  `env:"OTEL_EXPORTER_OTLP_HEADERS"`
+		SampleRatio    float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+	}
 	Database struct {
 		URL          string `env:"DATABASE_URL,required"`
 		MaxIdleConns int    `env:"DATABASE_MAX_IDLE_CONNS,default=2,strict"`
@@ -85,6 +96,18 @@ type config struct {
 		Sql        bool   `env:"LOG_SQL,default=true"`
 		File       bool   `env:"LOG_FILE,default=false"`
 		OutputFile string `env:"LOG_FILE_OUTPUT,default=logs/output.log"`
+		// DebugSampleRate is the fraction of requests and tasks whose debug logs are written, from 0 to 1
+		DebugSampleRate float64 `env:"LOG_DEBUG_SAMPLE_RATE,default=1"`
+		Redact          bool    `env:"LOG_REDACT,default=true"`
+		GELF            struct {
+			Address string `env:"LOG_GELF_ADDRESS"` // such as udp://graylog:12201 or tcp://graylog:12201
+		}
+		HTTP struct {
+			URL           string        `env:"LOG_HTTP_URL"`
+			Headers       string        `env:"LOG_HTTP_HEADERS"`
+			BatchSize     int           `env:"LOG_HTTP_BATCH_SIZE,default=100,strict"`
+			FlushInterval time.Duration `env:"LOG_HTTP_FLUSH_INTERVAL,default=5s,strict"`
+		}
 	}
 	OAuth struct {
 		Google struct {
@@ -124,8 +147,9 @@ type config struct {
 		}
 	}
 	BlobStorage struct {
-		Type string `env:"BLOB_STORAGE,default=sql"` // possible values: sql, fs or s3
-		S3   struct {
+		Type     string `env:"BLOB_STORAGE,default=sql"` // possible values: sql, fs or s3
+		Fallback string `env:"BLOB_STORAGE_FALLBACK"`    // previous storage, read from when a blob is not found on BLOB_STORAGE while migrating
+		S3       struct {
 			EndpointURL     string `env:"BLOB_STORAGE_S3_ENDPOINT_URL"`
 			Region          string `env:"BLOB_STORAGE_S3_REGION"`
 			AccessKeyID     string `env:"BLOB_STORAGE_S3_ACCESS_KEY_ID"`
@@ -141,9 +165,54 @@ type config struct {
 		Message string `env:"MAINTENANCE_MESSAGE"`
 		Until   string `env:"MAINTENANCE_UNTIL"`
 	}
+	RateLimit struct {
+		Enabled    bool   `env:"RATE_LIMIT_ENABLED,default=false"`
+		Backend    string `env:"RATE_LIMIT_BACKEND,default=memory"` // possible values: memory or sql
+		Auth       string `env:"RATE_LIMIT_AUTH,default=10/1m"`
+		PublicAPI  string `env:"RATE_LIMIT_PUBLIC_API,default=300/1m"`
+		MembersAPI string `env:"RATE_LIMIT_MEMBERS_API,default=60/1m"`
+	}
+	Backup struct {
+		Schedule   string `env:"BACKUP_SCHEDULE"` // cron expression, scheduled backups are disabled when empty
+		Retention  int    `env:"BACKUP_RETENTION,default=7"`
+		Passphrase string `env:"BACKUP_PASSPHRASE"`
+	}
+	BlobGC struct {
+		Schedule    string        `env:"BLOB_GC_SCHEDULE,default=0 0 5 * * *"` // cron expression, blob garbage collection is disabled when empty
+		GracePeriod time.Duration `env:"BLOB_GC_GRACE_PERIOD,default=168h,strict"`
+	}
+	Spam struct {
+		Threshold      int           `env:"SPAM_THRESHOLD,default=10,strict"` // new posts and comments scoring this or more are held for moderation, scoring is disabled when 0
+		VelocityWindow time.Duration `env:"SPAM_VELOCITY_WINDOW,default=10m,strict"`
+		PhrasesFile    string        `env:"SPAM_PHRASES_FILE"` // additional spam phrases, one per line
+	}
+	Reports struct {
+		HideThreshold int `env:"REPORT_HIDE_THRESHOLD,default=3,strict"` // content with this many open reports is hidden until moderated, never hidden when 0
+	}
+	Comments struct {
+		ReplyDepth int `env:"COMMENT_REPLY_DEPTH,default=1,strict"` // levels of replies below a comment, replies are disabled when 0
+	}
 	Webhook struct {
 		DisableOnFailure bool `env:"WEBHOOK_DISABLE_ON_FAILURE,default=true"`
 	}
+	Diagnostics struct {
+		Token string `env:"DIAGNOSTICS_TOKEN"`
+	}
+	Jobs struct {
+		Schedules   string `env:"JOBS_SCHEDULES"` // e.g. BackupJob=0 0 4 * * *;EmailSupressionJob=@every 6h
+		HistorySize int    `env:"JOBS_HISTORY_SIZE,default=20,strict"`
+	}
+	FileScan struct {
+		Type          string        `env:"FILE_SCAN,default=none"` // possible values: none or clamav
+		ClamAVAddress string        `env:"FILE_SCAN_CLAMAV_ADDRESS,default=unix:/var/run/clamav/clamd.ctl"`
+		Timeout       time.Duration `env:"FILE_SCAN_TIMEOUT,default=1m,strict"`
+	}
+	Worker struct {
+		Queue        string        `env:"WORKER_QUEUE,default=postgres"` // postgres or memory
+		PollInterval time.Duration `env:"WORKER_POLL_INTERVAL,default=2s,strict"`
+		MaxAttempts  int           `env:"WORKER_MAX_ATTEMPTS,default=5,strict"`
+		LeaseTimeout time.Duration `env:"WORKER_LEASE_TIMEOUT,default=10m,strict"`
+	}
 	GoogleAnalytics  string `env:"GOOGLE_ANALYTICS"`
 	SearchNoiseWords string `env:"SEARCH_NOISE_WORDS,default=add|support|for|implement|create|make|allow|enable|provide|some|also|include|very|make|and|for|to|a|able|function|feature|app"`
 	Demo             struct {
@@ -211,6 +280,12 @@ func Reload() {
 		mustBeSet("EMAIL_SMTP_PORT")
 	}
 
+	for _, proxy := range TrustedProxies() {
+		if proxy == nil {
+			panic(errors.New("HTTP_TRUSTED_PROXIES must be a comma separated list of IPs or CIDRs, got '%s'", Config.HTTP.TrustedProxies))
+		}
+	}
+
 	switch Config.BlobStorage.Type {
 	case "s3":
 		mustBeSet("BLOB_STORAGE_S3_BUCKET")
@@ -226,6 +301,28 @@ func mustBeSet(name string) {
 	}
 }
 
+// TrustedProxies returns the networks of HTTP_TRUSTED_PROXIES, single IPs are returned as networks of one address.
+// Invalid entries are returned as nil
+func TrustedProxies() []*net.IPNet {
+	networks := make([]*net.IPNet, 0)
+	for _, entry := range strings.Split(Config.HTTP.TrustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, _ := net.ParseCIDR(entry)
+		networks = append(networks, network)
+	}
+	return networks
+}
+
 // IsSingleHostMode returns true if host mode is set to single tenant
 func IsSingleHostMode() bool {
 	return Config.HostMode == "single"
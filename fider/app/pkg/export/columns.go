@@ -0,0 +1,142 @@
+package export
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// column is a value of an exported record. Private columns hold personal data, such as emails
+type column[T any] struct {
+	name    string
+	private bool
+	value   func(record T) any
+}
+
+var postColumns = []column[*entity.ExportedPost]{
+	{name: "number", value: func(p *entity.ExportedPost) any { return p.Number }},
+	{name: "title", value: func(p *entity.ExportedPost) any { return p.Title }},
+	{name: "description", value: func(p *entity.ExportedPost) any { return p.Description }},
+	{name: "status", value: func(p *entity.ExportedPost) any { return p.Status.Name() }},
+	{name: "tags", value: func(p *entity.ExportedPost) any { return p.Tags }},
+	{name: "created_at", value: func(p *entity.ExportedPost) any { return p.CreatedAt }},
+	{name: "created_by", value: func(p *entity.ExportedPost) any { return p.User.Name }},
+	{name: "created_by_email", private: true, value: func(p *entity.ExportedPost) any { return p.User.Email }},
+	{name: "votes_count", value: func(p *entity.ExportedPost) any { return p.VotesCount }},
+	{name: "votes_from_visitors", value: func(p *entity.ExportedPost) any { return p.VotesByRole[enum.RoleVisitor] }},
+	{name: "votes_from_collaborators", value: func(p *entity.ExportedPost) any { return p.VotesByRole[enum.RoleCollaborator] }},
+	{name: "votes_from_administrators", value: func(p *entity.ExportedPost) any { return p.VotesByRole[enum.RoleAdministrator] }},
+	{name: "voter_emails", private: true, value: func(p *entity.ExportedPost) any { return p.VoterEmails }},
+	{name: "comments_count", value: func(p *entity.ExportedPost) any { return p.CommentsCount }},
+	{name: "last_activity_at", value: func(p *entity.ExportedPost) any { return p.LastActivityAt }},
+	{name: "responded_by", value: func(p *entity.ExportedPost) any {
+		if p.Response == nil || p.Response.User == nil {
+			return nil
+		}
+		return p.Response.User.Name
+	}},
+	{name: "responded_at", value: func(p *entity.ExportedPost) any {
+		if p.Response == nil {
+			return nil
+		}
+		return p.Response.RespondedAt
+	}},
+	{name: "response", value: func(p *entity.ExportedPost) any {
+		if p.Response == nil {
+			return nil
+		}
+		return p.Response.Text
+	}},
+	{name: "original_number", value: func(p *entity.ExportedPost) any {
+		if p.Response == nil || p.Response.Original == nil {
+			return nil
+		}
+		return p.Response.Original.Number
+	}},
+	{name: "original_title", value: func(p *entity.ExportedPost) any {
+		if p.Response == nil || p.Response.Original == nil {
+			return nil
+		}
+		return p.Response.Original.Title
+	}},
+}
+
+var commentColumns = []column[*entity.ExportedComment]{
+	{name: "id", value: func(c *entity.ExportedComment) any { return c.ID }},
+	{name: "post_number", value: func(c *entity.ExportedComment) any { return c.PostNumber }},
+	{name: "post_title", value: func(c *entity.ExportedComment) any { return c.PostTitle }},
+	{name: "content", value: func(c *entity.ExportedComment) any { return c.Content }},
+	{name: "created_at", value: func(c *entity.ExportedComment) any { return c.CreatedAt }},
+	{name: "created_by", value: func(c *entity.ExportedComment) any { return c.User.Name }},
+	{name: "created_by_email", private: true, value: func(c *entity.ExportedComment) any { return c.User.Email }},
+	{name: "edited_at", value: func(c *entity.ExportedComment) any { return c.EditedAt }},
+	{name: "is_approved", value: func(c *entity.ExportedComment) any { return c.IsApproved }},
+}
+
+var voteColumns = []column[*entity.ExportedVote]{
+	{name: "post_number", value: func(v *entity.ExportedVote) any { return v.PostNumber }},
+	{name: "post_title", value: func(v *entity.ExportedVote) any { return v.PostTitle }},
+	{name: "created_at", value: func(v *entity.ExportedVote) any { return v.CreatedAt }},
+	{name: "voter", value: func(v *entity.ExportedVote) any { return v.User.Name }},
+	{name: "voter_role", value: func(v *entity.ExportedVote) any { return v.User.Role.String() }},
+	{name: "voter_email", private: true, value: func(v *entity.ExportedVote) any { return v.User.Email }},
+}
+
+var reactionColumns = []column[*entity.ExportedReaction]{
+	{name: "post_number", value: func(r *entity.ExportedReaction) any { return r.PostNumber }},
+	{name: "post_title", value: func(r *entity.ExportedReaction) any { return r.PostTitle }},
+	{name: "comment_id", value: func(r *entity.ExportedReaction) any { return r.CommentID }},
+	{name: "emoji", value: func(r *entity.ExportedReaction) any { return r.Emoji }},
+	{name: "created_at", value: func(r *entity.ExportedReaction) any { return r.CreatedAt }},
+	{name: "reacted_by", value: func(r *entity.ExportedReaction) any { return r.User.Name }},
+	{name: "reacted_by_role", value: func(r *entity.ExportedReaction) any { return r.User.Role.String() }},
+	{name: "reacted_by_email", private: true, value: func(r *entity.ExportedReaction) any { return r.User.Email }},
+}
+
+func names[T any](columns []column[T], private bool) []string {
+	result := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !c.private || private {
+			result = append(result, c.name)
+		}
+	}
+	return result
+}
+
+// pick returns the columns with given names, or all public columns when no name is given
+func pick[T any](columns []column[T], selected []string, private bool) ([]column[T], error) {
+	if len(selected) == 0 {
+		result := make([]column[T], 0, len(columns))
+		for _, c := range columns {
+			if !c.private {
+				result = append(result, c)
+			}
+		}
+		return result, nil
+	}
+
+	result := make([]column[T], 0, len(selected))
+	for _, name := range selected {
+		found := false
+		for _, c := range columns {
+			if c.name == name && (!c.private || private) {
+				result = append(result, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("column '%s' cannot be exported", name)
+		}
+	}
+	return result, nil
+}
+
+func hasPrivate[T any](columns []column[T]) bool {
+	for _, c := range columns {
+		if c.private {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// pageSize is the number of records read from the database at a time, so exports of any size use a constant amount of memory
+const pageSize = 500
+
+// Type is the kind of records of an export
+type Type string
+
+var (
+	// TypePosts exports posts with their statistics
+	TypePosts Type = "posts"
+	// TypeComments exports the comments of posts
+	TypeComments Type = "comments"
+	// TypeVotes exports the votes of posts
+	TypeVotes Type = "votes"
+	// TypeReactions exports the reactions to posts and their comments
+	TypeReactions Type = "reactions"
+)
+
+// IsValid returns true if type is a known type
+func (t Type) IsValid() bool {
+	return t == TypePosts || t == TypeComments || t == TypeVotes || t == TypeReactions
+}
+
+// Format is the file format of an export
+type Format string
+
+var (
+	// FormatCSV writes a header row followed by one row per record
+	FormatCSV Format = "csv"
+	// FormatJSON writes an array of objects
+	FormatJSON Format = "json"
+	// FormatNDJSON writes one object per line
+	FormatNDJSON Format = "ndjson"
+)
+
+// IsValid returns true if format is a known format
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatJSON || f == FormatNDJSON
+}
+
+// ContentType returns the media type of files of this format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Options of an export
+type Options struct {
+	Type   Type
+	Format Format
+	// Columns to export, in order. All public columns of the type are exported when empty
+	Columns []string
+	Filter  query.ExportFilter
+	// Private allows columns with personal data, such as emails
+	Private bool
+}
+
+// FileName returns the name of the exported file, such as posts.csv
+func (o Options) FileName() string {
+	return string(o.Type) + "." + string(o.Format)
+}
+
+// Columns returns the names of the columns of given type, in their default order.
+// Columns with personal data are only returned when private is true
+func Columns(t Type, private bool) []string {
+	switch t {
+	case TypePosts:
+		return names(postColumns, private)
+	case TypeComments:
+		return names(commentColumns, private)
+	case TypeVotes:
+		return names(voteColumns, private)
+	case TypeReactions:
+		return names(reactionColumns, private)
+	}
+	return []string{}
+}
+
+// Write exports the records that match the options into w, reading and writing one page of records at a time
+func Write(ctx context.Context, w io.Writer, opts Options) error {
+	if !opts.Format.IsValid() {
+		return errors.New("unknown export format '%s'", opts.Format)
+	}
+
+	switch opts.Type {
+	case TypePosts:
+		columns, err := pick(postColumns, opts.Columns, opts.Private)
+		if err != nil {
+			return err
+		}
+		return writeRecords(newRecordWriter(w, opts.Format), columns, func(last *entity.ExportedPost) ([]*entity.ExportedPost, error) {
+			q := &query.ExportPosts{Filter: opts.Filter, Limit: pageSize, IncludeEmails: hasPrivate(columns)}
+			if last != nil {
+				q.AfterID = last.ID
+			}
+			err := bus.Dispatch(ctx, q)
+			return q.Result, err
+		})
+	case TypeComments:
+		columns, err := pick(commentColumns, opts.Columns, opts.Private)
+		if err != nil {
+			return err
+		}
+		return writeRecords(newRecordWriter(w, opts.Format), columns, func(last *entity.ExportedComment) ([]*entity.ExportedComment, error) {
+			q := &query.ExportComments{Filter: opts.Filter, Limit: pageSize, IncludeEmails: hasPrivate(columns)}
+			if last != nil {
+				q.AfterID = last.ID
+			}
+			err := bus.Dispatch(ctx, q)
+			return q.Result, err
+		})
+	case TypeVotes:
+		columns, err := pick(voteColumns, opts.Columns, opts.Private)
+		if err != nil {
+			return err
+		}
+		return writeRecords(newRecordWriter(w, opts.Format), columns, func(last *entity.ExportedVote) ([]*entity.ExportedVote, error) {
+			q := &query.ExportVotes{Filter: opts.Filter, Limit: pageSize, IncludeEmails: hasPrivate(columns)}
+			if last != nil {
+				q.AfterPostID, q.AfterUserID = last.PostID, last.User.ID
+			}
+			err := bus.Dispatch(ctx, q)
+			return q.Result, err
+		})
+	case TypeReactions:
+		columns, err := pick(reactionColumns, opts.Columns, opts.Private)
+		if err != nil {
+			return err
+		}
+		return writeRecords(newRecordWriter(w, opts.Format), columns, func(last *entity.ExportedReaction) ([]*entity.ExportedReaction, error) {
+			q := &query.ExportReactions{Filter: opts.Filter, Limit: pageSize, IncludeEmails: hasPrivate(columns)}
+			if last != nil {
+				q.AfterID = last.ID
+			}
+			err := bus.Dispatch(ctx, q)
+			return q.Result, err
+		})
+	}
+	return errors.New("unknown export type '%s'", opts.Type)
+}
+
+// writeRecords writes every page returned by next, which receives the last record of the previous page
+func writeRecords[T any](w recordWriter, columns []column[T], next func(last T) ([]T, error)) error {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	if err := w.begin(header); err != nil {
+		return err
+	}
+
+	var last T
+	for {
+		records, err := next(last)
+		if err != nil {
+			return errors.Wrap(err, "failed to read records to export")
+		}
+
+		for _, record := range records {
+			values := make([]any, len(columns))
+			for i, c := range columns {
+				values[i] = c.value(record)
+			}
+			if err := w.write(values); err != nil {
+				return err
+			}
+		}
+
+		if len(records) < pageSize {
+			return w.end()
+		}
+		last = records[len(records)-1]
+	}
+}
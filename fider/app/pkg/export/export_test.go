@@ -0,0 +1,191 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/export"
+)
+
+var createdAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+var planned = &entity.ExportedPost{
+	Post: &entity.Post{
+		ID:            10,
+		Number:        4,
+		Title:         "Pay by invoice",
+		Status:        enum.PostPlanned,
+		Tags:          []string{"billing", "enterprise"},
+		CreatedAt:     createdAt,
+		User:          &entity.User{ID: 1, Name: "Jon Snow", Email: "jon@got.com"},
+		VotesCount:    3,
+		CommentsCount: 1,
+	},
+	VotesByRole:    map[enum.Role]int{enum.RoleVisitor: 2, enum.RoleAdministrator: 1},
+	VoterEmails:    []string{"arya@got.com", "jon@got.com", "sansa@got.com"},
+	LastActivityAt: createdAt.Add(48 * time.Hour),
+}
+
+func TestWrite_PostsToCSV(t *testing.T) {
+	RegisterT(t)
+
+	var filter query.ExportFilter
+	bus.AddHandler(func(ctx context.Context, q *query.ExportPosts) error {
+		filter = q.Filter
+		Expect(q.IncludeEmails).IsFalse()
+		q.Result = []*entity.ExportedPost{planned}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{
+		Type:    export.TypePosts,
+		Format:  export.FormatCSV,
+		Columns: []string{"number", "title", "tags", "votes_from_visitors", "votes_from_collaborators", "last_activity_at", "response"},
+		Filter:  query.ExportFilter{Tags: []string{"billing"}, Statuses: []enum.PostStatus{enum.PostPlanned}},
+	})
+	Expect(err).IsNil()
+	Expect(filter.Tags).Equals([]string{"billing"})
+	Expect(buffer.String()).Equals(
+		"number,title,tags,votes_from_visitors,votes_from_collaborators,last_activity_at,response\n" +
+			"4,Pay by invoice,\"billing, enterprise\",2,0,2024-03-03T10:00:00Z,\n",
+	)
+}
+
+func TestWrite_PostsWithEmails(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ExportPosts) error {
+		Expect(q.IncludeEmails).IsTrue()
+		q.Result = []*entity.ExportedPost{planned}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{
+		Type:    export.TypePosts,
+		Format:  export.FormatNDJSON,
+		Columns: []string{"number", "voter_emails"},
+		Private: true,
+	})
+	Expect(err).IsNil()
+	Expect(buffer.String()).Equals(`{"number":4,"voter_emails":["arya@got.com","jon@got.com","sansa@got.com"]}` + "\n")
+}
+
+func TestWrite_PrivateColumnsRequirePrivate(t *testing.T) {
+	RegisterT(t)
+
+	err := export.Write(context.Background(), &bytes.Buffer{}, export.Options{
+		Type:    export.TypeVotes,
+		Format:  export.FormatCSV,
+		Columns: []string{"voter_email"},
+	})
+	Expect(err).IsNotNil()
+
+	Expect(export.Columns(export.TypeVotes, false)).Equals([]string{"post_number", "post_title", "created_at", "voter", "voter_role"})
+	Expect(export.Columns(export.TypeVotes, true)).Equals([]string{"post_number", "post_title", "created_at", "voter", "voter_role", "voter_email"})
+}
+
+func TestWrite_CommentsToJSON(t *testing.T) {
+	RegisterT(t)
+
+	editedAt := createdAt.Add(time.Hour)
+	bus.AddHandler(func(ctx context.Context, q *query.ExportComments) error {
+		q.Result = []*entity.ExportedComment{
+			{ID: 1, PostNumber: 4, Content: "Yes", User: &entity.User{Name: "Arya"}, CreatedAt: createdAt},
+			{ID: 2, PostNumber: 4, Content: "No", User: &entity.User{Name: "Jon"}, CreatedAt: createdAt, EditedAt: &editedAt},
+		}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{
+		Type:    export.TypeComments,
+		Format:  export.FormatJSON,
+		Columns: []string{"id", "content", "created_by", "edited_at"},
+	})
+	Expect(err).IsNil()
+	Expect(buffer.String()).Equals(`[{"id":1,"content":"Yes","created_by":"Arya","edited_at":null},{"id":2,"content":"No","created_by":"Jon","edited_at":"2024-03-01T11:00:00Z"}]`)
+}
+
+func TestWrite_ReactionsToCSV(t *testing.T) {
+	RegisterT(t)
+
+	commentID := 9
+	bus.AddHandler(func(ctx context.Context, q *query.ExportReactions) error {
+		q.Result = []*entity.ExportedReaction{
+			{ID: 1, PostNumber: 4, Emoji: "👍", User: &entity.User{Name: "Arya"}, CreatedAt: createdAt},
+			{ID: 2, PostNumber: 4, CommentID: &commentID, Emoji: "🎉", User: &entity.User{Name: "Jon"}, CreatedAt: createdAt},
+		}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{
+		Type:    export.TypeReactions,
+		Format:  export.FormatCSV,
+		Columns: []string{"post_number", "comment_id", "emoji", "reacted_by"},
+	})
+	Expect(err).IsNil()
+	Expect(buffer.String()).Equals("post_number,comment_id,emoji,reacted_by\n4,,👍,Arya\n4,9,🎉,Jon\n")
+	Expect(export.Columns(export.TypeReactions, false)).Equals([]string{"post_number", "post_title", "comment_id", "emoji", "created_at", "reacted_by", "reacted_by_role"})
+}
+
+func TestWrite_EmptyJSON(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ExportVotes) error {
+		q.Result = []*entity.ExportedVote{}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{Type: export.TypeVotes, Format: export.FormatJSON})
+	Expect(err).IsNil()
+	Expect(buffer.String()).Equals("[]")
+}
+
+func TestWrite_ReadsAllPages(t *testing.T) {
+	RegisterT(t)
+
+	afterIDs := []int{}
+	bus.AddHandler(func(ctx context.Context, q *query.ExportComments) error {
+		afterIDs = append(afterIDs, q.AfterID)
+		count := q.Limit
+		if q.AfterID > 0 {
+			count = 2
+		}
+		q.Result = make([]*entity.ExportedComment, count)
+		for i := range q.Result {
+			q.Result[i] = &entity.ExportedComment{ID: q.AfterID + i + 1, User: &entity.User{}}
+		}
+		return nil
+	})
+
+	buffer := &bytes.Buffer{}
+	err := export.Write(context.Background(), buffer, export.Options{
+		Type:    export.TypeComments,
+		Format:  export.FormatNDJSON,
+		Columns: []string{"id"},
+	})
+	Expect(err).IsNil()
+	Expect(afterIDs).Equals([]int{0, 500})
+	Expect(bytes.Count(buffer.Bytes(), []byte("\n"))).Equals(502)
+}
+
+func TestWrite_UnknownTypeOrFormat(t *testing.T) {
+	RegisterT(t)
+
+	err := export.Write(context.Background(), &bytes.Buffer{}, export.Options{Type: "users", Format: export.FormatCSV})
+	Expect(err).IsNotNil()
+
+	err = export.Write(context.Background(), &bytes.Buffer{}, export.Options{Type: export.TypePosts, Format: "xml"})
+	Expect(err).IsNotNil()
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"bytes"
+	gocsv "encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recordWriter writes exported records in the format of a file
+type recordWriter interface {
+	begin(columns []string) error
+	write(values []any) error
+	end() error
+}
+
+func newRecordWriter(w io.Writer, format Format) recordWriter {
+	switch format {
+	case FormatJSON:
+		return &jsonWriter{w: w}
+	case FormatNDJSON:
+		return &jsonWriter{w: w, lines: true}
+	}
+	return &csvWriter{w: gocsv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	w *gocsv.Writer
+}
+
+func (c *csvWriter) begin(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvWriter) write(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = csvValue(value)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// jsonWriter writes records as objects whose keys keep the order of the columns,
+// either on a JSON array or one per line
+type jsonWriter struct {
+	w       io.Writer
+	lines   bool
+	columns [][]byte
+	count   int
+}
+
+func (j *jsonWriter) begin(columns []string) error {
+	j.columns = make([][]byte, len(columns))
+	for i, name := range columns {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		j.columns[i] = key
+	}
+
+	if !j.lines {
+		_, err := io.WriteString(j.w, "[")
+		return err
+	}
+	return nil
+}
+
+func (j *jsonWriter) write(values []any) error {
+	buffer := &bytes.Buffer{}
+	if !j.lines && j.count > 0 {
+		buffer.WriteString(",")
+	}
+
+	buffer.WriteString("{")
+	for i, value := range values {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		content, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buffer.Write(j.columns[i])
+		buffer.WriteString(":")
+		buffer.Write(content)
+	}
+	buffer.WriteString("}")
+
+	if j.lines {
+		buffer.WriteString("\n")
+	}
+
+	j.count++
+	_, err := j.w.Write(buffer.Bytes())
+	return err
+}
+
+func (j *jsonWriter) end() error {
+	if !j.lines {
+		_, err := io.WriteString(j.w, "]")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package img
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+const (
+	// JPEGQuality is the quality of JPEG images encoded by this package
+	JPEGQuality = 85
+
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+// Image is an encoded image
+type Image struct {
+	Content     []byte
+	ContentType string
+	Format      string
+	Width       int
+	Height      int
+}
+
+// Normalize applies the EXIF orientation of an image, removes its metadata and scales it down to fit maxSize x maxSize.
+// GIF images are returned as they are, so that animations are preserved
+func Normalize(content []byte, maxSize int) (*Image, error) {
+	src, format, err := decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatGIF {
+		bounds := src.Bounds()
+		return &Image{Content: content, ContentType: ContentType(format), Format: format, Width: bounds.Dx(), Height: bounds.Dy()}, nil
+	}
+
+	// encoding the decoded image drops every metadata block, including the EXIF with GPS coordinates
+	return encode(fit(src, maxSize), format)
+}
+
+// Resize scales an image down to fit size x size and encodes it in the given format, or in its own format when empty.
+// Only the first frame of animated GIFs is kept
+func Resize(content []byte, size int, format string) (*Image, error) {
+	src, srcFormat, err := decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "" {
+		format = srcFormat
+	}
+	return encode(fit(src, size), format)
+}
+
+// Negotiate returns the format an image should be served with to a client that sends given Accept header.
+// WebP is only used for PNG images, as they can be encoded losslessly in a smaller size.
+// AVIF and lossy WebP can't be encoded without native libraries, so JPEG images are served as they are
+func Negotiate(accept string, format string) string {
+	if format == FormatPNG && strings.Contains(accept, "image/webp") {
+		return FormatWebP
+	}
+	return format
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	return "image/" + format
+}
+
+func decode(content []byte) (image.Image, string, error) {
+	_, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to decode image config")
+	}
+
+	src, err := imaging.Decode(bytes.NewReader(content), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to decode %s image", format)
+	}
+	return src, format, nil
+}
+
+func fit(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	if size <= 0 || (bounds.Dx() <= size && bounds.Dy() <= size) {
+		return src
+	}
+	return imaging.Fit(src, size, size, imaging.Lanczos)
+}
+
+func encode(src image.Image, format string) (*Image, error) {
+	buf := new(bytes.Buffer)
+
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(buf, src, &jpeg.Options{Quality: JPEGQuality})
+	case FormatPNG:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(buf, src)
+	case FormatGIF:
+		err = gif.Encode(buf, src, nil)
+	case FormatWebP:
+		err = nativewebp.Encode(buf, src, nil)
+	default:
+		return nil, errors.New("image format '%s' is not supported", format)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode %s image", format)
+	}
+
+	bounds := src.Bounds()
+	return &Image{
+		Content:     buf.Bytes(),
+		ContentType: ContentType(format),
+		Format:      format,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}, nil
+}
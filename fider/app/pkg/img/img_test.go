@@ -0,0 +1,147 @@
+package img_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/img"
+)
+
+func newImage(width, height int) *image.RGBA {
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			src.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	return src
+}
+
+func encodePNG(width, height int) []byte {
+	buf := new(bytes.Buffer)
+	_ = png.Encode(buf, newImage(width, height))
+	return buf.Bytes()
+}
+
+// encodeJPEGWithOrientation returns a JPEG with an EXIF block holding given orientation
+func encodeJPEGWithOrientation(width, height int, orientation uint16) []byte {
+	buf := new(bytes.Buffer)
+	_ = jpeg.Encode(buf, newImage(width, height), nil)
+	content := buf.Bytes()
+
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("II*\x00")
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(8))
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(1))
+	_ = binary.Write(tiff, binary.LittleEndian, []uint16{0x0112, 3})
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(1))
+	_ = binary.Write(tiff, binary.LittleEndian, []uint16{orientation, 0})
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(0))
+
+	exif := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(exif)+2))
+	segment = append(segment, exif...)
+
+	result := append([]byte{}, content[:2]...)
+	result = append(result, segment...)
+	return append(result, content[2:]...)
+}
+
+func TestNormalize_ScalesDownLargeImages(t *testing.T) {
+	RegisterT(t)
+
+	result, err := img.Normalize(encodePNG(300, 150), 100)
+	Expect(err).IsNil()
+	Expect(result.Format).Equals("png")
+	Expect(result.ContentType).Equals("image/png")
+	Expect(result.Width).Equals(100)
+	Expect(result.Height).Equals(50)
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(result.Content))
+	Expect(err).IsNil()
+	Expect(format).Equals("png")
+	Expect(config.Width).Equals(100)
+	Expect(config.Height).Equals(50)
+}
+
+func TestNormalize_KeepsSmallImagesSize(t *testing.T) {
+	RegisterT(t)
+
+	result, err := img.Normalize(encodePNG(80, 40), 100)
+	Expect(err).IsNil()
+	Expect(result.Width).Equals(80)
+	Expect(result.Height).Equals(40)
+}
+
+func TestNormalize_AppliesOrientationAndStripsExif(t *testing.T) {
+	RegisterT(t)
+
+	content := encodeJPEGWithOrientation(60, 30, 6)
+	Expect(bytes.Contains(content, []byte("Exif"))).IsTrue()
+
+	result, err := img.Normalize(content, 100)
+	Expect(err).IsNil()
+	Expect(result.ContentType).Equals("image/jpeg")
+	Expect(result.Width).Equals(30)
+	Expect(result.Height).Equals(60)
+	Expect(bytes.Contains(result.Content, []byte("Exif"))).IsFalse()
+}
+
+func TestNormalize_KeepsGIFAsIs(t *testing.T) {
+	RegisterT(t)
+
+	buf := new(bytes.Buffer)
+	_ = gif.Encode(buf, newImage(300, 150), nil)
+
+	result, err := img.Normalize(buf.Bytes(), 100)
+	Expect(err).IsNil()
+	Expect(result.ContentType).Equals("image/gif")
+	Expect(result.Content).Equals(buf.Bytes())
+	Expect(result.Width).Equals(300)
+}
+
+func TestNormalize_InvalidImage(t *testing.T) {
+	RegisterT(t)
+
+	result, err := img.Normalize([]byte("not an image"), 100)
+	Expect(err).IsNotNil()
+	Expect(result).IsNil()
+}
+
+func TestResize(t *testing.T) {
+	RegisterT(t)
+
+	result, err := img.Resize(encodePNG(300, 150), 50, "")
+	Expect(err).IsNil()
+	Expect(result.ContentType).Equals("image/png")
+	Expect(result.Width).Equals(50)
+	Expect(result.Height).Equals(25)
+
+	result, err = img.Resize(encodePNG(300, 150), 50, img.FormatWebP)
+	Expect(err).IsNil()
+	Expect(result.ContentType).Equals("image/webp")
+	Expect(string(result.Content[0:4])).Equals("RIFF")
+	Expect(string(result.Content[8:12])).Equals("WEBP")
+
+	_, err = img.Resize(encodePNG(300, 150), 50, "avif")
+	Expect(err).IsNotNil()
+}
+
+func TestNegotiate(t *testing.T) {
+	RegisterT(t)
+
+	accept := "image/avif,image/webp,image/apng,image/*,*/*;q=0.8"
+	Expect(img.Negotiate(accept, "png")).Equals("webp")
+	Expect(img.Negotiate(accept, "jpeg")).Equals("jpeg")
+	Expect(img.Negotiate(accept, "gif")).Equals("gif")
+	Expect(img.Negotiate("image/*", "png")).Equals("png")
+	Expect(img.Negotiate("", "png")).Equals("png")
+}
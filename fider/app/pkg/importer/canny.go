@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// cannyExport is the layout of the Canny export, made of the objects returned by its API
+type cannyExport struct {
+	Posts    []cannyPost    `json:"posts"`
+	Comments []cannyComment `json:"comments"`
+	Votes    []cannyVote    `json:"votes"`
+}
+
+type cannyUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type cannyRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type cannyPost struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Details         string     `json:"details"`
+	Status          string     `json:"status"`
+	Created         string     `json:"created"`
+	StatusChangedAt string     `json:"statusChangedAt"`
+	Author          cannyUser  `json:"author"`
+	Board           cannyRef   `json:"board"`
+	Tags            []cannyRef `json:"tags"`
+}
+
+type cannyComment struct {
+	ID      string    `json:"id"`
+	Value   string    `json:"value"`
+	Created string    `json:"created"`
+	Author  cannyUser `json:"author"`
+	Post    cannyRef  `json:"post"`
+}
+
+type cannyVote struct {
+	ID      string    `json:"id"`
+	Created string    `json:"created"`
+	Voter   cannyUser `json:"voter"`
+	Post    cannyRef  `json:"post"`
+}
+
+func parseCanny(content []byte) (*Result, error) {
+	export := cannyExport{}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, invalidFile("File is not a valid Canny export: %s", err.Error())
+	}
+
+	result := &Result{Records: make([]*Record, 0)}
+	row := 0
+
+	for _, post := range export.Posts {
+		row++
+		if strings.TrimSpace(post.Title) == "" {
+			result.fail(row, post.ID, "Post has no title.")
+			continue
+		}
+		status, ok := parseStatus(post.Status)
+		if !ok {
+			result.fail(row, post.ID, "Status '%s' is unknown.", post.Status)
+			continue
+		}
+		createdAt, ok := parseTime(post.Created)
+		if !ok {
+			result.fail(row, post.ID, "Date '%s' is invalid.", post.Created)
+			continue
+		}
+
+		record := &Record{
+			Row:         row,
+			Kind:        KindPost,
+			SourceID:    post.ID,
+			Author:      newAuthor(post.Author.ID, post.Author.Name, post.Author.Email),
+			CreatedAt:   createdAt,
+			Title:       strings.TrimSpace(post.Title),
+			Description: post.Details,
+			Status:      status,
+			Tags:        make([]string, 0),
+		}
+		if changedAt, ok := parseTime(post.StatusChangedAt); ok && !changedAt.IsZero() {
+			record.RespondedAt = &changedAt
+		}
+		// Boards have no equivalent on Fider, so they become tags
+		if post.Board.Name != "" {
+			record.Tags = append(record.Tags, post.Board.Name)
+		}
+		for _, tag := range post.Tags {
+			record.Tags = append(record.Tags, tag.Name)
+		}
+		result.Records = append(result.Records, record)
+	}
+
+	for _, comment := range export.Comments {
+		row++
+		if strings.TrimSpace(comment.Value) == "" {
+			result.fail(row, comment.ID, "Comment is empty.")
+			continue
+		}
+		createdAt, ok := parseTime(comment.Created)
+		if !ok {
+			result.fail(row, comment.ID, "Date '%s' is invalid.", comment.Created)
+			continue
+		}
+
+		result.Records = append(result.Records, &Record{
+			Row:          row,
+			Kind:         KindComment,
+			SourceID:     comment.ID,
+			PostSourceID: comment.Post.ID,
+			Author:       newAuthor(comment.Author.ID, comment.Author.Name, comment.Author.Email),
+			CreatedAt:    createdAt,
+			Content:      comment.Value,
+		})
+	}
+
+	for _, vote := range export.Votes {
+		row++
+		createdAt, ok := parseTime(vote.Created)
+		if !ok {
+			result.fail(row, vote.ID, "Date '%s' is invalid.", vote.Created)
+			continue
+		}
+		voter := newAuthor(vote.Voter.ID, vote.Voter.Name, vote.Voter.Email)
+		if voter.IsEmpty() {
+			result.fail(row, vote.ID, "Vote has no voter.")
+			continue
+		}
+
+		result.Records = append(result.Records, &Record{
+			Row:          row,
+			Kind:         KindVote,
+			SourceID:     vote.ID,
+			PostSourceID: vote.Post.ID,
+			Author:       voter,
+			CreatedAt:    createdAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package importer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/importer"
+)
+
+func TestParse_Canny(t *testing.T) {
+	RegisterT(t)
+
+	content := []byte(`{
+		"posts": [
+			{
+				"id": "p1", "title": "Dark mode", "details": "Please add it", "status": "in progress",
+				"created": "2023-04-05T10:00:00.000Z", "statusChangedAt": "2023-05-01T08:00:00.000Z",
+				"author": { "id": "u1", "name": "Jon Snow", "email": "Jon@Snow.com" },
+				"board": { "name": "Feature Requests" }, "tags": [{ "name": "UI" }]
+			},
+			{ "id": "p2", "title": "", "status": "open", "created": "2023-04-05T10:00:00.000Z" },
+			{ "id": "p3", "title": "Export", "status": "somewhere", "created": "2023-04-05T10:00:00.000Z" }
+		],
+		"comments": [
+			{ "id": "c1", "value": "Yes!", "created": "2023-04-06T10:00:00.000Z", "author": { "id": "u2", "name": "Arya" }, "post": { "id": "p1" } }
+		],
+		"votes": [
+			{ "id": "v1", "created": "2023-04-07T10:00:00.000Z", "voter": { "id": "u2", "name": "Arya" }, "post": { "id": "p1" } },
+			{ "id": "v2", "created": "2023-04-07T10:00:00.000Z", "voter": {}, "post": { "id": "p1" } }
+		]
+	}`)
+
+	result, err := importer.Parse(enum.ImportSourceCanny, content, nil)
+	Expect(err).IsNil()
+	Expect(result.Rows()).Equals(6)
+	Expect(result.Records).HasLen(3)
+	Expect(result.Errors).HasLen(3)
+
+	post := result.Records[0]
+	Expect(post.Kind).Equals(importer.KindPost)
+	Expect(post.SourceID).Equals("p1")
+	Expect(post.Title).Equals("Dark mode")
+	Expect(post.Status).Equals(enum.PostStarted)
+	Expect(post.Author).Equals(importer.Author{SourceID: "u1", Name: "Jon Snow", Email: "jon@snow.com"})
+	Expect(post.CreatedAt).Equals(time.Date(2023, 4, 5, 10, 0, 0, 0, time.UTC))
+	Expect(*post.RespondedAt).Equals(time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC))
+	Expect(post.Tags).Equals([]string{"Feature Requests", "UI"})
+
+	comment := result.Records[1]
+	Expect(comment.Kind).Equals(importer.KindComment)
+	Expect(comment.PostSourceID).Equals("p1")
+	Expect(comment.Content).Equals("Yes!")
+
+	vote := result.Records[2]
+	Expect(vote.Kind).Equals(importer.KindVote)
+	Expect(vote.Author.SourceID).Equals("u2")
+
+	Expect(result.Errors[0].SourceID).Equals("p2")
+	Expect(result.Errors[1].Message).Equals("Status 'somewhere' is unknown.")
+	Expect(result.Errors[2].SourceID).Equals("v2")
+}
+
+func TestParse_Canny_InvalidFile(t *testing.T) {
+	RegisterT(t)
+
+	_, err := importer.Parse(enum.ImportSourceCanny, []byte("title,description"), nil)
+	_, isFileError := err.(*importer.FileError)
+	Expect(isFileError).IsTrue()
+}
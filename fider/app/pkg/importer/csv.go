@@ -0,0 +1,186 @@
+package importer
+
+import (
+	"bytes"
+	gocsv "encoding/csv"
+	"strings"
+)
+
+// Field is a value of a post that can be read from a column of a CSV file
+type Field string
+
+// Fields of a post, Voters holds a list of emails separated by commas or semicolons
+var (
+	FieldID          Field = "id"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldAuthorName  Field = "authorName"
+	FieldAuthorEmail Field = "authorEmail"
+	FieldCreatedAt   Field = "createdAt"
+	FieldStatus      Field = "status"
+	FieldResponse    Field = "response"
+	FieldRespondedAt Field = "respondedAt"
+	FieldTags        Field = "tags"
+	FieldVoters      Field = "voters"
+)
+
+// Fields is the list of all fields that can be mapped on a CSV import
+var Fields = []Field{
+	FieldID,
+	FieldTitle,
+	FieldDescription,
+	FieldAuthorName,
+	FieldAuthorEmail,
+	FieldCreatedAt,
+	FieldStatus,
+	FieldResponse,
+	FieldRespondedAt,
+	FieldTags,
+	FieldVoters,
+}
+
+// IsValid returns true if field is a known field
+func (f Field) IsValid() bool {
+	for _, field := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Mapping maps fields of a post to the name of the column that holds it on a CSV file
+type Mapping map[Field]string
+
+// csvTable is a CSV file whose columns are found by their name on the first row
+type csvTable struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+func readCSV(content []byte) (*csvTable, error) {
+	reader := gocsv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, invalidFile("File is not a valid CSV: %s", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, invalidFile("File is empty.")
+	}
+
+	table := &csvTable{columns: make(map[string]int), rows: records[1:]}
+	for i, name := range records[0] {
+		table.columns[normalizeColumn(name)] = i
+	}
+	return table, nil
+}
+
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// has returns true if any of given columns exist on the file
+func (t *csvTable) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := t.columns[normalizeColumn(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the value of the first of given columns that exist on the file
+func (t *csvTable) get(row []string, names ...string) string {
+	for _, name := range names {
+		if i, ok := t.columns[normalizeColumn(name)]; ok {
+			if i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// line returns the line of the file where given row index is, the header being on line 1
+func line(index int) int {
+	return index + 2
+}
+
+func parseCSV(content []byte, mapping Mapping) (*Result, error) {
+	if mapping[FieldTitle] == "" {
+		return nil, invalidFile("Title column is required.")
+	}
+
+	table, err := readCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	for field, column := range mapping {
+		if column != "" && !table.has(column) {
+			return nil, invalidFile("Column '%s' mapped to %s was not found on the file.", column, field)
+		}
+	}
+
+	column := func(row []string, field Field) string {
+		if mapping[field] == "" {
+			return ""
+		}
+		return table.get(row, mapping[field])
+	}
+
+	result := &Result{Records: make([]*Record, 0)}
+	for i, row := range table.rows {
+		id := column(row, FieldID)
+
+		title := column(row, FieldTitle)
+		if title == "" {
+			result.fail(line(i), id, "Title is empty.")
+			continue
+		}
+		// Without an id column, the title is what identifies a post when the file is imported again
+		if id == "" {
+			id = "title:" + strings.ToLower(title)
+		}
+		status, ok := parseStatus(column(row, FieldStatus))
+		if !ok {
+			result.fail(line(i), id, "Status '%s' is unknown.", column(row, FieldStatus))
+			continue
+		}
+		createdAt, ok := parseTime(column(row, FieldCreatedAt))
+		if !ok {
+			result.fail(line(i), id, "Date '%s' is invalid.", column(row, FieldCreatedAt))
+			continue
+		}
+		respondedAt, ok := parseTime(column(row, FieldRespondedAt))
+		if !ok {
+			result.fail(line(i), id, "Date '%s' is invalid.", column(row, FieldRespondedAt))
+			continue
+		}
+
+		record := &Record{
+			Row:         line(i),
+			Kind:        KindPost,
+			SourceID:    id,
+			Author:      newAuthor("", column(row, FieldAuthorName), column(row, FieldAuthorEmail)),
+			CreatedAt:   createdAt,
+			Title:       title,
+			Description: column(row, FieldDescription),
+			Status:      status,
+			Response:    column(row, FieldResponse),
+			Tags:        splitList(column(row, FieldTags)),
+			Voters:      make([]Author, 0),
+		}
+		if !respondedAt.IsZero() {
+			record.RespondedAt = &respondedAt
+		}
+		for _, email := range splitList(column(row, FieldVoters)) {
+			record.Voters = append(record.Voters, newAuthor("", "", email))
+		}
+		result.Records = append(result.Records, record)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/importer"
+)
+
+func TestParse_CSV(t *testing.T) {
+	RegisterT(t)
+
+	content := []byte("\xef\xbb\xbfKey,Summary,Details,Email,State,Labels,Voters\n" +
+		"A-1,Dark mode,Please,jon@snow.com,Planned,\"UI, Themes\",arya@stark.com;sansa@stark.com\n" +
+		"A-2,Offline,,,open,,\n" +
+		"A-3,Sync,,,unknown,,\n")
+
+	mapping := importer.Mapping{
+		importer.FieldID:          "key",
+		importer.FieldTitle:       "Summary",
+		importer.FieldDescription: "Details",
+		importer.FieldAuthorEmail: "Email",
+		importer.FieldStatus:      "State",
+		importer.FieldTags:        "Labels",
+		importer.FieldVoters:      "Voters",
+	}
+
+	result, err := importer.Parse(enum.ImportSourceCSV, content, mapping)
+	Expect(err).IsNil()
+	Expect(result.Records).HasLen(2)
+	Expect(result.Errors).HasLen(1)
+
+	post := result.Records[0]
+	Expect(post.SourceID).Equals("A-1")
+	Expect(post.Status).Equals(enum.PostPlanned)
+	Expect(post.Author.Email).Equals("jon@snow.com")
+	Expect(post.Tags).Equals([]string{"UI", "Themes"})
+	Expect(post.Voters).HasLen(2)
+	Expect(result.Records[1].Author.IsEmpty()).IsTrue()
+	Expect(result.Errors[0].SourceID).Equals("A-3")
+}
+
+func TestParse_CSV_WithoutID_UsesTitle(t *testing.T) {
+	RegisterT(t)
+
+	content := []byte("title\nDark Mode\n")
+	result, err := importer.Parse(enum.ImportSourceCSV, content, importer.Mapping{importer.FieldTitle: "title"})
+	Expect(err).IsNil()
+	Expect(result.Records[0].SourceID).Equals("title:dark mode")
+}
+
+func TestParse_CSV_InvalidMapping(t *testing.T) {
+	RegisterT(t)
+
+	content := []byte("title\nDark Mode\n")
+	for _, mapping := range []importer.Mapping{
+		{},
+		{importer.FieldTitle: "name"},
+		{importer.FieldTitle: "title", importer.FieldStatus: "state"},
+	} {
+		_, err := importer.Parse(enum.ImportSourceCSV, content, mapping)
+		_, isFileError := err.(*importer.FileError)
+		Expect(isFileError).IsTrue()
+	}
+}
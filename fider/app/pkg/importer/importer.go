@@ -0,0 +1,178 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// RecordKind is what a record of an import file creates
+type RecordKind string
+
+var (
+	// KindPost creates a post, with its tags, status and response
+	KindPost RecordKind = "post"
+	// KindComment adds a comment to a post of the same source
+	KindComment RecordKind = "comment"
+	// KindVote adds a vote to a post of the same source
+	KindVote RecordKind = "vote"
+)
+
+// Author is the user of the source tool that created a record
+type Author struct {
+	SourceID string
+	Name     string
+	Email    string
+}
+
+// IsEmpty returns true if nothing is known about the author
+func (a Author) IsEmpty() bool {
+	return a.SourceID == "" && a.Name == "" && a.Email == ""
+}
+
+func newAuthor(sourceID, name, email string) Author {
+	author := Author{
+		SourceID: strings.TrimSpace(sourceID),
+		Name:     strings.TrimSpace(name),
+		Email:    strings.ToLower(strings.TrimSpace(email)),
+	}
+
+	// Authors known only by name would be created again on every row, so the name becomes their id
+	if author.SourceID == "" && author.Email == "" && author.Name != "" {
+		author.SourceID = "name:" + strings.ToLower(author.Name)
+	}
+	return author
+}
+
+// Record is a row of an import file, converted from the format of its source
+type Record struct {
+	Row          int
+	Kind         RecordKind
+	SourceID     string
+	PostSourceID string
+	Author       Author
+	CreatedAt    time.Time
+
+	// Posts only
+	Title       string
+	Description string
+	Status      enum.PostStatus
+	Response    string
+	RespondedAt *time.Time
+	Tags        []string
+	Voters      []Author
+
+	// Comments only
+	Content string
+}
+
+// Result is the content of an import file, rows that cannot be converted are reported on Errors
+type Result struct {
+	Records []*Record
+	Errors  []*entity.ImportRowError
+}
+
+// Rows returns the number of rows found on the file, including the ones that cannot be imported
+func (r *Result) Rows() int {
+	return len(r.Records) + len(r.Errors)
+}
+
+func (r *Result) fail(row int, sourceID, format string, args ...any) {
+	r.Errors = append(r.Errors, &entity.ImportRowError{
+		Row:      row,
+		SourceID: sourceID,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// FileError is returned when the whole file cannot be read, its message can be shown to the user
+type FileError struct {
+	message string
+}
+
+func (e *FileError) Error() string {
+	return e.message
+}
+
+func invalidFile(format string, args ...any) error {
+	return &FileError{message: fmt.Sprintf(format, args...)}
+}
+
+// Parse converts an import file of given source into records.
+// Mapping is only used by the CSV source, where it maps fields to column names
+func Parse(source enum.ImportSource, content []byte, mapping Mapping) (*Result, error) {
+	switch source {
+	case enum.ImportSourceCanny:
+		return parseCanny(content)
+	case enum.ImportSourceUserVoice:
+		return parseUserVoice(content)
+	case enum.ImportSourceCSV:
+		return parseCSV(content, mapping)
+	}
+	return nil, invalidFile("Import source '%s' is not supported.", source)
+}
+
+var statusAliases = map[string]enum.PostStatus{
+	"":              enum.PostOpen,
+	"open":          enum.PostOpen,
+	"new":           enum.PostOpen,
+	"active":        enum.PostOpen,
+	"under review":  enum.PostOpen,
+	"planned":       enum.PostPlanned,
+	"started":       enum.PostStarted,
+	"in progress":   enum.PostStarted,
+	"working on it": enum.PostStarted,
+	"complete":      enum.PostCompleted,
+	"completed":     enum.PostCompleted,
+	"done":          enum.PostCompleted,
+	"shipped":       enum.PostCompleted,
+	"declined":      enum.PostDeclined,
+	"closed":        enum.PostDeclined,
+}
+
+// parseStatus converts the status names used by feedback tools into a post status
+func parseStatus(value string) (enum.PostStatus, bool) {
+	status, ok := statusAliases[strings.ToLower(strings.TrimSpace(value))]
+	return status, ok
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006",
+}
+
+// parseTime accepts the date formats used by the exports of feedback tools, an empty value returns the zero time
+func parseTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, true
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitList splits a list of values separated by commas or semicolons
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,298 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// batchSize is the number of records imported on each transaction, progress is saved after every batch
+const batchSize = 50
+
+// maxReportedErrors limits the size of the error report, failed rows beyond it are only counted
+const maxReportedErrors = 500
+
+// rowError is a reason a record cannot be imported, its message can be shown to the user
+type rowError struct {
+	message string
+}
+
+func (e *rowError) Error() string {
+	return e.message
+}
+
+func rowFailed(format string, args ...any) error {
+	return &rowError{message: fmt.Sprintf(format, args...)}
+}
+
+type runner struct {
+	job       *entity.ImportJob
+	source    enum.ImportSource
+	processed int
+	failed    int
+	errors    []*entity.ImportRowError
+
+	// users and posts already imported, indexed by their source id.
+	// Entries found while importing a row are only kept once the row is saved
+	users        map[string]*entity.User
+	posts        map[string]*entity.Post
+	pendingUsers map[string]*entity.User
+	pendingPosts map[string]*entity.Post
+}
+
+// Run imports the records of a job on the tenant of given context, on behalf of its current user.
+// Each batch of records runs on its own transaction and each record on its own savepoint,
+// so a failed row is reported and skipped while the others are still imported.
+// Records already imported by a previous run of the same source are updated instead of duplicated
+func Run(ctx context.Context, job *entity.ImportJob, parsed *Result) error {
+	r := &runner{
+		job:       job,
+		source:    job.Source,
+		processed: len(parsed.Errors),
+		errors:    make([]*entity.ImportRowError, 0),
+		users:     make(map[string]*entity.User),
+		posts:     make(map[string]*entity.Post),
+	}
+	for _, rowErr := range parsed.Errors {
+		r.report(rowErr)
+	}
+
+	if err := r.saveProgress(ctx, enum.ImportRunning); err != nil {
+		return err
+	}
+
+	records := parsed.Records
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		processed, failed, reported := r.processed, r.failed, len(r.errors)
+
+		err := ctx.Err()
+		if err == nil {
+			err = inTransaction(ctx, func(ctx context.Context) error {
+				if err := r.importBatch(ctx, records[start:end]); err != nil {
+					return err
+				}
+				return r.updateJob(ctx, enum.ImportRunning)
+			})
+		}
+		if err != nil {
+			// Nothing of the batch was saved, so its rows are reported as not processed
+			r.processed, r.failed, r.errors = processed, failed, r.errors[:reported]
+			if saveErr := r.saveProgress(ctx, enum.ImportFailed); saveErr != nil {
+				return errors.Wrap(saveErr, "failed to save progress after: %s", err.Error())
+			}
+			return errors.Wrap(err, "failed to import batch of rows %d to %d", start+1, end)
+		}
+	}
+
+	return r.saveProgress(ctx, enum.ImportCompleted)
+}
+
+func inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, app.TransactionCtxKey, trx)); err != nil {
+		trx.MustRollback()
+		return err
+	}
+	return trx.Commit()
+}
+
+// saveProgress updates the job on its own transaction, so it's visible while the import is running.
+// It also runs after the import is canceled, so the job isn't left as running
+func (r *runner) saveProgress(ctx context.Context, status enum.ImportStatus) error {
+	return inTransaction(context.WithoutCancel(ctx), func(ctx context.Context) error {
+		return r.updateJob(ctx, status)
+	})
+}
+
+func (r *runner) updateJob(ctx context.Context, status enum.ImportStatus) error {
+	return bus.Dispatch(ctx, &cmd.UpdateImportJobProgress{
+		JobID:         r.job.ID,
+		Status:        status,
+		ProcessedRows: r.processed,
+		FailedRows:    r.failed,
+		Errors:        r.errors,
+	})
+}
+
+func (r *runner) report(rowErr *entity.ImportRowError) {
+	r.failed++
+	if len(r.errors) < maxReportedErrors {
+		r.errors = append(r.errors, rowErr)
+	}
+}
+
+func (r *runner) importBatch(ctx context.Context, records []*Record) error {
+	trx := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
+
+	for _, record := range records {
+		if _, err := trx.Execute("SAVEPOINT import_row"); err != nil {
+			return errors.Wrap(err, "failed to create savepoint")
+		}
+
+		r.pendingUsers = make(map[string]*entity.User)
+		r.pendingPosts = make(map[string]*entity.Post)
+		err := r.importRecord(ctx, record)
+		r.processed++
+
+		if err != nil {
+			if _, rollbackErr := trx.Execute("ROLLBACK TO SAVEPOINT import_row"); rollbackErr != nil {
+				return errors.Wrap(rollbackErr, "failed to rollback row %d", record.Row)
+			}
+
+			message := errors.Cause(err).Error()
+			if rowErr, ok := errors.Cause(err).(*rowError); ok {
+				message = rowErr.message
+			}
+			r.report(&entity.ImportRowError{Row: record.Row, SourceID: record.SourceID, Message: message})
+			continue
+		}
+
+		if _, err := trx.Execute("RELEASE SAVEPOINT import_row"); err != nil {
+			return errors.Wrap(err, "failed to release savepoint")
+		}
+		for key, user := range r.pendingUsers {
+			r.users[key] = user
+		}
+		for key, post := range r.pendingPosts {
+			r.posts[key] = post
+		}
+	}
+	return nil
+}
+
+func (r *runner) importRecord(ctx context.Context, record *Record) error {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = r.job.CreatedAt
+	}
+
+	author, err := r.user(ctx, record.Author, record.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	switch record.Kind {
+	case KindPost:
+		return r.importPost(ctx, record, author)
+	case KindComment:
+		post, err := r.post(ctx, record.PostSourceID)
+		if err != nil {
+			return err
+		}
+		return bus.Dispatch(ctx, &cmd.ImportComment{
+			Source:    r.source,
+			SourceID:  record.SourceID,
+			Post:      post,
+			User:      author,
+			Content:   record.Content,
+			CreatedAt: record.CreatedAt,
+		})
+	case KindVote:
+		post, err := r.post(ctx, record.PostSourceID)
+		if err != nil {
+			return err
+		}
+		return bus.Dispatch(ctx, &cmd.ImportVote{Post: post, User: author, CreatedAt: record.CreatedAt})
+	}
+	return rowFailed("Row of kind '%s' cannot be imported.", record.Kind)
+}
+
+func (r *runner) importPost(ctx context.Context, record *Record, author *entity.User) error {
+	importPost := &cmd.ImportPost{
+		Source:      r.source,
+		SourceID:    record.SourceID,
+		Title:       record.Title,
+		Description: record.Description,
+		User:        author,
+		CreatedAt:   record.CreatedAt,
+		Status:      record.Status,
+		Response:    record.Response,
+		RespondedAt: record.RespondedAt,
+		Tags:        record.Tags,
+	}
+	if err := bus.Dispatch(ctx, importPost); err != nil {
+		return err
+	}
+	if record.SourceID != "" {
+		r.pendingPosts[record.SourceID] = importPost.Result
+	}
+
+	for _, voter := range record.Voters {
+		user, err := r.user(ctx, voter, record.CreatedAt)
+		if err != nil {
+			return err
+		}
+		err = bus.Dispatch(ctx, &cmd.ImportVote{Post: importPost.Result, User: user, CreatedAt: record.CreatedAt})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// user returns the user that matches the author, records without an author are attributed to whoever started the import
+func (r *runner) user(ctx context.Context, author Author, createdAt time.Time) (*entity.User, error) {
+	if author.IsEmpty() {
+		user, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+		return user, nil
+	}
+
+	key := author.SourceID
+	if key == "" {
+		key = author.Email
+	}
+	if user, ok := r.users[key]; ok {
+		return user, nil
+	}
+	if user, ok := r.pendingUsers[key]; ok {
+		return user, nil
+	}
+
+	// The date of the first record of an author is the closest to when they joined the source tool
+	importUser := &cmd.ImportUser{
+		Source:    r.source,
+		SourceID:  author.SourceID,
+		Name:      author.Name,
+		Email:     author.Email,
+		CreatedAt: createdAt,
+	}
+	if err := bus.Dispatch(ctx, importUser); err != nil {
+		return nil, err
+	}
+
+	r.pendingUsers[key] = importUser.Result
+	return importUser.Result, nil
+}
+
+func (r *runner) post(ctx context.Context, sourceID string) (*entity.Post, error) {
+	if post, ok := r.posts[sourceID]; ok {
+		return post, nil
+	}
+	if post, ok := r.pendingPosts[sourceID]; ok {
+		return post, nil
+	}
+
+	getPost := &query.GetImportedPost{Source: r.source, SourceID: sourceID}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return nil, err
+	}
+	if getPost.Result == nil {
+		return nil, rowFailed("Post '%s' was not found, it must be imported before its comments and votes.", sourceID)
+	}
+
+	r.pendingPosts[sourceID] = getPost.Result
+	return getPost.Result, nil
+}
@@ -0,0 +1,147 @@
+package importer
+
+// UserVoice exports suggestions, comments and supporters as separate CSV files,
+// so each one is imported on its own and they are linked by the suggestion id.
+// Column names changed between versions of UserVoice, the alternatives are listed in order of preference
+var (
+	uvSuggestionID = []string{"Suggestion ID", "Idea ID"}
+	uvCommentID    = []string{"Comment ID"}
+	uvTitle        = []string{"Suggestion Title", "Title"}
+	uvBody         = []string{"Suggestion Body", "Description", "Body", "Text"}
+	uvCommentBody  = []string{"Comment Body", "Comment Text", "Comment", "Body", "Text"}
+	uvCreatedAt    = []string{"Created At", "Created"}
+	uvUserID       = []string{"Creator ID", "User ID"}
+	uvUserName     = []string{"Creator Name", "User Name", "Name"}
+	uvUserEmail    = []string{"Creator Email", "User Email", "Email"}
+	uvSupporterID  = []string{"Supporter ID", "User ID"}
+	uvSupporter    = []string{"Supporter Name", "User Name", "Name"}
+	uvSupporterEml = []string{"Supporter Email", "User Email", "Email"}
+	uvStatus       = []string{"Status", "State"}
+	uvResponse     = []string{"Status Response", "Admin Response", "Response"}
+	uvRespondedAt  = []string{"Status Updated At", "Response Created At"}
+	uvCategory     = []string{"Category", "Forum"}
+	uvLabels       = []string{"Labels"}
+)
+
+func parseUserVoice(content []byte) (*Result, error) {
+	table, err := readCSV(content)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case table.has(uvCommentID...):
+		return parseUserVoiceComments(table)
+	case table.has("Supporter Email", "Supporter ID", "Supporter Name"):
+		return parseUserVoiceSupporters(table)
+	case table.has(uvSuggestionID...) && table.has(uvTitle...):
+		return parseUserVoiceSuggestions(table)
+	}
+	return nil, invalidFile("File is not a UserVoice export of suggestions, comments or supporters.")
+}
+
+func parseUserVoiceSuggestions(table *csvTable) (*Result, error) {
+	result := &Result{Records: make([]*Record, 0)}
+	for i, row := range table.rows {
+		id := table.get(row, uvSuggestionID...)
+		title := table.get(row, uvTitle...)
+		if title == "" {
+			result.fail(line(i), id, "Suggestion has no title.")
+			continue
+		}
+		status, ok := parseStatus(table.get(row, uvStatus...))
+		if !ok {
+			result.fail(line(i), id, "Status '%s' is unknown.", table.get(row, uvStatus...))
+			continue
+		}
+		createdAt, ok := parseTime(table.get(row, uvCreatedAt...))
+		if !ok {
+			result.fail(line(i), id, "Date '%s' is invalid.", table.get(row, uvCreatedAt...))
+			continue
+		}
+
+		record := &Record{
+			Row:         line(i),
+			Kind:        KindPost,
+			SourceID:    id,
+			Author:      newAuthor(table.get(row, uvUserID...), table.get(row, uvUserName...), table.get(row, uvUserEmail...)),
+			CreatedAt:   createdAt,
+			Title:       title,
+			Description: table.get(row, uvBody...),
+			Status:      status,
+			Response:    table.get(row, uvResponse...),
+			Tags:        splitList(table.get(row, uvLabels...)),
+		}
+		if respondedAt, ok := parseTime(table.get(row, uvRespondedAt...)); ok && !respondedAt.IsZero() {
+			record.RespondedAt = &respondedAt
+		}
+		if category := table.get(row, uvCategory...); category != "" {
+			record.Tags = append(record.Tags, category)
+		}
+		result.Records = append(result.Records, record)
+	}
+	return result, nil
+}
+
+func parseUserVoiceComments(table *csvTable) (*Result, error) {
+	result := &Result{Records: make([]*Record, 0)}
+	for i, row := range table.rows {
+		id := table.get(row, uvCommentID...)
+		postID := table.get(row, uvSuggestionID...)
+		if postID == "" {
+			result.fail(line(i), id, "Comment has no suggestion id.")
+			continue
+		}
+		body := table.get(row, uvCommentBody...)
+		if body == "" {
+			result.fail(line(i), id, "Comment is empty.")
+			continue
+		}
+		createdAt, ok := parseTime(table.get(row, uvCreatedAt...))
+		if !ok {
+			result.fail(line(i), id, "Date '%s' is invalid.", table.get(row, uvCreatedAt...))
+			continue
+		}
+
+		result.Records = append(result.Records, &Record{
+			Row:          line(i),
+			Kind:         KindComment,
+			SourceID:     id,
+			PostSourceID: postID,
+			Author:       newAuthor(table.get(row, uvUserID...), table.get(row, uvUserName...), table.get(row, uvUserEmail...)),
+			CreatedAt:    createdAt,
+			Content:      body,
+		})
+	}
+	return result, nil
+}
+
+func parseUserVoiceSupporters(table *csvTable) (*Result, error) {
+	result := &Result{Records: make([]*Record, 0)}
+	for i, row := range table.rows {
+		postID := table.get(row, uvSuggestionID...)
+		if postID == "" {
+			result.fail(line(i), "", "Supporter has no suggestion id.")
+			continue
+		}
+		voter := newAuthor(table.get(row, uvSupporterID...), table.get(row, uvSupporter...), table.get(row, uvSupporterEml...))
+		if voter.IsEmpty() {
+			result.fail(line(i), postID, "Supporter has no name or email.")
+			continue
+		}
+		createdAt, ok := parseTime(table.get(row, uvCreatedAt...))
+		if !ok {
+			result.fail(line(i), postID, "Date '%s' is invalid.", table.get(row, uvCreatedAt...))
+			continue
+		}
+
+		result.Records = append(result.Records, &Record{
+			Row:          line(i),
+			Kind:         KindVote,
+			PostSourceID: postID,
+			Author:       voter,
+			CreatedAt:    createdAt,
+		})
+	}
+	return result, nil
+}
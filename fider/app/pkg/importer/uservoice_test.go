@@ -0,0 +1,65 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/importer"
+)
+
+func TestParse_UserVoice_Suggestions(t *testing.T) {
+	RegisterT(t)
+
+	content := []byte("Suggestion ID,Suggestion Title,Suggestion Body,Created At,Creator Name,Creator Email,Status,Status Response,Category\n" +
+		"10,Dark mode,Please,2023-04-05 10:00:00,Jon Snow,jon@snow.com,completed,Shipped it,UI\n" +
+		"11,,Empty title,2023-04-05 10:00:00,Jon Snow,jon@snow.com,,,\n" +
+		"12,Offline,,yesterday,Arya,,,,\n")
+
+	result, err := importer.Parse(enum.ImportSourceUserVoice, content, nil)
+	Expect(err).IsNil()
+	Expect(result.Records).HasLen(1)
+	Expect(result.Errors).HasLen(2)
+
+	post := result.Records[0]
+	Expect(post.Kind).Equals(importer.KindPost)
+	Expect(post.Row).Equals(2)
+	Expect(post.SourceID).Equals("10")
+	Expect(post.Status).Equals(enum.PostCompleted)
+	Expect(post.Response).Equals("Shipped it")
+	Expect(post.Tags).Equals([]string{"UI"})
+
+	Expect(result.Errors[0].Row).Equals(3)
+	Expect(result.Errors[1].Message).Equals("Date 'yesterday' is invalid.")
+}
+
+func TestParse_UserVoice_CommentsAndSupporters(t *testing.T) {
+	RegisterT(t)
+
+	comments := []byte("Comment ID,Suggestion ID,Comment Body,Created At,Creator Name,Creator Email\n" +
+		"100,10,Great news,2023-04-06,Arya,\n")
+	result, err := importer.Parse(enum.ImportSourceUserVoice, comments, nil)
+	Expect(err).IsNil()
+	Expect(result.Records).HasLen(1)
+	Expect(result.Records[0].Kind).Equals(importer.KindComment)
+	Expect(result.Records[0].PostSourceID).Equals("10")
+	Expect(result.Records[0].Author.SourceID).Equals("name:arya")
+
+	supporters := []byte("Suggestion ID,Supporter Name,Supporter Email,Created At\n" +
+		"10,Jon Snow,jon@snow.com,2023-04-06\n" +
+		"10,,,2023-04-06\n")
+	result, err = importer.Parse(enum.ImportSourceUserVoice, supporters, nil)
+	Expect(err).IsNil()
+	Expect(result.Records).HasLen(1)
+	Expect(result.Records[0].Kind).Equals(importer.KindVote)
+	Expect(result.Records[0].Author.Email).Equals("jon@snow.com")
+	Expect(result.Errors).HasLen(1)
+}
+
+func TestParse_UserVoice_UnknownFile(t *testing.T) {
+	RegisterT(t)
+
+	_, err := importer.Parse(enum.ImportSourceUserVoice, []byte("a,b\n1,2\n"), nil)
+	_, isFileError := err.(*importer.FileError)
+	Expect(isFileError).IsTrue()
+}
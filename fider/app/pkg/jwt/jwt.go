@@ -31,6 +31,15 @@ type FiderClaims struct {
 	UserName  string `json:"user/name"`
 	UserEmail string `json:"user/email"`
 	Origin    string `json:"origin"`
+
+	// TwoFactorVerifiedAt is when the user last verified their second factor
+	TwoFactorVerifiedAt *jwtgo.NumericDate `json:"2fa/verified_at,omitempty"`
+	Metadata
+}
+
+// TwoFactorClaims represents what goes into temporary JWT tokens used while the second factor is pending
+type TwoFactorClaims struct {
+	UserID int `json:"2fa/user_id"`
 	Metadata
 }
 
@@ -71,6 +80,16 @@ func DecodeFiderClaims(token string) (*FiderClaims, error) {
 	return claims, nil
 }
 
+// DecodeTwoFactorClaims extract TwoFactorClaims from given JWT token
+func DecodeTwoFactorClaims(token string) (*TwoFactorClaims, error) {
+	claims := &TwoFactorClaims{}
+	err := decode(token, claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode TwoFactor claims")
+	}
+	return claims, nil
+}
+
 // DecodeOAuthClaims extract OAuthClaims from given JWT token
 func DecodeOAuthClaims(token string) (*OAuthClaims, error) {
 	claims := &OAuthClaims{}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchWriter buffers entries in memory and writes them in batches, when a batch is full or when the flush interval elapses.
+// The buffer is bounded, entries are dropped when it's full so that logging never blocks the caller
+type BatchWriter struct {
+	entries  chan *Entry
+	size     int
+	interval time.Duration
+	write    func(entries []*Entry) error
+	dropped  atomic.Int64
+	done     chan struct{}
+	closed   bool
+	lock     sync.RWMutex
+}
+
+// NewBatchWriter starts a writer that calls write with up to size entries at a time, buffering up to capacity entries
+func NewBatchWriter(size, capacity int, interval time.Duration, write func(entries []*Entry) error) *BatchWriter {
+	if size <= 0 {
+		size = 1
+	}
+	if capacity < size {
+		capacity = size
+	}
+
+	w := &BatchWriter{
+		entries:  make(chan *Entry, capacity),
+		size:     size,
+		interval: interval,
+		write:    write,
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Add queues an entry to be written. Returns false if the entry was dropped because the buffer is full
+func (w *BatchWriter) Add(entry *Entry) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.closed {
+		return false
+	}
+
+	select {
+	case w.entries <- entry:
+		return true
+	default:
+		w.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped returns the number of entries that were dropped because the buffer was full
+func (w *BatchWriter) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Close writes all buffered entries and stops the writer. Entries added after Close are lost
+func (w *BatchWriter) Close() {
+	w.lock.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.lock.Unlock()
+	<-w.done
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	batch := make([]*Entry, 0, w.size)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.write(batch); err != nil {
+			// Errors can't be logged through the bus, as that would write them back into this writer
+			fmt.Fprintf(os.Stderr, "failed to write %d log entries: %s\n", len(batch), err.Error())
+		}
+		batch = make([]*Entry, 0, w.size)
+	}
+
+	for {
+		select {
+		case entry, ok := <-w.entries:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= w.size {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
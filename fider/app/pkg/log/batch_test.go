@@ -0,0 +1,83 @@
+package log_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+type batchRecorder struct {
+	sync.Mutex
+	batches [][]*log.Entry
+	block   chan struct{}
+}
+
+func (r *batchRecorder) write(entries []*log.Entry) error {
+	if r.block != nil {
+		<-r.block
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.batches = append(r.batches, entries)
+	return nil
+}
+
+func (r *batchRecorder) sizes() []int {
+	r.Lock()
+	defer r.Unlock()
+	sizes := make([]int, len(r.batches))
+	for i, batch := range r.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestBatchWriter_FlushWhenFull(t *testing.T) {
+	RegisterT(t)
+
+	recorder := &batchRecorder{}
+	writer := log.NewBatchWriter(2, 10, time.Hour, recorder.write)
+	for i := 0; i < 5; i++ {
+		Expect(writer.Add(&log.Entry{Format: "Hello"})).IsTrue()
+	}
+	writer.Close()
+
+	Expect(recorder.sizes()).Equals([]int{2, 2, 1})
+	Expect(writer.Add(&log.Entry{Format: "Hello"})).IsFalse()
+}
+
+func TestBatchWriter_FlushOnInterval(t *testing.T) {
+	RegisterT(t)
+
+	recorder := &batchRecorder{}
+	writer := log.NewBatchWriter(100, 100, 10*time.Millisecond, recorder.write)
+	defer writer.Close()
+
+	writer.Add(&log.Entry{Format: "Hello"})
+	Expect(func() bool {
+		return len(recorder.sizes()) == 1
+	}).EventuallyEquals(true)
+}
+
+func TestBatchWriter_DropWhenFull(t *testing.T) {
+	RegisterT(t)
+
+	recorder := &batchRecorder{block: make(chan struct{})}
+	writer := log.NewBatchWriter(1, 2, time.Hour, recorder.write)
+
+	added := 0
+	for i := 0; i < 10; i++ {
+		if writer.Add(&log.Entry{Format: "Hello"}) {
+			added++
+		}
+	}
+	Expect(added < 10).IsTrue()
+	Expect(writer.Dropped()).Equals(int64(10 - added))
+
+	close(recorder.block)
+	writer.Close()
+	Expect(recorder.sizes()).HasLen(added)
+}
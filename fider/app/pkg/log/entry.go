@@ -0,0 +1,69 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/env"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Entry is a log message along with the properties of its context, as written by log services
+type Entry struct {
+	Level     Level
+	Timestamp time.Time
+	Tag       string
+	// Format is the message, with placeholders such as @{Name}
+	Format string
+	Props  dto.Props
+}
+
+// NewEntry creates an entry for a message written on ctx. The properties of ctx and the ids of its trace
+// are merged into props. Both the message and props are redacted unless disabled. Returns nil when level is not enabled
+func NewEntry(ctx context.Context, level Level, format string, props dto.Props) *Entry {
+	if !IsEnabled(level) {
+		return nil
+	}
+
+	props = GetProperties(ctx).Merge(props)
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		props[PropertyKeyTraceID] = spanContext.TraceID().String()
+		props[PropertyKeySpanID] = spanContext.SpanID().String()
+	}
+	if env.Config.Log.Redact {
+		props = Redact(props)
+		format = RedactMessage(format)
+	}
+
+	tag := "???"
+	if value := props[PropertyKeyTag]; value != nil {
+		tag = fmt.Sprint(value)
+	}
+	delete(props, PropertyKeyTag)
+
+	return &Entry{
+		Level:     level,
+		Timestamp: time.Now(),
+		Tag:       tag,
+		Format:    format,
+		Props:     props,
+	}
+}
+
+// Message returns the text of the entry, with placeholders replaced by their values
+func (e *Entry) Message(colorize bool) string {
+	return Parse(e.Format, e.Props, colorize)
+}
+
+// Fields returns all properties of the entry along with its Level, Message, Tag and Timestamp, as written by structured logs
+func (e *Entry) Fields() dto.Props {
+	fields := e.Props.Merge(dto.Props{
+		"Level":        e.Level.String(),
+		"Message":      e.Message(false),
+		"Timestamp":    e.Timestamp.Format(time.RFC3339),
+		PropertyKeyTag: e.Tag,
+	})
+	return fields
+}
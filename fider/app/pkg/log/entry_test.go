@@ -0,0 +1,83 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/dto"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewEntry(t *testing.T) {
+	RegisterT(t)
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       "WEB",
+		log.PropertyKeyRequestID: "abc",
+	})
+	entry := log.NewEntry(ctx, log.INFO, "Hello @{Name}, your token is @{Token}", dto.Props{
+		"Name":  "jon@got.com",
+		"Token": "123",
+	})
+
+	Expect(entry.Level).Equals(log.INFO)
+	Expect(entry.Tag).Equals("WEB")
+	Expect(entry.Message(false)).Equals("Hello j***@got.com, your token is [REDACTED]")
+	Expect(entry.Props).Equals(dto.Props{
+		log.PropertyKeyRequestID: "abc",
+		"Name":                   "j***@got.com",
+		"Token":                  "[REDACTED]",
+	})
+
+	fields := entry.Fields()
+	Expect(fields["Level"]).Equals("INFO")
+	Expect(fields["Tag"]).Equals("WEB")
+	Expect(fields["RequestID"]).Equals("abc")
+	Expect(fields["Message"]).Equals("Hello j***@got.com, your token is [REDACTED]")
+	Expect(fields["Timestamp"]).IsNotEmpty()
+}
+
+func TestNewEntry_RedactsMessage(t *testing.T) {
+	RegisterT(t)
+
+	entry := log.NewEntry(context.Background(), log.ERROR, "failed to sign in jon@got.com with password=123456", nil)
+	Expect(entry.Message(false)).Equals("failed to sign in j***@got.com with password=[REDACTED]")
+}
+
+func TestNewEntry_WithoutRedaction(t *testing.T) {
+	RegisterT(t)
+	env.Config.Log.Redact = false
+
+	entry := log.NewEntry(context.Background(), log.WARN, "Hello @{Name}", dto.Props{
+		"Name": "jon@got.com",
+	})
+	Expect(entry.Tag).Equals("???")
+	Expect(entry.Message(false)).Equals("Hello jon@got.com")
+}
+
+func TestNewEntry_DisabledLevel(t *testing.T) {
+	RegisterT(t)
+	log.CurrentLevel = log.ERROR
+	defer func() { log.CurrentLevel = log.DEBUG }()
+
+	Expect(log.NewEntry(context.Background(), log.WARN, "Hello", nil) == nil).IsTrue()
+	Expect(log.NewEntry(context.Background(), log.ERROR, "Hello", nil) == nil).IsFalse()
+}
+
+func TestNewEntry_WithTrace(t *testing.T) {
+	RegisterT(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	entry := log.NewEntry(ctx, log.INFO, "Hello", nil)
+	Expect(entry.Props[log.PropertyKeyTraceID]).Equals("4bf92f3577b34da6a3ce929d0e0e4736")
+	Expect(entry.Props[log.PropertyKeySpanID]).Equals("00f067aa0ba902b7")
+}
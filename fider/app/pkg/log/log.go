@@ -2,6 +2,8 @@ package log
 
 import (
 	"context"
+	"hash/fnv"
+	"math/rand"
 	"strings"
 
 	"github.com/getfider/fider/app/models/cmd"
@@ -17,11 +19,15 @@ func IsEnabled(level Level) bool {
 }
 
 func Debug(ctx context.Context, message string) {
-	bus.Publish(ctx, &cmd.LogDebug{Message: message})
+	if IsEnabled(DEBUG) && isDebugSampled(ctx) {
+		bus.Publish(ctx, &cmd.LogDebug{Message: message})
+	}
 }
 
 func Debugf(ctx context.Context, message string, props dto.Props) {
-	bus.Publish(ctx, &cmd.LogDebug{Message: message, Props: props})
+	if IsEnabled(DEBUG) && isDebugSampled(ctx) {
+		bus.Publish(ctx, &cmd.LogDebug{Message: message, Props: props})
+	}
 }
 
 func Info(ctx context.Context, message string) {
@@ -47,3 +53,30 @@ func Error(ctx context.Context, err error) {
 func Errorf(ctx context.Context, message string, props dto.Props) {
 	bus.Publish(ctx, &cmd.LogError{Message: message, Props: props})
 }
+
+// Flush writes the entries buffered by log sinks. It's meant to be called right before the process exits,
+// as entries logged afterwards are only written by sinks that don't buffer them
+func Flush(ctx context.Context) {
+	bus.Publish(ctx, &cmd.FlushLogs{})
+}
+
+// isDebugSampled returns true if debug logs of ctx should be written, according to LOG_DEBUG_SAMPLE_RATE.
+// The decision is based on the context id, so that all debug logs of a request or task are either written or not
+func isDebugSampled(ctx context.Context) bool {
+	rate := env.Config.Log.DebugSampleRate
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+
+	contextID, ok := GetProperty(ctx, PropertyKeyContextID).(string)
+	if !ok || contextID == "" {
+		return rand.Float64() < rate
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(contextID))
+	return float64(hash.Sum32()%10000) < rate*10000
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/log"
 )
 
@@ -33,3 +36,30 @@ func TestLog_WithProperties(t *testing.T) {
 		"Age":  15,
 	})
 }
+
+func TestLog_DebugSampling(t *testing.T) {
+	RegisterT(t)
+
+	count := 0
+	bus.AddListener(func(ctx context.Context, c *cmd.LogDebug) {
+		count++
+	})
+
+	ctx := log.WithProperty(context.Background(), log.PropertyKeyContextID, "abc")
+
+	env.Config.Log.DebugSampleRate = 0
+	log.Debug(ctx, "Hello")
+	Expect(count).Equals(0)
+
+	env.Config.Log.DebugSampleRate = 1
+	log.Debug(ctx, "Hello")
+	Expect(count).Equals(1)
+
+	// all debug logs of a context are either written or not
+	env.Config.Log.DebugSampleRate = 0.5
+	count = 0
+	for i := 0; i < 10; i++ {
+		log.Debugf(ctx, "Hello @{Number}", dto.Props{"Number": i})
+	}
+	Expect(count == 0 || count == 10).IsTrue()
+}
@@ -18,6 +18,14 @@ const (
 	PropertyKeyTenantID = "TenantID"
 	// PropertyKeyTag is the tag of current logger
 	PropertyKeyTag = "Tag"
+	// PropertyKeyRequestID is the id of the request that is being handled, or that enqueued current task
+	PropertyKeyRequestID = "RequestID"
+	// PropertyKeyTaskName is the name of the task that is being executed
+	PropertyKeyTaskName = "TaskName"
+	// PropertyKeyTraceID is the id of the trace of current span
+	PropertyKeyTraceID = "TraceID"
+	// PropertyKeySpanID is the id of current span
+	PropertyKeySpanID = "SpanID"
 )
 
 func GetProperties(ctx context.Context) dto.Props {
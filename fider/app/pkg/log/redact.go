@@ -0,0 +1,77 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/getfider/fider/app/models/dto"
+)
+
+const redacted = "[REDACTED]"
+
+var secretKeyFinder = regexp.MustCompile(`(?i)(password|passphrase|secret|token|api_?key|authorization|cookie|credential|private_?key)`)
+var emailFinder = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+var secretValueFinder = regexp.MustCompile(`(?i)((?:password|passphrase|secret|token|api_?key|authorization|cookie|credential|private_?key)\w*["']?\s*[=:]\s*(?:(?:Bearer|Basic)\s+)?)("[^"]*"|'[^']*'|[^\s,;&]+)`)
+
+// Redact returns a copy of props where values of secret keys, such as passwords and tokens, are removed
+// and email addresses are masked, including those of nested props
+func Redact(props dto.Props) dto.Props {
+	result := make(dto.Props, len(props))
+	for key, value := range props {
+		result[key] = redactValue(key, value)
+	}
+	return result
+}
+
+// RedactMessage returns message with email addresses masked and values assigned to secret keys removed,
+// such as 'token=abc'. Error messages often carry them, as they are written without props
+func RedactMessage(message string) string {
+	message = secretValueFinder.ReplaceAllString(message, "${1}"+redacted)
+	return emailFinder.ReplaceAllStringFunc(message, maskEmail)
+}
+
+func redactValue(key string, value any) any {
+	if value == nil {
+		return nil
+	}
+	if key != "" && secretKeyFinder.MatchString(key) {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return emailFinder.ReplaceAllStringFunc(v, maskEmail)
+	case dto.Props:
+		return Redact(v)
+	case map[string]any:
+		return Redact(v)
+	case map[string]string:
+		result := make(map[string]string, len(v))
+		for k, item := range v {
+			result[k] = redactValue(k, item).(string)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = redactValue("", item)
+		}
+		return result
+	case []string:
+		result := make([]string, len(v))
+		for i, item := range v {
+			result[i] = redactValue("", item).(string)
+		}
+		return result
+	}
+	return value
+}
+
+// maskEmail keeps the first letter of the mailbox and the domain, so that logs can still be correlated
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return redacted
+	}
+	return email[:1] + "***" + email[at:]
+}
@@ -0,0 +1,64 @@
+package log_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/dto"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+func TestRedact(t *testing.T) {
+	RegisterT(t)
+
+	props := dto.Props{
+		"Name":     "Jon Snow",
+		"Password": "123456",
+		"api_key":  "abc",
+		"Message":  "Email sent to jon.snow@got.com and arya@got.com",
+		"Nested": dto.Props{
+			"AccessToken": "xyz",
+			"Email":       "jon@got.com",
+		},
+		"Headers": map[string]string{
+			"Authorization": "Bearer abc",
+			"Accept":        "application/json",
+		},
+		"Emails": []string{"jon@got.com", "not an email"},
+		"Values": []any{12, "arya@got.com"},
+		"Count":  3,
+		"Empty":  nil,
+	}
+
+	Expect(log.Redact(props)).Equals(dto.Props{
+		"Name":     "Jon Snow",
+		"Password": "[REDACTED]",
+		"api_key":  "[REDACTED]",
+		"Message":  "Email sent to j***@got.com and a***@got.com",
+		"Nested": dto.Props{
+			"AccessToken": "[REDACTED]",
+			"Email":       "j***@got.com",
+		},
+		"Headers": map[string]string{
+			"Authorization": "[REDACTED]",
+			"Accept":        "application/json",
+		},
+		"Emails": []string{"j***@got.com", "not an email"},
+		"Values": []any{12, "a***@got.com"},
+		"Count":  3,
+		"Empty":  nil,
+	})
+
+	// original props are not modified
+	Expect(props["Password"]).Equals("123456")
+}
+
+func TestRedactMessage(t *testing.T) {
+	RegisterT(t)
+
+	Expect(log.RedactMessage("Hello @{Name}")).Equals("Hello @{Name}")
+	Expect(log.RedactMessage("failed to send email to jon.snow@got.com")).Equals("failed to send email to j***@got.com")
+	Expect(log.RedactMessage("GET /verify?token=abc123&user=2 failed")).Equals("GET /verify?token=[REDACTED]&user=2 failed")
+	Expect(log.RedactMessage(`invalid config {"api_key": "xyz", "password":'p4ss'}`)).Equals(`invalid config {"api_key": [REDACTED], "password":[REDACTED]}`)
+	Expect(log.RedactMessage("Authorization: Bearer abc")).Equals("Authorization: Bearer [REDACTED]")
+}
@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+// ResolveMentions updates the name of each mention on given contents to the current name of the mentioned user,
+// so that mentions follow users who renamed themselves. All contents are resolved with a single query.
+// Mentions without an id, or of users that no longer exist, keep the name they were written with
+func ResolveMentions(ctx context.Context, contents ...*string) error {
+	ids := make([]int, 0)
+	seen := make(map[int]bool)
+	for _, content := range contents {
+		for _, mention := range entity.CommentString(*content).ParseMentions() {
+			if mention.UserID > 0 && !seen[mention.UserID] {
+				seen[mention.UserID] = true
+				ids = append(ids, mention.UserID)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	getNames := &query.GetUserNamesByIDs{UserIDs: ids}
+	if err := bus.Dispatch(ctx, getNames); err != nil {
+		return err
+	}
+
+	names := make(map[int]string, len(getNames.Result))
+	for _, user := range getNames.Result {
+		if user.Name != "" {
+			names[user.ID] = user.Name
+		}
+	}
+
+	for _, content := range contents {
+		*content = entity.CommentString(*content).ReplaceMentions(func(m entity.Mention) string {
+			if name, ok := names[m.UserID]; ok {
+				return entity.FormatMention(m.UserID, name)
+			}
+			if m.UserID > 0 {
+				return entity.FormatMention(m.UserID, m.Name)
+			}
+			return "@[" + m.Name + "]"
+		})
+	}
+	return nil
+}
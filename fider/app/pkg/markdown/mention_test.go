@@ -0,0 +1,47 @@
+package markdown_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/markdown"
+)
+
+func TestResolveMentions(t *testing.T) {
+	RegisterT(t)
+
+	var requestedIDs []int
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserNamesByIDs) error {
+		requestedIDs = q.UserIDs
+		q.Result = []*dto.UserNames{{ID: 12, Name: "Jon the Bastard"}}
+		return nil
+	})
+
+	description := "Ping @[Jon Snow]{12} and @[Arya Stark]{7}"
+	comment := "Also @[Jon Snow]{12} and @[Sansa Stark]"
+	err := markdown.ResolveMentions(context.Background(), &description, &comment)
+	Expect(err).IsNil()
+	Expect(requestedIDs).Equals([]int{12, 7})
+	Expect(description).Equals("Ping @[Jon the Bastard]{12} and @[Arya Stark]{7}")
+	Expect(comment).Equals("Also @[Jon the Bastard]{12} and @[Sansa Stark]")
+}
+
+func TestResolveMentions_WithoutIDs(t *testing.T) {
+	RegisterT(t)
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserNamesByIDs) error {
+		dispatched = true
+		return nil
+	})
+
+	content := "Thanks @[Jon Snow]"
+	err := markdown.ResolveMentions(context.Background(), &content)
+	Expect(err).IsNil()
+	Expect(dispatched).IsFalse()
+	Expect(content).Equals("Thanks @[Jon Snow]")
+}
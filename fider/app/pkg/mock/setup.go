@@ -53,6 +53,8 @@ func seed() {
 		Status:             enum.TenantActive,
 		IsEmailAuthAllowed: true,
 		IsFeedEnabled:      true,
+		AllowedFileTypes:   ".txt, .log, .csv, .json, .pdf",
+		FileQuotaMB:        100,
 	}
 	AvengersTenant = &entity.Tenant{
 		ID:        2,
@@ -39,8 +39,14 @@ func (w *Worker) WithBaseURL(baseURL string) *Worker {
 	return w
 }
 
-// Execute given task with current context
+// Execute given task with current context.
+// Tasks of a defined type are recreated from the JSON encoding of their payload first, as they are when stored
 func (w *Worker) Execute(task worker.Task) error {
+	task, err := worker.Reload(task)
+	if err != nil {
+		return err
+	}
+
 	task.OriginContext = context.Background()
 
 	if w.user != nil {
@@ -0,0 +1,209 @@
+package spam
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+var linkRegex = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
+func countLinks(content *Content) int {
+	return len(linkRegex.FindAllString(content.Title+" "+content.Text, -1))
+}
+
+// linkScorer gives points to content with many links, or with mostly links
+type linkScorer struct{}
+
+func (linkScorer) Name() string {
+	return "links"
+}
+
+func (linkScorer) Score(content *Content) []entity.SpamReason {
+	links := countLinks(content)
+	if links == 0 {
+		return nil
+	}
+
+	reasons := make([]entity.SpamReason, 0)
+	if links > 2 {
+		reasons = append(reasons, entity.SpamReason{
+			Points:      min((links-2)*2, 8),
+			Description: fmt.Sprintf("%d links", links),
+		})
+	}
+
+	words := len(strings.Fields(content.Title + " " + content.Text))
+	if links*5 >= words {
+		reasons = append(reasons, entity.SpamReason{
+			Points:      4,
+			Description: fmt.Sprintf("%d links in %d words", links, words),
+		})
+	}
+	return reasons
+}
+
+//go:embed phrases.txt
+var defaultPhrases string
+
+var (
+	phrasesOnce sync.Once
+	phrases     []*regexp.Regexp
+)
+
+// loadPhrases compiles the built-in phrases along with the ones of SPAM_PHRASES_FILE
+func loadPhrases() []*regexp.Regexp {
+	phrasesOnce.Do(func() {
+		lines := strings.Split(defaultPhrases, "\n")
+		if env.Config.Spam.PhrasesFile != "" {
+			content, err := os.ReadFile(env.Config.Spam.PhrasesFile)
+			if err != nil {
+				// scoring goes on with the built-in phrases, as content can't be rejected because of a misconfiguration
+				log.Error(context.Background(), errors.Wrap(err, "failed to read spam phrases from '%s'", env.Config.Spam.PhrasesFile))
+			} else {
+				lines = append(lines, strings.Split(string(content), "\n")...)
+			}
+		}
+
+		for _, line := range lines {
+			phrase := normalize(line)
+			if phrase == "" || strings.HasPrefix(phrase, "#") {
+				continue
+			}
+			phrases = append(phrases, regexp.MustCompile(`(^|\W)`+regexp.QuoteMeta(phrase)+`($|\W)`))
+		}
+	})
+	return phrases
+}
+
+// phraseScorer gives points to content containing phrases that are common on spam
+type phraseScorer struct{}
+
+func (phraseScorer) Name() string {
+	return "phrases"
+}
+
+func (phraseScorer) Score(content *Content) []entity.SpamReason {
+	text := normalize(content.Title + " " + content.Text)
+	matches := make([]string, 0)
+	for _, phrase := range loadPhrases() {
+		if match := phrase.FindString(text); match != "" {
+			matches = append(matches, fmt.Sprintf("'%s'", strings.TrimFunc(match, isNotWord)))
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	return []entity.SpamReason{{
+		Points:      min(len(matches)*4, 12),
+		Description: "contains " + strings.Join(matches, ", "),
+	}}
+}
+
+func isNotWord(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// velocityScorer gives points to users and IPs submitting a lot of content in a short time
+type velocityScorer struct{}
+
+func (velocityScorer) Name() string {
+	return "velocity"
+}
+
+func (velocityScorer) Score(content *Content) []entity.SpamReason {
+	if content.Signals == nil {
+		return nil
+	}
+
+	window := env.Config.Spam.VelocityWindow
+	reasons := make([]entity.SpamReason, 0)
+	if points := velocityPoints(content.Signals.RecentByUser, 5); points > 0 {
+		reasons = append(reasons, entity.SpamReason{
+			Points:      points,
+			Description: fmt.Sprintf("%d submissions by this user in the last %s", content.Signals.RecentByUser, window),
+		})
+	}
+	if points := velocityPoints(content.Signals.RecentByIP, 10); points > 0 {
+		reasons = append(reasons, entity.SpamReason{
+			Points:      points,
+			Description: fmt.Sprintf("%d submissions from %s in the last %s", content.Signals.RecentByIP, content.IP, window),
+		})
+	}
+	return reasons
+}
+
+// velocityPoints gives 5 points once count reaches limit and 10 points once it reaches twice the limit
+func velocityPoints(count, limit int) int {
+	if count >= limit*2 {
+		return 10
+	} else if count >= limit {
+		return 5
+	}
+	return 0
+}
+
+// duplicateScorer gives points to content that has been submitted before, ignoring short content such as "+1"
+type duplicateScorer struct{}
+
+func (duplicateScorer) Name() string {
+	return "duplicate"
+}
+
+func (duplicateScorer) Score(content *Content) []entity.SpamReason {
+	if content.Signals == nil || content.Signals.DuplicateCount == 0 || len(normalize(content.Title+" "+content.Text)) < 20 {
+		return nil
+	}
+
+	points := 6
+	if content.Signals.DuplicateCount >= 3 {
+		points = 10
+	}
+	return []entity.SpamReason{{
+		Points:      points,
+		Description: fmt.Sprintf("same content submitted %d times before", content.Signals.DuplicateCount),
+	}}
+}
+
+// newAccountScorer gives points to content of accounts created recently, more so when it has links
+type newAccountScorer struct{}
+
+func (newAccountScorer) Name() string {
+	return "new_account"
+}
+
+func (newAccountScorer) Score(content *Content) []entity.SpamReason {
+	if content.Signals == nil || content.Signals.AccountCreatedAt.IsZero() {
+		return nil
+	}
+
+	age := content.Now.Sub(content.Signals.AccountCreatedAt)
+	points := 0
+	if age < time.Hour {
+		points = 3
+	} else if age < 24*time.Hour {
+		points = 2
+	}
+	if points == 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("account created %s ago", age.Round(time.Minute))
+	if countLinks(content) > 0 {
+		points += 3
+		description += " and content has links"
+	}
+	return []entity.SpamReason{{Points: points, Description: description}}
+}
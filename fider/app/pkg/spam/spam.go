@@ -0,0 +1,146 @@
+package spam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+// Content is a new post or comment to be scored
+type Content struct {
+	Title string
+	Text  string
+	User  *entity.User
+	IP    string
+	Now   time.Time
+	// Signals is the recent activity of the user, the IP and the content
+	Signals *entity.SpamSignals
+}
+
+// Scorer gives points to content that looks like spam or abuse.
+// Scorers only look at the content and its signals, so they never depend on external services
+type Scorer interface {
+	Name() string
+	Score(content *Content) []entity.SpamReason
+}
+
+var (
+	mu      sync.RWMutex
+	scorers []Scorer
+)
+
+func init() {
+	Register(linkScorer{})
+	Register(phraseScorer{})
+	Register(velocityScorer{})
+	Register(duplicateScorer{})
+	Register(newAccountScorer{})
+}
+
+// Register adds a scorer to the pipeline, replacing any scorer with the same name
+func Register(scorer Scorer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, s := range scorers {
+		if s.Name() == scorer.Name() {
+			scorers[i] = scorer
+			return
+		}
+	}
+	scorers = append(scorers, scorer)
+}
+
+// Evaluate scores new content of current user. Content of users that don't require moderation is not scored,
+// in which case the returned score is nil, just like when SPAM_THRESHOLD is 0
+func Evaluate(ctx context.Context, title, text string, user *entity.User, ip string) (*entity.SpamScore, error) {
+	if env.Config.Spam.Threshold <= 0 || user == nil || !user.RequiresModeration() {
+		return nil, nil
+	}
+
+	now := time.Now()
+	hash := ContentHash(title, text)
+	getSignals := &query.GetSpamSignals{
+		UserID:      user.ID,
+		IP:          ip,
+		ContentHash: hash,
+		Since:       now.Add(-env.Config.Spam.VelocityWindow),
+	}
+	if err := bus.Dispatch(ctx, getSignals); err != nil {
+		return nil, err
+	}
+
+	score := Score(&Content{
+		Title:   title,
+		Text:    text,
+		User:    user,
+		IP:      ip,
+		Now:     now,
+		Signals: getSignals.Result,
+	})
+	score.ContentHash = hash
+	score.IP = ip
+	return score, nil
+}
+
+// Score runs every scorer on given content, flagging it when the total reaches SPAM_THRESHOLD
+func Score(content *Content) *entity.SpamScore {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	score := &entity.SpamScore{Reasons: make([]entity.SpamReason, 0)}
+	for _, scorer := range scorers {
+		for _, reason := range scorer.Score(content) {
+			if reason.Points > 0 {
+				reason.Scorer = scorer.Name()
+				score.Score += reason.Points
+				score.Reasons = append(score.Reasons, reason)
+			}
+		}
+	}
+	score.IsFlagged = env.Config.Spam.Threshold > 0 && score.Score >= env.Config.Spam.Threshold
+	return score
+}
+
+// Hold flags content for moderation because it matched given word filters, whatever its score is.
+// Content that wasn't scored gets a score of its own, so that moderators can see which filters held it
+func Hold(score *entity.SpamScore, title, text, ip string, filters []*entity.WordFilter) *entity.SpamScore {
+	if len(filters) == 0 {
+		return score
+	}
+
+	if score == nil {
+		score = &entity.SpamScore{
+			Reasons:     make([]entity.SpamReason, 0),
+			ContentHash: ContentHash(title, text),
+			IP:          ip,
+		}
+	}
+	for _, filter := range filters {
+		score.Reasons = append(score.Reasons, entity.SpamReason{
+			Scorer:      "word_filter",
+			Description: fmt.Sprintf("Matched %s word filter \"%s\"", filter.Action, filter.Pattern),
+		})
+	}
+	score.IsFlagged = true
+	return score
+}
+
+// ContentHash identifies content regardless of case and whitespace, so that reposts are detected
+func ContentHash(title, text string) string {
+	sum := sha256.Sum256([]byte(normalize(title + " " + text)))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
@@ -0,0 +1,139 @@
+package spam_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/spam"
+)
+
+var now = time.Date(2025, 12, 30, 12, 0, 0, 0, time.UTC)
+
+func scorers(score *entity.SpamScore) map[string]int {
+	result := make(map[string]int)
+	for _, reason := range score.Reasons {
+		result[reason.Scorer] += reason.Points
+	}
+	return result
+}
+
+func TestScore_CleanContent(t *testing.T) {
+	RegisterT(t)
+
+	score := spam.Score(&spam.Content{
+		Title:   "Add dark mode",
+		Text:    "It would be great to have a dark mode, see https://example.com/dark for an example",
+		Now:     now,
+		Signals: &entity.SpamSignals{RecentByUser: 1, AccountCreatedAt: now.Add(-30 * 24 * time.Hour)},
+	})
+	Expect(score.Score).Equals(0)
+	Expect(score.IsFlagged).IsFalse()
+	Expect(score.Reasons).HasLen(0)
+}
+
+func TestScore_SpamContent(t *testing.T) {
+	RegisterT(t)
+
+	score := spam.Score(&spam.Content{
+		Title:   "BUY NOW",
+		Text:    "Online Casino! http://a.example http://b.example http://c.example",
+		IP:      "10.0.0.1",
+		Now:     now,
+		Signals: &entity.SpamSignals{RecentByUser: 6, RecentByIP: 20, AccountCreatedAt: now.Add(-10 * time.Minute)},
+	})
+	Expect(score.IsFlagged).IsTrue()
+	Expect(scorers(score)).Equals(map[string]int{
+		"links":       2 + 4,
+		"phrases":     8,
+		"velocity":    5 + 10,
+		"new_account": 3 + 3,
+	})
+	Expect(score.Score).Equals(35)
+}
+
+func TestScore_Duplicates(t *testing.T) {
+	RegisterT(t)
+
+	content := &spam.Content{Text: "+1", Now: now, Signals: &entity.SpamSignals{DuplicateCount: 5}}
+	Expect(spam.Score(content).Score).Equals(0)
+
+	content.Text = "Please add this feature, we really need it"
+	Expect(scorers(spam.Score(content))).Equals(map[string]int{"duplicate": 10})
+
+	content.Signals.DuplicateCount = 1
+	Expect(scorers(spam.Score(content))).Equals(map[string]int{"duplicate": 6})
+}
+
+func TestContentHash(t *testing.T) {
+	RegisterT(t)
+
+	Expect(spam.ContentHash("Hello", "World  again")).Equals(spam.ContentHash("hello", " world\nagain "))
+	Expect(spam.ContentHash("Hello", "World")).NotEquals(spam.ContentHash("Hello", "Word"))
+}
+
+type blocklistScorer struct{}
+
+func (blocklistScorer) Name() string {
+	return "blocklist"
+}
+
+func (blocklistScorer) Score(content *spam.Content) []entity.SpamReason {
+	if content.IP == "10.6.6.6" {
+		return []entity.SpamReason{{Points: 100, Description: "blocked IP"}}
+	}
+	return nil
+}
+
+func TestEvaluate(t *testing.T) {
+	RegisterT(t)
+	spam.Register(blocklistScorer{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetSpamSignals) error {
+		Expect(q.UserID).Equals(1)
+		q.Result = &entity.SpamSignals{AccountCreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+		return nil
+	})
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	score, err := spam.Evaluate(context.Background(), "Add dark mode", "Please", visitor, "10.6.6.6")
+	Expect(err).IsNil()
+	Expect(score.IsFlagged).IsTrue()
+	Expect(score.IP).Equals("10.6.6.6")
+	Expect(score.ContentHash).Equals(spam.ContentHash("Add dark mode", "Please"))
+	Expect(score.Reasons).Equals([]entity.SpamReason{{Scorer: "blocklist", Points: 100, Description: "blocked IP"}})
+
+	score, err = spam.Evaluate(context.Background(), "Add dark mode", "Please", visitor, "10.0.0.1")
+	Expect(err).IsNil()
+	Expect(score.IsFlagged).IsFalse()
+
+	trusted := &entity.User{ID: 2, Role: enum.RoleVisitor, IsTrusted: true}
+	score, err = spam.Evaluate(context.Background(), "Add dark mode", "Please", trusted, "10.6.6.6")
+	Expect(err).IsNil()
+	Expect(score).IsNil()
+}
+
+func TestHold(t *testing.T) {
+	RegisterT(t)
+
+	filters := []*entity.WordFilter{{ID: 1, Pattern: "acme", Action: enum.WordFilterModerate}}
+
+	Expect(spam.Hold(nil, "Title", "Text", "10.0.0.1", nil)).IsNil()
+
+	score := spam.Hold(nil, "Title", "Acme", "10.0.0.1", filters)
+	Expect(score.IsFlagged).IsTrue()
+	Expect(score.Score).Equals(0)
+	Expect(score.IP).Equals("10.0.0.1")
+	Expect(score.ContentHash).Equals(spam.ContentHash("Title", "Acme"))
+	Expect(score.Reasons).Equals([]entity.SpamReason{{Scorer: "word_filter", Description: `Matched moderate word filter "acme"`}})
+
+	score = spam.Hold(&entity.SpamScore{Score: 4, Reasons: []entity.SpamReason{{Scorer: "links", Points: 4}}}, "Title", "Acme", "10.0.0.1", filters)
+	Expect(score.IsFlagged).IsTrue()
+	Expect(score.Score).Equals(4)
+	Expect(score.Reasons).HasLen(2)
+}
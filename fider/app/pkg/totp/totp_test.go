@@ -0,0 +1,86 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/totp"
+)
+
+// RFC 6238 test secret for HMAC-SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	RegisterT(t)
+
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+		Expect(err).IsNil()
+		Expect(code).Equals(expected)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Unix(1111111111, 0)
+	step, ok := totp.Validate(rfcSecret, "050471", now)
+	Expect(ok).IsTrue()
+	Expect(step).Equals(totp.Step(now))
+
+	// previous and next periods are accepted
+	previous, _ := totp.Code(rfcSecret, now.Add(-totp.Period*time.Second))
+	step, ok = totp.Validate(rfcSecret, previous, now)
+	Expect(ok).IsTrue()
+	Expect(step).Equals(totp.Step(now) - 1)
+
+	next, _ := totp.Code(rfcSecret, now.Add(totp.Period*time.Second))
+	_, ok = totp.Validate(rfcSecret, " "+next[:3]+" "+next[3:]+" ", now)
+	Expect(ok).IsTrue()
+
+	// codes outside of the skew window are rejected
+	old, _ := totp.Code(rfcSecret, now.Add(-3*totp.Period*time.Second))
+	_, ok = totp.Validate(rfcSecret, old, now)
+	Expect(ok).IsFalse()
+
+	for _, code := range []string{"", "12345", "1234567", "abcdef"} {
+		_, ok = totp.Validate(rfcSecret, code, now)
+		Expect(ok).IsFalse()
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	RegisterT(t)
+
+	secret1, err := totp.GenerateSecret()
+	Expect(err).IsNil()
+	secret2, err := totp.GenerateSecret()
+	Expect(err).IsNil()
+
+	Expect(secret1).HasLen(32)
+	Expect(secret1).NotEquals(secret2)
+
+	code, err := totp.Code(secret1, time.Now())
+	Expect(err).IsNil()
+	_, ok := totp.Validate(secret1, code, time.Now())
+	Expect(ok).IsTrue()
+}
+
+func TestURI(t *testing.T) {
+	RegisterT(t)
+
+	uri := totp.URI("Demo Tenant", "jon.snow@got.com", "JBSWY3DPEHPK3PXP")
+	Expect(strings.HasPrefix(uri, "otpauth://totp/Demo%20Tenant:jon.snow@got.com?")).IsTrue()
+	Expect(uri).ContainsSubstring("secret=JBSWY3DPEHPK3PXP")
+	Expect(uri).ContainsSubstring("issuer=Demo+Tenant")
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/getfider/fider"
+
+var provider *sdktrace.TracerProvider
+var tracer = noop.NewTracerProvider().Tracer(instrumentationName)
+var propagator = propagation.TraceContext{}
+
+// Init configures the tracer provider that exports spans to an OTLP/HTTP collector.
+// It does nothing unless tracing is enabled. Errors of the exporter are sent to handleError
+func Init(handleError func(err error)) error {
+	if !env.Config.Tracing.Enabled {
+		return nil
+	}
+
+	endpoint := exporterEndpoint()
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid OTLP endpoint '%s'", endpoint)
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(endpoint),
+		otlptracehttp.WithHeaders(parseHeaders(env.Config.Tracing.Headers)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create trace exporter")
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", env.Config.Tracing.ServiceName),
+		attribute.String("service.version", env.Version()),
+		attribute.String("deployment.environment.name", env.Config.Environment),
+	)
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(env.Config.Tracing.SampleRatio))),
+	)
+
+	if handleError != nil {
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(handleError))
+	}
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	tracer = provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(env.Version()))
+	return nil
+}
+
+// Shutdown exports all pending spans and stops the tracer provider
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+
+	err := provider.Shutdown(ctx)
+	provider = nil
+	tracer = noop.NewTracerProvider().Tracer(instrumentationName)
+	return err
+}
+
+// IsEnabled returns true if spans are being recorded
+func IsEnabled() bool {
+	return provider != nil
+}
+
+// Start creates a span and a context containing it. The span is a child of the span in ctx, if any
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, opts...)
+}
+
+// StartChild creates a span only when ctx already contains a recorded span, so that
+// low level operations such as SQL queries don't start traces of their own
+func StartChild(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, parent
+	}
+	return tracer.Start(ctx, name, opts...)
+}
+
+// End marks the span as failed when err is not nil and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// WithParent returns a copy of ctx whose span is the span of origin, which is
+// how the trace of a request continues on tasks that run after it has finished
+func WithParent(ctx context.Context, origin context.Context) context.Context {
+	spanContext := trace.SpanContextFromContext(origin)
+	if !spanContext.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, spanContext)
+}
+
+// Extract returns a copy of ctx with the remote span sent on the headers of an incoming request
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject adds the span of ctx to the headers of an outgoing request
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// exporterEndpoint returns the URL spans are sent to, following the OTLP exporter conventions
+func exporterEndpoint() string {
+	if env.Config.Tracing.TracesEndpoint != "" {
+		return env.Config.Tracing.TracesEndpoint
+	}
+	return strings.TrimSuffix(env.Config.Tracing.Endpoint, "/") + "/v1/traces"
+}
+
+// parseHeaders parses a list of key=value pairs separated by commas, whose values are URL encoded
+func parseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			headers[key] = unescaped
+		}
+	}
+	return headers
+}
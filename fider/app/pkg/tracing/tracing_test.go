@@ -0,0 +1,206 @@
+package tracing_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// attributeOf returns the value of an attribute of the span, or nil if it isn't set
+func attributeOf(span *tracepb.Span, key string) any {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			switch v := attr.Value.Value.(type) {
+			case *commonpb.AnyValue_StringValue:
+				return v.StringValue
+			case *commonpb.AnyValue_IntValue:
+				return v.IntValue
+			case *commonpb.AnyValue_BoolValue:
+				return v.BoolValue
+			}
+		}
+	}
+	return nil
+}
+
+type collector struct {
+	sync.Mutex
+	spans   []*tracepb.Span
+	headers http.Header
+}
+
+func (c *collector) span(name string) *tracepb.Span {
+	c.Lock()
+	defer c.Unlock()
+	for _, s := range c.spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	return &tracepb.Span{}
+}
+
+// startCollector starts an OTLP/HTTP server and initializes tracing to export into it
+func startCollector(t *testing.T) *collector {
+	c := &collector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.URL.Path).Equals("/v1/traces")
+		Expect(r.Header.Get("Content-Type")).Equals("application/x-protobuf")
+		body, err := io.ReadAll(r.Body)
+		Expect(err).IsNil()
+		payload := &coltracepb.ExportTraceServiceRequest{}
+		Expect(proto.Unmarshal(body, payload)).IsNil()
+
+		c.Lock()
+		defer c.Unlock()
+		c.headers = r.Header
+		for _, rs := range payload.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				c.spans = append(c.spans, ss.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	env.Config.Tracing.Enabled = true
+	env.Config.Tracing.Endpoint = server.URL
+	env.Config.Tracing.Headers = "X-Api-Key=abc%20123,Invalid"
+	Expect(tracing.Init(nil)).IsNil()
+	Expect(tracing.IsEnabled()).IsTrue()
+	return c
+}
+
+func TestTracing_Disabled(t *testing.T) {
+	RegisterT(t)
+
+	Expect(tracing.Init(nil)).IsNil()
+	Expect(tracing.IsEnabled()).IsFalse()
+
+	ctx, span := tracing.Start(context.Background(), "test")
+	Expect(span.IsRecording()).IsFalse()
+	Expect(ctx).IsNotNil()
+	tracing.End(span, nil)
+}
+
+func TestTracing_InvalidEndpoint(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Tracing.Enabled = true
+	env.Config.Tracing.TracesEndpoint = "localhost:4318"
+	Expect(tracing.Init(nil)).IsNotNil()
+	Expect(tracing.IsEnabled()).IsFalse()
+}
+
+func TestTracing_ExportSpans(t *testing.T) {
+	RegisterT(t)
+	c := startCollector(t)
+
+	ctx, parent := tracing.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+		attribute.String("http.route", "/posts/:number"),
+		attribute.Int("http.response.status_code", 200),
+		attribute.Bool("cached", true),
+	))
+	_, child := tracing.StartChild(ctx, "child")
+	tracing.End(child, errors.New("something went wrong"))
+	tracing.End(parent, nil)
+
+	Expect(tracing.Shutdown(context.Background())).IsNil()
+	Expect(tracing.IsEnabled()).IsFalse()
+
+	Expect(c.headers.Get("X-Api-Key")).Equals("abc 123")
+	Expect(c.spans).HasLen(2)
+
+	p := c.span("parent")
+	Expect(p.TraceId).HasLen(16)
+	Expect(p.SpanId).HasLen(8)
+	Expect(p.ParentSpanId).HasLen(0)
+	Expect(p.Kind).Equals(tracepb.Span_SPAN_KIND_SERVER)
+	Expect(p.Status.GetCode()).Equals(tracepb.Status_STATUS_CODE_UNSET)
+	Expect(attributeOf(p, "http.route")).Equals("/posts/:number")
+	Expect(attributeOf(p, "http.response.status_code")).Equals(int64(200))
+	Expect(attributeOf(p, "cached")).Equals(true)
+
+	ch := c.span("child")
+	Expect(ch.TraceId).Equals(p.TraceId)
+	Expect(ch.ParentSpanId).Equals(p.SpanId)
+	Expect(ch.Kind).Equals(tracepb.Span_SPAN_KIND_INTERNAL)
+	Expect(ch.Status.GetCode()).Equals(tracepb.Status_STATUS_CODE_ERROR)
+	Expect(ch.Status.GetMessage()).ContainsSubstring("something went wrong")
+}
+
+func TestTracing_StartChild_WithoutParent(t *testing.T) {
+	RegisterT(t)
+	c := startCollector(t)
+
+	_, span := tracing.StartChild(context.Background(), "orphan")
+	Expect(span.IsRecording()).IsFalse()
+	tracing.End(span, nil)
+
+	Expect(tracing.Shutdown(context.Background())).IsNil()
+	Expect(c.spans).HasLen(0)
+}
+
+func TestTracing_Propagation(t *testing.T) {
+	RegisterT(t)
+	c := startCollector(t)
+
+	ctx, span := tracing.Start(context.Background(), "request")
+	header := http.Header{}
+	tracing.Inject(ctx, header)
+	Expect(header.Get("traceparent")).IsNotEmpty()
+
+	remote := tracing.Extract(context.Background(), header)
+	Expect(trace.SpanContextFromContext(remote).TraceID()).Equals(span.SpanContext().TraceID())
+
+	taskCtx, task := tracing.Start(tracing.WithParent(context.Background(), ctx), "task")
+	Expect(taskCtx).IsNotNil()
+	tracing.End(task, nil)
+	tracing.End(span, nil)
+
+	Expect(tracing.Shutdown(context.Background())).IsNil()
+	Expect(c.span("task").ParentSpanId).Equals(c.span("request").SpanId)
+}
+
+type sayHello struct {
+	Name string
+}
+
+func TestTracing_BusMessages(t *testing.T) {
+	RegisterT(t)
+	c := startCollector(t)
+
+	bus.AddHandler(func(ctx context.Context, q *sayHello) error {
+		_, span := tracing.StartChild(ctx, "inner")
+		tracing.End(span, nil)
+		return errors.New("no one to say hello to")
+	})
+
+	ctx, span := tracing.Start(context.Background(), "request")
+	err := bus.Dispatch(ctx, &sayHello{})
+	Expect(err).IsNotNil()
+	tracing.End(span, nil)
+
+	Expect(tracing.Shutdown(context.Background())).IsNil()
+	Expect(c.spans).HasLen(3)
+
+	dispatch := c.span("bus.Dispatch tracing_test.sayHello")
+	Expect(dispatch.ParentSpanId).Equals(c.span("request").SpanId)
+	Expect(dispatch.Status.GetCode()).Equals(tracepb.Status_STATUS_CODE_ERROR)
+	Expect(attributeOf(dispatch, "fider.bus.message")).Equals("github.com/getfider/fider/app/pkg/tracing_test.sayHello")
+	Expect(c.span("inner").ParentSpanId).Equals(dispatch.SpanId)
+}
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/i18n"
+)
+
+var fileExtensionRegex = regexp.MustCompile(`^\.[a-z0-9]+$`)
+var mimeTypeRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.+-]*/([a-z0-9][a-z0-9.+-]*|\*)$`)
+
+// FileUploadOpts arguments to validate given file upload
+type FileUploadOpts struct {
+	// AllowedTypes is a comma separated list of extensions (.pdf) and MIME types (application/pdf or text/*)
+	AllowedTypes string
+	MaxKilobytes int
+	// AvailableBytes is how much is left on the quota of the tenant
+	AvailableBytes int64
+}
+
+// FileUpload validates given file upload. The content type is detected from the content itself,
+// replacing the one sent by the client
+func FileUpload(ctx context.Context, upload *dto.FileUpload, opts FileUploadOpts) []string {
+	if upload == nil || len(upload.Content) == 0 {
+		return []string{i18n.T(ctx, "validation.required", i18n.Params{"name": i18n.T(ctx, "property.file")})}
+	}
+
+	messages := []string{}
+
+	upload.FileName = strings.TrimSpace(upload.FileName)
+	if upload.FileName == "" || len(upload.FileName) > 255 {
+		messages = append(messages, i18n.T(ctx, "validation.custom.invalidfilename"))
+	}
+
+	size := int64(len(upload.Content))
+	if size > int64(opts.MaxKilobytes)*1024 {
+		messages = append(messages, i18n.T(ctx, "validation.custom.maxfilesize",
+			i18n.Params{"kilobytes": opts.MaxKilobytes},
+		))
+	} else if size > opts.AvailableBytes {
+		messages = append(messages, i18n.T(ctx, "validation.custom.filequotaexceeded"))
+	}
+
+	contentType := DetectContentType(upload.Content)
+	if !IsFileTypeAllowed(opts.AllowedTypes, upload.FileName, contentType) {
+		messages = append(messages, i18n.T(ctx, "validation.custom.unsupportedfileformat"))
+	}
+
+	upload.ContentType = contentType
+	return messages
+}
+
+// FileTypes validates a comma separated list of file extensions and MIME types
+func FileTypes(ctx context.Context, value string) []string {
+	messages := []string{}
+	for _, entry := range ParseFileTypes(value) {
+		if !fileExtensionRegex.MatchString(entry) && !mimeTypeRegex.MatchString(entry) {
+			messages = append(messages, i18n.T(ctx, "validation.custom.invalidfiletype", i18n.Params{"type": entry}))
+		}
+	}
+	return messages
+}
+
+// ParseFileTypes splits a list of file extensions and MIME types separated by commas or spaces
+func ParseFileTypes(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t' || r == '\r'
+	})
+}
+
+// DetectContentType returns the MIME type of given content, without parameters
+func DetectContentType(content []byte) string {
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	return contentType
+}
+
+// IsFileTypeAllowed returns true if both the extension of the file and its detected content type are in the allowlist.
+// Extensions can be allowed directly or through their MIME type, and the other way around for content types.
+// Plain text can't be told apart from logs, CSV or JSON files, so it's accepted for any allowed extension
+func IsFileTypeAllowed(allowedTypes, fileName, contentType string) bool {
+	allowed := ParseFileTypes(allowedTypes)
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == "" {
+		return false
+	}
+
+	extAllowed := false
+	extType := extensionType(ext)
+	for _, entry := range allowed {
+		if entry == ext || (extType != "" && matchesMIMEType(entry, extType)) {
+			extAllowed = true
+			break
+		}
+	}
+	if !extAllowed {
+		return false
+	}
+
+	if contentType == "text/plain" {
+		return true
+	}
+
+	for _, entry := range allowed {
+		if matchesMIMEType(entry, contentType) || (fileExtensionRegex.MatchString(entry) && extensionType(entry) == contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func extensionType(ext string) string {
+	contentType, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	return contentType
+}
+
+func matchesMIMEType(pattern, contentType string) bool {
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(contentType, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == contentType
+}
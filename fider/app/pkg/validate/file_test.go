@@ -0,0 +1,91 @@
+package validate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/dto"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+var pdfContent = []byte("%PDF-1.4\n%âãÏÓ\n1 0 obj\n<< /Type /Catalog >>\nendobj")
+var zipContent = []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00")
+
+func TestIsFileTypeAllowed(t *testing.T) {
+	RegisterT(t)
+
+	allowed := ".txt, .log, .csv, application/pdf"
+
+	var testCases = []struct {
+		fileName string
+		content  []byte
+		allowed  bool
+	}{
+		{"app.log", []byte("2025-12-01 ERROR failed"), true},
+		{"APP.LOG", []byte("2025-12-01 ERROR failed"), true},
+		{"repro.csv", []byte("id,name\n1,Jon"), true},
+		{"report.pdf", pdfContent, true},
+		{"report.PDF", pdfContent, true},
+		{"script.sh", []byte("#!/bin/sh\nrm -rf /"), false},
+		{"noextension", []byte("hello"), false},
+		{"page.log", []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), false},
+		{"archive.log", zipContent, false},
+		{"report.txt", pdfContent, true},
+		{"report.pdf", zipContent, false},
+	}
+
+	for _, testCase := range testCases {
+		contentType := validate.DetectContentType(testCase.content)
+		Expect(validate.IsFileTypeAllowed(allowed, testCase.fileName, contentType)).Equals(testCase.allowed)
+	}
+
+	Expect(validate.IsFileTypeAllowed(".zip, application/zip", "archive.zip", validate.DetectContentType(zipContent))).IsTrue()
+	Expect(validate.IsFileTypeAllowed("text/*", "noextension", "text/plain")).IsFalse()
+	Expect(validate.IsFileTypeAllowed("", "app.log", "text/plain")).IsFalse()
+}
+
+func TestFileTypes(t *testing.T) {
+	RegisterT(t)
+
+	Expect(validate.FileTypes(context.Background(), ".txt, .log\napplication/pdf text/*")).HasLen(0)
+	Expect(validate.FileTypes(context.Background(), "")).HasLen(0)
+	Expect(validate.FileTypes(context.Background(), "txt, .log, application/, */*, .tar.gz")).HasLen(4)
+	Expect(validate.ParseFileTypes(" .TXT,,.log \n text/CSV")).Equals([]string{".txt", ".log", "text/csv"})
+}
+
+func TestValidateFileUpload(t *testing.T) {
+	RegisterT(t)
+
+	opts := validate.FileUploadOpts{
+		AllowedTypes:   ".log, .pdf",
+		MaxKilobytes:   1,
+		AvailableBytes: 1000,
+	}
+
+	upload := &dto.FileUpload{FileName: " app.log ", ContentType: "application/pdf", Content: []byte("ERROR failed")}
+	Expect(validate.FileUpload(context.Background(), upload, opts)).HasLen(0)
+	Expect(upload.FileName).Equals("app.log")
+	Expect(upload.ContentType).Equals("text/plain")
+
+	upload = &dto.FileUpload{FileName: "report.pdf", Content: pdfContent}
+	Expect(validate.FileUpload(context.Background(), upload, opts)).HasLen(0)
+	Expect(upload.ContentType).Equals("application/pdf")
+
+	Expect(validate.FileUpload(context.Background(), nil, opts)).HasLen(1)
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "app.log"}, opts)).HasLen(1)
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "app.exe", Content: []byte("MZ")}, opts)).HasLen(1)
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "", Content: []byte("ERROR")}, opts)).HasLen(2)
+
+	large := make([]byte, 1025)
+	for i := range large {
+		large[i] = 'a'
+	}
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "app.log", Content: large}, opts)).HasLen(1)
+
+	opts.MaxKilobytes = 10
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "app.log", Content: large}, opts)).HasLen(1)
+
+	opts.AvailableBytes = 2000
+	Expect(validate.FileUpload(context.Background(), &dto.FileUpload{FileName: "app.log", Content: large}, opts)).HasLen(0)
+}
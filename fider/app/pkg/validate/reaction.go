@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getfider/fider/app/pkg/i18n"
+)
+
+// maxReactionEmojis is the largest palette of reactions a tenant can have
+const maxReactionEmojis = 20
+
+// ReactionEmojis validates a space separated palette of reaction emojis.
+// An empty palette is valid, as it means the default palette is used
+func ReactionEmojis(ctx context.Context, value string) []string {
+	messages := []string{}
+	emojis := strings.Fields(value)
+	if len(emojis) > maxReactionEmojis {
+		messages = append(messages, i18n.T(ctx, "validation.custom.maxreactionemojis", i18n.Params{"number": maxReactionEmojis}))
+	}
+
+	seen := make(map[string]bool)
+	for _, emoji := range emojis {
+		if !isEmoji(emoji) {
+			messages = append(messages, i18n.T(ctx, "validation.custom.invalidreactionemoji", i18n.Params{"emoji": emoji}))
+		} else if seen[emoji] {
+			messages = append(messages, i18n.T(ctx, "validation.custom.duplicatereactionemoji", i18n.Params{"emoji": emoji}))
+		}
+		seen[emoji] = true
+	}
+	return messages
+}
+
+// isEmoji returns true if value is a single short symbol, such as an emoji with its modifiers, without any letter, digit or punctuation
+func isEmoji(value string) bool {
+	if utf8.RuneCountInString(value) > 8 {
+		return false
+	}
+
+	for _, r := range value {
+		if r < utf8.RuneSelf || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
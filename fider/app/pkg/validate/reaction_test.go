@@ -0,0 +1,20 @@
+package validate_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+func TestReactionEmojis(t *testing.T) {
+	RegisterT(t)
+
+	Expect(validate.ReactionEmojis(context.Background(), "👍 👎 😄 🎉 😕 ❤️ 🚀 👀")).HasLen(0)
+	Expect(validate.ReactionEmojis(context.Background(), "👍🏽\n👨‍👩‍👧")).HasLen(0)
+	Expect(validate.ReactionEmojis(context.Background(), "")).HasLen(0)
+	Expect(validate.ReactionEmojis(context.Background(), "👍 +1 ok 😄 👍")).HasLen(3)
+	Expect(validate.ReactionEmojis(context.Background(), strings.Repeat("👍 ", 21))).HasLen(21)
+}
@@ -5,10 +5,12 @@ import (
 
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/img"
 	"github.com/goenning/imagic"
 )
 
 // MaxDimensionSize is the max width/height of an image. If image is bigger than this, it'll be resized.
+// Uploaded images are also rotated to their EXIF orientation and stripped of any metadata.
 const MaxDimensionSize = 1500
 
 // MultiImageUploadOpts arguments to validate mulitple image upload process
@@ -109,12 +111,13 @@ func ImageUpload(ctx context.Context, upload *dto.ImageUpload, opts ImageUploadO
 				))
 			}
 
-			if logo.Height > MaxDimensionSize && logo.Width > MaxDimensionSize {
-				newImageBytes, err := imagic.Apply(upload.Upload.Content, imagic.Resize(MaxDimensionSize))
+			if len(messages) == 0 {
+				normalized, err := img.Normalize(upload.Upload.Content, MaxDimensionSize)
 				if err != nil {
 					return nil, err
 				}
-				upload.Upload.Content = newImageBytes
+				upload.Upload.Content = normalized.Content
+				upload.Upload.ContentType = normalized.ContentType
 			}
 		}
 	}
@@ -1,7 +1,10 @@
 package validate_test
 
 import (
+	"bytes"
 	"context"
+	"image"
+	"image/png"
 	"os"
 	"testing"
 
@@ -46,6 +49,31 @@ func TestValidateImageUpload(t *testing.T) {
 	}
 }
 
+func TestValidateImageUpload_Normalize(t *testing.T) {
+	RegisterT(t)
+
+	buf := new(bytes.Buffer)
+	_ = png.Encode(buf, image.NewGray(image.Rect(0, 0, 3000, 1000)))
+
+	upload := &dto.ImageUpload{
+		Upload: &dto.ImageUploadData{
+			ContentType: "application/octet-stream",
+			Content:     buf.Bytes(),
+		},
+	}
+	messages, err := validate.ImageUpload(context.Background(), upload, validate.ImageUploadOpts{
+		MaxKilobytes: 500,
+	})
+	Expect(messages).HasLen(0)
+	Expect(err).IsNil()
+	Expect(upload.Upload.ContentType).Equals("image/png")
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(upload.Upload.Content))
+	Expect(err).IsNil()
+	Expect(config.Width).Equals(validate.MaxDimensionSize)
+	Expect(config.Height).Equals(500)
+}
+
 func TestValidateImageUpload_ExactRatio(t *testing.T) {
 	RegisterT(t)
 
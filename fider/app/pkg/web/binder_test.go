@@ -124,6 +124,25 @@ func TestDefaultBinder_Array_TrimSpaces(t *testing.T) {
 	})
 }
 
+func TestDefaultBinder_NamedStringTypes(t *testing.T) {
+	RegisterT(t)
+
+	type flavor string
+	type pizza struct {
+		Flavor   flavor   `json:"flavor" format:"lower"`
+		Toppings []flavor `json:"toppings" format:"lower"`
+	}
+
+	params := make(web.StringMap)
+	body := `{ "flavor": " Pepperoni ", "toppings": [ "Cheese ", " OLIVES" ] }`
+	ctx := newBodyContext("POST", params, body, "application/json")
+	u := new(pizza)
+	err := binder.Bind(u, ctx)
+	Expect(err).IsNil()
+	Expect(u.Flavor).Equals(flavor("pepperoni"))
+	Expect(u.Toppings).Equals([]flavor{"cheese", "olives"})
+}
+
 func TestDefaultBinder_DELETE(t *testing.T) {
 	RegisterT(t)
 
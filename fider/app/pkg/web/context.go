@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -63,6 +65,9 @@ const CookieAuthName = "auth"
 // CookieSignUpAuthName is the name of the cookie that holds the temporary Authentication Token
 const CookieSignUpAuthName = "__signup_auth"
 
+// CookieTwoFactorName is the name of the cookie that holds the user waiting to verify their second factor
+const CookieTwoFactorName = "__2fa_pending"
+
 // Context shared between http pipeline
 type Context struct {
 	context.Context
@@ -83,8 +88,15 @@ func NewContext(engine *Engine, req *http.Request, rw http.ResponseWriter, param
 
 	ctx := context.WithValue(req.Context(), app.RequestCtxKey, wrappedRequest)
 
+	requestID := wrappedRequest.GetHeader("X-Request-ID")
+	if !isValidRequestID(requestID) {
+		requestID = contextID
+	}
+	rw.Header().Set("X-Request-ID", requestID)
+
 	ctx = log.WithProperties(ctx, dto.Props{
 		log.PropertyKeyContextID: contextID,
+		log.PropertyKeyRequestID: requestID,
 		log.PropertyKeyTag:       "WEB",
 	})
 
@@ -99,6 +111,20 @@ func NewContext(engine *Engine, req *http.Request, rw http.ResponseWriter, param
 	}
 }
 
+// isValidRequestID returns true if the id sent by a proxy is short and safe to be logged
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphanumeric && !strings.ContainsRune("-_.:", r) {
+			return false
+		}
+	}
+	return true
+}
+
 // Engine returns main HTTP engine
 func (c *Context) Engine() *Engine {
 	return c.engine
@@ -238,6 +264,15 @@ func (c *Context) Forbidden() error {
 	})
 }
 
+// TooManyRequests returns a 429 error response
+func (c *Context) TooManyRequests() error {
+	return c.JSON(http.StatusTooManyRequests, Map{
+		"errors": []Map{
+			{"message": "Too many requests. Please wait a moment and try again."},
+		},
+	})
+}
+
 // NotFound returns a 404 error page
 func (c *Context) NotFound() error {
 	if c.IsAjax() {
@@ -304,11 +339,36 @@ func (c *Context) HandleValidation(result *validate.Result) error {
 
 // Attachment returns an attached file
 func (c *Context) Attachment(fileName, contentType string, file []byte) error {
-	c.Response.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	c.Response.Header().Set("Content-Disposition", contentDisposition(fileName))
 
 	return c.Blob(http.StatusOK, contentType, file)
 }
 
+// contentDisposition returns the header value of an attachment, quoting and encoding the file name as needed
+func contentDisposition(fileName string) string {
+	if value := mime.FormatMediaType("attachment", map[string]string{"filename": fileName}); value != "" {
+		return value
+	}
+	return "attachment"
+}
+
+// StreamAttachment returns an attached file whose content is written by given func as it is generated.
+// The write deadline is lifted as large files can take longer than HTTP_WRITE_TIMEOUT.
+// Headers are sent before the content, so an error returned by write can no longer change the response status
+func (c *Context) StreamAttachment(fileName, contentType string, write func(w io.Writer) error) error {
+	_ = http.NewResponseController(c.Response.Writer).SetWriteDeadline(time.Time{})
+
+	c.Response.Header().Set("Content-Disposition", contentDisposition(fileName))
+	c.Response.Header().Set("Content-Type", contentType)
+	c.Response.WriteHeader(http.StatusOK)
+
+	if c.Request.Method == http.MethodHead {
+		return nil
+	}
+
+	return write(c.Response.Writer)
+}
+
 // Ok returns 200 OK with JSON result
 func (c *Context) Ok(data any) error {
 	return c.JSON(http.StatusOK, data)
@@ -10,6 +10,7 @@ import (
 	"github.com/getfider/fider/app/models/entity"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -49,6 +50,25 @@ func TestContextID(t *testing.T) {
 	Expect(ctx.ContextID()).HasLen(32)
 }
 
+func TestRequestID(t *testing.T) {
+	RegisterT(t)
+
+	ctx := newGetContext("http://demo.test.fider.io:3000", nil)
+	Expect(log.GetProperty(ctx, log.PropertyKeyRequestID)).Equals(ctx.ContextID())
+	Expect(ctx.Response.Header().Get("X-Request-ID")).Equals(ctx.ContextID())
+
+	ctx = newGetContext("http://demo.test.fider.io:3000", map[string]string{
+		"X-Request-ID": "a1b2-c3d4",
+	})
+	Expect(log.GetProperty(ctx, log.PropertyKeyRequestID)).Equals("a1b2-c3d4")
+	Expect(ctx.Response.Header().Get("X-Request-ID")).Equals("a1b2-c3d4")
+
+	ctx = newGetContext("http://demo.test.fider.io:3000", map[string]string{
+		"X-Request-ID": "<script>alert(1)</script>",
+	})
+	Expect(log.GetProperty(ctx, log.PropertyKeyRequestID)).Equals(ctx.ContextID())
+}
+
 func TestBaseURL(t *testing.T) {
 	RegisterT(t)
 
@@ -86,7 +86,7 @@ func New() *Engine {
 		renderer:    NewRenderer(),
 		binder:      NewDefaultBinder(),
 		middlewares: make([]MiddlewareFunc, 0),
-		worker:      worker.New(),
+		worker:      newWorker(),
 		cache:       cache.New(5*time.Minute, 10*time.Minute),
 	}
 
@@ -212,7 +212,7 @@ func (e *Engine) Worker() worker.Worker {
 func (e *Engine) Group() *Group {
 	g := &Group{
 		engine:      e,
-		middlewares: e.middlewares,
+		middlewares: append([]MiddlewareFunc{}, e.middlewares...),
 	}
 	return g
 }
@@ -264,8 +264,10 @@ func (e *Engine) handle(middlewares []MiddlewareFunc, handler HandlerFunc) httpr
 		for _, p := range ps {
 			params[p.Key] = p.Value
 		}
+		req, span := startRequestSpan(req, ps.MatchedRoutePath())
 		ctx := NewContext(e, req, res, params)
-		_ = next(ctx)
+		err := next(ctx)
+		endRequestSpan(span, ctx, err)
 	}
 	return h
 }
@@ -280,13 +282,17 @@ type Group struct {
 func (g *Group) Group() *Group {
 	g2 := &Group{
 		engine:      g.engine,
-		middlewares: g.middlewares,
+		middlewares: append([]MiddlewareFunc{}, g.middlewares...),
 	}
 	return g2
 }
 
 // Use adds a middleware to current route stack
 func (g *Group) Use(middleware MiddlewareFunc) {
+	if middleware == nil {
+		return
+	}
+
 	g.middlewares = append(g.middlewares, middleware)
 }
 
@@ -222,17 +222,16 @@ func (r *Renderer) Render(w io.Writer, statusCode int, props Props, ctx *Context
 	if ctx.IsAuthenticated() {
 		u := ctx.User()
 		public["user"] = &Map{
-			"id":              u.ID,
-			"name":            u.Name,
-			"email":           u.Email,
-			"role":            u.Role,
-			"status":          u.Status,
-			"avatarType":      u.AvatarType,
-			"avatarURL":       u.AvatarURL,
-			"avatarBlobKey":   u.AvatarBlobKey,
-			"isAdministrator": u.IsAdministrator(),
-			"isCollaborator":  u.IsCollaborator(),
-			"isTrusted":       u.IsTrusted,
+			"id":            u.ID,
+			"name":          u.Name,
+			"email":         u.Email,
+			"role":          u.Role,
+			"status":        u.Status,
+			"avatarType":    u.AvatarType,
+			"avatarURL":     u.AvatarURL,
+			"avatarBlobKey": u.AvatarBlobKey,
+			"isTrusted":     u.IsTrusted,
+			"permissions":   u.Permissions(),
 		}
 	}
 
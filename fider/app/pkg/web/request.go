@@ -2,6 +2,7 @@ package web
 
 import (
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -21,6 +22,7 @@ type Request struct {
 	IsSecure      bool
 	StartTime     time.Time
 	URL           *url.URL
+	ClientIP      string
 }
 
 // WrapRequest returns Fider wrapper of HTTP Request
@@ -57,9 +59,54 @@ func WrapRequest(request *http.Request) Request {
 		URL:           u,
 		IsSecure:      protocol == "https",
 		StartTime:     time.Now(),
+		ClientIP:      clientIP(request),
 	}
 }
 
+// clientIP returns the address of the client that originated the request.
+// Headers set by reverse proxies are only honored when the request comes from a trusted proxy,
+// as any client can set them. X-Forwarded-For is read from the right, skipping trusted proxies
+func clientIP(request *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		remoteIP = request.RemoteAddr
+	}
+
+	proxies := env.TrustedProxies()
+	if !isTrustedProxy(proxies, remoteIP) {
+		return remoteIP
+	}
+
+	if forwardedFor := request.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		addresses := strings.Split(forwardedFor, ",")
+		for i := len(addresses) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(addresses[i])
+			if address != "" && !isTrustedProxy(proxies, address) {
+				return address
+			}
+		}
+	}
+
+	if realIP := strings.TrimSpace(request.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+
+	return remoteIP
+}
+
+func isTrustedProxy(proxies []*net.IPNet, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy != nil && proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHeader returns the value of HTTP header from given key
 func (r *Request) GetHeader(key string) string {
 	return r.instance.Header.Get(key)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -97,6 +98,32 @@ func TestIsCustomDomain(t *testing.T) {
 	Expect(req.IsAPI()).IsTrue()
 }
 
+func TestRequest_ClientIP(t *testing.T) {
+	RegisterT(t)
+
+	req1 := web.WrapRequest(&http.Request{Host: "demo.test.fider.io", RemoteAddr: "10.0.0.1:54321"})
+	Expect(req1.ClientIP).Equals("10.0.0.1")
+
+	// forwarded headers are ignored unless the request comes from a trusted proxy
+	header := make(http.Header)
+	header.Set("X-Real-IP", "172.16.0.4")
+	header.Set("X-Forwarded-For", "198.51.100.9, 203.0.113.7, 10.0.0.2")
+	req2 := web.WrapRequest(&http.Request{Host: "demo.test.fider.io", RemoteAddr: "10.0.0.1:54321", Header: header})
+	Expect(req2.ClientIP).Equals("10.0.0.1")
+
+	env.Config.HTTP.TrustedProxies = "10.0.0.0/24, 192.168.1.1"
+	defer func() { env.Config.HTTP.TrustedProxies = "" }()
+
+	req3 := web.WrapRequest(&http.Request{Host: "demo.test.fider.io", RemoteAddr: "10.0.0.1:54321", Header: header})
+	Expect(req3.ClientIP).Equals("203.0.113.7")
+
+	req4 := web.WrapRequest(&http.Request{Host: "demo.test.fider.io", RemoteAddr: "192.168.1.1:54321", Header: http.Header{"X-Real-Ip": []string{"172.16.0.4"}}})
+	Expect(req4.ClientIP).Equals("172.16.0.4")
+
+	req5 := web.WrapRequest(&http.Request{Host: "demo.test.fider.io", RemoteAddr: "192.168.1.2:54321", Header: header})
+	Expect(req5.ClientIP).Equals("192.168.1.2")
+}
+
 func TestRequest_FullURL(t *testing.T) {
 	RegisterT(t)
 
@@ -0,0 +1,47 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// startRequestSpan creates the span of an incoming request, which continues the trace of the caller if there's any
+func startRequestSpan(req *http.Request, route string) (*http.Request, trace.Span) {
+	if !tracing.IsEnabled() {
+		return req, noop.Span{}
+	}
+
+	ctx := tracing.Extract(req.Context(), req.Header)
+	ctx, span := tracing.Start(ctx, req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("http.route", route),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.Host),
+			attribute.String("user_agent.original", req.UserAgent()),
+		),
+	)
+	return req.WithContext(ctx), span
+}
+
+// endRequestSpan records the response of the request, which is failed when its status is 5xx
+func endRequestSpan(span trace.Span, c *Context, err error) {
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", c.Response.StatusCode))
+	if tenant := c.Tenant(); tenant != nil {
+		span.SetAttributes(attribute.Int("fider.tenant.id", tenant.ID))
+	}
+	if err == nil && c.Response.StatusCode >= http.StatusInternalServerError {
+		err = errors.New("request failed with status %d", c.Response.StatusCode)
+	}
+	tracing.End(span, err)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func encode(user *entity.User) string {
-	token, err := jwt.Encode(jwt.FiderClaims{
+	return encodeClaims(jwt.FiderClaims{
 		UserID:    user.ID,
 		UserName:  user.Name,
 		UserEmail: user.Email,
@@ -21,6 +21,10 @@ func encode(user *entity.User) string {
 			ExpiresAt: jwt.Time(time.Now().Add(365 * 24 * time.Hour)),
 		},
 	})
+}
+
+func encodeClaims(claims jwt.FiderClaims) string {
+	token, err := jwt.Encode(claims)
 
 	if err != nil {
 		panic(errors.Wrap(err, "failed to add auth cookie"))
@@ -34,6 +38,21 @@ func AddAuthUserCookie(ctx *web.Context, user *entity.User) {
 	AddAuthTokenCookie(ctx, encode(user))
 }
 
+//AddTwoFactorAuthUserCookie generates Auth Token for a user that has just verified their second factor and adds a cookie
+func AddTwoFactorAuthUserCookie(ctx *web.Context, user *entity.User) {
+	now := time.Now()
+	AddAuthTokenCookie(ctx, encodeClaims(jwt.FiderClaims{
+		UserID:              user.ID,
+		UserName:            user.Name,
+		UserEmail:           user.Email,
+		Origin:              jwt.FiderClaimsOriginUI,
+		TwoFactorVerifiedAt: jwt.Time(now),
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(now.Add(365 * 24 * time.Hour)),
+		},
+	}))
+}
+
 //AddAuthTokenCookie adds given token to a cookie
 func AddAuthTokenCookie(ctx *web.Context, token string) {
 	expiresAt := time.Now().Add(365 * 24 * time.Hour)
@@ -70,3 +89,31 @@ func GetSignUpAuthCookie(ctx *web.Context) string {
 	}
 	return ""
 }
+
+//SetTwoFactorPendingCookie sets a short-lived cookie for a user that still needs to verify their second factor
+func SetTwoFactorPendingCookie(ctx *web.Context, user *entity.User) {
+	expiresAt := time.Now().Add(10 * time.Minute)
+	token, err := jwt.Encode(jwt.TwoFactorClaims{
+		UserID: user.ID,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(expiresAt),
+		},
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "failed to add two-factor cookie"))
+	}
+	ctx.AddCookie(web.CookieTwoFactorName, token, expiresAt)
+}
+
+//GetTwoFactorPendingUserID returns the ID of the user waiting to verify their second factor, or 0 if there's none
+func GetTwoFactorPendingUserID(ctx *web.Context) int {
+	cookie, err := ctx.Request.Cookie(web.CookieTwoFactorName)
+	if err != nil {
+		return 0
+	}
+	claims, err := jwt.DecodeTwoFactorClaims(cookie.Value)
+	if err != nil {
+		return 0
+	}
+	return claims.UserID
+}
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+func newWorker() worker.Worker {
+	if env.Config.Worker.Queue == "memory" {
+		return worker.New()
+	}
+	return worker.NewDurable(taskOrigin{})
+}
+
+// taskOrigin stores the tenant, user and request of the context tasks are enqueued from
+type taskOrigin struct{}
+
+// Capture returns the origin of a task enqueued from ctx
+func (taskOrigin) Capture(ctx context.Context) worker.Origin {
+	origin := worker.Origin{}
+	origin.RequestID, _ = log.GetProperty(ctx, log.PropertyKeyRequestID).(string)
+	origin.SessionID, _ = log.GetProperty(ctx, log.PropertyKeySessionID).(string)
+	origin.Locale, _ = ctx.Value(app.LocaleCtxKey).(string)
+
+	if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok && tenant != nil {
+		origin.TenantID = tenant.ID
+	}
+	if user, ok := ctx.Value(app.UserCtxKey).(*entity.User); ok && user != nil {
+		origin.UserID = user.ID
+	}
+	if request, ok := ctx.Value(app.RequestCtxKey).(Request); ok && request.URL != nil {
+		origin.BaseURL = request.BaseURL()
+	}
+
+	header := http.Header{}
+	tracing.Inject(ctx, header)
+	origin.TraceParent = header.Get("traceparent")
+	return origin
+}
+
+// Restore returns a context equivalent to the one a task was enqueued from, with its tenant and user loaded from the database
+func (taskOrigin) Restore(ctx context.Context, origin worker.Origin) (context.Context, error) {
+	if origin.BaseURL != "" {
+		u, err := url.Parse(origin.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, app.RequestCtxKey, Request{URL: u})
+	}
+
+	if origin.TraceParent != "" {
+		header := http.Header{}
+		header.Set("traceparent", origin.TraceParent)
+		ctx = tracing.Extract(ctx, header)
+	}
+
+	ctx = log.WithProperties(ctx, dto.Props{
+		log.PropertyKeyRequestID: origin.RequestID,
+		log.PropertyKeySessionID: origin.SessionID,
+	})
+
+	if origin.TenantID == 0 {
+		return ctx, nil
+	}
+
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer trx.MustRollback()
+	queryCtx := context.WithValue(ctx, app.TransactionCtxKey, trx)
+
+	getTenant := &query.GetTenantByID{TenantID: origin.TenantID}
+	if err := bus.Dispatch(queryCtx, getTenant); err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, app.TenantCtxKey, getTenant.Result)
+	ctx = log.WithProperty(ctx, log.PropertyKeyTenantID, getTenant.Result.ID)
+
+	locale := origin.Locale
+	if locale == "" {
+		locale = getTenant.Result.Locale
+	}
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, locale)
+
+	if origin.UserID > 0 {
+		getUser := &query.GetUserByID{UserID: origin.UserID}
+		if err := bus.Dispatch(context.WithValue(queryCtx, app.TenantCtxKey, getTenant.Result), getUser); err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, app.UserCtxKey, getUser.Result)
+		ctx = log.WithProperty(ctx, log.PropertyKeyUserID, getUser.Result.ID)
+	}
+
+	return ctx, nil
+}
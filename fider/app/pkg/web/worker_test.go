@@ -0,0 +1,57 @@
+package web
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+func TestTaskOrigin_Capture(t *testing.T) {
+	RegisterT(t)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Host = "demo.test.fider.io:3000"
+	req.Header.Set("X-Request-ID", "abc")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req = req.WithContext(tracing.Extract(req.Context(), req.Header))
+	ctx := NewContext(New(), req, httptest.NewRecorder(), nil)
+	ctx.SetSessionID("session-1")
+	ctx.Set(app.TenantCtxKey, &entity.Tenant{ID: 2})
+	ctx.Set(app.UserCtxKey, &entity.User{ID: 5})
+	ctx.Set(app.LocaleCtxKey, "pt-BR")
+
+	origin := taskOrigin{}.Capture(ctx)
+	Expect(origin).Equals(worker.Origin{
+		TenantID:    2,
+		UserID:      5,
+		Locale:      "pt-BR",
+		BaseURL:     "http://demo.test.fider.io:3000",
+		RequestID:   "abc",
+		SessionID:   "session-1",
+		TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+}
+
+func TestTaskOrigin_Restore_WithoutTenant(t *testing.T) {
+	RegisterT(t)
+
+	ctx, err := taskOrigin{}.Restore(context.Background(), worker.Origin{
+		BaseURL:     "https://login.test.fider.io:3000",
+		RequestID:   "abc",
+		TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	Expect(err).IsNil()
+	Expect(BaseURL(ctx)).Equals("https://login.test.fider.io:3000")
+	Expect(log.GetProperty(ctx, log.PropertyKeyRequestID)).Equals("abc")
+	Expect(ctx.Value(app.TenantCtxKey)).IsNil()
+
+	c := worker.NewContext(context.Background(), "0", worker.Task{OriginContext: ctx})
+	Expect(log.GetProperty(c, log.PropertyKeyRequestID)).Equals("abc")
+}
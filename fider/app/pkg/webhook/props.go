@@ -55,6 +55,7 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 			p[keyPrefix+"_comments"] = post.CommentsCount
 			p[keyPrefix+"_status"] = post.Status.Name()
 			p[keyPrefix+"_tags"] = post.Tags
+			p[keyPrefix+"_reactions"] = reactionsMap(post.ReactionCounts)
 			p[keyPrefix+"_response"] = postResponse != nil
 
 			if postResponse != nil {
@@ -77,3 +78,11 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 	}
 	return p
 }
+
+func reactionsMap(reactions []entity.ReactionCounts) map[string]int {
+	result := make(map[string]int, len(reactions))
+	for _, r := range reactions {
+		result[r.Emoji] = r.Count
+	}
+	return result
+}
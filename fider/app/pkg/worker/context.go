@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/tracing"
 )
 
 //Context holds references to services available for jobs
@@ -20,9 +21,13 @@ type Context struct {
 
 //NewContext creates a new context
 func NewContext(ctx context.Context, workerID string, task Task) *Context {
-	ctx = log.WithProperty(ctx, log.PropertyKeyContextID, rand.String(32))
+	ctx = log.WithProperties(ctx, dto.Props{
+		log.PropertyKeyContextID: rand.String(32),
+		log.PropertyKeyTaskName:  task.Name,
+	})
 
 	if task.OriginContext != nil {
+		ctx = tracing.WithParent(ctx, task.OriginContext)
 		ctx = context.WithValue(ctx, app.RequestCtxKey, task.OriginContext.Value(app.RequestCtxKey))
 		ctx = context.WithValue(ctx, app.TenantCtxKey, task.OriginContext.Value(app.TenantCtxKey))
 		ctx = context.WithValue(ctx, app.LocaleCtxKey, task.OriginContext.Value(app.LocaleCtxKey))
@@ -32,6 +37,7 @@ func NewContext(ctx context.Context, workerID string, task Task) *Context {
 			log.PropertyKeySessionID: log.GetProperty(task.OriginContext, log.PropertyKeySessionID),
 			log.PropertyKeyUserID:    log.GetProperty(task.OriginContext, log.PropertyKeyUserID),
 			log.PropertyKeyTenantID:  log.GetProperty(task.OriginContext, log.PropertyKeyTenantID),
+			log.PropertyKeyRequestID: log.GetProperty(task.OriginContext, log.PropertyKeyRequestID),
 		})
 	}
 
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// DeadTask is a stored task that failed on all its attempts and won't be retried by the worker
+type DeadTask struct {
+	ID        int64          `db:"id"`
+	TenantID  dbx.NullInt    `db:"tenant_id"`
+	Type      string         `db:"type"`
+	Name      string         `db:"name"`
+	Attempts  int            `db:"attempts"`
+	LastError dbx.NullString `db:"last_error"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+// DeadTaskFilter selects dead tasks by ID, or by how long ago they've failed.
+// Zero values match every dead task
+type DeadTaskFilter struct {
+	ID        int64
+	OlderThan time.Duration
+}
+
+func (f DeadTaskFilter) condition() (string, []any) {
+	updatedBefore := time.Now().Add(-f.OlderThan)
+	if f.ID > 0 {
+		return "status = $1 AND updated_at <= $2 AND id = $3", []any{statusDead, updatedBefore, f.ID}
+	}
+	return "status = $1 AND updated_at <= $2", []any{statusDead, updatedBefore}
+}
+
+// ListDeadTasks returns the dead tasks that match given filter, oldest first
+func ListDeadTasks(ctx context.Context, filter DeadTaskFilter) ([]*DeadTask, error) {
+	tasks := []*DeadTask{}
+	where, args := filter.condition()
+	err := withTrx(ctx, func(trx *dbx.Trx) error {
+		return trx.Select(&tasks, `
+			SELECT id, tenant_id, type, name, attempts, last_error, updated_at
+			FROM worker_tasks
+			WHERE `+where+`
+			ORDER BY updated_at, id
+		`, args...)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list dead tasks")
+	}
+	return tasks, nil
+}
+
+// RetryDeadTasks hands the dead tasks that match given filter back to the queue, with all their attempts.
+// Returns the number of tasks that will be retried
+func RetryDeadTasks(ctx context.Context, filter DeadTaskFilter) (int64, error) {
+	var count int64
+	where, args := filter.condition()
+	err := withTrx(ctx, func(trx *dbx.Trx) error {
+		var err error
+		count, err = trx.Execute(fmt.Sprintf(`
+			UPDATE worker_tasks
+			SET status = $%d, attempts = 0, run_at = NOW(), updated_at = NOW()
+			WHERE %s
+		`, len(args)+1, where), append(args, statusPending)...)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to retry dead tasks")
+	}
+	return count, nil
+}
+
+// PurgeDeadTasks removes the dead tasks that match given filter. Returns the number of removed tasks
+func PurgeDeadTasks(ctx context.Context, filter DeadTaskFilter) (int64, error) {
+	var count int64
+	where, args := filter.condition()
+	err := withTrx(ctx, func(trx *dbx.Trx) error {
+		var err error
+		count, err = trx.Execute(`DELETE FROM worker_tasks WHERE `+where, args...)
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge dead tasks")
+	}
+	return count, nil
+}
+
+func withTrx(ctx context.Context, fn func(trx *dbx.Trx) error) error {
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(trx); err != nil {
+		trx.MustRollback()
+		return err
+	}
+	return trx.Commit()
+}
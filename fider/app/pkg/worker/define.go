@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type definition struct {
+	name   string
+	decode func(payload []byte) (Job, error)
+}
+
+var (
+	definitions     = make(map[string]definition)
+	definitionsLock sync.RWMutex
+)
+
+// Define registers a type of task whose job only depends on its payload, so that it can be persisted
+// and executed after a restart or by another instance. The payload must survive a JSON round trip.
+// It returns the function that creates tasks of this type
+func Define[T any](taskType, name string, job func(c *Context, payload T) error) func(payload T) Task {
+	definitionsLock.Lock()
+	defer definitionsLock.Unlock()
+
+	if _, ok := definitions[taskType]; ok {
+		panic(fmt.Sprintf("task type '%s' is already defined", taskType))
+	}
+
+	definitions[taskType] = definition{
+		name: name,
+		decode: func(content []byte) (Job, error) {
+			var payload T
+			if err := json.Unmarshal(content, &payload); err != nil {
+				return nil, errors.Wrap(err, "failed to decode payload of task '%s'", taskType)
+			}
+			return func(c *Context) error {
+				return job(c, payload)
+			}, nil
+		},
+	}
+
+	return func(payload T) Task {
+		return Task{
+			Name:    name,
+			Type:    taskType,
+			Payload: payload,
+			Job: func(c *Context) error {
+				return job(c, payload)
+			},
+		}
+	}
+}
+
+// decodeTask recreates a task of given type from its serialized payload
+func decodeTask(taskType string, payload []byte) (Task, error) {
+	definitionsLock.RLock()
+	def, ok := definitions[taskType]
+	definitionsLock.RUnlock()
+
+	if !ok {
+		return Task{}, errors.New("task type '%s' is not defined", taskType)
+	}
+
+	job, err := def.decode(payload)
+	if err != nil {
+		return Task{}, err
+	}
+	return Task{Name: def.name, Type: taskType, Job: job}, nil
+}
+
+// Reload recreates given task from the JSON encoding of its payload, just like a stored task is recreated before it runs.
+// Tasks that are not of a defined type are returned as they are
+func Reload(task Task) (Task, error) {
+	if task.Type == "" {
+		return task, nil
+	}
+
+	payload, err := json.Marshal(task.Payload)
+	if err != nil {
+		return Task{}, errors.Wrap(err, "failed to encode payload of task '%s'", task.Type)
+	}
+
+	reloaded, err := decodeTask(task.Type, payload)
+	if err != nil {
+		return Task{}, err
+	}
+	reloaded.Payload = task.Payload
+	reloaded.OriginContext = task.OriginContext
+	return reloaded, nil
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+type reminder struct {
+	UserID  int
+	Message string
+	Tags    []string
+}
+
+func TestDefine(t *testing.T) {
+	RegisterT(t)
+
+	var received []reminder
+	newReminder := Define("test_reminder", "Send reminder", func(c *Context, payload reminder) error {
+		received = append(received, payload)
+		return nil
+	})
+
+	task := newReminder(reminder{UserID: 1, Message: "Hello", Tags: []string{"a"}})
+	Expect(task.Name).Equals("Send reminder")
+	Expect(task.Type).Equals("test_reminder")
+	Expect(task.Job(&Context{})).IsNil()
+
+	decoded, err := decodeTask("test_reminder", []byte(`{"UserID":2,"Message":"Bye","Tags":["b"]}`))
+	Expect(err).IsNil()
+	Expect(decoded.Name).Equals("Send reminder")
+	Expect(decoded.Job(&Context{})).IsNil()
+
+	Expect(received).Equals([]reminder{
+		{UserID: 1, Message: "Hello", Tags: []string{"a"}},
+		{UserID: 2, Message: "Bye", Tags: []string{"b"}},
+	})
+
+	_, err = decodeTask("test_reminder", []byte(`{"UserID":"abc"}`))
+	Expect(err).IsNotNil()
+
+	_, err = decodeTask("test_unknown", []byte(`{}`))
+	Expect(err).IsNotNil()
+
+	Expect(func() {
+		Define("test_reminder", "Send reminder again", func(c *Context, payload reminder) error {
+			return nil
+		})
+	}).Panics()
+}
+
+func TestReload(t *testing.T) {
+	RegisterT(t)
+
+	var received []reminder
+	newReminder := Define("test_reload_reminder", "Send reminder", func(c *Context, payload reminder) error {
+		received = append(received, payload)
+		return nil
+	})
+
+	task, err := Reload(newReminder(reminder{UserID: 1, Message: "Hello"}))
+	Expect(err).IsNil()
+	Expect(task.Type).Equals("test_reload_reminder")
+	Expect(task.Job(&Context{})).IsNil()
+	Expect(received).Equals([]reminder{{UserID: 1, Message: "Hello"}})
+
+	untyped := Task{Name: "Untyped", Job: func(c *Context) error { return nil }}
+	task, err = Reload(untyped)
+	Expect(err).IsNil()
+	Expect(task.Name).Equals("Untyped")
+}
+
+func TestBackoff(t *testing.T) {
+	RegisterT(t)
+
+	Expect(backoff(1)).Equals(retryDelay)
+	Expect(backoff(2)).Equals(2 * retryDelay)
+	Expect(backoff(3)).Equals(4 * retryDelay)
+	Expect(backoff(20)).Equals(maxRetryDelay)
+}
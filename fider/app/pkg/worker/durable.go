@@ -0,0 +1,497 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+const (
+	statusPending = "pending"
+	statusRunning = "running"
+	statusDead    = "dead"
+
+	retryDelay    = 10 * time.Second
+	maxRetryDelay = time.Hour
+)
+
+// Origin is what is stored about the context a task was enqueued from,
+// so that it runs on behalf of the same tenant and user when it's restored
+type Origin struct {
+	TenantID    int    `json:"tenantId,omitempty"`
+	UserID      int    `json:"userId,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	BaseURL     string `json:"baseUrl,omitempty"`
+	RequestID   string `json:"requestId,omitempty"`
+	SessionID   string `json:"sessionId,omitempty"`
+	TraceParent string `json:"traceParent,omitempty"`
+}
+
+// OriginCodec captures the origin of tasks when they are enqueued and restores it before they run
+type OriginCodec interface {
+	Capture(ctx context.Context) Origin
+	Restore(ctx context.Context, origin Origin) (context.Context, error)
+}
+
+type storedTask struct {
+	ID          int64  `db:"id"`
+	Type        string `db:"type"`
+	Name        string `db:"name"`
+	Payload     string `db:"payload"`
+	Origin      string `db:"origin"`
+	Attempts    int    `db:"attempts"`
+	MaxAttempts int    `db:"max_attempts"`
+}
+
+// DurableWorker is a worker that stores tasks on the database, so that they survive restarts and
+// are shared by all instances. Tasks that were not created by a Define'd constructor can't be stored,
+// so they run on background of current instance only
+type DurableWorker struct {
+	context.Context
+	cancel     context.CancelFunc
+	instance   string
+	codec      OriginCodec
+	middleware MiddlewareFunc
+	wakeup     chan struct{}
+	stopping   chan struct{}
+	stopOnce   sync.Once
+	monitor    sync.Once
+	len        int64
+	sync.RWMutex
+}
+
+// NewDurable creates a new DurableWorker
+func NewDurable(codec OriginCodec) *DurableWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	instance := rand.String(16)
+
+	ctx = log.WithProperties(ctx, dto.Props{
+		log.PropertyKeyContextID: rand.String(32),
+		log.PropertyKeyTag:       "BGW",
+		"WorkerInstance":         instance,
+	})
+
+	return &DurableWorker{
+		Context:  ctx,
+		cancel:   cancel,
+		instance: instance,
+		codec:    codec,
+		wakeup:   make(chan struct{}, 1),
+		stopping: make(chan struct{}),
+		middleware: func(next Job) Job {
+			return next
+		},
+	}
+}
+
+// Run initializes the worker loop, which picks tasks from the database until the worker is shut down
+func (w *DurableWorker) Run(workerID string) {
+	log.Infof(w, "Starting durable worker @{WorkerID:magenta}.", dto.Props{
+		"WorkerID": workerID,
+	})
+	w.monitor.Do(func() {
+		go w.updateQueueLength()
+	})
+
+	for {
+		select {
+		case <-w.stopping:
+			return
+		default:
+		}
+
+		// the task is tracked while it's being dequeued, so that Shutdown waits for it
+		w.track(1)
+		stored, err := w.dequeue()
+		if err != nil {
+			log.Error(w, err)
+		}
+		if stored != nil {
+			w.process(workerID, stored)
+		}
+		w.track(-1)
+
+		if stored == nil {
+			select {
+			case <-w.stopping:
+				return
+			case <-w.wakeup:
+			case <-time.After(env.Config.Worker.PollInterval):
+			}
+		}
+	}
+}
+
+// Enqueue stores a task on the database. Tasks that can't be stored run on background of current instance
+func (w *DurableWorker) Enqueue(task Task) {
+	if task.Type == "" {
+		w.runLocal(task)
+		return
+	}
+
+	if err := w.insert(task); err != nil {
+		log.Error(w, errors.Wrap(err, "failed to store task '%s', it will run on current instance only", task.Name))
+		w.runLocal(task)
+		return
+	}
+
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Length returns the number of tasks running on current instance, pending tasks are stored on the database
+func (w *DurableWorker) Length() int64 {
+	w.RLock()
+	defer w.RUnlock()
+	return w.len
+}
+
+// CountStored returns the number of stored tasks of each status: pending, running and dead
+func (w *DurableWorker) CountStored() (map[string]int, error) {
+	type statusCount struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+
+	rows := []*statusCount{}
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		return trx.Select(&rows, `SELECT status, COUNT(*) AS count FROM worker_tasks GROUP BY status`)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count stored tasks")
+	}
+
+	counts := map[string]int{statusPending: 0, statusRunning: 0, statusDead: 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Use this to inject worker dependencies
+func (w *DurableWorker) Use(middleware MiddlewareFunc) {
+	w.middleware = middleware
+}
+
+// Shutdown stops picking new tasks and waits for running tasks.
+// Tasks still running when ctx is done are canceled and handed back to the queue
+func (w *DurableWorker) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() {
+		close(w.stopping)
+	})
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		count := w.Length()
+		if count == 0 {
+			return nil
+		}
+
+		log.Infof(w, "Waiting for running tasks: @{Count}", dto.Props{
+			"Count": count,
+		})
+
+		select {
+		case <-ctx.Done():
+			w.cancel()
+			released, err := w.release()
+			if err != nil {
+				return errors.Wrap(err, "failed to hand unfinished tasks back to the queue")
+			}
+			log.Infof(w, "@{Count} unfinished tasks were handed back to the queue", dto.Props{
+				"Count": released,
+			})
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *DurableWorker) track(delta int64) {
+	w.Lock()
+	w.len = w.len + delta
+	w.Unlock()
+}
+
+func (w *DurableWorker) runLocal(task Task) {
+	w.track(1)
+	go func() {
+		defer w.track(-1)
+		_ = execute(w, w.middleware, "local", task)
+	}()
+}
+
+func (w *DurableWorker) process(workerID string, stored *storedTask) {
+	if stored.Attempts > stored.MaxAttempts {
+		// it was picked again after the lease of its last attempt expired
+		w.fail(stored, errors.New("task '%s' was interrupted on its last attempt", stored.Name))
+		return
+	}
+
+	task, err := decodeTask(stored.Type, []byte(stored.Payload))
+	if err != nil {
+		w.bury(stored, err)
+		return
+	}
+
+	var origin Origin
+	if err := json.Unmarshal([]byte(stored.Origin), &origin); err != nil {
+		w.bury(stored, errors.Wrap(err, "failed to decode origin of task '%s'", stored.Name))
+		return
+	}
+
+	task.OriginContext, err = w.codec.Restore(w, origin)
+	if err != nil {
+		w.fail(stored, errors.Wrap(err, "failed to restore origin of task '%s'", stored.Name))
+		return
+	}
+
+	stopHeartbeat := w.heartbeat(stored)
+	err = execute(w, w.middleware, workerID, task)
+	stopHeartbeat()
+
+	if err != nil {
+		if w.Err() != nil {
+			// it was canceled by Shutdown, which hands it back to the queue
+			return
+		}
+		w.fail(stored, err)
+		return
+	}
+	w.complete(stored)
+}
+
+// heartbeat keeps renewing the lease of a task while it runs, so that long running tasks
+// are not picked again by other instances. Call the returned func to stop renewing it
+func (w *DurableWorker) heartbeat(stored *storedTask) func() {
+	interval := env.Config.Worker.LeaseTimeout / 3
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			renewed, err := w.renew(stored)
+			if err != nil {
+				log.Error(w, errors.Wrap(err, "failed to renew lease of task '%d'", stored.ID))
+			} else if !renewed {
+				log.Warnf(w, "Lease of task '@{TaskName}' was lost, it might run again on another instance", dto.Props{
+					"TaskName": stored.Name,
+				})
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+// renew extends the lease of a running task. Returns false if current instance no longer holds it
+func (w *DurableWorker) renew(stored *storedTask) (bool, error) {
+	var count int64
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		var err error
+		now := time.Now()
+		count, err = trx.Execute(`
+			UPDATE worker_tasks
+			SET locked_until = $3, updated_at = $4
+			WHERE id = $1 AND locked_by = $2 AND status = $5
+		`, stored.ID, w.instance, now.Add(env.Config.Worker.LeaseTimeout), now, statusRunning)
+		return err
+	})
+	return count > 0, err
+}
+
+// withTrx runs fn on a new transaction that is not bound to the worker context, so that it
+// can still be committed while the worker is being shut down
+func (w *DurableWorker) withTrx(fn func(trx *dbx.Trx) error) error {
+	return withTrx(context.WithoutCancel(w.Context), fn)
+}
+
+func (w *DurableWorker) insert(task Task) error {
+	payload, err := json.Marshal(task.Payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode payload of task '%s'", task.Name)
+	}
+
+	var origin Origin
+	if task.OriginContext != nil {
+		origin = w.codec.Capture(task.OriginContext)
+	}
+	content, err := json.Marshal(origin)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode origin of task '%s'", task.Name)
+	}
+
+	var tenantID any
+	if origin.TenantID > 0 {
+		tenantID = origin.TenantID
+	}
+
+	return w.withTrx(func(trx *dbx.Trx) error {
+		now := time.Now()
+		_, err := trx.Execute(`
+			INSERT INTO worker_tasks (tenant_id, type, name, payload, origin, status, attempts, max_attempts, run_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $8, $8, $8)
+		`, tenantID, task.Type, task.Name, string(payload), string(content), statusPending, env.Config.Worker.MaxAttempts, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert task '%s'", task.Name)
+		}
+		return nil
+	})
+}
+
+// dequeue picks the next task that is due, or whose lease has expired, and leases it to current instance.
+// Tasks that are locked by other instances are skipped. Returns nil if there are no tasks to run
+func (w *DurableWorker) dequeue() (*storedTask, error) {
+	var stored *storedTask
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		now := time.Now()
+		task := storedTask{}
+		err := trx.Get(&task, `
+			UPDATE worker_tasks
+			SET status = $1, attempts = attempts + 1, locked_by = $2, locked_until = $3, updated_at = $4
+			WHERE id = (
+				SELECT id FROM worker_tasks
+				WHERE (status = $5 AND run_at <= $4) OR (status = $1 AND locked_until < $4)
+				ORDER BY run_at, id
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, type, name, payload, origin, attempts, max_attempts
+		`, statusRunning, w.instance, now.Add(env.Config.Worker.LeaseTimeout), now, statusPending)
+		if err == app.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to dequeue task")
+		}
+		stored = &task
+		return nil
+	})
+	return stored, err
+}
+
+// complete removes a task that finished successfully
+func (w *DurableWorker) complete(stored *storedTask) {
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		_, err := trx.Execute(`DELETE FROM worker_tasks WHERE id = $1 AND locked_by = $2`, stored.ID, w.instance)
+		return err
+	})
+	if err != nil {
+		log.Error(w, errors.Wrap(err, "failed to complete task '%d'", stored.ID))
+	}
+}
+
+// fail schedules a retry of a failed task, with exponential backoff, or moves it to the dead letter
+// state once it has no attempts left
+func (w *DurableWorker) fail(stored *storedTask, cause error) {
+	if stored.Attempts >= stored.MaxAttempts {
+		w.bury(stored, cause)
+		return
+	}
+
+	runAt := time.Now().Add(backoff(stored.Attempts))
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		_, err := trx.Execute(`
+			UPDATE worker_tasks
+			SET status = $3, run_at = $4, last_error = $5, locked_by = NULL, locked_until = NULL, updated_at = $6
+			WHERE id = $1 AND locked_by = $2
+		`, stored.ID, w.instance, statusPending, runAt, cause.Error(), time.Now())
+		return err
+	})
+	if err != nil {
+		log.Error(w, errors.Wrap(err, "failed to schedule retry of task '%d'", stored.ID))
+	}
+}
+
+// bury moves a task to the dead letter state, where it stays until an administrator retries or purges it with 'fider tasks'
+func (w *DurableWorker) bury(stored *storedTask, cause error) {
+	log.Error(w, errors.Wrap(cause, "task '%s' failed after %d attempts and won't be retried", stored.Name, stored.Attempts))
+
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		_, err := trx.Execute(`
+			UPDATE worker_tasks
+			SET status = $3, last_error = $4, locked_by = NULL, locked_until = NULL, updated_at = $5
+			WHERE id = $1 AND locked_by = $2
+		`, stored.ID, w.instance, statusDead, cause.Error(), time.Now())
+		return err
+	})
+	if err != nil {
+		log.Error(w, errors.Wrap(err, "failed to move task '%d' to dead letter", stored.ID))
+	}
+}
+
+// release hands the tasks leased by current instance back to the queue, without counting their attempt
+func (w *DurableWorker) release() (int64, error) {
+	var count int64
+	err := w.withTrx(func(trx *dbx.Trx) error {
+		var err error
+		count, err = trx.Execute(`
+			UPDATE worker_tasks
+			SET status = $2, attempts = GREATEST(attempts - 1, 0), run_at = $3, locked_by = NULL, locked_until = NULL, updated_at = $3
+			WHERE status = $4 AND locked_by = $1
+		`, w.instance, statusPending, time.Now(), statusRunning)
+		return err
+	})
+	return count, err
+}
+
+// updateQueueLength periodically sets the queue length metric to the number of stored tasks that are waiting or running,
+// and the dead tasks metric to the number of tasks that won't be retried
+func (w *DurableWorker) updateQueueLength() {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for {
+		counts, err := w.CountStored()
+		if err == nil {
+			metrics.WorkerQueueLength.Set(float64(counts[statusPending] + counts[statusRunning]))
+			metrics.WorkerDeadTasks.Set(float64(counts[statusDead]))
+		}
+
+		select {
+		case <-w.stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt of a task that failed given number of times
+func backoff(attempts int) time.Duration {
+	delay := retryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay = delay * 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,261 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/worker"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+type testOrigin struct{}
+
+func (testOrigin) Capture(ctx context.Context) worker.Origin {
+	requestID, _ := log.GetProperty(ctx, log.PropertyKeyRequestID).(string)
+	return worker.Origin{RequestID: requestID}
+}
+
+func (testOrigin) Restore(ctx context.Context, origin worker.Origin) (context.Context, error) {
+	return log.WithProperty(ctx, log.PropertyKeyRequestID, origin.RequestID), nil
+}
+
+type greeting struct {
+	Name string
+}
+
+var (
+	greetings     = make(chan string, 10)
+	greetingsSent = worker.Define("test_send_greeting", "Send greeting", func(c *worker.Context, payload greeting) error {
+		requestID, _ := log.GetProperty(c, log.PropertyKeyRequestID).(string)
+		greetings <- payload.Name + "@" + requestID
+		return nil
+	})
+	failingTask = worker.Define("test_failing_task", "Failing task", func(c *worker.Context, payload greeting) error {
+		return errors.New("failed to greet " + payload.Name)
+	})
+	slowRuns = make(chan string, 10)
+	slowTask = worker.Define("test_slow_task", "Slow task", func(c *worker.Context, payload greeting) error {
+		slowRuns <- payload.Name
+		time.Sleep(600 * time.Millisecond)
+		return nil
+	})
+	blockingTask = worker.Define("test_blocking_task", "Blocking task", func(c *worker.Context, payload greeting) error {
+		<-c.Done()
+		return c.Err()
+	})
+)
+
+type storedTask struct {
+	Status    string         `db:"status"`
+	Attempts  int            `db:"attempts"`
+	RunAt     time.Time      `db:"run_at"`
+	LastError dbx.NullString `db:"last_error"`
+}
+
+func execute(t *testing.T, command string, args ...any) {
+	trx, err := dbx.BeginTx(context.Background())
+	Expect(err).IsNil()
+	_, err = trx.Execute(command, args...)
+	Expect(err).IsNil()
+	Expect(trx.Commit()).IsNil()
+}
+
+func getStoredTasks(t *testing.T) []*storedTask {
+	trx, err := dbx.BeginTx(context.Background())
+	Expect(err).IsNil()
+	defer trx.MustRollback()
+
+	var tasks []*storedTask
+	err = trx.Select(&tasks, "SELECT status, attempts, run_at, last_error FROM worker_tasks ORDER BY id")
+	Expect(err).IsNil()
+	return tasks
+}
+
+func setupDurableWorker(t *testing.T) *worker.DurableWorker {
+	RegisterT(t)
+	execute(t, "DELETE FROM worker_tasks")
+	env.Config.Worker.PollInterval = 50 * time.Millisecond
+	env.Config.Worker.MaxAttempts = 2
+
+	w := worker.NewDurable(testOrigin{})
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = w.Shutdown(ctx)
+	})
+	return w
+}
+
+func TestDurableWorker_RunsStoredTasks(t *testing.T) {
+	w := setupDurableWorker(t)
+
+	task := greetingsSent(greeting{Name: "Jon"})
+	task.OriginContext = log.WithProperty(context.Background(), log.PropertyKeyRequestID, "abc")
+	w.Enqueue(task)
+
+	Expect(getStoredTasks(t)).HasLen(1)
+	Expect(getStoredTasks(t)[0].Status).Equals("pending")
+
+	go w.Run("worker-1")
+	Expect(<-greetings).Equals("Jon@abc")
+	Expect(func() int {
+		return len(getStoredTasks(t))
+	}).EventuallyEquals(0)
+}
+
+func TestDurableWorker_SharedByWorkers(t *testing.T) {
+	w1 := setupDurableWorker(t)
+	w2 := worker.NewDurable(testOrigin{})
+	defer func() { _ = w2.Shutdown(context.Background()) }()
+
+	for _, name := range []string{"Jon", "Arya", "Sansa", "Bran"} {
+		w1.Enqueue(greetingsSent(greeting{Name: name}))
+	}
+
+	go w1.Run("worker-1")
+	go w2.Run("worker-1")
+
+	received := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		received[<-greetings] = true
+	}
+	Expect(received).Equals(map[string]bool{"Jon@": true, "Arya@": true, "Sansa@": true, "Bran@": true})
+}
+
+func TestDurableWorker_RetryAndDeadLetter(t *testing.T) {
+	w := setupDurableWorker(t)
+
+	w.Enqueue(failingTask(greeting{Name: "Jon"}))
+	go w.Run("worker-1")
+
+	Expect(func() bool {
+		return getStoredTasks(t)[0].LastError.Valid
+	}).EventuallyEquals(true)
+
+	stored := getStoredTasks(t)[0]
+	Expect(stored.Status).Equals("pending")
+	Expect(stored.Attempts).Equals(1)
+	Expect(stored.LastError.String).Equals("failed to greet Jon")
+	Expect(stored.RunAt.After(time.Now())).IsTrue()
+
+	// make it due to be retried now
+	execute(t, "UPDATE worker_tasks SET run_at = $1", time.Now())
+
+	Expect(func() string {
+		return getStoredTasks(t)[0].Status
+	}).EventuallyEquals("dead")
+	Expect(getStoredTasks(t)[0].Attempts).Equals(2)
+
+	counts, err := w.CountStored()
+	Expect(err).IsNil()
+	Expect(counts).Equals(map[string]int{"pending": 0, "running": 0, "dead": 1})
+}
+
+func TestDurableWorker_RenewsLeaseOfRunningTasks(t *testing.T) {
+	w1 := setupDurableWorker(t)
+	leaseTimeout := env.Config.Worker.LeaseTimeout
+	env.Config.Worker.LeaseTimeout = 150 * time.Millisecond
+	t.Cleanup(func() {
+		env.Config.Worker.LeaseTimeout = leaseTimeout
+	})
+
+	w2 := worker.NewDurable(testOrigin{})
+	defer func() { _ = w2.Shutdown(context.Background()) }()
+
+	w1.Enqueue(slowTask(greeting{Name: "Jon"}))
+	go w1.Run("worker-1")
+	Expect(<-slowRuns).Equals("Jon")
+
+	go w2.Run("worker-2")
+	Expect(func() int {
+		return len(getStoredTasks(t))
+	}).EventuallyEquals(0)
+	Expect(slowRuns).HasLen(0)
+}
+
+func TestDurableWorker_UndefinedTask(t *testing.T) {
+	w := setupDurableWorker(t)
+
+	var finished bool
+	mu := &sync.RWMutex{}
+	w.Enqueue(worker.Task{
+		Name: "Do Something",
+		Job: func(c *worker.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			finished = true
+			return nil
+		},
+	})
+
+	Expect(getStoredTasks(t)).HasLen(0)
+	Expect(func() bool {
+		mu.RLock()
+		defer mu.RUnlock()
+		return finished
+	}).EventuallyEquals(true)
+}
+
+func TestDurableWorker_ShutdownHandsBackUnfinishedTasks(t *testing.T) {
+	w := setupDurableWorker(t)
+
+	w.Enqueue(blockingTask(greeting{Name: "Jon"}))
+	go w.Run("worker-1")
+
+	Expect(func() string {
+		return getStoredTasks(t)[0].Status
+	}).EventuallyEquals("running")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	Expect(w.Shutdown(ctx)).IsNil()
+
+	stored := getStoredTasks(t)
+	Expect(stored).HasLen(1)
+	Expect(stored[0].Status).Equals("pending")
+	Expect(stored[0].Attempts).Equals(0)
+}
+
+func TestDeadTasks_ListRetryAndPurge(t *testing.T) {
+	setupDurableWorker(t)
+	ctx := context.Background()
+
+	execute(t, `
+		INSERT INTO worker_tasks (type, name, payload, origin, status, attempts, max_attempts, run_at, last_error, created_at, updated_at)
+		VALUES ('test_failing_task', 'Failing task', '{}', '{}', 'dead', 2, 2, NOW(), 'failed to greet', NOW(), NOW() - INTERVAL '2 days'),
+		       ('test_failing_task', 'Failing task', '{}', '{}', 'dead', 2, 2, NOW(), 'failed to greet', NOW(), NOW()),
+		       ('test_send_greeting', 'Send greeting', '{}', '{}', 'pending', 0, 2, NOW() + INTERVAL '1 hour', NULL, NOW(), NOW())
+	`)
+
+	dead, err := worker.ListDeadTasks(ctx, worker.DeadTaskFilter{})
+	Expect(err).IsNil()
+	Expect(dead).HasLen(2)
+	Expect(dead[0].Type).Equals("test_failing_task")
+	Expect(dead[0].LastError.String).Equals("failed to greet")
+
+	old, err := worker.ListDeadTasks(ctx, worker.DeadTaskFilter{OlderThan: 24 * time.Hour})
+	Expect(err).IsNil()
+	Expect(old).HasLen(1)
+	Expect(old[0].ID).Equals(dead[0].ID)
+
+	purged, err := worker.PurgeDeadTasks(ctx, worker.DeadTaskFilter{OlderThan: 24 * time.Hour})
+	Expect(err).IsNil()
+	Expect(purged).Equals(int64(1))
+
+	retried, err := worker.RetryDeadTasks(ctx, worker.DeadTaskFilter{ID: dead[1].ID})
+	Expect(err).IsNil()
+	Expect(retried).Equals(int64(1))
+
+	stored := getStoredTasks(t)
+	Expect(stored).HasLen(2)
+	Expect(stored[0].Status).Equals("pending")
+	Expect(stored[0].Attempts).Equals(0)
+	Expect(stored[1].Status).Equals("pending")
+}
@@ -6,9 +6,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //MiddlewareFunc is worker middleware
@@ -22,6 +26,10 @@ type Task struct {
 	OriginContext context.Context
 	Name          string
 	Job           Job
+
+	// Type and Payload are set on tasks created by a Define'd constructor, which can be persisted by a DurableWorker
+	Type    string
+	Payload any
 }
 
 //Worker is a process that runs tasks
@@ -68,15 +76,37 @@ func (w *BackgroundWorker) Run(workerID string) {
 		"WorkerID": workerID,
 	})
 	for task := range w.queue {
-		c := NewContext(w, workerID, task)
+		_ = execute(w, w.middleware, workerID, task)
+		metrics.WorkerQueueLength.Dec()
 
-		_ = w.middleware(task.Job)(c)
 		w.Lock()
 		w.len = w.len - 1
 		w.Unlock()
 	}
 }
 
+// execute runs a task through the middleware on a new context, recording its span and metrics
+func execute(ctx context.Context, middleware MiddlewareFunc, workerID string, task Task) error {
+	c := NewContext(ctx, workerID, task)
+
+	spanCtx, span := tracing.Start(c.Context, "task "+task.Name,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("fider.worker.id", workerID)),
+	)
+	c.Context = spanCtx
+	start := time.Now()
+	err := middleware(task.Job)(c)
+	tracing.End(span, err)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	metrics.WorkerTasks.WithLabelValues(task.Name, outcome).Inc()
+	metrics.WorkerTaskDuration.WithLabelValues(task.Name, outcome).Observe(time.Since(start).Seconds())
+	return err
+}
+
 //Shutdown current worker
 func (w *BackgroundWorker) Shutdown(ctx context.Context) error {
 	if w.Length() > 0 {
@@ -107,6 +137,7 @@ func (w *BackgroundWorker) Enqueue(task Task) {
 	w.Lock()
 	w.len = w.len + 1
 	w.Unlock()
+	metrics.WorkerQueueLength.Inc()
 	w.queue <- task
 }
 
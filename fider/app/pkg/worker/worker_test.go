@@ -2,11 +2,14 @@ package worker_test
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/pkg/worker"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	. "github.com/getfider/fider/app/pkg/assert"
 )
@@ -44,6 +47,32 @@ func TestBackgroundWorker(t *testing.T) {
 	}).EventuallyEquals(true)
 }
 
+func TestBackgroundWorker_Metrics(t *testing.T) {
+	RegisterT(t)
+
+	w := worker.New()
+	w.Enqueue(worker.Task{
+		Name: "Metrics Task",
+		Job: func(ctx *worker.Context) error {
+			return errors.New("failed")
+		},
+	})
+	w.Enqueue(worker.Task{
+		Name: "Metrics Task",
+		Job: func(ctx *worker.Context) error {
+			return nil
+		},
+	})
+
+	Expect(testutil.ToFloat64(metrics.WorkerQueueLength)).Equals(float64(2))
+	go w.Run("worker-1")
+	Expect(func() float64 {
+		return testutil.ToFloat64(metrics.WorkerQueueLength)
+	}).EventuallyEquals(float64(0))
+	Expect(testutil.ToFloat64(metrics.WorkerTasks.WithLabelValues("Metrics Task", "failure"))).Equals(float64(1))
+	Expect(testutil.ToFloat64(metrics.WorkerTasks.WithLabelValues("Metrics Task", "success"))).Equals(float64(1))
+}
+
 func TestBackgroundWorker_ShutdownWhenEmpty(t *testing.T) {
 	RegisterT(t)
 
@@ -0,0 +1,65 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+)
+
+// Backend gives direct access to a blob storage, without going through the bus.
+// It's used to copy blobs between storages and to read from a previous storage while migrating
+type Backend struct {
+	// Setup prepares the storage to be used, such as creating its clients
+	Setup           func()
+	ListBlobs       func(ctx context.Context, q *query.ListBlobs) error
+	GetBlobByKey    func(ctx context.Context, q *query.GetBlobByKey) error
+	OpenBlob        func(ctx context.Context, q *query.OpenBlob) error
+	StoreBlob       func(ctx context.Context, c *cmd.StoreBlob) error
+	StoreBlobStream func(ctx context.Context, c *cmd.StoreBlobStream) error
+	DeleteBlob      func(ctx context.Context, c *cmd.DeleteBlob) error
+}
+
+var (
+	backends     = make(map[string]*Backend)
+	backendsLock sync.RWMutex
+)
+
+// RegisterBackend makes a storage available by its BLOB_STORAGE name
+func RegisterBackend(name string, backend *Backend) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	backends[name] = backend
+}
+
+// GetBackend returns the storage registered with given name, after setting it up
+func GetBackend(name string) (*Backend, error) {
+	backendsLock.RLock()
+	backend, ok := backends[name]
+	backendsLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown blob storage '%s', possible values are: %v", name, BackendNames())
+	}
+	if backend.Setup != nil {
+		backend.Setup()
+	}
+	return backend, nil
+}
+
+// BackendNames returns the names of all registered storages
+func BackendNames() []string {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,9 +1,12 @@
 package blob_test
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	awss3 "github.com/aws/aws-sdk-go/service/s3"
@@ -63,6 +66,8 @@ var tests = []struct {
 	test blobTestCase
 }{
 	{"AllOperations", AllOperations},
+	{"StoreFromStream", StoreFromStream},
+	{"OpenAsStream", OpenAsStream},
 	{"DeleteUnkownFile", DeleteUnkownFile},
 	{"KeyFormats", KeyFormats},
 	{"PathTraversalOnRead", PathTraversalOnRead},
@@ -135,6 +140,48 @@ func AllOperations(ctx context.Context) {
 	}
 }
 
+func StoreFromStream(ctx context.Context) {
+	content, _ := os.ReadFile(env.Path("/app/services/blob/testdata/file2.png"))
+	err := bus.Dispatch(ctx, &cmd.StoreBlobStream{
+		Key:         "backups/file2.png",
+		Reader:      bytes.NewReader(content),
+		ContentType: "image/png",
+	})
+	Expect(err).IsNil()
+
+	q := &query.GetBlobByKey{Key: "backups/file2.png"}
+	err = bus.Dispatch(ctx, q)
+	Expect(err).IsNil()
+	Expect(q.Result.Size).Equals(int64(len(content)))
+	Expect(q.Result.Content).Equals(content)
+
+	err = bus.Dispatch(ctx, &cmd.DeleteBlob{Key: "backups/file2.png"})
+	Expect(err).IsNil()
+}
+
+func OpenAsStream(ctx context.Context) {
+	content, _ := os.ReadFile(env.Path("/app/services/blob/testdata/file2.png"))
+	err := bus.Dispatch(ctx, &cmd.StoreBlob{Key: "images/file2.png", Content: content, ContentType: "image/png"})
+	Expect(err).IsNil()
+
+	q := &query.OpenBlob{Key: "images/file2.png"}
+	err = bus.Dispatch(ctx, q)
+	Expect(err).IsNil()
+	read, err := io.ReadAll(q.Result.Reader)
+	Expect(err).IsNil()
+	Expect(q.Result.Reader.Close()).IsNil()
+	Expect(read).Equals(content)
+	Expect(q.Result.ContentType).Equals("image/png")
+	Expect(q.Result.ModifiedAt).TemporarilySimilar(time.Now(), time.Minute)
+
+	err = bus.Dispatch(ctx, &cmd.DeleteBlob{Key: "images/file2.png"})
+	Expect(err).IsNil()
+
+	q = &query.OpenBlob{Key: "images/file2.png"}
+	err = bus.Dispatch(ctx, q)
+	Expect(errors.Cause(err)).Equals(blob.ErrNotFound)
+}
+
 func DeleteUnkownFile(ctx context.Context) {
 	err := bus.Dispatch(ctx, &cmd.DeleteBlob{
 		Key: "path/somefile.txt",
@@ -0,0 +1,185 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// CopyOptions of a copy between storages
+type CopyOptions struct {
+	// Verify compares the checksum of blobs that already exist on the target, copying them again when they differ,
+	// unless the blob on the target is newer. Otherwise they're skipped, which is what allows an interrupted copy to be resumed
+	Verify bool
+	// Progress is called after each blob is handled, with the number of blobs handled so far
+	Progress func(done, total int, key string, status CopyStatus)
+}
+
+// CopyStatus is what happened to a blob during a copy
+type CopyStatus string
+
+const (
+	// CopyStatusCopied is used when the blob was copied to the target
+	CopyStatusCopied CopyStatus = "copied"
+	// CopyStatusSkipped is used when the blob already existed on the target
+	CopyStatusSkipped CopyStatus = "skipped"
+	// CopyStatusNewer is used when the blob on the target differs and was modified after the one on the source, so it's kept
+	CopyStatusNewer CopyStatus = "newer"
+)
+
+// CopyReport is the result of a copy between storages
+type CopyReport struct {
+	Total   int
+	Copied  int
+	Skipped int
+	Newer   int
+	Bytes   int64
+}
+
+// Copy copies every blob of current tenant, or the blobs outside of any tenant when there's none on ctx,
+// from one storage to another. Blobs are streamed, and each copy is read back from the target and compared with the source checksum
+func Copy(ctx context.Context, from, to *Backend, opts CopyOptions) (*CopyReport, error) {
+	sourceBlobs := &query.ListBlobs{}
+	if err := from.ListBlobs(ctx, sourceBlobs); err != nil {
+		return nil, errors.Wrap(err, "failed to list blobs from source")
+	}
+
+	targetBlobs := &query.ListBlobs{}
+	if err := to.ListBlobs(ctx, targetBlobs); err != nil {
+		return nil, errors.Wrap(err, "failed to list blobs from target")
+	}
+
+	existing := make(map[string]bool, len(targetBlobs.Result))
+	for _, key := range targetBlobs.Result {
+		existing[key] = true
+	}
+
+	// blobs of tenants are listed along with the ones outside of any tenant on some storages
+	hasTenant := ctx.Value(app.TenantCtxKey) != nil
+	keys := make([]string, 0, len(sourceBlobs.Result))
+	for _, key := range sourceBlobs.Result {
+		if hasTenant || !strings.HasPrefix(key, "tenants/") {
+			keys = append(keys, key)
+		}
+	}
+
+	report := &CopyReport{Total: len(keys)}
+	for i, key := range keys {
+		status, size, err := copyBlob(ctx, from, to, key, existing[key], opts.Verify)
+		if err != nil {
+			return report, err
+		}
+
+		switch status {
+		case CopyStatusCopied:
+			report.Copied++
+			report.Bytes += size
+		case CopyStatusNewer:
+			report.Newer++
+		default:
+			report.Skipped++
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(i+1, report.Total, key, status)
+		}
+	}
+	return report, nil
+}
+
+func copyBlob(ctx context.Context, from, to *Backend, key string, exists, verify bool) (CopyStatus, int64, error) {
+	if exists && !verify {
+		return CopyStatusSkipped, 0, nil
+	}
+
+	if exists {
+		sourceSum, sourceModifiedAt, err := checksumOf(ctx, from, key)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "failed to read blob '%s' from source", key)
+		}
+		targetSum, targetModifiedAt, err := checksumOf(ctx, to, key)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "failed to read blob '%s' from target", key)
+		}
+		if bytes.Equal(sourceSum, targetSum) {
+			return CopyStatusSkipped, 0, nil
+		}
+		// it was written on the target after the source, most likely by a site that already uses the target
+		if !sourceModifiedAt.IsZero() && targetModifiedAt.After(sourceModifiedAt) {
+			return CopyStatusNewer, 0, nil
+		}
+	}
+
+	source := &query.OpenBlob{Key: key}
+	if err := from.OpenBlob(ctx, source); err != nil {
+		return "", 0, errors.Wrap(err, "failed to read blob '%s' from source", key)
+	}
+	defer source.Result.Reader.Close()
+
+	reader := newHashingReader(source.Result.Reader)
+	err := to.StoreBlobStream(ctx, &cmd.StoreBlobStream{
+		Key:         key,
+		Reader:      reader,
+		ContentType: source.Result.ContentType,
+	})
+	if err != nil {
+		return "", 0, errors.Wrap(err, "failed to store blob '%s' on target", key)
+	}
+
+	copiedSum, _, err := checksumOf(ctx, to, key)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "failed to read blob '%s' back from target", key)
+	}
+	if !bytes.Equal(copiedSum, reader.Sum()) {
+		return "", 0, errors.New("checksum of blob '%s' on target doesn't match the source", key)
+	}
+
+	return CopyStatusCopied, reader.size, nil
+}
+
+// checksumOf reads a blob as a stream and returns its checksum along with when it was last modified
+func checksumOf(ctx context.Context, backend *Backend, key string) ([]byte, time.Time, error) {
+	q := &query.OpenBlob{Key: key}
+	if err := backend.OpenBlob(ctx, q); err != nil {
+		return nil, time.Time{}, err
+	}
+	defer q.Result.Reader.Close()
+
+	reader := newHashingReader(q.Result.Reader)
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return nil, time.Time{}, err
+	}
+	return reader.Sum(), q.Result.ModifiedAt, nil
+}
+
+// hashingReader computes the checksum and size of the content as it's read
+type hashingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
+
+func newHashingReader(reader io.Reader) *hashingReader {
+	return &hashingReader{reader: reader, hash: sha256.New()}
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
+
+// Sum returns the checksum of the content read so far
+func (r *hashingReader) Sum() []byte {
+	return r.hash.Sum(nil)
+}
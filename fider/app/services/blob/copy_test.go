@@ -0,0 +1,198 @@
+package blob_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/services/blob"
+)
+
+// memoryBackend keeps blobs in memory, with tenant blobs under tenants/<id>/ like fs and s3
+type memoryBackend struct {
+	blobs    map[string]*dto.Blob
+	modified map[string]time.Time
+	// corrupt changes the content of blobs when they're stored
+	corrupt bool
+}
+
+func newMemoryBackend(blobs map[string]string) *memoryBackend {
+	m := &memoryBackend{blobs: make(map[string]*dto.Blob), modified: make(map[string]time.Time)}
+	for key, content := range blobs {
+		m.blobs[key] = &dto.Blob{Content: []byte(content), ContentType: "text/plain", Size: int64(len(content))}
+		m.modified[key] = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return m
+}
+
+func (m *memoryBackend) fullKey(ctx context.Context, key string) string {
+	if ctx.Value(app.TenantCtxKey) != nil {
+		return "tenants/1/" + key
+	}
+	return key
+}
+
+func (m *memoryBackend) backend() *blob.Backend {
+	return &blob.Backend{
+		ListBlobs: func(ctx context.Context, q *query.ListBlobs) error {
+			prefix := m.fullKey(ctx, q.Prefix)
+			q.Result = make([]string, 0)
+			for key := range m.blobs {
+				if strings.HasPrefix(key, prefix) {
+					q.Result = append(q.Result, q.Prefix+key[len(prefix):])
+				}
+			}
+			sort.Strings(q.Result)
+			return nil
+		},
+		GetBlobByKey: func(ctx context.Context, q *query.GetBlobByKey) error {
+			b, ok := m.blobs[m.fullKey(ctx, q.Key)]
+			if !ok {
+				return blob.ErrNotFound
+			}
+			q.Result = b
+			return nil
+		},
+		OpenBlob: func(ctx context.Context, q *query.OpenBlob) error {
+			b, ok := m.blobs[m.fullKey(ctx, q.Key)]
+			if !ok {
+				return blob.ErrNotFound
+			}
+			q.Result = &dto.BlobReader{
+				Reader:      io.NopCloser(bytes.NewReader(b.Content)),
+				ContentType: b.ContentType,
+				ModifiedAt:  m.modified[m.fullKey(ctx, q.Key)],
+			}
+			return nil
+		},
+		StoreBlob: func(ctx context.Context, c *cmd.StoreBlob) error {
+			m.blobs[m.fullKey(ctx, c.Key)] = &dto.Blob{Content: c.Content, ContentType: c.ContentType, Size: int64(len(c.Content))}
+			return nil
+		},
+		StoreBlobStream: func(ctx context.Context, c *cmd.StoreBlobStream) error {
+			content, err := io.ReadAll(c.Reader)
+			if err != nil {
+				return err
+			}
+			if m.corrupt {
+				content = append(content, '!')
+			}
+			m.blobs[m.fullKey(ctx, c.Key)] = &dto.Blob{Content: content, ContentType: c.ContentType, Size: int64(len(content))}
+			m.modified[m.fullKey(ctx, c.Key)] = time.Now()
+			return nil
+		},
+		DeleteBlob: func(ctx context.Context, c *cmd.DeleteBlob) error {
+			delete(m.blobs, m.fullKey(ctx, c.Key))
+			return nil
+		},
+	}
+}
+
+func TestCopy_CopiesAndResumes(t *testing.T) {
+	RegisterT(t)
+
+	source := newMemoryBackend(map[string]string{
+		"etc/config.txt":              "config",
+		"tenants/1/logos/logo.png":    "logo",
+		"tenants/1/attachments/a.png": "image",
+	})
+	target := newMemoryBackend(map[string]string{
+		"tenants/1/logos/logo.png": "old logo",
+	})
+
+	report, err := blob.Copy(context.Background(), source.backend(), target.backend(), blob.CopyOptions{})
+	Expect(err).IsNil()
+	Expect(report.Total).Equals(1)
+	Expect(report.Copied).Equals(1)
+	Expect(string(target.blobs["etc/config.txt"].Content)).Equals("config")
+
+	tenantCtx := context.WithValue(context.Background(), app.TenantCtxKey, tenant1)
+	progress := make([]string, 0)
+	report, err = blob.Copy(tenantCtx, source.backend(), target.backend(), blob.CopyOptions{
+		Progress: func(done, total int, key string, status blob.CopyStatus) {
+			progress = append(progress, key+":"+string(status))
+			Expect(total).Equals(2)
+		},
+	})
+	Expect(err).IsNil()
+	Expect(report.Copied).Equals(1)
+	Expect(report.Skipped).Equals(1)
+	Expect(report.Bytes).Equals(int64(5))
+	Expect(progress).Equals([]string{"attachments/a.png:copied", "logos/logo.png:skipped"})
+	Expect(string(target.blobs["tenants/1/attachments/a.png"].Content)).Equals("image")
+	Expect(target.blobs["tenants/1/attachments/a.png"].ContentType).Equals("text/plain")
+	Expect(string(target.blobs["tenants/1/logos/logo.png"].Content)).Equals("old logo")
+
+	report, err = blob.Copy(tenantCtx, source.backend(), target.backend(), blob.CopyOptions{Verify: true})
+	Expect(err).IsNil()
+	Expect(report.Copied).Equals(1)
+	Expect(report.Skipped).Equals(1)
+	Expect(string(target.blobs["tenants/1/logos/logo.png"].Content)).Equals("logo")
+}
+
+func TestCopy_Verify_KeepsNewerBlobsOnTarget(t *testing.T) {
+	RegisterT(t)
+
+	source := newMemoryBackend(map[string]string{
+		"logos/logo.png":    "logo",
+		"logos/banner.png":  "banner",
+		"logos/favicon.png": "favicon",
+	})
+	target := newMemoryBackend(map[string]string{
+		"logos/logo.png":   "new logo",
+		"logos/banner.png": "old banner",
+	})
+	target.modified["logos/logo.png"] = time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	target.modified["logos/banner.png"] = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	progress := make([]string, 0)
+	report, err := blob.Copy(context.Background(), source.backend(), target.backend(), blob.CopyOptions{
+		Verify: true,
+		Progress: func(done, total int, key string, status blob.CopyStatus) {
+			progress = append(progress, key+":"+string(status))
+		},
+	})
+	Expect(err).IsNil()
+	Expect(report.Copied).Equals(2)
+	Expect(report.Newer).Equals(1)
+	Expect(report.Bytes).Equals(int64(13))
+	Expect(progress).Equals([]string{"logos/banner.png:copied", "logos/favicon.png:copied", "logos/logo.png:newer"})
+	Expect(string(target.blobs["logos/logo.png"].Content)).Equals("new logo")
+	Expect(string(target.blobs["logos/banner.png"].Content)).Equals("banner")
+}
+
+func TestCopy_FailsWhenChecksumDoesNotMatch(t *testing.T) {
+	RegisterT(t)
+
+	source := newMemoryBackend(map[string]string{"logos/logo.png": "logo"})
+	target := newMemoryBackend(nil)
+	target.corrupt = true
+
+	report, err := blob.Copy(context.Background(), source.backend(), target.backend(), blob.CopyOptions{})
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("checksum of blob 'logos/logo.png'")
+	Expect(report.Copied).Equals(0)
+}
+
+func TestGetBackend(t *testing.T) {
+	RegisterT(t)
+
+	Expect(blob.BackendNames()).Equals([]string{"fs", "s3", "sql"})
+
+	backend, err := blob.GetBackend("fs")
+	Expect(err).IsNil()
+	Expect(backend.GetBlobByKey).IsNotNil()
+
+	backend, err = blob.GetBackend("ftp")
+	Expect(err).IsNotNil()
+	Expect(backend).IsNil()
+}
@@ -1,7 +1,9 @@
 package fs
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"path"
@@ -26,6 +28,14 @@ var perm os.FileMode = 0744
 
 func init() {
 	bus.Register(Service{})
+	blob.RegisterBackend("fs", &blob.Backend{
+		ListBlobs:       listBlobs,
+		GetBlobByKey:    getBlobByKey,
+		OpenBlob:        openBlob,
+		StoreBlob:       storeBlob,
+		StoreBlobStream: storeBlobStream,
+		DeleteBlob:      deleteBlob,
+	})
 }
 
 type Service struct{}
@@ -45,7 +55,9 @@ func (s Service) Enabled() bool {
 func (s Service) Init() {
 	bus.AddHandler(listBlobs)
 	bus.AddHandler(getBlobByKey)
+	bus.AddHandler(openBlob)
 	bus.AddHandler(storeBlob)
+	bus.AddHandler(storeBlobStream)
 	bus.AddHandler(deleteBlob)
 }
 
@@ -99,6 +111,46 @@ func getBlobByKey(ctx context.Context, q *query.GetBlobByKey) error {
 	return nil
 }
 
+func openBlob(ctx context.Context, q *query.OpenBlob) error {
+	if err := blob.ValidateKey(q.Key); err != nil {
+		return errors.Wrap(err, "failed to validate blob key '%s'", q.Key)
+	}
+
+	fullPath := keyFullPath(ctx, q.Key)
+	file, err := os.Open(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return blob.ErrNotFound
+		}
+		return errors.Wrap(err, "failed to open '%s' from FileSystem", q.Key)
+	}
+
+	stats, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to get stats '%s' from FileSystem", q.Key)
+	}
+
+	// the content type is detected from the first bytes, which are then read again along with the rest
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to read '%s' from FileSystem", q.Key)
+	}
+	head = head[:n]
+
+	q.Result = &dto.BlobReader{
+		Reader: struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), file), file},
+		ContentType: http.DetectContentType(head),
+		ModifiedAt:  stats.ModTime(),
+	}
+	return nil
+}
+
 func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	if err := blob.ValidateKey(c.Key); err != nil {
 		return errors.Wrap(err, "failed to validate blob key '%s'", c.Key)
@@ -119,6 +171,41 @@ func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	return nil
 }
 
+func storeBlobStream(ctx context.Context, c *cmd.StoreBlobStream) error {
+	if err := blob.ValidateKey(c.Key); err != nil {
+		return errors.Wrap(err, "failed to validate blob key '%s'", c.Key)
+	}
+
+	fullPath := keyFullPath(ctx, c.Key)
+	err := os.MkdirAll(filepath.Dir(fullPath), perm)
+	if err != nil {
+		return errors.Wrap(err, "failed to create folder '%s' on FileSystem", fullPath)
+	}
+
+	// Content is written to a temporary file first, so a failed stream never leaves a partial blob behind
+	file, err := os.CreateTemp(filepath.Dir(fullPath), ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file for '%s' on FileSystem", fullPath)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, c.Reader); err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to write file '%s' on FileSystem", fullPath)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close file '%s' on FileSystem", fullPath)
+	}
+	if err := os.Chmod(file.Name(), perm); err != nil {
+		return errors.Wrap(err, "failed to change mode of file '%s' on FileSystem", fullPath)
+	}
+	if err := os.Rename(file.Name(), fullPath); err != nil {
+		return errors.Wrap(err, "failed to create file '%s' on FileSystem", fullPath)
+	}
+
+	return nil
+}
+
 func deleteBlob(ctx context.Context, c *cmd.DeleteBlob) error {
 	fullPath := keyFullPath(ctx, c.Key)
 	err := os.Remove(fullPath)
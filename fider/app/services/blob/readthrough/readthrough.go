@@ -0,0 +1,118 @@
+package readthrough
+
+import (
+	"context"
+	"sort"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/blob"
+
+	// storages are imported so that they're initialized before this service, which overrides their handlers
+	_ "github.com/getfider/fider/app/services/blob/fs"
+	_ "github.com/getfider/fider/app/services/blob/s3"
+	_ "github.com/getfider/fider/app/services/blob/sql"
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+var (
+	target   *blob.Backend
+	fallback *blob.Backend
+)
+
+// Service is used while migrating between storages. Blobs are written to BLOB_STORAGE
+// and read from BLOB_STORAGE_FALLBACK when they're not found on BLOB_STORAGE
+type Service struct{}
+
+func (s Service) Name() string {
+	return "ReadThrough"
+}
+
+func (s Service) Category() string {
+	return "blobstorage"
+}
+
+func (s Service) Enabled() bool {
+	return env.Config.BlobStorage.Fallback != "" && env.Config.BlobStorage.Fallback != env.Config.BlobStorage.Type
+}
+
+func (s Service) Init() {
+	var err error
+	if target, err = blob.GetBackend(env.Config.BlobStorage.Type); err != nil {
+		panic(err)
+	}
+	if fallback, err = blob.GetBackend(env.Config.BlobStorage.Fallback); err != nil {
+		panic(err)
+	}
+
+	bus.AddHandler(listBlobs)
+	bus.AddHandler(getBlobByKey)
+	bus.AddHandler(openBlob)
+	bus.AddHandler(storeBlob)
+	bus.AddHandler(storeBlobStream)
+	bus.AddHandler(deleteBlob)
+}
+
+// listBlobs returns the blobs of both storages, so that nothing is missed until the migration is done
+func listBlobs(ctx context.Context, q *query.ListBlobs) error {
+	fromTarget := &query.ListBlobs{Prefix: q.Prefix}
+	if err := target.ListBlobs(ctx, fromTarget); err != nil {
+		return err
+	}
+
+	fromFallback := &query.ListBlobs{Prefix: q.Prefix}
+	if err := fallback.ListBlobs(ctx, fromFallback); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(fromTarget.Result))
+	files := make([]string, 0, len(fromTarget.Result)+len(fromFallback.Result))
+	for _, key := range append(fromTarget.Result, fromFallback.Result...) {
+		if !seen[key] {
+			seen[key] = true
+			files = append(files, key)
+		}
+	}
+
+	sort.Strings(files)
+	q.Result = files
+	return nil
+}
+
+func getBlobByKey(ctx context.Context, q *query.GetBlobByKey) error {
+	err := target.GetBlobByKey(ctx, q)
+	if errors.Cause(err) != blob.ErrNotFound {
+		return err
+	}
+	return fallback.GetBlobByKey(ctx, q)
+}
+
+func openBlob(ctx context.Context, q *query.OpenBlob) error {
+	err := target.OpenBlob(ctx, q)
+	if errors.Cause(err) != blob.ErrNotFound {
+		return err
+	}
+	return fallback.OpenBlob(ctx, q)
+}
+
+func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
+	return target.StoreBlob(ctx, c)
+}
+
+func storeBlobStream(ctx context.Context, c *cmd.StoreBlobStream) error {
+	return target.StoreBlobStream(ctx, c)
+}
+
+// deleteBlob removes the blob from both storages, otherwise it'd still be read from the fallback
+func deleteBlob(ctx context.Context, c *cmd.DeleteBlob) error {
+	if err := target.DeleteBlob(ctx, c); err != nil {
+		return err
+	}
+	return fallback.DeleteBlob(ctx, c)
+}
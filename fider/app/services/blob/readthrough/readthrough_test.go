@@ -0,0 +1,113 @@
+package readthrough_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/blob"
+	"github.com/getfider/fider/app/services/blob/readthrough"
+)
+
+func memoryBackend(blobs map[string]string) *blob.Backend {
+	return &blob.Backend{
+		ListBlobs: func(ctx context.Context, q *query.ListBlobs) error {
+			q.Result = make([]string, 0)
+			for key := range blobs {
+				if strings.HasPrefix(key, q.Prefix) {
+					q.Result = append(q.Result, key)
+				}
+			}
+			sort.Strings(q.Result)
+			return nil
+		},
+		GetBlobByKey: func(ctx context.Context, q *query.GetBlobByKey) error {
+			content, ok := blobs[q.Key]
+			if !ok {
+				return errors.Wrap(blob.ErrNotFound, "unable to find blob '%s'", q.Key)
+			}
+			q.Result = &dto.Blob{Content: []byte(content), Size: int64(len(content))}
+			return nil
+		},
+		StoreBlob: func(ctx context.Context, c *cmd.StoreBlob) error {
+			blobs[c.Key] = string(c.Content)
+			return nil
+		},
+		StoreBlobStream: func(ctx context.Context, c *cmd.StoreBlobStream) error {
+			content, err := io.ReadAll(c.Reader)
+			blobs[c.Key] = string(content)
+			return err
+		},
+		DeleteBlob: func(ctx context.Context, c *cmd.DeleteBlob) error {
+			delete(blobs, c.Key)
+			return nil
+		},
+	}
+}
+
+func TestReadThrough(t *testing.T) {
+	RegisterT(t)
+
+	oldBlobs := map[string]string{"logos/old.png": "old", "logos/both.png": "old both"}
+	newBlobs := map[string]string{"logos/both.png": "new both"}
+	blob.RegisterBackend("memory-old", memoryBackend(oldBlobs))
+	blob.RegisterBackend("memory-new", memoryBackend(newBlobs))
+
+	storageType := env.Config.BlobStorage.Type
+	env.Config.BlobStorage.Type = "memory-new"
+	env.Config.BlobStorage.Fallback = "memory-old"
+	defer func() {
+		env.Config.BlobStorage.Type = storageType
+		env.Config.BlobStorage.Fallback = ""
+	}()
+
+	Expect(readthrough.Service{}.Enabled()).IsTrue()
+	bus.Init(readthrough.Service{})
+
+	ctx := context.Background()
+	getOld := &query.GetBlobByKey{Key: "logos/old.png"}
+	getBoth := &query.GetBlobByKey{Key: "logos/both.png"}
+	err := bus.Dispatch(ctx, getOld, getBoth)
+	Expect(err).IsNil()
+	Expect(string(getOld.Result.Content)).Equals("old")
+	Expect(string(getBoth.Result.Content)).Equals("new both")
+
+	err = bus.Dispatch(ctx, &query.GetBlobByKey{Key: "logos/none.png"})
+	Expect(errors.Cause(err)).Equals(blob.ErrNotFound)
+
+	err = bus.Dispatch(ctx, &cmd.StoreBlob{Key: "logos/new.png", Content: []byte("new")})
+	Expect(err).IsNil()
+	Expect(newBlobs["logos/new.png"]).Equals("new")
+	_, ok := oldBlobs["logos/new.png"]
+	Expect(ok).IsFalse()
+
+	list := &query.ListBlobs{Prefix: "logos/"}
+	err = bus.Dispatch(ctx, list)
+	Expect(err).IsNil()
+	Expect(list.Result).Equals([]string{"logos/both.png", "logos/new.png", "logos/old.png"})
+
+	err = bus.Dispatch(ctx, &cmd.DeleteBlob{Key: "logos/both.png"})
+	Expect(err).IsNil()
+	Expect(newBlobs).Equals(map[string]string{"logos/new.png": "new"})
+	Expect(oldBlobs).Equals(map[string]string{"logos/old.png": "old"})
+}
+
+func TestReadThrough_DisabledWithoutFallback(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.BlobStorage.Fallback = ""
+	Expect(readthrough.Service{}.Enabled()).IsFalse()
+
+	env.Config.BlobStorage.Fallback = env.Config.BlobStorage.Type
+	defer func() { env.Config.BlobStorage.Fallback = "" }()
+	Expect(readthrough.Service{}.Enabled()).IsFalse()
+}
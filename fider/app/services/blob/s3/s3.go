@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/getfider/fider/app"
 
 	"github.com/getfider/fider/app/models/cmd"
@@ -33,6 +34,15 @@ var DefaultClient *s3.S3
 
 func init() {
 	bus.Register(Service{})
+	blob.RegisterBackend("s3", &blob.Backend{
+		Setup:           setupClient,
+		ListBlobs:       listBlobs,
+		GetBlobByKey:    getBlobByKey,
+		OpenBlob:        openBlob,
+		StoreBlob:       storeBlob,
+		StoreBlobStream: storeBlobStream,
+		DeleteBlob:      deleteBlob,
+	})
 }
 
 type Service struct{}
@@ -50,6 +60,17 @@ func (s Service) Enabled() bool {
 }
 
 func (s Service) Init() {
+	setupClient()
+
+	bus.AddHandler(listBlobs)
+	bus.AddHandler(getBlobByKey)
+	bus.AddHandler(openBlob)
+	bus.AddHandler(storeBlob)
+	bus.AddHandler(storeBlobStream)
+	bus.AddHandler(deleteBlob)
+}
+
+func setupClient() {
 	s3EnvConfig := env.Config.BlobStorage.S3
 	if s3EnvConfig.EndpointURL != "" {
 		s3Config := &aws.Config{
@@ -66,39 +87,31 @@ func (s Service) Init() {
 
 		DefaultClient = s3.New(awsSession)
 	}
-
-	bus.AddHandler(listBlobs)
-	bus.AddHandler(getBlobByKey)
-	bus.AddHandler(storeBlob)
-	bus.AddHandler(deleteBlob)
 }
 
 func listBlobs(ctx context.Context, q *query.ListBlobs) error {
 	prefix := basePath(ctx, q.Prefix)
-	response, err := DefaultClient.ListObjectsWithContext(ctx, &s3.ListObjectsInput{
+	files := make([]string, 0)
+	err := DefaultClient.ListObjectsPagesWithContext(ctx, &s3.ListObjectsInput{
 		Bucket:  aws.String(env.Config.BlobStorage.S3.BucketName),
 		MaxKeys: aws.Int64(1000),
 		Prefix:  aws.String(prefix),
-	})
-	if err != nil {
-		return wrap(err, "failed to list blobs from S3")
-	}
-
-	if response.IsTruncated != nil && *response.IsTruncated {
-		return wrap(err, "failed to return list of blobs because it was truncated")
-	}
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, item := range page.Contents {
+			key := *item.Key
 
-	files := make([]string, 0)
-	for _, item := range response.Contents {
-		key := *item.Key
+			// if it ends with '/' it's not an actual blob
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
 
-		// if it ends with '/' it's not an actual blob
-		if strings.HasSuffix(key, "/") {
-			continue
+			fullKey := q.Prefix + key[len(prefix):]
+			files = append(files, strings.TrimLeft(fullKey, "/"))
 		}
-
-		fullKey := q.Prefix + key[len(prefix):]
-		files = append(files, strings.TrimLeft(fullKey, "/"))
+		return true
+	})
+	if err != nil {
+		return wrap(err, "failed to list blobs from S3")
 	}
 
 	sort.Strings(files)
@@ -136,6 +149,30 @@ func getBlobByKey(ctx context.Context, q *query.GetBlobByKey) error {
 	return nil
 }
 
+func openBlob(ctx context.Context, q *query.OpenBlob) error {
+	if err := blob.ValidateKey(q.Key); err != nil {
+		return wrap(err, "failed to validate blob key '%s'", q.Key)
+	}
+
+	resp, err := DefaultClient.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(env.Config.BlobStorage.S3.BucketName),
+		Key:    aws.String(keyFullPathURL(ctx, q.Key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return wrap(blob.ErrNotFound, "unable to find blob '%s' on S3", q.Key)
+		}
+		return wrap(err, "failed to get blob '%s' from S3", q.Key)
+	}
+
+	q.Result = &dto.BlobReader{
+		Reader:      resp.Body,
+		ContentType: aws.StringValue(resp.ContentType),
+		ModifiedAt:  aws.TimeValue(resp.LastModified),
+	}
+	return nil
+}
+
 func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	if err := blob.ValidateKey(c.Key); err != nil {
 		return wrap(err, "failed to validate blob key '%s'", c.Key)
@@ -155,6 +192,26 @@ func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	return nil
 }
 
+func storeBlobStream(ctx context.Context, c *cmd.StoreBlobStream) error {
+	if err := blob.ValidateKey(c.Key); err != nil {
+		return wrap(err, "failed to validate blob key '%s'", c.Key)
+	}
+
+	// The uploader sends the content in parts, so it doesn't need to know its size upfront
+	uploader := s3manager.NewUploaderWithClient(DefaultClient)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(env.Config.BlobStorage.S3.BucketName),
+		Key:         aws.String(keyFullPathURL(ctx, c.Key)),
+		ContentType: aws.String(c.ContentType),
+		ACL:         aws.String(s3.ObjectCannedACLPrivate),
+		Body:        c.Reader,
+	})
+	if err != nil {
+		return wrap(err, "failed to upload blob '%s' to S3", c.Key)
+	}
+	return nil
+}
+
 func deleteBlob(ctx context.Context, c *cmd.DeleteBlob) error {
 	_, err := DefaultClient.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(env.Config.BlobStorage.S3.BucketName),
@@ -1,8 +1,10 @@
 package sql
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"io"
 	"sort"
 	"time"
 
@@ -23,6 +25,14 @@ import (
 
 func init() {
 	bus.Register(Service{})
+	blob.RegisterBackend("sql", &blob.Backend{
+		ListBlobs:       listBlobs,
+		GetBlobByKey:    getBlobByKey,
+		OpenBlob:        openBlob,
+		StoreBlob:       storeBlob,
+		StoreBlobStream: storeBlobStream,
+		DeleteBlob:      deleteBlob,
+	})
 }
 
 type Service struct{}
@@ -42,15 +52,18 @@ func (s Service) Enabled() bool {
 func (s Service) Init() {
 	bus.AddHandler(listBlobs)
 	bus.AddHandler(getBlobByKey)
+	bus.AddHandler(openBlob)
 	bus.AddHandler(storeBlob)
+	bus.AddHandler(storeBlobStream)
 	bus.AddHandler(deleteBlob)
 }
 
 type dbBlob struct {
-	Key         string `db:"key"`
-	ContentType string `db:"content_type"`
-	Size        int64  `db:"size"`
-	Content     []byte `db:"file"`
+	Key         string    `db:"key"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Content     []byte    `db:"file"`
+	ModifiedAt  time.Time `db:"modified_at"`
 }
 
 func listBlobs(ctx context.Context, q *query.ListBlobs) error {
@@ -112,6 +125,39 @@ func getBlobByKey(ctx context.Context, q *query.GetBlobByKey) error {
 	})
 }
 
+// openBlob reads the whole content in memory, as blobs are stored in a single column
+func openBlob(ctx context.Context, q *query.OpenBlob) error {
+	if err := blob.ValidateKey(q.Key); err != nil {
+		return errors.Wrap(err, "failed to validate blob key '%s'", q.Key)
+	}
+
+	blob.EnsureAuthorizedPrefix(ctx, q.Key)
+
+	return using(ctx, func(tenantID sql.NullInt64) error {
+		trx, err := dbx.BeginTx(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to open transaction")
+		}
+		defer trx.MustCommit()
+
+		b := dbBlob{}
+		err = trx.Get(&b, "SELECT file, content_type, modified_at FROM blobs WHERE key = $1 AND (tenant_id = $2 OR ($2 IS NULL AND tenant_id IS NULL))", q.Key, tenantID)
+		if err != nil {
+			if err == app.ErrNotFound {
+				return blob.ErrNotFound
+			}
+			return errors.Wrap(err, "failed to get blob with key '%s'", q.Key)
+		}
+
+		q.Result = &dto.BlobReader{
+			Reader:      io.NopCloser(bytes.NewReader(b.Content)),
+			ContentType: b.ContentType,
+			ModifiedAt:  b.ModifiedAt,
+		}
+		return nil
+	})
+}
+
 func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	blob.EnsureAuthorizedPrefix(ctx, c.Key)
 
@@ -144,6 +190,20 @@ func storeBlob(ctx context.Context, c *cmd.StoreBlob) error {
 	})
 }
 
+// storeBlobStream reads the whole content in memory, as blobs are stored in a single column
+func storeBlobStream(ctx context.Context, c *cmd.StoreBlobStream) error {
+	content, err := io.ReadAll(c.Reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read blob with key '%s'", c.Key)
+	}
+
+	return storeBlob(ctx, &cmd.StoreBlob{
+		Key:         c.Key,
+		Content:     content,
+		ContentType: c.ContentType,
+	})
+}
+
 func deleteBlob(ctx context.Context, c *cmd.DeleteBlob) error {
 	blob.EnsureAuthorizedPrefix(ctx, c.Key)
 	
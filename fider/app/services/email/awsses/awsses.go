@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	ses "github.com/aws/aws-sdk-go/service/sesv2"
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
@@ -119,8 +120,10 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 		result, err := sesClient.SendEmailWithContext(ctx, input)
 		if err != nil {
+			metrics.EmailFailures.WithLabelValues("awsses", c.TemplateName).Inc()
 			panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
 		}
+		metrics.EmailsSent.WithLabelValues("awsses", c.TemplateName).Inc()
 
 		log.Debugf(ctx, "Email sent with ID @{MessageId}.", dto.Props{
 			"MessageId": *result.MessageId,
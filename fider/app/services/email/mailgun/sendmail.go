@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
@@ -114,6 +115,11 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		},
 	}
 	err := bus.Dispatch(ctx, req)
+	if err != nil || req.ResponseStatusCode >= 400 {
+		metrics.EmailFailures.WithLabelValues("mailgun", c.TemplateName).Add(float64(len(recipientVariables)))
+	} else {
+		metrics.EmailsSent.WithLabelValues("mailgun", c.TemplateName).Add(float64(len(recipientVariables)))
+	}
 	if err != nil {
 		panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
 	}
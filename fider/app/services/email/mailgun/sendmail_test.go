@@ -3,10 +3,12 @@ package mailgun_test
 import (
 	"context"
 	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
@@ -14,6 +16,7 @@ import (
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/services/email/mailgun"
 	"github.com/getfider/fider/app/services/httpclient/httpclientmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/getfider/fider/app/services/email"
 
@@ -319,3 +322,34 @@ func TestGetBaseURL(t *testing.T) {
 	Expect(httpclientmock.RequestsHistory[5].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 
 }
+
+func TestSend_Metrics(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+	reset()
+
+	sent := testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("mailgun", "echo_test"))
+	failures := testutil.ToFloat64(metrics.EmailFailures.WithLabelValues("mailgun", "echo_test"))
+
+	message := &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			{Name: "Jon Sow", Address: "jon.snow@got.com"},
+			{Name: "Arya Stark", Address: "arya.stark@got.com"},
+		},
+		TemplateName: "echo_test",
+		Props:        dto.Props{"name": "Hello"},
+	}
+
+	bus.Publish(ctx, message)
+	Expect(testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("mailgun", "echo_test"))).Equals(sent + 2)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.HTTPRequest) error {
+		c.ResponseStatusCode = http.StatusInternalServerError
+		return nil
+	})
+
+	bus.Publish(ctx, message)
+	Expect(testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("mailgun", "echo_test"))).Equals(sent + 2)
+	Expect(testutil.ToFloat64(metrics.EmailFailures.WithLabelValues("mailgun", "echo_test"))).Equals(failures + 2)
+}
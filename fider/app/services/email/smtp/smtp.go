@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/query"
@@ -102,8 +103,10 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		auth := authenticate(smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
 		err = Send(localname, servername, smtpConfig.EnableStartTLS, auth, email.NoReply, []string{to.Address}, b.Bytes())
 		if err != nil {
+			metrics.EmailFailures.WithLabelValues("smtp", c.TemplateName).Inc()
 			panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
 		}
+		metrics.EmailsSent.WithLabelValues("smtp", c.TemplateName).Inc()
 		log.Debug(ctx, "Email sent.")
 	}
 }
@@ -2,11 +2,14 @@ package smtp_test
 
 import (
 	"context"
+	"errors"
 	gosmtp "net/smtp"
 	"regexp"
 	"testing"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
@@ -160,3 +163,30 @@ func TestBatch_Success(t *testing.T) {
 	Expect(string(requests[1].body)).ContainsSubstring("Message-ID: ")
 	Expect(string(requests[1].body)).ContainsSubstring("Hello World Arya!")
 }
+
+func TestSend_Metrics(t *testing.T) {
+	RegisterT(t)
+	reset()
+
+	sent := testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("smtp", "echo_test"))
+	failures := testutil.ToFloat64(metrics.EmailFailures.WithLabelValues("smtp", "echo_test"))
+
+	message := &cmd.SendMail{
+		From:         dto.Recipient{Name: "Fider Test"},
+		To:           []dto.Recipient{{Name: "Jon Sow", Address: "jon.snow@got.com"}},
+		TemplateName: "echo_test",
+		Props:        dto.Props{"name": "Hello"},
+	}
+
+	bus.Publish(ctx, message)
+	Expect(testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("smtp", "echo_test"))).Equals(sent + 1)
+
+	smtp.Send = func(localname, servername string, enableStartTLS bool, auth gosmtp.Auth, from string, to []string, body []byte) error {
+		return errors.New("connection refused")
+	}
+	Expect(func() {
+		bus.Publish(ctx, message)
+	}).Panics()
+	Expect(testutil.ToFloat64(metrics.EmailsSent.WithLabelValues("smtp", "echo_test"))).Equals(sent + 1)
+	Expect(testutil.ToFloat64(metrics.EmailFailures.WithLabelValues("smtp", "echo_test"))).Equals(failures + 1)
+}
@@ -0,0 +1,111 @@
+package clamav
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+type Service struct{}
+
+func (s Service) Name() string {
+	return "ClamAV"
+}
+
+func (s Service) Category() string {
+	return "filescan"
+}
+
+func (s Service) Enabled() bool {
+	return env.Config.FileScan.Type == "clamav"
+}
+
+func (s Service) Init() {
+	bus.AddHandler(scanFile)
+}
+
+// chunkSize is the size of each chunk streamed to clamd, which must be below its StreamMaxLength
+const chunkSize = 64 * 1024
+
+// scanFile streams the content to clamd with the INSTREAM command
+// See https://docs.clamav.net/manual/Usage/Scanning.html#clamd
+func scanFile(ctx context.Context, c *cmd.ScanFile) error {
+	network, address := parseAddress(env.Config.FileScan.ClamAVAddress)
+
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to clamd on '%s'", env.Config.FileScan.ClamAVAddress)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(env.Config.FileScan.Timeout)); err != nil {
+		return errors.Wrap(err, "failed to set clamd connection deadline")
+	}
+
+	w := bufio.NewWriter(conn)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return errors.Wrap(err, "failed to send INSTREAM command to clamd")
+	}
+
+	size := make([]byte, 4)
+	for content := c.Content; len(content) > 0; {
+		chunk := content[:min(chunkSize, len(content))]
+		content = content[len(chunk):]
+
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err := w.Write(size); err != nil {
+			return errors.Wrap(err, "failed to stream '%s' to clamd", c.FileName)
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return errors.Wrap(err, "failed to stream '%s' to clamd", c.FileName)
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := w.Write(size); err != nil {
+		return errors.Wrap(err, "failed to stream '%s' to clamd", c.FileName)
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(err, "failed to stream '%s' to clamd", c.FileName)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && len(reply) == 0 {
+		return errors.Wrap(err, "failed to read clamd reply for '%s'", c.FileName)
+	}
+
+	// replies are either "stream: OK", "stream: <threat> FOUND" or "<message> ERROR"
+	result := strings.TrimPrefix(string(bytes.TrimRight(reply, "\x00\n")), "stream: ")
+	switch {
+	case result == "OK":
+		c.IsInfected = false
+	case strings.HasSuffix(result, " FOUND"):
+		c.IsInfected = true
+		c.Threat = strings.TrimSuffix(result, " FOUND")
+	default:
+		return errors.New("clamd failed to scan '%s': %s", c.FileName, result)
+	}
+	return nil
+}
+
+// parseAddress accepts unix:/path/to/socket, tcp://host:port or host:port
+func parseAddress(address string) (string, string) {
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", strings.TrimPrefix(address, "tcp://")
+}
@@ -0,0 +1,115 @@
+package clamav_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/services/filescan/clamav"
+)
+
+// startClamd starts a fake clamd that replies to INSTREAM commands and returns the received streams
+func startClamd(t *testing.T, reply func(content []byte) string) <-chan []byte {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).IsNil()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	env.Config.FileScan.ClamAVAddress = "tcp://" + listener.Addr().String()
+	bus.Init(clamav.Service{})
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		command, _ := r.ReadString(0)
+		if command != "zINSTREAM\x00" {
+			_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+
+		content := new(bytes.Buffer)
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			if _, err := io.CopyN(content, r, int64(n)); err != nil {
+				return
+			}
+		}
+
+		received <- content.Bytes()
+		_, _ = conn.Write([]byte(reply(content.Bytes()) + "\x00"))
+	}()
+	return received
+}
+
+func TestScanFile_Clean(t *testing.T) {
+	RegisterT(t)
+
+	content := bytes.Repeat([]byte("log line\n"), 20000)
+	received := startClamd(t, func(content []byte) string {
+		return "stream: OK"
+	})
+
+	scan := &cmd.ScanFile{FileName: "app.log", Content: content}
+	err := bus.Dispatch(context.Background(), scan)
+	Expect(err).IsNil()
+	Expect(scan.IsInfected).IsFalse()
+	Expect(<-received).Equals(content)
+}
+
+func TestScanFile_Infected(t *testing.T) {
+	RegisterT(t)
+
+	startClamd(t, func(content []byte) string {
+		return "stream: Eicar-Test-Signature FOUND"
+	})
+
+	scan := &cmd.ScanFile{FileName: "eicar.txt", Content: []byte("X5O!P%@AP")}
+	err := bus.Dispatch(context.Background(), scan)
+	Expect(err).IsNil()
+	Expect(scan.IsInfected).IsTrue()
+	Expect(scan.Threat).Equals("Eicar-Test-Signature")
+}
+
+func TestScanFile_Error(t *testing.T) {
+	RegisterT(t)
+
+	startClamd(t, func(content []byte) string {
+		return "INSTREAM size limit exceeded. ERROR"
+	})
+
+	scan := &cmd.ScanFile{FileName: "big.log", Content: []byte("...")}
+	err := bus.Dispatch(context.Background(), scan)
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("INSTREAM size limit exceeded")
+}
+
+func TestScanFile_Unavailable(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.FileScan.ClamAVAddress = "unix:/tmp/does-not-exist/clamd.ctl"
+	bus.Init(clamav.Service{})
+
+	scan := &cmd.ScanFile{FileName: "app.log", Content: []byte("...")}
+	err := bus.Dispatch(context.Background(), scan)
+	Expect(err).IsNotNil()
+}
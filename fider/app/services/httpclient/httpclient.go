@@ -9,6 +9,9 @@ import (
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func init() {
@@ -39,11 +42,27 @@ func (s Service) Init() {
 	bus.AddHandler(requestHandler)
 }
 
-func requestHandler(ctx context.Context, c *cmd.HTTPRequest) error {
+func requestHandler(ctx context.Context, c *cmd.HTTPRequest) (err error) {
 	req, err := http.NewRequest(c.Method, c.URL, c.Body)
 	if err != nil {
 		return err
 	}
+
+	// query strings and credentials are left out of the span, as they might contain secrets
+	spanURL := *req.URL
+	spanURL.User = nil
+	spanURL.RawQuery = ""
+	ctx, span := tracing.StartChild(ctx, "HTTP "+c.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", c.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.full", spanURL.String()),
+		),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
 	req = req.WithContext(ctx)
 
 	for k, v := range c.Headers {
@@ -52,11 +71,13 @@ func requestHandler(ctx context.Context, c *cmd.HTTPRequest) error {
 	if c.BasicAuth != nil {
 		req.SetBasicAuth(c.BasicAuth.User, c.BasicAuth.Password)
 	}
+	tracing.Inject(ctx, req.Header)
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
 
 	defer func() { _ = res.Body.Close() }()
 	respBody, err := io.ReadAll(res.Body)
@@ -84,22 +84,15 @@ func logError(ctx context.Context, c *cmd.LogError) {
 }
 
 func writeLog(ctx context.Context, level log.Level, message string, props dto.Props) {
-	if !log.IsEnabled(level) {
+	entry := log.NewEntry(ctx, level, message, props)
+	if entry == nil {
 		return
 	}
 
-	props = log.GetProperties(ctx).Merge(props)
-	props["Level"] = level.String()
-	props["Message"] = log.Parse(message, props, false)
-	props["Timestamp"] = time.Now().Format(time.RFC3339)
-	if props[log.PropertyKeyTag] == nil {
-		props[log.PropertyKeyTag] = "???"
-	}
-
 	if env.Config.Log.Structured {
-		_ = json.NewEncoder(stdOut.Writer()).Encode(props)
+		_ = json.NewEncoder(stdOut.Writer()).Encode(entry.Fields())
 		return
 	}
 
-	stdOut.Printf("%s [%s] [%s] %s\n", level, props["Timestamp"], props[log.PropertyKeyTag], props["Message"])
+	stdOut.Printf("%s [%s] [%s] %s\n", level, entry.Timestamp.Format(time.RFC3339), entry.Tag, entry.Message(false))
 }
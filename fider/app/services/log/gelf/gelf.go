@@ -0,0 +1,254 @@
+package gelf
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+const (
+	batchSize     = 50
+	bufferSize    = 5000
+	flushInterval = time.Second
+
+	// UDP messages larger than this are split into chunks, with up to maxChunks chunks
+	maxDatagramSize = 8192
+	chunkHeaderSize = 12
+	maxChunks       = 128
+)
+
+var (
+	writer   *log.BatchWriter
+	network  string
+	address  string
+	hostname string
+	conn     net.Conn
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+// Service sends logs to Graylog, or any other server that accepts GELF messages over UDP or TCP
+type Service struct{}
+
+func (s Service) Name() string {
+	return "GELF"
+}
+
+func (s Service) Category() string {
+	return "log"
+}
+
+func (s Service) Enabled() bool {
+	return !env.IsTest() && env.Config.Log.GELF.Address != ""
+}
+
+func (s Service) Init() {
+	u, err := url.Parse(env.Config.Log.GELF.Address)
+	if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+		panic(errors.New("invalid LOG_GELF_ADDRESS '%s', expected udp://host:port or tcp://host:port", env.Config.Log.GELF.Address))
+	}
+
+	network, address = u.Scheme, u.Host
+	hostname, _ = os.Hostname()
+	writer = log.NewBatchWriter(batchSize, bufferSize, flushInterval, send)
+
+	bus.AddListener(logDebug)
+	bus.AddListener(logWarn)
+	bus.AddListener(logInfo)
+	bus.AddListener(logError)
+	bus.AddListener(flushLogs)
+}
+
+func logDebug(ctx context.Context, c *cmd.LogDebug) {
+	writeLog(ctx, log.DEBUG, c.Message, c.Props)
+}
+
+func logWarn(ctx context.Context, c *cmd.LogWarn) {
+	writeLog(ctx, log.WARN, c.Message, c.Props)
+}
+
+func logInfo(ctx context.Context, c *cmd.LogInfo) {
+	writeLog(ctx, log.INFO, c.Message, c.Props)
+}
+
+func logError(ctx context.Context, c *cmd.LogError) {
+	if c.Err != nil {
+		writeLog(ctx, log.ERROR, c.Err.Error(), c.Props)
+	} else if c.Message != "" {
+		writeLog(ctx, log.ERROR, c.Message, c.Props)
+	} else {
+		writeLog(ctx, log.ERROR, "nil", c.Props)
+	}
+}
+
+func flushLogs(ctx context.Context, c *cmd.FlushLogs) {
+	writer.Close()
+}
+
+func writeLog(ctx context.Context, level log.Level, message string, props dto.Props) {
+	entry := log.NewEntry(ctx, level, message, props)
+	if entry == nil {
+		return
+	}
+
+	writer.Add(entry)
+}
+
+// send writes a batch of entries, reconnecting once if the connection was lost
+func send(entries []*log.Entry) error {
+	for _, entry := range entries {
+		payload, err := encode(entry, hostname)
+		if err != nil {
+			return err
+		}
+
+		if err := write(payload); err != nil {
+			if conn != nil {
+				_ = conn.Close()
+				conn = nil
+			}
+			if err := write(payload); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func write(payload []byte) error {
+	if conn == nil {
+		var err error
+		conn, err = net.DialTimeout(network, address, 5*time.Second)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to GELF server '%s'", address)
+		}
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	if network == "tcp" {
+		// messages are delimited by a null byte on TCP
+		_, err := conn.Write(append(payload, 0))
+		return err
+	}
+
+	chunks, err := chunk(payload)
+	if err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if _, err := conn.Write(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var invalidFieldChars = regexp.MustCompile(`[^\w\.\-]`)
+
+// encode returns the GELF 1.1 message of an entry, with its properties as additional fields
+// https://go2docs.graylog.org/current/getting_in_log_data/gelf.html
+func encode(entry *log.Entry, host string) ([]byte, error) {
+	message := map[string]any{
+		"version":       "1.1",
+		"host":          host,
+		"short_message": entry.Message(false),
+		"timestamp":     float64(entry.Timestamp.UnixNano()) / float64(time.Second),
+		"level":         syslogLevel(entry.Level),
+		"_tag":          entry.Tag,
+	}
+
+	for key, value := range entry.Props {
+		field := "_" + invalidFieldChars.ReplaceAllString(key, "_")
+		if field == "_id" {
+			// _id is reserved by GELF
+			field = "_id_"
+		}
+		message[field] = fieldValue(value)
+	}
+
+	return json.Marshal(message)
+}
+
+// fieldValue converts a value to a string or a number, the only types accepted for additional fields
+func fieldValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case error:
+		return v.Error()
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
+
+// syslogLevel returns the severity of a level as defined by syslog
+func syslogLevel(level log.Level) int {
+	switch level {
+	case log.DEBUG:
+		return 7
+	case log.INFO:
+		return 6
+	case log.WARN:
+		return 4
+	}
+	return 3
+}
+
+// chunk splits a UDP message into datagrams that fit in maxDatagramSize
+func chunk(payload []byte) ([][]byte, error) {
+	if len(payload) <= maxDatagramSize {
+		return [][]byte{payload}, nil
+	}
+
+	dataSize := maxDatagramSize - chunkHeaderSize
+	count := (len(payload) + dataSize - 1) / dataSize
+	if count > maxChunks {
+		return nil, errors.New("GELF message of %d bytes is too large to be sent over UDP", len(payload))
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "failed to generate GELF message id")
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * dataSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		c := make([]byte, 0, chunkHeaderSize+end-i*dataSize)
+		c = append(c, 0x1e, 0x0f)
+		c = append(c, id...)
+		c = append(c, byte(i), byte(count))
+		c = append(c, payload[i*dataSize:end]...)
+		chunks = append(chunks, c)
+	}
+	return chunks, nil
+}
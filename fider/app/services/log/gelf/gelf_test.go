@@ -0,0 +1,121 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/dto"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+func TestEncode(t *testing.T) {
+	RegisterT(t)
+
+	entry := &log.Entry{
+		Level:     log.WARN,
+		Timestamp: time.Unix(1600000000, 500000000),
+		Tag:       "WEB",
+		Format:    "Hello @{Name}",
+		Props: dto.Props{
+			"Name":      "Jon",
+			"id":        12,
+			"Post Slug": "my-post",
+			"Tags":      []string{"bug"},
+		},
+	}
+
+	payload, err := encode(entry, "fider-1")
+	Expect(err).IsNil()
+
+	message := make(map[string]any)
+	Expect(json.Unmarshal(payload, &message)).IsNil()
+	Expect(message).Equals(map[string]any{
+		"version":       "1.1",
+		"host":          "fider-1",
+		"short_message": "Hello Jon",
+		"timestamp":     1600000000.5,
+		"level":         float64(4),
+		"_tag":          "WEB",
+		"_Name":         "Jon",
+		"_id_":          float64(12),
+		"_Post_Slug":    "my-post",
+		"_Tags":         `["bug"]`,
+	})
+}
+
+func TestChunk(t *testing.T) {
+	RegisterT(t)
+
+	chunks, err := chunk([]byte("small"))
+	Expect(err).IsNil()
+	Expect(chunks).Equals([][]byte{[]byte("small")})
+
+	payload := bytes.Repeat([]byte("a"), maxDatagramSize*2)
+	chunks, err = chunk(payload)
+	Expect(err).IsNil()
+	Expect(chunks).HasLen(3)
+
+	joined := make([]byte, 0)
+	for i, c := range chunks {
+		Expect(len(c) <= maxDatagramSize).IsTrue()
+		Expect(c[:2]).Equals([]byte{0x1e, 0x0f})
+		Expect(c[2:10]).Equals(chunks[0][2:10])
+		Expect(int(c[10])).Equals(i)
+		Expect(int(c[11])).Equals(3)
+		joined = append(joined, c[chunkHeaderSize:]...)
+	}
+	Expect(joined).Equals(payload)
+
+	_, err = chunk(bytes.Repeat([]byte("a"), maxDatagramSize*maxChunks))
+	Expect(err).IsNotNil()
+}
+
+func TestSend_TCP(t *testing.T) {
+	RegisterT(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).IsNil()
+	defer listener.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		c, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		reader := bufio.NewReader(c)
+		for {
+			message, err := reader.ReadString(0)
+			if err != nil {
+				return
+			}
+			received <- message
+		}
+	}()
+
+	network, address, hostname = "tcp", listener.Addr().String(), "fider-1"
+	defer func() {
+		_ = conn.Close()
+		conn = nil
+	}()
+
+	err = send([]*log.Entry{
+		{Level: log.INFO, Tag: "WEB", Format: "First"},
+		{Level: log.ERROR, Tag: "WEB", Format: "Second"},
+	})
+	Expect(err).IsNil()
+
+	for _, expected := range []string{"First", "Second"} {
+		message := make(map[string]any)
+		payload := <-received
+		Expect(payload[len(payload)-1]).Equals(byte(0))
+		Expect(json.Unmarshal([]byte(payload[:len(payload)-1]), &message)).IsNil()
+		Expect(message["short_message"]).Equals(expected)
+	}
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	gohttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+const bufferSize = 10000
+
+var (
+	writer  *log.BatchWriter
+	headers map[string]string
+	// The default client is not used, as requests sent through cmd.HTTPRequest are logged themselves
+	client = &gohttp.Client{Timeout: 10 * time.Second}
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+// Service sends logs in batches to an HTTP endpoint, one JSON object per line
+type Service struct{}
+
+func (s Service) Name() string {
+	return "HTTP"
+}
+
+func (s Service) Category() string {
+	return "log"
+}
+
+func (s Service) Enabled() bool {
+	return !env.IsTest() && env.Config.Log.HTTP.URL != ""
+}
+
+func (s Service) Init() {
+	headers = parseHeaders(env.Config.Log.HTTP.Headers)
+	writer = log.NewBatchWriter(env.Config.Log.HTTP.BatchSize, bufferSize, env.Config.Log.HTTP.FlushInterval, send)
+
+	bus.AddListener(logDebug)
+	bus.AddListener(logWarn)
+	bus.AddListener(logInfo)
+	bus.AddListener(logError)
+	bus.AddListener(flushLogs)
+}
+
+func logDebug(ctx context.Context, c *cmd.LogDebug) {
+	writeLog(ctx, log.DEBUG, c.Message, c.Props)
+}
+
+func logWarn(ctx context.Context, c *cmd.LogWarn) {
+	writeLog(ctx, log.WARN, c.Message, c.Props)
+}
+
+func logInfo(ctx context.Context, c *cmd.LogInfo) {
+	writeLog(ctx, log.INFO, c.Message, c.Props)
+}
+
+func logError(ctx context.Context, c *cmd.LogError) {
+	if c.Err != nil {
+		writeLog(ctx, log.ERROR, c.Err.Error(), c.Props)
+	} else if c.Message != "" {
+		writeLog(ctx, log.ERROR, c.Message, c.Props)
+	} else {
+		writeLog(ctx, log.ERROR, "nil", c.Props)
+	}
+}
+
+func flushLogs(ctx context.Context, c *cmd.FlushLogs) {
+	writer.Close()
+}
+
+func writeLog(ctx context.Context, level log.Level, message string, props dto.Props) {
+	entry := log.NewEntry(ctx, level, message, props)
+	if entry == nil {
+		return
+	}
+
+	writer.Add(entry)
+}
+
+// send posts a batch of entries as newline delimited JSON
+func send(entries []*log.Entry) error {
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry.Fields()); err != nil {
+			return errors.Wrap(err, "failed to encode log entry")
+		}
+	}
+
+	req, err := gohttp.NewRequest("POST", env.Config.Log.HTTP.URL, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send logs")
+	}
+	defer func() { _ = res.Body.Close() }()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 300 {
+		return errors.New("failed to send logs: status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// parseHeaders parses a list of key=value pairs separated by commas, whose values are URL encoded
+func parseHeaders(value string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			result[key] = unescaped
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+func TestSend(t *testing.T) {
+	RegisterT(t)
+
+	lines := make([]map[string]any, 0)
+	var header gohttp.Header
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		header = r.Header
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			line := make(map[string]any)
+			Expect(json.Unmarshal(scanner.Bytes(), &line)).IsNil()
+			lines = append(lines, line)
+		}
+	}))
+	defer server.Close()
+
+	env.Config.Log.HTTP.URL = server.URL
+	headers = parseHeaders("Authorization=Bearer%20abc, X-Source=fider,Invalid")
+
+	err := send([]*log.Entry{
+		{Level: log.INFO, Tag: "WEB", Format: "Hello @{Name}", Timestamp: time.Now(), Props: dto.Props{"Name": "Jon"}},
+		{Level: log.ERROR, Tag: "BGW", Format: "Failed", Timestamp: time.Now()},
+	})
+	Expect(err).IsNil()
+
+	Expect(header.Get("Content-Type")).Equals("application/x-ndjson")
+	Expect(header.Get("Authorization")).Equals("Bearer abc")
+	Expect(header.Get("X-Source")).Equals("fider")
+	Expect(lines).HasLen(2)
+	Expect(lines[0]["Message"]).Equals("Hello Jon")
+	Expect(lines[0]["Name"]).Equals("Jon")
+	Expect(lines[0]["Level"]).Equals("INFO")
+	Expect(lines[0]["Tag"]).Equals("WEB")
+	Expect(lines[1]["Message"]).Equals("Failed")
+	Expect(lines[1]["Level"]).Equals("ERROR")
+}
+
+func TestSend_Failure(t *testing.T) {
+	RegisterT(t)
+
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		w.WriteHeader(gohttp.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	env.Config.Log.HTTP.URL = server.URL
+	err := send([]*log.Entry{{Level: log.INFO, Format: "Hello"}})
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("status 503")
+}
+
+func TestFlushLogs(t *testing.T) {
+	RegisterT(t)
+
+	received := 0
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			received++
+		}
+	}))
+	defer server.Close()
+
+	env.Config.Log.HTTP.URL = server.URL
+	headers = parseHeaders("")
+	writer = log.NewBatchWriter(10, 100, time.Hour, send)
+
+	Expect(writer.Add(&log.Entry{Level: log.INFO, Format: "Shutting down", Timestamp: time.Now()})).IsTrue()
+	Expect(received).Equals(0)
+
+	flushLogs(context.Background(), &cmd.FlushLogs{})
+	Expect(received).Equals(1)
+	Expect(writer.Add(&log.Entry{Level: log.INFO, Format: "Too late", Timestamp: time.Now()})).IsFalse()
+}
@@ -2,6 +2,8 @@ package sql
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
@@ -12,6 +14,15 @@ import (
 	"github.com/getfider/fider/app/pkg/log"
 )
 
+const (
+	batchSize     = 100
+	bufferSize    = 10000
+	flushInterval = time.Second
+)
+
+// writer inserts logs in batches, so that logging doesn't start a query for each entry
+var writer *log.BatchWriter
+
 func init() {
 	bus.Register(Service{})
 }
@@ -31,10 +42,13 @@ func (s Service) Enabled() bool {
 }
 
 func (s Service) Init() {
+	writer = log.NewBatchWriter(batchSize, bufferSize, flushInterval, insertEntries)
+
 	bus.AddListener(logDebug)
 	bus.AddListener(logWarn)
 	bus.AddListener(logInfo)
 	bus.AddListener(logError)
+	bus.AddListener(flushLogs)
 }
 
 func logDebug(ctx context.Context, c *cmd.LogDebug) {
@@ -59,24 +73,32 @@ func logError(ctx context.Context, c *cmd.LogError) {
 	}
 }
 
+func flushLogs(ctx context.Context, c *cmd.FlushLogs) {
+	writer.Close()
+}
+
 func writeLog(ctx context.Context, level log.Level, message string, props dto.Props) {
-	if !log.IsEnabled(level) {
+	entry := log.NewEntry(ctx, level, message, props)
+	if entry == nil {
 		return
 	}
 
-	props = log.GetProperties(ctx).Merge(props)
+	writer.Add(entry)
+}
 
-	message = log.Parse(message, props, false)
-	tag := props[log.PropertyKeyTag]
-	if tag == nil {
-		tag = "???"
+// insertEntries writes a batch of entries with a single statement
+func insertEntries(entries []*log.Entry) error {
+	values := make([]string, len(entries))
+	args := make([]any, 0, len(entries)*5)
+	for i, entry := range entries {
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, entry.Tag, entry.Level.String(), entry.Message(false), entry.Timestamp, entry.Props)
 	}
-	delete(props, log.PropertyKeyTag)
-
-	go func() {
-		_, _ = dbx.Connection().Exec(
-			"INSERT INTO logs (tag, level, text, created_at, properties) VALUES ($1, $2, $3, $4, $5)",
-			tag, level.String(), message, time.Now(), props,
-		)
-	}()
+
+	_, err := dbx.Connection().Exec(
+		"INSERT INTO logs (tag, level, text, created_at, properties) VALUES "+strings.Join(values, ", "),
+		args...,
+	)
+	return err
 }
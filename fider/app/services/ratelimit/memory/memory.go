@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+type Service struct{}
+
+func (s Service) Name() string {
+	return "Memory"
+}
+
+func (s Service) Category() string {
+	return "ratelimit"
+}
+
+func (s Service) Enabled() bool {
+	return env.Config.RateLimit.Backend == "memory"
+}
+
+func (s Service) Init() {
+	store = newCounterStore()
+	bus.AddHandler(hitRateLimit)
+}
+
+// sweepInterval is how often expired counters are removed from memory
+const sweepInterval = time.Minute
+
+type counter struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	hits        int
+}
+
+type counterStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+func newCounterStore() *counterStore {
+	return &counterStore{
+		counters:  make(map[string]*counter),
+		lastSweep: time.Now(),
+	}
+}
+
+var store = newCounterStore()
+
+func (s *counterStore) hit(key string, windowStart time.Time, window time.Duration, now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > sweepInterval {
+		for k, c := range s.counters {
+			if now.After(c.expiresAt) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	c, ok := s.counters[key]
+	if !ok || !c.windowStart.Equal(windowStart) {
+		c = &counter{windowStart: windowStart, expiresAt: windowStart.Add(window)}
+		s.counters[key] = c
+	}
+
+	c.hits++
+	return c.hits
+}
+
+func hitRateLimit(ctx context.Context, c *cmd.HitRateLimit) error {
+	c.Result = store.hit(c.Key, c.WindowStart, c.Window, time.Now())
+	return nil
+}
@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func init() {
+	bus.Register(Service{})
+}
+
+type Service struct{}
+
+func (s Service) Name() string {
+	return "SQL"
+}
+
+func (s Service) Category() string {
+	return "ratelimit"
+}
+
+func (s Service) Enabled() bool {
+	return env.Config.RateLimit.Backend == "sql"
+}
+
+func (s Service) Init() {
+	bus.AddHandler(hitRateLimit)
+}
+
+// purgeInterval is how often expired counters are deleted from the database by each instance
+const purgeInterval = time.Minute
+
+var lastPurge atomic.Int64
+
+func hitRateLimit(ctx context.Context, c *cmd.HitRateLimit) error {
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to open transaction")
+	}
+	// Rolling back after a commit is a no-op, so this only undoes failed hits
+	defer func() {
+		_ = trx.Rollback()
+	}()
+
+	now := time.Now()
+	last := lastPurge.Load()
+	if now.Unix()-last >= int64(purgeInterval.Seconds()) && lastPurge.CompareAndSwap(last, now.Unix()) {
+		if _, err := trx.Execute("DELETE FROM rate_limit_counters WHERE expires_at < $1", now); err != nil {
+			return errors.Wrap(err, "failed to purge expired rate limit counters")
+		}
+	}
+
+	err = trx.Scalar(&c.Result, `
+		INSERT INTO rate_limit_counters (key, window_start, expires_at, hits)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (key) DO UPDATE SET
+			hits = CASE WHEN rate_limit_counters.window_start = EXCLUDED.window_start THEN rate_limit_counters.hits + 1 ELSE 1 END,
+			window_start = EXCLUDED.window_start,
+			expires_at = EXCLUDED.expires_at
+		RETURNING hits
+	`, c.Key, c.WindowStart, c.WindowStart.Add(c.Window))
+	if err != nil {
+		return errors.Wrap(err, "failed to hit rate limit counter '%s'", c.Key)
+	}
+
+	if err = trx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit rate limit counter '%s'", c.Key)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package dbEntities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
+)
+
+type AuditLog struct {
+	ID         int            `db:"id"`
+	ActorID    dbx.NullInt    `db:"actor_id"`
+	ActorName  string         `db:"actor_name"`
+	Action     string         `db:"action"`
+	TargetType string         `db:"target_type"`
+	TargetID   dbx.NullInt    `db:"target_id"`
+	TargetName string         `db:"target_name"`
+	Before     dbx.NullString `db:"before_value"`
+	After      dbx.NullString `db:"after_value"`
+	ClientIP   string         `db:"client_ip"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (a *AuditLog) ToModel() *entity.AuditLog {
+	auditLog := &entity.AuditLog{
+		ID:         a.ID,
+		ActorID:    int(a.ActorID.Int64),
+		ActorName:  a.ActorName,
+		Action:     enum.AuditAction(a.Action),
+		TargetType: a.TargetType,
+		TargetID:   int(a.TargetID.Int64),
+		TargetName: a.TargetName,
+		ClientIP:   a.ClientIP,
+		CreatedAt:  a.CreatedAt,
+	}
+
+	if a.Before.Valid {
+		_ = json.Unmarshal([]byte(a.Before.String), &auditLog.Before)
+	}
+	if a.After.Valid {
+		_ = json.Unmarshal([]byte(a.After.String), &auditLog.After)
+	}
+	return auditLog
+}
@@ -0,0 +1,94 @@
+package dbEntities
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/lib/pq"
+)
+
+type ExportedComment struct {
+	ID         int          `db:"id"`
+	PostNumber int          `db:"post_number"`
+	PostTitle  string       `db:"post_title"`
+	Content    string       `db:"content"`
+	User       *User        `db:"user"`
+	CreatedAt  time.Time    `db:"created_at"`
+	EditedAt   dbx.NullTime `db:"edited_at"`
+	IsApproved bool         `db:"is_approved"`
+}
+
+func (c *ExportedComment) ToModel(ctx context.Context) *entity.ExportedComment {
+	comment := &entity.ExportedComment{
+		ID:         c.ID,
+		PostNumber: c.PostNumber,
+		PostTitle:  c.PostTitle,
+		Content:    c.Content,
+		User:       c.User.ToModel(ctx),
+		CreatedAt:  c.CreatedAt,
+		IsApproved: c.IsApproved,
+	}
+	if c.EditedAt.Valid {
+		comment.EditedAt = &c.EditedAt.Time
+	}
+	return comment
+}
+
+type ExportedVote struct {
+	PostID     int       `db:"post_id"`
+	PostNumber int       `db:"post_number"`
+	PostTitle  string    `db:"post_title"`
+	User       *User     `db:"user"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (v *ExportedVote) ToModel(ctx context.Context) *entity.ExportedVote {
+	return &entity.ExportedVote{
+		PostID:     v.PostID,
+		PostNumber: v.PostNumber,
+		PostTitle:  v.PostTitle,
+		User:       v.User.ToModel(ctx),
+		CreatedAt:  v.CreatedAt,
+	}
+}
+
+type ExportedReaction struct {
+	ID         int         `db:"id"`
+	PostNumber int         `db:"post_number"`
+	PostTitle  string      `db:"post_title"`
+	CommentID  dbx.NullInt `db:"comment_id"`
+	Emoji      string      `db:"emoji"`
+	User       *User       `db:"user"`
+	CreatedAt  time.Time   `db:"created_at"`
+}
+
+func (r *ExportedReaction) ToModel(ctx context.Context) *entity.ExportedReaction {
+	reaction := &entity.ExportedReaction{
+		ID:         r.ID,
+		PostNumber: r.PostNumber,
+		PostTitle:  r.PostTitle,
+		Emoji:      r.Emoji,
+		User:       r.User.ToModel(ctx),
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.CommentID.Valid {
+		commentID := int(r.CommentID.Int64)
+		reaction.CommentID = &commentID
+	}
+	return reaction
+}
+
+// PostVoteStats is the number of votes of a post given by users of a role
+type PostVoteStats struct {
+	PostID int            `db:"post_id"`
+	Role   int            `db:"role"`
+	Votes  int            `db:"votes"`
+	Emails pq.StringArray `db:"emails"`
+}
+
+type PostActivity struct {
+	PostID         int       `db:"post_id"`
+	LastActivityAt time.Time `db:"last_activity_at"`
+}
@@ -0,0 +1,39 @@
+package dbEntities
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type File struct {
+	ID          int            `db:"id"`
+	PostID      int            `db:"post_id"`
+	CommentID   sql.NullInt64  `db:"comment_id"`
+	UserID      int            `db:"user_id"`
+	BlobKey     string         `db:"blob_key"`
+	Name        string         `db:"name"`
+	ContentType string         `db:"content_type"`
+	Size        int64          `db:"size"`
+	Status      string         `db:"status"`
+	ScanResult  sql.NullString `db:"scan_result"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+func (f *File) ToModel() *entity.File {
+	return &entity.File{
+		ID:          f.ID,
+		PostID:      f.PostID,
+		CommentID:   int(f.CommentID.Int64),
+		UserID:      f.UserID,
+		BlobKey:     f.BlobKey,
+		Name:        f.Name,
+		ContentType: f.ContentType,
+		Size:        f.Size,
+		Status:      enum.FileStatus(f.Status),
+		ScanResult:  f.ScanResult.String,
+		CreatedAt:   f.CreatedAt,
+	}
+}
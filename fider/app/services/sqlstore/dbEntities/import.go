@@ -0,0 +1,46 @@
+package dbEntities
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
+)
+
+type ImportJob struct {
+	ID            int          `db:"id"`
+	Source        string       `db:"source"`
+	FileName      string       `db:"file_name"`
+	Status        string       `db:"status"`
+	TotalRows     int          `db:"total_rows"`
+	ProcessedRows int          `db:"processed_rows"`
+	FailedRows    int          `db:"failed_rows"`
+	Errors        string       `db:"row_errors"`
+	CreatedBy     *User        `db:"created_by"`
+	CreatedAt     time.Time    `db:"created_at"`
+	FinishedAt    dbx.NullTime `db:"finished_at"`
+}
+
+func (j *ImportJob) ToModel(ctx context.Context) *entity.ImportJob {
+	job := &entity.ImportJob{
+		ID:            j.ID,
+		Source:        enum.ImportSource(j.Source),
+		FileName:      j.FileName,
+		Status:        enum.ImportStatus(j.Status),
+		TotalRows:     j.TotalRows,
+		ProcessedRows: j.ProcessedRows,
+		FailedRows:    j.FailedRows,
+		Errors:        make([]*entity.ImportRowError, 0),
+		CreatedBy:     j.CreatedBy.ToModel(ctx),
+		CreatedAt:     j.CreatedAt,
+	}
+
+	_ = json.Unmarshal([]byte(j.Errors), &job.Errors)
+	if j.FinishedAt.Valid {
+		job.FinishedAt = &j.FinishedAt.Time
+	}
+	return job
+}
@@ -0,0 +1,33 @@
+package dbEntities
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type JobRun struct {
+	ID         int            `db:"id"`
+	JobName    string         `db:"job_name"`
+	Trigger    string         `db:"trigger"`
+	Status     string         `db:"status"`
+	Error      sql.NullString `db:"error"`
+	StartedAt  time.Time      `db:"started_at"`
+	FinishedAt time.Time      `db:"finished_at"`
+	DurationMs int64          `db:"duration_ms"`
+}
+
+func (r *JobRun) ToModel() *entity.JobRun {
+	return &entity.JobRun{
+		ID:         r.ID,
+		JobName:    r.JobName,
+		Trigger:    enum.JobTrigger(r.Trigger),
+		Status:     enum.JobRunStatus(r.Status),
+		Error:      r.Error.String,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		DurationMs: r.DurationMs,
+	}
+}
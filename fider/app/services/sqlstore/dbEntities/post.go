@@ -2,6 +2,7 @@ package dbEntities
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/getfider/fider/app/models/entity"
@@ -34,6 +35,7 @@ type Post struct {
 	OriginalStatus dbx.NullInt    `db:"original_status"`
 	Tags           pq.StringArray `db:"tags"`
 	IsApproved     bool           `db:"is_approved"`
+	ReactionCounts dbx.NullString `db:"reaction_counts"`
 }
 
 func (i *Post) ToModel(ctx context.Context) *entity.Post {
@@ -53,6 +55,10 @@ func (i *Post) ToModel(ctx context.Context) *entity.Post {
 		IsApproved:    i.IsApproved,
 	}
 
+	if i.ReactionCounts.Valid {
+		_ = json.Unmarshal([]byte(i.ReactionCounts.String), &post.ReactionCounts)
+	}
+
 	if i.Response.Valid {
 		post.Response = &entity.PostResponse{
 			Text:        i.Response.String,
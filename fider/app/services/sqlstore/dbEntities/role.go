@@ -0,0 +1,29 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type Role struct {
+	ID          int      `db:"id"`
+	Name        string   `db:"name"`
+	Description string   `db:"description"`
+	Permissions []string `db:"permissions"`
+}
+
+func (r *Role) ToModel() *entity.Role {
+	permissions := make([]enum.Permission, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		if permission := enum.Permission(p); permission.IsValid() {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return &entity.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+	}
+}
@@ -2,14 +2,17 @@ package dbEntities
 
 import (
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
 )
 
 type Tag struct {
-	ID       int    `db:"id"`
-	Name     string `db:"name"`
-	Slug     string `db:"slug"`
-	Color    string `db:"color"`
-	IsPublic bool   `db:"is_public"`
+	ID       int         `db:"id"`
+	Name     string      `db:"name"`
+	Slug     string      `db:"slug"`
+	Color    string      `db:"color"`
+	IsPublic bool        `db:"is_public"`
+	GroupID  dbx.NullInt `db:"group_id"`
 }
 
 func (t *Tag) ToModel() *entity.Tag {
@@ -19,5 +22,26 @@ func (t *Tag) ToModel() *entity.Tag {
 		Slug:     t.Slug,
 		Color:    t.Color,
 		IsPublic: t.IsPublic,
+		GroupID:  int(t.GroupID.Int64),
+	}
+}
+
+type TagGroup struct {
+	ID           int      `db:"id"`
+	Name         string   `db:"name"`
+	Slug         string   `db:"slug"`
+	Selection    string   `db:"selection"`
+	VisibleTo    []string `db:"visible_to"`
+	AssignableBy []string `db:"assignable_by"`
+}
+
+func (g *TagGroup) ToModel() *entity.TagGroup {
+	return &entity.TagGroup{
+		ID:           g.ID,
+		Name:         g.Name,
+		Slug:         g.Slug,
+		Selection:    enum.TagGroupSelection(g.Selection),
+		VisibleTo:    g.VisibleTo,
+		AssignableBy: g.AssignableBy,
 	}
 }
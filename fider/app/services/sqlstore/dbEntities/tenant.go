@@ -21,10 +21,14 @@ type Tenant struct {
 	LogoBlobKey          string `db:"logo_bkey"`
 	CustomCSS            string `db:"custom_css"`
 	AllowedSchemes       string `db:"allowed_schemes"`
+	AllowedFileTypes     string `db:"allowed_file_types"`
+	ReactionEmojis       string `db:"reaction_emojis"`
+	FileQuotaMB          int    `db:"file_quota_mb"`
 	IsEmailAuthAllowed   bool   `db:"is_email_auth_allowed"`
 	IsFeedEnabled        bool   `db:"is_feed_enabled"`
 	PreventIndexing      bool   `db:"prevent_indexing"`
 	IsModerationEnabled  bool   `db:"is_moderation_enabled"`
+	IsTwoFactorRequired  bool   `db:"is_two_factor_required"`
 	IsPro                bool   `db:"is_pro"`
 	HasPaddleSubscription bool  `db:"has_paddle_subscription"`
 }
@@ -58,10 +62,14 @@ func (t *Tenant) ToModel() *entity.Tenant {
 		LogoBlobKey:           t.LogoBlobKey,
 		CustomCSS:             t.CustomCSS,
 		AllowedSchemes:        t.AllowedSchemes,
+		AllowedFileTypes:      t.AllowedFileTypes,
+		ReactionEmojis:        t.ReactionEmojis,
+		FileQuotaMB:           t.FileQuotaMB,
 		IsEmailAuthAllowed:    t.IsEmailAuthAllowed,
 		IsFeedEnabled:         t.IsFeedEnabled,
 		PreventIndexing:       t.PreventIndexing,
 		IsModerationEnabled:   t.IsModerationEnabled,
+		IsTwoFactorRequired:   t.IsTwoFactorRequired,
 		HasCommercialFeatures: hasCommercialFeatures,
 	}
 
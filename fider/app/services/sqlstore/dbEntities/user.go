@@ -8,6 +8,7 @@ import (
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -22,6 +23,8 @@ type User struct {
 	AvatarType    sql.NullInt64  `db:"avatar_type"`
 	AvatarBlobKey sql.NullString `db:"avatar_bkey"`
 	IsTrusted     sql.NullBool   `db:"is_trusted"`
+	CustomRoleID  sql.NullInt64  `db:"custom_role_id"`
+	TwoFactorAt   dbx.NullTime   `db:"two_factor_enabled_at"`
 	Providers     []*UserProvider
 }
 
@@ -64,6 +67,8 @@ func (u *User) ToModel(ctx context.Context) *entity.User {
 		AvatarBlobKey: u.AvatarBlobKey.String,
 		AvatarURL:     avatarURL,
 		IsTrusted:     u.IsTrusted.Bool,
+
+		IsTwoFactorEnabled: u.TwoFactorAt.Valid,
 	}
 
 	if u.Providers != nil {
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+func addAuditLog(ctx context.Context, c *cmd.AddAuditLog) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var actorID any
+		actorName := "System"
+		if user != nil {
+			actorID = user.ID
+			actorName = user.Name
+		}
+
+		var targetID any
+		if c.TargetID > 0 {
+			targetID = c.TargetID
+		}
+
+		clientIP := ""
+		if request, ok := ctx.Value(app.RequestCtxKey).(web.Request); ok {
+			clientIP = request.ClientIP
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO audit_logs (tenant_id, actor_id, actor_name, action, target_type, target_id, target_name, before_value, after_value, client_ip, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`, tenant.ID, actorID, actorName, string(c.Action), c.TargetType, targetID, c.TargetName,
+			auditValue(c.Before), auditValue(c.After), clientIP, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add audit log")
+		}
+		return nil
+	})
+}
+
+func searchAuditLogs(ctx context.Context, q *query.SearchAuditLogs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if q.Limit <= 0 {
+			q.Limit = 50
+		}
+		if q.Page <= 0 {
+			q.Page = 1
+		}
+
+		condition := "tenant_id = $1"
+		args := []any{tenant.ID}
+
+		addFilter := func(format string, value any) {
+			args = append(args, value)
+			condition += fmt.Sprintf(format, len(args))
+		}
+
+		if q.Action != "" {
+			addFilter(" AND action = $%d", string(q.Action))
+		}
+		if q.ActorID > 0 {
+			addFilter(" AND actor_id = $%d", q.ActorID)
+		}
+		if q.TargetType != "" {
+			addFilter(" AND target_type = $%d", q.TargetType)
+		}
+		if q.TargetID > 0 {
+			addFilter(" AND target_id = $%d", q.TargetID)
+		}
+		if q.Since != nil {
+			addFilter(" AND created_at >= $%d", *q.Since)
+		}
+		if q.Until != nil {
+			addFilter(" AND created_at < $%d", *q.Until)
+		}
+
+		err := trx.Get(&q.TotalCount, "SELECT COUNT(*) FROM audit_logs WHERE "+condition, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to count audit logs")
+		}
+
+		offset := (q.Page - 1) * q.Limit
+		var auditLogs []*dbEntities.AuditLog
+		err = trx.Select(&auditLogs, fmt.Sprintf(`
+			SELECT id, actor_id, actor_name, action, target_type, target_id, target_name, before_value, after_value, client_ip, created_at
+			FROM audit_logs
+			WHERE %s
+			ORDER BY created_at DESC, id DESC
+			LIMIT %d OFFSET %d
+		`, condition, q.Limit, offset), args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to search audit logs")
+		}
+
+		q.Result = make([]*entity.AuditLog, len(auditLogs))
+		for i, auditLog := range auditLogs {
+			q.Result[i] = auditLog.ToModel()
+		}
+		return nil
+	})
+}
+
+// auditValue stores empty props as NULL instead of a JSON null
+func auditValue(props dto.Props) any {
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestAuditLogStorage_AddAndSearch(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(jonSnowCtx, &cmd.AddAuditLog{
+		Action:     enum.AuditUserRoleChanged,
+		TargetType: "user",
+		TargetID:   aryaStark.ID,
+		TargetName: aryaStark.Name,
+		Before:     dto.Props{"role": enum.RoleVisitor},
+		After:      dto.Props{"role": enum.RoleCollaborator},
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddAuditLog{
+		Action:     enum.AuditBackupExported,
+		TargetType: "tenant",
+		TargetID:   demoTenant.ID,
+		TargetName: demoTenant.Name,
+	})
+	Expect(err).IsNil()
+
+	searchAll := &query.SearchAuditLogs{}
+	err = bus.Dispatch(jonSnowCtx, searchAll)
+	Expect(err).IsNil()
+	Expect(searchAll.TotalCount).Equals(2)
+	Expect(searchAll.Result).HasLen(2)
+
+	searchRoles := &query.SearchAuditLogs{Action: enum.AuditUserRoleChanged}
+	err = bus.Dispatch(jonSnowCtx, searchRoles)
+	Expect(err).IsNil()
+	Expect(searchRoles.TotalCount).Equals(1)
+	Expect(searchRoles.Result[0].ActorID).Equals(jonSnow.ID)
+	Expect(searchRoles.Result[0].ActorName).Equals(jonSnow.Name)
+	Expect(searchRoles.Result[0].TargetID).Equals(aryaStark.ID)
+	Expect(searchRoles.Result[0].Before["role"]).Equals("visitor")
+	Expect(searchRoles.Result[0].After["role"]).Equals("collaborator")
+
+	searchOtherTenant := &query.SearchAuditLogs{}
+	err = bus.Dispatch(tonyStarkCtx, searchOtherTenant)
+	Expect(err).IsNil()
+	Expect(searchOtherTenant.TotalCount).Equals(0)
+}
+
+func TestAuditLogStorage_IsImmutable(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(jonSnowCtx, &cmd.AddAuditLog{
+		Action:     enum.AuditPostsExported,
+		TargetType: "tenant",
+		TargetID:   demoTenant.ID,
+		TargetName: demoTenant.Name,
+	})
+	Expect(err).IsNil()
+
+	_, err = trx.Execute("UPDATE audit_logs SET actor_name = 'Someone else' WHERE tenant_id = $1", demoTenant.ID)
+	Expect(err).IsNotNil()
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+func listReferencedBlobs(ctx context.Context, q *query.ListReferencedBlobs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		type entry struct {
+			BlobKey string `db:"bkey"`
+		}
+
+		entries := []*entry{}
+		err := trx.Select(&entries, `
+			SELECT a.attachment_bkey AS bkey
+			FROM attachments a
+			INNER JOIN posts p ON p.id = a.post_id AND p.tenant_id = a.tenant_id
+			LEFT JOIN comments c ON c.id = a.comment_id AND c.tenant_id = a.tenant_id
+			WHERE a.tenant_id = $1 AND p.status != $2 AND (a.comment_id IS NULL OR c.deleted_at IS NULL)
+			UNION
+			SELECT f.blob_key
+			FROM files f
+			INNER JOIN posts p ON p.id = f.post_id AND p.tenant_id = f.tenant_id
+			LEFT JOIN comments c ON c.id = f.comment_id AND c.tenant_id = f.tenant_id
+			WHERE f.tenant_id = $1 AND p.status != $2 AND (f.comment_id IS NULL OR c.deleted_at IS NULL)
+			UNION
+			SELECT avatar_bkey FROM users WHERE tenant_id = $1 AND status != $3 AND avatar_type = $4 AND avatar_bkey != ''
+			UNION
+			SELECT logo_bkey FROM tenants WHERE id = $1 AND logo_bkey != ''
+			UNION
+			SELECT logo_bkey FROM oauth_providers WHERE tenant_id = $1 AND logo_bkey != ''
+			ORDER BY 1
+		`, tenant.ID, enum.PostDeleted, enum.UserDeleted, enum.AvatarTypeCustom)
+		if err != nil {
+			return errors.Wrap(err, "failed to list referenced blobs")
+		}
+
+		q.Result = make([]string, len(entries))
+		for i, entry := range entries {
+			q.Result[i] = entry.BlobKey
+		}
+		return nil
+	})
+}
+
+func trackOrphanedBlobs(ctx context.Context, c *cmd.TrackOrphanedBlobs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		keys := c.Keys
+		if keys == nil {
+			keys = []string{}
+		}
+
+		_, err := trx.Execute("DELETE FROM blob_orphans WHERE tenant_id = $1 AND NOT (key = ANY($2))", tenant.ID, pq.Array(keys))
+		if err != nil {
+			return errors.Wrap(err, "failed to delete blobs that are no longer orphaned")
+		}
+
+		_, err = trx.Execute(`
+			INSERT INTO blob_orphans (tenant_id, key, detected_at)
+			SELECT $1, key, $3 FROM unnest($2::text[]) AS key
+			ON CONFLICT (tenant_id, key) DO NOTHING
+		`, tenant.ID, pq.Array(keys), time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to track orphaned blobs")
+		}
+
+		type orphan struct {
+			Key        string    `db:"key"`
+			DetectedAt time.Time `db:"detected_at"`
+		}
+
+		orphans := []*orphan{}
+		err = trx.Select(&orphans, "SELECT key, detected_at FROM blob_orphans WHERE tenant_id = $1", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get orphaned blobs")
+		}
+
+		c.Result = make(map[string]time.Time, len(orphans))
+		for _, o := range orphans {
+			c.Result[o.Key] = o.DetectedAt
+		}
+		return nil
+	})
+}
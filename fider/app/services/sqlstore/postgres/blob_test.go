@@ -0,0 +1,92 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestBlobStorage_ListReferencedBlobs(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post := &cmd.AddNewPost{Title: "App crashes on start", Description: "See attached screenshot"}
+	deletedPost := &cmd.AddNewPost{Title: "Spam", Description: "Buy now"}
+	err := bus.Dispatch(jonSnowCtx, post, deletedPost)
+	Expect(err).IsNil()
+
+	comment := &cmd.AddNewComment{Post: post.Result, Content: "Same here"}
+	err = bus.Dispatch(aryaStarkCtx, comment)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.SetAttachments{Post: post.Result, Attachments: []*dto.ImageUpload{{BlobKey: "attachments/post.png"}}},
+		&cmd.SetAttachments{Post: post.Result, Comment: comment.Result, Attachments: []*dto.ImageUpload{{BlobKey: "attachments/comment.png"}}},
+		&cmd.SetAttachments{Post: deletedPost.Result, Attachments: []*dto.ImageUpload{{BlobKey: "attachments/spam.png"}}},
+		&cmd.AddFile{File: &entity.File{PostID: post.Result.ID, Name: "app.log", ContentType: "text/plain", Size: 100, BlobKey: "files/app.log"}},
+		&cmd.UpdateCurrentUser{Name: "Jon Snow", AvatarType: enum.AvatarTypeCustom, Avatar: &dto.ImageUpload{BlobKey: "avatars/jon.png"}},
+	)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UpdateCurrentUser{Name: "Arya Stark", AvatarType: enum.AvatarTypeGravatar, Avatar: &dto.ImageUpload{BlobKey: "avatars/arya.png"}})
+	Expect(err).IsNil()
+
+	referenced := &query.ListReferencedBlobs{}
+	err = bus.Dispatch(jonSnowCtx, referenced)
+	Expect(err).IsNil()
+	Expect(referenced.Result).Equals([]string{"attachments/comment.png", "attachments/post.png", "avatars/jon.png", "files/app.log"})
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.DeleteComment{CommentID: comment.Result.ID},
+		&cmd.SetPostResponse{Post: deletedPost.Result, Status: enum.PostDeleted},
+	)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, referenced)
+	Expect(err).IsNil()
+	Expect(referenced.Result).Equals([]string{"attachments/post.png", "avatars/jon.png", "files/app.log"})
+
+	referenced = &query.ListReferencedBlobs{}
+	err = bus.Dispatch(avengersTenantCtx, referenced)
+	Expect(err).IsNil()
+	Expect(referenced.Result).HasLen(0)
+}
+
+func TestBlobStorage_TrackOrphanedBlobs(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	track := &cmd.TrackOrphanedBlobs{Keys: []string{"attachments/a.png", "attachments/b.png"}}
+	err := bus.Dispatch(demoTenantCtx, track)
+	Expect(err).IsNil()
+	Expect(track.Result).HasLen(2)
+	firstSeen := track.Result["attachments/a.png"]
+	Expect(firstSeen).TemporarilySimilar(time.Now(), 5*time.Second)
+
+	track = &cmd.TrackOrphanedBlobs{Keys: []string{"attachments/a.png", "attachments/c.png"}}
+	err = bus.Dispatch(demoTenantCtx, track)
+	Expect(err).IsNil()
+	Expect(track.Result).HasLen(2)
+	Expect(track.Result["attachments/a.png"]).Equals(firstSeen)
+	_, ok := track.Result["attachments/c.png"]
+	Expect(ok).IsTrue()
+	_, ok = track.Result["attachments/b.png"]
+	Expect(ok).IsFalse()
+
+	other := &cmd.TrackOrphanedBlobs{}
+	err = bus.Dispatch(avengersTenantCtx, other)
+	Expect(err).IsNil()
+	Expect(other.Result).HasLen(0)
+
+	track = &cmd.TrackOrphanedBlobs{}
+	err = bus.Dispatch(demoTenantCtx, track)
+	Expect(err).IsNil()
+	Expect(track.Result).HasLen(0)
+}
@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
@@ -17,15 +16,24 @@ import (
 func addNewComment(ctx context.Context, c *cmd.AddNewComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		isApproved := !tenant.IsModerationEnabled || !user.RequiresModeration()
+		if isFlaggedForModeration(tenant, c.SpamScore) {
+			isApproved = false
+		}
 		var id int
 		if err := trx.Get(&id, `
-			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, is_approved) 
-			VALUES ($1, $2, $3, $4, $5, $6) 
+			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, is_approved, parent_id) 
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0)) 
 			RETURNING id
-		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now(), isApproved); err != nil {
+		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now(), isApproved, c.ParentID); err != nil {
 			return errors.Wrap(err, "failed add new comment")
 		}
 
+		if c.SpamScore != nil {
+			if err := addSpamCheck(trx, tenant, user, c.Post.ID, id, c.SpamScore); err != nil {
+				return err
+			}
+		}
+
 		q := &query.GetCommentByID{CommentID: id}
 		if err := getCommentByID(ctx, q); err != nil {
 			return err
@@ -41,14 +49,14 @@ func toggleCommentReaction(ctx context.Context, c *cmd.ToggleCommentReaction) er
 		var added bool
 		err := trx.Scalar(&added, `
 			WITH toggle_reaction AS (
-				INSERT INTO reactions (comment_id, user_id, emoji, created_on)
-				VALUES ($1, $2, $3, $4)
+				INSERT INTO reactions (tenant_id, post_id, comment_id, user_id, emoji, created_on)
+				SELECT $5, post_id, id, $2, $3, $4 FROM comments WHERE id = $1 AND tenant_id = $5
 				ON CONFLICT (comment_id, user_id, emoji) DO NOTHING
 				RETURNING true AS added
 			),
 			delete_existing AS (
 				DELETE FROM reactions
-				WHERE comment_id = $1 AND user_id = $2 AND emoji = $3
+				WHERE comment_id = $1 AND user_id = $2 AND emoji = $3 AND tenant_id = $5
 				AND NOT EXISTS (SELECT 1 FROM toggle_reaction)
 				RETURNING false AS added
 			)
@@ -57,7 +65,7 @@ func toggleCommentReaction(ctx context.Context, c *cmd.ToggleCommentReaction) er
 				(SELECT added FROM delete_existing),
 				false
 			)
-		`, c.Comment.ID, user.ID, c.Emoji, time.Now())
+		`, c.Comment.ID, user.ID, c.Emoji, time.Now(), tenant.ID)
 
 		if err != nil {
 			return errors.Wrap(err, "failed to toggle reaction")
@@ -70,12 +78,21 @@ func toggleCommentReaction(ctx context.Context, c *cmd.ToggleCommentReaction) er
 
 func updateComment(ctx context.Context, c *cmd.UpdateComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		_, err := trx.Execute(`
-			UPDATE comments SET content = $1, edited_at = $2, edited_by_id = $3 
-			WHERE id = $4 AND tenant_id = $5`, c.Content, time.Now(), user.ID, c.CommentID, tenant.ID)
+		isApproved := !isFlaggedForModeration(tenant, c.SpamScore)
+		var postID int
+		err := trx.Get(&postID, `
+			UPDATE comments SET content = $1, edited_at = $2, edited_by_id = $3, is_approved = is_approved AND $6
+			WHERE id = $4 AND tenant_id = $5
+			RETURNING post_id`, c.Content, time.Now(), user.ID, c.CommentID, tenant.ID, isApproved)
 		if err != nil {
 			return errors.Wrap(err, "failed update comment")
 		}
+
+		if c.SpamScore != nil {
+			if err := addSpamCheck(trx, tenant, user, postID, c.CommentID, c.SpamScore); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -99,10 +116,12 @@ func getCommentByID(ctx context.Context, q *query.GetCommentByID) error {
 		comment := dbEntities.Comment{}
 		err := trx.Get(&comment,
 			`SELECT c.id, 
+							c.post_id,
 							c.content, 
 							c.created_at, 
 							c.edited_at, 
 							c.is_approved,
+							c.parent_id,
 							u.id AS user_id, 
 							u.name AS user_name,
 							u.email AS user_email,
@@ -146,21 +165,8 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 		if user != nil {
 			userId = user.ID
 		}
-		
-		// Build approval filter based on user permissions
-		approvalFilter := ""
-		if user != nil && user.IsCollaborator() {
-			// Admins and collaborators can see all comments
-			approvalFilter = ""
-		} else if user != nil {
-			// Regular users can see approved comments + their own unapproved comments
-			approvalFilter = fmt.Sprintf(" AND (c.is_approved = true OR c.user_id = %d)", user.ID)
-		} else {
-			// Anonymous users can only see approved comments
-			approvalFilter = " AND c.is_approved = true"
-		}
-		
-		query := fmt.Sprintf(`
+
+		query := `
 			WITH agg_attachments AS ( 
 					SELECT 
 							c.id as comment_id, 
@@ -190,7 +196,7 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 						COUNT(*) as count,
 						array_agg(user_id) as user_ids
 					FROM reactions
-					WHERE comment_id IN (SELECT id FROM comments WHERE post_id = $1)
+					WHERE post_id = $1 AND tenant_id = $2 AND comment_id IS NOT NULL
 					GROUP BY comment_id, emoji
 				) r
 				GROUP BY comment_id
@@ -200,6 +206,8 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 					c.created_at, 
 					c.edited_at, 
 					c.is_approved,
+					c.parent_id,
+					c.deleted_at IS NOT NULL AS is_deleted,
 					u.id AS user_id, 
 					u.name AS user_name,
 					u.email AS user_email,
@@ -232,17 +240,54 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 			ON ar.comment_id = c.id
 			WHERE p.id = $1
 			AND p.tenant_id = $2
-			AND c.deleted_at IS NULL%s
-			ORDER BY c.created_at DESC`, approvalFilter)
-		
+			ORDER BY c.created_at DESC`
+
 		err := trx.Select(&comments, query, q.Post.ID, tenant.ID, userId)
 		if err != nil {
 			return errors.Wrap(err, "failed get comments of post with id '%d'", q.Post.ID)
 		}
 
-		q.Result = make([]*entity.Comment, len(comments))
-		for i, comment := range comments {
-			q.Result[i] = comment.ToModel(ctx)
+		// Moderators and collaborators can see all comments, other users only see approved comments and their own
+		canSee := func(c *dbEntities.Comment) bool {
+			if c.IsDeleted {
+				return false
+			}
+			return c.IsApproved || (user != nil && (user.CanSeePendingContent() || int(c.User.ID.Int64) == user.ID))
+		}
+
+		// Comments that can't be seen are kept as removed when any of their replies can be seen
+		byID := make(map[int]*dbEntities.Comment, len(comments))
+		for _, comment := range comments {
+			byID[comment.ID] = comment
+		}
+		keep := make(map[int]bool, len(comments))
+		for _, comment := range comments {
+			if !canSee(comment) {
+				continue
+			}
+			keep[comment.ID] = true
+			if q.IncludeRemovedParents {
+				for parent := byID[int(comment.ParentID.Int64)]; parent != nil && !keep[parent.ID]; parent = byID[int(parent.ParentID.Int64)] {
+					keep[parent.ID] = true
+				}
+			}
+		}
+
+		for _, comment := range comments {
+			if !keep[comment.ID] {
+				continue
+			}
+			if canSee(comment) {
+				q.Result = append(q.Result, comment.ToModel(ctx))
+			} else {
+				q.Result = append(q.Result, &entity.Comment{
+					ID:         comment.ID,
+					CreatedAt:  comment.CreatedAt,
+					ParentID:   int(comment.ParentID.Int64),
+					IsApproved: true,
+					IsRemoved:  true,
+				})
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,333 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+const defaultExportLimit = 500
+
+// exportedPostsQuery returns the query of the posts that match the filter, along with its parameters.
+// The conditions are the same used by searchPosts, except that no status filter means all statuses
+func exportedPostsQuery(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, filter query.ExportFilter) (string, []any, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []enum.PostStatus{
+			enum.PostOpen,
+			enum.PostStarted,
+			enum.PostPlanned,
+			enum.PostCompleted,
+			enum.PostDeclined,
+		}
+	}
+
+	innerQuery := buildPostQuery(user, "p.tenant_id = $1 AND p.status = ANY($2)", "")
+	params := []any{tenant.ID, pq.Array(statuses)}
+	condition := ""
+
+	if filter.NoTagsOnly {
+		condition += " AND tags = '{}'"
+	}
+	if len(filter.Tags) > 0 {
+		params = append(params, pq.Array(filter.Tags))
+		condition += fmt.Sprintf(" AND tags && $%d", len(params))
+	}
+	if len(filter.TagGroups) > 0 {
+		groupTags, err := queryVisibleTagSlugsByGroups(trx, tenant, user, filter.TagGroups)
+		if err != nil {
+			return "", nil, err
+		}
+		params = append(params, pq.Array(groupTags))
+		condition += fmt.Sprintf(" AND tags && $%d", len(params))
+	}
+	if filter.Query != "" {
+		tsQuery := ToTSQuery(SanitizeString(filter.Query))
+		if tsQuery == "" {
+			condition += " AND false"
+		} else {
+			params = append(params, tsQuery)
+			tsQueryExpr, tsQuerySimple := postSearchExpressions(tenant, len(params))
+			condition += fmt.Sprintf(" AND (search @@ %s OR search @@ %s)", tsQueryExpr, tsQuerySimple)
+		}
+	}
+
+	return fmt.Sprintf("SELECT * FROM (%s) AS q WHERE 1 = 1 %s", innerQuery, condition), params, nil
+}
+
+// exportPeriodCondition limits given column to the period of the filter
+func exportPeriodCondition(column string, filter query.ExportFilter, params []any) (string, []any) {
+	condition := ""
+	if filter.Since != nil {
+		params = append(params, *filter.Since)
+		condition += fmt.Sprintf(" AND %s >= $%d", column, len(params))
+	}
+	if filter.Until != nil {
+		params = append(params, *filter.Until)
+		condition += fmt.Sprintf(" AND %s < $%d", column, len(params))
+	}
+	return condition, params
+}
+
+func exportLimit(limit int) int {
+	if limit <= 0 {
+		return defaultExportLimit
+	}
+	return limit
+}
+
+func exportPosts(ctx context.Context, q *query.ExportPosts) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		postsQuery, params, err := exportedPostsQuery(trx, tenant, user, q.Filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to build export query")
+		}
+
+		condition, params := exportPeriodCondition("e.created_at", q.Filter, params)
+		params = append(params, q.AfterID)
+		condition += fmt.Sprintf(" AND e.id > $%d", len(params))
+
+		posts := []*dbEntities.Post{}
+		err = trx.Select(&posts, fmt.Sprintf(`
+			SELECT * FROM (%s) AS e
+			WHERE 1 = 1 %s
+			ORDER BY e.id
+			LIMIT %d
+		`, postsQuery, condition, exportLimit(q.Limit)), params...)
+		if err != nil {
+			return errors.Wrap(err, "failed to export posts")
+		}
+
+		q.Result = make([]*entity.ExportedPost, len(posts))
+		ids := make([]int, len(posts))
+		byID := make(map[int]*entity.ExportedPost, len(posts))
+		for i, post := range posts {
+			q.Result[i] = &entity.ExportedPost{
+				Post:           post.ToModel(ctx),
+				VotesByRole:    make(map[enum.Role]int),
+				VoterEmails:    make([]string, 0),
+				LastActivityAt: post.CreatedAt,
+			}
+			ids[i] = post.ID
+			byID[post.ID] = q.Result[i]
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		emailsColumn := "ARRAY[]::text[]"
+		if q.IncludeEmails {
+			emailsColumn = "ARRAY_REMOVE(ARRAY_AGG(NULLIF(u.email, '')), NULL)"
+		}
+
+		stats := []*dbEntities.PostVoteStats{}
+		err = trx.Select(&stats, `
+			SELECT v.post_id, u.role, COUNT(*) AS votes, `+emailsColumn+` AS emails
+			FROM post_votes v
+			INNER JOIN users u
+			ON u.id = v.user_id
+			AND u.tenant_id = v.tenant_id
+			WHERE v.tenant_id = $1
+			AND v.post_id = ANY($2)
+			GROUP BY v.post_id, u.role
+		`, tenant.ID, pq.Array(ids))
+		if err != nil {
+			return errors.Wrap(err, "failed to get votes of exported posts")
+		}
+		for _, s := range stats {
+			post := byID[s.PostID]
+			post.VotesByRole[enum.Role(s.Role)] = s.Votes
+			post.VoterEmails = append(post.VoterEmails, s.Emails...)
+		}
+		for _, post := range q.Result {
+			sort.Strings(post.VoterEmails)
+		}
+
+		activities := []*dbEntities.PostActivity{}
+		err = trx.Select(&activities, `
+			SELECT p.id AS post_id,
+				GREATEST(
+					p.created_at,
+					p.response_date,
+					(SELECT MAX(c.created_at) FROM comments c WHERE c.post_id = p.id AND c.tenant_id = p.tenant_id AND c.deleted_at IS NULL),
+					(SELECT MAX(v.created_at) FROM post_votes v WHERE v.post_id = p.id AND v.tenant_id = p.tenant_id)
+				) AS last_activity_at
+			FROM posts p
+			WHERE p.tenant_id = $1
+			AND p.id = ANY($2)
+		`, tenant.ID, pq.Array(ids))
+		if err != nil {
+			return errors.Wrap(err, "failed to get last activity of exported posts")
+		}
+		for _, a := range activities {
+			byID[a.PostID].LastActivityAt = a.LastActivityAt
+		}
+
+		return nil
+	})
+}
+
+func exportComments(ctx context.Context, q *query.ExportComments) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		postsQuery, params, err := exportedPostsQuery(trx, tenant, user, q.Filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to build export query")
+		}
+
+		condition, params := exportPeriodCondition("c.created_at", q.Filter, params)
+		params = append(params, q.AfterID)
+		condition += fmt.Sprintf(" AND c.id > $%d", len(params))
+
+		emailColumn := "''"
+		if q.IncludeEmails {
+			emailColumn = "u.email"
+		}
+
+		comments := []*dbEntities.ExportedComment{}
+		err = trx.Select(&comments, fmt.Sprintf(`
+			SELECT c.id,
+				e.number AS post_number,
+				e.title AS post_title,
+				c.content,
+				c.created_at,
+				c.edited_at,
+				c.is_approved,
+				u.id AS user_id,
+				u.name AS user_name,
+				%s AS user_email,
+				u.role AS user_role,
+				u.status AS user_status
+			FROM comments c
+			INNER JOIN (%s) AS e
+			ON e.id = c.post_id
+			INNER JOIN users u
+			ON u.id = c.user_id
+			AND u.tenant_id = c.tenant_id
+			WHERE c.tenant_id = $1
+			AND c.deleted_at IS NULL %s
+			ORDER BY c.id
+			LIMIT %d
+		`, emailColumn, postsQuery, condition, exportLimit(q.Limit)), params...)
+		if err != nil {
+			return errors.Wrap(err, "failed to export comments")
+		}
+
+		q.Result = make([]*entity.ExportedComment, len(comments))
+		for i, comment := range comments {
+			q.Result[i] = comment.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func exportVotes(ctx context.Context, q *query.ExportVotes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		postsQuery, params, err := exportedPostsQuery(trx, tenant, user, q.Filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to build export query")
+		}
+
+		condition, params := exportPeriodCondition("v.created_at", q.Filter, params)
+		params = append(params, q.AfterPostID, q.AfterUserID)
+		condition += fmt.Sprintf(" AND (v.post_id, v.user_id) > ($%d, $%d)", len(params)-1, len(params))
+
+		emailColumn := "''"
+		if q.IncludeEmails {
+			emailColumn = "u.email"
+		}
+
+		votes := []*dbEntities.ExportedVote{}
+		err = trx.Select(&votes, fmt.Sprintf(`
+			SELECT v.post_id,
+				e.number AS post_number,
+				e.title AS post_title,
+				v.created_at,
+				u.id AS user_id,
+				u.name AS user_name,
+				%s AS user_email,
+				u.role AS user_role,
+				u.status AS user_status
+			FROM post_votes v
+			INNER JOIN (%s) AS e
+			ON e.id = v.post_id
+			INNER JOIN users u
+			ON u.id = v.user_id
+			AND u.tenant_id = v.tenant_id
+			WHERE v.tenant_id = $1 %s
+			ORDER BY v.post_id, v.user_id
+			LIMIT %d
+		`, emailColumn, postsQuery, condition, exportLimit(q.Limit)), params...)
+		if err != nil {
+			return errors.Wrap(err, "failed to export votes")
+		}
+
+		q.Result = make([]*entity.ExportedVote, len(votes))
+		for i, vote := range votes {
+			q.Result[i] = vote.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func exportReactions(ctx context.Context, q *query.ExportReactions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		postsQuery, params, err := exportedPostsQuery(trx, tenant, user, q.Filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to build export query")
+		}
+
+		condition, params := exportPeriodCondition("r.created_on", q.Filter, params)
+		params = append(params, q.AfterID)
+		condition += fmt.Sprintf(" AND r.id > $%d", len(params))
+
+		emailColumn := "''"
+		if q.IncludeEmails {
+			emailColumn = "u.email"
+		}
+
+		reactions := []*dbEntities.ExportedReaction{}
+		err = trx.Select(&reactions, fmt.Sprintf(`
+			SELECT r.id,
+				e.number AS post_number,
+				e.title AS post_title,
+				r.comment_id,
+				r.emoji,
+				r.created_on AS created_at,
+				u.id AS user_id,
+				u.name AS user_name,
+				%s AS user_email,
+				u.role AS user_role,
+				u.status AS user_status
+			FROM reactions r
+			INNER JOIN (%s) AS e
+			ON e.id = r.post_id
+			INNER JOIN users u
+			ON u.id = r.user_id
+			AND u.tenant_id = r.tenant_id
+			LEFT JOIN comments c
+			ON c.id = r.comment_id
+			AND c.tenant_id = r.tenant_id
+			WHERE r.tenant_id = $1
+			AND (r.comment_id IS NULL OR c.deleted_at IS NULL) %s
+			ORDER BY r.id
+			LIMIT %d
+		`, emailColumn, postsQuery, condition, exportLimit(q.Limit)), params...)
+		if err != nil {
+			return errors.Wrap(err, "failed to export reactions")
+		}
+
+		q.Result = make([]*entity.ExportedReaction, len(reactions))
+		for i, reaction := range reactions {
+			q.Result[i] = reaction.ToModel(ctx)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,108 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestExportStorage_Posts(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	billing := &cmd.AddNewPost{Title: "Pay by invoice", Description: "For enterprises"}
+	other := &cmd.AddNewPost{Title: "Dark mode", Description: "Please"}
+	err := bus.Dispatch(jonSnowCtx, billing, other)
+	Expect(err).IsNil()
+
+	tag := &cmd.AddNewTag{Name: "Billing", Color: "FF0000", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, tag)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: tag.Result, Post: billing.Result},
+		&cmd.SetPostResponse{Post: billing.Result, Text: "Next quarter", Status: enum.PostPlanned},
+		&cmd.AddVote{Post: billing.Result, User: jonSnow},
+		&cmd.AddVote{Post: billing.Result, User: aryaStark},
+		&cmd.AddVote{Post: billing.Result, User: sansaStark},
+	)
+	Expect(err).IsNil()
+
+	exportPosts := &query.ExportPosts{Filter: query.ExportFilter{
+		Statuses: []enum.PostStatus{enum.PostPlanned},
+		Tags:     []string{"billing"},
+	}}
+	err = bus.Dispatch(jonSnowCtx, exportPosts)
+	Expect(err).IsNil()
+	Expect(exportPosts.Result).HasLen(1)
+
+	post := exportPosts.Result[0]
+	Expect(post.ID).Equals(billing.Result.ID)
+	Expect(post.VotesCount).Equals(3)
+	Expect(post.VotesByRole[enum.RoleAdministrator]).Equals(1)
+	Expect(post.VotesByRole[enum.RoleVisitor]).Equals(2)
+	Expect(post.VoterEmails).HasLen(0)
+	Expect(post.LastActivityAt.Before(post.CreatedAt)).IsFalse()
+
+	withEmails := &query.ExportPosts{IncludeEmails: true}
+	err = bus.Dispatch(jonSnowCtx, withEmails)
+	Expect(err).IsNil()
+	Expect(withEmails.Result).HasLen(2)
+	Expect(withEmails.Result[0].VoterEmails).Equals([]string{"arya.stark@got.com", "jon.snow@got.com", "sansa.stark@got.com"})
+
+	nextPage := &query.ExportPosts{AfterID: billing.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, nextPage)
+	Expect(err).IsNil()
+	Expect(nextPage.Result).HasLen(1)
+	Expect(nextPage.Result[0].ID).Equals(other.Result.ID)
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	future := &query.ExportPosts{Filter: query.ExportFilter{Since: &tomorrow}}
+	err = bus.Dispatch(jonSnowCtx, future)
+	Expect(err).IsNil()
+	Expect(future.Result).HasLen(0)
+}
+
+func TestExportStorage_CommentsAndVotes(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	first := &cmd.AddNewPost{Title: "Pay by invoice", Description: "For enterprises"}
+	second := &cmd.AddNewPost{Title: "Dark mode", Description: "Please"}
+	err := bus.Dispatch(jonSnowCtx, first, second)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx,
+		&cmd.AddNewComment{Post: first.Result, Content: "Yes please"},
+		&cmd.AddNewComment{Post: second.Result, Content: "Too dark"},
+		&cmd.AddVote{Post: first.Result, User: aryaStark},
+		&cmd.AddVote{Post: second.Result, User: aryaStark},
+		&cmd.AddVote{Post: second.Result, User: jonSnow},
+	)
+	Expect(err).IsNil()
+
+	exportComments := &query.ExportComments{Filter: query.ExportFilter{Query: "invoice"}, IncludeEmails: true}
+	err = bus.Dispatch(jonSnowCtx, exportComments)
+	Expect(err).IsNil()
+	Expect(exportComments.Result).HasLen(1)
+	Expect(exportComments.Result[0].Content).Equals("Yes please")
+	Expect(exportComments.Result[0].PostNumber).Equals(first.Result.Number)
+	Expect(exportComments.Result[0].User.Email).Equals("arya.stark@got.com")
+
+	exportVotes := &query.ExportVotes{Limit: 2}
+	err = bus.Dispatch(jonSnowCtx, exportVotes)
+	Expect(err).IsNil()
+	Expect(exportVotes.Result).HasLen(2)
+	Expect(exportVotes.Result[0].User.Email).Equals("")
+
+	last := exportVotes.Result[1]
+	nextPage := &query.ExportVotes{AfterPostID: last.PostID, AfterUserID: last.User.ID}
+	err = bus.Dispatch(jonSnowCtx, nextPage)
+	Expect(err).IsNil()
+	Expect(nextPage.Result).HasLen(1)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+const selectFiles = `
+	SELECT id, post_id, comment_id, user_id, blob_key, name, content_type, size, status, scan_result, created_at
+	FROM files
+`
+
+func addFile(ctx context.Context, c *cmd.AddFile) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var commentID sql.NullInt64
+		if c.File.CommentID > 0 {
+			_ = commentID.Scan(c.File.CommentID)
+		}
+
+		c.File.UserID = user.ID
+		c.File.Status = enum.FileStatusPending
+		c.File.CreatedAt = time.Now()
+
+		err := trx.Get(&c.File.ID, `
+			INSERT INTO files (tenant_id, post_id, comment_id, user_id, blob_key, name, content_type, size, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id
+		`, tenant.ID, c.File.PostID, commentID, user.ID, c.File.BlobKey, c.File.Name, c.File.ContentType, c.File.Size, string(c.File.Status), c.File.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to add file '%s'", c.File.Name)
+		}
+		return nil
+	})
+}
+
+func setFileStatus(ctx context.Context, c *cmd.SetFileStatus) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		var scanResult any
+		if c.ScanResult != "" {
+			scanResult = c.ScanResult
+		}
+
+		_, err := trx.Execute(`
+			UPDATE files SET status = $1, scan_result = $2, scanned_at = $3
+			WHERE id = $4 AND tenant_id = $5
+		`, string(c.Status), scanResult, time.Now(), c.FileID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to set status of file '%d'", c.FileID)
+		}
+		return nil
+	})
+}
+
+func deleteFile(ctx context.Context, c *cmd.DeleteFile) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		_, err := trx.Execute("DELETE FROM files WHERE id = $1 AND tenant_id = $2", c.FileID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete file '%d'", c.FileID)
+		}
+		return nil
+	})
+}
+
+func getFileByID(ctx context.Context, q *query.GetFileByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		file := dbEntities.File{}
+		err := trx.Get(&file, selectFiles+" WHERE id = $1 AND tenant_id = $2", q.FileID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get file '%d'", q.FileID)
+		}
+
+		q.Result = file.ToModel()
+		return nil
+	})
+}
+
+func listPostFiles(ctx context.Context, q *query.ListPostFiles) error {
+	// comments follow the same visibility rules as when they're listed on the post
+	getComments := &query.GetCommentsByPost{Post: &entity.Post{ID: q.PostID}}
+	if err := getCommentsByPost(ctx, getComments); err != nil {
+		return err
+	}
+
+	visibleComments := make(map[int]bool, len(getComments.Result))
+	for _, comment := range getComments.Result {
+		if !comment.IsRemoved {
+			visibleComments[comment.ID] = true
+		}
+	}
+
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		files := []*dbEntities.File{}
+		err := trx.Select(&files, selectFiles+" WHERE post_id = $1 AND tenant_id = $2 ORDER BY created_at, id", q.PostID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list files of post '%d'", q.PostID)
+		}
+
+		q.Result = make([]*entity.File, 0, len(files))
+		for _, file := range files {
+			if !file.CommentID.Valid || visibleComments[int(file.CommentID.Int64)] {
+				q.Result = append(q.Result, file.ToModel())
+			}
+		}
+		return nil
+	})
+}
+
+func getFilesUsage(ctx context.Context, q *query.GetFilesUsage) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		err := trx.Scalar(&q.Result, "SELECT COALESCE(SUM(size), 0) FROM files WHERE tenant_id = $1", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get files usage")
+		}
+		return nil
+	})
+}
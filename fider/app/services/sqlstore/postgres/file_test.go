@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestFileStorage_AddListAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "App crashes on start", Description: "See attached logs"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "Same here"}
+	err = bus.Dispatch(aryaStarkCtx, newComment)
+	Expect(err).IsNil()
+
+	postFile := &cmd.AddFile{File: &entity.File{PostID: newPost.Result.ID, Name: "app.log", ContentType: "text/plain", Size: 100, BlobKey: "files/abc-app.log"}}
+	commentFile := &cmd.AddFile{File: &entity.File{PostID: newPost.Result.ID, CommentID: newComment.Result.ID, Name: "report.pdf", ContentType: "application/pdf", Size: 250, BlobKey: "files/def-report.pdf"}}
+	err = bus.Dispatch(jonSnowCtx, postFile)
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, commentFile)
+	Expect(err).IsNil()
+	Expect(postFile.File.ID).IsNotEmpty()
+	Expect(postFile.File.Status).Equals(enum.FileStatusPending)
+
+	listFiles := &query.ListPostFiles{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, listFiles)
+	Expect(err).IsNil()
+	Expect(listFiles.Result).HasLen(2)
+	Expect(listFiles.Result[0].Name).Equals("app.log")
+	Expect(listFiles.Result[0].UserID).Equals(jonSnow.ID)
+	Expect(listFiles.Result[0].CommentID).Equals(0)
+	Expect(listFiles.Result[0].BlobKey).Equals("files/abc-app.log")
+	Expect(listFiles.Result[1].Name).Equals("report.pdf")
+	Expect(listFiles.Result[1].UserID).Equals(aryaStark.ID)
+	Expect(listFiles.Result[1].CommentID).Equals(newComment.Result.ID)
+
+	// files of deleted comments are no longer listed
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DeleteComment{CommentID: newComment.Result.ID})
+	Expect(err).IsNil()
+
+	listFiles = &query.ListPostFiles{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, listFiles)
+	Expect(err).IsNil()
+	Expect(listFiles.Result).HasLen(1)
+	Expect(listFiles.Result[0].Name).Equals("app.log")
+
+	usage := &query.GetFilesUsage{}
+	err = bus.Dispatch(jonSnowCtx, usage)
+	Expect(err).IsNil()
+	Expect(usage.Result).Equals(int64(350))
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteFile{FileID: postFile.File.ID})
+	Expect(err).IsNil()
+
+	getFile := &query.GetFileByID{FileID: postFile.File.ID}
+	err = bus.Dispatch(jonSnowCtx, getFile)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	usage = &query.GetFilesUsage{}
+	err = bus.Dispatch(jonSnowCtx, usage)
+	Expect(err).IsNil()
+	Expect(usage.Result).Equals(int64(250))
+}
+
+func TestFileStorage_SetStatus(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "App crashes on start", Description: "See attached logs"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	addFile := &cmd.AddFile{File: &entity.File{PostID: newPost.Result.ID, Name: "repro.csv", ContentType: "text/plain", Size: 10, BlobKey: "files/abc-repro.csv"}}
+	err = bus.Dispatch(jonSnowCtx, addFile)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetFileStatus{FileID: addFile.File.ID, Status: enum.FileStatusQuarantined, ScanResult: "Eicar-Test-Signature"})
+	Expect(err).IsNil()
+
+	getFile := &query.GetFileByID{FileID: addFile.File.ID}
+	err = bus.Dispatch(jonSnowCtx, getFile)
+	Expect(err).IsNil()
+	Expect(getFile.Result.Status).Equals(enum.FileStatusQuarantined)
+	Expect(getFile.Result.ScanResult).Equals("Eicar-Test-Signature")
+	Expect(getFile.Result.CanBeDownloaded()).IsFalse()
+
+	// files are not visible from other tenants
+	getFile = &query.GetFileByID{FileID: addFile.File.ID}
+	err = bus.Dispatch(avengersTenantCtx, getFile)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/gosimple/slug"
+)
+
+// importedTagColor is the color of tags created by an import, they can be changed later on the tags page
+const importedTagColor = "8D96A0"
+
+const importJobColumns = `
+	j.id, j.source, j.file_name, j.status, j.total_rows, j.processed_rows, j.failed_rows, j.row_errors, j.created_at, j.finished_at,
+	u.id AS created_by_id,
+	u.name AS created_by_name,
+	u.email AS created_by_email,
+	u.role AS created_by_role,
+	u.status AS created_by_status,
+	u.avatar_type AS created_by_avatar_type,
+	u.avatar_bkey AS created_by_avatar_bkey
+	FROM import_jobs j
+	INNER JOIN users u ON u.id = j.created_by_id AND u.tenant_id = j.tenant_id`
+
+func addImportJob(ctx context.Context, c *cmd.AddImportJob) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO import_jobs (tenant_id, source, file_name, status, total_rows, created_by_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, tenant.ID, string(c.Source), c.FileName, string(enum.ImportPending), c.TotalRows, user.ID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add import job")
+		}
+
+		q := &query.GetImportJobByID{JobID: id}
+		if err := getImportJobByID(ctx, q); err != nil {
+			return err
+		}
+		c.Result = q.Result
+		return nil
+	})
+}
+
+func updateImportJobProgress(ctx context.Context, c *cmd.UpdateImportJobProgress) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rowErrors := c.Errors
+		if rowErrors == nil {
+			rowErrors = make([]*entity.ImportRowError, 0)
+		}
+		content, err := json.Marshal(rowErrors)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal import errors")
+		}
+
+		var finishedAt any
+		if c.Status == enum.ImportCompleted || c.Status == enum.ImportFailed {
+			finishedAt = time.Now()
+		}
+
+		_, err = trx.Execute(`
+			UPDATE import_jobs
+			SET status = $3, processed_rows = $4, failed_rows = $5, row_errors = $6, finished_at = $7
+			WHERE id = $1 AND tenant_id = $2
+		`, c.JobID, tenant.ID, string(c.Status), c.ProcessedRows, c.FailedRows, string(content), finishedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to update import job '%d'", c.JobID)
+		}
+		return nil
+	})
+}
+
+func getImportJobByID(ctx context.Context, q *query.GetImportJobByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		job := dbEntities.ImportJob{}
+		err := trx.Get(&job, "SELECT "+importJobColumns+" WHERE j.id = $1 AND j.tenant_id = $2", q.JobID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get import job '%d'", q.JobID)
+		}
+		q.Result = job.ToModel(ctx)
+		return nil
+	})
+}
+
+func listImportJobs(ctx context.Context, q *query.ListImportJobs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var jobs []*dbEntities.ImportJob
+		err := trx.Select(&jobs, "SELECT "+importJobColumns+" WHERE j.tenant_id = $1 ORDER BY j.created_at DESC, j.id DESC LIMIT 20", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list import jobs")
+		}
+
+		q.Result = make([]*entity.ImportJob, len(jobs))
+		for i, job := range jobs {
+			q.Result[i] = job.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func getImportedPost(ctx context.Context, q *query.GetImportedPost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = nil
+
+		postID, err := getImportTarget(trx, tenant, q.Source, "post", q.SourceID)
+		if err != nil || postID == 0 {
+			return err
+		}
+
+		post, err := querySinglePost(ctx, trx, buildSinglePostQuery(user, "p.tenant_id = $1 AND p.id = $2"), tenant.ID, postID)
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "failed to get imported post '%s'", q.SourceID)
+		}
+		q.Result = post
+		return nil
+	})
+}
+
+func importUser(ctx context.Context, c *cmd.ImportUser) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		email := strings.ToLower(strings.TrimSpace(c.Email))
+		sourceID := c.SourceID
+		if sourceID == "" {
+			sourceID = email
+		}
+
+		userID, err := getImportTarget(trx, tenant, c.Source, "user", sourceID)
+		if err != nil {
+			return err
+		}
+
+		if userID > 0 {
+			c.Result, err = queryUser(ctx, trx, "id = $1 AND tenant_id = $2", userID, tenant.ID)
+			if err == nil {
+				return nil
+			} else if errors.Cause(err) != app.ErrNotFound {
+				return errors.Wrap(err, "failed to get imported user '%s'", sourceID)
+			}
+		}
+
+		if email != "" {
+			c.Result, err = queryUser(ctx, trx, "email = $1 AND tenant_id = $2", email, tenant.ID)
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return errors.Wrap(err, "failed to get user with email '%s'", email)
+			}
+		}
+
+		if c.Result == nil {
+			name := strings.TrimSpace(c.Name)
+			if name == "" {
+				name, _, _ = strings.Cut(email, "@")
+			}
+			if name == "" {
+				name = "Anonymous"
+			}
+
+			var id int
+			err := trx.Get(&id,
+				"INSERT INTO users (name, email, created_at, tenant_id, role, status, avatar_type, avatar_bkey) VALUES ($1, $2, $3, $4, $5, $6, $7, '') RETURNING id",
+				name, email, c.CreatedAt, tenant.ID, enum.RoleVisitor, enum.UserActive, enum.AvatarTypeGravatar)
+			if err != nil {
+				return errors.Wrap(err, "failed to import user '%s'", sourceID)
+			}
+
+			c.Result, err = queryUser(ctx, trx, "id = $1", id)
+			if err != nil {
+				return errors.Wrap(err, "failed to get imported user '%s'", sourceID)
+			}
+		}
+
+		if sourceID == "" {
+			return nil
+		}
+		return setImportTarget(trx, tenant, c.Source, "user", sourceID, c.Result.ID)
+	})
+}
+
+func importPost(ctx context.Context, c *cmd.ImportPost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		postID, err := getImportTarget(trx, tenant, c.Source, "post", c.SourceID)
+		if err != nil {
+			return err
+		}
+
+		lang := detectPostLanguage(c.Title, c.Description)
+		if postID > 0 {
+			_, err = trx.Execute(`
+				UPDATE posts SET title = $3, slug = $4, description = $5, language = $6
+				WHERE id = $1 AND tenant_id = $2
+			`, postID, tenant.ID, c.Title, slug.Make(c.Title), c.Description, lang)
+			if err != nil {
+				return errors.Wrap(err, "failed to update imported post '%s'", c.SourceID)
+			}
+		} else {
+			err = trx.Get(&postID, `
+				INSERT INTO posts (title, slug, number, description, tenant_id, user_id, created_at, status, is_approved, language)
+				VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM posts p WHERE p.tenant_id = $4), $3, $4, $5, $6, 0, true, $7)
+				RETURNING id
+			`, c.Title, slug.Make(c.Title), c.Description, tenant.ID, c.User.ID, c.CreatedAt, lang)
+			if err != nil {
+				return errors.Wrap(err, "failed to import post '%s'", c.SourceID)
+			}
+
+			if err := setImportTarget(trx, tenant, c.Source, "post", c.SourceID, postID); err != nil {
+				return err
+			}
+		}
+
+		if c.Status != enum.PostOpen || c.Response != "" {
+			respondedAt := c.CreatedAt
+			if c.RespondedAt != nil {
+				respondedAt = *c.RespondedAt
+			}
+
+			_, err = trx.Execute(`
+				UPDATE posts SET status = $3, response = $4, response_date = $5, response_user_id = $6
+				WHERE id = $1 AND tenant_id = $2
+			`, postID, tenant.ID, c.Status, c.Response, respondedAt, user.ID)
+			if err != nil {
+				return errors.Wrap(err, "failed to set response of imported post '%s'", c.SourceID)
+			}
+		}
+
+		for _, name := range c.Tags {
+			if err := importPostTag(trx, tenant, user, postID, name); err != nil {
+				return err
+			}
+		}
+
+		post, err := querySinglePost(ctx, trx, buildSinglePostQuery(user, "p.tenant_id = $1 AND p.id = $2"), tenant.ID, postID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get imported post '%s'", c.SourceID)
+		}
+		c.Result = post
+
+		return internalAddSubscriber(trx, post, tenant, c.User, false)
+	})
+}
+
+func importPostTag(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, postID int, name string) error {
+	tagSlug := slug.Make(name)
+	if tagSlug == "" {
+		return nil
+	}
+
+	tag, err := queryTagBySlug(trx, tenant, tagSlug)
+	if errors.Cause(err) == app.ErrNotFound {
+		_, err = trx.Execute(`
+			INSERT INTO tags (name, slug, color, is_public, created_at, tenant_id)
+			VALUES ($1, $2, $3, true, $4, $5)
+		`, name, tagSlug, importedTagColor, time.Now(), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to import tag '%s'", name)
+		}
+		tag, err = queryTagBySlug(trx, tenant, tagSlug)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = trx.Execute(`
+		INSERT INTO post_tags (tag_id, post_id, created_at, created_by_id, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+	`, tag.ID, postID, time.Now(), user.ID, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to assign imported tag '%s'", name)
+	}
+	return nil
+}
+
+func importComment(ctx context.Context, c *cmd.ImportComment) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		commentID, err := getImportTarget(trx, tenant, c.Source, "comment", c.SourceID)
+		if err != nil || commentID > 0 {
+			return err
+		}
+
+		err = trx.Get(&commentID, `
+			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, is_approved)
+			VALUES ($1, $2, $3, $4, $5, true)
+			RETURNING id
+		`, tenant.ID, c.Post.ID, c.Content, c.User.ID, c.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to import comment '%s'", c.SourceID)
+		}
+
+		return setImportTarget(trx, tenant, c.Source, "comment", c.SourceID, commentID)
+	})
+}
+
+func importVote(ctx context.Context, c *cmd.ImportVote) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			`INSERT INTO post_votes (tenant_id, user_id, post_id, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+			tenant.ID, c.User.ID, c.Post.ID, c.CreatedAt,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to import vote of user '%d' on post '%d'", c.User.ID, c.Post.ID)
+		}
+		return nil
+	})
+}
+
+// getImportTarget returns the id of the row previously created for given source id, or 0 if there is none
+func getImportTarget(trx *dbx.Trx, tenant *entity.Tenant, source enum.ImportSource, kind, sourceID string) (int, error) {
+	if sourceID == "" {
+		return 0, nil
+	}
+
+	var targetID int
+	err := trx.Scalar(&targetID, `
+		SELECT COALESCE(MAX(target_id), 0) FROM import_sources
+		WHERE tenant_id = $1 AND source = $2 AND kind = $3 AND source_id = $4
+	`, tenant.ID, string(source), kind, sourceID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get imported %s '%s'", kind, sourceID)
+	}
+	return targetID, nil
+}
+
+func setImportTarget(trx *dbx.Trx, tenant *entity.Tenant, source enum.ImportSource, kind, sourceID string, targetID int) error {
+	if sourceID == "" {
+		return nil
+	}
+
+	_, err := trx.Execute(`
+		INSERT INTO import_sources (tenant_id, source, kind, source_id, target_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, source, kind, source_id) DO UPDATE SET target_id = $5
+	`, tenant.ID, string(source), kind, sourceID, targetID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to map imported %s '%s'", kind, sourceID)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+func addJobRun(ctx context.Context, c *cmd.AddJobRun) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		var runError any
+		if c.Run.Error != "" {
+			runError = c.Run.Error
+		}
+
+		err := trx.Get(&c.Run.ID, `
+			INSERT INTO job_runs (job_name, trigger, status, error, started_at, finished_at, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, c.Run.JobName, string(c.Run.Trigger), string(c.Run.Status), runError, c.Run.StartedAt, c.Run.FinishedAt, c.Run.DurationMs)
+		if err != nil {
+			return errors.Wrap(err, "failed to add run of job '%s'", c.Run.JobName)
+		}
+
+		_, err = trx.Execute(`
+			DELETE FROM job_runs
+			WHERE job_name = $1 AND id NOT IN (
+				SELECT id FROM job_runs WHERE job_name = $1 ORDER BY started_at DESC, id DESC LIMIT $2
+			)
+		`, c.Run.JobName, c.Keep)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete old runs of job '%s'", c.Run.JobName)
+		}
+		return nil
+	})
+}
+
+func listJobRuns(ctx context.Context, q *query.ListJobRuns) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		runs := []*dbEntities.JobRun{}
+		err := trx.Select(&runs, `
+			SELECT id, job_name, trigger, status, error, started_at, finished_at, duration_ms
+			FROM job_runs
+			WHERE job_name = $1
+			ORDER BY started_at DESC, id DESC
+			LIMIT $2
+		`, q.JobName, q.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list runs of job '%s'", q.JobName)
+		}
+
+		q.Result = make([]*entity.JobRun, len(runs))
+		for i, run := range runs {
+			q.Result[i] = run.ToModel()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestJobRunStorage_AddAndList(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 4; i++ {
+		run := &entity.JobRun{
+			JobName:    "TestJob",
+			Trigger:    enum.JobTriggerSchedule,
+			Status:     enum.JobRunSucceeded,
+			StartedAt:  start.Add(time.Duration(i) * time.Minute),
+			FinishedAt: start.Add(time.Duration(i)*time.Minute + time.Second),
+			DurationMs: 1000,
+		}
+		if i == 3 {
+			run.Trigger = enum.JobTriggerManual
+			run.Status = enum.JobRunFailed
+			run.Error = "something went wrong"
+		}
+		err := bus.Dispatch(demoTenantCtx, &cmd.AddJobRun{Run: run, Keep: 3})
+		Expect(err).IsNil()
+		Expect(run.ID).IsNotEmpty()
+	}
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.AddJobRun{Run: &entity.JobRun{
+		JobName:    "OtherJob",
+		Trigger:    enum.JobTriggerSchedule,
+		Status:     enum.JobRunSucceeded,
+		StartedAt:  start,
+		FinishedAt: start,
+	}, Keep: 3})
+	Expect(err).IsNil()
+
+	listRuns := &query.ListJobRuns{JobName: "TestJob", Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, listRuns)
+	Expect(err).IsNil()
+	Expect(listRuns.Result).HasLen(3)
+	Expect(listRuns.Result[0].Trigger).Equals(enum.JobTriggerManual)
+	Expect(listRuns.Result[0].Status).Equals(enum.JobRunFailed)
+	Expect(listRuns.Result[0].Error).Equals("something went wrong")
+	Expect(listRuns.Result[0].DurationMs).Equals(int64(1000))
+	Expect(listRuns.Result[1].Error).Equals("")
+	Expect(listRuns.Result[2].StartedAt.Unix()).Equals(start.Add(time.Minute).Unix())
+
+	listOther := &query.ListJobRuns{JobName: "OtherJob", Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, listOther)
+	Expect(err).IsNil()
+	Expect(listOther.Result).HasLen(1)
+}
@@ -97,6 +97,7 @@ var (
 																d.status AS original_status,
 																COALESCE(agg_t.tags, ARRAY[]::text[]) AS tags,
 																COALESCE(%s, false) AS has_voted,
+																%s AS reaction_counts,
 																p.is_approved
 													FROM posts p
 													INNER JOIN users u
@@ -236,6 +237,9 @@ func countPostPerStatus(ctx context.Context, q *query.CountPostPerStatus) error
 func addNewPost(ctx context.Context, c *cmd.AddNewPost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		isApproved := !tenant.IsModerationEnabled || !user.RequiresModeration()
+		if isFlaggedForModeration(tenant, c.SpamScore) {
+			isApproved = false
+		}
 		var id int
 		// Detect language using lingua-go
 		lang := detectPostLanguage(c.Title, c.Description)
@@ -248,6 +252,12 @@ func addNewPost(ctx context.Context, c *cmd.AddNewPost) error {
 			return errors.Wrap(err, "failed add new post")
 		}
 
+		if c.SpamScore != nil {
+			if err := addSpamCheck(trx, tenant, user, id, 0, c.SpamScore); err != nil {
+				return err
+			}
+		}
+
 		q := &query.GetPostByID{PostID: id}
 		if err := getPostByID(ctx, q); err != nil {
 			return err
@@ -273,6 +283,18 @@ func updatePost(ctx context.Context, c *cmd.UpdatePost) error {
 			return errors.Wrap(err, "failed update post")
 		}
 
+		if isFlaggedForModeration(tenant, c.SpamScore) {
+			if _, err := trx.Execute("UPDATE posts SET is_approved = false WHERE id = $1 AND tenant_id = $2", c.Post.ID, tenant.ID); err != nil {
+				return errors.Wrap(err, "failed to hold post for moderation")
+			}
+		}
+
+		if c.SpamScore != nil {
+			if err := addSpamCheck(trx, tenant, user, c.Post.ID, 0, c.SpamScore); err != nil {
+				return err
+			}
+		}
+
 		q := &query.GetPostByID{PostID: c.Post.ID}
 		if err := getPostByID(ctx, q); err != nil {
 			return err
@@ -443,10 +465,7 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 				return nil
 			}
 
-			tsConfig := MapLocaleToTSConfig(tenant.Locale)
-
-			tsQueryExpr := fmt.Sprintf("to_tsquery('%s', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))", tsConfig)
-			tsQuerySimple := "to_tsquery('simple', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))"
+			tsQueryExpr, tsQuerySimple := postSearchExpressions(tenant, 3)
 
 			score := fmt.Sprintf("ts_rank_cd(q.search, %s) + ts_rank_cd(q.search, %s)", tsQueryExpr, tsQuerySimple)
 
@@ -472,16 +491,25 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 				condition += " AND user_id = " + strconv.Itoa(user.ID)
 			}
 
+			params := []interface{}{tenant.ID, pq.Array(statuses)}
+			if len(q.Tags) > 0 {
+				params = append(params, pq.Array(q.Tags))
+			}
+			if len(q.TagGroups) > 0 {
+				groupTags, err := queryVisibleTagSlugsByGroups(trx, tenant, user, q.TagGroups)
+				if err != nil {
+					return err
+				}
+				params = append(params, pq.Array(groupTags))
+				condition += fmt.Sprintf(" AND tags && $%d", len(params))
+			}
+
 			sql := fmt.Sprintf(`
 				SELECT * FROM (%s) AS q
 				WHERE 1 = 1 %s
 				ORDER BY %s DESC
 				LIMIT %s
 			`, innerQuery, condition, sort, q.Limit)
-			params := []interface{}{tenant.ID, pq.Array(statuses)}
-			if len(q.Tags) > 0 {
-				params = append(params, pq.Array(q.Tags))
-			}
 			err = trx.Select(&posts, sql, params...)
 		}
 
@@ -508,6 +536,15 @@ func getAllPosts(ctx context.Context, q *query.GetAllPosts) error {
 	})
 }
 
+// postSearchExpressions returns the full text queries of the search term at given parameter,
+// the first uses the language of the tenant and the second matches words as they were written
+func postSearchExpressions(tenant *entity.Tenant, param int) (string, string) {
+	tsConfig := MapLocaleToTSConfig(tenant.Locale)
+	tsQueryExpr := fmt.Sprintf("to_tsquery('%s', regexp_replace(regexp_replace($%d, '\\\\s+', ':* & ', 'g'), '$', ':*'))", tsConfig, param)
+	tsQuerySimple := fmt.Sprintf("to_tsquery('simple', regexp_replace(regexp_replace($%d, '\\\\s+', ':* & ', 'g'), '$', ':*'))", param)
+	return tsQueryExpr, tsQuerySimple
+}
+
 func querySinglePost(ctx context.Context, trx *dbx.Trx, query string, args ...any) (*entity.Post, error) {
 	post := dbEntities.Post{}
 
@@ -519,10 +556,7 @@ func querySinglePost(ctx context.Context, trx *dbx.Trx, query string, args ...an
 }
 
 func buildPostQuery(user *entity.User, filter string, moderationFilter string) string {
-	tagCondition := `AND tags.is_public = true`
-	if user != nil && user.IsCollaborator() {
-		tagCondition = ``
-	}
+	tagCondition := tagVisibilityCondition(user, "tags")
 	hasVotedSubQuery := "null"
 	if user != nil {
 		hasVotedSubQuery = fmt.Sprintf("(SELECT true FROM post_votes WHERE post_id = p.id AND user_id = %d)", user.ID)
@@ -531,8 +565,8 @@ func buildPostQuery(user *entity.User, filter string, moderationFilter string) s
 	// Add approval filtering based on moderation filter and user permissions
 	approvalFilter := ""
 
-	// If user is a collaborator and has specified a moderation filter, apply it
-	if user != nil && user.IsCollaborator() && moderationFilter != "" {
+	// If user can see pending content and has specified a moderation filter, apply it
+	if user != nil && user.CanSeePendingContent() && moderationFilter != "" {
 		switch moderationFilter {
 		case "pending":
 			// Show only unapproved posts
@@ -551,16 +585,30 @@ func buildPostQuery(user *entity.User, filter string, moderationFilter string) s
 	}
 
 	combinedFilter := filter + approvalFilter
-	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, combinedFilter)
+	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, reactionCountsSubQuery(user), combinedFilter)
+}
+
+// reactionCountsSubQuery returns the number of reactions of each emoji on a post, and whether given user is one of those who reacted
+func reactionCountsSubQuery(user *entity.User) string {
+	userID := 0
+	if user != nil {
+		userID = user.ID
+	}
+	return fmt.Sprintf(`(
+		SELECT json_agg(json_build_object('emoji', emoji, 'count', count, 'includesMe', includes_me) ORDER BY count DESC)
+		FROM (
+			SELECT emoji, COUNT(*) AS count, bool_or(user_id = %d) AS includes_me
+			FROM reactions
+			WHERE tenant_id = p.tenant_id AND post_id = p.id AND comment_id IS NULL
+			GROUP BY emoji
+		) r
+	)`, userID)
 }
 
 // buildSinglePostQuery is used for fetching individual posts (by ID, slug, or number)
-// Collaborators can view any post for moderation purposes
+// Those who can see pending content can view any post
 func buildSinglePostQuery(user *entity.User, filter string) string {
-	tagCondition := `AND tags.is_public = true`
-	if user != nil && user.IsCollaborator() {
-		tagCondition = ``
-	}
+	tagCondition := tagVisibilityCondition(user, "tags")
 	hasVotedSubQuery := "null"
 	if user != nil {
 		hasVotedSubQuery = fmt.Sprintf("(SELECT true FROM post_votes WHERE post_id = p.id AND user_id = %d)", user.ID)
@@ -568,8 +616,8 @@ func buildSinglePostQuery(user *entity.User, filter string) string {
 
 	// Approval filtering for single post views
 	approvalFilter := ""
-	if user != nil && user.IsCollaborator() {
-		// Collaborators can view any post (for moderation purposes)
+	if user != nil && user.CanSeePendingContent() {
+		// Moderators and collaborators can view any post
 		approvalFilter = ""
 	} else if user != nil {
 		// Regular authenticated users can see approved posts + their own unapproved posts
@@ -580,5 +628,5 @@ func buildSinglePostQuery(user *entity.User, filter string) string {
 	}
 
 	combinedFilter := filter + approvalFilter
-	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, combinedFilter)
+	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, reactionCountsSubQuery(user), combinedFilter)
 }
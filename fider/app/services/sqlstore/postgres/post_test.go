@@ -368,6 +368,52 @@ func TestPostStorage_AddDeleteComment(t *testing.T) {
 	Expect(commentByID.Result).IsNil()
 }
 
+func TestPostStorage_CommentReplies(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	parent := &cmd.AddNewComment{Post: newPost.Result, Content: "Comment #1"}
+	err = bus.Dispatch(jonSnowCtx, parent)
+	Expect(err).IsNil()
+	Expect(parent.Result.ParentID).Equals(0)
+
+	reply := &cmd.AddNewComment{Post: newPost.Result, Content: "Reply #1", ParentID: parent.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, reply)
+	Expect(err).IsNil()
+	Expect(reply.Result.ParentID).Equals(parent.Result.ID)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteComment{CommentID: parent.Result.ID})
+	Expect(err).IsNil()
+
+	commentsByPost := &query.GetCommentsByPost{Post: newPost.Result}
+	err = bus.Dispatch(aryaStarkCtx, commentsByPost)
+	Expect(err).IsNil()
+	Expect(commentsByPost.Result).HasLen(1)
+	Expect(commentsByPost.Result[0].ID).Equals(reply.Result.ID)
+
+	commentsByPost = &query.GetCommentsByPost{Post: newPost.Result, IncludeRemovedParents: true}
+	err = bus.Dispatch(aryaStarkCtx, commentsByPost)
+	Expect(err).IsNil()
+	Expect(commentsByPost.Result).HasLen(2)
+	Expect(commentsByPost.Result[0].ID).Equals(reply.Result.ID)
+	Expect(commentsByPost.Result[0].IsRemoved).IsFalse()
+	Expect(commentsByPost.Result[1].ID).Equals(parent.Result.ID)
+	Expect(commentsByPost.Result[1].IsRemoved).IsTrue()
+	Expect(commentsByPost.Result[1].Content).Equals("")
+	Expect(commentsByPost.Result[1].User).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DeleteComment{CommentID: reply.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, commentsByPost)
+	Expect(err).IsNil()
+	Expect(commentsByPost.Result).HasLen(0)
+}
+
 func TestPostStorage_AddAndGet_DifferentTenants(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/tracing"
 )
 
 func init() {
@@ -53,6 +54,12 @@ func (s Service) Init() {
 	bus.AddHandler(deleteTag)
 	bus.AddHandler(assignTag)
 	bus.AddHandler(unassignTag)
+	bus.AddHandler(getTagGroupBySlug)
+	bus.AddHandler(getTagGroupByID)
+	bus.AddHandler(getAllTagGroups)
+	bus.AddHandler(addNewTagGroup)
+	bus.AddHandler(updateTagGroup)
+	bus.AddHandler(deleteTagGroup)
 
 	bus.AddHandler(addVote)
 	bus.AddHandler(removeVote)
@@ -66,6 +73,10 @@ func (s Service) Init() {
 	bus.AddHandler(searchPosts)
 	bus.AddHandler(findSimilarPosts)
 	bus.AddHandler(getAllPosts)
+	bus.AddHandler(exportPosts)
+	bus.AddHandler(exportComments)
+	bus.AddHandler(exportVotes)
+	bus.AddHandler(exportReactions)
 	bus.AddHandler(countPostPerStatus)
 	bus.AddHandler(markPostAsDuplicate)
 	bus.AddHandler(setPostResponse)
@@ -73,12 +84,22 @@ func (s Service) Init() {
 
 	bus.AddHandler(setAttachments)
 	bus.AddHandler(getAttachments)
+	bus.AddHandler(addFile)
+	bus.AddHandler(setFileStatus)
+	bus.AddHandler(deleteFile)
+	bus.AddHandler(getFileByID)
+	bus.AddHandler(listPostFiles)
+	bus.AddHandler(getFilesUsage)
 	bus.AddHandler(uploadImage)
 	bus.AddHandler(uploadImages)
+	bus.AddHandler(listReferencedBlobs)
+	bus.AddHandler(trackOrphanedBlobs)
 
 	bus.AddHandler(addNewComment)
 	bus.AddHandler(updateComment)
 	bus.AddHandler(toggleCommentReaction)
+	bus.AddHandler(togglePostReaction)
+	bus.AddHandler(listReactions)
 	bus.AddHandler(deleteComment)
 	bus.AddHandler(getCommentByID)
 	bus.AddHandler(getCommentsByPost)
@@ -102,18 +123,23 @@ func (s Service) Init() {
 	bus.AddHandler(getUserByID)
 	bus.AddHandler(getUserByProvider)
 	bus.AddHandler(getAllUsers)
-	bus.AddHandler(getAllUsersNames)
+	bus.AddHandler(searchTaggableUsers)
+	bus.AddHandler(getUserNamesByIDs)
 	bus.AddHandler(searchUsers)
 
 	bus.AddHandler(createTenant)
 	bus.AddHandler(getFirstTenant)
+	bus.AddHandler(getActiveTenants)
+	bus.AddHandler(getAllTenants)
 	bus.AddHandler(getTenantByDomain)
+	bus.AddHandler(getTenantByID)
 	bus.AddHandler(activateTenant)
 	bus.AddHandler(isSubdomainAvailable)
 	bus.AddHandler(isCNAMEAvailable)
 	bus.AddHandler(updateTenantSettings)
 	bus.AddHandler(updateTenantPrivacySettings)
 	bus.AddHandler(updateTenantEmailAuthAllowedSettings)
+	bus.AddHandler(updateTenantTwoFactorSettings)
 	bus.AddHandler(updateTenantAdvancedSettings)
 
 	bus.AddHandler(getVerificationByKey)
@@ -137,6 +163,39 @@ func (s Service) Init() {
 	bus.AddHandler(deleteWebhook)
 	bus.AddHandler(markWebhookAsFailed)
 
+	bus.AddHandler(addAuditLog)
+	bus.AddHandler(searchAuditLogs)
+
+	bus.AddHandler(addImportJob)
+	bus.AddHandler(updateImportJobProgress)
+	bus.AddHandler(getImportJobByID)
+	bus.AddHandler(listImportJobs)
+	bus.AddHandler(getImportedPost)
+	bus.AddHandler(importUser)
+	bus.AddHandler(importPost)
+	bus.AddHandler(importComment)
+	bus.AddHandler(importVote)
+
+	bus.AddHandler(listRoles)
+	bus.AddHandler(getRoleByID)
+	bus.AddHandler(getRoleByName)
+	bus.AddHandler(addNewRole)
+	bus.AddHandler(updateRole)
+	bus.AddHandler(deleteRole)
+	bus.AddHandler(assignCustomRole)
+
+	bus.AddHandler(getTwoFactorSecret)
+	bus.AddHandler(saveTwoFactorSecret)
+	bus.AddHandler(enableTwoFactor)
+	bus.AddHandler(disableTwoFactor)
+	bus.AddHandler(replaceRecoveryCodes)
+	bus.AddHandler(countUnusedRecoveryCodes)
+	bus.AddHandler(markTwoFactorStepUsed)
+	bus.AddHandler(useRecoveryCode)
+	bus.AddHandler(getTwoFactorLockout)
+	bus.AddHandler(recordTwoFactorFailure)
+	bus.AddHandler(resetTwoFactorFailures)
+
 	bus.AddHandler(activateBillingSubscription)
 	bus.AddHandler(cancelBillingSubscription)
 	bus.AddHandler(getStripeBillingState)
@@ -145,8 +204,18 @@ func (s Service) Init() {
 
 	bus.AddHandler(setSystemSettings)
 	bus.AddHandler(getSystemSettings)
+	bus.AddHandler(addJobRun)
+	bus.AddHandler(listJobRuns)
 	bus.AddHandler(AddMentionNotification)
 	bus.AddHandler(getMentionsNotifications)
+	bus.AddHandler(getSpamSignals)
+	bus.AddHandler(getWordFilters)
+	bus.AddHandler(getWordFilterByID)
+	bus.AddHandler(addWordFilter)
+	bus.AddHandler(deleteWordFilter)
+	bus.AddHandler(hasReportedContent)
+	bus.AddHandler(addContentReport)
+	bus.AddHandler(resolveContentReports)
 
 	// Only register moderation handlers if commercial service is not available
 	// Check if commercial features are enabled via license service
@@ -169,5 +238,8 @@ func using(ctx context.Context, handler SqlHandler) error {
 	trx, _ := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
 	tenant, _ := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
 	user, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+	if trx != nil && tracing.IsEnabled() {
+		trx = trx.WithContext(ctx)
+	}
 	return handler(trx, tenant, user)
 }
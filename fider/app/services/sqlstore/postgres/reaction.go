@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+type dbReaction struct {
+	ID        int              `db:"id"`
+	Emoji     string           `db:"emoji"`
+	User      *dbEntities.User `db:"user"`
+	CreatedAt time.Time        `db:"created_on"`
+}
+
+func togglePostReaction(ctx context.Context, c *cmd.TogglePostReaction) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var added bool
+		err := trx.Scalar(&added, `
+			WITH toggle_reaction AS (
+				INSERT INTO reactions (tenant_id, post_id, user_id, emoji, created_on)
+				VALUES ($5, $1, $2, $3, $4)
+				ON CONFLICT (post_id, user_id, emoji) WHERE comment_id IS NULL DO NOTHING
+				RETURNING true AS added
+			),
+			delete_existing AS (
+				DELETE FROM reactions
+				WHERE post_id = $1 AND comment_id IS NULL AND user_id = $2 AND emoji = $3 AND tenant_id = $5
+				AND NOT EXISTS (SELECT 1 FROM toggle_reaction)
+				RETURNING false AS added
+			)
+			SELECT COALESCE(
+				(SELECT added FROM toggle_reaction),
+				(SELECT added FROM delete_existing),
+				false
+			)
+		`, c.Post.ID, user.ID, c.Emoji, time.Now(), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to toggle post reaction")
+		}
+
+		c.Result = added
+		return nil
+	})
+}
+
+func listReactions(ctx context.Context, q *query.ListReactions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		reactions := []*dbReaction{}
+		err := trx.Select(&reactions, `
+			SELECT r.id, r.emoji, r.created_on,
+				u.id AS user_id,
+				u.name AS user_name,
+				u.role AS user_role,
+				u.status AS user_status,
+				u.avatar_type AS user_avatar_type,
+				u.avatar_bkey AS user_avatar_bkey
+			FROM reactions r
+			INNER JOIN users u
+			ON u.id = r.user_id
+			AND u.tenant_id = r.tenant_id
+			WHERE r.tenant_id = $1 AND r.post_id = $2 AND COALESCE(r.comment_id, 0) = $3
+			ORDER BY r.created_on`, tenant.ID, q.PostID, q.CommentID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list reactions")
+		}
+
+		q.Result = make([]*entity.Reaction, len(reactions))
+		for i, r := range reactions {
+			q.Result[i] = &entity.Reaction{
+				ID:        r.ID,
+				Emoji:     r.Emoji,
+				User:      r.User.ToModel(ctx),
+				CreatedAt: r.CreatedAt,
+			}
+		}
+		return nil
+	})
+}
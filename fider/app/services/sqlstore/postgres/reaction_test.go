@@ -0,0 +1,85 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestReactionStorage_TogglePostReaction(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	reaction := &cmd.TogglePostReaction{Post: newPost.Result, Emoji: "🎉", User: jonSnow}
+	err = bus.Dispatch(jonSnowCtx, reaction)
+	Expect(err).IsNil()
+	Expect(reaction.Result).IsTrue()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.TogglePostReaction{Post: newPost.Result, Emoji: "🎉", User: aryaStark})
+	Expect(err).IsNil()
+
+	postByNumber := &query.GetPostByNumber{Number: newPost.Result.Number}
+	err = bus.Dispatch(jonSnowCtx, postByNumber)
+	Expect(err).IsNil()
+	Expect(postByNumber.Result.ReactionCounts).HasLen(1)
+	Expect(postByNumber.Result.ReactionCounts[0].Emoji).Equals("🎉")
+	Expect(postByNumber.Result.ReactionCounts[0].Count).Equals(2)
+	Expect(postByNumber.Result.ReactionCounts[0].IncludesMe).IsTrue()
+
+	reaction = &cmd.TogglePostReaction{Post: newPost.Result, Emoji: "🎉", User: jonSnow}
+	err = bus.Dispatch(jonSnowCtx, reaction)
+	Expect(err).IsNil()
+	Expect(reaction.Result).IsFalse()
+
+	postByNumber = &query.GetPostByNumber{Number: newPost.Result.Number}
+	err = bus.Dispatch(jonSnowCtx, postByNumber)
+	Expect(err).IsNil()
+	Expect(postByNumber.Result.ReactionCounts).HasLen(1)
+	Expect(postByNumber.Result.ReactionCounts[0].Count).Equals(1)
+	Expect(postByNumber.Result.ReactionCounts[0].IncludesMe).IsFalse()
+}
+
+func TestReactionStorage_PostAndCommentReactionsAreKeptApart(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "This is my comment"}
+	err = bus.Dispatch(jonSnowCtx, newComment)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.TogglePostReaction{Post: newPost.Result, Emoji: "👍", User: jonSnow})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.ToggleCommentReaction{Comment: newComment.Result, Emoji: "🚀", User: aryaStark})
+	Expect(err).IsNil()
+
+	postReactions := &query.ListReactions{PostID: newPost.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, postReactions)
+	Expect(err).IsNil()
+	Expect(postReactions.Result).HasLen(1)
+	Expect(postReactions.Result[0].Emoji).Equals("👍")
+	Expect(postReactions.Result[0].User.ID).Equals(jonSnow.ID)
+
+	commentReactions := &query.ListReactions{PostID: newPost.Result.ID, CommentID: newComment.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, commentReactions)
+	Expect(err).IsNil()
+	Expect(commentReactions.Result).HasLen(1)
+	Expect(commentReactions.Result[0].Emoji).Equals("🚀")
+	Expect(commentReactions.Result[0].User.ID).Equals(aryaStark.ID)
+
+	postByNumber := &query.GetPostByNumber{Number: newPost.Result.Number}
+	err = bus.Dispatch(demoTenantCtx, postByNumber)
+	Expect(err).IsNil()
+	Expect(postByNumber.Result.ReactionCounts).HasLen(1)
+	Expect(postByNumber.Result.ReactionCounts[0].Emoji).Equals("👍")
+}
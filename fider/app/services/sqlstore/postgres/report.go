@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func hasReportedContent(ctx context.Context, q *query.HasReportedContent) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		err := trx.Scalar(&q.Result, `
+			SELECT EXISTS(
+				SELECT 1 FROM content_reports
+				WHERE tenant_id = $1 AND user_id = $2 AND post_id = $3 AND COALESCE(comment_id, 0) = $4
+			)
+		`, tenant.ID, user.ID, q.PostID, q.CommentID)
+		if err != nil {
+			return errors.Wrap(err, "failed to check if user has reported content")
+		}
+		return nil
+	})
+}
+
+func addContentReport(ctx context.Context, c *cmd.AddContentReport) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		commentID := 0
+		if c.Comment != nil {
+			commentID = c.Comment.ID
+		}
+
+		now := time.Now()
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO content_reports (tenant_id, post_id, comment_id, user_id, reason, details, created_at)
+			VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6, $7)
+			RETURNING id
+		`, tenant.ID, c.Post.ID, commentID, user.ID, c.Reason, c.Details, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to add content report")
+		}
+
+		c.Result = &entity.ContentReport{
+			ID:        id,
+			PostID:    c.Post.ID,
+			CommentID: commentID,
+			Reason:    c.Reason,
+			Details:   c.Details,
+			Reporter:  user,
+			CreatedAt: now,
+		}
+
+		// Content is only hidden on sites with a moderation queue, otherwise nobody would be able to restore it
+		if env.Config.Reports.HideThreshold <= 0 || !tenant.HasCommercialFeatures {
+			return nil
+		}
+
+		var openReports int
+		err = trx.Scalar(&openReports, `
+			SELECT COUNT(*) FROM content_reports
+			WHERE tenant_id = $1 AND post_id = $2 AND COALESCE(comment_id, 0) = $3 AND resolved_at IS NULL
+		`, tenant.ID, c.Post.ID, commentID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count open reports")
+		}
+		if openReports < env.Config.Reports.HideThreshold {
+			return nil
+		}
+
+		var hidden int64
+		if commentID > 0 {
+			hidden, err = trx.Execute("UPDATE comments SET is_approved = false WHERE id = $1 AND tenant_id = $2 AND is_approved = true", commentID, tenant.ID)
+		} else {
+			hidden, err = trx.Execute("UPDATE posts SET is_approved = false WHERE id = $1 AND tenant_id = $2 AND is_approved = true", c.Post.ID, tenant.ID)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to hide reported content")
+		}
+		c.IsHidden = hidden > 0
+		return nil
+	})
+}
+
+func resolveContentReports(ctx context.Context, c *cmd.ResolveContentReports) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var err error
+		if c.CommentID > 0 {
+			_, err = trx.Execute(`
+				UPDATE content_reports SET resolution = $1, resolved_at = $2, resolved_by_id = $3
+				WHERE tenant_id = $4 AND comment_id = $5 AND resolved_at IS NULL
+			`, c.Resolution, time.Now(), user.ID, tenant.ID, c.CommentID)
+		} else {
+			_, err = trx.Execute(`
+				UPDATE content_reports SET resolution = $1, resolved_at = $2, resolved_by_id = $3
+				WHERE tenant_id = $4 AND post_id = $5 AND comment_id IS NULL AND resolved_at IS NULL
+			`, c.Resolution, time.Now(), user.ID, tenant.ID, c.PostID)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve content reports")
+		}
+		return nil
+	})
+}
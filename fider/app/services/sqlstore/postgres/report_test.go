@@ -0,0 +1,144 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func withCommercialFeatures(ctx context.Context) context.Context {
+	tenant := *ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	tenant.HasCommercialFeatures = true
+	return context.WithValue(ctx, app.TenantCtxKey, &tenant)
+}
+
+func TestReportStorage_AddAndHasReported(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	hasReported := &query.HasReportedContent{PostID: newPost.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, hasReported)
+	Expect(err).IsNil()
+	Expect(hasReported.Result).IsFalse()
+
+	addReport := &cmd.AddContentReport{Post: newPost.Result, Reason: enum.ReportSpam, Details: "Selling watches"}
+	err = bus.Dispatch(aryaStarkCtx, addReport)
+	Expect(err).IsNil()
+	Expect(addReport.Result.ID).NotEquals(0)
+	Expect(addReport.Result.Reason).Equals(enum.ReportSpam)
+	Expect(addReport.Result.Reporter.ID).Equals(aryaStark.ID)
+	Expect(addReport.IsHidden).IsFalse()
+
+	err = bus.Dispatch(aryaStarkCtx, hasReported)
+	Expect(err).IsNil()
+	Expect(hasReported.Result).IsTrue()
+
+	// Reports of a post and of its comments are kept apart
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "Buy my watches"}
+	err = bus.Dispatch(jonSnowCtx, newComment)
+	Expect(err).IsNil()
+
+	hasReportedComment := &query.HasReportedContent{PostID: newPost.Result.ID, CommentID: newComment.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, hasReportedComment)
+	Expect(err).IsNil()
+	Expect(hasReportedComment.Result).IsFalse()
+
+	err = bus.Dispatch(sansaStarkCtx, hasReported)
+	Expect(err).IsNil()
+	Expect(hasReported.Result).IsFalse()
+
+	// Members can't report the same content twice
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddContentReport{Post: newPost.Result, Reason: enum.ReportOther})
+	Expect(err).IsNotNil()
+}
+
+func TestReportStorage_HideAtThreshold(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	threshold := env.Config.Reports.HideThreshold
+	env.Config.Reports.HideThreshold = 2
+	defer func() {
+		env.Config.Reports.HideThreshold = threshold
+	}()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "Buy my watches"}
+	err = bus.Dispatch(jonSnowCtx, newComment)
+	Expect(err).IsNil()
+
+	addReport := &cmd.AddContentReport{Post: newPost.Result, Comment: newComment.Result, Reason: enum.ReportSpam}
+	err = bus.Dispatch(withCommercialFeatures(aryaStarkCtx), addReport)
+	Expect(err).IsNil()
+	Expect(addReport.IsHidden).IsFalse()
+
+	addReport = &cmd.AddContentReport{Post: newPost.Result, Comment: newComment.Result, Reason: enum.ReportOffensive}
+	err = bus.Dispatch(withCommercialFeatures(sansaStarkCtx), addReport)
+	Expect(err).IsNil()
+	Expect(addReport.IsHidden).IsTrue()
+
+	getComment := &query.GetCommentByID{CommentID: newComment.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getComment)
+	Expect(err).IsNil()
+	Expect(getComment.Result.IsApproved).IsFalse()
+
+	// Post is only reported once, and without a moderation queue it's never hidden
+	addReport = &cmd.AddContentReport{Post: newPost.Result, Reason: enum.ReportSpam}
+	err = bus.Dispatch(aryaStarkCtx, addReport)
+	Expect(err).IsNil()
+	addReport = &cmd.AddContentReport{Post: newPost.Result, Reason: enum.ReportSpam}
+	err = bus.Dispatch(sansaStarkCtx, addReport)
+	Expect(err).IsNil()
+	Expect(addReport.IsHidden).IsFalse()
+}
+
+func TestReportStorage_Resolve(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "Buy my watches"}
+	err = bus.Dispatch(jonSnowCtx, newComment)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddContentReport{Post: newPost.Result, Reason: enum.ReportOffTopic})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddContentReport{Post: newPost.Result, Comment: newComment.Result, Reason: enum.ReportSpam})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ResolveContentReports{CommentID: newComment.Result.ID, Resolution: enum.ReportRemoved})
+	Expect(err).IsNil()
+
+	Expect(countReports(newPost.Result.ID, "comment_id IS NULL AND resolution IS NULL")).Equals(1)
+	Expect(countReports(newPost.Result.ID, "comment_id IS NOT NULL AND resolution = 2 AND resolved_by_id IS NOT NULL")).Equals(1)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ResolveContentReports{PostID: newPost.Result.ID, Resolution: enum.ReportDismissed})
+	Expect(err).IsNil()
+
+	Expect(countReports(newPost.Result.ID, "comment_id IS NULL AND resolution = 1")).Equals(1)
+	Expect(countReports(newPost.Result.ID, "comment_id IS NOT NULL AND resolution = 2")).Equals(1)
+}
+
+func countReports(postID int, where string) int {
+	var count int
+	_ = trx.Scalar(&count, "SELECT COUNT(*) FROM content_reports WHERE post_id = $1 AND "+where, postID)
+	return count
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+func listRoles(ctx context.Context, q *query.ListRoles) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		roles, err := queryRoles(trx, tenant)
+		if err != nil {
+			return errors.Wrap(err, "failed to list roles")
+		}
+		q.Result = roles
+		return nil
+	})
+}
+
+func getRoleByID(ctx context.Context, q *query.GetRoleByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		role := dbEntities.Role{}
+		err := trx.Get(&role, `
+			SELECT id, name, description, permissions
+			FROM roles
+			WHERE id = $1 AND tenant_id = $2
+		`, q.RoleID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get role with id '%d'", q.RoleID)
+		}
+		q.Result = role.ToModel()
+		return nil
+	})
+}
+
+func getRoleByName(ctx context.Context, q *query.GetRoleByName) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		role := dbEntities.Role{}
+		err := trx.Get(&role, `
+			SELECT id, name, description, permissions
+			FROM roles
+			WHERE LOWER(name) = LOWER($1) AND tenant_id = $2
+		`, q.Name, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get role with name '%s'", q.Name)
+		}
+		q.Result = role.ToModel()
+		return nil
+	})
+}
+
+func addNewRole(ctx context.Context, c *cmd.AddNewRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO roles (tenant_id, name, description, permissions, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, tenant.ID, c.Name, c.Description, pq.Array(permissionValues(c.Permissions)), time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add new role")
+		}
+
+		c.Result = &entity.Role{
+			ID:          id,
+			Name:        c.Name,
+			Description: c.Description,
+			Permissions: c.Permissions,
+		}
+		return nil
+	})
+}
+
+func updateRole(ctx context.Context, c *cmd.UpdateRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE roles SET name = $1, description = $2, permissions = $3
+			WHERE id = $4 AND tenant_id = $5
+		`, c.Name, c.Description, pq.Array(permissionValues(c.Permissions)), c.RoleID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update role")
+		}
+
+		c.Result = &entity.Role{
+			ID:          c.RoleID,
+			Name:        c.Name,
+			Description: c.Description,
+			Permissions: c.Permissions,
+		}
+		return nil
+	})
+}
+
+func deleteRole(ctx context.Context, c *cmd.DeleteRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE users SET custom_role_id = NULL WHERE custom_role_id = $1 AND tenant_id = $2`, c.Role.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to unassign role with id '%d'", c.Role.ID)
+		}
+
+		_, err = trx.Execute(`
+			UPDATE tag_groups SET visible_to = array_remove(visible_to, $1), assignable_by = array_remove(assignable_by, $1)
+			WHERE tenant_id = $2
+		`, entity.CustomRoleKey(c.Role.ID), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove role with id '%d' from tag groups", c.Role.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM roles WHERE id = $1 AND tenant_id = $2`, c.Role.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete role with id '%d'", c.Role.ID)
+		}
+		return nil
+	})
+}
+
+func assignCustomRole(ctx context.Context, c *cmd.AssignCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var roleID any
+		if c.Role != nil {
+			roleID = c.Role.ID
+		}
+
+		_, err := trx.Execute(`UPDATE users SET custom_role_id = $1 WHERE id = $2 AND tenant_id = $3`, roleID, c.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to assign custom role to user")
+		}
+		return nil
+	})
+}
+
+func queryRoles(trx *dbx.Trx, tenant *entity.Tenant) ([]*entity.Role, error) {
+	roles := []*dbEntities.Role{}
+	err := trx.Select(&roles, `
+		SELECT id, name, description, permissions
+		FROM roles
+		WHERE tenant_id = $1
+		ORDER BY name
+	`, tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Role, len(roles))
+	for i, role := range roles {
+		result[i] = role.ToModel()
+	}
+	return result, nil
+}
+
+func permissionValues(permissions []enum.Permission) []string {
+	values := make([]string, len(permissions))
+	for i, p := range permissions {
+		values[i] = string(p)
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestRoleStorage_AddUpdateAndList(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewRole := &cmd.AddNewRole{
+		Name:        "Moderator",
+		Description: "Keeps the conversation healthy",
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionModerateContent},
+	}
+	err := bus.Dispatch(demoTenantCtx, addNewRole)
+	Expect(err).IsNil()
+	Expect(addNewRole.Result.ID).NotEquals(0)
+
+	updateRole := &cmd.UpdateRole{
+		RoleID:      addNewRole.Result.ID,
+		Name:        "Support",
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionRespondToPosts},
+	}
+	err = bus.Dispatch(demoTenantCtx, updateRole)
+	Expect(err).IsNil()
+
+	getRole := &query.GetRoleByName{Name: "support"}
+	err = bus.Dispatch(demoTenantCtx, getRole)
+	Expect(err).IsNil()
+	Expect(getRole.Result.ID).Equals(addNewRole.Result.ID)
+	Expect(getRole.Result.Name).Equals("Support")
+	Expect(getRole.Result.Description).Equals("")
+	Expect(getRole.Result.Permissions).Equals([]enum.Permission{enum.PermissionAccessAdmin, enum.PermissionRespondToPosts})
+
+	listRoles := &query.ListRoles{}
+	err = bus.Dispatch(demoTenantCtx, listRoles)
+	Expect(err).IsNil()
+	Expect(listRoles.Result).HasLen(1)
+
+	err = bus.Dispatch(avengersTenantCtx, listRoles)
+	Expect(err).IsNil()
+	Expect(listRoles.Result).HasLen(0)
+}
+
+func TestRoleStorage_AssignAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewRole := &cmd.AddNewRole{
+		Name:        "Moderator",
+		Permissions: []enum.Permission{enum.PermissionAccessAdmin, enum.PermissionModerateContent},
+	}
+	err := bus.Dispatch(demoTenantCtx, addNewRole)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignCustomRole{UserID: aryaStark.ID, Role: addNewRole.Result})
+	Expect(err).IsNil()
+
+	getUser := &query.GetUserByID{UserID: aryaStark.ID}
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.CustomRole.ID).Equals(addNewRole.Result.ID)
+	Expect(getUser.Result.HasPermission(enum.PermissionModerateContent)).IsTrue()
+
+	searchUsers := &query.SearchUsers{Query: "Arya"}
+	err = bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(1)
+	Expect(searchUsers.Result[0].CustomRole.Name).Equals("Moderator")
+
+	// changing the built-in role removes the custom role
+	err = bus.Dispatch(demoTenantCtx, &cmd.ChangeUserRole{UserID: aryaStark.ID, Role: enum.RoleVisitor})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.CustomRole).IsNil()
+	Expect(getUser.Result.HasPermission(enum.PermissionModerateContent)).IsFalse()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignCustomRole{UserID: aryaStark.ID, Role: addNewRole.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteRole{Role: addNewRole.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.CustomRole).IsNil()
+	Expect(getUser.Result.HasPermission(enum.PermissionModerateContent)).IsFalse()
+
+	getRole := &query.GetRoleByID{RoleID: addNewRole.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, getRole)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbSpamSignals struct {
+	RecentByUser     int       `db:"recent_by_user"`
+	RecentByIP       int       `db:"recent_by_ip"`
+	DuplicateCount   int       `db:"duplicate_count"`
+	AccountCreatedAt time.Time `db:"account_created_at"`
+}
+
+func getSpamSignals(ctx context.Context, q *query.GetSpamSignals) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		signals := dbSpamSignals{}
+		err := trx.Get(&signals, `
+			SELECT
+				(SELECT COUNT(*) FROM spam_checks WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $5) AS recent_by_user,
+				(SELECT COUNT(*) FROM spam_checks WHERE tenant_id = $1 AND ip = $3 AND created_at >= $5) AS recent_by_ip,
+				(SELECT COUNT(*) FROM spam_checks WHERE tenant_id = $1 AND content_hash = $4) AS duplicate_count,
+				u.created_at AS account_created_at
+			FROM users u
+			WHERE u.id = $2 AND u.tenant_id = $1
+		`, tenant.ID, q.UserID, q.IP, q.ContentHash, q.Since)
+		if err != nil {
+			return errors.Wrap(err, "failed to get spam signals of user '%d'", q.UserID)
+		}
+
+		q.Result = &entity.SpamSignals{
+			RecentByUser:     signals.RecentByUser,
+			RecentByIP:       signals.RecentByIP,
+			DuplicateCount:   signals.DuplicateCount,
+			AccountCreatedAt: signals.AccountCreatedAt,
+		}
+		return nil
+	})
+}
+
+// isFlaggedForModeration returns true when content must be held for moderation because of its spam score.
+// Content is only held on sites with a moderation queue, otherwise nobody would be able to publish it
+func isFlaggedForModeration(tenant *entity.Tenant, score *entity.SpamScore) bool {
+	return score != nil && score.IsFlagged && tenant.HasCommercialFeatures
+}
+
+// addSpamCheck records the score of a new post or comment, which is also what velocity and duplicates are counted from
+func addSpamCheck(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, postID, commentID int, score *entity.SpamScore) error {
+	reasons := score.Reasons
+	if reasons == nil {
+		reasons = make([]entity.SpamReason, 0)
+	}
+	content, err := json.Marshal(reasons)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal spam reasons")
+	}
+
+	_, err = trx.Execute(`
+		INSERT INTO spam_checks (tenant_id, user_id, post_id, comment_id, ip, content_hash, score, reasons, is_flagged, created_at)
+		VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8, $9, $10)
+	`, tenant.ID, user.ID, postID, commentID, score.IP, score.ContentHash, score.Score, string(content), score.IsFlagged, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to add spam check")
+	}
+	return nil
+}
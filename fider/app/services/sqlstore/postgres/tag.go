@@ -3,15 +3,18 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
 	"github.com/gosimple/slug"
+	"github.com/lib/pq"
 )
 
 func getTagBySlug(ctx context.Context, q *query.GetTagBySlug) error {
@@ -27,7 +30,7 @@ func getAssignedTags(ctx context.Context, q *query.GetAssignedTags) error {
 		q.Result = make([]*entity.Tag, 0)
 
 		tags, err := queryTags(trx, `
-			SELECT t.id, t.name, t.slug, t.color, t.is_public 
+			SELECT t.id, t.name, t.slug, t.color, t.is_public, t.group_id
 			FROM tags t
 			INNER JOIN post_tags pt
 			ON pt.tag_id = t.id
@@ -49,13 +52,9 @@ func getAllTags(ctx context.Context, q *query.GetAllTags) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.Tag, 0)
 
-		condition := `AND t.is_public = true`
-		if user != nil && user.IsCollaborator() {
-			condition = ``
-		}
-
+		condition := tagVisibilityCondition(user, "t")
 		query := fmt.Sprintf(`
-			SELECT t.id, t.name, t.slug, t.color, t.is_public 
+			SELECT t.id, t.name, t.slug, t.color, t.is_public, t.group_id
 			FROM tags t
 			WHERE t.tenant_id = $1 %s
 			ORDER BY t.name
@@ -76,9 +75,9 @@ func addNewTag(ctx context.Context, c *cmd.AddNewTag) error {
 		newSlug := slug.Make(c.Name)
 
 		_, err := trx.Execute(`
-			INSERT INTO tags (name, slug, color, is_public, created_at, tenant_id) 
-			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
-		`, c.Name, newSlug, c.Color, c.IsPublic, time.Now(), tenant.ID)
+			INSERT INTO tags (name, slug, color, is_public, group_id, created_at, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+		`, c.Name, newSlug, c.Color, c.IsPublic, nullableID(c.GroupID), time.Now(), tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to add new tag")
 		}
@@ -94,8 +93,8 @@ func updateTag(ctx context.Context, c *cmd.UpdateTag) error {
 		c.Result = nil
 		newSlug := slug.Make(c.Name)
 
-		_, err := trx.Execute(`UPDATE tags SET name = $1, slug = $2, color = $3, is_public = $4
-													 WHERE id = $5 AND tenant_id = $6`, c.Name, newSlug, c.Color, c.IsPublic, c.TagID, tenant.ID)
+		_, err := trx.Execute(`UPDATE tags SET name = $1, slug = $2, color = $3, is_public = $4, group_id = $5
+													 WHERE id = $6 AND tenant_id = $7`, c.Name, newSlug, c.Color, c.IsPublic, nullableID(c.GroupID), c.TagID, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to update tag")
 		}
@@ -132,6 +131,19 @@ func assignTag(ctx context.Context, c *cmd.AssignTag) error {
 			return nil
 		}
 
+		if c.Tag.GroupID > 0 {
+			// On single selection groups, the new tag replaces any other tag of the same group
+			_, err = trx.Execute(`
+				DELETE FROM post_tags
+				WHERE post_id = $1 AND tenant_id = $2 AND tag_id <> $3
+				AND tag_id IN (SELECT id FROM tags WHERE group_id = $4 AND tenant_id = $2)
+				AND EXISTS (SELECT 1 FROM tag_groups WHERE id = $4 AND tenant_id = $2 AND selection = $5)
+			`, c.Post.ID, tenant.ID, c.Tag.ID, c.Tag.GroupID, enum.TagGroupSelectionSingle)
+			if err != nil {
+				return errors.Wrap(err, "failed to unassign other tags of the group")
+			}
+		}
+
 		_, err = trx.Execute(
 			`INSERT INTO post_tags (tag_id, post_id, created_at, created_by_id, tenant_id) VALUES ($1, $2, $3, $4, $5)`,
 			c.Tag.ID, c.Post.ID, time.Now(), user.ID, tenant.ID,
@@ -161,7 +173,7 @@ func unassignTag(ctx context.Context, c *cmd.UnassignTag) error {
 func queryTagBySlug(trx *dbx.Trx, tenant *entity.Tenant, slug string) (*entity.Tag, error) {
 	tag := dbEntities.Tag{}
 
-	err := trx.Get(&tag, "SELECT id, name, slug, color, is_public FROM tags WHERE tenant_id = $1 AND slug = $2", tenant.ID, slug)
+	err := trx.Get(&tag, "SELECT id, name, slug, color, is_public, group_id FROM tags WHERE tenant_id = $1 AND slug = $2", tenant.ID, slug)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tag with slug '%s'", slug)
 	}
@@ -182,3 +194,40 @@ func queryTags(trx *dbx.Trx, query string, args ...any) ([]*entity.Tag, error) {
 	}
 	return result, nil
 }
+
+// tagVisibilityCondition returns the SQL condition that hides private tags and tags of
+// groups that given user (which can be nil) is not allowed to see
+func tagVisibilityCondition(user *entity.User, alias string) string {
+	if user != nil && user.HasPermission(enum.PermissionManageTags) {
+		return ""
+	}
+
+	condition := ""
+	if user == nil || !user.HasPermission(enum.PermissionAssignTags) {
+		condition = fmt.Sprintf("AND %s.is_public = true ", alias)
+	}
+
+	return condition + fmt.Sprintf(`AND (%s.group_id IS NULL OR %s.group_id IN (
+		SELECT id FROM tag_groups WHERE tenant_id = %s.tenant_id AND %s
+	))`, alias, alias, alias, tagGroupVisibilityCondition(user))
+}
+
+func tagGroupVisibilityCondition(user *entity.User) string {
+	if user == nil {
+		return "visible_to = '{}'"
+	}
+
+	keys := user.RoleKeys()
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = pq.QuoteLiteral(key)
+	}
+	return fmt.Sprintf("(visible_to = '{}' OR visible_to && ARRAY[%s]::text[])", strings.Join(quoted, ", "))
+}
+
+func nullableID(id int) any {
+	if id > 0 {
+		return id
+	}
+	return nil
+}
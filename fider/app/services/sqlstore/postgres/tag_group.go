@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/gosimple/slug"
+	"github.com/lib/pq"
+)
+
+func getTagGroupBySlug(ctx context.Context, q *query.GetTagGroupBySlug) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		group := dbEntities.TagGroup{}
+		err := trx.Get(&group, `
+			SELECT id, name, slug, selection, visible_to, assignable_by
+			FROM tag_groups
+			WHERE slug = $1 AND tenant_id = $2
+		`, q.Slug, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag group with slug '%s'", q.Slug)
+		}
+		q.Result = group.ToModel()
+		return nil
+	})
+}
+
+func getTagGroupByID(ctx context.Context, q *query.GetTagGroupByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		group := dbEntities.TagGroup{}
+		err := trx.Get(&group, `
+			SELECT id, name, slug, selection, visible_to, assignable_by
+			FROM tag_groups
+			WHERE id = $1 AND tenant_id = $2
+		`, q.GroupID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag group with id '%d'", q.GroupID)
+		}
+		q.Result = group.ToModel()
+		return nil
+	})
+}
+
+func getAllTagGroups(ctx context.Context, q *query.GetAllTagGroups) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		condition := "AND " + tagGroupVisibilityCondition(user)
+		if user != nil && user.HasPermission(enum.PermissionManageTags) {
+			condition = ""
+		}
+
+		groups := []*dbEntities.TagGroup{}
+		err := trx.Select(&groups, fmt.Sprintf(`
+			SELECT id, name, slug, selection, visible_to, assignable_by
+			FROM tag_groups
+			WHERE tenant_id = $1 %s
+			ORDER BY name
+		`, condition), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all tag groups")
+		}
+
+		q.Result = make([]*entity.TagGroup, len(groups))
+		for i, group := range groups {
+			q.Result[i] = group.ToModel()
+		}
+		return nil
+	})
+}
+
+func addNewTagGroup(ctx context.Context, c *cmd.AddNewTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		group := &entity.TagGroup{
+			Name:         c.Name,
+			Slug:         slug.Make(c.Name),
+			Selection:    c.Selection,
+			VisibleTo:    nonNilStrings(c.VisibleTo),
+			AssignableBy: nonNilStrings(c.AssignableBy),
+		}
+
+		err := trx.Get(&group.ID, `
+			INSERT INTO tag_groups (tenant_id, name, slug, selection, visible_to, assignable_by, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, tenant.ID, group.Name, group.Slug, group.Selection, pq.Array(group.VisibleTo), pq.Array(group.AssignableBy), time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add new tag group")
+		}
+
+		c.Result = group
+		return nil
+	})
+}
+
+func updateTagGroup(ctx context.Context, c *cmd.UpdateTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		group := &entity.TagGroup{
+			ID:           c.GroupID,
+			Name:         c.Name,
+			Slug:         slug.Make(c.Name),
+			Selection:    c.Selection,
+			VisibleTo:    nonNilStrings(c.VisibleTo),
+			AssignableBy: nonNilStrings(c.AssignableBy),
+		}
+
+		_, err := trx.Execute(`
+			UPDATE tag_groups SET name = $1, slug = $2, selection = $3, visible_to = $4, assignable_by = $5
+			WHERE id = $6 AND tenant_id = $7
+		`, group.Name, group.Slug, group.Selection, pq.Array(group.VisibleTo), pq.Array(group.AssignableBy), group.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update tag group")
+		}
+
+		c.Result = group
+		return nil
+	})
+}
+
+func deleteTagGroup(ctx context.Context, c *cmd.DeleteTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE tags SET group_id = NULL WHERE group_id = $1 AND tenant_id = $2`, c.Group.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove tags from group with id '%d'", c.Group.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM tag_groups WHERE id = $1 AND tenant_id = $2`, c.Group.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete tag group with id '%d'", c.Group.ID)
+		}
+		return nil
+	})
+}
+
+func queryVisibleTagSlugsByGroups(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, groups []string) ([]string, error) {
+	tags := []*dbEntities.Tag{}
+	err := trx.Select(&tags, fmt.Sprintf(`
+		SELECT t.slug
+		FROM tags t
+		INNER JOIN tag_groups g
+		ON g.id = t.group_id
+		AND g.tenant_id = t.tenant_id
+		WHERE t.tenant_id = $1 AND g.slug = ANY($2) %s
+	`, tagVisibilityCondition(user, "t")), tenant.ID, pq.Array(groups))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tags of groups")
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+	return slugs, nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
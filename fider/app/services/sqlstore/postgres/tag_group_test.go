@@ -0,0 +1,160 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestTagGroupStorage_AddUpdateAndGet(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewGroup := &cmd.AddNewTagGroup{Name: "Product Area", Selection: enum.TagGroupSelectionMultiple}
+	err := bus.Dispatch(jonSnowCtx, addNewGroup)
+	Expect(err).IsNil()
+	Expect(addNewGroup.Result.Slug).Equals("product-area")
+	Expect(addNewGroup.Result.VisibleTo).HasLen(0)
+
+	updateGroup := &cmd.UpdateTagGroup{
+		GroupID:      addNewGroup.Result.ID,
+		Name:         "Customer Tier",
+		Selection:    enum.TagGroupSelectionSingle,
+		VisibleTo:    []string{"collaborator"},
+		AssignableBy: []string{"administrator"},
+	}
+	err = bus.Dispatch(jonSnowCtx, updateGroup)
+	Expect(err).IsNil()
+
+	getGroup := &query.GetTagGroupBySlug{Slug: "customer-tier"}
+	err = bus.Dispatch(jonSnowCtx, getGroup)
+	Expect(err).IsNil()
+	Expect(getGroup.Result.ID).Equals(addNewGroup.Result.ID)
+	Expect(getGroup.Result.Name).Equals("Customer Tier")
+	Expect(getGroup.Result.Selection).Equals(enum.TagGroupSelectionSingle)
+	Expect(getGroup.Result.VisibleTo).Equals([]string{"collaborator"})
+	Expect(getGroup.Result.AssignableBy).Equals([]string{"administrator"})
+
+	getByID := &query.GetTagGroupByID{GroupID: addNewGroup.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getByID)
+	Expect(err).IsNil()
+	Expect(getByID.Result.Slug).Equals("customer-tier")
+}
+
+func TestTagGroupStorage_DeleteKeepsTags(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewGroup := &cmd.AddNewTagGroup{Name: "Quarter", Selection: enum.TagGroupSelectionSingle}
+	err := bus.Dispatch(jonSnowCtx, addNewGroup)
+	Expect(err).IsNil()
+
+	addNewTag := &cmd.AddNewTag{Name: "Q1", Color: "FF0000", IsPublic: true, GroupID: addNewGroup.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addNewTag)
+	Expect(err).IsNil()
+	Expect(addNewTag.Result.GroupID).Equals(addNewGroup.Result.ID)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteTagGroup{Group: addNewGroup.Result})
+	Expect(err).IsNil()
+
+	getGroup := &query.GetTagGroupBySlug{Slug: "quarter"}
+	err = bus.Dispatch(jonSnowCtx, getGroup)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	getTag := &query.GetTagBySlug{Slug: "q1"}
+	err = bus.Dispatch(jonSnowCtx, getTag)
+	Expect(err).IsNil()
+	Expect(getTag.Result.GroupID).Equals(0)
+}
+
+func TestTagGroupStorage_Visibility(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewGroup := &cmd.AddNewTagGroup{Name: "Customer Tier", Selection: enum.TagGroupSelectionSingle, VisibleTo: []string{"collaborator"}}
+	err := bus.Dispatch(jonSnowCtx, addNewGroup)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddNewTag{Name: "Enterprise", Color: "FF0000", IsPublic: true, GroupID: addNewGroup.Result.ID})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddNewTag{Name: "Bug", Color: "000000", IsPublic: true})
+	Expect(err).IsNil()
+
+	getAllTags := &query.GetAllTags{}
+	err = bus.Dispatch(jonSnowCtx, getAllTags)
+	Expect(err).IsNil()
+	Expect(getAllTags.Result).HasLen(2)
+
+	getAllTags = &query.GetAllTags{}
+	err = bus.Dispatch(aryaStarkCtx, getAllTags)
+	Expect(err).IsNil()
+	Expect(getAllTags.Result).HasLen(1)
+	Expect(getAllTags.Result[0].Name).Equals("Bug")
+
+	getAllTags = &query.GetAllTags{}
+	err = bus.Dispatch(demoTenantCtx, getAllTags)
+	Expect(err).IsNil()
+	Expect(getAllTags.Result).HasLen(1)
+
+	getAllGroups := &query.GetAllTagGroups{}
+	err = bus.Dispatch(jonSnowCtx, getAllGroups)
+	Expect(err).IsNil()
+	Expect(getAllGroups.Result).HasLen(1)
+
+	getAllGroups = &query.GetAllTagGroups{}
+	err = bus.Dispatch(aryaStarkCtx, getAllGroups)
+	Expect(err).IsNil()
+	Expect(getAllGroups.Result).HasLen(0)
+}
+
+func TestTagGroupStorage_AssignOnSingleSelection(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	addNewGroup := &cmd.AddNewTagGroup{Name: "Quarter", Selection: enum.TagGroupSelectionSingle}
+	err = bus.Dispatch(jonSnowCtx, addNewGroup)
+	Expect(err).IsNil()
+
+	q1 := &cmd.AddNewTag{Name: "Q1", Color: "FF0000", IsPublic: true, GroupID: addNewGroup.Result.ID}
+	q2 := &cmd.AddNewTag{Name: "Q2", Color: "00FF00", IsPublic: true, GroupID: addNewGroup.Result.ID}
+	bug := &cmd.AddNewTag{Name: "Bug", Color: "000000", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, q1, q2, bug)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: bug.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: q1.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: q2.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	assignedTags := &query.GetAssignedTags{Post: newPost.Result}
+	err = bus.Dispatch(jonSnowCtx, assignedTags)
+	Expect(err).IsNil()
+	Expect(assignedTags.Result).HasLen(2)
+	Expect(assignedTags.Result[0].Slug).Equals("bug")
+	Expect(assignedTags.Result[1].Slug).Equals("q2")
+
+	searchPosts := &query.SearchPosts{View: "all", TagGroups: []string{"quarter"}}
+	err = bus.Dispatch(aryaStarkCtx, searchPosts)
+	Expect(err).IsNil()
+	Expect(searchPosts.Result).HasLen(1)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UnassignTag{Tag: q2.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	searchPosts = &query.SearchPosts{View: "all", TagGroups: []string{"quarter"}}
+	err = bus.Dispatch(aryaStarkCtx, searchPosts)
+	Expect(err).IsNil()
+	Expect(searchPosts.Result).HasLen(0)
+}
@@ -74,6 +74,17 @@ func updateTenantEmailAuthAllowedSettings(ctx context.Context, c *cmd.UpdateTena
 	})
 }
 
+func updateTenantTwoFactorSettings(ctx context.Context, c *cmd.UpdateTenantTwoFactorSettings) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("UPDATE tenants SET is_two_factor_required = $1 WHERE id = $2", c.IsTwoFactorRequired, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed update tenant two-factor settings")
+		}
+		tenant.IsTwoFactorRequired = c.IsTwoFactorRequired
+		return nil
+	})
+}
+
 func updateTenantSettings(ctx context.Context, c *cmd.UpdateTenantSettings) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		if c.Logo.Remove {
@@ -103,14 +114,17 @@ func updateTenantAdvancedSettings(ctx context.Context, c *cmd.UpdateTenantAdvanc
 			AllowedSchemes = ""
 		}
 
-		query := "UPDATE tenants SET custom_css = $1, allowed_schemes = $2 WHERE id = $3"
-		_, err := trx.Execute(query, c.CustomCSS, AllowedSchemes, tenant.ID)
+		query := "UPDATE tenants SET custom_css = $1, allowed_schemes = $2, allowed_file_types = $3, file_quota_mb = $4, reaction_emojis = $5 WHERE id = $6"
+		_, err := trx.Execute(query, c.CustomCSS, AllowedSchemes, c.AllowedFileTypes, c.FileQuotaMB, c.ReactionEmojis, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed update tenant advanced settings")
 		}
 
 		tenant.CustomCSS = c.CustomCSS
 		tenant.AllowedSchemes = AllowedSchemes
+		tenant.AllowedFileTypes = c.AllowedFileTypes
+		tenant.FileQuotaMB = c.FileQuotaMB
+		tenant.ReactionEmojis = c.ReactionEmojis
 		return nil
 	})
 }
@@ -208,7 +222,7 @@ func getFirstTenant(ctx context.Context, q *query.GetFirstTenant) error {
 		tenant := dbEntities.Tenant{}
 
 	err := trx.Get(&tenant, `
-		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro,
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.allowed_file_types, t.reaction_emojis, t.file_quota_mb, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.is_two_factor_required, t.prevent_indexing, t.is_pro,
 			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
 		FROM tenants t
 		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
@@ -223,12 +237,56 @@ func getFirstTenant(ctx context.Context, q *query.GetFirstTenant) error {
 	})
 }
 
+func getActiveTenants(ctx context.Context, q *query.GetActiveTenants) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		tenants, err := selectTenants(trx, "t.status = $1", enum.TenantActive)
+		if err != nil {
+			return errors.Wrap(err, "failed to get active tenants")
+		}
+		q.Result = tenants
+		return nil
+	})
+}
+
+func getAllTenants(ctx context.Context, q *query.GetAllTenants) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		tenants, err := selectTenants(trx, "1 = 1")
+		if err != nil {
+			return errors.Wrap(err, "failed to get all tenants")
+		}
+		q.Result = tenants
+		return nil
+	})
+}
+
+func selectTenants(trx *dbx.Trx, where string, args ...any) ([]*entity.Tenant, error) {
+	var tenants []*dbEntities.Tenant
+
+	err := trx.Select(&tenants, `
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.allowed_file_types, t.reaction_emojis, t.file_quota_mb, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.is_two_factor_required, t.prevent_indexing, t.is_pro,
+			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
+		FROM tenants t
+		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
+		WHERE `+where+`
+		ORDER BY t.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Tenant, len(tenants))
+	for i, tenant := range tenants {
+		result[i] = tenant.ToModel()
+	}
+	return result, nil
+}
+
 func getTenantByDomain(ctx context.Context, q *query.GetTenantByDomain) error {
 	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
 		tenant := dbEntities.Tenant{}
 
 	err := trx.Get(&tenant, `
-		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro,
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.allowed_file_types, t.reaction_emojis, t.file_quota_mb, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.is_two_factor_required, t.prevent_indexing, t.is_pro,
 			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
 		FROM tenants t
 		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
@@ -244,6 +302,26 @@ func getTenantByDomain(ctx context.Context, q *query.GetTenantByDomain) error {
 	})
 }
 
+func getTenantByID(ctx context.Context, q *query.GetTenantByID) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		tenant := dbEntities.Tenant{}
+
+		err := trx.Get(&tenant, `
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.allowed_file_types, t.reaction_emojis, t.file_quota_mb, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.is_two_factor_required, t.prevent_indexing, t.is_pro,
+			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
+		FROM tenants t
+		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
+		WHERE t.id = $1
+	`, q.TenantID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tenant with id '%d'", q.TenantID)
+		}
+
+		q.Result = tenant.ToModel()
+		return nil
+	})
+}
+
 func getPendingSignUpVerification(ctx context.Context, q *query.GetPendingSignUpVerification) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		verification := dbEntities.EmailVerification{}
@@ -51,6 +51,28 @@ func TestTenantStorage_Add_Activate(t *testing.T) {
 	Expect(getByDomain.Result.IsPrivate).IsFalse()
 }
 
+func TestTenantStorage_GetActiveAndAllTenants(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(ctx, &cmd.CreateTenant{
+		Name:      "My Domain Inc.",
+		Subdomain: "mydomain",
+		Status:    enum.TenantPending,
+	})
+	Expect(err).IsNil()
+
+	activeTenants := &query.GetActiveTenants{}
+	allTenants := &query.GetAllTenants{}
+	err = bus.Dispatch(ctx, activeTenants, allTenants)
+	Expect(err).IsNil()
+	Expect(activeTenants.Result).HasLen(4)
+	Expect(allTenants.Result).HasLen(5)
+	Expect(allTenants.Result[0].Subdomain).Equals("demo")
+	Expect(allTenants.Result[4].Subdomain).Equals("mydomain")
+	Expect(allTenants.Result[4].Status).Equals(enum.TenantPending)
+}
+
 func TestTenantStorage_SingleTenant_Add(t *testing.T) {
 	ctx := SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -78,6 +100,21 @@ func TestTenantStorage_First(t *testing.T) {
 	Expect(getFirst.Result.Name).Equals("Demonstration")
 }
 
+func TestTenantStorage_ByID(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	getTenant := &query.GetTenantByID{TenantID: 2}
+	err := bus.Dispatch(ctx, getTenant)
+	Expect(err).IsNil()
+	Expect(getTenant.Result.ID).Equals(2)
+	Expect(getTenant.Result.Subdomain).Equals("avengers")
+
+	getTenant = &query.GetTenantByID{TenantID: 999}
+	err = bus.Dispatch(ctx, getTenant)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
 func TestTenantStorage_Empty_First(t *testing.T) {
 	ctx := SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -222,8 +259,10 @@ func TestTenantStorage_AdvancedSettings(t *testing.T) {
 	defer TeardownDatabaseTest()
 
 	err := bus.Dispatch(demoTenantCtx, &cmd.UpdateTenantAdvancedSettings{
-		CustomCSS:      ".primary { color: red; }",
-		AllowedSchemes: "^monero:[48]\n^bitcoin:(1|3|bc1)",
+		CustomCSS:        ".primary { color: red; }",
+		AllowedSchemes:   "^monero:[48]\n^bitcoin:(1|3|bc1)",
+		AllowedFileTypes: ".log, text/csv",
+		FileQuotaMB:      50,
 	})
 	Expect(err).IsNil()
 
@@ -232,6 +271,8 @@ func TestTenantStorage_AdvancedSettings(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(getByDomain.Result.CustomCSS).Equals(".primary { color: red; }")
 	Expect(getByDomain.Result.AllowedSchemes).Equals("^monero:[48]\n^bitcoin:(1|3|bc1)")
+	Expect(getByDomain.Result.AllowedFileTypes).Equals(".log, text/csv")
+	Expect(getByDomain.Result.FileQuotaMB).Equals(50)
 }
 
 func TestTenantStorage_SaveFindSet_VerificationKey(t *testing.T) {
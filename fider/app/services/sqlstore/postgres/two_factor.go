@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func getTwoFactorSecret(ctx context.Context, q *query.GetTwoFactorSecret) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var secret dbx.NullString
+		err := trx.Scalar(&secret, "SELECT two_factor_secret FROM users WHERE id = $1 AND tenant_id = $2", q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get two-factor secret of user '%d'", q.UserID)
+		}
+		q.Result = secret.String
+		return nil
+	})
+}
+
+func saveTwoFactorSecret(ctx context.Context, c *cmd.SaveTwoFactorSecret) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE users SET two_factor_secret = $3, two_factor_last_step = NULL
+			WHERE id = $1 AND tenant_id = $2 AND two_factor_enabled_at IS NULL
+		`, c.UserID, tenant.ID, c.Secret)
+		if err != nil {
+			return errors.Wrap(err, "failed to save two-factor secret")
+		}
+		return nil
+	})
+}
+
+func enableTwoFactor(ctx context.Context, c *cmd.EnableTwoFactor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE users SET two_factor_enabled_at = $3 WHERE id = $1 AND tenant_id = $2",
+			c.UserID, tenant.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to enable two-factor authentication")
+		}
+		return replaceRecoveryCodes(ctx, &cmd.ReplaceRecoveryCodes{
+			UserID:             c.UserID,
+			RecoveryCodeHashes: c.RecoveryCodeHashes,
+		})
+	})
+}
+
+func disableTwoFactor(ctx context.Context, c *cmd.DisableTwoFactor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE users SET two_factor_secret = NULL, two_factor_enabled_at = NULL, two_factor_last_step = NULL
+			WHERE id = $1 AND tenant_id = $2
+		`, c.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to disable two-factor authentication")
+		}
+
+		_, err = trx.Execute("DELETE FROM user_recovery_codes WHERE user_id = $1 AND tenant_id = $2", c.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete recovery codes")
+		}
+		return nil
+	})
+}
+
+func replaceRecoveryCodes(ctx context.Context, c *cmd.ReplaceRecoveryCodes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("DELETE FROM user_recovery_codes WHERE user_id = $1 AND tenant_id = $2", c.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete recovery codes")
+		}
+
+		now := time.Now()
+		for _, hash := range c.RecoveryCodeHashes {
+			_, err := trx.Execute(
+				"INSERT INTO user_recovery_codes (tenant_id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)",
+				tenant.ID, c.UserID, hash, now,
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to insert recovery code")
+			}
+		}
+		return nil
+	})
+}
+
+func countUnusedRecoveryCodes(ctx context.Context, q *query.CountUnusedRecoveryCodes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var count int
+		err := trx.Scalar(
+			&count,
+			"SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND tenant_id = $2 AND used_at IS NULL",
+			q.UserID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to count recovery codes")
+		}
+		q.Result = count
+		return nil
+	})
+}
+
+func markTwoFactorStepUsed(ctx context.Context, c *cmd.MarkTwoFactorStepUsed) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE users SET two_factor_last_step = $3
+			WHERE id = $1 AND tenant_id = $2 AND (two_factor_last_step IS NULL OR two_factor_last_step < $3)
+		`, c.UserID, tenant.ID, c.Step)
+		if err != nil {
+			return errors.Wrap(err, "failed to mark two-factor step as used")
+		}
+		c.Result = rows > 0
+		return nil
+	})
+}
+
+func useRecoveryCode(ctx context.Context, c *cmd.UseRecoveryCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE user_recovery_codes SET used_at = $4
+			WHERE user_id = $1 AND tenant_id = $2 AND code_hash = $3 AND used_at IS NULL
+		`, c.UserID, tenant.ID, c.CodeHash, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to use recovery code")
+		}
+		c.Result = rows > 0
+		return nil
+	})
+}
+
+func getTwoFactorLockout(ctx context.Context, q *query.GetTwoFactorLockout) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var lockedUntil dbx.NullTime
+		err := trx.Scalar(&lockedUntil, "SELECT two_factor_locked_until FROM users WHERE id = $1 AND tenant_id = $2", q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get two-factor lockout of user '%d'", q.UserID)
+		}
+		q.Result = time.Time{}
+		if lockedUntil.Valid {
+			q.Result = lockedUntil.Time
+		}
+		return nil
+	})
+}
+
+func recordTwoFactorFailure(ctx context.Context, c *cmd.RecordTwoFactorFailure) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// the counter starts over once the user is locked out, so each lockout allows another round of attempts
+		_, err := trx.Execute(`
+			UPDATE users SET
+				two_factor_failed_attempts = CASE WHEN two_factor_failed_attempts + 1 >= $3 THEN 0 ELSE two_factor_failed_attempts + 1 END,
+				two_factor_locked_until = CASE WHEN two_factor_failed_attempts + 1 >= $3 THEN $4 ELSE two_factor_locked_until END
+			WHERE id = $1 AND tenant_id = $2
+		`, c.UserID, tenant.ID, c.MaxAttempts, time.Now().Add(c.Lockout))
+		if err != nil {
+			return errors.Wrap(err, "failed to record two-factor failure")
+		}
+		return nil
+	})
+}
+
+func resetTwoFactorFailures(ctx context.Context, c *cmd.ResetTwoFactorFailures) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE users SET two_factor_failed_attempts = 0, two_factor_locked_until = NULL
+			WHERE id = $1 AND tenant_id = $2
+		`, c.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to reset two-factor failures")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,166 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestTwoFactorStorage_EnableAndDisable(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.SaveTwoFactorSecret{UserID: jonSnow.ID, Secret: "JBSWY3DPEHPK3PXP"})
+	Expect(err).IsNil()
+
+	getSecret := &query.GetTwoFactorSecret{UserID: jonSnow.ID}
+	err = bus.Dispatch(demoTenantCtx, getSecret)
+	Expect(err).IsNil()
+	Expect(getSecret.Result).Equals("JBSWY3DPEHPK3PXP")
+
+	getUser := &query.GetUserByID{UserID: jonSnow.ID}
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.IsTwoFactorEnabled).IsFalse()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.EnableTwoFactor{UserID: jonSnow.ID, RecoveryCodeHashes: []string{"hash1", "hash2"}})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.IsTwoFactorEnabled).IsTrue()
+
+	// secret can't be replaced while 2FA is enabled
+	err = bus.Dispatch(demoTenantCtx, &cmd.SaveTwoFactorSecret{UserID: jonSnow.ID, Secret: "OTHERSECRET"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(demoTenantCtx, getSecret)
+	Expect(err).IsNil()
+	Expect(getSecret.Result).Equals("JBSWY3DPEHPK3PXP")
+
+	countCodes := &query.CountUnusedRecoveryCodes{UserID: jonSnow.ID}
+	err = bus.Dispatch(demoTenantCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(2)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DisableTwoFactor{UserID: jonSnow.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getUser)
+	Expect(err).IsNil()
+	Expect(getUser.Result.IsTwoFactorEnabled).IsFalse()
+
+	err = bus.Dispatch(demoTenantCtx, getSecret)
+	Expect(err).IsNil()
+	Expect(getSecret.Result).Equals("")
+
+	err = bus.Dispatch(demoTenantCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(0)
+}
+
+func TestTwoFactorStorage_StepsCantBeReplayed(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	markStep := &cmd.MarkTwoFactorStepUsed{UserID: jonSnow.ID, Step: 100}
+	err := bus.Dispatch(demoTenantCtx, markStep)
+	Expect(err).IsNil()
+	Expect(markStep.Result).IsTrue()
+
+	err = bus.Dispatch(demoTenantCtx, markStep)
+	Expect(err).IsNil()
+	Expect(markStep.Result).IsFalse()
+
+	olderStep := &cmd.MarkTwoFactorStepUsed{UserID: jonSnow.ID, Step: 99}
+	err = bus.Dispatch(demoTenantCtx, olderStep)
+	Expect(err).IsNil()
+	Expect(olderStep.Result).IsFalse()
+
+	newerStep := &cmd.MarkTwoFactorStepUsed{UserID: jonSnow.ID, Step: 101}
+	err = bus.Dispatch(demoTenantCtx, newerStep)
+	Expect(err).IsNil()
+	Expect(newerStep.Result).IsTrue()
+}
+
+func TestTwoFactorStorage_RecoveryCodesAreSingleUse(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.ReplaceRecoveryCodes{UserID: jonSnow.ID, RecoveryCodeHashes: []string{"hash1", "hash2"}})
+	Expect(err).IsNil()
+
+	useCode := &cmd.UseRecoveryCode{UserID: jonSnow.ID, CodeHash: "hash1"}
+	err = bus.Dispatch(demoTenantCtx, useCode)
+	Expect(err).IsNil()
+	Expect(useCode.Result).IsTrue()
+
+	err = bus.Dispatch(demoTenantCtx, useCode)
+	Expect(err).IsNil()
+	Expect(useCode.Result).IsFalse()
+
+	otherUser := &cmd.UseRecoveryCode{UserID: aryaStark.ID, CodeHash: "hash2"}
+	err = bus.Dispatch(demoTenantCtx, otherUser)
+	Expect(err).IsNil()
+	Expect(otherUser.Result).IsFalse()
+
+	countCodes := &query.CountUnusedRecoveryCodes{UserID: jonSnow.ID}
+	err = bus.Dispatch(demoTenantCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(1)
+}
+
+func TestTenantStorage_UpdateTwoFactorSettings(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.UpdateTenantTwoFactorSettings{IsTwoFactorRequired: true})
+	Expect(err).IsNil()
+
+	getTenant := &query.GetTenantByDomain{Domain: "demo"}
+	err = bus.Dispatch(demoTenantCtx, getTenant)
+	Expect(err).IsNil()
+	Expect(getTenant.Result.IsTwoFactorRequired).IsTrue()
+}
+
+func TestTwoFactorStorage_Lockout(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	getLockout := &query.GetTwoFactorLockout{UserID: jonSnow.ID}
+	err := bus.Dispatch(demoTenantCtx, getLockout)
+	Expect(err).IsNil()
+	Expect(getLockout.Result.IsZero()).IsTrue()
+
+	for i := 0; i < 2; i++ {
+		err = bus.Dispatch(demoTenantCtx, &cmd.RecordTwoFactorFailure{UserID: jonSnow.ID, MaxAttempts: 3, Lockout: 15 * time.Minute})
+		Expect(err).IsNil()
+	}
+
+	err = bus.Dispatch(demoTenantCtx, getLockout)
+	Expect(err).IsNil()
+	Expect(getLockout.Result.IsZero()).IsTrue()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.RecordTwoFactorFailure{UserID: jonSnow.ID, MaxAttempts: 3, Lockout: 15 * time.Minute})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getLockout)
+	Expect(err).IsNil()
+	Expect(getLockout.Result).TemporarilySimilar(time.Now().Add(15*time.Minute), 5*time.Second)
+
+	// other users are not affected
+	getOtherLockout := &query.GetTwoFactorLockout{UserID: aryaStark.ID}
+	err = bus.Dispatch(demoTenantCtx, getOtherLockout)
+	Expect(err).IsNil()
+	Expect(getOtherLockout.Result.IsZero()).IsTrue()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.ResetTwoFactorFailures{UserID: jonSnow.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getLockout)
+	Expect(err).IsNil()
+	Expect(getLockout.Result.IsZero()).IsTrue()
+}
@@ -69,7 +69,7 @@ func untrustUser(ctx context.Context, c *cmd.UntrustUser) error {
 func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		if _, err := trx.Execute(
-			"UPDATE users SET role = $3, status = $4, name = '', email = '', api_key = null, api_key_date = null WHERE id = $1 AND tenant_id = $2",
+			"UPDATE users SET role = $3, status = $4, name = '', email = '', api_key = null, api_key_date = null, two_factor_secret = null, two_factor_enabled_at = null, two_factor_last_step = null WHERE id = $1 AND tenant_id = $2",
 			user.ID, tenant.ID, enum.RoleVisitor, enum.UserDeleted,
 		); err != nil {
 			return errors.Wrap(err, "failed to delete current user")
@@ -86,6 +86,10 @@ func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 			{"post_votes", "user_id"},
 			{"post_subscribers", "user_id"},
 			{"email_verifications", "user_id"},
+			{"user_recovery_codes", "user_id"},
+			{"spam_checks", "user_id"},
+			{"content_reports", "user_id"},
+			{"reactions", "user_id"},
 		}
 
 		for _, table := range tables {
@@ -166,7 +170,8 @@ func userSubscribedTo(ctx context.Context, q *query.UserSubscribedTo) error {
 
 func changeUserRole(ctx context.Context, c *cmd.ChangeUserRole) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		cmd := "UPDATE users SET role = $3 WHERE id = $1 AND tenant_id = $2"
+		// a custom role would keep granting its permissions on top of the new role, so it's removed along
+		cmd := "UPDATE users SET role = $3, custom_role_id = NULL WHERE id = $1 AND tenant_id = $2"
 		_, err := trx.Execute(cmd, c.UserID, tenant.ID, c.Role)
 		if err != nil {
 			return errors.Wrap(err, "failed to change user's role")
@@ -345,22 +350,64 @@ func getAllUsers(ctx context.Context, q *query.GetAllUsers) error {
 	})
 }
 
-func getAllUsersNames(ctx context.Context, q *query.GetAllUsersNames) error {
+func searchTaggableUsers(ctx context.Context, q *query.SearchTaggableUsers) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if q.Limit <= 0 {
+			q.Limit = 10
+		}
+		if q.Page <= 0 {
+			q.Page = 1
+		}
+
+		// Wildcards typed by the user are matched literally
+		prefix := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(q.Query) + "%"
+
 		var users []*dbEntities.User
 		err := trx.Select(&users, `
-			SELECT name
+			SELECT id, name
 			FROM users
 			WHERE tenant_id = $1
 			AND status = $2
-			ORDER BY id`, tenant.ID, enum.UserActive)
+			AND name ILIKE $3
+			ORDER BY name, id
+			LIMIT $4 OFFSET $5`, tenant.ID, enum.UserActive, prefix, q.Limit, (q.Page-1)*q.Limit)
 		if err != nil {
-			return errors.Wrap(err, "failed to get all users")
+			return errors.Wrap(err, "failed to search taggable users")
 		}
 
 		q.Result = make([]*dto.UserNames, len(users))
 		for i, user := range users {
 			q.Result[i] = &dto.UserNames{
+				ID:   int(user.ID.Int64),
+				Name: user.Name.String,
+			}
+		}
+		return nil
+	})
+}
+
+func getUserNamesByIDs(ctx context.Context, q *query.GetUserNamesByIDs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = []*dto.UserNames{}
+		if len(q.UserIDs) == 0 {
+			return nil
+		}
+
+		var users []*dbEntities.User
+		err := trx.Select(&users, `
+			SELECT id, name
+			FROM users
+			WHERE tenant_id = $1
+			AND status != $2
+			AND id = ANY($3)`, tenant.ID, enum.UserDeleted, pq.Array(q.UserIDs))
+		if err != nil {
+			return errors.Wrap(err, "failed to get user names by ids")
+		}
+
+		q.Result = make([]*dto.UserNames, len(users))
+		for i, user := range users {
+			q.Result[i] = &dto.UserNames{
+				ID:   int(user.ID.Int64),
 				Name: user.Name.String,
 			}
 		}
@@ -370,7 +417,7 @@ func getAllUsersNames(ctx context.Context, q *query.GetAllUsersNames) error {
 
 func queryUser(ctx context.Context, trx *dbx.Trx, filter string, args ...any) (*entity.User, error) {
 	user := dbEntities.User{}
-	sql := fmt.Sprintf("SELECT id, name, email, tenant_id, role, status, avatar_type, avatar_bkey, is_trusted FROM users WHERE status != %d AND ", enum.UserDeleted)
+	sql := fmt.Sprintf("SELECT id, name, email, tenant_id, role, status, avatar_type, avatar_bkey, is_trusted, custom_role_id, two_factor_enabled_at FROM users WHERE status != %d AND ", enum.UserDeleted)
 	err := trx.Get(&user, sql+filter, args...)
 	if err != nil {
 		return nil, err
@@ -381,7 +428,17 @@ func queryUser(ctx context.Context, trx *dbx.Trx, filter string, args ...any) (*
 		return nil, err
 	}
 
-	return user.ToModel(ctx), nil
+	result := user.ToModel(ctx)
+	if user.CustomRoleID.Valid {
+		role := dbEntities.Role{}
+		err = trx.Get(&role, "SELECT id, name, description, permissions FROM roles WHERE id = $1", user.CustomRoleID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		result.CustomRole = role.ToModel()
+	}
+
+	return result, nil
 }
 
 func searchUsers(ctx context.Context, q *query.SearchUsers) error {
@@ -397,7 +454,7 @@ func searchUsers(ctx context.Context, q *query.SearchUsers) error {
 		}
 
 		baseQuery := `
-			SELECT id, name, email, tenant_id, role, status, avatar_type, avatar_bkey, is_trusted
+			SELECT id, name, email, tenant_id, role, status, avatar_type, avatar_bkey, is_trusted, custom_role_id
 			FROM users
 			WHERE tenant_id = $1 AND status != $2
 		`
@@ -479,9 +536,22 @@ func searchUsers(ctx context.Context, q *query.SearchUsers) error {
 			return errors.Wrap(err, "failed to search users")
 		}
 
+		roles, err := queryRoles(trx, tenant)
+		if err != nil {
+			return errors.Wrap(err, "failed to get roles")
+		}
+
+		rolesByID := make(map[int]*entity.Role, len(roles))
+		for _, role := range roles {
+			rolesByID[role.ID] = role
+		}
+
 		q.Result = make([]*entity.User, len(users))
 		for i, user := range users {
 			q.Result[i] = user.ToModel(ctx)
+			if user.CustomRoleID.Valid {
+				q.Result[i].CustomRole = rolesByID[int(user.CustomRoleID.Int64)]
+			}
 		}
 		return nil
 	})
@@ -420,3 +420,56 @@ func TestUserStorage_BlockUser(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(getUser.Result.Status).Equals(enum.UserActive)
 }
+
+func TestUserStorage_SearchTaggableUsers(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	searchUsers := &query.SearchTaggableUsers{Query: "a"}
+	err := bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(1)
+	Expect(searchUsers.Result[0].ID).Equals(2)
+	Expect(searchUsers.Result[0].Name).Equals("Arya Stark")
+
+	searchUsers = &query.SearchTaggableUsers{Limit: 2}
+	err = bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(2)
+	Expect(searchUsers.Result[0].Name).Equals("Arya Stark")
+	Expect(searchUsers.Result[1].Name).Equals("Jon Snow")
+
+	searchUsers = &query.SearchTaggableUsers{Limit: 2, Page: 2}
+	err = bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(1)
+	Expect(searchUsers.Result[0].Name).Equals("Sansa Stark")
+
+	searchUsers = &query.SearchTaggableUsers{Query: "%"}
+	err = bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(0)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.BlockUser{UserID: 2})
+	Expect(err).IsNil()
+
+	searchUsers = &query.SearchTaggableUsers{Query: "a"}
+	err = bus.Dispatch(demoTenantCtx, searchUsers)
+	Expect(err).IsNil()
+	Expect(searchUsers.Result).HasLen(0)
+}
+
+func TestUserStorage_GetUserNamesByIDs(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	getNames := &query.GetUserNamesByIDs{UserIDs: []int{1, 3, 4}}
+	err := bus.Dispatch(demoTenantCtx, getNames)
+	Expect(err).IsNil()
+	Expect(getNames.Result).HasLen(2)
+
+	getNames = &query.GetUserNamesByIDs{}
+	err = bus.Dispatch(demoTenantCtx, getNames)
+	Expect(err).IsNil()
+	Expect(getNames.Result).HasLen(0)
+}
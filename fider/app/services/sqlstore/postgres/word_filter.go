@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbWordFilter struct {
+	ID        int       `db:"id"`
+	Pattern   string    `db:"pattern"`
+	IsRegex   bool      `db:"is_regex"`
+	Action    int       `db:"action"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (f *dbWordFilter) toModel() *entity.WordFilter {
+	return &entity.WordFilter{
+		ID:        f.ID,
+		Pattern:   f.Pattern,
+		IsRegex:   f.IsRegex,
+		Action:    enum.WordFilterAction(f.Action),
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func getWordFilters(ctx context.Context, q *query.GetWordFilters) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		filters := []*dbWordFilter{}
+		err := trx.Select(&filters, `
+			SELECT id, pattern, is_regex, action, created_at
+			FROM word_filters
+			WHERE tenant_id = $1
+			ORDER BY id
+		`, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get word filters")
+		}
+
+		q.Result = make([]*entity.WordFilter, len(filters))
+		for i, filter := range filters {
+			q.Result[i] = filter.toModel()
+		}
+		return nil
+	})
+}
+
+func getWordFilterByID(ctx context.Context, q *query.GetWordFilterByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		filter := dbWordFilter{}
+		err := trx.Get(&filter, `
+			SELECT id, pattern, is_regex, action, created_at
+			FROM word_filters
+			WHERE id = $1 AND tenant_id = $2
+		`, q.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get word filter with id '%d'", q.ID)
+		}
+
+		q.Result = filter.toModel()
+		return nil
+	})
+}
+
+func addWordFilter(ctx context.Context, c *cmd.AddWordFilter) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO word_filters (tenant_id, pattern, is_regex, action, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, tenant.ID, c.Pattern, c.IsRegex, c.Action, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to add word filter")
+		}
+
+		c.Result = &entity.WordFilter{
+			ID:        id,
+			Pattern:   c.Pattern,
+			IsRegex:   c.IsRegex,
+			Action:    c.Action,
+			CreatedAt: now,
+		}
+		return nil
+	})
+}
+
+func deleteWordFilter(ctx context.Context, c *cmd.DeleteWordFilter) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("DELETE FROM word_filters WHERE id = $1 AND tenant_id = $2", c.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete word filter with id '%d'", c.ID)
+		}
+		return nil
+	})
+}
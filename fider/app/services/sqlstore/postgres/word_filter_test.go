@@ -0,0 +1,54 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestWordFilterStorage_AddListAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addFilter := &cmd.AddWordFilter{Pattern: "acme corp", Action: enum.WordFilterModerate}
+	err := bus.Dispatch(demoTenantCtx, addFilter)
+	Expect(err).IsNil()
+	Expect(addFilter.Result.ID).NotEquals(0)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.AddWordFilter{Pattern: `\d{16}`, IsRegex: true, Action: enum.WordFilterReject})
+	Expect(err).IsNil()
+
+	getFilters := &query.GetWordFilters{}
+	err = bus.Dispatch(demoTenantCtx, getFilters)
+	Expect(err).IsNil()
+	Expect(getFilters.Result).HasLen(2)
+	Expect(getFilters.Result[0].Pattern).Equals("acme corp")
+	Expect(getFilters.Result[0].IsRegex).IsFalse()
+	Expect(getFilters.Result[0].Action).Equals(enum.WordFilterModerate)
+	Expect(getFilters.Result[1].Pattern).Equals(`\d{16}`)
+	Expect(getFilters.Result[1].IsRegex).IsTrue()
+	Expect(getFilters.Result[1].Action).Equals(enum.WordFilterReject)
+
+	// Filters belong to a single tenant
+	getOtherFilters := &query.GetWordFilters{}
+	err = bus.Dispatch(avengersTenantCtx, getOtherFilters)
+	Expect(err).IsNil()
+	Expect(getOtherFilters.Result).HasLen(0)
+
+	getFilter := &query.GetWordFilterByID{ID: addFilter.Result.ID}
+	err = bus.Dispatch(avengersTenantCtx, getFilter)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeleteWordFilter{ID: addFilter.Result.ID})
+	Expect(err).IsNil()
+
+	getFilter = &query.GetWordFilterByID{ID: addFilter.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, getFilter)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
@@ -97,9 +99,11 @@ func triggerWebhook(ctx context.Context, webhook *entity.Webhook, props webhook.
 	}
 	err = bus.Dispatch(ctx, httpRequest)
 	if err != nil {
+		metrics.WebhookDeliveries.WithLabelValues(webhook.Type.Name(), "error").Inc()
 		return resultWithError(ctx, "Could not execute webhook HTTP request", err.Error(), result)
 	}
 	result.StatusCode = httpRequest.ResponseStatusCode
+	metrics.WebhookDeliveries.WithLabelValues(webhook.Type.Name(), strconv.Itoa(result.StatusCode)).Inc()
 	if result.StatusCode >= http.StatusBadRequest {
 		fullResponse := fmt.Sprintf("%d %s:\n%s", result.StatusCode, http.StatusText(result.StatusCode), httpRequest.ResponseBody)
 		return resultWithError(ctx, "Webhook HTTP request returned an error response code", fullResponse, result)
@@ -0,0 +1,68 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/metrics"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	pkgwebhook "github.com/getfider/fider/app/pkg/webhook"
+	"github.com/getfider/fider/app/services/webhook"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func deliveries(statusCode string) float64 {
+	return testutil.ToFloat64(metrics.WebhookDeliveries.WithLabelValues("new_post", statusCode))
+}
+
+func TestTriggerWebhooks_Metrics(t *testing.T) {
+	RegisterT(t)
+	bus.Init(webhook.Service{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveWebhooksByType) error {
+		q.Result = []*entity.Webhook{
+			{ID: 1, Name: "OK", Type: enum.WebhookNewPost, Url: "http://example.com/ok", Content: "{{ .post_title }}", HttpMethod: "POST"},
+			{ID: 2, Name: "Broken", Type: enum.WebhookNewPost, Url: "http://example.com/broken", Content: "{{ .post_title }}", HttpMethod: "POST"},
+			{ID: 3, Name: "Down", Type: enum.WebhookNewPost, Url: "http://example.com/down", Content: "{{ .post_title }}", HttpMethod: "POST"},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.HTTPRequest) error {
+		switch c.URL {
+		case "http://example.com/ok":
+			c.ResponseStatusCode = http.StatusOK
+		case "http://example.com/broken":
+			c.ResponseStatusCode = http.StatusInternalServerError
+		default:
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	failed := make([]int, 0)
+	bus.AddHandler(func(ctx context.Context, q *query.MarkWebhookAsFailed) error {
+		failed = append(failed, q.ID)
+		return nil
+	})
+
+	ok, broken, down := deliveries("200"), deliveries("500"), deliveries("error")
+
+	err := bus.Dispatch(context.Background(), &cmd.TriggerWebhooks{
+		Type:  enum.WebhookNewPost,
+		Props: pkgwebhook.Props{"post_title": "My new post"},
+	})
+	Expect(err).IsNil()
+	Expect(failed).Equals([]int{2, 3})
+
+	Expect(deliveries("200")).Equals(ok + 1)
+	Expect(deliveries("500")).Equals(broken + 1)
+	Expect(deliveries("error")).Equals(down + 1)
+}
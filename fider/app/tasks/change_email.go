@@ -9,31 +9,43 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
+type changeEmailPayload struct {
+	RequestorName   string
+	Email           string
+	VerificationKey string
+}
+
 //SendChangeEmailConfirmation is used to send the change email confirmation email to requestor
 func SendChangeEmailConfirmation(action *actions.ChangeUserEmail) worker.Task {
-	return describe("Send change email confirmation", func(c *worker.Context) error {
-
-		previous := c.User().Email
-		if previous == "" {
-			previous = "(empty)"
-		}
-
-		to := dto.NewRecipient(action.Requestor.Name, action.Email, dto.Props{
-			"name":     c.User().Name,
-			"oldEmail": previous,
-			"newEmail": action.Email,
-			"link":     link(web.BaseURL(c), "/change-email/verify?k=%s", action.VerificationKey),
-		})
-
-		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: c.Tenant().Name},
-			To:           []dto.Recipient{to},
-			TemplateName: "change_emailaddress_email",
-			Props: dto.Props{
-				"logo": web.LogoURL(c),
-			},
-		})
-
-		return nil
+	return sendChangeEmailConfirmation(changeEmailPayload{
+		RequestorName:   action.Requestor.Name,
+		Email:           action.Email,
+		VerificationKey: action.VerificationKey,
 	})
 }
+
+var sendChangeEmailConfirmation = worker.Define("send_change_email_confirmation", "Send change email confirmation", func(c *worker.Context, payload changeEmailPayload) error {
+
+	previous := c.User().Email
+	if previous == "" {
+		previous = "(empty)"
+	}
+
+	to := dto.NewRecipient(payload.RequestorName, payload.Email, dto.Props{
+		"name":     c.User().Name,
+		"oldEmail": previous,
+		"newEmail": payload.Email,
+		"link":     link(web.BaseURL(c), "/change-email/verify?k=%s", payload.VerificationKey),
+	})
+
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: c.Tenant().Name},
+		To:           []dto.Recipient{to},
+		TemplateName: "change_emailaddress_email",
+		Props: dto.Props{
+			"logo": web.LogoURL(c),
+		},
+	})
+
+	return nil
+})
@@ -15,82 +15,92 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
+type deletedPostPayload struct {
+	Post               *entity.Post
+	PostEmails         postEmails
+	DeleteCommentAdded bool
+}
+
 // NotifyAboutDeletedPost sends a notification (web and email) to subscribers of the post that has been deleted
 func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.Task {
-	return describe("Notify about deleted post", func(c *worker.Context) error {
-
-		tenant := c.Tenant()
-		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
-		author := c.User()
-		title := fmt.Sprintf("**%s** deleted **%s**", author.Name, post.Title)
-
-		// Webhook
-		webhookProps := webhook.Props{}
-		webhookProps.SetPost(post, "post", baseURL, true, true)
-		webhookProps.SetUser(author, "author")
-		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
-
-		err := bus.Dispatch(c, &cmd.TriggerWebhooks{
-			Type:  enum.WebhookDeletePost,
-			Props: webhookProps,
-		})
-		if err != nil {
-			return c.Failure(err)
-		}
+	return notifyAboutDeletedPost(deletedPostPayload{Post: post, PostEmails: emailsOf(post), DeleteCommentAdded: deleteCommentAdded})
+}
 
-		// If no comment was added, we can stop here
-		// (I'm not sure about the rational of this business rule, but this is how it currently works)
-		if !deleteCommentAdded {
-			return nil
-		}
+var notifyAboutDeletedPost = worker.Define("notify_about_deleted_post", "Notify about deleted post", func(c *worker.Context, payload deletedPostPayload) error {
+	post, deleteCommentAdded := payload.Post, payload.DeleteCommentAdded
+	payload.PostEmails.restore(post)
 
-		// Web notification
-		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventChangeStatus)
-		if err != nil {
-			return c.Failure(err)
-		}
+	tenant := c.Tenant()
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+	author := c.User()
+	title := fmt.Sprintf("**%s** deleted **%s**", author.Name, post.Title)
 
-		for _, user := range users {
-			if user.ID != author.ID {
-				err = bus.Dispatch(c, &cmd.AddNewNotification{
-					User:   user,
-					Title:  title,
-					PostID: post.ID,
-				})
-				if err != nil {
-					return c.Failure(err)
-				}
-			}
-		}
+	// Webhook
+	webhookProps := webhook.Props{}
+	webhookProps.SetPost(post, "post", baseURL, true, true)
+	webhookProps.SetUser(author, "author")
+	webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
-		// Email notification
-		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
-		if err != nil {
-			return c.Failure(err)
-		}
+	err := bus.Dispatch(c, &cmd.TriggerWebhooks{
+		Type:  enum.WebhookDeletePost,
+		Props: webhookProps,
+	})
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	// If no comment was added, we can stop here
+	// (I'm not sure about the rational of this business rule, but this is how it currently works)
+	if !deleteCommentAdded {
+		return nil
+	}
+
+	// Web notification
+	users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventChangeStatus)
+	if err != nil {
+		return c.Failure(err)
+	}
 
-		to := make([]dto.Recipient, 0)
-		for _, user := range users {
-			if user.ID != author.ID {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+	for _, user := range users {
+		if user.ID != author.ID {
+			err = bus.Dispatch(c, &cmd.AddNewNotification{
+				User:   user,
+				Title:  title,
+				PostID: post.ID,
+			})
+			if err != nil {
+				return c.Failure(err)
 			}
 		}
+	}
 
-		props := dto.Props{
-			"title":    post.Title,
-			"siteName": tenant.Name,
-			"content":  markdown.Full(post.Response.Text, true),
-			"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
-			"logo":     logoURL,
+	// Email notification
+	users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	to := make([]dto.Recipient, 0)
+	for _, user := range users {
+		if user.ID != author.ID {
+			to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
 		}
+	}
 
-		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: c.User().Name},
-			To:           to,
-			TemplateName: "delete_post",
-			Props:        props,
-		})
+	props := dto.Props{
+		"title":    post.Title,
+		"siteName": tenant.Name,
+		"content":  markdown.Full(post.Response.Text, true),
+		"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+		"logo":     logoURL,
+	}
 
-		return nil
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: c.User().Name},
+		To:           to,
+		TemplateName: "delete_post",
+		Props:        props,
 	})
-}
+
+	return nil
+})
@@ -54,7 +54,7 @@ func TestNotifyAboutDeletePostTask(t *testing.T) {
 		User:        mock.AryaStark,
 		Status:      enum.PostDeleted,
 		Response: &entity.PostResponse{
-			RespondedAt: time.Now(),
+			RespondedAt: time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC),
 			Text:        "Invalid post!",
 			User:        mock.JonSnow,
 		},
@@ -161,7 +161,7 @@ func TestNotifyAboutDeletePostTask_NoComment(t *testing.T) {
 		User:        mock.AryaStark,
 		Status:      enum.PostDeleted,
 		Response: &entity.PostResponse{
-			RespondedAt: time.Now(),
+			RespondedAt: time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC),
 			Text:        "Invalid post!",
 			User:        mock.JonSnow,
 		},
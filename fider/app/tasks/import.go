@@ -0,0 +1,18 @@
+package tasks
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/importer"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// RunImport imports the parsed rows of an import job, progress is saved on the job as it goes.
+// The parsed rows are only kept in memory, so it always runs on the instance that received the file
+func RunImport(job *entity.ImportJob, parsed *importer.Result) worker.Task {
+	return describe("Run import", func(c *worker.Context) error {
+		if err := importer.Run(c, job, parsed); err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
@@ -15,39 +15,48 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
+type invitesPayload struct {
+	Subject     string
+	Message     string
+	Invitations []*actions.UserInvitation
+}
+
 // SendInvites sends one email to each invited recipient
 func SendInvites(subject, message string, invitations []*actions.UserInvitation) worker.Task {
-	return describe("Send invites", func(c *worker.Context) error {
-		to := make([]dto.Recipient, len(invitations))
-		for i, invite := range invitations {
-			err := bus.Dispatch(c, &cmd.SaveVerificationKey{
-				Key:      invite.VerificationKey,
-				Duration: 15 * 24 * time.Hour,
-				Request:  invite,
-			})
-			if err != nil {
-				return c.Failure(err)
-			}
-
-			url := fmt.Sprintf("%s/invite/verify?k=%s", web.BaseURL(c), invite.VerificationKey)
-			toMessage := strings.ReplaceAll(message, app.InvitePlaceholder, url)
-			to[i] = dto.NewRecipient("", invite.Email, dto.Props{
-				"message": markdown.Full(toMessage, true),
-			})
+	return sendInvites(invitesPayload{Subject: subject, Message: message, Invitations: invitations})
+}
+
+var sendInvites = worker.Define("send_invites", "Send invites", func(c *worker.Context, payload invitesPayload) error {
+	subject, message, invitations := payload.Subject, payload.Message, payload.Invitations
+	to := make([]dto.Recipient, len(invitations))
+	for i, invite := range invitations {
+		err := bus.Dispatch(c, &cmd.SaveVerificationKey{
+			Key:      invite.VerificationKey,
+			Duration: 15 * 24 * time.Hour,
+			Request:  invite,
+		})
+		if err != nil {
+			return c.Failure(err)
 		}
 
-		bus.Publish(c, &cmd.SendMail{
-			From: dto.Recipient{
-				Name: c.User().Name,
-			},
-			To:           to,
-			TemplateName: "invite_email",
-			Props: dto.Props{
-				"subject": subject,
-				"logo":    web.LogoURL(c),
-			},
+		url := fmt.Sprintf("%s/invite/verify?k=%s", web.BaseURL(c), invite.VerificationKey)
+		toMessage := strings.ReplaceAll(message, app.InvitePlaceholder, url)
+		to[i] = dto.NewRecipient("", invite.Email, dto.Props{
+			"message": markdown.Full(toMessage, true),
 		})
+	}
 
-		return nil
+	bus.Publish(c, &cmd.SendMail{
+		From: dto.Recipient{
+			Name: c.User().Name,
+		},
+		To:           to,
+		TemplateName: "invite_email",
+		Props: dto.Props{
+			"subject": subject,
+			"logo":    web.LogoURL(c),
+		},
 	})
-}
+
+	return nil
+})
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/i18n"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/markdown"
@@ -18,215 +20,329 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
+type commentPayload struct {
+	Post       *entity.Post
+	PostEmails postEmails
+	Comment    *entity.Comment
+}
+
 // NotifyAboutNewComment sends a notification (web and email) to subscribers
 func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Task {
-	return describe("Notify about new comment", func(c *worker.Context) error {
+	return notifyAboutNewComment(commentPayload{Post: post, PostEmails: emailsOf(post), Comment: comment})
+}
 
-		// comment.ParseMentions()
-		contentString := entity.CommentString(comment.Content)
-		mentions := contentString.ParseMentions()
-		var mentionNotifications []*entity.MentionNotification
+var notifyAboutNewComment = worker.Define("notify_about_new_comment", "Notify about new comment", func(c *worker.Context, payload commentPayload) error {
+	post, comment := payload.Post, payload.Comment
+	payload.PostEmails.restore(post)
 
-		// Web notification
-		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewComment)
-		if err != nil {
-			return c.Failure(err)
-		}
+	content := comment.Content
+	if err := markdown.ResolveMentions(c, &content); err != nil {
+		return c.Failure(err)
+	}
+	contentString := entity.CommentString(content)
+	mentions := contentString.ParseMentions()
+	var mentionNotifications []*entity.MentionNotification
+
+	// Web notification
+	users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewComment)
+	if err != nil {
+		return c.Failure(err)
+	}
 
-		author := c.User()
-		title := fmt.Sprintf("**%s** left a comment on **%s**", author.Name, post.Title)
-		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
-		for _, user := range users {
-			if user.ID != author.ID {
-				err = bus.Dispatch(c, &cmd.AddNewNotification{
-					User:   user,
-					Title:  title,
-					Link:   link,
-					PostID: post.ID,
-				})
-				if err != nil {
-					return c.Failure(err)
-				}
+	author := c.User()
+	title := fmt.Sprintf("**%s** left a comment on **%s**", author.Name, post.Title)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	notified := make(map[int]bool)
+	for _, user := range users {
+		if user.ID != author.ID {
+			notified[user.ID] = true
+			err = bus.Dispatch(c, &cmd.AddNewNotification{
+				User:   user,
+				Title:  title,
+				Link:   link,
+				PostID: post.ID,
+			})
+			if err != nil {
+				return c.Failure(err)
 			}
 		}
+	}
 
-		// Web notification - mentions
-		title = fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
+	// Web notification - mentions
+	title = fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
 
-		if mentions != nil {
+	if mentions != nil {
 
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
-
-			// Get the existing mentions that have been sent for this comment
-			mN := &query.GetMentionNotifications{
-				CommentID: comment.ID,
-			}
-			err := bus.Dispatch(c, mN)
-			if err != nil {
-				return c.Failure(err)
-			}
-			mentionNotifications = mN.Result
-
-			// Iterate the mentions
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-						// Also send the notification log
-						err = bus.Dispatch(c, &cmd.AddMentionNotification{
-							UserID:    u.ID,
-							CommentID: comment.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+		// Get the existing mentions that have been sent for this comment
+		mN := &query.GetMentionNotifications{
+			CommentID: comment.ID,
+		}
+		err := bus.Dispatch(c, mN)
+		if err != nil {
+			return c.Failure(err)
+		}
+		mentionNotifications = mN.Result
+
+		// Iterate the mentions
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					notified[u.ID] = true
+					err = bus.Dispatch(c, &cmd.AddNewNotification{
+						User:   u,
+						Title:  title,
+						Link:   link,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
 
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID:    u.ID,
+						CommentID: comment.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
 					}
 
 				}
-			}
 
+			}
 		}
 
-		// Standard email notitifications
-		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewComment)
+	}
+
+	// Web notification - reply, the author of the parent comment is notified like a mentioned user
+	replyTo, err := getReplyTo(c, comment)
+	if err != nil {
+		return c.Failure(err)
+	}
+	replyToNotified := false
+	if replyTo != nil && !notified[replyTo.ID] {
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
 		if err != nil {
 			return c.Failure(err)
 		}
 
-		to := make([]dto.Recipient, 0)
-		for _, user := range users {
-			if user.ID != author.ID {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+		if slices.ContainsFunc(users, func(u *entity.User) bool { return u.ID == replyTo.ID }) {
+			err = bus.Dispatch(c, &cmd.AddNewNotification{
+				User:   replyTo,
+				Title:  fmt.Sprintf("**%s** replied to your comment on **%s**", author.Name, post.Title),
+				Link:   link,
+				PostID: post.ID,
+			})
+			if err != nil {
+				return c.Failure(err)
 			}
+			replyToNotified = true
 		}
+	}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventNewComment, "new_comment")
+	// Standard email notitifications
+	users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewComment)
+	if err != nil {
+		return c.Failure(err)
+	}
 
-		// Mentions
-		to = make([]dto.Recipient, 0)
-		if mentions != nil {
+	emailed := make(map[int]bool)
+	to := make([]dto.Recipient, 0)
+	for _, user := range users {
+		if user.ID != author.ID {
+			emailed[user.ID] = true
+			to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+		}
+	}
 
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
+	sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventNewComment, "new_comment")
 
-			for _, mention := range mentions {
-				for _, u := range users {
+	// Mentions
+	to = make([]dto.Recipient, 0)
+	if mentions != nil {
 
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-						// Also send the notification log
-						err = bus.Dispatch(c, &cmd.AddMentionNotification{
-							UserID:    u.ID,
-							CommentID: comment.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+		for _, mention := range mentions {
+			for _, u := range users {
+
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					emailed[u.ID] = true
+					to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID:    u.ID,
+						CommentID: comment.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
 					}
 				}
 			}
-
 		}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+	}
 
-		tenant := c.Tenant()
-		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+	sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
-		webhookProps := webhook.Props{"comment": contentString.SanitizeMentions()}
-		webhookProps["comment_id"] = comment.ID
-		webhookProps.SetPost(post, "post", baseURL, true, true)
-		webhookProps.SetUser(author, "author")
-		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
+	// Email - reply
+	if replyTo != nil && !emailed[replyTo.ID] {
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-		err = bus.Dispatch(c, &cmd.TriggerWebhooks{
-			Type:  enum.WebhookNewComment,
-			Props: webhookProps,
+		for _, u := range users {
+			if u.ID == replyTo.ID {
+				to = []dto.Recipient{dto.NewRecipient(u.Name, u.Email, dto.Props{})}
+				sendCommentEmail(c, post, to, contentString.SanitizeMentions(), "email.new_reply.text", "new_comment")
+				replyToNotified = true
+			}
+		}
+	}
+
+	// Replies are logged as mentions, so that the parent author isn't notified again when the reply is edited to mention them
+	if replyToNotified {
+		err = bus.Dispatch(c, &cmd.AddMentionNotification{
+			UserID:    replyTo.ID,
+			CommentID: comment.ID,
 		})
 		if err != nil {
 			return c.Failure(err)
 		}
+	}
 
-		return nil
+	tenant := c.Tenant()
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	webhookProps := webhook.Props{"comment": contentString.SanitizeMentions()}
+	webhookProps["comment_id"] = comment.ID
+	webhookProps.SetPost(post, "post", baseURL, true, true)
+	webhookProps.SetUser(author, "author")
+	webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
+
+	err = bus.Dispatch(c, &cmd.TriggerWebhooks{
+		Type:  enum.WebhookNewComment,
+		Props: webhookProps,
 	})
-}
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	return nil
+})
 
 func NotifyAboutUpdatedComment(post *entity.Post, comment *entity.Comment) worker.Task {
-	return describe("Notify about updated comment", func(c *worker.Context) error {
+	return notifyAboutUpdatedComment(commentPayload{Post: post, PostEmails: emailsOf(post), Comment: comment})
+}
 
-		contentString := entity.CommentString(comment.Content)
-		mentions := contentString.ParseMentions()
-		var mentionNotifications []*entity.MentionNotification
+var notifyAboutUpdatedComment = worker.Define("notify_about_updated_comment", "Notify about updated comment", func(c *worker.Context, payload commentPayload) error {
+	post, comment := payload.Post, payload.Comment
+	payload.PostEmails.restore(post)
 
-		log.Infof(c, "Comment updated: @{Comment:Yellow}. Mentions @{MentionsCount}", dto.Props{
-			"Comment":       contentString,
-			"MentionsCount": len(mentions),
-		})
+	content := comment.Content
+	if err := markdown.ResolveMentions(c, &content); err != nil {
+		return c.Failure(err)
+	}
+	contentString := entity.CommentString(content)
+	mentions := contentString.ParseMentions()
+	var mentionNotifications []*entity.MentionNotification
 
-		author := c.User()
-		title := fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
-		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
-		mentionNotificationSent := false
-		if mentions != nil {
+	log.Infof(c, "Comment updated: @{Comment:Yellow}. Mentions @{MentionsCount}", dto.Props{
+		"Comment":       contentString,
+		"MentionsCount": len(mentions),
+	})
 
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
+	author := c.User()
+	title := fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	mentionNotificationSent := false
+	if mentions != nil {
 
-			// Get the existing mentions that have been sent for this comment
-			mN := &query.GetMentionNotifications{
-				CommentID: comment.ID,
-			}
-			err = bus.Dispatch(c, mN)
-			if err != nil {
-				return c.Failure(err)
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		// Get the existing mentions that have been sent for this comment
+		mN := &query.GetMentionNotifications{
+			CommentID: comment.ID,
+		}
+		err = bus.Dispatch(c, mN)
+		if err != nil {
+			return c.Failure(err)
+		}
+		mentionNotifications = mN.Result
+
+		// Iterate the mentions
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					err = bus.Dispatch(c, &cmd.AddNewNotification{
+						User:   u,
+						Title:  title,
+						Link:   link,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
+
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID:    u.ID,
+						CommentID: comment.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
+					mentionNotificationSent = true
+				}
 			}
-			mentionNotifications = mN.Result
-
-			// Iterate the mentions
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+		}
+
+	}
+
+	to := make([]dto.Recipient, 0)
+	if mentions != nil {
+
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-						// Also send the notification log
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+
+					// Also send the notification log
+					if !mentionNotificationSent {
 						err = bus.Dispatch(c, &cmd.AddMentionNotification{
 							UserID:    u.ID,
 							CommentID: comment.ID,
@@ -234,52 +350,46 @@ func NotifyAboutUpdatedComment(post *entity.Post, comment *entity.Comment) worke
 						if err != nil {
 							return c.Failure(err)
 						}
-						mentionNotificationSent = true
 					}
 				}
 			}
-
 		}
+	}
 
-		to := make([]dto.Recipient, 0)
-		if mentions != nil {
+	sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
+	return nil
+})
 
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
-
-						// Also send the notification log
-						if !mentionNotificationSent {
-							err = bus.Dispatch(c, &cmd.AddMentionNotification{
-								UserID:    u.ID,
-								CommentID: comment.ID,
-							})
-							if err != nil {
-								return c.Failure(err)
-							}
-						}
-					}
-				}
-			}
-		}
+// getReplyTo returns the author of the comment that given comment replies to, unless it's current user or the parent is gone
+func getReplyTo(c *worker.Context, comment *entity.Comment) (*entity.User, error) {
+	if comment.ParentID == 0 {
+		return nil, nil
+	}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+	getParent := &query.GetCommentByID{CommentID: comment.ParentID}
+	if err := bus.Dispatch(c, getParent); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-		return nil
-	})
+	if getParent.Result.User.ID == c.User().ID {
+		return nil, nil
+	}
+	return getParent.Result.User, nil
 }
 
 func sendEmailNotifications(c *worker.Context, post *entity.Post, to []dto.Recipient, comment string, event enum.NotificationEvent, templateName string) {
+	messaleLocaleString := "email.new_comment.text"
+	if event.UserSettingsKeyName == enum.NotificationEventMention.UserSettingsKeyName {
+		messaleLocaleString = "email.new_mention.text"
+	}
+	sendCommentEmail(c, post, to, comment, messaleLocaleString, templateName)
+}
+
+func sendCommentEmail(c *worker.Context, post *entity.Post, to []dto.Recipient, comment string, messaleLocaleString string, templateName string) {
 	// Short circuit if there is no one to notify
 	if len(to) == 0 {
 		return
@@ -288,10 +398,6 @@ func sendEmailNotifications(c *worker.Context, post *entity.Post, to []dto.Recip
 	author := c.User()
 	tenant := c.Tenant()
 	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
-	messaleLocaleString := "email.new_comment.text"
-	if event.UserSettingsKeyName == enum.NotificationEventMention.UserSettingsKeyName {
-		messaleLocaleString = "email.new_mention.text"
-	}
 
 	mailProps := dto.Props{
 		"title":               post.Title,
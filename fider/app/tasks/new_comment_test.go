@@ -257,6 +257,77 @@ func TestNotifyAboutNewCommentTask_WithMention(t *testing.T) {
 
 }
 
+func TestNotifyAboutNewCommentTask_Reply(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	addNewNotifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotifications = append(addNewNotifications, c)
+		return nil
+	})
+
+	addNotificationLogs := make([]*cmd.AddMentionNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddMentionNotification) error {
+		addNotificationLogs = append(addNotificationLogs, c)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = &entity.Comment{ID: q.CommentID, User: mock.JonSnow}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		if q.Event.UserSettingsKeyName == "event_notification_mention" {
+			q.Result = []*entity.User{mock.JonSnow}
+		} else {
+			q.Result = []*entity.User{}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+	task := tasks.NotifyAboutNewComment(&entity.Comment{ID: 2, ParentID: 1, Content: "I agree"}, post)
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(addNewNotifications).HasLen(1)
+	Expect(addNewNotifications[0].Title).Equals("**Arya Stark** replied to your comment on **Add support for TypeScript**")
+	Expect(addNewNotifications[0].User).Equals(mock.JonSnow)
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].Props["messageLocaleString"]).Equals("email.new_reply.text")
+	Expect(emailmock.MessageHistory[0].To).Equals([]dto.Recipient{
+		{Name: "Jon Snow", Address: "jon.snow@got.com", Props: dto.Props{}},
+	})
+
+	Expect(addNotificationLogs).HasLen(1)
+	Expect(addNotificationLogs[0].UserID).Equals(mock.JonSnow.ID)
+	Expect(addNotificationLogs[0].CommentID).Equals(2)
+}
+
 func TestNotifyAboutUpdatedComment(t *testing.T) {
 	RegisterT(t)
 	bus.Init(emailmock.Service{})
@@ -20,219 +20,263 @@ import (
 
 // NotifyAboutNewPost sends a notification (web and email) to subscribers
 func NotifyAboutNewPost(post *entity.Post) worker.Task {
-	return describe("Notify about new post", func(c *worker.Context) error {
-		// Parse mentions from post description
-		contentString := entity.CommentString(post.Description)
-		mentions := contentString.ParseMentions()
-		var mentionNotifications []*entity.MentionNotification
-
-		// Web notification
-		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewPost)
-		if err != nil {
-			return c.Failure(err)
-		}
+	return notifyAboutNewPost(post)
+}
 
-		author := c.User()
-		title := fmt.Sprintf("New post: **%s**", post.Title)
-		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
-		for _, user := range users {
-			if user.ID != author.ID {
-				err = bus.Dispatch(c, &cmd.AddNewNotification{
-					User:   user,
-					Title:  title,
-					Link:   link,
-					PostID: post.ID,
-				})
-				if err != nil {
-					return c.Failure(err)
-				}
+var notifyAboutNewPost = worker.Define("notify_about_new_post", "Notify about new post", func(c *worker.Context, post *entity.Post) error {
+	// Parse mentions from post description
+	description := post.Description
+	if err := markdown.ResolveMentions(c, &description); err != nil {
+		return c.Failure(err)
+	}
+	contentString := entity.CommentString(description)
+	mentions := contentString.ParseMentions()
+	var mentionNotifications []*entity.MentionNotification
+
+	// Web notification
+	users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewPost)
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	author := c.User()
+	title := fmt.Sprintf("New post: **%s**", post.Title)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	for _, user := range users {
+		if user.ID != author.ID {
+			err = bus.Dispatch(c, &cmd.AddNewNotification{
+				User:   user,
+				Title:  title,
+				Link:   link,
+				PostID: post.ID,
+			})
+			if err != nil {
+				return c.Failure(err)
 			}
 		}
+	}
 
-		// Web notification - mentions
-		if len(mentions) > 0 {
-			title = fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
+	// Web notification - mentions
+	if len(mentions) > 0 {
+		title = fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
 
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-			// Get the existing mentions that have been sent for this post
-			mN := &query.GetMentionNotifications{
-				PostID: post.ID,
-			}
-			err := bus.Dispatch(c, mN)
-			if err != nil {
-				return c.Failure(err)
-			}
-			mentionNotifications = mN.Result
-
-			// Iterate the mentions
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+		// Get the existing mentions that have been sent for this post
+		mN := &query.GetMentionNotifications{
+			PostID: post.ID,
+		}
+		err := bus.Dispatch(c, mN)
+		if err != nil {
+			return c.Failure(err)
+		}
+		mentionNotifications = mN.Result
+
+		// Iterate the mentions
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					err = bus.Dispatch(c, &cmd.AddNewNotification{
+						User:   u,
+						Title:  title,
+						Link:   link,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
 
-						// Also send the notification log
-						err = bus.Dispatch(c, &cmd.AddMentionNotification{
-							UserID: u.ID,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID: u.ID,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
 					}
 				}
 			}
 		}
+	}
+
+	// Email notification
+	users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewPost)
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	to := make([]dto.Recipient, 0)
+	for _, user := range users {
+		if user.ID != author.ID {
+			to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+		}
+	}
+
+	tenant := c.Tenant()
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	mailProps := dto.Props{
+		"title":    post.Title,
+		"siteName": tenant.Name,
+		"userName": author.Name,
+		"content":  markdown.Full(contentString.SanitizeMentions(), false),
+		"postLink": linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+		"view":     linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+		"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+		"logo":     logoURL,
+	}
+
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: author.Name},
+		To:           to,
+		TemplateName: "new_post",
+		Props:        mailProps,
+	})
 
-		// Email notification
-		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewPost)
+	// Email notification - mentions
+	to = make([]dto.Recipient, 0)
+	if len(mentions) > 0 {
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
 		if err != nil {
 			return c.Failure(err)
 		}
 
-		to := make([]dto.Recipient, 0)
-		for _, user := range users {
-			if user.ID != author.ID {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+		for _, mention := range mentions {
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID: u.ID,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
+				}
 			}
 		}
+	}
 
-		tenant := c.Tenant()
-		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
-
-		mailProps := dto.Props{
-			"title":    post.Title,
-			"siteName": tenant.Name,
-			"userName": author.Name,
-			"content":  markdown.Full(contentString.SanitizeMentions(), false),
-			"postLink": linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
-			"view":     linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
-			"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
-			"logo":     logoURL,
-		}
+	// Send mention email notifications
+	sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
-		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: author.Name},
-			To:           to,
-			TemplateName: "new_post",
-			Props:        mailProps,
-		})
-
-		// Email notification - mentions
-		to = make([]dto.Recipient, 0)
-		if len(mentions) > 0 {
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
+	webhookProps := webhook.Props{}
+	webhookProps.SetPost(post, "post", baseURL, false, false)
+	webhookProps.SetUser(author, "author")
+	webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
-			for _, mention := range mentions {
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+	err = bus.Dispatch(c, &cmd.TriggerWebhooks{
+		Type:  enum.WebhookNewPost,
+		Props: webhookProps,
+	})
+	if err != nil {
+		return c.Failure(err)
+	}
 
-						// Also send the notification log
-						err = bus.Dispatch(c, &cmd.AddMentionNotification{
-							UserID: u.ID,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
-					}
-				}
-			}
-		}
+	return nil
+})
 
-		// Send mention email notifications
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+// NotifyAboutUpdatedPost sends notifications about mentions in an updated post
+func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
+	return notifyAboutUpdatedPost(post)
+}
 
-		webhookProps := webhook.Props{}
-		webhookProps.SetPost(post, "post", baseURL, false, false)
-		webhookProps.SetUser(author, "author")
-		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
+var notifyAboutUpdatedPost = worker.Define("notify_about_updated_post", "Notify about updated post", func(c *worker.Context, post *entity.Post) error {
+	description := post.Description
+	if err := markdown.ResolveMentions(c, &description); err != nil {
+		return c.Failure(err)
+	}
+	contentString := entity.CommentString(description)
+	mentions := contentString.ParseMentions()
+	var mentionNotifications []*entity.MentionNotification
+
+	log.Infof(c, "Post updated: @{Post:Yellow}. Mentions @{MentionsCount}", dto.Props{
+		"Post":          post.Title,
+		"MentionsCount": len(mentions),
+	})
 
-		err = bus.Dispatch(c, &cmd.TriggerWebhooks{
-			Type:  enum.WebhookNewPost,
-			Props: webhookProps,
-		})
+	author := c.User()
+	title := fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	mentionNotificationSent := false
+	if len(mentions) > 0 {
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
 		if err != nil {
 			return c.Failure(err)
 		}
 
-		return nil
-	})
-}
+		// Get the existing mentions that have been sent for this post
+		mN := &query.GetMentionNotifications{
+			PostID: post.ID,
+		}
+		err = bus.Dispatch(c, mN)
+		if err != nil {
+			return c.Failure(err)
+		}
+		mentionNotifications = mN.Result
+
+		// Iterate the mentions
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					err = bus.Dispatch(c, &cmd.AddNewNotification{
+						User:   u,
+						Title:  title,
+						Link:   link,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
 
-// NotifyAboutUpdatedPost sends notifications about mentions in an updated post
-func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
-	return describe("Notify about updated post", func(c *worker.Context) error {
-		contentString := entity.CommentString(post.Description)
-		mentions := contentString.ParseMentions()
-		var mentionNotifications []*entity.MentionNotification
-
-		log.Infof(c, "Post updated: @{Post:Yellow}. Mentions @{MentionsCount}", dto.Props{
-			"Post":          post.Title,
-			"MentionsCount": len(mentions),
-		})
-
-		author := c.User()
-		title := fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
-		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
-		mentionNotificationSent := false
-		if len(mentions) > 0 {
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
+					// Also send the notification log
+					err = bus.Dispatch(c, &cmd.AddMentionNotification{
+						UserID: u.ID,
+						PostID: post.ID,
+					})
+					if err != nil {
+						return c.Failure(err)
+					}
+					mentionNotificationSent = true
+				}
 			}
+		}
+	}
 
-			// Get the existing mentions that have been sent for this post
-			mN := &query.GetMentionNotifications{
-				PostID: post.ID,
-			}
-			err = bus.Dispatch(c, mN)
-			if err != nil {
-				return c.Failure(err)
-			}
-			mentionNotifications = mN.Result
-
-			// Iterate the mentions
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
-						})
-						if err != nil {
-							return c.Failure(err)
-						}
+	to := make([]dto.Recipient, 0)
+	if len(mentions) > 0 {
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+		if err != nil {
+			return c.Failure(err)
+		}
 
-						// Also send the notification log
+		for _, mention := range mentions {
+			// Check if the user is in the list of mention subscribers (users)
+			for _, u := range users {
+				if mention.Matches(u) && !slices.ContainsFunc(mentionNotifications,
+					func(n *entity.MentionNotification) bool {
+						return n.UserID == u.ID
+					}) {
+					to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+
+					// Also send the notification log
+					if !mentionNotificationSent {
 						err = bus.Dispatch(c, &cmd.AddMentionNotification{
 							UserID: u.ID,
 							PostID: post.ID,
@@ -240,46 +284,14 @@ func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
 						if err != nil {
 							return c.Failure(err)
 						}
-						mentionNotificationSent = true
 					}
 				}
 			}
 		}
+	}
 
-		to := make([]dto.Recipient, 0)
-		if len(mentions) > 0 {
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
-			if err != nil {
-				return c.Failure(err)
-			}
-
-			for _, mention := range mentions {
-				// Check if the user is in the list of mention subscribers (users)
-				for _, u := range users {
-					if u.Name == mention && !slices.ContainsFunc(mentionNotifications,
-						func(n *entity.MentionNotification) bool {
-							return n.UserID == u.ID
-						}) {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
-
-						// Also send the notification log
-						if !mentionNotificationSent {
-							err = bus.Dispatch(c, &cmd.AddMentionNotification{
-								UserID: u.ID,
-								PostID: post.ID,
-							})
-							if err != nil {
-								return c.Failure(err)
-							}
-						}
-					}
-				}
-			}
-		}
+	// Send email notifications for mentions
+	sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
-		// Send email notifications for mentions
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
-
-		return nil
-	})
-}
+	return nil
+})
@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+type scanFilePayload struct {
+	FileID int
+}
+
+// ScanFile checks an attached file for malware. Files are cleared when no threat is found, otherwise they are quarantined.
+// When the scanner is unavailable the task fails, so it's retried and the file stays pending meanwhile
+func ScanFile(file *entity.File) worker.Task {
+	return scanFile(scanFilePayload{FileID: file.ID})
+}
+
+var scanFile = worker.Define("scan_file", "Scan attached file", func(c *worker.Context, payload scanFilePayload) error {
+	getFile := &query.GetFileByID{FileID: payload.FileID}
+	if err := bus.Dispatch(c, getFile); err != nil {
+		// the file was deleted before it could be scanned
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil
+		}
+		return c.Failure(err)
+	}
+
+	file := getFile.Result
+	getBlob := &query.GetBlobByKey{Key: file.BlobKey}
+	if err := bus.Dispatch(c, getBlob); err != nil {
+		return c.Failure(err)
+	}
+
+	scan := &cmd.ScanFile{FileName: file.Name, Content: getBlob.Result.Content}
+	if err := bus.Dispatch(c, scan); err != nil {
+		return c.Failure(err)
+	}
+
+	status := enum.FileStatusClean
+	if scan.IsInfected {
+		status = enum.FileStatusQuarantined
+		log.Warnf(c, "File @{FileID} '@{FileName}' was quarantined: @{Threat}", dto.Props{
+			"FileID":   file.ID,
+			"FileName": file.Name,
+			"Threat":   scan.Threat,
+		})
+	}
+
+	return bus.Dispatch(c, &cmd.SetFileStatus{
+		FileID:     file.ID,
+		Status:     status,
+		ScanResult: scan.Threat,
+	})
+})
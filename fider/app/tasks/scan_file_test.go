@@ -0,0 +1,97 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func setupScanFile(scanner func(c *cmd.ScanFile) error) **cmd.SetFileStatus {
+	file := &entity.File{ID: 7, Name: "app.log", BlobKey: "files/abc-app.log", Status: enum.FileStatusPending}
+	bus.AddHandler(func(ctx context.Context, q *query.GetFileByID) error {
+		if q.FileID != file.ID {
+			return app.ErrNotFound
+		}
+		q.Result = file
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		Expect(q.Key).Equals(file.BlobKey)
+		q.Result = &dto.Blob{Content: []byte("log line")}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.ScanFile) error {
+		Expect(c.Content).Equals([]byte("log line"))
+		return scanner(c)
+	})
+
+	var setStatus *cmd.SetFileStatus
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetFileStatus) error {
+		setStatus = c
+		return nil
+	})
+	return &setStatus
+}
+
+func TestScanFileTask_Clean(t *testing.T) {
+	RegisterT(t)
+
+	setStatus := setupScanFile(func(c *cmd.ScanFile) error {
+		return nil
+	})
+
+	err := mock.NewWorker().OnTenant(mock.DemoTenant).Execute(tasks.ScanFile(&entity.File{ID: 7}))
+	Expect(err).IsNil()
+	Expect((*setStatus).FileID).Equals(7)
+	Expect((*setStatus).Status).Equals(enum.FileStatusClean)
+}
+
+func TestScanFileTask_Infected(t *testing.T) {
+	RegisterT(t)
+
+	setStatus := setupScanFile(func(c *cmd.ScanFile) error {
+		c.IsInfected = true
+		c.Threat = "Eicar-Test-Signature"
+		return nil
+	})
+
+	err := mock.NewWorker().OnTenant(mock.DemoTenant).Execute(tasks.ScanFile(&entity.File{ID: 7}))
+	Expect(err).IsNil()
+	Expect((*setStatus).Status).Equals(enum.FileStatusQuarantined)
+	Expect((*setStatus).ScanResult).Equals("Eicar-Test-Signature")
+}
+
+func TestScanFileTask_ScannerUnavailable(t *testing.T) {
+	RegisterT(t)
+
+	setStatus := setupScanFile(func(c *cmd.ScanFile) error {
+		return errors.New("connection refused")
+	})
+
+	err := mock.NewWorker().OnTenant(mock.DemoTenant).Execute(tasks.ScanFile(&entity.File{ID: 7}))
+	Expect(err).IsNotNil()
+	Expect(*setStatus).IsNil()
+}
+
+func TestScanFileTask_DeletedFile(t *testing.T) {
+	RegisterT(t)
+
+	setStatus := setupScanFile(func(c *cmd.ScanFile) error {
+		return nil
+	})
+
+	err := mock.NewWorker().OnTenant(mock.DemoTenant).Execute(tasks.ScanFile(&entity.File{ID: 8}))
+	Expect(err).IsNil()
+	Expect(*setStatus).IsNil()
+}
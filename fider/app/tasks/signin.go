@@ -8,24 +8,31 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
+type signInEmailPayload struct {
+	Email            string
+	VerificationCode string
+}
+
 // SendSignInEmail is used to send the sign in email to requestor
 func SendSignInEmail(email, verificationCode string) worker.Task {
-	return describe("Send sign in email", func(c *worker.Context) error {
-		to := dto.NewRecipient("", email, dto.Props{
-			"siteName": c.Tenant().Name,
-			"code":     verificationCode,
-			"link":     link(web.BaseURL(c), "/signin/verify?k=%s", verificationCode),
-		})
+	return sendSignInEmail(signInEmailPayload{Email: email, VerificationCode: verificationCode})
+}
 
-		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: c.Tenant().Name},
-			To:           []dto.Recipient{to},
-			TemplateName: "signin_email",
-			Props: dto.Props{
-				"logo": web.LogoURL(c),
-			},
-		})
+var sendSignInEmail = worker.Define("send_sign_in_email", "Send sign in email", func(c *worker.Context, payload signInEmailPayload) error {
+	to := dto.NewRecipient("", payload.Email, dto.Props{
+		"siteName": c.Tenant().Name,
+		"code":     payload.VerificationCode,
+		"link":     link(web.BaseURL(c), "/signin/verify?k=%s", payload.VerificationCode),
+	})
 
-		return nil
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: c.Tenant().Name},
+		To:           []dto.Recipient{to},
+		TemplateName: "signin_email",
+		Props: dto.Props{
+			"logo": web.LogoURL(c),
+		},
 	})
-}
+
+	return nil
+})
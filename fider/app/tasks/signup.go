@@ -15,22 +15,36 @@ type SignUpEmailData interface {
 	GetVerificationKey() string
 }
 
+type signUpEmailPayload struct {
+	Name            string
+	Email           string
+	VerificationKey string
+	BaseURL         string
+}
+
 // SendSignUpEmail is used to send the sign up email to requestor
 func SendSignUpEmail(data SignUpEmailData, baseURL string) worker.Task {
-	return describe("Send sign up email", func(c *worker.Context) error {
-		to := dto.NewRecipient(data.GetName(), data.GetEmail(), dto.Props{
-			"link": link(baseURL, "/signup/verify?k=%s", data.GetVerificationKey()),
-		})
+	return sendSignUpEmail(signUpEmailPayload{
+		Name:            data.GetName(),
+		Email:           data.GetEmail(),
+		VerificationKey: data.GetVerificationKey(),
+		BaseURL:         baseURL,
+	})
+}
 
-		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: "Fider"},
-			To:           []dto.Recipient{to},
-			TemplateName: "signup_email",
-			Props: dto.Props{
-				"logo": web.LogoURL(c),
-			},
-		})
+var sendSignUpEmail = worker.Define("send_sign_up_email", "Send sign up email", func(c *worker.Context, payload signUpEmailPayload) error {
+	to := dto.NewRecipient(payload.Name, payload.Email, dto.Props{
+		"link": link(payload.BaseURL, "/signup/verify?k=%s", payload.VerificationKey),
+	})
 
-		return nil
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: "Fider"},
+		To:           []dto.Recipient{to},
+		TemplateName: "signup_email",
+		Props: dto.Props{
+			"logo": web.LogoURL(c),
+		},
 	})
-}
+
+	return nil
+})
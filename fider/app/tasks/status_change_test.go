@@ -54,7 +54,7 @@ func TestNotifyAboutStatusChangeTask(t *testing.T) {
 		User:        mock.AryaStark,
 		Status:      enum.PostPlanned,
 		Response: &entity.PostResponse{
-			RespondedAt: time.Now(),
+			RespondedAt: time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC),
 			Text:        "Planned for next release.",
 			User:        mock.JonSnow,
 		},
@@ -166,7 +166,7 @@ func TestNotifyAboutStatusChangeTask_Duplicate(t *testing.T) {
 		User:   mock.AryaStark,
 		Status: enum.PostDuplicate,
 		Response: &entity.PostResponse{
-			RespondedAt: time.Now(),
+			RespondedAt: time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC),
 			User:        mock.JonSnow,
 			Original: &entity.OriginalPost{
 				Number: 1,
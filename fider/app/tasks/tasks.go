@@ -32,3 +32,31 @@ func getActiveSubscribers(ctx context.Context, post *entity.Post, channel enum.N
 	err := bus.Dispatch(ctx, q)
 	return q.Result, err
 }
+
+// postEmails carries the emails of the post author and responder, which are left out
+// of the JSON encoding of users but are described on webhooks
+type postEmails struct {
+	Author    string
+	Responder string
+}
+
+func emailsOf(post *entity.Post) postEmails {
+	emails := postEmails{}
+	if post.User != nil {
+		emails.Author = post.User.Email
+	}
+	if post.Response != nil && post.Response.User != nil {
+		emails.Responder = post.Response.User.Email
+	}
+	return emails
+}
+
+// restore sets the emails back on the users of a post decoded from a task payload
+func (e postEmails) restore(post *entity.Post) {
+	if post.User != nil && e.Author != "" {
+		post.User.Email = e.Author
+	}
+	if post.Response != nil && post.Response.User != nil && e.Responder != "" {
+		post.Response.User.Email = e.Responder
+	}
+}
@@ -12,77 +12,115 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
-func UserListCreateCompany(tenant entity.Tenant, user entity.User) worker.Task {
-	return describe("Create UserList Company", func(c *worker.Context) error {
-		log.Debugf(c, "Sending new tenant @{Tenant} to userlist with user email @{User}", dto.Props{
-			"Tenant": tenant.Name,
-			"User":   user.Email,
-		})
+// userListCompanyPayload has the fields of the tenant and user, as emails of entities are not kept on JSON
+type userListCompanyPayload struct {
+	TenantID              int
+	TenantName            string
+	Subdomain             string
+	HasCommercialFeatures bool
+	UserID                int
+	UserName              string
+	UserEmail             string
+}
 
-		plan := enum.PlanFree
-		if tenant.HasCommercialFeatures {
-			plan = enum.PlanPro
-		}
+type userListUserPayload struct {
+	ID    int
+	Name  string
+	Email string
+}
+
+type userListRolePayload struct {
+	UserID int
+	Role   enum.Role
+}
 
-		if err := bus.Dispatch(c, &cmd.UserListCreateCompany{
-			Name:       tenant.Name,
-			TenantId:   tenant.ID,
-			SignedUpAt: time.Now().Format(time.RFC3339),
-			Plan:       plan,
-			Subdomain:  tenant.Subdomain,
-			UserId:     user.ID,
-			UserEmail:  user.Email,
-			UserName:   user.Name,
-		}); err != nil {
-			return c.Failure(err)
-		}
-		return nil
+func UserListCreateCompany(tenant entity.Tenant, user entity.User) worker.Task {
+	return userListCreateCompany(userListCompanyPayload{
+		TenantID:              tenant.ID,
+		TenantName:            tenant.Name,
+		Subdomain:             tenant.Subdomain,
+		HasCommercialFeatures: tenant.HasCommercialFeatures,
+		UserID:                user.ID,
+		UserName:              user.Name,
+		UserEmail:             user.Email,
 	})
 }
 
-func UserListUpdateCompany(action *dto.UserListUpdateCompany) worker.Task {
-	return describe("Update Company in UserList", func(c *worker.Context) error {
-		log.Debugf(c, "Updating company @{Tenant} in UserList", dto.Props{
-			"Tenant": action.Name,
-		})
-		if err := bus.Dispatch(c, &cmd.UserListUpdateCompany{
-			TenantId: action.TenantID,
-			Name:     action.Name,
-			Plan:     action.Plan,
-		}); err != nil {
-			return c.Failure(err)
-		}
-		return nil
+var userListCreateCompany = worker.Define("userlist_create_company", "Create UserList Company", func(c *worker.Context, payload userListCompanyPayload) error {
+	log.Debugf(c, "Sending new tenant @{Tenant} to userlist with user email @{User}", dto.Props{
+		"Tenant": payload.TenantName,
+		"User":   payload.UserEmail,
 	})
+
+	plan := enum.PlanFree
+	if payload.HasCommercialFeatures {
+		plan = enum.PlanPro
+	}
+
+	if err := bus.Dispatch(c, &cmd.UserListCreateCompany{
+		Name:       payload.TenantName,
+		TenantId:   payload.TenantID,
+		SignedUpAt: time.Now().Format(time.RFC3339),
+		Plan:       plan,
+		Subdomain:  payload.Subdomain,
+		UserId:     payload.UserID,
+		UserEmail:  payload.UserEmail,
+		UserName:   payload.UserName,
+	}); err != nil {
+		return c.Failure(err)
+	}
+	return nil
+})
+
+func UserListUpdateCompany(action *dto.UserListUpdateCompany) worker.Task {
+	return userListUpdateCompany(action)
 }
 
-func UserListUpdateUser(id int, name string, email string) worker.Task {
-	return describe("Update User in UserList", func(c *worker.Context) error {
-		log.Debugf(c, "Updating user @{User} in UserList", dto.Props{
-			"User": id,
-		})
-		if err := bus.Dispatch(c, &cmd.UserListUpdateUser{
-			Id:    id,
-			Email: email,
-			Name:  name,
-		}); err != nil {
-			return c.Failure(err)
-		}
-		return nil
+var userListUpdateCompany = worker.Define("userlist_update_company", "Update Company in UserList", func(c *worker.Context, action *dto.UserListUpdateCompany) error {
+	log.Debugf(c, "Updating company @{Tenant} in UserList", dto.Props{
+		"Tenant": action.Name,
 	})
+	if err := bus.Dispatch(c, &cmd.UserListUpdateCompany{
+		TenantId: action.TenantID,
+		Name:     action.Name,
+		Plan:     action.Plan,
+	}); err != nil {
+		return c.Failure(err)
+	}
+	return nil
+})
+
+func UserListUpdateUser(id int, name string, email string) worker.Task {
+	return userListUpdateUser(userListUserPayload{ID: id, Name: name, Email: email})
 }
 
-func UserListAddOrRemoveUser(userID int, role enum.Role) worker.Task {
-	return describe("Add or Remove User in UserList", func(c *worker.Context) error {
-		log.Debugf(c, "Handling role change for user in UserList", dto.Props{
-			"User": userID,
-		})
-		if err := bus.Dispatch(c, &cmd.UserListHandleRoleChange{
-			Id:   userID,
-			Role: role,
-		}); err != nil {
-			return c.Failure(err)
-		}
-		return nil
+var userListUpdateUser = worker.Define("userlist_update_user", "Update User in UserList", func(c *worker.Context, payload userListUserPayload) error {
+	log.Debugf(c, "Updating user @{User} in UserList", dto.Props{
+		"User": payload.ID,
 	})
+	if err := bus.Dispatch(c, &cmd.UserListUpdateUser{
+		Id:    payload.ID,
+		Email: payload.Email,
+		Name:  payload.Name,
+	}); err != nil {
+		return c.Failure(err)
+	}
+	return nil
+})
+
+func UserListAddOrRemoveUser(userID int, role enum.Role) worker.Task {
+	return userListAddOrRemoveUser(userListRolePayload{UserID: userID, Role: role})
 }
+
+var userListAddOrRemoveUser = worker.Define("userlist_add_or_remove_user", "Add or Remove User in UserList", func(c *worker.Context, payload userListRolePayload) error {
+	log.Debugf(c, "Handling role change for user in UserList", dto.Props{
+		"User": payload.UserID,
+	})
+	if err := bus.Dispatch(c, &cmd.UserListHandleRoleChange{
+		Id:   payload.UserID,
+		Role: payload.Role,
+	}); err != nil {
+		return c.Failure(err)
+	}
+	return nil
+})
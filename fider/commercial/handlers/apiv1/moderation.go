@@ -4,6 +4,8 @@ import (
 	"strconv"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/log"
@@ -18,7 +20,12 @@ func ApprovePost() web.HandlerFunc {
 			return c.BadRequest(web.Map{"error": "Invalid post ID"})
 		}
 
-		if err := bus.Dispatch(c, &cmd.ApprovePost{PostID: postID}); err != nil {
+		getPost := &query.GetPostByID{PostID: postID}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.ApprovePost{PostID: postID}, postAuditLog(enum.AuditPostApproved, getPost.Result)); err != nil {
 			return c.Failure(err)
 		}
 
@@ -34,7 +41,12 @@ func DeclinePost() web.HandlerFunc {
 			return c.BadRequest(web.Map{"error": "Invalid post ID"})
 		}
 
-		if err := bus.Dispatch(c, &cmd.DeclinePost{PostID: postID}); err != nil {
+		getPost := &query.GetPostByID{PostID: postID}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeclinePost{PostID: postID}, postAuditLog(enum.AuditPostDeclined, getPost.Result)); err != nil {
 			return c.Failure(err)
 		}
 
@@ -50,7 +62,12 @@ func ApproveComment() web.HandlerFunc {
 			return c.BadRequest(web.Map{"error": "Invalid comment ID"})
 		}
 
-		if err := bus.Dispatch(c, &cmd.ApproveComment{CommentID: commentID}); err != nil {
+		getComment := &query.GetCommentByID{CommentID: commentID}
+		if err := bus.Dispatch(c, getComment); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.ApproveComment{CommentID: commentID}, commentAuditLog(enum.AuditCommentApproved, getComment.Result)); err != nil {
 			return c.Failure(err)
 		}
 
@@ -66,7 +83,12 @@ func DeclineComment() web.HandlerFunc {
 			return c.BadRequest(web.Map{"error": "Invalid comment ID"})
 		}
 
-		if err := bus.Dispatch(c, &cmd.DeclineComment{CommentID: commentID}); err != nil {
+		getComment := &query.GetCommentByID{CommentID: commentID}
+		if err := bus.Dispatch(c, getComment); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeclineComment{CommentID: commentID}, commentAuditLog(enum.AuditCommentDeclined, getComment.Result)); err != nil {
 			return c.Failure(err)
 		}
 
@@ -90,13 +112,19 @@ func DeclinePostAndBlock() web.HandlerFunc {
 		}
 
 		// Call the existing BlockUser command inside the DeclinePostAndBlock handler
-		err = bus.Dispatch(c, &cmd.BlockUser{UserID: getPost.Result.User.ID})
+		err = bus.Dispatch(c, &cmd.BlockUser{UserID: getPost.Result.User.ID}, userAuditLog(enum.AuditUserBlocked, getPost.Result.User))
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		// Reports are resolved before the post is declined, so they are recorded as the author being blocked
+		err = bus.Dispatch(c, &cmd.ResolveContentReports{PostID: postID, Resolution: enum.ReportAuthorBlocked})
 		if err != nil {
 			return c.Failure(err)
 		}
 
 		// Finally call the existing DeclinePost command
-		err = bus.Dispatch(c, &cmd.DeclinePost{PostID: postID})
+		err = bus.Dispatch(c, &cmd.DeclinePost{PostID: postID}, postAuditLog(enum.AuditPostDeclined, getPost.Result))
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -120,13 +148,19 @@ func DeclineCommentAndBlock() web.HandlerFunc {
 		}
 
 		// Call the existing BlockUser command inside the DeclinePostAndBlock handler
-		err = bus.Dispatch(c, &cmd.BlockUser{UserID: getComment.Result.User.ID})
+		err = bus.Dispatch(c, &cmd.BlockUser{UserID: getComment.Result.User.ID}, userAuditLog(enum.AuditUserBlocked, getComment.Result.User))
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		// Reports are resolved before the comment is declined, so they are recorded as the author being blocked
+		err = bus.Dispatch(c, &cmd.ResolveContentReports{CommentID: commentID, Resolution: enum.ReportAuthorBlocked})
 		if err != nil {
 			return c.Failure(err)
 		}
 
 		// Finally call the existing DeclinePost command
-		err = bus.Dispatch(c, &cmd.DeclineComment{CommentID: commentID})
+		err = bus.Dispatch(c, &cmd.DeclineComment{CommentID: commentID}, commentAuditLog(enum.AuditCommentDeclined, getComment.Result))
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -150,12 +184,12 @@ func ApprovePostAndVerify() web.HandlerFunc {
 		}
 
 		// First approve the post
-		if err := bus.Dispatch(c, &cmd.ApprovePost{PostID: postID}); err != nil {
+		if err := bus.Dispatch(c, &cmd.ApprovePost{PostID: postID}, postAuditLog(enum.AuditPostApproved, getPost.Result)); err != nil {
 			return c.Failure(err)
 		}
 
 		// Then trust the user
-		if err := bus.Dispatch(c, &cmd.TrustUser{UserID: getPost.Result.User.ID}); err != nil {
+		if err := bus.Dispatch(c, &cmd.TrustUser{UserID: getPost.Result.User.ID}, userAuditLog(enum.AuditUserTrusted, getPost.Result.User)); err != nil {
 			return c.Failure(err)
 		}
 
@@ -179,15 +213,46 @@ func ApproveCommentAndVerify() web.HandlerFunc {
 		}
 
 		// First approve the comment
-		if err := bus.Dispatch(c, &cmd.ApproveComment{CommentID: commentID}); err != nil {
+		if err := bus.Dispatch(c, &cmd.ApproveComment{CommentID: commentID}, commentAuditLog(enum.AuditCommentApproved, getComment.Result)); err != nil {
 			return c.Failure(err)
 		}
 
 		// Then trust the user
-		if err := bus.Dispatch(c, &cmd.TrustUser{UserID: getComment.Result.User.ID}); err != nil {
+		if err := bus.Dispatch(c, &cmd.TrustUser{UserID: getComment.Result.User.ID}, userAuditLog(enum.AuditUserTrusted, getComment.Result.User)); err != nil {
 			return c.Failure(err)
 		}
 
 		return c.Ok(web.Map{})
 	}
-}
\ No newline at end of file
+}
+
+func postAuditLog(action enum.AuditAction, post *entity.Post) *cmd.AddAuditLog {
+	return &cmd.AddAuditLog{
+		Action:     action,
+		TargetType: "post",
+		TargetID:   post.ID,
+		TargetName: post.Title,
+	}
+}
+
+func commentAuditLog(action enum.AuditAction, comment *entity.Comment) *cmd.AddAuditLog {
+	content := []rune(comment.Content)
+	if len(content) > 100 {
+		content = append(content[:100], '…')
+	}
+	return &cmd.AddAuditLog{
+		Action:     action,
+		TargetType: "comment",
+		TargetID:   comment.ID,
+		TargetName: string(content),
+	}
+}
+
+func userAuditLog(action enum.AuditAction, user *entity.User) *cmd.AddAuditLog {
+	return &cmd.AddAuditLog{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   user.ID,
+		TargetName: user.Name,
+	}
+}
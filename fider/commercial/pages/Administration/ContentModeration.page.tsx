@@ -5,7 +5,7 @@ import { Button, Avatar, Loader, Icon, Markdown } from "@fider/components/common
 import { Header } from "@fider/components"
 import { HStack, VStack } from "@fider/components/layout"
 import { actions, chopString, http, notify } from "@fider/services"
-import { User, UserStatus } from "@fider/models"
+import { ContentReport, Permission, ReportReason, User, UserStatus } from "@fider/models"
 import { useFider } from "@fider/hooks"
 import { Trans } from "@lingui/react/macro"
 import IconCheck from "@fider/assets/images/heroicons-check.svg"
@@ -13,6 +13,7 @@ import IconX from "@fider/assets/images/heroicons-x.svg"
 import IconShieldCheck from "@fider/assets/images/heroicons-shieldcheck.svg"
 import IconBan from "@fider/assets/images/heroicons-x-circle.svg"
 import { Moment } from "@fider/components/common"
+import { WordFilters } from "@fider/pages/Administration/components/WordFilters"
 
 interface ModerationItem {
   type: "post" | "comment"
@@ -25,6 +26,22 @@ interface ModerationItem {
   user: User
   createdAt: string
   postTitle?: string
+  spamScore?: SpamScore
+  isApproved: boolean
+  reports?: ContentReport[]
+}
+
+const reportReasons: { [key: string]: string } = {
+  [ReportReason.Spam]: "Spam",
+  [ReportReason.Harassment]: "Harassment",
+  [ReportReason.Offensive]: "Offensive content",
+  [ReportReason.OffTopic]: "Off-topic",
+  [ReportReason.Other]: "Other",
+}
+
+interface SpamScore {
+  score: number
+  reasons: { scorer: string; points: number; description: string }[]
 }
 
 interface ContentModerationPageState {
@@ -200,13 +217,41 @@ const ContentModerationPage = () => {
               <Markdown text={chopString(item.content, 200)} style="plainText" />
             </div>
             {item.type === "comment" && <div className="text-muted text-break">{title}</div>}
+            {item.spamScore && (
+              <div className="c-moderation-item__spam">
+                <div className="text-semibold">
+                  <Trans id="moderation.spamscore">Spam score: {item.spamScore.score}</Trans>
+                </div>
+                <ul>
+                  {item.spamScore.reasons.map((r) => (
+                    <li key={r.scorer + r.description}>
+                      {r.points > 0 && `+${r.points} `}
+                      {r.description}
+                    </li>
+                  ))}
+                </ul>
+              </div>
+            )}
+            {item.reports && item.reports.length > 0 && (
+              <div className="c-moderation-item__reports">
+                <div className="text-semibold">
+                  <Trans id="moderation.reports">Reported {item.reports.length} time(s)</Trans>
+                </div>
+                <ul>
+                  {item.reports.map((r) => (
+                    <li key={r.id}>
+                      {reportReasons[r.reason]} by {r.reporter.name}
+                      {r.details && `: ${r.details}`}
+                    </li>
+                  ))}
+                </ul>
+              </div>
+            )}
 
             <div className="c-moderation-item__actions invisible" onClick={(e) => e.stopPropagation()}>
               <Button size="small" variant="secondary" onClick={() => (item.type === "post" ? handleApprovePost(item.id) : handleApproveComment(item.id))}>
                 <Icon sprite={IconCheck} />
-                <span>
-                  <Trans id="action.publish">Publish</Trans>
-                </span>
+                <span>{item.isApproved ? <Trans id="action.dismiss">Dismiss</Trans> : <Trans id="action.publish">Publish</Trans>}</span>
               </Button>
               <Button size="small" variant="secondary" onClick={() => (item.type === "post" ? handleDeclinePost(item.id) : handleDeclineComment(item.id))}>
                 <Icon sprite={IconX} />
@@ -286,6 +331,8 @@ const ContentModerationPage = () => {
             </>
           )}
         </div>
+
+        {fider.session.hasPermission(Permission.ModerateContent) && <WordFilters />}
       </div>
     </>
   )
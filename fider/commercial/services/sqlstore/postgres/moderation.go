@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -24,7 +25,7 @@ func ApprovePost(ctx context.Context, c *cmd.ApprovePost) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to approve post")
 		}
-		return nil
+		return bus.Dispatch(ctx, &cmd.ResolveContentReports{PostID: c.PostID, Resolution: enum.ReportDismissed})
 	})
 }
 
@@ -43,7 +44,7 @@ func DeclinePost(ctx context.Context, c *cmd.DeclinePost) error {
 			Status: enum.PostDeleted,
 		}
 
-		return bus.Dispatch(ctx, setResponse)
+		return bus.Dispatch(ctx, setResponse, &cmd.ResolveContentReports{PostID: c.PostID, Resolution: enum.ReportRemoved})
 	})
 }
 
@@ -55,7 +56,7 @@ func ApproveComment(ctx context.Context, c *cmd.ApproveComment) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to approve comment")
 		}
-		return nil
+		return bus.Dispatch(ctx, &cmd.ResolveContentReports{CommentID: c.CommentID, Resolution: enum.ReportDismissed})
 	})
 }
 
@@ -63,7 +64,7 @@ func DeclineComment(ctx context.Context, c *cmd.DeclineComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		// Use existing delete command
 		deleteComment := &cmd.DeleteComment{CommentID: c.CommentID}
-		return bus.Dispatch(ctx, deleteComment)
+		return bus.Dispatch(ctx, deleteComment, &cmd.ResolveContentReports{CommentID: c.CommentID, Resolution: enum.ReportRemoved})
 	})
 }
 
@@ -101,6 +102,17 @@ func BulkApproveItems(ctx context.Context, c *cmd.BulkApproveItems) error {
 			}
 		}
 
+		for _, postID := range c.PostIDs {
+			if err := bus.Dispatch(ctx, &cmd.ResolveContentReports{PostID: postID, Resolution: enum.ReportDismissed}); err != nil {
+				return errors.Wrap(err, "failed to dismiss post reports")
+			}
+		}
+		for _, commentID := range c.CommentIDs {
+			if err := bus.Dispatch(ctx, &cmd.ResolveContentReports{CommentID: commentID, Resolution: enum.ReportDismissed}); err != nil {
+				return errors.Wrap(err, "failed to dismiss comment reports")
+			}
+		}
+
 		return nil
 	})
 }
@@ -123,7 +135,8 @@ func BulkDeclineItems(ctx context.Context, c *cmd.BulkDeclineItems) error {
 					Status: enum.PostDeleted,
 				}
 
-				if err := bus.Dispatch(ctx, setResponse); err != nil {
+				resolveReports := &cmd.ResolveContentReports{PostID: postID, Resolution: enum.ReportRemoved}
+				if err := bus.Dispatch(ctx, setResponse, resolveReports); err != nil {
 					return errors.Wrap(err, "failed to bulk decline post")
 				}
 			}
@@ -133,7 +146,8 @@ func BulkDeclineItems(ctx context.Context, c *cmd.BulkDeclineItems) error {
 			// Use existing delete command for each comment
 			for _, commentID := range c.CommentIDs {
 				deleteComment := &cmd.DeleteComment{CommentID: commentID}
-				if err := bus.Dispatch(ctx, deleteComment); err != nil {
+				resolveReports := &cmd.ResolveContentReports{CommentID: commentID, Resolution: enum.ReportRemoved}
+				if err := bus.Dispatch(ctx, deleteComment, resolveReports); err != nil {
 					return errors.Wrap(err, "failed to bulk decline comment")
 				}
 			}
@@ -150,29 +164,105 @@ type dbModerationPost struct {
 	Slug        string           `db:"slug"`
 	Description string           `db:"description"`
 	CreatedAt   time.Time        `db:"created_at"`
+	IsApproved  bool             `db:"is_approved"`
 	User        *dbEntities.User `db:"user"`
+	SpamScore   dbx.NullInt      `db:"spam_score"`
+	SpamReasons dbx.NullString   `db:"spam_reasons"`
 }
 
 type dbModerationComment struct {
-	ID         int              `db:"id"`
-	PostID     int              `db:"post_id"`
-	PostNumber int              `db:"post_number"`
-	PostSlug   string           `db:"post_slug"`
-	Content    string           `db:"content"`
-	CreatedAt  time.Time        `db:"created_at"`
-	User       *dbEntities.User `db:"user"`
-	PostTitle  string           `db:"post_title"`
+	ID          int              `db:"id"`
+	PostID      int              `db:"post_id"`
+	PostNumber  int              `db:"post_number"`
+	PostSlug    string           `db:"post_slug"`
+	Content     string           `db:"content"`
+	CreatedAt   time.Time        `db:"created_at"`
+	IsApproved  bool             `db:"is_approved"`
+	User        *dbEntities.User `db:"user"`
+	PostTitle   string           `db:"post_title"`
+	SpamScore   dbx.NullInt      `db:"spam_score"`
+	SpamReasons dbx.NullString   `db:"spam_reasons"`
+}
+
+type dbContentReport struct {
+	ID        int               `db:"id"`
+	PostID    int               `db:"post_id"`
+	CommentID dbx.NullInt       `db:"comment_id"`
+	Reason    enum.ReportReason `db:"reason"`
+	Details   string            `db:"details"`
+	CreatedAt time.Time         `db:"created_at"`
+	User      *dbEntities.User  `db:"user"`
+}
+
+// contentKey identifies a post, or one of its comments, that can be reported
+type contentKey struct {
+	postID    int
+	commentID int
+}
+
+// getOpenContentReports returns the reports that weren't resolved yet, grouped by the content they were made on
+func getOpenContentReports(ctx context.Context, trx *dbx.Trx, tenant *entity.Tenant) (map[contentKey][]*entity.ContentReport, error) {
+	var reports []*dbContentReport
+	err := trx.Select(&reports, `
+		SELECT r.id, r.post_id, r.comment_id, r.reason, r.details, r.created_at,
+			u.id AS user_id,
+			u.name AS user_name,
+			u.email AS user_email,
+			u.role AS user_role,
+			u.status AS user_status,
+			u.avatar_type AS user_avatar_type,
+			u.avatar_bkey AS user_avatar_bkey
+		FROM content_reports r
+		INNER JOIN users u ON u.id = r.user_id AND u.tenant_id = r.tenant_id
+		WHERE r.tenant_id = $1 AND r.resolved_at IS NULL
+		ORDER BY r.created_at`, tenant.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get open content reports")
+	}
+
+	result := make(map[contentKey][]*entity.ContentReport)
+	for _, r := range reports {
+		key := contentKey{postID: r.PostID, commentID: int(r.CommentID.Int64)}
+		result[key] = append(result[key], &entity.ContentReport{
+			ID:        r.ID,
+			PostID:    r.PostID,
+			CommentID: key.commentID,
+			Reason:    r.Reason,
+			Details:   r.Details,
+			Reporter:  r.User.ToModel(ctx),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// toSpamScore returns the spam score of a moderation item, or nil when it wasn't scored
+func toSpamScore(score dbx.NullInt, reasons dbx.NullString) *entity.SpamScore {
+	if !score.Valid {
+		return nil
+	}
+
+	result := &entity.SpamScore{Score: int(score.Int64), IsFlagged: true}
+	_ = json.Unmarshal([]byte(reasons.String), &result.Reasons)
+	return result
 }
 
 func GetModerationItems(ctx context.Context, q *query.GetModerationItems) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*query.ModerationItem, 0)
 
-		// Get unmoderated posts
+		reports, err := getOpenContentReports(ctx, trx, tenant)
+		if err != nil {
+			return err
+		}
+
+		// Get unmoderated and reported posts
 		var posts []*dbModerationPost
 
-		err := trx.Select(&posts, `
-			SELECT p.id, p.number, p.title, p.slug, p.description, p.created_at,
+		err = trx.Select(&posts, `
+			SELECT p.id, p.number, p.title, p.slug, p.description, p.created_at, p.is_approved,
+				sc.score AS spam_score,
+				sc.reasons AS spam_reasons,
 				u.id AS user_id,
 				u.name AS user_name,
 				u.email AS user_email,
@@ -182,7 +272,16 @@ func GetModerationItems(ctx context.Context, q *query.GetModerationItems) error
 				u.avatar_bkey AS user_avatar_bkey
 			FROM posts p
 			INNER JOIN users u ON u.id = p.user_id AND u.tenant_id = p.tenant_id
-			WHERE p.tenant_id = $1 AND p.is_approved = false and p.status <> $2
+			LEFT JOIN LATERAL (
+				SELECT score, reasons FROM spam_checks
+				WHERE post_id = p.id AND comment_id IS NULL AND tenant_id = p.tenant_id AND is_flagged = true
+				ORDER BY created_at DESC LIMIT 1
+			) sc ON true
+			WHERE p.tenant_id = $1 AND p.status <> $2
+			AND (p.is_approved = false OR EXISTS (
+				SELECT 1 FROM content_reports r
+				WHERE r.tenant_id = p.tenant_id AND r.post_id = p.id AND r.comment_id IS NULL AND r.resolved_at IS NULL
+			))
 			ORDER BY p.created_at DESC`, tenant.ID, enum.PostDeleted)
 		if err != nil {
 			return errors.Wrap(err, "failed to get unmoderated posts")
@@ -202,14 +301,19 @@ func GetModerationItems(ctx context.Context, q *query.GetModerationItems) error
 				Content:    post.Description,
 				CreatedAt:  post.CreatedAt,
 				User:       userWithEmail,
+				SpamScore:  toSpamScore(post.SpamScore, post.SpamReasons),
+				IsApproved: post.IsApproved,
+				Reports:    reports[contentKey{postID: post.ID}],
 			})
 		}
 
-		// Get unmoderated comments
+		// Get unmoderated and reported comments
 		var comments []*dbModerationComment
 
 		err = trx.Select(&comments, `
-			SELECT c.id, c.post_id, p.number as post_number, p.slug as post_slug, c.content, c.created_at,
+			SELECT c.id, c.post_id, p.number as post_number, p.slug as post_slug, c.content, c.created_at, c.is_approved,
+					sc.score AS spam_score,
+					sc.reasons AS spam_reasons,
 					u.id AS user_id,
 					u.name AS user_name,
 					u.email AS user_email,
@@ -221,8 +325,17 @@ func GetModerationItems(ctx context.Context, q *query.GetModerationItems) error
 			FROM comments c
 			INNER JOIN users u ON u.id = c.user_id AND u.tenant_id = c.tenant_id
 			INNER JOIN posts p ON p.id = c.post_id AND p.tenant_id = c.tenant_id
-			WHERE c.tenant_id = $1 AND c.is_approved = false and p.status <> $2
+			LEFT JOIN LATERAL (
+				SELECT score, reasons FROM spam_checks
+				WHERE comment_id = c.id AND tenant_id = c.tenant_id AND is_flagged = true
+				ORDER BY created_at DESC LIMIT 1
+			) sc ON true
+			WHERE c.tenant_id = $1 AND p.status <> $2
 			AND c.deleted_at IS NULL
+			AND (c.is_approved = false OR EXISTS (
+				SELECT 1 FROM content_reports r
+				WHERE r.tenant_id = c.tenant_id AND r.comment_id = c.id AND r.resolved_at IS NULL
+			))
 			ORDER BY c.created_at DESC`, tenant.ID, enum.PostDeleted)
 		if err != nil {
 			return errors.Wrap(err, "failed to get unmoderated comments")
@@ -244,6 +357,9 @@ func GetModerationItems(ctx context.Context, q *query.GetModerationItems) error
 				CreatedAt:  comment.CreatedAt,
 				PostTitle:  comment.PostTitle,
 				User:       userWithEmail,
+				SpamScore:  toSpamScore(comment.SpamScore, comment.SpamReasons),
+				IsApproved: comment.IsApproved,
+				Reports:    reports[contentKey{postID: comment.PostID, commentID: comment.ID}],
 			})
 		}
 
@@ -257,8 +373,12 @@ func GetModerationCount(ctx context.Context, q *query.GetModerationCount) error
 
 		err := trx.Get(&count, `
 			SELECT
-				(SELECT COUNT(*) FROM posts WHERE tenant_id = $1 AND is_approved = false and status <> $2) +
-				(SELECT COUNT(*) FROM comments c JOIN posts p on c.post_id = p.id WHERE p.tenant_id = $1 AND c.is_approved = false AND p.status <> $2 AND c.deleted_at IS NULL)
+				(SELECT COUNT(*) FROM posts p WHERE tenant_id = $1 AND status <> $2 AND (is_approved = false OR EXISTS (
+					SELECT 1 FROM content_reports r WHERE r.tenant_id = p.tenant_id AND r.post_id = p.id AND r.comment_id IS NULL AND r.resolved_at IS NULL
+				))) +
+				(SELECT COUNT(*) FROM comments c JOIN posts p on c.post_id = p.id WHERE p.tenant_id = $1 AND p.status <> $2 AND c.deleted_at IS NULL AND (c.is_approved = false OR EXISTS (
+					SELECT 1 FROM content_reports r WHERE r.tenant_id = c.tenant_id AND r.comment_id = c.id AND r.resolved_at IS NULL
+				)))
 		`, tenant.ID, enum.PostDeleted)
 
 		if err != nil {
@@ -15,6 +15,14 @@ func main() {
 		os.Exit(cmd.RunPing())
 	} else if len(args) > 0 && args[0] == "migrate" {
 		os.Exit(cmd.RunMigrate())
+	} else if len(args) > 0 && args[0] == "restore" {
+		os.Exit(cmd.RunRestore(args[1:]))
+	} else if len(args) > 0 && args[0] == "blobs" {
+		os.Exit(cmd.RunBlobs(args[1:]))
+	} else if len(args) > 0 && args[0] == "copy-blobs" {
+		os.Exit(cmd.RunCopyBlobs(args[1:]))
+	} else if len(args) > 0 && args[0] == "tasks" {
+		os.Exit(cmd.RunTasks(args[1:]))
 	} else {
 		os.Exit(cmd.RunServer())
 	}
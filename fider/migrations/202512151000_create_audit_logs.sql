@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL,
+  actor_id INT NULL,
+  actor_name VARCHAR(100) NOT NULL,
+  action VARCHAR(100) NOT NULL,
+  target_type VARCHAR(50) NOT NULL,
+  target_id INT NULL,
+  target_name TEXT NOT NULL,
+  before_value JSONB NULL,
+  after_value JSONB NULL,
+  client_ip VARCHAR(100) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_tenant_id_created_at_idx ON audit_logs (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS audit_logs_tenant_id_action_idx ON audit_logs (tenant_id, action);
+
+-- Audit entries are append-only, any attempt to change or remove them is rejected
+CREATE OR REPLACE FUNCTION prevent_audit_log_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_logs entries are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_immutable ON audit_logs;
+CREATE TRIGGER audit_logs_immutable
+  BEFORE UPDATE OR DELETE ON audit_logs
+  FOR EACH ROW EXECUTE PROCEDURE prevent_audit_log_changes();
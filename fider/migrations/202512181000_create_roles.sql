@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS roles (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS roles_tenant_id_name_key ON roles (tenant_id, LOWER(name));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_role_id INT NULL REFERENCES roles (id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tag_groups (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL,
+  name VARCHAR(60) NOT NULL,
+  slug VARCHAR(60) NOT NULL,
+  selection VARCHAR(20) NOT NULL DEFAULT 'multiple',
+  visible_to TEXT[] NOT NULL DEFAULT '{}',
+  assignable_by TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tag_groups_tenant_id_slug_key ON tag_groups (tenant_id, slug);
+
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS group_id INT NULL REFERENCES tag_groups (id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+  key VARCHAR(200) PRIMARY KEY,
+  window_start TIMESTAMPTZ NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  hits INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_counters_expires_at_idx ON rate_limit_counters (expires_at);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  file_name VARCHAR(200) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  total_rows INT NOT NULL DEFAULT 0,
+  processed_rows INT NOT NULL DEFAULT 0,
+  failed_rows INT NOT NULL DEFAULT 0,
+  row_errors JSONB NOT NULL DEFAULT '[]',
+  created_by_id INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants (id),
+  FOREIGN KEY (created_by_id, tenant_id) REFERENCES users (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS import_jobs_tenant_id_created_at_idx ON import_jobs (tenant_id, created_at DESC);
+
+-- Maps the ids of the source tool into the rows created by an import, so running it again doesn't duplicate them
+CREATE TABLE IF NOT EXISTS import_sources (
+  tenant_id INT NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  kind VARCHAR(20) NOT NULL,
+  source_id VARCHAR(200) NOT NULL,
+  target_id INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (tenant_id, source, kind, source_id),
+  FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS worker_tasks (
+  id BIGSERIAL PRIMARY KEY,
+  tenant_id INT NULL,
+  type VARCHAR(100) NOT NULL,
+  name VARCHAR(200) NOT NULL,
+  payload JSONB NOT NULL,
+  origin JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  max_attempts INT NOT NULL,
+  run_at TIMESTAMPTZ NOT NULL,
+  locked_by VARCHAR(50) NULL,
+  locked_until TIMESTAMPTZ NULL,
+  last_error TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Tasks are picked by run_at, running tasks whose lease expired are picked again
+CREATE INDEX IF NOT EXISTS worker_tasks_pending_idx ON worker_tasks (run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS worker_tasks_running_idx ON worker_tasks (locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS worker_tasks_tenant_id_status_idx ON worker_tasks (tenant_id, status);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+  id SERIAL PRIMARY KEY,
+  job_name VARCHAR(100) NOT NULL,
+  trigger VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  error TEXT NULL,
+  started_at TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NOT NULL,
+  duration_ms BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS job_runs_job_name_started_at_idx ON job_runs (job_name, started_at DESC);
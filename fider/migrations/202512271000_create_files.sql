@@ -0,0 +1,20 @@
+ALTER TABLE tenants ADD allowed_file_types TEXT NOT NULL DEFAULT '.txt, .log, .csv, .json, .pdf';
+ALTER TABLE tenants ADD file_quota_mb INT NOT NULL DEFAULT 100;
+
+CREATE TABLE IF NOT EXISTS files (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL REFERENCES tenants(id),
+  post_id INT NOT NULL REFERENCES posts(id),
+  comment_id INT NULL REFERENCES comments(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  blob_key VARCHAR(512) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(200) NOT NULL,
+  size BIGINT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  scan_result TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  scanned_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS files_tenant_id_post_id_idx ON files (tenant_id, post_id);
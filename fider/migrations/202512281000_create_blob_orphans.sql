@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS blob_orphans (
+  tenant_id INT NOT NULL REFERENCES tenants(id),
+  key VARCHAR(512) NOT NULL,
+  detected_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (tenant_id, key)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS parent_id INT NULL REFERENCES comments(id);
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent ON comments(tenant_id, parent_id) WHERE parent_id IS NOT NULL;
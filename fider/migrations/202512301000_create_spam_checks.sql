@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS spam_checks (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL REFERENCES tenants(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  post_id INT NULL REFERENCES posts(id),
+  comment_id INT NULL REFERENCES comments(id),
+  ip VARCHAR(45) NOT NULL,
+  content_hash CHAR(64) NOT NULL,
+  score INT NOT NULL,
+  reasons JSONB NOT NULL,
+  is_flagged BOOLEAN NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_spam_checks_user ON spam_checks(tenant_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_spam_checks_ip ON spam_checks(tenant_id, ip, created_at);
+CREATE INDEX IF NOT EXISTS idx_spam_checks_content_hash ON spam_checks(tenant_id, content_hash);
+CREATE INDEX IF NOT EXISTS idx_spam_checks_post ON spam_checks(post_id) WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_spam_checks_comment ON spam_checks(comment_id) WHERE comment_id IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS word_filters (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL REFERENCES tenants(id),
+  pattern VARCHAR(200) NOT NULL,
+  is_regex BOOLEAN NOT NULL,
+  action SMALLINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_filters_tenant ON word_filters(tenant_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS content_reports (
+  id SERIAL PRIMARY KEY,
+  tenant_id INT NOT NULL REFERENCES tenants(id),
+  post_id INT NOT NULL REFERENCES posts(id),
+  comment_id INT NULL REFERENCES comments(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  reason SMALLINT NOT NULL,
+  details TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  resolution SMALLINT NULL,
+  resolved_at TIMESTAMPTZ NULL,
+  resolved_by_id INT NULL REFERENCES users(id)
+);
+
+-- Members can only report each post and comment once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_reports_post_user ON content_reports(tenant_id, post_id, user_id) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_reports_comment_user ON content_reports(tenant_id, comment_id, user_id) WHERE comment_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_content_reports_open ON content_reports(tenant_id) WHERE resolved_at IS NULL;
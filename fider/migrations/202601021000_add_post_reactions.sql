@@ -0,0 +1,15 @@
+ALTER TABLE reactions ADD tenant_id INT NULL REFERENCES tenants(id);
+ALTER TABLE reactions ADD post_id INT NULL REFERENCES posts(id);
+
+UPDATE reactions r SET tenant_id = c.tenant_id, post_id = c.post_id
+FROM comments c WHERE c.id = r.comment_id;
+
+ALTER TABLE reactions ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE reactions ALTER COLUMN post_id SET NOT NULL;
+
+-- Reactions on posts have no comment, comment reactions are still unique through unique_reaction
+ALTER TABLE reactions ALTER COLUMN comment_id DROP NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reactions_post_user_emoji ON reactions(post_id, user_id, emoji) WHERE comment_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reactions_tenant_post ON reactions(tenant_id, post_id);
+
+ALTER TABLE tenants ADD reaction_emojis TEXT NOT NULL DEFAULT '👍 👎 😄 🎉 😕 ❤️ 🚀 👀';
@@ -0,0 +1,34 @@
+-- Mentions were written as @[name], they now carry the id of the mentioned user as @[name]{id}.
+-- Only names that belong to a single user of the tenant can be converted, all others are kept as they are
+CREATE TEMPORARY TABLE mentioned_users ON COMMIT DROP AS
+SELECT u.tenant_id, MIN(u.id) AS id, u.name
+FROM users u
+INNER JOIN (
+    SELECT DISTINCT tenant_id, m[1] AS name
+    FROM comments, regexp_matches(content, '@\[(.*?)\]', 'g') AS m
+    UNION
+    SELECT DISTINCT tenant_id, m[1] AS name
+    FROM posts, regexp_matches(description, '@\[(.*?)\]', 'g') AS m
+) mentions
+ON mentions.tenant_id = u.tenant_id
+AND mentions.name = u.name
+WHERE u.name != ''
+GROUP BY u.tenant_id, u.name
+HAVING COUNT(*) = 1;
+
+DO $$
+DECLARE
+    mentioned RECORD;
+BEGIN
+    FOR mentioned IN SELECT tenant_id, id, name FROM mentioned_users LOOP
+        UPDATE comments
+        SET content = replace(content, '@[' || mentioned.name || ']', '@[' || mentioned.name || ']{' || mentioned.id || '}')
+        WHERE tenant_id = mentioned.tenant_id
+        AND strpos(content, '@[' || mentioned.name || ']') > 0;
+
+        UPDATE posts
+        SET description = replace(description, '@[' || mentioned.name || ']', '@[' || mentioned.name || ']{' || mentioned.id || '}')
+        WHERE tenant_id = mentioned.tenant_id
+        AND strpos(description, '@[' || mentioned.name || ']') > 0;
+    END LOOP;
+END $$;
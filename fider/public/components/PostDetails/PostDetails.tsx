@@ -1,8 +1,8 @@
 import "./PostDetails.scss"
 
-import React, { useState, useEffect, useCallback } from "react"
+import React, { useState, useEffect, useCallback, useRef } from "react"
 
-import { Comment, Post, Tag, Vote, CurrentUser, PostStatus } from "@fider/models"
+import { Comment, Post, Tag, TagGroup, Vote, CurrentUser, PostStatus, Permission } from "@fider/models"
 import { actions, cache, clearUrlHash, Failure, Fider, notify, timeAgo } from "@fider/services"
 import IconDuplicate from "@fider/assets/images/heroicons-duplicate.svg"
 import { i18n } from "@lingui/core"
@@ -10,19 +10,36 @@ import IconRSS from "@fider/assets/images/heroicons-rss.svg"
 import IconPencil from "@fider/assets/images/heroicons-pencil-alt.svg"
 import IconChat from "@fider/assets/images/heroicons-chat-alt-2.svg"
 
-import { ResponseDetails, Button, UserName, Moment, Markdown, Input, Form, Icon, Avatar, PoweredByFider, RSSModal, ResponseLozenge } from "@fider/components"
+import {
+  ResponseDetails,
+  Button,
+  UserName,
+  Moment,
+  Markdown,
+  Input,
+  Form,
+  Icon,
+  Avatar,
+  PoweredByFider,
+  RSSModal,
+  ResponseLozenge,
+  Reactions,
+  applyReactionToggle,
+} from "@fider/components"
 import { CommentInput } from "@fider/pages/ShowPost/components/CommentInput"
-import { ShowComment } from "@fider/pages/ShowPost/components/ShowComment"
+import { CommentThread } from "@fider/pages/ShowPost/components/CommentThread"
 import { VoteSection } from "@fider/pages/ShowPost/components/VoteSection"
 import CommentEditor from "@fider/components/common/form/CommentEditor"
 
 import IconX from "@fider/assets/images/heroicons-x.svg"
 import IconThumbsUp from "@fider/assets/images/heroicons-thumbsup.svg"
 import IconTrash from "@fider/assets/images/heroicons-trash.svg"
+import IconExclamation from "@fider/assets/images/heroicons-exclamation.svg"
 import { HStack, VStack } from "@fider/components/layout"
 import { Trans } from "@lingui/react/macro"
 import { DeletePostModal } from "@fider/pages/ShowPost/components/DeletePostModal"
 import { ResponseModal } from "@fider/pages/ShowPost/components/ResponseModal"
+import { ReportModal } from "@fider/pages/ShowPost/components/ReportModal"
 import { VotesPanel } from "@fider/pages/ShowPost/components/VotesPanel"
 import { TagsPanel } from "@fider/pages/ShowPost/components/TagsPanel"
 import { ActionButton } from "@fider/pages/ShowPost/components/ActionButton"
@@ -39,13 +56,14 @@ interface PostDetailsProps {
   initialSubscribed?: boolean
   initialComments?: Comment[]
   initialTags?: Tag[]
+  initialTagGroups?: TagGroup[]
   initialVotes?: Vote[]
   initialAttachments?: string[]
 }
 
 const oneHour = 3600
 const canEditPost = (user: CurrentUser, post: Post) => {
-  if (user.isCollaborator) {
+  if (user.permissions.includes(Permission.EditPosts)) {
     return true
   }
 
@@ -71,6 +89,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
   const [subscribed, setSubscribed] = useState(props.initialSubscribed || false)
   const [comments, setComments] = useState<Comment[]>(props.initialComments || [])
   const [tags, setTags] = useState<Tag[]>(props.initialTags || [])
+  const [tagGroups, setTagGroups] = useState<TagGroup[]>(props.initialTagGroups || [])
   const [votes, setVotes] = useState<Vote[]>(props.initialVotes || [])
   const [loading, setLoading] = useState(!props.initialPost)
 
@@ -78,6 +97,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
   const [showDeleteModal, setShowDeleteModal] = useState(false)
   const [isRSSModalOpen, setIsRSSModalOpen] = useState(false)
   const [showResponseModal, setShowResponseModal] = useState(false)
+  const [showReportModal, setShowReportModal] = useState(false)
   const [newTitle, setNewTitle] = useState(post?.title || "")
   const [newDescription, setNewDescription] = useState(post?.description || "")
   const { attachments, handleImageUploaded, getImageSrc } = useAttachments({
@@ -85,6 +105,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
   })
   const [highlightedComment, setHighlightedComment] = useState<number | undefined>(undefined)
   const [error, setError] = useState<Failure | undefined>(undefined)
+  const emojiSelectorRef = useRef<HTMLDivElement>(null)
   const fider = useFider()
 
   // Fetch data if not provided initially
@@ -96,10 +117,11 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
     const fetchData = async () => {
       setLoading(true)
       try {
-        const [postResponse, commentsResult, tagsResult] = await Promise.all([
+        const [postResponse, commentsResult, tagsResult, tagGroupsResult] = await Promise.all([
           fetch(`/api/v1/posts/${props.postNumber}`).then((r) => r.json()),
           fetch(`/api/v1/posts/${props.postNumber}/comments`).then((r) => r.json()),
           fetch(`/api/v1/tags`).then((r) => r.json()),
+          fetch(`/api/v1/tag-groups`).then((r) => r.json()),
         ])
 
         if (postResponse) {
@@ -110,6 +132,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
 
         setComments(commentsResult || [])
         setTags(tagsResult || [])
+        setTagGroups(tagGroupsResult || [])
 
         const votesResult = await actions.listVotes(props.postNumber)
         // Limit votes to 24 to match SSR behavior
@@ -214,7 +237,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
   const canDeletePost = () => {
     if (!post) return false
     const status = PostStatus.Get(post.status)
-    if (!Fider.session.isAuthenticated || !Fider.session.user.isAdministrator || status.closed) {
+    if (!Fider.session.hasPermission(Permission.DeletePosts) || status.closed) {
       return false
     }
     return true
@@ -255,7 +278,16 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
     }
   }
 
-  const onActionSelected = (action: "copy" | "delete" | "status" | "feed" | "edit") => () => {
+  const togglePostReaction = async (emoji: string) => {
+    if (!post) return
+    const response = await actions.togglePostReaction(post.number, emoji)
+    if (response.ok) {
+      const added = response.data.added
+      setPost((prevPost) => prevPost && { ...prevPost, reactionCounts: applyReactionToggle(prevPost.reactionCounts, emoji, added) })
+    }
+  }
+
+  const onActionSelected = (action: "copy" | "delete" | "status" | "feed" | "edit" | "report") => () => {
     if (action === "copy") {
       navigator.clipboard.writeText(window.location.href)
       notify.success(<Trans id="showpost.copylink.success">Link copied to clipboard</Trans>)
@@ -267,6 +299,8 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
       startEdit()
     } else if (action == "feed") {
       setIsRSSModalOpen(true)
+    } else if (action === "report") {
+      setShowReportModal(true)
     }
   }
 
@@ -282,6 +316,8 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
     )
   }
 
+  const canReportPost = Fider.session.isAuthenticated && post.user.id !== Fider.session.user.id
+
   return (
     <div className="p-show-post">
       {/* Left Sidebar - hidden on mobile, shown on desktop */}
@@ -323,7 +359,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
               )}
 
               {/* Admin moderation buttons */}
-              {fider.session.isAuthenticated && fider.session.showModerationControls && fider.session.user.isCollaborator && (
+              {fider.session.showModerationControls && fider.session.hasPermission(Permission.ModerateContent) && (
                 <div className="p-3 bg-blue-50 rounded border-l-4 border-blue-500 mt-4">
                   <div className="mb-2 text-sm font-medium text-blue-800">
                     <Trans id="showpost.moderation.admin.title">Moderation</Trans>
@@ -353,6 +389,12 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                   <Trans id="showpost.message.nodescription">No description provided.</Trans>
                 </em>
               )}
+              <Reactions
+                reactions={post.reactionCounts}
+                emojiSelectorRef={emojiSelectorRef}
+                toggleReaction={togglePostReaction}
+                listReactions={() => actions.listReactions(post.number)}
+              />
             </div>
           ) : (
             <div className="p-show-post__description-section">
@@ -377,7 +419,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
 
           {tags.length >= 1 && (
             <div className="pt-7">
-              <TagsPanel post={post} tags={tags} onDataChanged={props.onDataChanged} />
+              <TagsPanel post={post} tags={tags} tagGroups={tagGroups} onDataChanged={props.onDataChanged} />
             </div>
           )}
 
@@ -420,7 +462,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                   </ActionButton>
                 )}
 
-                {Fider.session.isAuthenticated && Fider.session.user.isCollaborator && (
+                {Fider.session.hasPermission(Permission.RespondToPosts) && (
                   <ActionButton icon={IconChat} onClick={onActionSelected("status")}>
                     <Trans id="action.respond">Respond</Trans>
                   </ActionButton>
@@ -432,6 +474,12 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                   </ActionButton>
                 )}
 
+                {canReportPost && (
+                  <ActionButton icon={IconExclamation} onClick={onActionSelected("report")}>
+                    <Trans id="action.report">Report</Trans>
+                  </ActionButton>
+                )}
+
                 {canDeletePost() && (
                   <ActionButton icon={IconTrash} onClick={onActionSelected("delete")} variant="danger">
                     <Trans id="action.delete">Delete</Trans>
@@ -460,7 +508,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                 <Trans id="label.discussion">Discussion</Trans>
               </span>
             </h2>
-            <div className="p-show-post__discussion-count">{comments.length}</div>
+            <div className="p-show-post__discussion-count">{comments.filter((c) => !c.isRemoved).length}</div>
           </HStack>
 
           {/* Comment Input at top */}
@@ -470,13 +518,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
           {post.response && <ResponseDetails status={post.status} response={post.response} />}
 
           {/* Comments List */}
-          {comments.length > 0 && (
-            <VStack spacing={4}>
-              {comments.map((c) => (
-                <ShowComment key={c.id} post={post} comment={c} highlighted={highlightedComment === c.id} />
-              ))}
-            </VStack>
-          )}
+          {comments.length > 0 && <CommentThread post={post} comments={comments} highlightedComment={highlightedComment} />}
         </div>
 
         {/* Powered by Fider - bottom of page on mobile only */}
@@ -488,7 +530,8 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
       {/* Modals */}
       <RSSModal isOpen={isRSSModalOpen} onClose={hideRSSModal} url={`${fider.settings.baseURL}/feed/posts/${post.number}.atom`} />
       <DeletePostModal onModalClose={() => setShowDeleteModal(false)} showModal={showDeleteModal} post={post} />
-      {Fider.session.isAuthenticated && Fider.session.user.isCollaborator && (
+      {canReportPost && <ReportModal onModalClose={() => setShowReportModal(false)} showModal={showReportModal} post={post} />}
+      {Fider.session.hasPermission(Permission.RespondToPosts) && (
         <ResponseModal onCloseModal={() => setShowResponseModal(false)} showModal={showResponseModal} post={post} />
       )}
     </div>
@@ -1,9 +1,9 @@
 import React, { useEffect, useState } from "react"
-import { ReactionCount } from "@fider/models"
+import { Reaction, ReactionCount } from "@fider/models"
 import { Icon } from "@fider/components"
 import ReactionAdd from "@fider/assets/images/reaction-add.svg"
 import { HStack } from "@fider/components/layout"
-import { classSet } from "@fider/services"
+import { classSet, Result } from "@fider/services"
 import { useFider } from "@fider/hooks"
 import "./Reactions.scss"
 
@@ -11,13 +11,60 @@ interface ReactionsProps {
   emojiSelectorRef: React.RefObject<HTMLDivElement>
   toggleReaction: (emoji: string) => void
   reactions?: ReactionCount[]
+  listReactions?: () => Promise<Result<Reaction[]>>
 }
 
-const availableEmojis = ["👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"]
+const defaultEmojis = ["👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"]
 
-export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleReaction, reactions }) => {
+// applyReactionToggle returns the counts after current user added or removed given reaction
+export const applyReactionToggle = (counts: ReactionCount[] | undefined, emoji: string, added: boolean): ReactionCount[] => {
+  const newCounts = [...(counts ?? [])]
+  const reactionIndex = newCounts.findIndex((r) => r.emoji === emoji)
+  if (reactionIndex !== -1) {
+    const newCount = added ? newCounts[reactionIndex].count + 1 : newCounts[reactionIndex].count - 1
+    if (newCount === 0) {
+      newCounts.splice(reactionIndex, 1)
+    } else {
+      newCounts[reactionIndex] = {
+        ...newCounts[reactionIndex],
+        count: newCount,
+        includesMe: added,
+      }
+    }
+  } else if (added) {
+    newCounts.push({ emoji, count: 1, includesMe: true })
+  }
+  return newCounts
+}
+
+export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleReaction, reactions, listReactions }) => {
   const fider = useFider()
   const [isEmojiSelectorOpen, setIsEmojiSelectorOpen] = useState(false)
+  const [reactedBy, setReactedBy] = useState<Reaction[]>()
+
+  const tenantEmojis = (fider.session.tenant?.reactionEmojis || "").split(/\s+/).filter((e) => e !== "")
+  const availableEmojis = tenantEmojis.length > 0 ? tenantEmojis : defaultEmojis
+
+  useEffect(() => {
+    setReactedBy(undefined)
+  }, [reactions])
+
+  const loadReactedBy = async () => {
+    if (!listReactions || reactedBy) {
+      return
+    }
+    const response = await listReactions()
+    if (response.ok) {
+      setReactedBy(response.data)
+    }
+  }
+
+  const namesOf = (emoji: string): string | undefined => {
+    return reactedBy
+      ?.filter((r) => r.emoji === emoji)
+      .map((r) => r.user.name)
+      .join(", ")
+  }
 
   useEffect(() => {
     const handleClickOutside = (event: MouseEvent) => {
@@ -66,6 +113,8 @@ export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleRe
             {reactions.map((reaction) => (
               <span
                 key={reaction.emoji}
+                title={namesOf(reaction.emoji)}
+                onMouseEnter={loadReactedBy}
                 {...(fider.session.isAuthenticated && { onClick: () => toggleReaction(reaction.emoji) })}
                 className={classSet({
                   "inline-flex items-center px-2 py-1 rounded-full text-xs": true,
@@ -1,6 +1,7 @@
 import React from "react"
 import { useFider } from "@fider/hooks"
 import { Message } from "./common"
+import { Permission } from "@fider/models"
 
 export const ReadOnlyNotice = () => {
   const fider = useFider()
@@ -8,7 +9,7 @@ export const ReadOnlyNotice = () => {
     return null
   }
 
-  if (fider.session.isAuthenticated && fider.session.user.isAdministrator) {
+  if (fider.session.hasPermission(Permission.ManageBilling)) {
     return (
       <Message alignment="center" type="warning">
         This website is currently in read-only mode because there is no active subscription. Visit{" "}
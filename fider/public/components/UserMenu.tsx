@@ -1,5 +1,6 @@
 import React from "react"
 import { useFider } from "@fider/hooks"
+import { Permission } from "@fider/models"
 import { Avatar, Dropdown } from "./common"
 import { Trans } from "@lingui/react/macro"
 import IconCog from "@fider/assets/images/heroicons-cog.svg"
@@ -18,7 +19,7 @@ export const UserMenu = () => {
         </Dropdown.ListItem>
         <Dropdown.Divider />
 
-        {fider.session.user.isCollaborator && (
+        {fider.session.hasPermission(Permission.AccessAdmin) && (
           <>
             <div className="p-2 text-medium uppercase">
               <Trans id="menu.administration">Administration</Trans>
@@ -88,7 +88,10 @@ export const SignInControl: React.FunctionComponent<SignInControlProps> = (props
   const verifyCode = async () => {
     const result = await actions.verifySignInCode(email, code)
     if (result.ok) {
-      if (props.onCodeVerified) {
+      if (result.data && result.data.twoFactorRequired) {
+        // A second factor is still required to complete the sign in
+        location.href = `/signin/2fa?redirect=${encodeURIComponent(location.pathname + location.search)}`
+      } else if (props.onCodeVerified) {
         // Let the parent component decide what to do
         props.onCodeVerified()
       } else {
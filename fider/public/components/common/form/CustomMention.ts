@@ -7,7 +7,8 @@ export const CustomMention = Mention.extend({
     return {
       markdown: {
         serialize: (state: any, node: { attrs: { id: string; label: string } }) => {
-          state.write(`@[${node.attrs.label}]`)
+          // The id is what identifies the mentioned user, the label is only a fallback for when the user no longer exists
+          state.write(/^\d+$/.test(node.attrs.id || "") ? `@[${node.attrs.label}]{${node.attrs.id}}` : `@[${node.attrs.label}]`)
         },
         parse: {
           setup(markdownit: MarkdownIt) {
@@ -23,12 +24,15 @@ export const CustomMention = Mention.extend({
             }
 
             markdownit.inline.ruler.before("text", "mention", (state: MarkdownIt.StateInline, silent: boolean) => {
-              const match = state.src.slice(state.pos).match(/^@\[(.+?)\]/)
+              const match = state.src.slice(state.pos).match(/^@\[(.+?)\](?:\{(\d+)\})?/)
               if (!match) return false
               if (!silent) {
                 const label = match[1]
                 const token = state.push("mention_open", "span", 1)
-                token.attrs = [["label", label]]
+                token.attrs = [
+                  ["id", match[2] || ""],
+                  ["label", label],
+                ]
 
                 // Add the text content token
                 const contentToken = state.push("text", "", 0)
@@ -8,21 +8,14 @@ interface MentionListProps {
   command?: (item: MentionNodeAttrs) => void
 }
 
-// Cache for storing users
-let cachedUsers: MentionNodeAttrs[] = []
-
 export default {
-  items: async ({ query }: { query: string }) => {
-    // If we don't have cached users yet, fetch them
-    if (cachedUsers.length === 0) {
-      const result = await actions.getTaggableUsers("")
-      if (result.ok) {
-        cachedUsers = result.data.map((user, idx) => ({ id: idx.toString(), label: user.name }))
-      }
+  items: async ({ query }: { query: string }): Promise<MentionNodeAttrs[]> => {
+    // Users are searched on the server, which decides who can be listed
+    const result = await actions.getTaggableUsers(query)
+    if (!result.ok) {
+      return []
     }
-
-    // Filter the cached users based on the query
-    return cachedUsers.filter((item) => item.label?.toLowerCase().startsWith(query.toLowerCase())).slice(0, 100)
+    return result.data.map((user) => ({ id: user.id.toString(), label: user.name }))
   },
   render: () => {
     let reactRenderer: ReactRenderer<MentionListHandle, MentionListProps>
@@ -4,6 +4,7 @@ import React, { Suspense } from "react"
 import { createRoot } from "react-dom/client"
 import { ErrorBoundary, Loader, ReadOnlyNotice, DevBanner } from "@fider/components"
 import { classSet, Fider, FiderContext, actions, activateI18N } from "@fider/services"
+import { Permission } from "@fider/models"
 
 import { I18n } from "@lingui/core"
 import { I18nProvider } from "@lingui/react"
@@ -40,7 +41,7 @@ const bootstrapApp = (i18n: I18n) => {
   const component = AsyncPage(fider.session.page)
   document.body.className = classSet({
     "is-authenticated": fider.session.isAuthenticated,
-    "is-staff": fider.session.isAuthenticated && fider.session.user.isCollaborator,
+    "is-staff": fider.session.hasPermission(Permission.AccessAdmin),
   })
 
   const rootElement = document.getElementById("root")
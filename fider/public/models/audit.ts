@@ -0,0 +1,13 @@
+export interface AuditLog {
+  id: number
+  actorId?: number
+  actorName: string
+  action: string
+  targetType: string
+  targetId?: number
+  targetName: string
+  before?: { [key: string]: any }
+  after?: { [key: string]: any }
+  clientIp: string
+  createdAt: string
+}
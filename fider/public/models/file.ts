@@ -0,0 +1,20 @@
+export type FileStatus = "pending" | "clean" | "quarantined"
+
+export interface FileAttachment {
+  id: number
+  postId: number
+  commentId?: number
+  userId: number
+  name: string
+  contentType: string
+  size: number
+  status: FileStatus
+  scanResult?: string
+  createdAt: string
+}
+
+export interface FileUpload {
+  fileName: string
+  contentType: string
+  content: string
+}
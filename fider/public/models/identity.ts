@@ -1,3 +1,5 @@
+import { Permission, Role } from "./role"
+
 export interface Tenant {
   id: number
   name: string
@@ -11,9 +13,13 @@ export interface Tenant {
   isPrivate: boolean
   logoBlobKey: string
   allowedSchemes: string
+  allowedFileTypes: string
+  reactionEmojis: string
+  fileQuotaMB: number
   isEmailAuthAllowed: boolean
   isFeedEnabled: boolean
   isModerationEnabled: boolean
+  isTwoFactorRequired: boolean
   hasCommercialFeatures: boolean
 }
 
@@ -32,6 +38,7 @@ export interface User {
   status: UserStatus
   isTrusted: boolean
   avatarURL: string
+  customRole?: Role
 }
 
 export interface UserNames {
@@ -74,7 +81,6 @@ export interface CurrentUser {
   avatarURL: string
   role: UserRole
   status: UserStatus
-  isAdministrator: boolean
-  isCollaborator: boolean
   isTrusted: boolean
+  permissions: Permission[]
 }
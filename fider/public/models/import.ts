@@ -0,0 +1,24 @@
+import { User } from "./identity"
+
+export type ImportSource = "canny" | "uservoice" | "csv"
+export type ImportStatus = "pending" | "running" | "completed" | "failed"
+
+export interface ImportRowError {
+  row: number
+  sourceId?: string
+  message: string
+}
+
+export interface ImportJob {
+  id: number
+  source: ImportSource
+  fileName: string
+  status: ImportStatus
+  totalRows: number
+  processedRows: number
+  failedRows: number
+  errors: ImportRowError[]
+  createdBy: User
+  createdAt: string
+  finishedAt?: string
+}
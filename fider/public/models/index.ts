@@ -3,3 +3,9 @@ export * from "./identity"
 export * from "./settings"
 export * from "./notification"
 export * from "./webhook"
+export * from "./audit"
+export * from "./role"
+export * from "./import"
+export * from "./job"
+export * from "./file"
+export * from "./moderation"
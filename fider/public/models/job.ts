@@ -0,0 +1,24 @@
+export type JobTrigger = "schedule" | "manual"
+export type JobRunStatus = "success" | "failure"
+
+export interface JobRun {
+  id: number
+  jobName: string
+  trigger: JobTrigger
+  status: JobRunStatus
+  error?: string
+  startedAt: string
+  finishedAt: string
+  durationMs: number
+}
+
+export interface Job {
+  name: string
+  schedule: string
+  defaultSchedule: string
+  isPaused: boolean
+  lastSuccessfulRun?: string
+  lastFailedRun?: string
+  lastError?: string
+  runs: JobRun[]
+}
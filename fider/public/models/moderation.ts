@@ -0,0 +1,33 @@
+import { User } from "./identity"
+
+export enum WordFilterAction {
+  Reject = "reject",
+  Mask = "mask",
+  Moderate = "moderate",
+}
+
+export interface WordFilter {
+  id: number
+  pattern: string
+  isRegex: boolean
+  action: WordFilterAction
+  createdAt: string
+}
+
+export enum ReportReason {
+  Spam = "spam",
+  Harassment = "harassment",
+  Offensive = "offensive",
+  OffTopic = "off_topic",
+  Other = "other",
+}
+
+export interface ContentReport {
+  id: number
+  postId: number
+  commentId?: number
+  reason: ReportReason
+  details?: string
+  reporter: User
+  createdAt: string
+}
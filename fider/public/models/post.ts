@@ -15,6 +15,7 @@ export interface Post {
   commentsCount: number
   tags: string[]
   isApproved: boolean
+  reactionCounts?: ReactionCount[]
 }
 
 export class PostStatus {
@@ -58,6 +59,13 @@ export interface ReactionCount {
   includesMe: boolean
 }
 
+export interface Reaction {
+  id: number
+  emoji: string
+  user: User
+  createdAt: string
+}
+
 export interface Comment {
   id: number
   content: string
@@ -68,6 +76,8 @@ export interface Comment {
   editedAt?: string
   editedBy?: User
   isApproved: boolean
+  parentId?: number
+  isRemoved?: boolean
 }
 
 export interface Tag {
@@ -76,6 +86,18 @@ export interface Tag {
   name: string
   color: string
   isPublic: boolean
+  groupId?: number
+}
+
+export type TagGroupSelection = "single" | "multiple"
+
+export interface TagGroup {
+  id: number
+  slug: string
+  name: string
+  selection: TagGroupSelection
+  visibleTo: string[]
+  assignableBy: string[]
 }
 
 export interface Vote {
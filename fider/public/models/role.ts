@@ -0,0 +1,30 @@
+export enum Permission {
+  AccessAdmin = "admin.access",
+  UseAPI = "api.use",
+  ViewUsers = "users.view",
+  ManageUsers = "users.manage",
+  SendInvitations = "invitations.send",
+  RespondToPosts = "posts.respond",
+  EditPosts = "posts.edit",
+  DeletePosts = "posts.delete",
+  EditComments = "comments.edit",
+  AssignTags = "tags.assign",
+  ManageTags = "tags.manage",
+  ModerateContent = "moderation.manage",
+  ManageSettings = "settings.manage",
+  ManageAuthentication = "authentication.manage",
+  ManageWebhooks = "webhooks.manage",
+  ManageRoles = "roles.manage",
+  ManageBilling = "billing.manage",
+  ExportData = "data.export",
+  ImportData = "data.import",
+  ViewAuditLog = "audit.view",
+  ManageJobs = "jobs.manage",
+}
+
+export interface Role {
+  id: number
+  name: string
+  description: string
+  permissions: Permission[]
+}
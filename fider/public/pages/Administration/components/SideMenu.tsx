@@ -4,6 +4,7 @@ import React, { useState } from "react"
 import { classSet } from "@fider/services"
 import { Icon } from "@fider/components"
 import { useFider } from "@fider/hooks"
+import { Permission } from "@fider/models"
 import IconX from "@fider/assets/images/heroicons-x.svg"
 import IconMenu from "@fider/assets/images/heroicons-menu.svg"
 import { VStack } from "@fider/components/layout"
@@ -36,6 +37,7 @@ const SideMenuItem = (props: SideMenuItemProps) => {
 export const SideMenu = (props: SiteMenuProps) => {
   const fider = useFider()
   const activeItem = props.activeItem || "general"
+  const can = (permission: Permission) => fider.session.hasPermission(permission)
 
   return (
     <div className="js-admin-menu sm:hidden md:hidden lg:block">
@@ -43,16 +45,20 @@ export const SideMenu = (props: SiteMenuProps) => {
         <SideMenuItem name="general" title="General" href="/admin" isActive={activeItem === "general"} />
         <SideMenuItem name="privacy" title="Privacy" href="/admin/privacy" isActive={activeItem === "privacy"} />
         <SideMenuItem name="users" title="Users" href="/admin/users" isActive={activeItem === "users"} />
+        {can(Permission.ManageRoles) && <SideMenuItem name="roles" title="Roles" href="/admin/roles" isActive={activeItem === "roles"} />}
         <SideMenuItem name="tags" title="Tags" href="/admin/tags" isActive={activeItem === "tags"} />
         <SideMenuItem name="invitations" title="Invitations" href="/admin/invitations" isActive={activeItem === "invitations"} />
         <SideMenuItem name="authentication" title="Authentication" href="/admin/authentication" isActive={activeItem === "authentication"} />
         <SideMenuItem name="advanced" title="Advanced" href="/admin/advanced" isActive={activeItem === "advanced"} />
-        {fider.session.user.isAdministrator && (
-          <>
-            {fider.settings.isBillingEnabled && <SideMenuItem name="billing" title="Billing" href="/admin/billing" isActive={activeItem === "billing"} />}
-            <SideMenuItem name="webhooks" title="Webhooks" href="/admin/webhooks" isActive={activeItem === "webhooks"} />
-            <SideMenuItem name="export" title="Export" href="/admin/export" isActive={activeItem === "export"} />
-          </>
+        {fider.settings.isBillingEnabled && can(Permission.ManageBilling) && (
+          <SideMenuItem name="billing" title="Billing" href="/admin/billing" isActive={activeItem === "billing"} />
+        )}
+        {can(Permission.ManageWebhooks) && <SideMenuItem name="webhooks" title="Webhooks" href="/admin/webhooks" isActive={activeItem === "webhooks"} />}
+        {can(Permission.ExportData) && <SideMenuItem name="export" title="Export" href="/admin/export" isActive={activeItem === "export"} />}
+        {can(Permission.ImportData) && <SideMenuItem name="import" title="Import" href="/admin/import" isActive={activeItem === "import"} />}
+        {can(Permission.ViewAuditLog) && <SideMenuItem name="audit" title="Audit Log" href="/admin/audit" isActive={activeItem === "audit"} />}
+        {fider.settings.mode === "single" && can(Permission.ManageJobs) && (
+          <SideMenuItem name="jobs" title="Jobs" href="/admin/jobs" isActive={activeItem === "jobs"} />
         )}
       </VStack>
     </div>
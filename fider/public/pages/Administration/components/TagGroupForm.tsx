@@ -0,0 +1,90 @@
+import React, { useState } from "react"
+import { Button, Checkbox, Field, Form, Input, RadioButton, SelectOption } from "@fider/components"
+import { Role, TagGroup, TagGroupSelection } from "@fider/models"
+import { Failure } from "@fider/services"
+import { TagGroupInput } from "@fider/services/actions"
+import { HStack } from "@fider/components/layout"
+
+interface TagGroupFormProps {
+  group?: TagGroup
+  roles: Role[]
+  onSave: (data: TagGroupInput) => Promise<Failure | undefined>
+  onCancel: () => void
+}
+
+const selectionMultiple = { label: "Multiple tags", value: "multiple" }
+const selectionSingle = { label: "Single tag", value: "single" }
+
+export const getRoleOptions = (roles: Role[]): SelectOption[] => [
+  { value: "visitor", label: "Visitors" },
+  { value: "collaborator", label: "Collaborators" },
+  { value: "administrator", label: "Administrators" },
+  ...roles.map((r) => ({ value: `role:${r.id}`, label: r.name })),
+]
+
+export const TagGroupForm = (props: TagGroupFormProps) => {
+  const [name, setName] = useState(props.group ? props.group.name : "")
+  const [selection, setSelection] = useState<TagGroupSelection>(props.group ? props.group.selection : "multiple")
+  const [visibleTo, setVisibleTo] = useState<string[]>(props.group ? props.group.visibleTo : [])
+  const [assignableBy, setAssignableBy] = useState<string[]>(props.group ? props.group.assignableBy : [])
+  const [error, setError] = useState<Failure | undefined>(undefined)
+
+  const options = getRoleOptions(props.roles)
+  const toggle = (keys: string[], setKeys: (keys: string[]) => void, key: string) => (checked: boolean) => {
+    setKeys(checked ? [...keys, key] : keys.filter((k) => k !== key))
+  }
+
+  const handleSave = async () => {
+    setError(await props.onSave({ name, selection, visibleTo, assignableBy }))
+  }
+
+  const setSelectionOption = (option: SelectOption) => {
+    setSelection(option === selectionSingle ? "single" : "multiple")
+  }
+
+  return (
+    <Form error={error}>
+      <Input field="name" label="Name" maxLength={60} value={name} onChange={setName} />
+      <RadioButton
+        label="Selection"
+        field="selection"
+        defaultOption={selection === "single" ? selectionSingle : selectionMultiple}
+        options={[selectionMultiple, selectionSingle]}
+        onSelect={setSelectionOption}
+      />
+      <Field label="Visible to">
+        <p className="text-muted text-sm">Leave empty to make this group visible to everyone who can see its tags.</p>
+        <div className="grid gap-2 lg:grid-cols-3">
+          {options.map((o) => (
+            <Checkbox key={o.value} field={`visibleTo-${o.value}`} checked={visibleTo.includes(o.value)} onChange={toggle(visibleTo, setVisibleTo, o.value)}>
+              {o.label}
+            </Checkbox>
+          ))}
+        </div>
+      </Field>
+      <Field label="Assignable by">
+        <p className="text-muted text-sm">Leave empty to allow everyone with permission to assign tags.</p>
+        <div className="grid gap-2 lg:grid-cols-3">
+          {options.map((o) => (
+            <Checkbox
+              key={o.value}
+              field={`assignableBy-${o.value}`}
+              checked={assignableBy.includes(o.value)}
+              onChange={toggle(assignableBy, setAssignableBy, o.value)}
+            >
+              {o.label}
+            </Checkbox>
+          ))}
+        </div>
+      </Field>
+      <HStack>
+        <Button variant="primary" onClick={handleSave}>
+          Save
+        </Button>
+        <Button variant="tertiary" onClick={props.onCancel}>
+          Cancel
+        </Button>
+      </HStack>
+    </Form>
+  )
+}
@@ -0,0 +1,97 @@
+import React, { useState } from "react"
+import { Permission, Role, TagGroup } from "@fider/models"
+import { Button, Icon } from "@fider/components"
+import { TagGroupForm, getRoleOptions } from "./TagGroupForm"
+import { actions, Failure } from "@fider/services"
+import { TagGroupInput } from "@fider/services/actions"
+import { useFider } from "@fider/hooks"
+
+import IconX from "@fider/assets/images/heroicons-x.svg"
+import IconPencilAlt from "@fider/assets/images/heroicons-pencil-alt.svg"
+import { HStack, VStack } from "@fider/components/layout"
+
+interface TagGroupListItemProps {
+  group: TagGroup
+  roles: Role[]
+  onGroupEdited: (group: TagGroup) => void
+  onGroupDeleted: (group: TagGroup) => void
+}
+
+export const TagGroupListItem = (props: TagGroupListItemProps) => {
+  const fider = useFider()
+  const [state, setState] = useState<"view" | "edit" | "delete">("view")
+
+  const resetState = () => setState("view")
+
+  const deleteGroup = async () => {
+    const result = await actions.deleteTagGroup(props.group.slug)
+    if (result.ok) {
+      resetState()
+      props.onGroupDeleted(props.group)
+    }
+  }
+
+  const updateGroup = async (data: TagGroupInput): Promise<Failure | undefined> => {
+    const result = await actions.updateTagGroup(props.group.slug, data)
+    if (result.ok) {
+      resetState()
+      props.onGroupEdited(result.data)
+    } else {
+      return result.error
+    }
+  }
+
+  const describeRoles = (keys: string[], fallback: string) => {
+    if (keys.length === 0) {
+      return fallback
+    }
+    const options = getRoleOptions(props.roles)
+    return keys.map((key) => options.find((o) => o.value === key)?.label || key).join(", ")
+  }
+
+  if (state === "edit") {
+    return <TagGroupForm group={props.group} roles={props.roles} onSave={updateGroup} onCancel={resetState} />
+  }
+
+  if (state === "delete") {
+    return (
+      <VStack spacing={2}>
+        <div>
+          <b>Are you sure?</b> <span>The group {props.group.name} will be deleted, its tags are kept without a group.</span>
+        </div>
+        <div>
+          <Button variant="danger" onClick={deleteGroup}>
+            Delete group
+          </Button>
+          <Button onClick={resetState} variant="tertiary">
+            Cancel
+          </Button>
+        </div>
+      </VStack>
+    )
+  }
+
+  const buttons = fider.session.hasPermission(Permission.ManageTags) && [
+    <Button size="small" key={0} onClick={() => setState("edit")}>
+      <Icon sprite={IconPencilAlt} />
+      <span>Edit</span>
+    </Button>,
+    <Button size="small" key={1} onClick={() => setState("delete")}>
+      <Icon sprite={IconX} />
+      <span>Delete</span>
+    </Button>,
+  ]
+
+  return (
+    <HStack justify="between">
+      <VStack spacing={1}>
+        <span className="text-subtitle">{props.group.name}</span>
+        <span className="text-xs text-gray-600">
+          {props.group.selection === "single" ? "Single tag" : "Multiple tags"} · Visible to {describeRoles(props.group.visibleTo, "everyone")} · Assignable by{" "}
+          {describeRoles(props.group.assignableBy, "members allowed to assign tags")}
+        </span>
+      </VStack>
+      <HStack>{buttons}</HStack>
+    </HStack>
+  )
+}
@@ -1,5 +1,5 @@
 import React, { useState } from "react"
-import { Tag } from "@fider/models"
+import { Permission, Tag, TagGroup } from "@fider/models"
 import { ShowTag, Button, Icon } from "@fider/components"
 import { TagFormState, TagForm } from "./TagForm"
 import { actions, Failure } from "@fider/services"
@@ -11,6 +11,7 @@ import { HStack, VStack } from "@fider/components/layout"
 
 interface TagListItemProps {
   tag: Tag
+  groups?: TagGroup[]
   onTagEdited: (tag: Tag) => void
   onTagDeleted: (tag: Tag) => void
 }
@@ -33,12 +34,13 @@ export const TagListItem = (props: TagListItemProps) => {
   }
 
   const updateTag = async (data: TagFormState): Promise<Failure | undefined> => {
-    const result = await actions.updateTag(tag.slug, data.name, data.color, data.isPublic)
+    const result = await actions.updateTag(tag.slug, data.name, data.color, data.isPublic, data.groupId)
     if (result.ok) {
       tag.name = result.data.name
       tag.slug = result.data.slug
       tag.color = result.data.color
       tag.isPublic = result.data.isPublic
+      tag.groupId = result.data.groupId
 
       resetState()
       props.onTagEdited(tag)
@@ -69,7 +71,7 @@ export const TagListItem = (props: TagListItemProps) => {
   }
 
   const renderViewMode = () => {
-    const buttons = fider.session.user.isAdministrator && [
+    const buttons = fider.session.hasPermission(Permission.ManageTags) && [
       <Button size="small" key={0} onClick={startEdit}>
         <Icon sprite={IconPencilAlt} />
         <span>Edit</span>
@@ -89,7 +91,17 @@ export const TagListItem = (props: TagListItemProps) => {
   }
 
   const renderEditMode = () => {
-    return <TagForm name={props.tag.name} color={props.tag.color} isPublic={props.tag.isPublic} onSave={updateTag} onCancel={resetState} />
+    return (
+      <TagForm
+        name={props.tag.name}
+        color={props.tag.color}
+        isPublic={props.tag.isPublic}
+        groupId={props.tag.groupId}
+        groups={props.groups}
+        onSave={updateTag}
+        onCancel={resetState}
+      />
+    )
   }
 
   return state === "delete" ? renderDeleteMode() : state === "edit" ? renderEditMode() : renderViewMode()
@@ -0,0 +1,114 @@
+import "./WordFilters.scss"
+
+import React, { useEffect, useState } from "react"
+import { Button, Form, Input, Select, SelectOption, Checkbox } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { WordFilter, WordFilterAction } from "@fider/models"
+import { actions, Failure } from "@fider/services"
+
+const actionOptions: SelectOption[] = [
+  { value: WordFilterAction.Reject, label: "Reject the content" },
+  { value: WordFilterAction.Mask, label: "Mask the matched text" },
+  { value: WordFilterAction.Moderate, label: "Send to the moderation queue" },
+]
+
+const actionLabels: { [key: string]: string } = {
+  [WordFilterAction.Reject]: "Reject",
+  [WordFilterAction.Mask]: "Mask",
+  [WordFilterAction.Moderate]: "Moderate",
+}
+
+export const WordFilters = () => {
+  const [filters, setFilters] = useState<WordFilter[]>([])
+  const [pattern, setPattern] = useState("")
+  const [isRegex, setIsRegex] = useState(false)
+  const [action, setAction] = useState<WordFilterAction>(WordFilterAction.Reject)
+  const [error, setError] = useState<Failure | undefined>()
+  const [formKey, setFormKey] = useState(0)
+
+  useEffect(() => {
+    actions.listWordFilters().then((result) => {
+      if (result.ok) {
+        setFilters(result.data)
+      }
+    })
+  }, [])
+
+  const addFilter = async () => {
+    const result = await actions.createWordFilter(pattern, isRegex, action)
+    if (result.ok) {
+      setFilters([...filters, result.data])
+      setPattern("")
+      setIsRegex(false)
+      setError(undefined)
+      setFormKey(formKey + 1)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const removeFilter = async (filter: WordFilter) => {
+    const result = await actions.deleteWordFilter(filter.id)
+    if (result.ok) {
+      setFilters(filters.filter((f) => f.id !== filter.id))
+    }
+  }
+
+  return (
+    <VStack spacing={4} className="c-word-filters">
+      <VStack spacing={1}>
+        <h2 className="text-title">Word Filters</h2>
+        <p className="text-muted">
+          Blocked words and patterns are checked on titles, descriptions, comments and display names of everyone but moderators. Words match whole words
+          ignoring case, regular expressions are used as they are.
+        </p>
+      </VStack>
+
+      {filters.length > 0 && (
+        <table className="c-word-filters__list">
+          <thead>
+            <tr>
+              <th>Pattern</th>
+              <th>Action</th>
+              <th />
+            </tr>
+          </thead>
+          <tbody>
+            {filters.map((f) => (
+              <tr key={f.id}>
+                <td>
+                  <code>{f.isRegex ? `/${f.pattern}/` : f.pattern}</code>
+                </td>
+                <td>{actionLabels[f.action]}</td>
+                <td>
+                  <Button size="small" variant="tertiary" onClick={() => removeFilter(f)}>
+                    Remove
+                  </Button>
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+
+      <Form key={formKey} error={error}>
+        <HStack spacing={4} align="end">
+          <Input field="pattern" label="Word or pattern" value={pattern} onChange={setPattern} />
+          <Select
+            field="action"
+            label="When it matches"
+            defaultValue={action}
+            options={actionOptions}
+            onChange={(o?: SelectOption) => setAction((o?.value as WordFilterAction) || WordFilterAction.Reject)}
+          />
+        </HStack>
+        <Checkbox field="isRegex" checked={isRegex} onChange={setIsRegex}>
+          Regular expression
+        </Checkbox>
+        <Button variant="primary" onClick={addFilter}>
+          Add filter
+        </Button>
+      </Form>
+    </VStack>
+  )
+}
@@ -1,12 +1,17 @@
 import React from "react"
 
-import { TextArea, Form, Button } from "@fider/components"
-import { Failure, actions, Fider } from "@fider/services"
+import { TextArea, Input, Form, Button } from "@fider/components"
+import { Failure, actions, Fider, formatFileSize } from "@fider/services"
+import { Permission } from "@fider/models"
 import { AdminBasePage } from "../components/AdminBasePage"
 
 interface AdvancedSettingsPageProps {
   customCSS: string
   allowedSchemes: string
+  allowedFileTypes: string
+  fileQuotaMB: number
+  filesUsage: number
+  reactionEmojis: string
   licenseKey: string
   hasCommercialFeatures: boolean
 }
@@ -14,6 +19,9 @@ interface AdvancedSettingsPageProps {
 interface AdvancedSettingsPageState {
   customCSS: string
   allowedSchemes: string
+  allowedFileTypes: string
+  fileQuotaMB: string
+  reactionEmojis: string
   error?: Failure
   copied: boolean
 }
@@ -30,6 +38,9 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
     this.state = {
       customCSS: this.props.customCSS,
       allowedSchemes: this.props.allowedSchemes,
+      allowedFileTypes: this.props.allowedFileTypes,
+      fileQuotaMB: this.props.fileQuotaMB.toString(),
+      reactionEmojis: this.props.reactionEmojis,
       copied: false,
     }
   }
@@ -42,8 +53,26 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
     this.setState({ allowedSchemes })
   }
 
+  private setAllowedFileTypes = (allowedFileTypes: string): void => {
+    this.setState({ allowedFileTypes })
+  }
+
+  private setFileQuotaMB = (fileQuotaMB: string): void => {
+    this.setState({ fileQuotaMB })
+  }
+
+  private setReactionEmojis = (reactionEmojis: string): void => {
+    this.setState({ reactionEmojis })
+  }
+
   private handleSave = async (): Promise<void> => {
-    const result = await actions.updateTenantAdvancedSettings(this.state.customCSS, this.state.allowedSchemes)
+    const result = await actions.updateTenantAdvancedSettings({
+      customCSS: this.state.customCSS,
+      allowedSchemes: this.state.allowedSchemes,
+      allowedFileTypes: this.state.allowedFileTypes,
+      fileQuotaMB: parseInt(this.state.fileQuotaMB, 10) || 0,
+      reactionEmojis: this.state.reactionEmojis,
+    })
     if (result.ok) {
       location.reload()
     } else {
@@ -85,7 +114,7 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
         <TextArea
           field="customCSS"
           label="Custom CSS"
-          disabled={!Fider.session.user.isAdministrator}
+          disabled={!Fider.session.hasPermission(Permission.ManageSettings)}
           minRows={10}
           value={this.state.customCSS}
           onChange={this.setCustomCSS}
@@ -113,7 +142,7 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
           <TextArea
             field="allowedSchemes"
             label="Allowed URL Schemes"
-            disabled={!Fider.session.user.isAdministrator}
+            disabled={!Fider.session.hasPermission(Permission.ManageSettings)}
             minRows={3}
             value={this.state.allowedSchemes}
             onChange={this.setAllowedSchemes}
@@ -129,7 +158,51 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
           </TextArea>
         )}
 
-        {Fider.session.user.isAdministrator && (
+        <TextArea
+          field="allowedFileTypes"
+          label="Allowed File Attachments"
+          disabled={!Fider.session.hasPermission(Permission.ManageSettings)}
+          minRows={2}
+          value={this.state.allowedFileTypes}
+          onChange={this.setAllowedFileTypes}
+        >
+          <p className="text-muted">
+            Extensions such as <code>.log</code> or <code>.pdf</code> and MIME types such as <code>application/pdf</code> or <code>text/*</code>, separated
+            by commas. Leave it empty to disable file attachments.
+          </p>
+          <p className="text-muted">
+            Both the extension and the actual content of each file are checked against this list. Files can only be downloaded after they pass the malware
+            scan.
+          </p>
+        </TextArea>
+
+        <Input
+          field="fileQuotaMB"
+          label="File Attachments Quota"
+          inputMode="numeric"
+          disabled={!Fider.session.hasPermission(Permission.ManageSettings)}
+          value={this.state.fileQuotaMB}
+          onChange={this.setFileQuotaMB}
+          suffix="MB"
+        >
+          <p className="text-muted">
+            Total space available for file attachments. {formatFileSize(this.props.filesUsage)} are currently in use.
+          </p>
+        </Input>
+
+        <Input
+          field="reactionEmojis"
+          label="Reactions"
+          disabled={!Fider.session.hasPermission(Permission.ManageSettings)}
+          value={this.state.reactionEmojis}
+          onChange={this.setReactionEmojis}
+        >
+          <p className="text-muted">
+            The emoji members can react with on posts and comments, separated by spaces. Up to 20 are allowed. Leave it empty to use the default list.
+          </p>
+        </Input>
+
+        {Fider.session.hasPermission(Permission.ManageSettings) && (
           <div className="field">
             <Button variant="primary" onClick={this.handleSave}>
               Save
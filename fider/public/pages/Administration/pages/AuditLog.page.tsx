@@ -0,0 +1,153 @@
+import React, { useState, useCallback } from "react"
+import { Button, Icon, Pagination, Select } from "@fider/components"
+import { AuditLog } from "@fider/models"
+import { Fider, formatDate } from "@fider/services"
+import { AdminPageContainer } from "../components/AdminBasePage"
+import { HStack, VStack } from "@fider/components/layout"
+import IconDownload from "@fider/assets/images/heroicons-download.svg"
+
+interface AuditLogPageProps {
+  auditLogs: AuditLog[]
+  actions: string[]
+  totalCount: number
+  totalPages: number
+}
+
+interface AuditLogFilters {
+  action: string
+  since: string
+  until: string
+}
+
+const gridTemplateColumns = "minmax(160px, 1fr) minmax(140px, 1fr) minmax(180px, 1fr) minmax(200px, 2fr) 120px"
+
+const describeChanges = (auditLog: AuditLog): string => {
+  const keys = new Set([...Object.keys(auditLog.before || {}), ...Object.keys(auditLog.after || {})])
+  return Array.from(keys)
+    .map((key) => {
+      const before = auditLog.before && auditLog.before[key]
+      const after = auditLog.after && auditLog.after[key]
+      if (before === undefined) {
+        return `${key}: ${JSON.stringify(after)}`
+      }
+      if (after === undefined) {
+        return `${key}: ${JSON.stringify(before)}`
+      }
+      return `${key}: ${JSON.stringify(before)} → ${JSON.stringify(after)}`
+    })
+    .join(", ")
+}
+
+const AuditLogListItem = (props: { auditLog: AuditLog; isLast: boolean }) => {
+  const { auditLog } = props
+  return (
+    <div
+      className={`border-b border-gray-200 grid gap-4 py-3 px-4 bg-white text-sm ${props.isLast ? "rounded-md-b" : ""}`}
+      style={{ gridTemplateColumns }}
+    >
+      <div className="text-muted">{formatDate(Fider.currentLocale, auditLog.createdAt)}</div>
+      <div className="text-semibold">{auditLog.actorName}</div>
+      <div>
+        <span className="text-xs bg-gray-100 text-gray-800 px-2 py-1 rounded">{auditLog.action}</span>
+      </div>
+      <div>
+        <div>
+          {auditLog.targetType}
+          {auditLog.targetName && <>: {auditLog.targetName}</>}
+        </div>
+        <div className="text-muted text-xs">{describeChanges(auditLog)}</div>
+      </div>
+      <div className="text-muted text-xs">{auditLog.clientIp}</div>
+    </div>
+  )
+}
+
+const toQueryString = (filters: AuditLogFilters, page?: number): string => {
+  const params = new URLSearchParams()
+  if (filters.action) {
+    params.append("action", filters.action)
+  }
+  if (filters.since) {
+    params.append("since", filters.since)
+  }
+  if (filters.until) {
+    params.append("until", filters.until)
+  }
+  if (page) {
+    params.append("page", page.toString())
+  }
+  return params.toString()
+}
+
+export default function AuditLogPage(props: AuditLogPageProps) {
+  const [auditLogs, setAuditLogs] = useState<AuditLog[]>(props.auditLogs)
+  const [totalPages, setTotalPages] = useState(props.totalPages)
+  const [currentPage, setCurrentPage] = useState(1)
+  const [filters, setFilters] = useState<AuditLogFilters>({ action: "", since: "", until: "" })
+
+  const reload = useCallback(async (newFilters: AuditLogFilters, page = 1) => {
+    const response = await fetch(`/api/v1/admin/audit?${toQueryString(newFilters, page)}`)
+    if (response.ok) {
+      const data = await response.json()
+      setAuditLogs(data.auditLogs)
+      setTotalPages(data.totalPages)
+      setCurrentPage(page)
+    }
+  }, [])
+
+  const changeFilter = (key: keyof AuditLogFilters, value: string) => {
+    const newFilters = { ...filters, [key]: value }
+    setFilters(newFilters)
+    reload(newFilters, 1)
+  }
+
+  const actionOptions = [{ value: "", label: "All actions" }, ...props.actions.map((x) => ({ value: x, label: x }))]
+  const exportQuery = toQueryString(filters)
+
+  return (
+    <AdminPageContainer id="p-admin-audit" name="audit" title="Audit Log" subtitle="Review administrative actions taken on this site">
+      <HStack spacing={4} className="mb-4 flex-items-end">
+        <Select field="action" label="Action" options={actionOptions} onChange={(o) => changeFilter("action", o ? o.value : "")} />
+        <div className="c-form-field">
+          <label htmlFor="input-since">From</label>
+          <input id="input-since" className="c-input" type="date" value={filters.since} onChange={(e) => changeFilter("since", e.currentTarget.value)} />
+        </div>
+        <div className="c-form-field">
+          <label htmlFor="input-until">To</label>
+          <input id="input-until" className="c-input" type="date" value={filters.until} onChange={(e) => changeFilter("until", e.currentTarget.value)} />
+        </div>
+      </HStack>
+
+      <VStack className="rounded-md border border-gray-200 relative">
+        <div className="grid rounded-md-t gap-4 py-3 px-4 bg-gray-100 text-category" style={{ gridTemplateColumns }}>
+          <div>Date</div>
+          <div>Actor</div>
+          <div>Action</div>
+          <div>Target</div>
+          <div>IP</div>
+        </div>
+        <div>
+          {auditLogs.length === 0 && <p className="text-muted py-4 px-4">No actions have been recorded yet.</p>}
+          {auditLogs.map((auditLog, index) => (
+            <AuditLogListItem key={auditLog.id} auditLog={auditLog} isLast={index === auditLogs.length - 1} />
+          ))}
+        </div>
+      </VStack>
+
+      <div className="pt-4">
+        <Pagination currentPage={currentPage} totalPages={totalPages} onPageChange={(page) => reload(filters, page)} />
+      </div>
+
+      <HStack spacing={2} className="mt-4">
+        <Button variant="secondary" href={`/admin/export/audit.csv${exportQuery ? `?${exportQuery}` : ""}`}>
+          <Icon sprite={IconDownload} />
+          <span>audit.csv</span>
+        </Button>
+        <Button variant="secondary" href={`/admin/export/audit.json${exportQuery ? `?${exportQuery}` : ""}`}>
+          <Icon sprite={IconDownload} />
+          <span>audit.json</span>
+        </Button>
+      </HStack>
+    </AdminPageContainer>
+  )
+}
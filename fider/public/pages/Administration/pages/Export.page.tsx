@@ -1,10 +1,183 @@
-import React from "react"
+import React, { useState } from "react"
 
-import { Button, Icon } from "@fider/components"
+import { Button, Checkbox, Field, Form, Icon, Input, Select, SelectOption } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { Permission, PostStatus, Tag } from "@fider/models"
+import { actions, Failure, Fider, fileToBase64 } from "@fider/services"
+import { RestoreReport } from "@fider/services/actions"
 import { AdminBasePage } from "../components/AdminBasePage"
 import IconDownload from "@fider/assets/images/heroicons-download.svg"
 
-export default class ExportPage extends AdminBasePage<any, any> {
+interface ExportPageProps {
+  tags: Tag[]
+  columns: { [type: string]: string[] }
+}
+
+const exportTypes: SelectOption[] = [
+  { value: "posts", label: "Posts" },
+  { value: "comments", label: "Comments" },
+  { value: "votes", label: "Votes" },
+  { value: "reactions", label: "Reactions" },
+]
+
+const exportFormats: SelectOption[] = [
+  { value: "csv", label: "CSV" },
+  { value: "json", label: "JSON" },
+  { value: "ndjson", label: "NDJSON (one JSON object per line)" },
+]
+
+const FilteredExportForm = (props: ExportPageProps) => {
+  const [type, setType] = useState("posts")
+  const [format, setFormat] = useState("csv")
+  const [columns, setColumns] = useState<string[]>([])
+  const [statuses, setStatuses] = useState<string[]>([])
+  const [tags, setTags] = useState<string[]>([])
+  const [search, setSearch] = useState("")
+  const [since, setSince] = useState("")
+  const [until, setUntil] = useState("")
+
+  const toggle = (list: string[], value: string, checked: boolean) => (checked ? [...list, value] : list.filter((x) => x !== value))
+
+  const selectType = (option?: SelectOption) => {
+    setType(option ? option.value : "posts")
+    setColumns([])
+  }
+
+  const params = new URLSearchParams({ format })
+  const filters: [string, string][] = [
+    ["columns", (props.columns[type] || []).filter((c) => columns.includes(c)).join(",")],
+    ["statuses", statuses.join(",")],
+    ["tags", tags.join(",")],
+    ["query", search],
+    ["since", since],
+    ["until", until],
+  ]
+  filters.filter(([, value]) => value !== "").forEach(([key, value]) => params.append(key, value))
+
+  return (
+    <VStack spacing={2}>
+      <HStack spacing={4} className="flex-items-end">
+        <Select field="type" label="Records" defaultValue={type} options={exportTypes} onChange={selectType} />
+        <Select field="format" label="Format" defaultValue={format} options={exportFormats} onChange={(o) => setFormat(o ? o.value : "csv")} />
+      </HStack>
+      <Input field="query" label="Search posts" value={search} onChange={setSearch} />
+      <HStack spacing={4}>
+        <div className="c-form-field">
+          <label htmlFor="input-since">Created from</label>
+          <input id="input-since" className="c-input" type="date" value={since} onChange={(e) => setSince(e.currentTarget.value)} />
+        </div>
+        <div className="c-form-field">
+          <label htmlFor="input-until">Created until</label>
+          <input id="input-until" className="c-input" type="date" value={until} onChange={(e) => setUntil(e.currentTarget.value)} />
+        </div>
+      </HStack>
+      <p className="text-subtitle">Statuses</p>
+      <HStack spacing={4} className="flex-wrap">
+        {PostStatus.All.map((status) => (
+          <Checkbox key={status.value} field={`status-${status.value}`} onChange={(checked) => setStatuses(toggle(statuses, status.value, checked))}>
+            {status.title}
+          </Checkbox>
+        ))}
+      </HStack>
+      {props.tags.length > 0 && (
+        <>
+          <p className="text-subtitle">Tags</p>
+          <HStack spacing={4} className="flex-wrap">
+            {props.tags.map((tag) => (
+              <Checkbox key={tag.slug} field={`tag-${tag.slug}`} onChange={(checked) => setTags(toggle(tags, tag.slug, checked))}>
+                {tag.name}
+              </Checkbox>
+            ))}
+          </HStack>
+        </>
+      )}
+      <p className="text-subtitle">Columns</p>
+      <p className="text-muted">All columns are exported when none is selected. Columns with emails are only available to administrators.</p>
+      <HStack spacing={4} className="flex-wrap">
+        {(props.columns[type] || []).map((column) => (
+          <Checkbox key={`${type}-${column}`} field={`column-${column}`} onChange={(checked) => setColumns(toggle(columns, column, checked))}>
+            {column}
+          </Checkbox>
+        ))}
+      </HStack>
+      <div>
+        <Button variant="secondary" href={`/admin/export/data/${type}?${params.toString()}`}>
+          <Icon sprite={IconDownload} />
+          <span>
+            {type}.{format}
+          </span>
+        </Button>
+      </div>
+    </VStack>
+  )
+}
+
+const RestoreReportSummary = (props: { report: RestoreReport }) => {
+  const tables = Object.keys(props.report.rows).sort()
+  return (
+    <VStack spacing={2} className="mt-4">
+      <p className="text-subtitle">
+        {props.report.dryRun ? "The backup is valid and would restore" : "Restored"} {props.report.tenantName} ({props.report.subdomain})
+      </p>
+      <ul className="text-muted">
+        {tables.map((table) => (
+          <li key={table}>
+            {table}: {props.report.rows[table]}
+          </li>
+        ))}
+        <li>files: {props.report.blobs}</li>
+      </ul>
+      {props.report.warnings.map((warning, i) => (
+        <p key={i} className="text-yellow-700">
+          {warning}
+        </p>
+      ))}
+    </VStack>
+  )
+}
+
+const RestoreBackupForm = () => {
+  const [file, setFile] = useState<File | undefined>(undefined)
+  const [passphrase, setPassphrase] = useState("")
+  const [report, setReport] = useState<RestoreReport | undefined>(undefined)
+  const [error, setError] = useState<Failure | undefined>(undefined)
+
+  const selectFile = (e: React.ChangeEvent<HTMLInputElement>) => {
+    setFile(e.currentTarget.files ? e.currentTarget.files[0] : undefined)
+    setReport(undefined)
+  }
+
+  const validate = async () => {
+    const content = file ? await fileToBase64(file) : ""
+    const result = await actions.validateBackup(file ? file.name : "", content, passphrase)
+    if (result.ok) {
+      setError(undefined)
+      setReport(result.data)
+    } else {
+      setError(result.error)
+      setReport(undefined)
+    }
+  }
+
+  return (
+    <Form error={error}>
+      <Field field="content" label="Backup file">
+        <input id="input-content" type="file" accept=".zip,.enc,application/zip" onChange={selectFile} />
+      </Field>
+      <Field field="passphrase" label="Passphrase (only for encrypted backups)">
+        <input id="input-passphrase" type="password" autoComplete="off" value={passphrase} onChange={(e) => setPassphrase(e.currentTarget.value)} />
+      </Field>
+      <HStack>
+        <Button variant="secondary" onClick={validate} disabled={!file}>
+          Validate
+        </Button>
+      </HStack>
+      {report && <RestoreReportSummary report={report} />}
+    </Form>
+  )
+}
+
+export default class ExportPage extends AdminBasePage<ExportPageProps, any> {
   public id = "p-admin-export"
   public name = "export"
   public title = "Export"
@@ -23,6 +196,15 @@ export default class ExportPage extends AdminBasePage<any, any> {
           <span>posts.csv</span>
         </Button>
 
+        <div className="mt-8">
+          <h2 className="text-display">Export with filters</h2>
+          <p className="text-muted">
+            Choose which posts, comments, votes or reactions to export and the columns to include. Comments, votes and reactions are exported for the posts
+            that match the filters, and the period applies to when each record was created.
+          </p>
+          <FilteredExportForm tags={this.props.tags} columns={this.props.columns} />
+        </div>
+
         <div className="mt-8">
           <h2 className="text-display">Backup your data</h2>
           <p className="text-muted">
@@ -33,6 +215,17 @@ export default class ExportPage extends AdminBasePage<any, any> {
             <span>backup.zip</span>
           </Button>
         </div>
+
+        {Fider.isSingleHostMode() && Fider.session.hasPermission(Permission.ImportData) && (
+          <div className="mt-8">
+            <h2 className="text-display">Restore a backup</h2>
+            <p className="text-muted">
+              Upload a backup.zip to check that it can be restored, then run the `fider restore` command against an empty database to restore it. Every record
+              gets a new identifier, so the original site is not affected.
+            </p>
+            <RestoreBackupForm />
+          </div>
+        )}
       </>
     )
   }
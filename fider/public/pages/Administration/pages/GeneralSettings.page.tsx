@@ -3,7 +3,7 @@ import React, { useState } from "react"
 import { Button, ButtonClickEvent, TextArea, Form, Input, ImageUploader, Select } from "@fider/components"
 import { AdminPageContainer } from "../components/AdminBasePage"
 import { actions, Failure, Fider } from "@fider/services"
-import { ImageUpload } from "@fider/models"
+import { ImageUpload, Permission } from "@fider/models"
 import { useFider } from "@fider/hooks"
 import locales from "@locale/locales"
 
@@ -45,7 +45,7 @@ const GeneralSettingsPage = () => {
   return (
     <AdminPageContainer id="p-admin-general" name="general" title="General" subtitle="Manage your site settings">
       <Form error={error}>
-        <Input field="title" label="Your Fider board's title" maxLength={60} value={title} disabled={!fider.session.user.isAdministrator} onChange={setTitle}>
+        <Input field="title" label="Your Fider board's title" maxLength={60} value={title} disabled={!fider.session.hasPermission(Permission.ManageSettings)} onChange={setTitle}>
           <p className="text-muted">Keep it short and snappy. Your product / service name is usually best.</p>
         </Input>
 
@@ -54,7 +54,7 @@ const GeneralSettingsPage = () => {
           label="Welcome Header"
           maxLength={100}
           value={welcomeHeader}
-          disabled={!fider.session.user.isAdministrator}
+          disabled={!fider.session.hasPermission(Permission.ManageSettings)}
           placeholder="Help us build the _best feedback platform_"
           onChange={setWelcomeHeader}
         >
@@ -67,7 +67,7 @@ const GeneralSettingsPage = () => {
           field="welcomeMessage"
           label="Welcome Message"
           value={welcomeMessage}
-          disabled={!fider.session.user.isAdministrator}
+          disabled={!fider.session.hasPermission(Permission.ManageSettings)}
           onChange={setWelcomeMessage}
         >
           <p className="text-muted">
@@ -81,14 +81,14 @@ const GeneralSettingsPage = () => {
           label="Invitation"
           maxLength={60}
           value={invitation}
-          disabled={!fider.session.user.isAdministrator}
+          disabled={!fider.session.hasPermission(Permission.ManageSettings)}
           placeholder="Enter your suggestion here..."
           onChange={setInvitation}
         >
           <p className="text-muted">Placeholder text in the suggestion&apos;s box. It should invite your visitors into sharing their feedback.</p>
         </Input>
 
-        <ImageUploader label="Your Logo" field="logo" bkey={fider.session.tenant.logoBlobKey} disabled={!fider.session.user.isAdministrator} onChange={setLogo}>
+        <ImageUploader label="Your Logo" field="logo" bkey={fider.session.tenant.logoBlobKey} disabled={!fider.session.hasPermission(Permission.ManageSettings)} onChange={setLogo}>
           <p className="text-muted">JPG, GIF or PNG smaller than 100KB, minimum size 200x200 pixels.</p>
         </ImageUploader>
 
@@ -99,7 +99,7 @@ const GeneralSettingsPage = () => {
             maxLength={100}
             placeholder="feedback.yourcompany.com"
             value={cname}
-            disabled={!fider.session.user.isAdministrator}
+            disabled={!fider.session.hasPermission(Permission.ManageSettings)}
             onChange={setCNAME}
           >
             <div className="text-muted">
@@ -144,7 +144,7 @@ const GeneralSettingsPage = () => {
         </Select>
 
         <div className="field">
-          <Button disabled={!fider.session.user.isAdministrator} variant="primary" onClick={handleSave}>
+          <Button disabled={!fider.session.hasPermission(Permission.ManageSettings)} variant="primary" onClick={handleSave}>
             Save
           </Button>
         </div>
@@ -0,0 +1,183 @@
+import React, { useEffect, useState } from "react"
+
+import { Button, Field, Form, Select, SelectOption } from "@fider/components"
+import { VStack } from "@fider/components/layout"
+import { ImportJob, ImportSource } from "@fider/models"
+import { actions, Failure, Fider, fileToBase64, formatDate } from "@fider/services"
+import { AdminBasePage } from "../components/AdminBasePage"
+
+interface ImportPageProps {
+  jobs: ImportJob[]
+  fields: string[]
+}
+
+const sources: SelectOption[] = [
+  { value: "canny", label: "Canny (JSON)" },
+  { value: "uservoice", label: "UserVoice (CSV of suggestions, comments or supporters)" },
+  { value: "csv", label: "Other tool (CSV)" },
+]
+
+const fieldLabels: { [field: string]: string } = {
+  id: "Id",
+  title: "Title (required)",
+  description: "Description",
+  authorName: "Author name",
+  authorEmail: "Author email",
+  createdAt: "Created at",
+  status: "Status",
+  response: "Response",
+  respondedAt: "Responded at",
+  tags: "Tags",
+  voters: "Voters' emails",
+}
+
+const isRunning = (job: ImportJob) => job.status === "pending" || job.status === "running"
+
+const ImportJobItem = (props: { job: ImportJob; onChange: (job: ImportJob) => void }) => {
+  const { job, onChange } = props
+  const [showErrors, setShowErrors] = useState(false)
+
+  useEffect(() => {
+    if (!isRunning(job)) {
+      return
+    }
+
+    const timer = setTimeout(async () => {
+      const result = await actions.getImport(job.id)
+      if (result.ok) {
+        onChange(result.data)
+      }
+    }, 2000)
+    return () => clearTimeout(timer)
+  }, [job])
+
+  const progress = job.totalRows > 0 ? Math.floor((job.processedRows / job.totalRows) * 100) : 0
+
+  return (
+    <div className="border-b border-gray-200 py-3 px-4 bg-white text-sm">
+      <div>
+        <span className="text-semibold">{job.fileName || job.source}</span>{" "}
+        <span className="text-xs bg-gray-100 text-gray-800 px-2 py-1 rounded">{job.status}</span>
+      </div>
+      <div className="text-muted text-xs">
+        {job.source} · {formatDate(Fider.currentLocale, job.createdAt)} · {job.createdBy.name}
+      </div>
+      <div className="text-muted">
+        {job.processedRows} of {job.totalRows} rows processed ({progress}%), {job.failedRows} failed
+      </div>
+      {job.errors.length > 0 && (
+        <Button variant="tertiary" size="small" onClick={() => setShowErrors(!showErrors)}>
+          {showErrors ? "Hide errors" : "Show errors"}
+        </Button>
+      )}
+      {showErrors && (
+        <ul className="text-xs">
+          {job.errors.map((error, i) => (
+            <li key={i}>
+              Row {error.row}
+              {error.sourceId && <> ({error.sourceId})</>}: {error.message}
+            </li>
+          ))}
+          {job.failedRows > job.errors.length && <li className="text-muted">and {job.failedRows - job.errors.length} more rows</li>}
+        </ul>
+      )}
+    </div>
+  )
+}
+
+const StartImportForm = (props: { fields: string[]; onStart: (job: ImportJob) => void }) => {
+  const [source, setSource] = useState<ImportSource>("canny")
+  const [file, setFile] = useState<File | undefined>(undefined)
+  const [mapping, setMapping] = useState<{ [field: string]: string }>({})
+  const [error, setError] = useState<Failure | undefined>(undefined)
+
+  const selectSource = (option?: SelectOption) => {
+    setSource(option ? (option.value as ImportSource) : "canny")
+  }
+
+  const selectFile = (e: React.ChangeEvent<HTMLInputElement>) => {
+    setFile(e.currentTarget.files ? e.currentTarget.files[0] : undefined)
+  }
+
+  const setColumn = (field: string) => (e: React.ChangeEvent<HTMLInputElement>) => {
+    setMapping({ ...mapping, [field]: e.currentTarget.value })
+  }
+
+  const submit = async () => {
+    const content = file ? await fileToBase64(file) : ""
+    const result = await actions.startImport(source, file ? file.name : "", content, source === "csv" ? mapping : {})
+    if (result.ok) {
+      setError(undefined)
+      props.onStart(result.data)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  return (
+    <Form error={error}>
+      <Select field="source" label="Exported from" defaultValue={source} options={sources} onChange={selectSource} />
+      <Field field="content" label="File">
+        <input id="input-content" type="file" accept=".json,.csv,application/json,text/csv" onChange={selectFile} />
+      </Field>
+      {source === "csv" && (
+        <Field field="mapping" label="Column of each field">
+          <VStack spacing={2}>
+            {props.fields.map((field) => (
+              <div key={field}>
+                <label htmlFor={`input-mapping-${field}`}>{fieldLabels[field] || field}</label>
+                <input id={`input-mapping-${field}`} type="text" value={mapping[field] || ""} onChange={setColumn(field)} />
+              </div>
+            ))}
+          </VStack>
+        </Field>
+      )}
+      <Button variant="primary" onClick={submit} disabled={!file}>
+        Import
+      </Button>
+    </Form>
+  )
+}
+
+export default class ImportPage extends AdminBasePage<ImportPageProps, { jobs: ImportJob[] }> {
+  public id = "p-admin-import"
+  public name = "import"
+  public title = "Import"
+  public subtitle = "Bring your feedback from other tools"
+
+  constructor(props: ImportPageProps) {
+    super(props)
+    this.state = { jobs: props.jobs }
+  }
+
+  private addJob = (job: ImportJob) => {
+    this.setState({ jobs: [job, ...this.state.jobs] })
+  }
+
+  private updateJob = (job: ImportJob) => {
+    this.setState({ jobs: this.state.jobs.map((j) => (j.id === job.id ? job : j)) })
+  }
+
+  public content() {
+    return (
+      <>
+        <h2 className="text-display">Import posts</h2>
+        <p className="text-muted">
+          Upload a file exported from Canny, UserVoice or any tool that exports CSV files. Users, posts, comments, votes, tags and statuses are created with
+          their original authors and dates. Importing the same file again updates the posts instead of duplicating them. UserVoice exports suggestions,
+          comments and supporters on separate files, import the suggestions first.
+        </p>
+        <StartImportForm fields={this.props.fields} onStart={this.addJob} />
+
+        {this.state.jobs.length > 0 && (
+          <div className="mt-8">
+            <h2 className="text-display">Recent imports</h2>
+            {this.state.jobs.map((job) => (
+              <ImportJobItem key={job.id} job={job} onChange={this.updateJob} />
+            ))}
+          </div>
+        )}
+      </>
+    )
+  }
+}
@@ -1,7 +1,7 @@
 import React from "react"
 
 import { Button, OAuthProviderLogo, Icon, Field, Toggle, Form } from "@fider/components"
-import { OAuthConfig, OAuthProviderOption } from "@fider/models"
+import { OAuthConfig, OAuthProviderOption, Permission } from "@fider/models"
 import { OAuthForm } from "../components/OAuthForm"
 import { actions, notify, Fider, Failure } from "@fider/services"
 import { AdminBasePage } from "../components/AdminBasePage"
@@ -18,6 +18,7 @@ interface ManageAuthenticationPageProps {
 interface ManageAuthenticationPageState {
   isAdding: boolean
   isEmailAuthAllowed: boolean
+  isTwoFactorRequired: boolean
   canDisableEmailAuth: boolean
   editing?: OAuthConfig
   error?: Failure
@@ -34,6 +35,7 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
     this.state = {
       isAdding: false,
       isEmailAuthAllowed: Fider.session.tenant.isEmailAuthAllowed,
+      isTwoFactorRequired: Fider.session.tenant.isTwoFactorRequired,
       canDisableEmailAuth: props.providers.map((o) => o.isEnabled).reduce((a, b) => a || b, false),
     }
   }
@@ -82,6 +84,16 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
     )
   }
 
+  private toggleTwoFactorRequired = async (active: boolean) => {
+    this.setState({ isTwoFactorRequired: active })
+    const response = await actions.updateTenantTwoFactorRequired(active)
+    if (response.ok) {
+      notify.success(`You successfully changed two-factor authentication setting.`)
+    } else {
+      this.setState({ isTwoFactorRequired: !active, error: response.error })
+    }
+  }
+
   private toggleSystemProvider = async (provider: OAuthProviderOption, active: boolean) => {
     const response = await actions.setSystemProviderStatus(provider.provider, active)
     if (response.ok) {
@@ -119,7 +131,7 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
               <Toggle
                 field="isEmailAuthAllowed"
                 label={this.state.isEmailAuthAllowed ? "Yes" : "No"}
-                disabled={!Fider.session.user.isAdministrator || !this.state.canDisableEmailAuth}
+                disabled={!Fider.session.hasPermission(Permission.ManageAuthentication) || !this.state.canDisableEmailAuth}
                 active={this.state.isEmailAuthAllowed}
                 onToggle={this.toggleEmailAuth}
               />
@@ -132,6 +144,21 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
               </p>
               <p className="text-muted mt-1">Note: Administrator accounts will still be allowed to sign in using their email.</p>
             </Field>
+            <Field label="Require Two-Factor Authentication for staff" className="mt-2">
+              <Toggle
+                field="isTwoFactorRequired"
+                label={this.state.isTwoFactorRequired ? "Yes" : "No"}
+                disabled={!Fider.session.hasPermission(Permission.ManageAuthentication)}
+                active={this.state.isTwoFactorRequired}
+                onToggle={this.toggleTwoFactorRequired}
+              />
+              <p className="text-muted my-1">
+                When enabled, collaborators, administrators and members with a custom role that grants access to the administration must verify a code from an
+                authenticator app every time they sign in. Members that haven&apos;t set up two-factor authentication yet will be asked to do so on their next
+                sign in.
+              </p>
+              <p className="text-muted mt-1">You need to enable two-factor authentication on your own account before requiring it.</p>
+            </Field>
           </Form>
         </div>
         <div>
@@ -152,7 +179,7 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
                     <strong>{o.displayName}</strong>
                   </HStack>
                   <HStack>
-                    {o.isCustomProvider && Fider.session.user.isAdministrator && (
+                    {o.isCustomProvider && Fider.session.hasPermission(Permission.ManageAuthentication) && (
                       <>
                         <Button onClick={this.edit.bind(this, o.provider)} size="small">
                           <Icon sprite={IconPencilAlt} />
@@ -164,7 +191,7 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
                         </Button>
                       </>
                     )}
-                    {!o.isCustomProvider && o.clientID && Fider.session.user.isAdministrator && (
+                    {!o.isCustomProvider && o.clientID && Fider.session.hasPermission(Permission.ManageAuthentication) && (
                       <Toggle
                         field={`provider-${o.provider}`}
                         label={o.isEnabled ? "Enabled" : "Disabled"}
@@ -188,7 +215,7 @@ export default class ManageAuthenticationPage extends AdminBasePage<ManageAuthen
               </div>
             ))}
             <div>
-              {Fider.session.user.isAdministrator && (
+              {Fider.session.hasPermission(Permission.ManageAuthentication) && (
                 <Button variant="secondary" onClick={this.addNew}>
                   Add new
                 </Button>
@@ -0,0 +1,117 @@
+import React, { useState } from "react"
+
+import { Button } from "@fider/components"
+import { VStack } from "@fider/components/layout"
+import { Job, JobRun } from "@fider/models"
+import { actions, Fider, formatDate } from "@fider/services"
+import { AdminPageContainer } from "../components/AdminBasePage"
+
+interface ManageJobsPageProps {
+  jobs: Job[]
+}
+
+const formatDuration = (ms: number) => (ms < 1000 ? `${ms}ms` : `${(ms / 1000).toFixed(1)}s`)
+
+const JobRunItem = (props: { run: JobRun }) => {
+  const { run } = props
+  return (
+    <li>
+      {formatDate(Fider.currentLocale, run.startedAt)} · {run.trigger} · {run.status} in {formatDuration(run.durationMs)}
+      {run.error && <div className="text-red-700">{run.error}</div>}
+    </li>
+  )
+}
+
+const JobItem = (props: { job: Job; onChange: () => void }) => {
+  const { job } = props
+  const [showHistory, setShowHistory] = useState(false)
+  const lastRun = job.runs.length > 0 ? job.runs[0] : undefined
+
+  const run = async () => {
+    const result = await actions.runJob(job.name)
+    if (result.ok) {
+      props.onChange()
+    }
+  }
+
+  const togglePause = async () => {
+    const result = job.isPaused ? await actions.resumeJob(job.name) : await actions.pauseJob(job.name)
+    if (result.ok) {
+      props.onChange()
+    }
+  }
+
+  return (
+    <div className="border-b border-gray-200 py-3 px-4 bg-white text-sm">
+      <div>
+        <span className="text-semibold">{job.name}</span>{" "}
+        {job.isPaused && <span className="text-xs bg-gray-100 text-gray-800 px-2 py-1 rounded">paused</span>}
+      </div>
+      <div className="text-muted text-xs">
+        <code>{job.schedule}</code>
+        {job.schedule !== job.defaultSchedule && <> (overrides {job.defaultSchedule})</>}
+      </div>
+      <div className="text-muted">
+        Last success: {job.lastSuccessfulRun ? formatDate(Fider.currentLocale, job.lastSuccessfulRun) : "never"}
+        {lastRun && <> · Last run took {formatDuration(lastRun.durationMs)}</>}
+      </div>
+      {job.lastFailedRun && (
+        <div className="text-muted">
+          Last failure: {formatDate(Fider.currentLocale, job.lastFailedRun)}
+          {job.lastError && <div className="text-red-700">{job.lastError}</div>}
+        </div>
+      )}
+      <div className="mt-2">
+        <Button size="small" variant="secondary" onClick={run}>
+          Run now
+        </Button>{" "}
+        <Button size="small" variant="tertiary" onClick={togglePause}>
+          {job.isPaused ? "Resume" : "Pause"}
+        </Button>{" "}
+        {job.runs.length > 0 && (
+          <Button size="small" variant="tertiary" onClick={() => setShowHistory(!showHistory)}>
+            {showHistory ? "Hide history" : "Show history"}
+          </Button>
+        )}
+      </div>
+      {showHistory && (
+        <ul className="text-xs">
+          {job.runs.map((r) => (
+            <JobRunItem key={r.id} run={r} />
+          ))}
+        </ul>
+      )}
+    </div>
+  )
+}
+
+const ManageJobsPage = (props: ManageJobsPageProps) => {
+  const [jobs, setJobs] = useState(props.jobs)
+
+  const refresh = async () => {
+    const result = await actions.listJobs()
+    if (result.ok) {
+      setJobs(result.data)
+    }
+  }
+
+  return (
+    <AdminPageContainer id="p-admin-jobs" name="jobs" title="Jobs" subtitle="Follow and control the scheduled jobs">
+      <VStack spacing={4}>
+        <p className="text-muted">
+          Scheduled jobs run in background for the whole instance. Paused jobs are not run by their schedule, but can still be run on demand. Schedules can
+          be overridden with the JOBS_SCHEDULES environment variable.
+        </p>
+        <div>
+          {jobs.length === 0 ? (
+            <p className="text-muted">There aren’t any scheduled jobs.</p>
+          ) : (
+            jobs.map((job) => <JobItem key={job.name} job={job} onChange={refresh} />)
+          )}
+        </div>
+      </VStack>
+    </AdminPageContainer>
+  )
+}
+
+export default ManageJobsPage
@@ -1,6 +1,6 @@
 import React, { useState, useEffect, useCallback } from "react"
 import { Input, Avatar, Icon, Dropdown, Pagination } from "@fider/components"
-import { User, UserRole, UserStatus } from "@fider/models"
+import { Permission, Role, User, UserRole, UserStatus } from "@fider/models"
 import IconSearch from "@fider/assets/images/heroicons-search.svg"
 import IconX from "@fider/assets/images/heroicons-x.svg"
 import IconDotsHorizontal from "@fider/assets/images/heroicons-dots-horizontal.svg"
@@ -11,12 +11,15 @@ import { HStack, VStack } from "@fider/components/layout"
 
 interface ManageMembersPageProps {
   users: User[]
+  roles: Role[]
   totalPages: number
 }
 
 interface UserListItemProps {
   user: User
+  roles: Role[]
   onAction: (actionName: string, user: User) => Promise<void>
+  onAssignRole: (user: User, role?: Role) => Promise<void>
 }
 
 interface UserListItemExtendedProps extends UserListItemProps {
@@ -30,7 +33,10 @@ const UserListItem = (props: UserListItemExtendedProps) => {
   const trusted = props.user.status === UserStatus.Active && props.user.role === UserRole.Visitor && props.user.isTrusted && (
     <span className="text-xs bg-green-100 text-green-800 px-2 py-1 rounded">trusted member</span>
   )
+  const customRole = props.user.customRole && <span className="text-xs bg-yellow-100 text-yellow-800 px-2 py-1 rounded">{props.user.customRole.name}</span>
   const isMember = props.user.role === UserRole.Visitor
+  const canManageUsers = Fider.session.hasPermission(Permission.ManageUsers) && (Fider.session.user.role === UserRole.Administrator || !admin)
+  const canAssignRoles = Fider.session.hasPermission(Permission.ManageRoles) && !admin && props.roles.length > 0
 
   const actionSelected = (actionName: string) => () => {
     props.onAction(actionName, props.user)
@@ -51,23 +57,33 @@ const UserListItem = (props: UserListItemExtendedProps) => {
       </div>
 
       <div>
-        {admin} {collaborator} {blocked} {trusted}
+        {admin} {collaborator} {customRole} {blocked} {trusted}
         {isMember && !blocked && !trusted && <span className="text-xs text-gray-600">member</span>}
       </div>
 
       <div className="flex justify-end relative">
-        {Fider.session.user.id !== props.user.id && Fider.session.user.isAdministrator && (
+        {Fider.session.user.id !== props.user.id && (canManageUsers || canAssignRoles) && (
           <div className="relative z-10">
             <Dropdown renderHandle={<Icon sprite={IconDotsHorizontal} width="16" height="16" />}>
-              {!blocked && (!!collaborator || isMember) && (
+              {canAssignRoles &&
+                props.roles
+                  .filter((role) => !props.user.customRole || props.user.customRole.id !== role.id)
+                  .map((role) => (
+                    <Dropdown.ListItem key={role.id} onClick={() => props.onAssignRole(props.user, role)}>
+                      Assign role: {role.name}
+                    </Dropdown.ListItem>
+                  ))}
+              {canAssignRoles && !!customRole && <Dropdown.ListItem onClick={() => props.onAssignRole(props.user)}>Remove custom role</Dropdown.ListItem>}
+              {canAssignRoles && canManageUsers && <Dropdown.Divider />}
+              {canManageUsers && Fider.session.user.role === UserRole.Administrator && !blocked && (!!collaborator || isMember) && (
                 <Dropdown.ListItem onClick={actionSelected("to-administrator")}>Promote to Administrator</Dropdown.ListItem>
               )}
-              {!blocked && (!!admin || isMember) && <Dropdown.ListItem onClick={actionSelected("to-collaborator")}>Promote to Collaborator</Dropdown.ListItem>}
-              {!blocked && (!!collaborator || !!admin) && <Dropdown.ListItem onClick={actionSelected("to-visitor")}>Demote to Member</Dropdown.ListItem>}
-              {isMember && !blocked && !props.user.isTrusted && <Dropdown.ListItem onClick={actionSelected("approve")}>Trust User</Dropdown.ListItem>}
-              {isMember && !blocked && props.user.isTrusted && <Dropdown.ListItem onClick={actionSelected("unapprove")}>Untrust User</Dropdown.ListItem>}
-              {isMember && !blocked && <Dropdown.ListItem onClick={actionSelected("block")}>Block User</Dropdown.ListItem>}
-              {isMember && !!blocked && <Dropdown.ListItem onClick={actionSelected("unblock")}>Unblock User</Dropdown.ListItem>}
+              {canManageUsers && !blocked && (!!admin || isMember) && <Dropdown.ListItem onClick={actionSelected("to-collaborator")}>Promote to Collaborator</Dropdown.ListItem>}
+              {canManageUsers && !blocked && (!!collaborator || !!admin) && <Dropdown.ListItem onClick={actionSelected("to-visitor")}>Demote to Member</Dropdown.ListItem>}
+              {canManageUsers && isMember && !blocked && !props.user.isTrusted && <Dropdown.ListItem onClick={actionSelected("approve")}>Trust User</Dropdown.ListItem>}
+              {canManageUsers && isMember && !blocked && props.user.isTrusted && <Dropdown.ListItem onClick={actionSelected("unapprove")}>Untrust User</Dropdown.ListItem>}
+              {canManageUsers && isMember && !blocked && <Dropdown.ListItem onClick={actionSelected("block")}>Block User</Dropdown.ListItem>}
+              {canManageUsers && isMember && !!blocked && <Dropdown.ListItem onClick={actionSelected("unblock")}>Unblock User</Dropdown.ListItem>}
             </Dropdown>
           </div>
         )}
@@ -167,6 +183,7 @@ export default function ManageMembersPage(props: ManageMembersPageProps) {
         const result = await actions.changeUserRole(user.id, role)
         if (result.ok) {
           user.role = role
+          user.customRole = undefined
           // Update the user in current state without full reload
           const updatedUsers = users.map((u) => (u.id === user.id ? user : u))
           setUsers(updatedUsers)
@@ -214,6 +231,19 @@ export default function ManageMembersPage(props: ManageMembersPageProps) {
     [users]
   )
 
+  const handleAssignRole = useCallback(
+    async (user: User, role?: Role) => {
+      const result = await actions.assignCustomRole(user.id, role ? role.id : 0)
+      if (result.ok) {
+        user.customRole = role
+        // Update the user in current state without full reload
+        const updatedUsers = users.map((u) => (u.id === user.id ? user : u))
+        setUsers(updatedUsers)
+      }
+    },
+    [users]
+  )
+
   return (
     <AdminPageContainer id="p-admin-members" name="members" title="Members" subtitle="Manage your site administrators and collaborators">
       <div className="flex gap-4 flex-items-center mb-4">
@@ -262,7 +292,7 @@ export default function ManageMembersPage(props: ManageMembersPageProps) {
         </div>
         <div>
           {users.map((user, index) => (
-            <UserListItem key={user.id} user={user} onAction={handleAction} isLast={index === users.length - 1} />
+            <UserListItem key={user.id} user={user} roles={props.roles || []} onAction={handleAction} onAssignRole={handleAssignRole} isLast={index === users.length - 1} />
           ))}
         </div>
       </VStack>
@@ -278,6 +308,9 @@ export default function ManageMembersPage(props: ManageMembersPageProps) {
         <li>
           <strong>Collaborators</strong> can edit and manage content, but not permissions and settings.
         </li>
+        <li>
+          <strong>Custom roles</strong> replace the permissions of members and collaborators with the ones defined on the Roles page.
+        </li>
         <li>
           <strong>Blocked</strong> users are unable to sign into this site.
         </li>
@@ -0,0 +1,162 @@
+import React, { useState } from "react"
+import { Button, Checkbox, Field, Form, Input, TextArea } from "@fider/components"
+import { Permission, Role } from "@fider/models"
+import { actions, Failure, Fider } from "@fider/services"
+import { AdminPageContainer } from "../components/AdminBasePage"
+import { HStack, VStack } from "@fider/components/layout"
+
+interface ManageRolesPageProps {
+  roles: Role[]
+  permissions: Permission[]
+  presets: { [role: string]: Permission[] }
+}
+
+interface RoleFormProps {
+  role?: Role
+  permissions: Permission[]
+  onSave: (name: string, description: string, permissions: Permission[]) => Promise<Failure | undefined>
+  onCancel: () => void
+}
+
+const RoleForm = (props: RoleFormProps) => {
+  const [name, setName] = useState(props.role ? props.role.name : "")
+  const [description, setDescription] = useState(props.role ? props.role.description : "")
+  const [permissions, setPermissions] = useState<Permission[]>(props.role ? props.role.permissions : [])
+  const [error, setError] = useState<Failure | undefined>(undefined)
+
+  const togglePermission = (permission: Permission) => (checked: boolean) => {
+    setPermissions(checked ? [...permissions, permission] : permissions.filter((p) => p !== permission))
+  }
+
+  const handleSave = async () => {
+    setError(await props.onSave(name, description, permissions))
+  }
+
+  return (
+    <Form error={error}>
+      <Input field="name" label="Name" maxLength={100} value={name} onChange={setName} />
+      <TextArea field="description" label="Description" minRows={2} value={description} onChange={setDescription} />
+      <Field label="Permissions">
+        <div className="grid gap-2 lg:grid-cols-3">
+          {props.permissions.map((permission) => (
+            <Checkbox
+              key={permission}
+              field={`permission-${permission}`}
+              checked={permissions.includes(permission)}
+              onChange={togglePermission(permission)}
+            >
+              {permission}
+            </Checkbox>
+          ))}
+        </div>
+      </Field>
+      <HStack>
+        <Button variant="primary" onClick={handleSave}>
+          Save
+        </Button>
+        <Button variant="tertiary" onClick={props.onCancel}>
+          Cancel
+        </Button>
+      </HStack>
+    </Form>
+  )
+}
+
+const RoleListItem = (props: { role: Role; onEdit: () => void; onDelete: () => void }) => {
+  return (
+    <div className="border-b border-gray-200 py-4 px-4 bg-white">
+      <HStack justify="between">
+        <VStack spacing={1}>
+          <span className="text-subtitle">{props.role.name}</span>
+          {props.role.description && <span className="text-muted">{props.role.description}</span>}
+          <span className="text-xs text-gray-600">{props.role.permissions.length > 0 ? props.role.permissions.join(", ") : "No permissions"}</span>
+        </VStack>
+        <HStack>
+          <Button size="small" onClick={props.onEdit}>
+            Edit
+          </Button>
+          <Button size="small" variant="danger" onClick={props.onDelete}>
+            Delete
+          </Button>
+        </HStack>
+      </HStack>
+    </div>
+  )
+}
+
+export default function ManageRolesPage(props: ManageRolesPageProps) {
+  const [roles, setRoles] = useState<Role[]>(props.roles)
+  const [isAdding, setIsAdding] = useState(false)
+  const [editing, setEditing] = useState<number | undefined>(undefined)
+
+  const grantable = props.permissions.filter((p) => Fider.session.hasPermission(p))
+
+  const create = async (name: string, description: string, permissions: Permission[]): Promise<Failure | undefined> => {
+    const result = await actions.createRole(name, description, permissions)
+    if (!result.ok) {
+      return result.error
+    }
+    setRoles([...roles, result.data].sort((a, b) => a.name.localeCompare(b.name)))
+    setIsAdding(false)
+  }
+
+  const update = (id: number) => async (name: string, description: string, permissions: Permission[]): Promise<Failure | undefined> => {
+    const result = await actions.updateRole(id, name, description, permissions)
+    if (!result.ok) {
+      return result.error
+    }
+    setRoles(roles.map((r) => (r.id === id ? result.data : r)).sort((a, b) => a.name.localeCompare(b.name)))
+    setEditing(undefined)
+  }
+
+  const remove = (role: Role) => async () => {
+    if (!window.confirm(`Delete role '${role.name}'? Members assigned to it will fall back to their built-in role.`)) {
+      return
+    }
+    const result = await actions.deleteRole(role.id)
+    if (result.ok) {
+      setRoles(roles.filter((r) => r.id !== role.id))
+    }
+  }
+
+  return (
+    <AdminPageContainer id="p-admin-roles" name="roles" title="Roles" subtitle="Define custom roles with a specific set of permissions">
+      <VStack spacing={4}>
+        <VStack className="rounded-md border border-gray-200">
+          {roles.length === 0 && <p className="text-muted p-4">No custom roles have been created yet.</p>}
+          {roles.map((role) =>
+            editing === role.id ? (
+              <div key={role.id} className="p-4 border-b border-gray-200">
+                <RoleForm role={role} permissions={grantable} onSave={update(role.id)} onCancel={() => setEditing(undefined)} />
+              </div>
+            ) : (
+              <RoleListItem key={role.id} role={role} onEdit={() => setEditing(role.id)} onDelete={remove(role)} />
+            )
+          )}
+        </VStack>
+
+        {isAdding ? (
+          <RoleForm permissions={grantable} onSave={create} onCancel={() => setIsAdding(false)} />
+        ) : (
+          <div>
+            <Button variant="secondary" onClick={() => setIsAdding(true)}>
+              Add new
+            </Button>
+          </div>
+        )}
+
+        <ul className="text-muted">
+          <li>
+            A custom role replaces the permissions of the built-in <strong>Member</strong> and <strong>Collaborator</strong> roles. Administrators always
+            have every permission.
+          </li>
+          {Object.keys(props.presets).map((role) => (
+            <li key={role}>
+              <strong className="capitalize">{role}</strong>: {props.presets[role].join(", ")}
+            </li>
+          ))}
+        </ul>
+      </VStack>
+    </AdminPageContainer>
+  )
+}
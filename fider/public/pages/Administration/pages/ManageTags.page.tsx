@@ -1,25 +1,32 @@
 import React from "react"
 import { Button } from "@fider/components"
 
-import { Tag } from "@fider/models"
+import { Permission, Role, Tag, TagGroup } from "@fider/models"
 import { actions, Failure, Fider } from "@fider/services"
 import { AdminBasePage } from "../components/AdminBasePage"
 import { TagFormState, TagForm } from "../components/TagForm"
 import { TagListItem } from "../components/TagListItem"
+import { TagGroupForm } from "../components/TagGroupForm"
+import { TagGroupListItem } from "../components/TagGroupListItem"
+import { TagGroupInput } from "@fider/services/actions"
 import { VStack } from "@fider/components/layout"
 
 interface ManageTagsPageProps {
   tags: Tag[]
+  tagGroups: TagGroup[]
+  roles: Role[]
 }
 
 interface ManageTagsPageState {
   isAdding: boolean
+  isAddingGroup: boolean
   allTags: Tag[]
+  allGroups: TagGroup[]
   deleting?: number
   editing?: number
 }
 
-const tagSorter = (t1: Tag, t2: Tag) => {
+const tagSorter = (t1: { name: string }, t2: { name: string }) => {
   if (t1.name < t2.name) {
     return -1
   } else if (t1.name > t2.name) {
@@ -38,7 +45,9 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     super(props)
     this.state = {
       isAdding: false,
+      isAddingGroup: false,
       allTags: this.props.tags,
+      allGroups: this.props.tagGroups || [],
     }
   }
 
@@ -55,7 +64,7 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
   }
 
   private saveNewTag = async (data: TagFormState): Promise<Failure | undefined> => {
-    const result = await actions.createTag(data.name, data.color, data.isPublic)
+    const result = await actions.createTag(data.name, data.color, data.isPublic, data.groupId)
     if (result.ok) {
       this.setState({
         isAdding: false,
@@ -79,9 +88,49 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     })
   }
 
+  private saveNewGroup = async (data: TagGroupInput): Promise<Failure | undefined> => {
+    const result = await actions.createTagGroup(data)
+    if (result.ok) {
+      this.setState({
+        isAddingGroup: false,
+        allGroups: this.state.allGroups.concat(result.data).sort(tagSorter),
+      })
+    } else {
+      return result.error
+    }
+  }
+
+  private handleGroupEdited = (group: TagGroup) => {
+    this.setState({
+      allGroups: this.state.allGroups.map((g) => (g.id === group.id ? group : g)).sort(tagSorter),
+    })
+  }
+
+  private handleGroupDeleted = (group: TagGroup) => {
+    this.setState({
+      allGroups: this.state.allGroups.filter((g) => g.id !== group.id),
+      allTags: this.state.allTags.map((t) => {
+        if (t.groupId === group.id) {
+          t.groupId = undefined
+        }
+        return t
+      }),
+    })
+  }
+
   private getTagList(filter: (tag: Tag) => boolean) {
     return this.state.allTags.filter(filter).map((t) => {
-      return <TagListItem key={t.id} tag={t} onTagDeleted={this.handleTagDeleted} onTagEdited={this.handleTagEdited} />
+      return (
+        <TagListItem key={t.id} tag={t} groups={this.state.allGroups} onTagDeleted={this.handleTagDeleted} onTagEdited={this.handleTagEdited} />
+      )
+    })
+  }
+
+  private getGroupList() {
+    return this.state.allGroups.map((g) => {
+      return (
+        <TagGroupListItem key={g.id} group={g} roles={this.props.roles || []} onGroupEdited={this.handleGroupEdited} onGroupDeleted={this.handleGroupDeleted} />
+      )
     })
   }
 
@@ -90,15 +139,26 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     const privateTagList = this.getTagList((t) => !t.isPublic)
 
     const form =
-      Fider.session.user.isAdministrator &&
+      Fider.session.hasPermission(Permission.ManageTags) &&
       (this.state.isAdding ? (
-        <TagForm onSave={this.saveNewTag} onCancel={this.cancelAdd} />
+        <TagForm groups={this.state.allGroups} onSave={this.saveNewTag} onCancel={this.cancelAdd} />
       ) : (
         <Button variant="secondary" onClick={this.addNew}>
           Add new
         </Button>
       ))
 
+    const groupList = this.getGroupList()
+    const groupForm =
+      Fider.session.hasPermission(Permission.ManageTags) &&
+      (this.state.isAddingGroup ? (
+        <TagGroupForm roles={this.props.roles || []} onSave={this.saveNewGroup} onCancel={() => this.setState({ isAddingGroup: false })} />
+      ) : (
+        <Button variant="secondary" onClick={() => this.setState({ isAddingGroup: true })}>
+          Add new group
+        </Button>
+      ))
+
     return (
       <VStack spacing={8}>
         <div>
@@ -116,6 +176,16 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
           </VStack>
         </div>
         <div>{form}</div>
+        <div>
+          <h2 className="text-display">Tag Groups</h2>
+          <p className="text-muted">
+            Groups organize tags, control which roles can see and assign them and whether a post can have one or many tags of the group.
+          </p>
+          <VStack spacing={4} divide={true}>
+            {groupList.length === 0 ? <p className="text-muted">There aren’t any tag groups yet.</p> : groupList}
+          </VStack>
+        </div>
+        <div>{groupForm}</div>
       </VStack>
     )
   }
@@ -1,6 +1,7 @@
 import React from "react"
 import { Toggle, Form, Field } from "@fider/components"
 import { actions, notify, Fider } from "@fider/services"
+import { Permission } from "@fider/models"
 import { AdminBasePage } from "@fider/pages/Administration/components/AdminBasePage"
 
 export interface PrivacySettingsPageState {
@@ -57,14 +58,14 @@ export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySetti
     return (
       <Form>
         <Field label="Private Site">
-          <Toggle disabled={!Fider.session.user.isAdministrator} active={this.state.isPrivate} onToggle={this.privacyToggle} />
+          <Toggle disabled={!Fider.session.hasPermission(Permission.ManageSettings)} active={this.state.isPrivate} onToggle={this.privacyToggle} />
           <p className="text-muted mt-1">
             A private site prevents unauthenticated users from viewing or interacting with its content. <br /> When enabled, only already registered users,
             invited users and users from trusted OAuth providers will have access to this site. Disables the feed feature.
           </p>
         </Field>
         <Field label="ATOM Feed">
-          <Toggle disabled={!Fider.session.user.isAdministrator || this.state.isPrivate} active={this.state.isFeedEnabled} onToggle={this.atomFeedToggle} />
+          <Toggle disabled={!Fider.session.hasPermission(Permission.ManageSettings) || this.state.isPrivate} active={this.state.isFeedEnabled} onToggle={this.atomFeedToggle} />
           <p className="text-muted mt-1">
             This feature lets users access this site via a feed reader. <br /> When enabled, the site makes its posts and comments available using the ATOM
             format. Links to feeds and autodiscovery metadata are shown on the site.
@@ -73,7 +74,7 @@ export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySetti
         {/* Moderation requires commercial plan */}
         {Fider.session.tenant.hasCommercialFeatures && (
           <Field label="Content Moderation">
-            <Toggle disabled={!Fider.session.user.isAdministrator} active={this.state.isModerationEnabled} onToggle={this.moderationToggle} />
+            <Toggle disabled={!Fider.session.hasPermission(Permission.ManageSettings)} active={this.state.isModerationEnabled} onToggle={this.moderationToggle} />
             <p className="text-muted mt-1">
               When enabled, new posts and comments will require approval from an administrator before being visible to other users. <br />
               Content creators can see their own unmoderated content, but it will be hidden from other users until approved.
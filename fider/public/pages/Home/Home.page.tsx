@@ -4,7 +4,7 @@ import IconPlusCircle from "@fider/assets/images/heroicons-pluscircle.svg"
 import IconArrowLeft from "@fider/assets/images/heroicons-arrowleft.svg"
 
 import React, { useEffect, useState, useRef } from "react"
-import { Post, Tag, PostStatus } from "@fider/models"
+import { Post, Tag, TagGroup, PostStatus, Permission } from "@fider/models"
 import { Markdown, Hint, PoweredByFider, Icon, Header, Button } from "@fider/components"
 import { PostsContainer } from "./components/PostsContainer"
 import { useFider } from "@fider/hooks"
@@ -18,6 +18,7 @@ import { PostDetails } from "@fider/components/PostDetails"
 export interface HomePageProps {
   posts: Post[]
   tags: Tag[]
+  tagGroups?: TagGroup[]
   searchNoiseWords: string[]
   countPerStatus: { [key: string]: number }
 }
@@ -31,7 +32,7 @@ const Lonely = () => {
 
   return (
     <div className="text-center">
-      <Hint permanentCloseKey="at-least-3-posts" condition={fider.session.isAuthenticated && fider.session.user.isAdministrator}>
+      <Hint permanentCloseKey="at-least-3-posts" condition={fider.session.hasPermission(Permission.ManageSettings)}>
         <p>
           <Trans id="home.lonely.suggestion">
             It&apos;s recommended that you create <strong>at least 3</strong> suggestions here before sharing this site. The initial content is important to
@@ -181,6 +182,7 @@ What can we do better? This is the place for you to vote, discuss and share idea
     <>
       <ShareFeedback
         tags={props.tags}
+        tagGroups={props.tagGroups}
         placeholder={fider.session.tenant.invitation || defaultInvitation}
         isOpen={isShareFeedbackOpen && !fider.isReadOnly}
         onClose={() => setIsShareFeedbackOpen(false)}
@@ -3,7 +3,6 @@ import { Post, Tag, CurrentUser } from "@fider/models"
 import { ShowTag, Markdown, Icon, ResponseLozenge } from "@fider/components"
 import IconChatAlt2 from "@fider/assets/images/heroicons-chat-alt-2.svg"
 import { HStack, VStack } from "@fider/components/layout"
-import { useFider } from "@fider/hooks"
 import { Trans } from "@lingui/react/macro"
 
 interface ListPostsProps {
@@ -15,9 +14,8 @@ interface ListPostsProps {
 }
 
 const ListPostItem = (props: { post: Post; user?: CurrentUser; tags: Tag[]; onPostClick?: (postNumber: number, slug: string) => void }) => {
-  const fider = useFider()
-  const isModerationEnabled = fider.session.tenant.isModerationEnabled
-  const isPending = isModerationEnabled && !props.post.isApproved
+  // content can also be held for moderation because of its spam score, even when moderation is not enabled
+  const isPending = !props.post.isApproved
 
   const handleClick = (e: React.MouseEvent<HTMLAnchorElement>) => {
     if (props.onPostClick) {
@@ -66,9 +64,8 @@ const ListPostItem = (props: { post: Post; user?: CurrentUser; tags: Tag[]; onPo
 }
 
 const MinimalListPostItem = (props: { post: Post; tags: Tag[]; onPostClick?: (postNumber: number, slug: string) => void }) => {
-  const fider = useFider()
-  const isModerationEnabled = fider.session.tenant.isModerationEnabled
-  const isPending = isModerationEnabled && !props.post.isApproved
+  // content can also be held for moderation because of its spam score, even when moderation is not enabled
+  const isPending = !props.post.isApproved
 
   const handleClick = (e: React.MouseEvent<HTMLAnchorElement>) => {
     if (props.onPostClick) {
@@ -1,5 +1,5 @@
 import React, { useState } from "react"
-import { PostStatus, Tag } from "@fider/models"
+import { Permission, PostStatus, Tag } from "@fider/models"
 import { Checkbox, Dropdown, Icon } from "@fider/components"
 import { HStack } from "@fider/components/layout"
 import HeroIconFilter from "@fider/assets/images/heroicons-filter.svg"
@@ -100,8 +100,8 @@ export const PostFilter = (props: PostFilterProps) => {
     })
   })
 
-  // Add Pending status for collaborators and admins
-  if (fider.session.isAuthenticated && fider.session.user.isCollaborator) {
+  // Add Pending status for those who can moderate content
+  if (fider.session.hasPermission(Permission.ModerateContent)) {
     options.push({
       label: "Pending",
       value: "pending",
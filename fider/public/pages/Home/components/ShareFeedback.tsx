@@ -5,10 +5,10 @@ import { SignInControl } from "@fider/components/common/SignInControl"
 import { Modal, CloseIcon, Form, Button, Input, LegalFooter } from "@fider/components/common"
 import { useFider } from "@fider/hooks"
 import { Trans } from "@lingui/react/macro"
-import { actions, Failure, querystring, classSet, cache } from "@fider/services"
+import { actions, Failure, querystring, classSet, cache, applyTagGroupSelection } from "@fider/services"
 import { plainText } from "@fider/services/markdown"
 import { i18n } from "@lingui/core"
-import { Tag } from "@fider/models"
+import { Tag, TagGroup } from "@fider/models"
 import { SimilarPosts } from "../components/SimilarPosts"
 import { TagsSelect } from "@fider/components/common/TagsSelect"
 import CommentEditor from "@fider/components/common/form/CommentEditor"
@@ -30,11 +30,17 @@ interface ShareFeedbackProps {
   placeholder: string
   onClose: () => void
   tags: Tag[]
+  tagGroups?: TagGroup[]
 }
 
 export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
   const fider = useFider()
   const { isOpen, onClose } = props
+  const tagGroups = props.tagGroups || []
+  const selectableTags = props.tags.filter((tag) => {
+    const group = tagGroups.find((g) => g.id === tag.groupId)
+    return !group || fider.session.canAssignTagGroup(group)
+  })
 
   const getTagsCachedValue = (): Tag[] => {
     if (!canEditTags) {
@@ -46,7 +52,11 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
     const combined = [...cacheValue, ...urlValue.split(",")]
     const tagsAsStrings = Array.from(new Set(combined.map((s) => s.trim()).filter((s) => s.length > 0)))
 
-    return props.tags.filter((tag) => tagsAsStrings.includes(tag.slug))
+    return applyTagGroupSelection(
+      [],
+      selectableTags.filter((tag) => tagsAsStrings.includes(tag.slug)),
+      tagGroups
+    )
   }
 
   const getTitleManuallyEditedValue = (): boolean => {
@@ -54,7 +64,7 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
     return getCachedTitle() !== getCachedDescription()
   }
 
-  const canEditTags = fider.settings.postWithTags && props.tags.length > 0
+  const canEditTags = fider.settings.postWithTags && selectableTags.length > 0
   const [title, setTitle] = useState(getCachedTitle())
   const [description, setDescription] = useState(getCachedDescription())
   const { attachments, handleImageUploaded, getImageSrc, clearAttachments } = useAttachments({
@@ -152,8 +162,9 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
   }
 
   const handleTagsChanged = (newTags: Tag[]) => {
-    setCachedTags(newTags.map((tag) => tag.slug))
-    setTags(newTags)
+    const selected = applyTagGroupSelection(tags, newTags, tagGroups)
+    setCachedTags(selected.map((tag) => tag.slug))
+    setTags(selected)
   }
 
   const handleDescriptionChange = (value: string) => {
@@ -264,7 +275,7 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
                     <Trans id="label.tags">Tags</Trans>
                   </label>
                   <div className={classSet({ "c-form-field": true })}>
-                    <TagsSelect tags={props.tags} selectionChanged={handleTagsChanged} selected={tags} alwaysEditing={true} canEdit={true} />
+                    <TagsSelect tags={selectableTags} selectionChanged={handleTagsChanged} selected={tags} alwaysEditing={true} canEdit={true} />
                   </div>
                 </div>
               )}
@@ -2,10 +2,11 @@ import React from "react"
 
 import { Modal, Form, Button, PageTitle, Input, Select, SelectOption, ImageUploader, Header } from "@fider/components"
 
-import { UserSettings, UserAvatarType, ImageUpload } from "@fider/models"
+import { UserSettings, UserAvatarType, ImageUpload, Permission } from "@fider/models"
 import { Failure, actions, Fider } from "@fider/services"
 import { NotificationSettings } from "./components/NotificationSettings"
 import { APIKeyForm } from "./components/APIKeyForm"
+import { TwoFactorForm, TwoFactorSettings } from "./components/TwoFactorForm"
 import { DangerZone } from "./components/DangerZone"
 import { i18n } from "@lingui/core"
 import { Trans } from "@lingui/react/macro"
@@ -23,6 +24,7 @@ interface MySettingsPageState {
 
 interface MySettingsPageProps {
   userSettings: UserSettings
+  twoFactor: TwoFactorSettings
 }
 
 export default class MySettingsPage extends React.Component<MySettingsPageProps, MySettingsPageState> {
@@ -216,7 +218,10 @@ export default class MySettingsPage extends React.Component<MySettingsPageProps,
               </Button>
             </Form>
 
-            <div className="mt-8">{Fider.session.user.isCollaborator && <APIKeyForm />}</div>
+            <div className="mt-8">
+              <TwoFactorForm twoFactor={this.props.twoFactor} />
+            </div>
+            <div className="mt-8">{Fider.session.hasPermission(Permission.UseAPI) && <APIKeyForm />}</div>
             <div className="mt-8">
               <DangerZone />
             </div>
@@ -0,0 +1,132 @@
+import React from "react"
+import { Button, Modal } from "@fider/components"
+import { HStack } from "@fider/components/layout"
+import { actions, notify } from "@fider/services"
+import { Trans } from "@lingui/react/macro"
+
+export interface TwoFactorSettings {
+  isEnabled: boolean
+  isRequired: boolean
+  recoveryCodesLeft?: number
+}
+
+interface TwoFactorFormProps {
+  twoFactor: TwoFactorSettings
+}
+
+interface TwoFactorFormState {
+  recoveryCodes?: string[]
+  confirmDisable: boolean
+}
+
+export class TwoFactorForm extends React.Component<TwoFactorFormProps, TwoFactorFormState> {
+  constructor(props: TwoFactorFormProps) {
+    super(props)
+    this.state = {
+      confirmDisable: false,
+    }
+  }
+
+  private enable = () => {
+    location.href = `/signin/2fa?redirect=${encodeURIComponent(location.pathname)}`
+  }
+
+  private regenerate = async () => {
+    const result = await actions.regenerateRecoveryCodes()
+    if (result.ok) {
+      this.setState({ recoveryCodes: result.data.recoveryCodes })
+    }
+  }
+
+  private disable = async () => {
+    const result = await actions.disableTwoFactor()
+    if (result.ok) {
+      location.reload()
+    } else if (result.error && result.error.errors && result.error.errors.length > 0) {
+      this.setState({ confirmDisable: false })
+      notify.error(result.error.errors[0].message)
+    }
+  }
+
+  private showRecoveryCodes(codes: string[]) {
+    return (
+      <>
+        <p className="text-muted">
+          <Trans id="mysettings.twofactor.recoverycodes.notice">
+            Store these codes somewhere safe. Each code can be used once to sign in if you lose access to your authenticator app.
+          </Trans>
+        </p>
+        <pre>{codes.join("\n")}</pre>
+      </>
+    )
+  }
+
+  public render() {
+    const { isEnabled, isRequired, recoveryCodesLeft } = this.props.twoFactor
+
+    return (
+      <div>
+        <Modal.Window isOpen={this.state.confirmDisable} center={false} onClose={() => this.setState({ confirmDisable: false })}>
+          <Modal.Header>
+            <Trans id="mysettings.twofactor.disable.header">Disable two-factor authentication</Trans>
+          </Modal.Header>
+          <Modal.Content>
+            <p>
+              <Trans id="mysettings.twofactor.disable.text">Your account will be protected only by your sign in method. Are you sure?</Trans>
+            </p>
+          </Modal.Content>
+          <Modal.Footer>
+            <Button variant="danger" size="small" onClick={this.disable}>
+              <Trans id="action.confirm">Confirm</Trans>
+            </Button>
+            <Button variant="tertiary" size="small" onClick={() => this.setState({ confirmDisable: false })}>
+              <Trans id="action.cancel">Cancel</Trans>
+            </Button>
+          </Modal.Footer>
+        </Modal.Window>
+
+        <h4 className="text-title mb-1">
+          <Trans id="mysettings.twofactor.title">Two-factor authentication</Trans>
+        </h4>
+        {isEnabled ? (
+          <>
+            <p className="text-muted">
+              <Trans id="mysettings.twofactor.enabled">
+                Two-factor authentication is enabled. You have {recoveryCodesLeft} unused recovery codes.
+              </Trans>
+            </p>
+            <HStack className="mb-4">
+              <Button size="small" onClick={this.regenerate}>
+                <Trans id="mysettings.twofactor.regenerate">Generate new recovery codes</Trans>
+              </Button>
+              {!isRequired && (
+                <Button size="small" variant="danger" onClick={() => this.setState({ confirmDisable: true })}>
+                  <Trans id="mysettings.twofactor.disable">Disable</Trans>
+                </Button>
+              )}
+            </HStack>
+            {this.state.recoveryCodes && this.showRecoveryCodes(this.state.recoveryCodes)}
+          </>
+        ) : (
+          <>
+            <p className="text-muted">
+              <Trans id="mysettings.twofactor.disabled">
+                Protect your account by also asking for a code from an authenticator app whenever you sign in.
+              </Trans>
+            </p>
+            <p>
+              <Button size="small" onClick={this.enable}>
+                <Trans id="mysettings.twofactor.enable">Enable two-factor authentication</Trans>
+              </Button>
+            </p>
+          </>
+        )}
+        {isRequired && (
+          <p className="text-muted">
+            <Trans id="mysettings.twofactor.required">Two-factor authentication is required for your account on this site.</Trans>
+          </p>
+        )}
+      </div>
+    )
+  }
+}
@@ -2,7 +2,7 @@ import "./ShowPost.page.scss"
 
 import React from "react"
 
-import { Comment, Post, Tag, Vote } from "@fider/models"
+import { Comment, Post, Tag, TagGroup, Vote } from "@fider/models"
 import { Header, PoweredByFider } from "@fider/components"
 import { PostDetails } from "@fider/components/PostDetails"
 
@@ -11,6 +11,7 @@ interface ShowPostPageProps {
   subscribed: boolean
   comments: Comment[]
   tags: Tag[]
+  tagGroups?: TagGroup[]
   votes: Vote[]
   attachments: string[]
 }
@@ -26,6 +27,7 @@ export default function ShowPostPage(props: ShowPostPageProps) {
           initialSubscribed={props.subscribed}
           initialComments={props.comments}
           initialTags={props.tags}
+          initialTagGroups={props.tagGroups}
           initialVotes={props.votes}
           initialAttachments={props.attachments}
         />
@@ -15,13 +15,15 @@ import CommentEditor from "@fider/components/common/form/CommentEditor"
 
 interface CommentInputProps {
   post: Post
+  parentId?: number
+  onCancel?: () => void
 }
 
 const CACHE_TITLE_KEY = "CommentInput-Comment-Title-"
 const CACHE_ATTACHMENTS_KEY = "CommentInput-Comment-Attachments-"
 
 export const CommentInput = (props: CommentInputProps) => {
-  const getCacheKey = (cachePrefix: string) => `${cachePrefix}${props.post.id}`
+  const getCacheKey = (cachePrefix: string) => `${cachePrefix}${props.post.id}${props.parentId ? `-${props.parentId}` : ""}`
 
   const getContentFromCache = () => {
     return cache.session.get(getCacheKey(CACHE_TITLE_KEY))
@@ -51,11 +53,11 @@ export const CommentInput = (props: CommentInputProps) => {
 
     const content = getContentFromCache()
 
-    const result = await actions.createComment(props.post.number, content || "", attachments)
+    const result = await actions.createComment(props.post.number, content || "", attachments, props.parentId)
     if (result.ok) {
       clearAttachments()
       cache.session.remove(getCacheKey(CACHE_TITLE_KEY))
-      if (fider.session.isModerationRequiredForNewPost) {
+      if (fider.session.isModerationRequiredForNewPost || !result.data.isApproved) {
         cache.session.set("COMMENT_CREATED_MODERATION", "true")
       }
       location.reload()
@@ -71,6 +73,9 @@ export const CommentInput = (props: CommentInputProps) => {
   }
 
   const hasContent = true
+  const placeholder = props.parentId
+    ? i18n._({ id: "showpost.commentinput.replyplaceholder", message: "Write a reply" })
+    : i18n._({ id: "showpost.commentinput.placeholder", message: "Leave a comment" })
 
   const commentChanged = useCallback((value: string): void => {
     cache.session.set(getCacheKey(CACHE_TITLE_KEY), value)
@@ -91,7 +96,7 @@ export const CommentInput = (props: CommentInputProps) => {
                   onChange={commentChanged}
                   onFocus={editorFocused}
                   initialValue={getContentFromCache()}
-                  placeholder={i18n._({ id: "showpost.commentinput.placeholder", message: "Leave a comment" })}
+                  placeholder={placeholder}
                   maxAttachments={2}
                   maxImageSizeKB={5 * 1024}
                   onGetImageSrc={getImageSrc}
@@ -103,11 +108,16 @@ export const CommentInput = (props: CommentInputProps) => {
                     <Button disabled={!fider.session.isAuthenticated} variant="primary" onClick={submit} className="mt-4">
                       <Trans id="action.postcomment">Post</Trans>
                     </Button>
+                    {props.onCancel && (
+                      <Button variant="tertiary" onClick={props.onCancel} className="mt-4">
+                        <Trans id="action.cancel">Cancel</Trans>
+                      </Button>
+                    )}
                   </>
                 )}
               </>
             ) : (
-              <div className="comment-input-placeholder p-2">{i18n._({ id: "showpost.commentinput.placeholder", message: "Leave a comment" })}</div>
+              <div className="comment-input-placeholder p-2">{placeholder}</div>
             )}
           </Form>
         </div>
@@ -0,0 +1,55 @@
+import React, { useState } from "react"
+import { Comment, Post } from "@fider/models"
+import { VStack } from "@fider/components/layout"
+import { useFider } from "@fider/hooks"
+import { ShowComment } from "./ShowComment"
+import { CommentInput } from "./CommentInput"
+
+import "./CommentThread.scss"
+
+interface CommentThreadProps {
+  post: Post
+  comments: Comment[]
+  highlightedComment?: number
+}
+
+export const CommentThread = (props: CommentThreadProps) => {
+  const fider = useFider()
+  const [replyingTo, setReplyingTo] = useState<number | undefined>(undefined)
+
+  const ids = new Set(props.comments.map((c) => c.id))
+  const topLevel: Comment[] = []
+  const replies = new Map<number, Comment[]>()
+  for (const c of props.comments) {
+    if (c.parentId && ids.has(c.parentId)) {
+      replies.set(c.parentId, [...(replies.get(c.parentId) || []), c])
+    } else {
+      topLevel.push(c)
+    }
+  }
+
+  const renderComment = (comment: Comment): JSX.Element => {
+    // comments come newest first, but replies are read as a conversation
+    const children = [...(replies.get(comment.id) || [])].reverse()
+    const canReply = fider.session.isAuthenticated && !comment.isRemoved
+
+    return (
+      <div key={comment.id} className="c-comment-thread">
+        <ShowComment
+          post={props.post}
+          comment={comment}
+          highlighted={props.highlightedComment === comment.id}
+          onReply={canReply ? () => setReplyingTo(comment.id) : undefined}
+        />
+        {(children.length > 0 || replyingTo === comment.id) && (
+          <VStack spacing={4} className="c-comment-thread__replies">
+            {children.map(renderComment)}
+            {replyingTo === comment.id && <CommentInput post={props.post} parentId={comment.id} onCancel={() => setReplyingTo(undefined)} />}
+          </VStack>
+        )}
+      </div>
+    )
+  }
+
+  return <VStack spacing={4}>{topLevel.map(renderComment)}</VStack>
+}
@@ -1,5 +1,5 @@
 import React, { useState } from "react"
-import { PostStatus, Post } from "@fider/models"
+import { PostStatus, Post, Permission } from "@fider/models"
 import { actions, navigator, Failure } from "@fider/services"
 import { Form, Modal, Button, TextArea } from "@fider/components"
 import { useFider } from "@fider/hooks"
@@ -28,7 +28,7 @@ export const DeletePostModal = (props: DeletePostModalProps) => {
   }
 
   const status = PostStatus.Get(props.post.status)
-  if (!fider.session.isAuthenticated || !fider.session.user.isAdministrator || status.closed) {
+  if (!fider.session.hasPermission(Permission.DeletePosts) || status.closed) {
     return null
   }
 
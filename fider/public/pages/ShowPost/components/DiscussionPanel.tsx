@@ -1,6 +1,6 @@
 import React from "react"
 import { CurrentUser, Comment, Post } from "@fider/models"
-import { ShowComment } from "./ShowComment"
+import { CommentThread } from "./CommentThread"
 import { CommentInput } from "./CommentInput"
 import { VStack } from "@fider/components/layout"
 import { Trans } from "@lingui/react/macro"
@@ -20,9 +20,7 @@ export const DiscussionPanel = (props: DiscussionPanelProps) => {
           <Trans id="label.discussion">Discussion</Trans>
         </span>
         <VStack spacing={4} className="c-comment-list">
-          {props.comments.map((c) => (
-            <ShowComment key={c.id} post={props.post} comment={c} highlighted={props.highlightedComment === c.id} />
-          ))}
+          <CommentThread post={props.post} comments={props.comments} highlightedComment={props.highlightedComment} />
           <CommentInput post={props.post} />
         </VStack>
       </VStack>
@@ -0,0 +1,80 @@
+import React, { useState } from "react"
+import { Post, Comment, ReportReason } from "@fider/models"
+import { actions, Failure, notify } from "@fider/services"
+import { Form, Modal, Button, Select, SelectOption, TextArea } from "@fider/components"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+interface ReportModalProps {
+  post: Post
+  comment?: Comment
+  showModal: boolean
+  onModalClose: () => void
+}
+
+export const ReportModal = (props: ReportModalProps) => {
+  const [reason, setReason] = useState<ReportReason>(ReportReason.Spam)
+  const [details, setDetails] = useState("")
+  const [error, setError] = useState<Failure>()
+
+  const options: SelectOption[] = [
+    { value: ReportReason.Spam, label: i18n._({ id: "showpost.report.reason.spam", message: "Spam" }) },
+    { value: ReportReason.Harassment, label: i18n._({ id: "showpost.report.reason.harassment", message: "Harassment" }) },
+    { value: ReportReason.Offensive, label: i18n._({ id: "showpost.report.reason.offensive", message: "Offensive content" }) },
+    { value: ReportReason.OffTopic, label: i18n._({ id: "showpost.report.reason.offtopic", message: "Off-topic" }) },
+    { value: ReportReason.Other, label: i18n._({ id: "showpost.report.reason.other", message: "Something else" }) },
+  ]
+
+  const close = () => {
+    setDetails("")
+    setError(undefined)
+    props.onModalClose()
+  }
+
+  const handleReport = async () => {
+    const response = props.comment
+      ? await actions.reportComment(props.post.number, props.comment.id, reason, details)
+      : await actions.reportPost(props.post.number, reason, details)
+
+    if (response.ok) {
+      close()
+      notify.success(<Trans id="showpost.report.success">Thanks, the moderators will review your report.</Trans>)
+    } else if (response.error) {
+      setError(response.error)
+    }
+  }
+
+  return (
+    <Modal.Window isOpen={props.showModal} onClose={close} center={false} size="small">
+      <Modal.Header>
+        {props.comment ? <Trans id="showpost.report.comment.header">Report comment</Trans> : <Trans id="showpost.report.post.header">Report post</Trans>}
+      </Modal.Header>
+      <Modal.Content>
+        <Form error={error}>
+          <Select
+            field="reason"
+            label={i18n._({ id: "showpost.report.reason.label", message: "Reason" })}
+            defaultValue={reason}
+            options={options}
+            onChange={(o?: SelectOption) => setReason((o?.value as ReportReason) || ReportReason.Spam)}
+          />
+          <TextArea
+            field="details"
+            onChange={setDetails}
+            value={details}
+            placeholder={i18n._({ id: "showpost.report.details.placeholder", message: "Anything the moderators should know? (optional)" })}
+          />
+        </Form>
+      </Modal.Content>
+
+      <Modal.Footer>
+        <Button variant="danger" onClick={handleReport}>
+          <Trans id="action.report">Report</Trans>
+        </Button>
+        <Button variant="tertiary" onClick={close}>
+          <Trans id="action.cancel">Cancel</Trans>
+        </Button>
+      </Modal.Footer>
+    </Modal.Window>
+  )
+}
@@ -1,6 +1,6 @@
 import React, { useEffect, useRef, useState } from "react"
-import { Comment, Post } from "@fider/models"
-import { Reactions, Avatar, UserName, Moment, Form, Button, Markdown, Modal, Dropdown, Icon } from "@fider/components"
+import { Comment, Permission, Post } from "@fider/models"
+import { Reactions, applyReactionToggle, Avatar, UserName, Moment, Form, Button, Markdown, Modal, Dropdown, Icon } from "@fider/components"
 import { HStack } from "@fider/components/layout"
 import { formatDate, Failure, actions, notify, copyToClipboard, classSet, clearUrlHash } from "@fider/services"
 import { useFider } from "@fider/hooks"
@@ -9,6 +9,7 @@ import { t } from "@lingui/core/macro"
 import { Trans } from "@lingui/react/macro"
 import CommentEditor from "@fider/components/common/form/CommentEditor"
 import { useAttachments } from "@fider/hooks/useAttachments"
+import { ReportModal } from "./ReportModal"
 
 import "./ShowComment.scss"
 
@@ -17,6 +18,7 @@ interface ShowCommentProps {
   comment: Comment
   highlighted?: boolean
   onToggleReaction?: () => void
+  onReply?: () => void
 }
 
 export const ShowComment = (props: ShowCommentProps) => {
@@ -25,6 +27,7 @@ export const ShowComment = (props: ShowCommentProps) => {
   const [isEditing, setIsEditing] = useState(false)
   const [newContent, setNewContent] = useState<string>(props.comment.content)
   const [isDeleteConfirmationModalOpen, setIsDeleteConfirmationModalOpen] = useState(false)
+  const [isReportModalOpen, setIsReportModalOpen] = useState(false)
   const { attachments, handleImageUploaded, getImageSrc } = useAttachments({
     maxAttachments: 2,
   })
@@ -50,11 +53,15 @@ export const ShowComment = (props: ShowCommentProps) => {
 
   const canEditComment = (): boolean => {
     if (fider.session.isAuthenticated) {
-      return fider.session.user.isCollaborator || props.comment.user.id === fider.session.user.id
+      return fider.session.hasPermission(Permission.EditComments) || props.comment.user.id === fider.session.user.id
     }
     return false
   }
 
+  const canReportComment = (): boolean => {
+    return fider.session.isAuthenticated && props.comment.user.id !== fider.session.user.id
+  }
+
   const clearError = () => setError(undefined)
 
   const cancelEdit = async () => {
@@ -108,25 +115,7 @@ export const ShowComment = (props: ShowCommentProps) => {
     if (response.ok) {
       const added = response.data.added
 
-      setLocalReactionCounts((prevCounts) => {
-        const newCounts = [...(prevCounts ?? [])]
-        const reactionIndex = newCounts.findIndex((r) => r.emoji === emoji)
-        if (reactionIndex !== -1) {
-          const newCount = added ? newCounts[reactionIndex].count + 1 : newCounts[reactionIndex].count - 1
-          if (newCount === 0) {
-            newCounts.splice(reactionIndex, 1)
-          } else {
-            newCounts[reactionIndex] = {
-              ...newCounts[reactionIndex],
-              count: newCount,
-              includesMe: added,
-            }
-          }
-        } else if (added) {
-          newCounts.push({ emoji, count: 1, includesMe: true })
-        }
-        return newCounts
-      })
+      setLocalReactionCounts((prevCounts) => applyReactionToggle(prevCounts, emoji, added))
     }
   }
 
@@ -142,6 +131,8 @@ export const ShowComment = (props: ShowCommentProps) => {
       clearError()
     } else if (action === "delete") {
       setIsDeleteConfirmationModalOpen(true)
+    } else if (action === "report") {
+      setIsReportModalOpen(true)
     }
   }
 
@@ -173,6 +164,17 @@ export const ShowComment = (props: ShowCommentProps) => {
 
   const comment = props.comment
 
+  // Removed comments are only kept to show their replies in place
+  if (comment.isRemoved) {
+    return (
+      <div id={`comment-${comment.id}`} className="c-comment">
+        <div className="c-comment__card c-comment__card--removed">
+          <Trans id="showpost.comment.removed">This comment was removed.</Trans>
+        </div>
+      </div>
+    )
+  }
+
   const editedMetadata = !!comment.editedAt && !!comment.editedBy && (
     <span data-tooltip={`This comment has been edited by ${comment.editedBy.name} on ${formatDate(fider.currentLocale, comment.editedAt)}`}>· edited</span>
   )
@@ -185,6 +187,9 @@ export const ShowComment = (props: ShowCommentProps) => {
   return (
     <div id={`comment-${comment.id}`} className="c-comment">
       {modal()}
+      {canReportComment() && (
+        <ReportModal post={props.post} comment={props.comment} showModal={isReportModalOpen} onModalClose={() => setIsReportModalOpen(false)} />
+      )}
       <HStack spacing={4} align="start">
         <Avatar user={comment.user} size="large" />
         <div ref={node} className={`c-comment__card ${classList}`}>
@@ -212,6 +217,14 @@ export const ShowComment = (props: ShowCommentProps) => {
                       </Dropdown.ListItem>
                     </>
                   )}
+                  {canReportComment() && (
+                    <>
+                      <Dropdown.Divider />
+                      <Dropdown.ListItem onClick={onActionSelected("report")}>
+                        <Trans id="action.report">Report</Trans>
+                      </Dropdown.ListItem>
+                    </>
+                  )}
                 </Dropdown>
               )}
             </HStack>
@@ -244,7 +257,7 @@ export const ShowComment = (props: ShowCommentProps) => {
                 <Markdown text={comment.content} style="full" />
 
                 {/* Moderation status banner for unapproved comments */}
-                {fider.session.tenant.isModerationEnabled && !comment.isApproved && (
+                {!comment.isApproved && (
                   <div className="mt-3">
                     {fider.session.isAuthenticated && fider.session.user.id === comment.user.id && (
                       <div className="text-muted text-xs p-2 bg-yellow-50 rounded-md border-yellow-500">
@@ -253,7 +266,7 @@ export const ShowComment = (props: ShowCommentProps) => {
                     )}
 
                     {/* Admin moderation buttons */}
-                    {fider.session.isAuthenticated && fider.session.user.isCollaborator && (
+                    {fider.session.hasPermission(Permission.ModerateContent) && (
                       <div className="p-2 bg-blue-50 rounded border-l-4 border-blue-500">
                         <div className="mb-1 text-xs font-medium text-blue-800">
                           <Trans id="home.postfilter.label.moderation">Moderation</Trans>
@@ -274,7 +287,18 @@ export const ShowComment = (props: ShowCommentProps) => {
                   </div>
                 )}
 
-                <Reactions reactions={localReactionCounts} emojiSelectorRef={emojiSelectorRef} toggleReaction={toggleReaction} />
+                <Reactions
+                  reactions={localReactionCounts}
+                  emojiSelectorRef={emojiSelectorRef}
+                  toggleReaction={toggleReaction}
+                  listReactions={() => actions.listReactions(props.post.number, comment.id)}
+                />
+
+                {props.onReply && (
+                  <Button variant="tertiary" size="small" onClick={props.onReply} className="mt-2">
+                    <Trans id="action.reply">Reply</Trans>
+                  </Button>
+                )}
               </>
             )}
           </div>
@@ -1,6 +1,6 @@
 import React, { useState } from "react"
-import { Post, Tag } from "@fider/models"
-import { actions } from "@fider/services"
+import { Permission, Post, Tag, TagGroup } from "@fider/models"
+import { actions, applyTagGroupSelection } from "@fider/services"
 import { useFider } from "@fider/hooks"
 import { TagsSelect } from "@fider/components/common/TagsSelect"
 
@@ -8,15 +8,25 @@ export interface TagsPanelProps {
   onDataChanged?: () => void
   post: Post
   tags: Tag[]
+  tagGroups?: TagGroup[]
 }
 
 export const TagsPanel = (props: TagsPanelProps) => {
   const fider = useFider()
-  const canEdit = fider.session.isAuthenticated && fider.session.user.isCollaborator && props.tags.length > 0
+  const tagGroups = props.tagGroups || []
+  const assignableTags = props.tags.filter((t) => {
+    const group = tagGroups.find((g) => g.id === t.groupId)
+    return !group || fider.session.canAssignTagGroup(group)
+  })
+  const canEdit = fider.session.hasPermission(Permission.AssignTags) && assignableTags.length > 0
 
   const [assignedTags, setAssignedTags] = useState(props.tags.filter((t) => props.post.tags.indexOf(t.slug) >= 0))
 
-  const assignOrUnassignTag = async (tags: Tag[]) => {
+  const assignOrUnassignTag = async (selected: Tag[]) => {
+    // Tags of groups the user cannot assign are kept untouched
+    const locked = assignedTags.filter((t) => !assignableTags.includes(t))
+    const tags = [...locked, ...applyTagGroupSelection(assignedTags, selected, tagGroups).filter((t) => !locked.includes(t))]
+
     await Promise.all([
       ...tags.filter((t) => !assignedTags.includes(t)).map((t) => actions.assignTag(t.slug, props.post.number)),
       ...assignedTags.filter((t) => !tags.includes(t)).map((t) => actions.unassignTag(t.slug, props.post.number)),
@@ -26,5 +36,5 @@ export const TagsPanel = (props: TagsPanelProps) => {
     props.onDataChanged?.()
   }
 
-  return <TagsSelect tags={props.tags} selected={assignedTags} canEdit={canEdit} selectionChanged={assignOrUnassignTag} asLinks />
+  return <TagsSelect tags={assignableTags} selected={assignedTags} canEdit={canEdit} selectionChanged={assignOrUnassignTag} asLinks />
 }
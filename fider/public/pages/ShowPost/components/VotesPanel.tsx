@@ -1,7 +1,7 @@
 import "./VotesPanel.scss"
 
 import React, { useState } from "react"
-import { Post, Vote } from "@fider/models"
+import { Permission, Post, Vote } from "@fider/models"
 import { Avatar, Icon } from "@fider/components"
 import { Fider } from "@fider/services"
 import { useFider } from "@fider/hooks"
@@ -21,7 +21,7 @@ const MAX_AVATARS_SHOWN = 12
 export const VotesPanel = (props: VotesPanelProps) => {
   const fider = useFider()
   const [isVotesModalOpen, setIsVotesModalOpen] = useState(false)
-  const canShowAll = fider.session.isAuthenticated && Fider.session.user.isCollaborator
+  const canShowAll = fider.session.hasPermission(Permission.ViewUsers)
 
   const openModal = () => {
     if (canShowAll) {
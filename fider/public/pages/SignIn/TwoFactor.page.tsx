@@ -0,0 +1,141 @@
+import React, { useState } from "react"
+
+import { Button, Form, Input, TenantLogo } from "@fider/components"
+import { actions, Failure } from "@fider/services"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+import "./TwoFactor.page.scss"
+
+interface TwoFactorPageProps {
+  isEnabled: boolean
+  redirect: string
+  secret?: string
+  uri?: string
+}
+
+const TwoFactorPage = (props: TwoFactorPageProps) => {
+  const [code, setCode] = useState("")
+  const [useRecoveryCode, setUseRecoveryCode] = useState(false)
+  const [recoveryCodes, setRecoveryCodes] = useState<string[] | undefined>()
+  const [error, setError] = useState<Failure | undefined>()
+
+  const submit = async () => {
+    const result = useRecoveryCode ? await actions.verifyTwoFactor("", code) : await actions.verifyTwoFactor(code, "")
+    if (result.ok) {
+      if (result.data && result.data.recoveryCodes) {
+        setRecoveryCodes(result.data.recoveryCodes)
+      } else {
+        location.href = props.redirect
+      }
+    } else if (result.error) {
+      setError(result.error)
+    }
+  }
+
+  const toggleRecoveryCode = () => {
+    setCode("")
+    setError(undefined)
+    setUseRecoveryCode(!useRecoveryCode)
+  }
+
+  const renderRecoveryCodes = (codes: string[]) => (
+    <>
+      <p className="text-title text-center">
+        <Trans id="signin.twofactor.recoverycodes.header">Save your recovery codes</Trans>
+      </p>
+      <p>
+        <Trans id="signin.twofactor.recoverycodes.text">
+          Each code can be used once to sign in if you lose access to your authenticator app. They will not be shown again.
+        </Trans>
+      </p>
+      <pre className="mb-4">{codes.join("\n")}</pre>
+      <Button variant="primary" onClick={() => (location.href = props.redirect)}>
+        <Trans id="action.continue">Continue</Trans>
+      </Button>
+    </>
+  )
+
+  const renderForm = () => (
+    <>
+      <p className="text-title text-center">
+        <Trans id="signin.twofactor.header">Two-factor authentication</Trans>
+      </p>
+
+      {props.isEnabled ? (
+        <p>
+          {useRecoveryCode ? (
+            <Trans id="signin.twofactor.recoverycode.text">Enter one of the recovery codes you saved when you set up two-factor authentication.</Trans>
+          ) : (
+            <Trans id="signin.twofactor.text">Enter the code shown by your authenticator app.</Trans>
+          )}
+        </p>
+      ) : (
+        <>
+          <p>
+            <Trans id="signin.twofactor.setup.text">
+              Two-factor authentication protects your account with a code from an authenticator app. Add the key below to your app and enter the code it
+              shows.
+            </Trans>
+          </p>
+          <p>
+            <code>{props.secret}</code>
+          </p>
+          <p>
+            <a className="text-link" href={props.uri}>
+              <Trans id="signin.twofactor.setup.open">Open in authenticator app</Trans>
+            </a>
+          </p>
+        </>
+      )}
+
+      <Form error={error} className="mb-4">
+        <Input
+          field="code"
+          value={code}
+          onChange={setCode}
+          maxLength={useRecoveryCode ? 20 : 6}
+          autoFocus={true}
+          placeholder={
+            useRecoveryCode
+              ? i18n._({ id: "signin.twofactor.recoverycode.placeholder", message: "Recovery code" })
+              : i18n._({ id: "signin.twofactor.code.placeholder", message: "6-digit code" })
+          }
+          suffix={
+            <Button type="submit" onClick={submit} variant="primary" disabled={code === ""}>
+              <Trans id="action.submit">Submit</Trans>
+            </Button>
+          }
+        />
+      </Form>
+
+      {props.isEnabled && (
+        <Button variant="link" onClick={toggleRecoveryCode}>
+          {useRecoveryCode ? (
+            <Trans id="signin.twofactor.usecode">Use your authenticator app instead</Trans>
+          ) : (
+            <Trans id="signin.twofactor.userecoverycode">Use a recovery code instead</Trans>
+          )}
+        </Button>
+      )}
+    </>
+  )
+
+  return (
+    <>
+      <div id="p-two-factor" className="page container w-max-6xl bg-gray-100">
+        <div className="flex flex-y justify-center full-height py-4">
+          <div className="text-center mb-8">
+            <a href="/">
+              <TenantLogo size={50} />
+            </a>
+          </div>
+
+          <div className="box shadow-sm text-center w-full">{recoveryCodes ? renderRecoveryCodes(recoveryCodes) : renderForm()}</div>
+        </div>
+      </div>
+    </>
+  )
+}
+
+export default TwoFactorPage
@@ -0,0 +1,16 @@
+import { http, Result } from "@fider/services/http"
+
+export interface RestoreReport {
+  dryRun: boolean
+  tenantId: number
+  tenantName: string
+  subdomain: string
+  schemaVersion: number
+  rows: { [table: string]: number }
+  blobs: number
+  warnings: string[]
+}
+
+export const validateBackup = async (fileName: string, content: string, passphrase: string): Promise<Result<RestoreReport>> => {
+  return http.post<RestoreReport>("/_api/admin/backup/restore", { fileName, content, passphrase, dryRun: true }).then(http.event("backup", "validate"))
+}
@@ -0,0 +1,22 @@
+import { http, Result } from "@fider/services"
+import { FileAttachment, FileUpload } from "@fider/models"
+
+export const listFiles = async (postNumber: number): Promise<Result<FileAttachment[]>> => {
+  return await http.get<FileAttachment[]>(`/api/v1/posts/${postNumber}/files`)
+}
+
+export const uploadFile = async (postNumber: number, file: FileUpload, commentId?: number): Promise<Result<FileAttachment>> => {
+  return await http.post<FileAttachment>(`/api/v1/posts/${postNumber}/files`, { file, commentId })
+}
+
+export const deleteFile = async (postNumber: number, fileId: number): Promise<Result> => {
+  return await http.delete(`/api/v1/posts/${postNumber}/files/${fileId}`)
+}
+
+export const releaseFile = async (fileId: number): Promise<Result> => {
+  return await http.post(`/api/v1/admin/files/${fileId}/release`)
+}
+
+export const fileDownloadURL = (file: FileAttachment): string => {
+  return `/files/${file.id}/${encodeURIComponent(file.name)}`
+}
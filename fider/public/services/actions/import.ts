@@ -0,0 +1,15 @@
+import { ImportJob, ImportSource } from "@fider/models"
+import { http, Result } from "@fider/services/http"
+
+export const startImport = async (
+  source: ImportSource,
+  fileName: string,
+  content: string,
+  mapping: { [field: string]: string }
+): Promise<Result<ImportJob>> => {
+  return http.post<ImportJob>("/_api/admin/imports", { source, fileName, content, mapping }).then(http.event("import", "start"))
+}
+
+export const getImport = async (id: number): Promise<Result<ImportJob>> => {
+  return http.get<ImportJob>(`/_api/admin/imports/${id}`)
+}
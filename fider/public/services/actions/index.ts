@@ -6,3 +6,9 @@ export * from "./notification"
 export * from "./invite"
 export * from "./infra"
 export * from "./webhook"
+export * from "./role"
+export * from "./backup"
+export * from "./import"
+export * from "./job"
+export * from "./file"
+export * from "./moderation"
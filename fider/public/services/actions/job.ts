@@ -0,0 +1,18 @@
+import { Job } from "@fider/models"
+import { http, Result } from "@fider/services/http"
+
+export const listJobs = async (): Promise<Result<Job[]>> => {
+  return http.get<Job[]>("/_api/admin/jobs")
+}
+
+export const runJob = async (name: string): Promise<Result> => {
+  return http.post(`/_api/admin/jobs/${encodeURIComponent(name)}/run`).then(http.event("job", "run"))
+}
+
+export const pauseJob = async (name: string): Promise<Result> => {
+  return http.put(`/_api/admin/jobs/${encodeURIComponent(name)}/pause`).then(http.event("job", "pause"))
+}
+
+export const resumeJob = async (name: string): Promise<Result> => {
+  return http.delete(`/_api/admin/jobs/${encodeURIComponent(name)}/pause`).then(http.event("job", "resume"))
+}
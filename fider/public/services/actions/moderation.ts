@@ -0,0 +1,14 @@
+import { http, Result } from "@fider/services/http"
+import { WordFilter, WordFilterAction } from "@fider/models"
+
+export const listWordFilters = async (): Promise<Result<WordFilter[]>> => {
+  return http.get<WordFilter[]>(`/api/v1/admin/moderation/word-filters`)
+}
+
+export const createWordFilter = async (pattern: string, isRegex: boolean, action: WordFilterAction): Promise<Result<WordFilter>> => {
+  return http.post<WordFilter>(`/api/v1/admin/moderation/word-filters`, { pattern, isRegex, action }).then(http.event("word-filter", "create"))
+}
+
+export const deleteWordFilter = async (id: number): Promise<Result> => {
+  return http.delete(`/api/v1/admin/moderation/word-filters/${id}`).then(http.event("word-filter", "delete"))
+}
@@ -1,5 +1,5 @@
 import { http, Result, querystring } from "@fider/services"
-import { Post, Vote, ImageUpload, UserNames } from "@fider/models"
+import { Post, Vote, ImageUpload, UserNames, ReportReason, Reaction } from "@fider/models"
 
 export const getAllPosts = async (): Promise<Result<Post[]>> => {
   return await http.get<Post[]>("/api/v1/posts")
@@ -79,12 +79,22 @@ export const listVotes = async (postNumber: number): Promise<Result<Vote[]>> =>
   return http.get<Vote[]>(`/api/v1/posts/${postNumber}/votes`)
 }
 
-export const getTaggableUsers = async (userFilter: string): Promise<Result<UserNames[]>> => {
-  return http.get<UserNames[]>(`/api/v1/taggable-users${querystring.stringify({ query: userFilter })}`)
+export const getTaggableUsers = async (userFilter: string, page = 1): Promise<Result<UserNames[]>> => {
+  return http.get<UserNames[]>(`/api/v1/taggable-users${querystring.stringify({ query: userFilter, page })}`)
 }
 
-export const createComment = async (postNumber: number, content: string, attachments: ImageUpload[]): Promise<Result> => {
-  return http.post(`/api/v1/posts/${postNumber}/comments`, { content, attachments }).then(http.event("comment", "create"))
+interface CreateCommentResponse {
+  id: number
+  isApproved: boolean
+}
+
+export const createComment = async (
+  postNumber: number,
+  content: string,
+  attachments: ImageUpload[],
+  parentId?: number
+): Promise<Result<CreateCommentResponse>> => {
+  return http.post(`/api/v1/posts/${postNumber}/comments`, { content, attachments, parentId }).then(http.event("comment", "create"))
 }
 
 export const updateComment = async (postNumber: number, commentID: number, content: string, attachments: ImageUpload[]): Promise<Result> => {
@@ -94,6 +104,21 @@ export const updateComment = async (postNumber: number, commentID: number, conte
 export const deleteComment = async (postNumber: number, commentID: number): Promise<Result> => {
   return http.delete(`/api/v1/posts/${postNumber}/comments/${commentID}`).then(http.event("comment", "delete"))
 }
+interface ReportContentResponse {
+  id: number
+  isHidden: boolean
+}
+
+export const reportPost = async (postNumber: number, reason: ReportReason, details: string): Promise<Result<ReportContentResponse>> => {
+  return http.post<ReportContentResponse>(`/api/v1/posts/${postNumber}/report`, { reason, details }).then(http.event("post", "report"))
+}
+
+export const reportComment = async (postNumber: number, commentID: number, reason: ReportReason, details: string): Promise<Result<ReportContentResponse>> => {
+  return http
+    .post<ReportContentResponse>(`/api/v1/posts/${postNumber}/comments/${commentID}/report`, { reason, details })
+    .then(http.event("comment", "report"))
+}
+
 interface ToggleReactionResponse {
   added: boolean
 }
@@ -102,6 +127,15 @@ export const toggleCommentReaction = async (postNumber: number, commentID: numbe
   return http.post<ToggleReactionResponse>(`/api/v1/posts/${postNumber}/comments/${commentID}/reactions/${emoji}`)
 }
 
+export const togglePostReaction = async (postNumber: number, emoji: string): Promise<Result<ToggleReactionResponse>> => {
+  return http.post<ToggleReactionResponse>(`/api/v1/posts/${postNumber}/reactions/${emoji}`)
+}
+
+export const listReactions = async (postNumber: number, commentID?: number): Promise<Result<Reaction[]>> => {
+  const path = commentID ? `/api/v1/posts/${postNumber}/comments/${commentID}/reactions` : `/api/v1/posts/${postNumber}/reactions`
+  return http.get<Reaction[]>(path)
+}
+
 interface SetResponseInput {
   status: string
   text: string
@@ -0,0 +1,18 @@
+import { http, Result } from "@fider/services/http"
+import { Permission, Role } from "@fider/models"
+
+export const createRole = async (name: string, description: string, permissions: Permission[]): Promise<Result<Role>> => {
+  return http.post<Role>(`/_api/admin/custom-roles`, { name, description, permissions }).then(http.event("role", "create"))
+}
+
+export const updateRole = async (id: number, name: string, description: string, permissions: Permission[]): Promise<Result<Role>> => {
+  return http.put<Role>(`/_api/admin/custom-roles/${id}`, { name, description, permissions }).then(http.event("role", "update"))
+}
+
+export const deleteRole = async (id: number): Promise<Result> => {
+  return http.delete(`/_api/admin/custom-roles/${id}`).then(http.event("role", "delete"))
+}
+
+export const assignCustomRole = async (userID: number, roleID: number): Promise<Result> => {
+  return http.put(`/_api/admin/users/${userID}/custom-role`, { roleID }).then(http.event("role", "assign"))
+}
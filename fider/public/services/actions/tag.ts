@@ -1,12 +1,12 @@
 import { http, Result } from "@fider/services/http"
-import { Tag } from "@fider/models"
+import { Tag, TagGroup, TagGroupSelection } from "@fider/models"
 
-export const createTag = async (name: string, color: string, isPublic: boolean): Promise<Result<Tag>> => {
-  return http.post<Tag>(`/api/v1/tags`, { name, color, isPublic }).then(http.event("tag", "create"))
+export const createTag = async (name: string, color: string, isPublic: boolean, groupId?: number): Promise<Result<Tag>> => {
+  return http.post<Tag>(`/api/v1/tags`, { name, color, isPublic, groupId }).then(http.event("tag", "create"))
 }
 
-export const updateTag = async (slug: string, name: string, color: string, isPublic: boolean): Promise<Result<Tag>> => {
-  return http.put<Tag>(`/api/v1/tags/${slug}`, { name, color, isPublic }).then(http.event("tag", "update"))
+export const updateTag = async (slug: string, name: string, color: string, isPublic: boolean, groupId?: number): Promise<Result<Tag>> => {
+  return http.put<Tag>(`/api/v1/tags/${slug}`, { name, color, isPublic, groupId }).then(http.event("tag", "update"))
 }
 
 export const deleteTag = async (slug: string): Promise<Result> => {
@@ -20,3 +20,22 @@ export const assignTag = async (slug: string, postNumber: number): Promise<Resul
 export const unassignTag = async (slug: string, postNumber: number): Promise<Result> => {
   return http.delete(`/api/v1/posts/${postNumber}/tags/${slug}`).then(http.event("tag", "unassign"))
 }
+
+export interface TagGroupInput {
+  name: string
+  selection: TagGroupSelection
+  visibleTo: string[]
+  assignableBy: string[]
+}
+
+export const createTagGroup = async (input: TagGroupInput): Promise<Result<TagGroup>> => {
+  return http.post<TagGroup>(`/api/v1/tag-groups`, input).then(http.event("tag-group", "create"))
+}
+
+export const updateTagGroup = async (slug: string, input: TagGroupInput): Promise<Result<TagGroup>> => {
+  return http.put<TagGroup>(`/api/v1/tag-groups/${slug}`, input).then(http.event("tag-group", "update"))
+}
+
+export const deleteTagGroup = async (slug: string): Promise<Result> => {
+  return http.delete(`/api/v1/tag-groups/${slug}`).then(http.event("tag-group", "delete"))
+}
@@ -37,8 +37,16 @@ export const updateTenantSettings = async (request: UpdateTenantSettingsRequest)
   return await http.post("/_api/admin/settings/general", request)
 }
 
-export const updateTenantAdvancedSettings = async (customCSS: string, allowedSchemes: string): Promise<Result> => {
-  return await http.post("/_api/admin/settings/advanced", { customCSS, allowedSchemes })
+export interface UpdateTenantAdvancedSettingsRequest {
+  customCSS: string
+  allowedSchemes: string
+  allowedFileTypes: string
+  fileQuotaMB: number
+  reactionEmojis: string
+}
+
+export const updateTenantAdvancedSettings = async (request: UpdateTenantAdvancedSettingsRequest): Promise<Result> => {
+  return await http.post("/_api/admin/settings/advanced", request)
 }
 
 export const updateTenantPrivacy = async (request: PrivacySettingsPageState): Promise<Result> => {
@@ -51,6 +59,12 @@ export const updateTenantEmailAuthAllowed = async (isEmailAuthAllowed: boolean):
   })
 }
 
+export const updateTenantTwoFactorRequired = async (isTwoFactorRequired: boolean): Promise<Result> => {
+  return await http.post("/_api/admin/settings/twofactor", {
+    isTwoFactorRequired,
+  })
+}
+
 export const checkAvailability = async (subdomain: string): Promise<Result<CheckAvailabilityResponse>> => {
   return await http.get<CheckAvailabilityResponse>(`/_api/tenants/${subdomain}/availability`)
 }
@@ -63,8 +77,8 @@ export const signInNewUser = async (email: string, name: string): Promise<Result
   return await http.post("/_api/signin/newuser", { email, name })
 }
 
-export const verifySignInCode = async (email: string, code: string): Promise<Result> => {
-  return await http.post("/_api/signin/verify", { email, code })
+export const verifySignInCode = async (email: string, code: string): Promise<Result<{ twoFactorRequired?: boolean }>> => {
+  return await http.post<{ twoFactorRequired?: boolean }>("/_api/signin/verify", { email, code })
 }
 
 export const resendSignInCode = async (email: string): Promise<Result> => {
@@ -25,3 +25,15 @@ export const deleteCurrentAccount = async (): Promise<Result> => {
 export const regenerateAPIKey = async (): Promise<Result<{ apiKey: string }>> => {
   return await http.post<{ apiKey: string }>("/_api/user/regenerate-apikey")
 }
+
+export const verifyTwoFactor = async (code: string, recoveryCode: string): Promise<Result<{ recoveryCodes?: string[] }>> => {
+  return await http.post<{ recoveryCodes?: string[] }>("/_api/signin/2fa", { code, recoveryCode })
+}
+
+export const regenerateRecoveryCodes = async (): Promise<Result<{ recoveryCodes: string[] }>> => {
+  return await http.post<{ recoveryCodes: string[] }>("/_api/user/2fa/recovery-codes")
+}
+
+export const disableTwoFactor = async (): Promise<Result> => {
+  return await http.delete("/_api/user/2fa")
+}
@@ -1,5 +1,5 @@
 import { createContext } from "react"
-import { CurrentUser, SystemSettings, Tenant, TenantStatus } from "@fider/models"
+import { CurrentUser, Permission, SystemSettings, TagGroup, Tenant, TenantStatus } from "@fider/models"
 
 export class FiderSession {
   private pPage: string
@@ -41,6 +41,30 @@ export class FiderSession {
     return !!this.pUser
   }
 
+  public hasPermission(permission: Permission): boolean {
+    return !!this.pUser && (this.pUser.permissions || []).includes(permission)
+  }
+
+  public canAssignTagGroup(group: TagGroup): boolean {
+    if (!this.pUser) {
+      return false
+    }
+    if (this.hasPermission(Permission.ManageTags)) {
+      return true
+    }
+
+    const keys: string[] = [this.pUser.role]
+    if (this.pUser.customRole) {
+      keys.push(`role:${this.pUser.customRole.id}`)
+    }
+    const matches = (rules: string[]) => rules.some((key) => keys.includes(key))
+
+    if (group.visibleTo.length > 0 && !matches(group.visibleTo)) {
+      return false
+    }
+    return group.assignableBy.length === 0 ? this.hasPermission(Permission.AssignTags) : matches(group.assignableBy)
+  }
+
   public get isModerationRequiredForNewPost(): boolean {
     return (
       this.pTenant.hasCommercialFeatures && this.pTenant.isModerationEnabled && this.isAuthenticated && this.pUser!.role === "visitor" && !this.pUser!.isTrusted
@@ -48,7 +72,7 @@ export class FiderSession {
   }
 
   public get showModerationControls(): boolean {
-    return this.pTenant.hasCommercialFeatures && this.pTenant.isModerationEnabled && this.isAuthenticated && (this.pUser?.isCollaborator ?? false)
+    return this.pTenant.hasCommercialFeatures && this.pTenant.isModerationEnabled && this.hasPermission(Permission.ModerateContent)
   }
 }
 
@@ -25,12 +25,20 @@ async function toResult<T>(response: Response): Promise<Result<T>> {
     }
   }
 
+  // sensitive operations require the second factor to be verified again
+  if (response.status === 403 && body.requiresTwoFactor) {
+    location.href = `/signin/2fa?redirect=${encodeURIComponent(location.pathname + location.search)}`
+    return { ok: false, data: body as T }
+  }
+
   if (response.status === 500) {
     notify.error("An unexpected error occurred while processing your request.")
   } else if (response.status === 401) {
     notify.error("You need to be authenticated to perform this operation.")
   } else if (response.status === 403) {
     notify.error("You are not authorized to perform this operation.")
+  } else if (response.status === 429) {
+    notify.error("You are making too many requests. Please wait a moment and try again.")
   }
 
   return {
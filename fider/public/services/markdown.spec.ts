@@ -19,6 +19,11 @@ const testCases = [
       '<p>My Fider Picture <img data-bkey="attachments/zy0hBtqrjQki7M56p26AuAXljRoaNUSwZO6MOky5gnYm2nW1rsMmrp3dwhjGk7ok-aden.jpeg" class="fider-inline-image" alt="" src="/static/images/attachments/zy0hBtqrjQki7M56p26AuAXljRoaNUSwZO6MOky5gnYm2nW1rsMmrp3dwhjGk7ok-aden.jpeg"></p>',
     expectedPlainText: "My Fider Picture",
   },
+  {
+    input: "Thanks @[Jon Snow]{12} and @[Arya Stark]!",
+    expectedFull: '<p>Thanks <span class="mention">@Jon Snow</span> and <span class="mention">@Arya Stark</span>!</p>',
+    expectedPlainText: "Thanks @Jon Snow and @Arya Stark!",
+  },
   {
     input: "# Hello World",
     expectedFull: "<h1>Hello World</h1>",
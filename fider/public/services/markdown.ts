@@ -49,10 +49,13 @@ fullRenderer.image = (href, title, alt) => {
   }
   return originalImage(href, title, alt)
 }
+// Mentions are in the format @[name]{id}, or @[name] when written before ids were added
+const mentionRegex = /@\[(.*?)\](?:\{\d+\})?/g
+
 fullRenderer.link = link
 fullRenderer.text = (text: string) => {
-  // Handling mention links (they're in the format @[name])
-  return text.replace(/@\[(.*?)\]/g, (match, name) => {
+  // Handling mention links
+  return text.replace(mentionRegex, (match, name) => {
     return `<span class="mention">@${name}</span>`
   })
 }
@@ -70,6 +73,7 @@ plainTextRenderer.code = (code) => code
 plainTextRenderer.codespan = (code) => code
 plainTextRenderer.html = (html) => html
 plainTextRenderer.del = (text) => text
+plainTextRenderer.text = (text) => text.replace(mentionRegex, "@$1")
 
 const entities: { [key: string]: string } = {
   // "<": "&lt;",
@@ -1,4 +1,4 @@
-import { classSet, formatDate, timeSince, fileToBase64, sortTags } from "./utils"
+import { classSet, formatDate, timeSince, fileToBase64, sortTags, applyTagGroupSelection } from "./utils"
 import { readFileSync } from "fs"
 
 // replaces non-breaking spaces with normal spaces
@@ -124,3 +124,17 @@ test("Can sort tags by not isPublic and then name", () => {
     { name: "e", id: 5, color: "Yellow", slug: "e", isPublic: true },
   ])
 })
+
+test("Keeps only the latest tag of single selection groups", () => {
+  const groups = [
+    { id: 1, name: "Quarter", slug: "quarter", selection: "single" as const, visibleTo: [], assignableBy: [] },
+    { id: 2, name: "Area", slug: "area", selection: "multiple" as const, visibleTo: [], assignableBy: [] },
+  ]
+  const q1 = { name: "Q1", id: 1, color: "Blue", slug: "q1", isPublic: true, groupId: 1 }
+  const q2 = { name: "Q2", id: 2, color: "Red", slug: "q2", isPublic: true, groupId: 1 }
+  const api = { name: "API", id: 3, color: "White", slug: "api", isPublic: true, groupId: 2 }
+  const ui = { name: "UI", id: 4, color: "Green", slug: "ui", isPublic: true, groupId: 2 }
+
+  expect(applyTagGroupSelection([q1, api], [q1, api, q2], groups)).toStrictEqual([api, q2])
+  expect(applyTagGroupSelection([q1, api], [q1, api, ui], groups)).toStrictEqual([q1, api, ui])
+})
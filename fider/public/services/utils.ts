@@ -1,4 +1,4 @@
-import { Tag } from "@fider/models"
+import { Tag, TagGroup } from "@fider/models"
 import { Fider } from "."
 
 export const delay = (ms: number) => {
@@ -111,6 +111,16 @@ export const uploadedImageURL = (bkey: string | undefined, size?: number): strin
   return undefined
 }
 
+export const formatFileSize = (bytes: number): string => {
+  if (bytes < 1024) {
+    return `${bytes} B`
+  }
+  if (bytes < 1024 * 1024) {
+    return `${(bytes / 1024).toFixed(1)} KB`
+  }
+  return `${(bytes / 1024 / 1024).toFixed(1)} MB`
+}
+
 export const truncate = (input: string, maxLength: number): string => {
   if (input && input.length > maxLength) {
     return `${input.substr(0, maxLength)}...`
@@ -164,6 +174,18 @@ export const sortTags = (tags: Tag[]) => {
   })
 }
 
+// Keeps only the most recently added tag of each single selection group
+export const applyTagGroupSelection = (previous: Tag[], next: Tag[], groups: TagGroup[]): Tag[] => {
+  const isSingle = (groupId?: number) => groups.some((g) => g.id === groupId && g.selection === "single")
+  const kept = next.filter((t) => previous.includes(t))
+  const added = next.filter((t) => !previous.includes(t))
+
+  return [...kept, ...added].reduce<Tag[]>((result, tag) => {
+    const others = isSingle(tag.groupId) ? result.filter((t) => t.groupId !== tag.groupId) : result
+    return [...others, tag]
+  }, [])
+}
+
 export const chopString = (input: string, length: number): string => {
   if (!input || input.length <= length) {
     return input